@@ -0,0 +1,49 @@
+package arn
+
+import "errors"
+
+// APIKeyToUser stores the user ID by the hash of the API key.
+type APIKeyToUser struct {
+	Hash   string `json:"hash" primary:"true"`
+	UserID UserID `json:"userId"`
+}
+
+// GetID returns the primary key which is the hash of the API key.
+func (mapping *APIKeyToUser) GetID() string {
+	return mapping.Hash
+}
+
+// GetUserByAPIKey returns the user the API key belongs to.
+func GetUserByAPIKey(key string) (*User, error) {
+	obj, err := DB.Get("APIKeyToUser", HashSecret(key))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return GetUser(obj.(*APIKeyToUser).UserID)
+}
+
+// GetUserAPIKey returns the user the API key belongs to and the stored key with its scopes.
+func GetUserAPIKey(key string) (*User, *UserAPIKey, error) {
+	hash := HashSecret(key)
+	obj, err := DB.Get("APIKeyToUser", hash)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	user, err := GetUser(obj.(*APIKeyToUser).UserID)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	apiKey := user.APIKeys().findByHash(hash)
+
+	if apiKey == nil {
+		return nil, nil, errors.New("API key not found")
+	}
+
+	return user, apiKey, nil
+}
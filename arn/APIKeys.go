@@ -57,6 +57,11 @@ type APIKeysData struct {
 		Secret string `json:"secret"`
 	} `json:"anilist"`
 
+	MyAnimeList struct {
+		ID     string `json:"id"`
+		Secret string `json:"secret"`
+	} `json:"myanimelist"`
+
 	Osu struct {
 		Secret string `json:"secret"`
 	} `json:"osu"`
@@ -81,6 +86,10 @@ type APIKeysData struct {
 		ID     string `json:"id"`
 		Secret string `json:"secret"`
 	} `json:"s3"`
+
+	ExchangeRates struct {
+		URL string `json:"url"`
+	} `json:"exchangeRates"`
 }
 
 func init() {
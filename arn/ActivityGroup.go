@@ -0,0 +1,135 @@
+package arn
+
+// ActivityGroup is a series of consecutive activities by the same user that the feed shows as a single entry.
+type ActivityGroup struct {
+	// Activities are sorted latest first.
+	Activities []Activity
+}
+
+// Latest returns the most recent activity of the group.
+func (group *ActivityGroup) Latest() Activity {
+	return group.Activities[0]
+}
+
+// Oldest returns the first activity of the group.
+func (group *ActivityGroup) Oldest() Activity {
+	return group.Activities[len(group.Activities)-1]
+}
+
+// Count returns the number of activities in the group.
+func (group *ActivityGroup) Count() int {
+	return len(group.Activities)
+}
+
+// Creator returns the user who caused the activities.
+func (group *ActivityGroup) Creator() *User {
+	return group.Latest().Creator()
+}
+
+// TypeName returns the type name of the grouped activities.
+func (group *ActivityGroup) TypeName() string {
+	return group.Latest().TypeName()
+}
+
+// GetCreated returns the date of the most recent activity.
+func (group *ActivityGroup) GetCreated() string {
+	return group.Latest().GetCreated()
+}
+
+// Anime returns the anime of grouped anime consumption activities.
+func (group *ActivityGroup) Anime() *Anime {
+	consume, ok := group.Latest().(*ActivityConsumeAnime)
+
+	if !ok {
+		return nil
+	}
+
+	return consume.Anime()
+}
+
+// FromEpisode returns the lowest episode of grouped anime consumption activities.
+func (group *ActivityGroup) FromEpisode() int {
+	from := 0
+
+	for _, activity := range group.Activities {
+		consume, ok := activity.(*ActivityConsumeAnime)
+
+		if ok && (from == 0 || consume.FromEpisode < from) {
+			from = consume.FromEpisode
+		}
+	}
+
+	return from
+}
+
+// ToEpisode returns the highest episode of grouped anime consumption activities.
+func (group *ActivityGroup) ToEpisode() int {
+	to := 0
+
+	for _, activity := range group.Activities {
+		consume, ok := activity.(*ActivityConsumeAnime)
+
+		if ok && consume.ToEpisode > to {
+			to = consume.ToEpisode
+		}
+	}
+
+	return to
+}
+
+// GroupActivities combines consecutive activities by the same user and of the same kind.
+// The activities need to be sorted latest first. It returns at most maxGroups groups
+// and the number of activities that have been consumed to create them.
+func GroupActivities(activities []Activity, maxGroups int) ([]*ActivityGroup, int) {
+	groups := []*ActivityGroup{}
+	lastKey := ""
+	consumed := 0
+
+	for _, activity := range activities {
+		key := activityGroupKey(activity)
+
+		if len(groups) > 0 && key == lastKey {
+			group := groups[len(groups)-1]
+			group.Activities = append(group.Activities, activity)
+			consumed++
+			continue
+		}
+
+		if len(groups) == maxGroups {
+			break
+		}
+
+		groups = append(groups, &ActivityGroup{
+			Activities: []Activity{activity},
+		})
+
+		lastKey = key
+		consumed++
+	}
+
+	return groups, consumed
+}
+
+// activityGroupKey returns the same key for activities that can be shown as a single feed entry.
+func activityGroupKey(activity Activity) string {
+	switch activity := activity.(type) {
+	case *ActivityConsumeAnime:
+		return activity.CreatedBy + ":watch:" + activity.AnimeID
+
+	case *ActivityCreate:
+		// Multiple replies in the same thread are grouped
+		if activity.ObjectType == "Post" {
+			post, err := GetPost(activity.ObjectID)
+
+			if err == nil {
+				return activity.CreatedBy + ":reply:" + post.ParentID
+			}
+		}
+
+		// Everything else is shown separately
+		return activity.ID
+
+	default:
+		return activity.GetID()
+	}
+}
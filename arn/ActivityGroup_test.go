@@ -0,0 +1,45 @@
+package arn_test
+
+import (
+	"testing"
+
+	"github.com/akyoto/assert"
+	"github.com/animenotifier/notify.moe/arn"
+)
+
+func TestActivityGrouping(t *testing.T) {
+	watch := func(userID arn.UserID, animeID arn.AnimeID, episode int) arn.Activity {
+		activity := &arn.ActivityConsumeAnime{
+			AnimeID:     animeID,
+			FromEpisode: episode,
+			ToEpisode:   episode,
+		}
+
+		activity.ID = animeID + "-" + string(rune('a'+episode))
+		activity.CreatedBy = userID
+		return activity
+	}
+
+	// Latest first
+	activities := []arn.Activity{
+		watch("binger", "anime", 12),
+		watch("binger", "anime", 8),
+		watch("binger", "anime", 5),
+		watch("other", "anime", 2),
+		watch("binger", "anime", 4),
+		watch("binger", "sequel", 1),
+	}
+
+	groups, consumed := arn.GroupActivities(activities, 10)
+	assert.Equal(t, len(groups), 4)
+	assert.Equal(t, consumed, len(activities))
+	assert.Equal(t, groups[0].Count(), 3)
+	assert.Equal(t, groups[0].FromEpisode(), 5)
+	assert.Equal(t, groups[0].ToEpisode(), 12)
+	assert.Equal(t, groups[0].Oldest(), activities[2])
+
+	// Groups are never cut in half by the limit
+	groups, consumed = arn.GroupActivities(activities, 1)
+	assert.Equal(t, len(groups), 1)
+	assert.Equal(t, consumed, 3)
+}
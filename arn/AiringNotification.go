@@ -0,0 +1,284 @@
+package arn
+
+import (
+	"sort"
+	"strconv"
+	"time"
+)
+
+// Notification lead times for new episodes
+const (
+	// NotificationLeadTimeRelease notifies when the episode data shows the episode as released.
+	NotificationLeadTimeRelease = "release"
+
+	// NotificationLeadTimeBefore notifies shortly before the episode starts airing.
+	NotificationLeadTimeBefore = "before"
+
+	// NotificationLeadTimeAiring notifies when the episode starts airing.
+	NotificationLeadTimeAiring = "airing"
+
+	// NotificationLeadTimeSubtitles notifies when subtitles are usually available.
+	NotificationLeadTimeSubtitles = "subtitles"
+)
+
+const (
+	// notificationTimeBeforeAiring is how early "before" notifications are sent.
+	notificationTimeBeforeAiring = 30 * time.Minute
+
+	// typicalSubtitleDelay is the usual time between the start of airing and the first subtitled release.
+	typicalSubtitleDelay = 3 * time.Hour
+
+	// maxAiringNotificationDelay is how late a scheduled notification can be sent before it is dropped.
+	maxAiringNotificationDelay = time.Hour
+)
+
+func init() {
+	leadTimes := []*Option{
+		{NotificationLeadTimeRelease, "When the episode is released"},
+		{NotificationLeadTimeBefore, "30 minutes before airing"},
+		{NotificationLeadTimeAiring, "When it starts airing"},
+		{NotificationLeadTimeSubtitles, "When subs typically drop"},
+	}
+
+	DataLists["notification-lead-times"] = leadTimes
+	DataLists["anime-notification-lead-times"] = append([]*Option{{"", "Same as notification settings"}}, leadTimes...)
+}
+
+// AiringNotification is a notification about an episode that is sent with a lead time.
+type AiringNotification struct {
+	Item     *AnimeListItem
+	Anime    *Anime
+	Episode  *Episode
+	LeadTime string
+}
+
+// AiringCountdown is the next episode of an anime in the user's watching list.
+type AiringCountdown struct {
+	AnimeID          AnimeID `json:"animeId"`
+	Title            string  `json:"title"`
+	Episode          int     `json:"episode"`
+	AiringStart      string  `json:"airingStart"`
+	AiringEnd        string  `json:"airingEnd"`
+	SecondsRemaining int64   `json:"secondsRemaining"`
+	LeadTime         string  `json:"leadTime"`
+	NotificationTime string  `json:"notificationTime"`
+}
+
+// IsNotificationLeadTime tells you whether the value is one of the notification lead times.
+func IsNotificationLeadTime(leadTime string) bool {
+	for _, option := range DataLists["notification-lead-times"] {
+		if option.Value == leadTime {
+			return true
+		}
+	}
+
+	return false
+}
+
+// NotificationLeadTimeOffset returns the time relative to the start of airing when notifications are sent.
+// The second return value is false for release notifications because they don't depend on the airing time.
+func NotificationLeadTimeOffset(leadTime string) (time.Duration, bool) {
+	switch leadTime {
+	case NotificationLeadTimeBefore:
+		return -notificationTimeBeforeAiring, true
+	case NotificationLeadTimeAiring:
+		return 0, true
+	case NotificationLeadTimeSubtitles:
+		return typicalSubtitleDelay, true
+	default:
+		return 0, false
+	}
+}
+
+// EpisodeLeadTime returns the lead time for new episode notifications.
+func (settings *NotificationSettings) EpisodeLeadTime() string {
+	if !IsNotificationLeadTime(settings.EpisodeNotificationLeadTime) {
+		return NotificationLeadTimeRelease
+	}
+
+	return settings.EpisodeNotificationLeadTime
+}
+
+// LeadTime returns the notification lead time of the anime,
+// falling back to the user's notification settings.
+func (item *AnimeListItem) LeadTime(settings *NotificationSettings) string {
+	if IsNotificationLeadTime(item.NotificationLeadTime) {
+		return item.NotificationLeadTime
+	}
+
+	return settings.EpisodeLeadTime()
+}
+
+// NotificationTime returns when the notification for the episode is sent with the given lead time.
+func (episode *Episode) NotificationTime(leadTime string) (time.Time, bool) {
+	offset, scheduled := NotificationLeadTimeOffset(leadTime)
+
+	if !scheduled {
+		return time.Time{}, false
+	}
+
+	start, err := time.Parse(time.RFC3339, episode.AiringDate.Start)
+
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return start.Add(offset), true
+}
+
+// DueAiringNotifications returns the episode notifications with a lead time that need to be sent now.
+// Only the newest due episode of each anime is returned and episodes that were already notified are skipped.
+func (list *AnimeList) DueAiringNotifications(settings *NotificationSettings, now time.Time) []*AiringNotification {
+	var notifications []*AiringNotification
+
+	list.Lock()
+	items := make([]*AnimeListItem, len(list.Items))
+	copy(items, list.Items)
+	list.Unlock()
+
+	for _, item := range items {
+		if item.Status != AnimeListStatusWatching && item.Status != AnimeListStatusPlanned {
+			continue
+		}
+
+		leadTime := item.LeadTime(settings)
+
+		if leadTime == NotificationLeadTimeRelease {
+			continue
+		}
+
+		anime := item.Anime()
+
+		if anime == nil || anime.Status == "finished" {
+			continue
+		}
+
+		var due *Episode
+
+		for _, episode := range anime.Episodes() {
+			if episode.AiringDate.Start <= item.NotifiedAiring {
+				continue
+			}
+
+			notificationTime, ok := episode.NotificationTime(leadTime)
+
+			if !ok || notificationTime.After(now) || now.Sub(notificationTime) > maxAiringNotificationDelay {
+				continue
+			}
+
+			if due == nil || episode.AiringDate.Start > due.AiringDate.Start {
+				due = episode
+			}
+		}
+
+		if due == nil {
+			continue
+		}
+
+		notifications = append(notifications, &AiringNotification{
+			Item:     item,
+			Anime:    anime,
+			Episode:  due,
+			LeadTime: leadTime,
+		})
+	}
+
+	return notifications
+}
+
+// Message returns the notification text for the lead time.
+func (notification *AiringNotification) Message() string {
+	episode := "Episode " + strconv.Itoa(notification.Episode.Number)
+
+	switch notification.LeadTime {
+	case NotificationLeadTimeBefore:
+		return episode + " starts airing in 30 minutes!"
+	case NotificationLeadTimeAiring:
+		return episode + " is airing now!"
+	default:
+		return episode + " should be available with subtitles now!"
+	}
+}
+
+// SendAiringNotifications sends the due episode notifications with a lead time to the user
+// and returns how many were sent.
+func (user *User) SendAiringNotifications(now time.Time) int {
+	settings := &user.Settings().Notification
+
+	if !settings.Preferences.AllowsAny(NotificationTypeAnimeEpisode) {
+		return 0
+	}
+
+	animeList := user.AnimeList()
+	notifications := animeList.DueAiringNotifications(settings, now)
+
+	if len(notifications) == 0 {
+		return 0
+	}
+
+	for _, notification := range notifications {
+		anime := notification.Anime
+
+		user.SendNotification(&PushNotification{
+			Title:   anime.Title.ByUser(user),
+			Message: notification.Message(),
+			Icon:    anime.ImageLink("medium"),
+			Link:    "https://" + Domain + anime.Link(),
+			Type:    NotificationTypeAnimeEpisode,
+		})
+
+		notification.Item.NotifiedAiring = notification.Episode.AiringDate.Start
+	}
+
+	animeList.Save()
+	return len(notifications)
+}
+
+// AiringCountdowns returns the next episode of every anime in the watching list, airing soonest first.
+func (list *AnimeList) AiringCountdowns(user *User, now time.Time) []*AiringCountdown {
+	settings := &user.Settings().Notification
+	countdowns := []*AiringCountdown{}
+
+	for _, item := range list.Watching().Items {
+		anime := item.Anime()
+
+		if anime == nil {
+			continue
+		}
+
+		upcoming := anime.UpcomingEpisode()
+
+		if upcoming == nil {
+			continue
+		}
+
+		episode := upcoming.Episode
+		start, _ := time.Parse(time.RFC3339, episode.AiringDate.Start)
+
+		countdown := &AiringCountdown{
+			AnimeID:          anime.ID,
+			Title:            anime.Title.ByUser(user),
+			Episode:          episode.Number,
+			AiringStart:      episode.AiringDate.Start,
+			AiringEnd:        episode.AiringDate.End,
+			SecondsRemaining: int64(start.Sub(now).Seconds()),
+			LeadTime:         item.LeadTime(settings),
+		}
+
+		notificationTime, scheduled := episode.NotificationTime(countdown.LeadTime)
+
+		if scheduled {
+			countdown.NotificationTime = notificationTime.UTC().Format(time.RFC3339)
+		} else {
+			countdown.NotificationTime = episode.AiringDate.End
+		}
+
+		countdowns = append(countdowns, countdown)
+	}
+
+	sort.Slice(countdowns, func(i, j int) bool {
+		return countdowns[i].AiringStart < countdowns[j].AiringStart
+	})
+
+	return countdowns
+}
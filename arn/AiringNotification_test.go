@@ -0,0 +1,48 @@
+package arn_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/akyoto/assert"
+	"github.com/animenotifier/notify.moe/arn"
+)
+
+func TestEpisodeNotificationTime(t *testing.T) {
+	episode := &arn.Episode{
+		AiringDate: arn.AiringDate{
+			Start: "2019-04-10T15:00:00Z",
+			End:   "2019-04-10T15:30:00Z",
+		},
+	}
+
+	start, _ := time.Parse(time.RFC3339, episode.AiringDate.Start)
+
+	before, scheduled := episode.NotificationTime(arn.NotificationLeadTimeBefore)
+	assert.True(t, scheduled)
+	assert.Equal(t, before, start.Add(-30*time.Minute))
+
+	airing, scheduled := episode.NotificationTime(arn.NotificationLeadTimeAiring)
+	assert.True(t, scheduled)
+	assert.Equal(t, airing, start)
+
+	subtitles, scheduled := episode.NotificationTime(arn.NotificationLeadTimeSubtitles)
+	assert.True(t, scheduled)
+	assert.True(t, subtitles.After(start))
+
+	_, scheduled = episode.NotificationTime(arn.NotificationLeadTimeRelease)
+	assert.False(t, scheduled)
+}
+
+func TestAnimeListItemLeadTime(t *testing.T) {
+	settings := &arn.NotificationSettings{EpisodeNotificationLeadTime: arn.NotificationLeadTimeAiring}
+	item := &arn.AnimeListItem{}
+	assert.Equal(t, item.LeadTime(settings), arn.NotificationLeadTimeAiring)
+
+	item.NotificationLeadTime = arn.NotificationLeadTimeBefore
+	assert.Equal(t, item.LeadTime(settings), arn.NotificationLeadTimeBefore)
+
+	settings.EpisodeNotificationLeadTime = ""
+	item.NotificationLeadTime = ""
+	assert.Equal(t, item.LeadTime(settings), arn.NotificationLeadTimeRelease)
+}
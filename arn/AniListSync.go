@@ -0,0 +1,195 @@
+package arn
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/aerogo/http/client"
+	"github.com/animenotifier/anilist"
+)
+
+// aniListMutation saves an anime list entry on AniList.
+const aniListMutation = `mutation ($mediaId: Int, $status: MediaListStatus, $progress: Int, $scoreRaw: Int, $repeat: Int) {
+	SaveMediaListEntry(mediaId: $mediaId, status: $status, progress: $progress, scoreRaw: $scoreRaw, repeat: $repeat) {
+		id
+	}
+}`
+
+// aniListDeletion deletes an anime list entry on AniList.
+const aniListDeletion = `mutation ($id: Int) {
+	DeleteMediaListEntry(id: $id) {
+		deleted
+	}
+}`
+
+// IsAniListLinked tells whether the user authorized notify.moe to edit the AniList anime list.
+func (user *User) IsAniListLinked() bool {
+	return user.Accounts.AniList.ID != 0 && user.Accounts.AniList.AccessToken != ""
+}
+
+// AniListSyncChanges compares the anime list with the AniList anime list
+// and returns the entries that need to be synchronized.
+func (user *User) AniListSyncChanges() ([]*ListSyncChange, error) {
+	if !user.IsAniListLinked() {
+		return nil, errors.New("AniList account is not linked")
+	}
+
+	return listSyncChanges(user.AnimeList(), &aniListSync{user: user})
+}
+
+// SyncAniList applies the changes to both anime lists.
+func (user *User) SyncAniList(changes []*ListSyncChange) error {
+	return applyListSync(user.AnimeList(), &aniListSync{user: user}, changes)
+}
+
+// aniListSync keeps the anime list of a user in sync with AniList.
+type aniListSync struct {
+	user *User
+}
+
+// entries loads the AniList anime list of the user.
+func (service *aniListSync) entries() ([]*ListSyncEntry, error) {
+	remoteList, err := anilist.GetAnimeList(service.user.Accounts.AniList.ID)
+
+	if err != nil {
+		return nil, fmt.Errorf("Couldn't load your anime list from AniList: %s", err.Error())
+	}
+
+	finder := NewAniListAnimeFinder()
+	entries := []*ListSyncEntry{}
+
+	for _, list := range remoteList.Lists {
+		for _, remote := range list.Entries {
+			anime := finder.GetAnime(strconv.Itoa(remote.Anime.ID), strconv.Itoa(remote.Anime.MALID))
+
+			if anime == nil {
+				continue
+			}
+
+			entries = append(entries, &ListSyncEntry{
+				ID:           remote.ID,
+				Anime:        anime,
+				Status:       AniListAnimeListStatus(remote),
+				Episodes:     remote.Progress,
+				Score:        remote.ScoreRaw,
+				RewatchCount: remote.Repeat,
+				Updated:      time.Unix(int64(remote.UpdatedAt), 0),
+			})
+		}
+	}
+
+	return entries, nil
+}
+
+// push saves the anime list item on AniList.
+func (service *aniListSync) push(item *AnimeListItem) error {
+	return service.user.PushAniListEntry(item)
+}
+
+// remove deletes the entry on AniList.
+func (service *aniListSync) remove(entry *ListSyncEntry) error {
+	return service.user.DeleteAniListEntry(entry.ID)
+}
+
+// mapping returns the name of the AniList anime mapping.
+func (service *aniListSync) mapping() string {
+	return "anilist/anime"
+}
+
+// score converts the rating to the raw AniList score from 0 to 100.
+func (service *aniListSync) score(rating float64) int {
+	return int(math.Round(rating * 10))
+}
+
+// rating converts the raw AniList score to a rating.
+func (service *aniListSync) rating(score int) float64 {
+	return float64(score) / 10.0
+}
+
+// synced returns the anime that were on both lists after the last AniList synchronization.
+func (service *aniListSync) synced(animeList *AnimeList) *[]AnimeID {
+	return &animeList.AniListSynced
+}
+
+// PushAniListEntry saves the anime list item in the AniList anime list of the user.
+func (user *User) PushAniListEntry(item *AnimeListItem) error {
+	if !user.IsAniListLinked() {
+		return errors.New("AniList account is not linked")
+	}
+
+	anime := item.Anime()
+
+	if anime == nil {
+		return errors.New("Anime not found")
+	}
+
+	mediaID, err := strconv.Atoi(anime.GetMapping("anilist/anime"))
+
+	if err != nil {
+		return fmt.Errorf("Anime %s has no AniList mapping", anime.ID)
+	}
+
+	return user.aniListRequest(aniListMutation, map[string]interface{}{
+		"mediaId":  mediaID,
+		"status":   AniListStatus(item.Status),
+		"progress": item.Episodes,
+		"scoreRaw": int(math.Round(item.Rating.Overall * 10)),
+		"repeat":   item.RewatchCount,
+	})
+}
+
+// DeleteAniListEntry deletes the entry with the given AniList ID from the AniList anime list of the user.
+func (user *User) DeleteAniListEntry(entryID int) error {
+	if !user.IsAniListLinked() {
+		return errors.New("AniList account is not linked")
+	}
+
+	return user.aniListRequest(aniListDeletion, map[string]interface{}{
+		"id": entryID,
+	})
+}
+
+// aniListRequest sends an authorized GraphQL request to AniList.
+func (user *User) aniListRequest(query string, variables map[string]interface{}) error {
+	body := map[string]interface{}{
+		"query":     query,
+		"variables": variables,
+	}
+
+	response, err := client.Post("https://graphql.anilist.co").
+		Header("Authorization", "Bearer "+user.Accounts.AniList.AccessToken).
+		Header("Content-Type", "application/json").
+		Header("Accept", "application/json").
+		BodyJSON(body).
+		End()
+
+	if err != nil {
+		return err
+	}
+
+	if response.StatusCode() != http.StatusOK {
+		return fmt.Errorf("AniList responded with status %d: %s", response.StatusCode(), response.String())
+	}
+
+	return nil
+}
+
+// AniListStatus converts an anime list status to the AniList status.
+func AniListStatus(status string) string {
+	switch status {
+	case AnimeListStatusWatching:
+		return "CURRENT"
+	case AnimeListStatusCompleted:
+		return "COMPLETED"
+	case AnimeListStatusHold:
+		return "PAUSED"
+	case AnimeListStatusDropped:
+		return "DROPPED"
+	default:
+		return "PLANNING"
+	}
+}
@@ -54,6 +54,9 @@ type Anime struct {
 
 	// Links to external websites
 	Links []*Link `json:"links" editable:"true"`
+
+	// Legal streaming services, tagged with the regions where they are available
+	Streaming []*StreamingLink `json:"streaming" editable:"true"`
 }
 
 // NewAnime creates a new anime.
@@ -69,6 +72,7 @@ func (anime *Anime) init() *Anime {
 	anime.Status = "upcoming"
 	anime.Trailers = []*ExternalMedia{}
 	anime.Mappings = []*Mapping{}
+	anime.Streaming = []*StreamingLink{}
 	anime.Created = DateTimeUTC()
 	return anime
 }
@@ -299,6 +303,35 @@ func (anime *Anime) Link() string {
 	return "/anime/" + anime.ID
 }
 
+// StreamingLinksFor returns the streaming links that are available in the user's country.
+// If the country is unknown, all links are returned.
+func (anime *Anime) StreamingLinksFor(user *User) []*StreamingLink {
+	if user == nil || user.Location == nil || user.Location.CountryCode == "" {
+		return anime.Streaming
+	}
+
+	links := make([]*StreamingLink, 0, len(anime.Streaming))
+
+	for _, link := range anime.Streaming {
+		if link.IsAvailableIn(user.Location.CountryCode) {
+			links = append(links, link)
+		}
+	}
+
+	return links
+}
+
+// HasStreamingLink tells you whether the anime already has a streaming link with the given URL.
+func (anime *Anime) HasStreamingLink(url string) bool {
+	for _, link := range anime.Streaming {
+		if link.URL == url {
+			return true
+		}
+	}
+
+	return false
+}
+
 // StartDateTime returns the start date as a time object.
 func (anime *Anime) StartDateTime() time.Time {
 	format := validate.DateFormat
@@ -394,17 +427,26 @@ func (anime *Anime) RefreshEpisodes() error {
 		// Notify all users who are watching the anime.
 		go func() {
 			for _, user := range anime.UsersWatchingOrPlanned() {
-				if !user.Settings().Notification.AnimeEpisodeReleases {
+				settings := &user.Settings().Notification
+
+				if !settings.Preferences.AllowsAny(NotificationTypeAnimeEpisode) {
 					continue
 				}
 
-				user.SendNotification(&PushNotification{
-					Title:   anime.Title.ByUser(user),
-					Message: "Episode " + strconv.Itoa(newAvailableCount) + " has been released!",
-					Icon:    anime.ImageLink("medium"),
-					Link:    "https://notify.moe" + anime.Link(),
-					Type:    NotificationTypeAnimeEpisode,
-				})
+				// Users with a lead time are notified by the airing notifications job
+				item := user.AnimeList().Find(anime.ID)
+
+				if item == nil || item.LeadTime(settings) == NotificationLeadTimeRelease {
+					user.SendNotification(&PushNotification{
+						Title:   anime.Title.ByUser(user),
+						Message: "Episode " + strconv.Itoa(newAvailableCount) + " has been released!",
+						Icon:    anime.ImageLink("medium"),
+						Link:    "https://notify.moe" + anime.Link(),
+						Type:    NotificationTypeAnimeEpisode,
+					})
+				}
+
+				go user.SendWebhook(NewEpisodeWebhookPayload(anime, newAvailableCount, user))
 			}
 		}()
 	}
@@ -82,38 +82,50 @@ func (anime *Anime) Create(ctx aero.Context) error {
 // Edit creates an edit log entry.
 func (anime *Anime) Edit(ctx aero.Context, key string, value reflect.Value, newValue reflect.Value) (consumed bool, err error) {
 	user := GetUserFromContext(ctx)
+	logEntry := NewFieldEditLogEntry(user.ID, "Anime", anime.ID, key, value, newValue)
 
-	if key == "Status" {
-		oldStatus := value.String()
-		newStatus := newValue.String()
-
-		// Notify people who want to know about finished series
-		if oldStatus == "current" && newStatus == "finished" {
-			go func() {
-				for _, user := range anime.UsersWatchingOrPlanned() {
-					if !user.Settings().Notification.AnimeFinished {
-						continue
-					}
-
-					user.SendNotification(&PushNotification{
-						Title:   anime.Title.ByUser(user),
-						Message: anime.Title.ByUser(user) + " has finished airing!",
-						Icon:    anime.ImageLink("medium"),
-						Link:    "https://notify.moe" + anime.Link(),
-						Type:    NotificationTypeAnimeFinished,
-					})
-				}
-			}()
-		}
+	// Changes by low-trust editors need to be reviewed first
+	if queuePendingEdit(anime, user, logEntry) {
+		return true, nil
 	}
 
+	anime.afterFieldEdit(key, value, newValue)
+
 	// Write log entry
-	logEntry := NewEditLogEntry(user.ID, "edit", "Anime", anime.ID, key, fmt.Sprint(value.Interface()), fmt.Sprint(newValue.Interface()))
 	logEntry.Save()
-
 	return false, nil
 }
 
+// afterFieldEdit runs the side effects of a field edit.
+// It is also called when a reviewed edit of a low-trust editor is applied.
+func (anime *Anime) afterFieldEdit(key string, value reflect.Value, newValue reflect.Value) {
+	if key != "Status" {
+		return
+	}
+
+	oldStatus := value.String()
+	newStatus := newValue.String()
+
+	// Notify people who want to know about finished series
+	if oldStatus == "current" && newStatus == "finished" {
+		go func() {
+			for _, user := range anime.UsersWatchingOrPlanned() {
+				if !user.Settings().Notification.AnimeFinished {
+					continue
+				}
+
+				user.SendNotification(&PushNotification{
+					Title:   anime.Title.ByUser(user),
+					Message: anime.Title.ByUser(user) + " has finished airing!",
+					Icon:    anime.ImageLink("medium"),
+					Link:    "https://notify.moe" + anime.Link(),
+					Type:    NotificationTypeAnimeFinished,
+				})
+			}
+		}()
+	}
+}
+
 // OnAppend saves a log entry.
 func (anime *Anime) OnAppend(ctx aero.Context, key string, index int, obj interface{}) {
 	onAppend(anime, ctx, key, index, obj)
@@ -132,7 +144,7 @@ func (anime *Anime) Authorize(ctx aero.Context, action string) error {
 		return errors.New("Not logged in or not authorized to edit this anime")
 	}
 
-	return nil
+	return authorizeArrayEdit(ctx, anime, user)
 }
 
 // DeleteInContext deletes the anime in the given context.
@@ -172,8 +184,9 @@ func (anime *Anime) Delete() error {
 	// Delete anime list items
 	for animeList := range StreamAnimeLists() {
 		removed := animeList.Remove(anime.ID)
+		trashed := animeList.DeleteFromTrash(anime.ID)
 
-		if removed {
+		if removed || trashed {
 			animeList.Save()
 		}
 	}
@@ -242,16 +255,22 @@ func (anime *Anime) Delete() error {
 		os.Remove(path.Join(Root, "images/anime/large/", anime.ID+"@2.jpg"))
 		os.Remove(path.Join(Root, "images/anime/large/", anime.ID+".webp"))
 		os.Remove(path.Join(Root, "images/anime/large/", anime.ID+"@2.webp"))
+		os.Remove(path.Join(Root, "images/anime/large/", anime.ID+".avif"))
+		os.Remove(path.Join(Root, "images/anime/large/", anime.ID+"@2.avif"))
 
 		os.Remove(path.Join(Root, "images/anime/medium/", anime.ID+".jpg"))
 		os.Remove(path.Join(Root, "images/anime/medium/", anime.ID+"@2.jpg"))
 		os.Remove(path.Join(Root, "images/anime/medium/", anime.ID+".webp"))
 		os.Remove(path.Join(Root, "images/anime/medium/", anime.ID+"@2.webp"))
+		os.Remove(path.Join(Root, "images/anime/medium/", anime.ID+".avif"))
+		os.Remove(path.Join(Root, "images/anime/medium/", anime.ID+"@2.avif"))
 
 		os.Remove(path.Join(Root, "images/anime/small/", anime.ID+".jpg"))
 		os.Remove(path.Join(Root, "images/anime/small/", anime.ID+"@2.jpg"))
 		os.Remove(path.Join(Root, "images/anime/small/", anime.ID+".webp"))
 		os.Remove(path.Join(Root, "images/anime/small/", anime.ID+"@2.webp"))
+		os.Remove(path.Join(Root, "images/anime/small/", anime.ID+".avif"))
+		os.Remove(path.Join(Root, "images/anime/small/", anime.ID+"@2.avif"))
 	}
 
 	// Delete the actual anime
@@ -0,0 +1,347 @@
+package arn
+
+import "sort"
+
+// maxFranchiseSize limits the number of anime in a franchise
+// so that long-running series don't slow down the page.
+const maxFranchiseSize = 150
+
+// AnimeFranchiseEdge is a relation between two anime of a franchise.
+// The relation type describes what "To" is for "From", e.g. its sequel.
+type AnimeFranchiseEdge struct {
+	From *Anime
+	To   *Anime
+	Type string
+}
+
+// HumanReadableType returns the relation type as shown to the user.
+func (edge *AnimeFranchiseEdge) HumanReadableType() string {
+	return HumanReadableAnimeRelation(edge.Type)
+}
+
+// AnimeWatchOrderEntry is an anime in the suggested watch order of a franchise.
+type AnimeWatchOrderEntry struct {
+	Anime *Anime
+	Note  string
+	Level int
+}
+
+// IsOptional tells you whether the anime is not part of the main story,
+// e.g. a side story, a summary or an alternative version.
+func (entry *AnimeWatchOrderEntry) IsOptional() bool {
+	return entry.Note != ""
+}
+
+// AnimeFranchise is the graph of all anime that are connected by relations.
+type AnimeFranchise struct {
+	Root  *Anime
+	Anime []*Anime
+	Edges []*AnimeFranchiseEdge
+}
+
+// Franchise returns the graph of all anime that can be reached from this anime via relations.
+func (anime *Anime) Franchise() *AnimeFranchise {
+	franchise := &AnimeFranchise{Root: anime}
+	seen := map[AnimeID]bool{anime.ID: true}
+	included := map[AnimeID]bool{}
+	queue := []*Anime{anime}
+	var edges []*AnimeFranchiseEdge
+
+	for len(queue) > 0 && len(franchise.Anime) < maxFranchiseSize {
+		current := queue[0]
+		queue = queue[1:]
+		franchise.Anime = append(franchise.Anime, current)
+		included[current.ID] = true
+		relations := current.Relations()
+
+		if relations == nil {
+			continue
+		}
+
+		relations.Lock()
+		items := append([]*AnimeRelation(nil), relations.Items...)
+		relations.Unlock()
+
+		for _, relation := range items {
+			if relation.Type == "other" {
+				continue
+			}
+
+			related := relation.Anime()
+
+			if related == nil {
+				continue
+			}
+
+			edges = append(edges, &AnimeFranchiseEdge{
+				From: current,
+				To:   related,
+				Type: relation.Type,
+			})
+
+			if !seen[related.ID] {
+				seen[related.ID] = true
+				queue = append(queue, related)
+			}
+		}
+	}
+
+	// Ignore relations to anime that didn't fit into the franchise
+	for _, edge := range edges {
+		if included[edge.To.ID] {
+			franchise.Edges = append(franchise.Edges, edge)
+		}
+	}
+
+	return franchise
+}
+
+// EdgesFrom returns the relations of the given anime within the franchise.
+func (franchise *AnimeFranchise) EdgesFrom(animeID AnimeID) []*AnimeFranchiseEdge {
+	var edges []*AnimeFranchiseEdge
+
+	for _, edge := range franchise.Edges {
+		if edge.From.ID == animeID {
+			edges = append(edges, edge)
+		}
+	}
+
+	return edges
+}
+
+// WatchOrder suggests an order to watch the franchise in.
+// Sequels come after their prequels and side stories after their parent story,
+// everything else is ordered by the start date.
+// Anime that are not part of the main story are marked with a note.
+func (franchise *AnimeFranchise) WatchOrder() []*AnimeWatchOrderEntry {
+	notes, mainStory := franchise.classify()
+	next := map[AnimeID][]AnimeID{}
+	inDegree := map[AnimeID]int{}
+
+	watchBefore := func(first *Anime, second *Anime) {
+		if first.ID == second.ID || Contains(next[first.ID], second.ID) {
+			return
+		}
+
+		next[first.ID] = append(next[first.ID], second.ID)
+		inDegree[second.ID]++
+	}
+
+	for _, edge := range franchise.Edges {
+		switch edge.Type {
+		case "sequel", "side story", "spinoff", "summary":
+			watchBefore(edge.From, edge.To)
+
+		case "prequel", "parent story", "full story":
+			watchBefore(edge.To, edge.From)
+
+		case "alternative version", "alternative setting":
+			if mainStory[edge.From.ID] && !mainStory[edge.To.ID] {
+				watchBefore(edge.From, edge.To)
+			} else if mainStory[edge.To.ID] && !mainStory[edge.From.ID] {
+				watchBefore(edge.To, edge.From)
+			}
+		}
+	}
+
+	remaining := append([]*Anime(nil), franchise.Anime...)
+	levels := map[AnimeID]int{}
+	order := make([]*AnimeWatchOrderEntry, 0, len(remaining))
+
+	for len(remaining) > 0 {
+		best := -1
+
+		for index, anime := range remaining {
+			if inDegree[anime.ID] > 0 {
+				continue
+			}
+
+			if best == -1 || watchEarlier(anime, remaining[best]) {
+				best = index
+			}
+		}
+
+		// Contradicting relations form a cycle, break it with the earliest anime
+		if best == -1 {
+			best = 0
+
+			for index, anime := range remaining {
+				if watchEarlier(anime, remaining[best]) {
+					best = index
+				}
+			}
+		}
+
+		anime := remaining[best]
+		remaining = append(remaining[:best], remaining[best+1:]...)
+
+		for _, animeID := range next[anime.ID] {
+			inDegree[animeID]--
+
+			if levels[animeID] < levels[anime.ID]+1 {
+				levels[animeID] = levels[anime.ID] + 1
+			}
+		}
+
+		order = append(order, &AnimeWatchOrderEntry{
+			Anime: anime,
+			Note:  notes[anime.ID],
+			Level: levels[anime.ID],
+		})
+	}
+
+	return order
+}
+
+// Graph returns the watch order grouped into columns.
+// Each anime is placed in a column after all anime that should be watched before it.
+func (franchise *AnimeFranchise) Graph() [][]*AnimeWatchOrderEntry {
+	var columns [][]*AnimeWatchOrderEntry
+
+	for _, entry := range franchise.WatchOrder() {
+		for len(columns) <= entry.Level {
+			columns = append(columns, nil)
+		}
+
+		columns[entry.Level] = append(columns[entry.Level], entry)
+	}
+
+	return columns
+}
+
+// classify returns the notes for anime that aren't part of the main story
+// and the set of anime that form the main story.
+func (franchise *AnimeFranchise) classify() (map[AnimeID]string, map[AnimeID]bool) {
+	notes := map[AnimeID]string{}
+
+	note := func(anime *Anime, text string) {
+		if notes[anime.ID] == "" {
+			notes[anime.ID] = text
+		}
+	}
+
+	for _, edge := range franchise.Edges {
+		switch edge.Type {
+		case "side story", "spinoff", "summary":
+			note(edge.To, edge.HumanReadableType())
+		case "parent story":
+			note(edge.From, "Side story")
+		case "full story":
+			note(edge.From, "Summary")
+		}
+	}
+
+	// The main story is the longest chain of sequels without notes,
+	// the chain with the earliest anime wins if they're equally long.
+	byDate := append([]*Anime(nil), franchise.Anime...)
+
+	sort.Slice(byDate, func(i, j int) bool {
+		return watchEarlier(byDate[i], byDate[j])
+	})
+
+	mainStory := map[AnimeID]bool{}
+
+	for _, anime := range byDate {
+		if notes[anime.ID] != "" || mainStory[anime.ID] {
+			continue
+		}
+
+		chain := franchise.sequelChain(anime, func(related *Anime) bool {
+			return notes[related.ID] == ""
+		})
+
+		if len(chain) > len(mainStory) {
+			mainStory = chain
+		}
+	}
+
+	// Alternative versions of the main story are optional
+	for _, edge := range franchise.Edges {
+		if edge.Type != "alternative version" && edge.Type != "alternative setting" {
+			continue
+		}
+
+		if mainStory[edge.From.ID] && !mainStory[edge.To.ID] {
+			note(edge.To, "Alternative")
+		} else if mainStory[edge.To.ID] && !mainStory[edge.From.ID] {
+			note(edge.From, "Alternative")
+		}
+	}
+
+	// Sequels of optional anime are optional as well
+	for _, anime := range franchise.Anime {
+		text := notes[anime.ID]
+
+		if text == "" || mainStory[anime.ID] {
+			continue
+		}
+
+		chain := franchise.sequelChain(anime, func(related *Anime) bool {
+			return !mainStory[related.ID]
+		})
+
+		for animeID := range chain {
+			if notes[animeID] == "" {
+				notes[animeID] = text
+			}
+		}
+	}
+
+	return notes, mainStory
+}
+
+// sequelChain returns the anime that are connected to the start via sequels and prequels
+// and pass the filter.
+func (franchise *AnimeFranchise) sequelChain(start *Anime, filter func(*Anime) bool) map[AnimeID]bool {
+	chain := map[AnimeID]bool{start.ID: true}
+	queue := []*Anime{start}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		for _, edge := range franchise.Edges {
+			if edge.Type != "sequel" && edge.Type != "prequel" {
+				continue
+			}
+
+			var related *Anime
+
+			switch current.ID {
+			case edge.From.ID:
+				related = edge.To
+			case edge.To.ID:
+				related = edge.From
+			default:
+				continue
+			}
+
+			if chain[related.ID] || !filter(related) {
+				continue
+			}
+
+			chain[related.ID] = true
+			queue = append(queue, related)
+		}
+	}
+
+	return chain
+}
+
+// watchEarlier tells you whether a should be watched before b when there are no relations between them.
+// Anime without a start date are watched last.
+func watchEarlier(a *Anime, b *Anime) bool {
+	if (a.StartDate == "") != (b.StartDate == "") {
+		return a.StartDate != ""
+	}
+
+	if a.StartDate != b.StartDate {
+		return a.StartDate < b.StartDate
+	}
+
+	if a.Title.Canonical != b.Title.Canonical {
+		return a.Title.Canonical < b.Title.Canonical
+	}
+
+	return a.ID < b.ID
+}
@@ -0,0 +1,92 @@
+package arn_test
+
+import (
+	"testing"
+
+	"github.com/akyoto/assert"
+	"github.com/animenotifier/notify.moe/arn"
+)
+
+func newFranchiseAnime(id string, startDate string) *arn.Anime {
+	anime := &arn.Anime{StartDate: startDate}
+	anime.ID = id
+	anime.Title.Canonical = id
+	return anime
+}
+
+func TestAnimeFranchiseWatchOrder(t *testing.T) {
+	season1 := newFranchiseAnime("season-1", "2010-04-01")
+	season2 := newFranchiseAnime("season-2", "2012-04-01")
+	ova := newFranchiseAnime("ova", "2011-01-01")
+	ovaSequel := newFranchiseAnime("ova-sequel", "2011-06-01")
+	recap := newFranchiseAnime("recap", "2012-01-01")
+	remake := newFranchiseAnime("remake", "2009-01-01")
+
+	// The prequel airs later than its sequel
+	prequelMovie := newFranchiseAnime("prequel-movie", "2013-01-01")
+
+	franchise := &arn.AnimeFranchise{
+		Root:  season2,
+		Anime: []*arn.Anime{season2, season1, ova, ovaSequel, recap, remake, prequelMovie},
+		Edges: []*arn.AnimeFranchiseEdge{
+			{From: season1, To: season2, Type: "sequel"},
+			{From: season2, To: season1, Type: "prequel"},
+			{From: season1, To: prequelMovie, Type: "prequel"},
+			{From: season1, To: ova, Type: "side story"},
+			{From: ova, To: ovaSequel, Type: "sequel"},
+			{From: recap, To: season1, Type: "full story"},
+			{From: season1, To: remake, Type: "alternative version"},
+			{From: remake, To: season1, Type: "alternative version"},
+		},
+	}
+
+	order := franchise.WatchOrder()
+	ids := make([]string, len(order))
+	notes := map[string]string{}
+
+	for index, entry := range order {
+		ids[index] = entry.Anime.ID
+		notes[entry.Anime.ID] = entry.Note
+	}
+
+	assert.DeepEqual(t, ids, []string{"prequel-movie", "season-1", "remake", "ova", "ova-sequel", "recap", "season-2"})
+	assert.Equal(t, notes["season-1"], "")
+	assert.Equal(t, notes["season-2"], "")
+	assert.Equal(t, notes["prequel-movie"], "")
+	assert.Equal(t, notes["ova"], "Side story")
+	assert.Equal(t, notes["ova-sequel"], "Side story")
+	assert.Equal(t, notes["recap"], "Summary")
+	assert.Equal(t, notes["remake"], "Alternative")
+
+	// Graph columns follow the watch order constraints
+	columns := franchise.Graph()
+	assert.Equal(t, len(columns), 4)
+	assert.Equal(t, columns[0][0].Anime.ID, "prequel-movie")
+	assert.Equal(t, columns[1][0].Anime.ID, "season-1")
+	assert.Equal(t, len(columns[2]), 4)
+}
+
+func TestAnimeFranchiseCycle(t *testing.T) {
+	a := newFranchiseAnime("a", "2010-01-01")
+	b := newFranchiseAnime("b", "2011-01-01")
+
+	franchise := &arn.AnimeFranchise{
+		Root:  a,
+		Anime: []*arn.Anime{b, a},
+		Edges: []*arn.AnimeFranchiseEdge{
+			{From: a, To: b, Type: "sequel"},
+			{From: a, To: b, Type: "prequel"},
+		},
+	}
+
+	order := franchise.WatchOrder()
+	assert.Equal(t, len(order), 2)
+	assert.Equal(t, order[0].Anime.ID, "a")
+}
+
+func TestReverseAnimeRelation(t *testing.T) {
+	assert.Equal(t, arn.ReverseAnimeRelation("sequel"), "prequel")
+	assert.Equal(t, arn.ReverseAnimeRelation("side story"), "parent story")
+	assert.Equal(t, arn.ReverseAnimeRelation("full story"), "summary")
+	assert.Equal(t, arn.ReverseAnimeRelation("alternative version"), "alternative version")
+}
@@ -1,12 +1,11 @@
 package arn
 
 import (
-	"bytes"
-	"image"
 	"path"
 	"time"
 
 	"github.com/akyoto/imageserver"
+	"github.com/animenotifier/notify.moe/arn/imageupload"
 )
 
 const (
@@ -31,6 +30,9 @@ const (
 	// AnimeImageWebPQuality is the WebP quality of anime images.
 	AnimeImageWebPQuality = 70
 
+	// AnimeImageAVIFQuality is the AVIF quality of anime images.
+	AnimeImageAVIFQuality = 55
+
 	// AnimeImageJPEGQuality is the JPEG quality of anime images.
 	AnimeImageJPEGQuality = 70
 
@@ -89,6 +91,14 @@ var animeImageOutputs = []imageserver.Output{
 		Quality:   AnimeImageWebPQuality + AnimeImageQualityBonusLowDPI + AnimeImageQualityBonusLarge,
 	},
 
+	// AVIF - Large
+	&imageupload.AVIFFile{
+		Directory: path.Join(Root, "images/anime/large/"),
+		Width:     AnimeImageLargeWidth,
+		Height:    AnimeImageLargeHeight,
+		Quality:   AnimeImageAVIFQuality + AnimeImageQualityBonusLowDPI + AnimeImageQualityBonusLarge,
+	},
+
 	// WebP - Medium
 	&imageserver.WebPFile{
 		Directory: path.Join(Root, "images/anime/medium/"),
@@ -97,6 +107,14 @@ var animeImageOutputs = []imageserver.Output{
 		Quality:   AnimeImageWebPQuality + AnimeImageQualityBonusLowDPI + AnimeImageQualityBonusMedium,
 	},
 
+	// AVIF - Medium
+	&imageupload.AVIFFile{
+		Directory: path.Join(Root, "images/anime/medium/"),
+		Width:     AnimeImageMediumWidth,
+		Height:    AnimeImageMediumHeight,
+		Quality:   AnimeImageAVIFQuality + AnimeImageQualityBonusLowDPI + AnimeImageQualityBonusMedium,
+	},
+
 	// WebP - Small
 	&imageserver.WebPFile{
 		Directory: path.Join(Root, "images/anime/small/"),
@@ -104,6 +122,14 @@ var animeImageOutputs = []imageserver.Output{
 		Height:    AnimeImageSmallHeight,
 		Quality:   AnimeImageWebPQuality + AnimeImageQualityBonusLowDPI + AnimeImageQualityBonusSmall,
 	},
+
+	// AVIF - Small
+	&imageupload.AVIFFile{
+		Directory: path.Join(Root, "images/anime/small/"),
+		Width:     AnimeImageSmallWidth,
+		Height:    AnimeImageSmallHeight,
+		Quality:   AnimeImageAVIFQuality + AnimeImageQualityBonusLowDPI + AnimeImageQualityBonusSmall,
+	},
 }
 
 // Define the high DPI anime image outputs
@@ -140,6 +166,14 @@ var animeImageOutputsHighDPI = []imageserver.Output{
 		Quality:   AnimeImageWebPQuality + AnimeImageQualityBonusLarge,
 	},
 
+	// AVIF - Large
+	&imageupload.AVIFFile{
+		Directory: path.Join(Root, "images/anime/large/"),
+		Width:     AnimeImageLargeWidth * 2,
+		Height:    AnimeImageLargeHeight * 2,
+		Quality:   AnimeImageAVIFQuality + AnimeImageQualityBonusLarge,
+	},
+
 	// WebP - Medium
 	&imageserver.WebPFile{
 		Directory: path.Join(Root, "images/anime/medium/"),
@@ -148,6 +182,14 @@ var animeImageOutputsHighDPI = []imageserver.Output{
 		Quality:   AnimeImageWebPQuality + AnimeImageQualityBonusMedium,
 	},
 
+	// AVIF - Medium
+	&imageupload.AVIFFile{
+		Directory: path.Join(Root, "images/anime/medium/"),
+		Width:     AnimeImageMediumWidth * 2,
+		Height:    AnimeImageMediumHeight * 2,
+		Quality:   AnimeImageAVIFQuality + AnimeImageQualityBonusMedium,
+	},
+
 	// WebP - Small
 	&imageserver.WebPFile{
 		Directory: path.Join(Root, "images/anime/small/"),
@@ -155,50 +197,54 @@ var animeImageOutputsHighDPI = []imageserver.Output{
 		Height:    AnimeImageSmallHeight * 2,
 		Quality:   AnimeImageWebPQuality + AnimeImageQualityBonusSmall,
 	},
+
+	// AVIF - Small
+	&imageupload.AVIFFile{
+		Directory: path.Join(Root, "images/anime/small/"),
+		Width:     AnimeImageSmallWidth * 2,
+		Height:    AnimeImageSmallHeight * 2,
+		Quality:   AnimeImageAVIFQuality + AnimeImageQualityBonusSmall,
+	},
 }
 
 // SetImageBytes accepts a byte buffer that represents an image file and updates the anime image.
 func (anime *Anime) SetImageBytes(data []byte) error {
-	// Decode
-	img, format, err := image.Decode(bytes.NewReader(data))
+	metaImage, err := imageupload.Decode(data)
 
 	if err != nil {
 		return err
 	}
 
-	return anime.SetImage(&imageserver.MetaImage{
-		Image:  img,
-		Format: format,
-		Data:   data,
-	})
+	return anime.SetImage(metaImage)
 }
 
 // SetImage sets the anime image to the given MetaImage.
 func (anime *Anime) SetImage(metaImage *imageserver.MetaImage) error {
-	var lastError error
-
 	// Save the different image formats and sizes in low DPI
-	for _, output := range animeImageOutputs {
-		err := output.Save(metaImage, anime.ID)
-
-		if err != nil {
-			lastError = err
-		}
-	}
+	lowDPIAVIF, lowDPIError := imageupload.Save(metaImage, anime.ID, animeImageOutputs)
 
 	// Save the different image formats and sizes in high DPI
-	for _, output := range animeImageOutputsHighDPI {
-		err := output.Save(metaImage, anime.ID+"@2")
-
-		if err != nil {
-			lastError = err
-		}
-	}
+	highDPIAVIF, highDPIError := imageupload.Save(metaImage, anime.ID+"@2", animeImageOutputsHighDPI)
 
 	anime.Image.Extension = metaImage.Extension()
 	anime.Image.Width = metaImage.Image.Bounds().Dx()
 	anime.Image.Height = metaImage.Image.Bounds().Dy()
 	anime.Image.AverageColor = GetAverageColor(metaImage.Image)
 	anime.Image.LastModified = time.Now().Unix()
-	return lastError
+	anime.Image.AVIF = lowDPIAVIF && highDPIAVIF
+
+	if highDPIError != nil {
+		return highDPIError
+	}
+
+	return lowDPIError
+}
+
+// ImageVariants returns the available sizes of the anime image.
+func (anime *Anime) ImageVariants() []*ImageVariant {
+	return []*ImageVariant{
+		{Link: anime.ImageLink("small"), Width: AnimeImageSmallWidth, Height: AnimeImageSmallHeight},
+		{Link: anime.ImageLink("medium"), Width: AnimeImageMediumWidth, Height: AnimeImageMediumHeight},
+		{Link: anime.ImageLink("large"), Width: AnimeImageLargeWidth, Height: AnimeImageLargeHeight},
+	}
 }
@@ -11,8 +11,16 @@ import (
 
 // AnimeList is a list of anime list items.
 type AnimeList struct {
-	UserID UserID           `json:"userId" primary:"true"`
-	Items  []*AnimeListItem `json:"items"`
+	UserID UserID                `json:"userId" primary:"true"`
+	Items  []*AnimeListItem      `json:"items"`
+	Trash  []*AnimeListTrashItem `json:"trash"`
+
+	// AniListSynced contains the anime that were on both lists after the last AniList synchronization.
+	// Entries missing on one side since then have been removed and must not be added again.
+	AniListSynced []AnimeID `json:"aniListSynced"`
+
+	// MyAnimeListSynced contains the anime that were on both lists after the last MyAnimeList synchronization.
+	MyAnimeListSynced []AnimeID `json:"myAnimeListSynced"`
 
 	sync.Mutex
 }
@@ -91,7 +99,12 @@ func (list *AnimeList) HasItemsWithStatus(status string) bool {
 func (list *AnimeList) Find(animeID AnimeID) *AnimeListItem {
 	list.Lock()
 	defer list.Unlock()
+	return list.find(animeID)
+}
 
+// find returns the list item with the specified anime ID, if available.
+// The caller needs to hold the lock.
+func (list *AnimeList) find(animeID AnimeID) *AnimeListItem {
 	for _, item := range list.Items {
 		if item.AnimeID == animeID {
 			return item
@@ -108,6 +121,7 @@ func (list *AnimeList) Import(item *AnimeListItem) {
 
 	// If it doesn't exist yet: Simply add it.
 	if existing == nil {
+		item.updateCompleted(DateTimeUTC())
 		list.Lock()
 		list.Items = append(list.Items, item)
 		list.Unlock()
@@ -148,6 +162,63 @@ func (list *AnimeList) Import(item *AnimeListItem) {
 
 	// Edited
 	existing.Edited = DateTimeUTC()
+	existing.updateCompleted(existing.Edited)
+}
+
+// Merge adds an imported item to the anime list and resolves
+// conflicts with an existing entry using the given strategy.
+// It returns whether the anime list has been changed.
+func (list *AnimeList) Merge(item *AnimeListItem, strategy string) bool {
+	list.Lock()
+	defer list.Unlock()
+
+	existing := list.find(item.AnimeID)
+
+	if existing == nil {
+		item.updateCompleted(DateTimeUTC())
+		list.Items = append(list.Items, item)
+		return true
+	}
+
+	switch strategy {
+	case ListImportTakeTheirs:
+		existing.Status = item.Status
+		existing.Episodes = item.Episodes
+		existing.Rating.Overall = item.Rating.Overall
+		existing.RewatchCount = item.RewatchCount
+
+		if item.Notes != "" {
+			existing.Notes = item.Notes
+		}
+
+	case ListImportTakeHigherProgress:
+		if !item.isAheadOf(existing) {
+			return false
+		}
+
+		existing.Status = item.Status
+		existing.Episodes = item.Episodes
+
+		if item.RewatchCount > existing.RewatchCount {
+			existing.RewatchCount = item.RewatchCount
+		}
+
+		if existing.Rating.Overall == 0 {
+			existing.Rating.Overall = item.Rating.Overall
+		}
+
+		if existing.Notes == "" {
+			existing.Notes = item.Notes
+		}
+
+	default:
+		return false
+	}
+
+	existing.Rating.Clamp()
+	existing.Edited = DateTimeUTC()
+	existing.updateCompleted(existing.Edited)
+	return true
 }
 
 // User returns the user this anime list belongs to.
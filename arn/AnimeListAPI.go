@@ -1,6 +1,8 @@
 package arn
 
 import (
+	"errors"
+
 	"github.com/aerogo/aero"
 	"github.com/aerogo/api"
 )
@@ -8,6 +10,7 @@ import (
 // Force interface implementations
 var (
 	_ api.Editable = (*AnimeList)(nil)
+	_ api.Filter   = (*AnimeList)(nil)
 	_ IDCollection = (*AnimeList)(nil)
 )
 
@@ -17,8 +20,93 @@ func init() {
 		// Add follow
 		AddAction(),
 
-		// Remove follow
-		RemoveAction(),
+		// Move to trash
+		{
+			Name:  "remove",
+			Route: "/remove/:item-id",
+			Run: func(obj interface{}, ctx aero.Context) error {
+				list := obj.(*AnimeList)
+
+				if !list.MoveToTrash(ctx.Get("item-id")) {
+					return errors.New("This item does not exist in the list")
+				}
+
+				list.Save()
+				return nil
+			},
+		},
+
+		// Restore from trash
+		{
+			Name:  "restore",
+			Route: "/trash/restore/:item-id",
+			Run: func(obj interface{}, ctx aero.Context) error {
+				list := obj.(*AnimeList)
+				err := list.RestoreFromTrash(ctx.Get("item-id"))
+
+				if err != nil {
+					return err
+				}
+
+				list.Save()
+				return nil
+			},
+		},
+
+		// Delete permanently
+		{
+			Name:  "purge",
+			Route: "/trash/delete/:item-id",
+			Run: func(obj interface{}, ctx aero.Context) error {
+				list := obj.(*AnimeList)
+
+				if !list.DeleteFromTrash(ctx.Get("item-id")) {
+					return errors.New("This item does not exist in the trash")
+				}
+
+				list.Save()
+				return nil
+			},
+		},
+
+		// Edit many entries at once
+		{
+			Name:  "bulk",
+			Route: "/bulk",
+			Run: func(obj interface{}, ctx aero.Context) error {
+				list := obj.(*AnimeList)
+				data, err := ctx.Request().Body().JSONObject()
+
+				if err != nil {
+					return err
+				}
+
+				action, _ := data["action"].(string)
+				value, _ := data["value"].(string)
+				ids, _ := data["animeIds"].([]interface{})
+				animeIDs := make([]AnimeID, 0, len(ids))
+
+				for _, id := range ids {
+					animeID, ok := id.(string)
+
+					if ok {
+						animeIDs = append(animeIDs, animeID)
+					}
+				}
+
+				changed, err := list.BulkEdit(animeIDs, action, value)
+
+				if err != nil {
+					return err
+				}
+
+				if changed > 0 {
+					list.Save()
+				}
+
+				return nil
+			},
+		},
 	})
 }
 
@@ -27,6 +115,19 @@ func (list *AnimeList) Authorize(ctx aero.Context, action string) error {
 	return AuthorizeIfLoggedInAndOwnData(ctx, "id")
 }
 
+// ShouldFilter tells whether data needs to be filtered in the given context.
+func (list *AnimeList) ShouldFilter(ctx aero.Context) bool {
+	user := GetUserFromContext(ctx)
+	return user == nil || user.ID != list.UserID
+}
+
+// Filter hides the removed entries from other users.
+func (list *AnimeList) Filter() {
+	list.Trash = nil
+	list.AniListSynced = nil
+	list.MyAnimeListSynced = nil
+}
+
 // Save saves the anime list in the database.
 func (list *AnimeList) Save() {
 	DB.Set("AnimeList", list.UserID, list)
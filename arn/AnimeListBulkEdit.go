@@ -0,0 +1,149 @@
+package arn
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Anime list bulk edit actions
+const (
+	AnimeListBulkStatus     = "status"
+	AnimeListBulkRewatching = "rewatching"
+	AnimeListBulkTag        = "tag"
+	AnimeListBulkPrivate    = "private"
+	AnimeListBulkRemove     = "remove"
+)
+
+const (
+	// maxAnimeListTagLength is the maximum number of characters in an anime list tag.
+	maxAnimeListTagLength = 30
+
+	// maxAnimeListItemTags is the maximum number of tags on a single anime list item.
+	maxAnimeListItemTags = 20
+)
+
+// BulkEdit applies the action to all entries with the given anime IDs
+// and returns the number of entries that were changed.
+func (list *AnimeList) BulkEdit(animeIDs []AnimeID, action string, value string) (int, error) {
+	if len(animeIDs) == 0 {
+		return 0, errors.New("No anime selected")
+	}
+
+	if action == AnimeListBulkRemove {
+		removed := 0
+
+		for _, animeID := range animeIDs {
+			if list.MoveToTrash(animeID) {
+				removed++
+			}
+		}
+
+		return removed, nil
+	}
+
+	edit, err := animeListBulkEditFunc(action, value)
+
+	if err != nil {
+		return 0, err
+	}
+
+	selected := make(map[AnimeID]bool, len(animeIDs))
+
+	for _, animeID := range animeIDs {
+		selected[animeID] = true
+	}
+
+	list.Lock()
+	defer list.Unlock()
+
+	changed := 0
+	now := DateTimeUTC()
+
+	for _, item := range list.Items {
+		if !selected[item.AnimeID] || !edit(item) {
+			continue
+		}
+
+		item.Edited = now
+		item.updateCompleted(now)
+		changed++
+	}
+
+	return changed, nil
+}
+
+// animeListBulkEditFunc returns a function that applies the action to a single entry
+// and reports whether the entry has been changed.
+func animeListBulkEditFunc(action string, value string) (func(*AnimeListItem) bool, error) {
+	switch action {
+	case AnimeListBulkStatus:
+		switch value {
+		case AnimeListStatusWatching, AnimeListStatusCompleted, AnimeListStatusPlanned, AnimeListStatusHold, AnimeListStatusDropped:
+		default:
+			return nil, fmt.Errorf("Invalid anime list item status: %s", value)
+		}
+
+		return func(item *AnimeListItem) bool {
+			if item.Status == value {
+				return false
+			}
+
+			item.Status = value
+			item.OnStatusChange()
+			return true
+		}, nil
+
+	case AnimeListBulkRewatching, AnimeListBulkPrivate:
+		enabled, err := strconv.ParseBool(value)
+
+		if err != nil {
+			return nil, errors.New("Invalid value, expected true or false")
+		}
+
+		return func(item *AnimeListItem) bool {
+			field := &item.Private
+
+			if action == AnimeListBulkRewatching {
+				field = &item.Rewatching
+			}
+
+			if *field == enabled {
+				return false
+			}
+
+			*field = enabled
+			return true
+		}, nil
+
+	case AnimeListBulkTag:
+		tag := strings.TrimSpace(value)
+
+		if tag == "" {
+			return nil, errors.New("The tag can't be empty")
+		}
+
+		if len([]rune(tag)) > maxAnimeListTagLength {
+			return nil, fmt.Errorf("Tags can't be longer than %d characters", maxAnimeListTagLength)
+		}
+
+		return func(item *AnimeListItem) bool {
+			if len(item.Tags) >= maxAnimeListItemTags {
+				return false
+			}
+
+			for _, existing := range item.Tags {
+				if strings.EqualFold(existing, tag) {
+					return false
+				}
+			}
+
+			item.Tags = append(item.Tags, tag)
+			return true
+		}, nil
+
+	default:
+		return nil, fmt.Errorf("Unknown bulk edit action: %s", action)
+	}
+}
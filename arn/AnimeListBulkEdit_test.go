@@ -0,0 +1,43 @@
+package arn_test
+
+import (
+	"testing"
+
+	"github.com/akyoto/assert"
+	"github.com/animenotifier/notify.moe/arn"
+)
+
+func TestAnimeListBulkEdit(t *testing.T) {
+	list := &arn.AnimeList{
+		Items: []*arn.AnimeListItem{
+			{AnimeID: "a", Status: arn.AnimeListStatusWatching},
+			{AnimeID: "b", Status: arn.AnimeListStatusDropped, Private: true},
+			{AnimeID: "c", Status: arn.AnimeListStatusWatching},
+		},
+	}
+
+	changed, err := list.BulkEdit([]arn.AnimeID{"a", "b"}, arn.AnimeListBulkPrivate, "true")
+	assert.Nil(t, err)
+	assert.Equal(t, changed, 1)
+	assert.True(t, list.Items[0].Private)
+	assert.False(t, list.Items[2].Private)
+
+	changed, err = list.BulkEdit([]arn.AnimeID{"a", "c"}, arn.AnimeListBulkTag, " rewatch ")
+	assert.Nil(t, err)
+	assert.Equal(t, changed, 2)
+	assert.DeepEqual(t, list.Items[2].Tags, []string{"rewatch"})
+
+	changed, _ = list.BulkEdit([]arn.AnimeID{"a"}, arn.AnimeListBulkTag, "Rewatch")
+	assert.Equal(t, changed, 0)
+
+	changed, err = list.BulkEdit([]arn.AnimeID{"b", "c"}, arn.AnimeListBulkRemove, "")
+	assert.Nil(t, err)
+	assert.Equal(t, changed, 2)
+	assert.Equal(t, len(list.Items), 1)
+
+	_, err = list.BulkEdit([]arn.AnimeID{"a"}, arn.AnimeListBulkStatus, "invalid")
+	assert.NotNil(t, err)
+
+	_, err = list.BulkEdit(nil, arn.AnimeListBulkRewatching, "true")
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,226 @@
+package arn
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"math"
+	"strconv"
+)
+
+// AnimeListExportItem is an anime list entry in the format of the JSON and CSV exports.
+type AnimeListExportItem struct {
+	AnimeID       AnimeID             `json:"animeId"`
+	MyAnimeListID string              `json:"myAnimeListId"`
+	Title         string              `json:"title"`
+	Status        string              `json:"status"`
+	Episodes      int                 `json:"episodes"`
+	EpisodeCount  int                 `json:"episodeCount"`
+	Rating        AnimeListItemRating `json:"rating"`
+	RewatchCount  int                 `json:"rewatchCount"`
+	Notes         string              `json:"notes"`
+	Private       bool                `json:"private"`
+	Created       string              `json:"created"`
+	Edited        string              `json:"edited"`
+	Completed     string              `json:"completed"`
+}
+
+// animeListExportCSVHeader contains the column names of the CSV export.
+var animeListExportCSVHeader = []string{
+	"anime_id",
+	"myanimelist_id",
+	"title",
+	"status",
+	"episodes",
+	"episode_count",
+	"rating_overall",
+	"rating_story",
+	"rating_visuals",
+	"rating_soundtrack",
+	"rewatch_count",
+	"notes",
+	"private",
+	"created",
+	"edited",
+	"completed",
+}
+
+// myAnimeListXMLExport is the root element of a MyAnimeList compatible XML export.
+type myAnimeListXMLExport struct {
+	XMLName xml.Name `xml:"myanimelist"`
+	Info    struct {
+		ExportType int    `xml:"user_export_type"`
+		UserName   string `xml:"user_name"`
+		TotalAnime int    `xml:"user_total_anime"`
+	} `xml:"myinfo"`
+	Anime []*myAnimeListXMLAnime `xml:"anime"`
+}
+
+// myAnimeListXMLAnime is an anime in a MyAnimeList compatible XML export.
+type myAnimeListXMLAnime struct {
+	ID              string    `xml:"series_animedb_id"`
+	Title           cdataText `xml:"series_title"`
+	Episodes        int       `xml:"series_episodes"`
+	WatchedEpisodes int       `xml:"my_watched_episodes"`
+	StartDate       string    `xml:"my_start_date"`
+	FinishDate      string    `xml:"my_finish_date"`
+	Score           int       `xml:"my_score"`
+	Status          string    `xml:"my_status"`
+	Comments        cdataText `xml:"my_comments"`
+	TimesWatched    int       `xml:"my_times_watched"`
+	UpdateOnImport  int       `xml:"update_on_import"`
+}
+
+// cdataText is written as a CDATA section so that titles and notes don't need escaping.
+type cdataText struct {
+	Text string `xml:",cdata"`
+}
+
+// ExportItems returns the entries of the anime list in the export format.
+// Entries whose anime doesn't exist anymore are skipped.
+func (list *AnimeList) ExportItems() []*AnimeListExportItem {
+	list.Lock()
+	defer list.Unlock()
+
+	items := make([]*AnimeListExportItem, 0, len(list.Items))
+
+	for _, item := range list.Items {
+		anime := item.Anime()
+
+		if anime == nil {
+			continue
+		}
+
+		items = append(items, &AnimeListExportItem{
+			AnimeID:       item.AnimeID,
+			MyAnimeListID: anime.GetMapping("myanimelist/anime"),
+			Title:         anime.Title.Canonical,
+			Status:        item.Status,
+			Episodes:      item.Episodes,
+			EpisodeCount:  anime.EpisodeCount,
+			Rating:        item.Rating,
+			RewatchCount:  item.RewatchCount,
+			Notes:         item.Notes,
+			Private:       item.Private,
+			Created:       item.Created,
+			Edited:        item.Edited,
+			Completed:     item.Completed,
+		})
+	}
+
+	return items
+}
+
+// WriteJSON writes the anime list as a JSON array.
+func (list *AnimeList) WriteJSON(writer io.Writer) error {
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "\t")
+	return encoder.Encode(list.ExportItems())
+}
+
+// WriteCSV writes the anime list as comma separated values with a header row.
+func (list *AnimeList) WriteCSV(writer io.Writer) error {
+	csvWriter := csv.NewWriter(writer)
+	err := csvWriter.Write(animeListExportCSVHeader)
+
+	if err != nil {
+		return err
+	}
+
+	for _, item := range list.ExportItems() {
+		err = csvWriter.Write([]string{
+			item.AnimeID,
+			item.MyAnimeListID,
+			item.Title,
+			item.Status,
+			strconv.Itoa(item.Episodes),
+			strconv.Itoa(item.EpisodeCount),
+			formatExportRating(item.Rating.Overall),
+			formatExportRating(item.Rating.Story),
+			formatExportRating(item.Rating.Visuals),
+			formatExportRating(item.Rating.Soundtrack),
+			strconv.Itoa(item.RewatchCount),
+			item.Notes,
+			strconv.FormatBool(item.Private),
+			item.Created,
+			item.Edited,
+			item.Completed,
+		})
+
+		if err != nil {
+			return err
+		}
+	}
+
+	csvWriter.Flush()
+	return csvWriter.Error()
+}
+
+// WriteMyAnimeListXML writes the anime list in the XML format of MyAnimeList exports
+// so that it can be imported on MyAnimeList and other websites supporting it.
+// Anime without a MyAnimeList mapping are skipped.
+func (list *AnimeList) WriteMyAnimeListXML(writer io.Writer, userName string) error {
+	export := &myAnimeListXMLExport{}
+	export.Info.ExportType = 1
+	export.Info.UserName = userName
+
+	for _, item := range list.ExportItems() {
+		if item.MyAnimeListID == "" {
+			continue
+		}
+
+		finishDate := "0000-00-00"
+
+		if len(item.Completed) >= len("2006-01-02") {
+			finishDate = item.Completed[:len("2006-01-02")]
+		}
+
+		export.Anime = append(export.Anime, &myAnimeListXMLAnime{
+			ID:              item.MyAnimeListID,
+			Title:           cdataText{item.Title},
+			Episodes:        item.EpisodeCount,
+			WatchedEpisodes: item.Episodes,
+			StartDate:       "0000-00-00",
+			FinishDate:      finishDate,
+			Score:           int(math.Round(item.Rating.Overall)),
+			Status:          myAnimeListExportStatusName(item.Status),
+			Comments:        cdataText{item.Notes},
+			TimesWatched:    item.RewatchCount,
+			UpdateOnImport:  1,
+		})
+	}
+
+	export.Info.TotalAnime = len(export.Anime)
+
+	_, err := io.WriteString(writer, xml.Header)
+
+	if err != nil {
+		return err
+	}
+
+	encoder := xml.NewEncoder(writer)
+	encoder.Indent("", "\t")
+	return encoder.Encode(export)
+}
+
+// formatExportRating formats a rating without unnecessary decimals.
+func formatExportRating(rating float64) string {
+	return strconv.FormatFloat(rating, 'f', -1, 64)
+}
+
+// myAnimeListExportStatusName returns the status name used in MyAnimeList exports.
+func myAnimeListExportStatusName(status string) string {
+	switch status {
+	case AnimeListStatusWatching:
+		return "Watching"
+	case AnimeListStatusCompleted:
+		return "Completed"
+	case AnimeListStatusHold:
+		return "On-Hold"
+	case AnimeListStatusDropped:
+		return "Dropped"
+	default:
+		return "Plan to Watch"
+	}
+}
@@ -0,0 +1,109 @@
+package arn_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/akyoto/assert"
+	"github.com/animenotifier/notify.moe/arn"
+)
+
+func TestAnimeListExportFormats(t *testing.T) {
+	animeList := &arn.AnimeList{
+		Items: []*arn.AnimeListItem{
+			{AnimeID: "does-not-exist", Status: arn.AnimeListStatusWatching},
+		},
+	}
+
+	buffer := bytes.Buffer{}
+	assert.Nil(t, animeList.WriteCSV(&buffer))
+	assert.Equal(t, strings.TrimSpace(buffer.String()), "anime_id,myanimelist_id,title,status,episodes,episode_count,rating_overall,rating_story,rating_visuals,rating_soundtrack,rewatch_count,notes,private,created,edited,completed")
+
+	buffer.Reset()
+	assert.Nil(t, animeList.WriteJSON(&buffer))
+	assert.Equal(t, strings.TrimSpace(buffer.String()), "[]")
+
+	buffer.Reset()
+	assert.Nil(t, animeList.WriteMyAnimeListXML(&buffer, "Akyoto"))
+	assert.Contains(t, buffer.String(), "<myanimelist>")
+	assert.Contains(t, buffer.String(), "<user_name>Akyoto</user_name>")
+	assert.Contains(t, buffer.String(), "<user_total_anime>0</user_total_anime>")
+}
+
+func TestAnimeListExportRoundTrip(t *testing.T) {
+	anime, err := arn.GetAnime("74y2cFiiR")
+	assert.Nil(t, err)
+
+	item := &arn.AnimeListItem{
+		AnimeID:      anime.ID,
+		Status:       arn.AnimeListStatusCompleted,
+		Episodes:     anime.EpisodeCount,
+		Rating:       arn.AnimeListItemRating{Overall: 8.5, Story: 9},
+		RewatchCount: 2,
+		Notes:        "Great, \"really\" <great>",
+		Created:      "2019-01-02T10:00:00Z",
+		Edited:       "2019-03-04T10:00:00Z",
+		Completed:    "2019-03-04T10:00:00Z",
+	}
+
+	animeList := &arn.AnimeList{Items: []*arn.AnimeListItem{item}}
+
+	// JSON
+	buffer := bytes.Buffer{}
+	assert.Nil(t, animeList.WriteJSON(&buffer))
+	exported := []*arn.AnimeListExportItem{}
+	assert.Nil(t, json.Unmarshal(buffer.Bytes(), &exported))
+	assert.DeepEqual(t, exported, animeList.ExportItems())
+	assert.Equal(t, exported[0].AnimeID, anime.ID)
+	assert.Equal(t, exported[0].Title, anime.Title.Canonical)
+	assert.Equal(t, exported[0].MyAnimeListID, anime.GetMapping("myanimelist/anime"))
+	assert.Equal(t, exported[0].Rating.Story, 9.0)
+	assert.Equal(t, exported[0].Notes, item.Notes)
+	assert.Equal(t, exported[0].Completed, item.Completed)
+
+	// CSV
+	buffer.Reset()
+	assert.Nil(t, animeList.WriteCSV(&buffer))
+	rows, err := csv.NewReader(&buffer).ReadAll()
+	assert.Nil(t, err)
+	assert.Equal(t, len(rows), 2)
+	assert.DeepEqual(t, rows[1], []string{
+		anime.ID,
+		anime.GetMapping("myanimelist/anime"),
+		anime.Title.Canonical,
+		arn.AnimeListStatusCompleted,
+		strconv.Itoa(anime.EpisodeCount),
+		strconv.Itoa(anime.EpisodeCount),
+		"8.5",
+		"9",
+		"0",
+		"0",
+		"2",
+		item.Notes,
+		"false",
+		item.Created,
+		item.Edited,
+		item.Completed,
+	})
+
+	// MyAnimeList XML can be imported again
+	buffer.Reset()
+	assert.Nil(t, animeList.WriteMyAnimeListXML(&buffer, "Akyoto"))
+	assert.Contains(t, buffer.String(), "<my_finish_date>2019-03-04</my_finish_date>")
+	listImport, err := arn.NewListImport("4J6qpK1ve", buffer.Bytes())
+	assert.Nil(t, err)
+	assert.Equal(t, listImport.Source, arn.ListImportSourceMyAnimeList)
+	assert.Equal(t, len(listImport.Items), 1)
+
+	imported := listImport.Items[0]
+	assert.Equal(t, imported.ServiceID, anime.GetMapping("myanimelist/anime"))
+	assert.Equal(t, imported.Title, anime.Title.Canonical)
+	assert.Equal(t, imported.Status, arn.AnimeListStatusCompleted)
+	assert.Equal(t, imported.Episodes, anime.EpisodeCount)
+	assert.Equal(t, imported.RewatchCount, 2)
+	assert.Equal(t, imported.Notes, item.Notes)
+}
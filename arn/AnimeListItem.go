@@ -1,5 +1,7 @@
 package arn
 
+import "time"
+
 // AnimeListStatus values for anime list items
 const (
 	AnimeListStatusWatching  = "watching"
@@ -11,15 +13,20 @@ const (
 
 // AnimeListItem represents a single item in an anime list.
 type AnimeListItem struct {
-	AnimeID      AnimeID             `json:"animeId"`
-	Status       string              `json:"status" editable:"true"`
-	Episodes     int                 `json:"episodes" editable:"true"`
-	Rating       AnimeListItemRating `json:"rating"`
-	Notes        string              `json:"notes" editable:"true"`
-	RewatchCount int                 `json:"rewatchCount" editable:"true"`
-	Private      bool                `json:"private" editable:"true"`
-	Created      string              `json:"created"`
-	Edited       string              `json:"edited"`
+	AnimeID              AnimeID             `json:"animeId"`
+	Status               string              `json:"status" editable:"true"`
+	Episodes             int                 `json:"episodes" editable:"true"`
+	Rating               AnimeListItemRating `json:"rating"`
+	Notes                string              `json:"notes" editable:"true"`
+	RewatchCount         int                 `json:"rewatchCount" editable:"true"`
+	Private              bool                `json:"private" editable:"true"`
+	Rewatching           bool                `json:"rewatching" editable:"true"`
+	Tags                 []string            `json:"tags" editable:"true"`
+	NotificationLeadTime string              `json:"notificationLeadTime" editable:"true" datalist:"anime-notification-lead-times"`
+	NotifiedAiring       string              `json:"notifiedAiring"`
+	Created              string              `json:"created"`
+	Edited               string              `json:"edited"`
+	Completed            string              `json:"completed"`
 }
 
 // Anime fetches the associated anime data.
@@ -33,6 +40,12 @@ func (item *AnimeListItem) Link(userNick string) string {
 	return "/+" + userNick + "/animelist/anime/" + item.AnimeID
 }
 
+// EditedTime returns the time of the last edit as a time struct.
+func (item *AnimeListItem) EditedTime() time.Time {
+	t, _ := time.Parse(time.RFC3339, item.Edited)
+	return t
+}
+
 // StatusHumanReadable returns the human readable representation of the status.
 func (item *AnimeListItem) StatusHumanReadable() string {
 	switch item.Status {
@@ -102,3 +115,29 @@ func (item *AnimeListItem) OnStatusChange() {
 		item.Episodes--
 	}
 }
+
+// updateCompleted remembers the date when the anime was completed
+// and forgets it when the anime is no longer completed.
+func (item *AnimeListItem) updateCompleted(date string) {
+	if item.Status != AnimeListStatusCompleted {
+		item.Completed = ""
+	} else if item.Completed == "" {
+		item.Completed = date
+	}
+}
+
+// isAheadOf tells you whether the item shows more watching progress than the other item.
+func (item *AnimeListItem) isAheadOf(other *AnimeListItem) bool {
+	if item.RewatchCount != other.RewatchCount {
+		return item.RewatchCount > other.RewatchCount
+	}
+
+	itemCompleted := item.Status == AnimeListStatusCompleted
+	otherCompleted := other.Status == AnimeListStatusCompleted
+
+	if itemCompleted != otherCompleted {
+		return itemCompleted
+	}
+
+	return item.Episodes > other.Episodes
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/aerogo/aero"
 	"github.com/aerogo/api"
+	"github.com/akyoto/color"
 )
 
 // Force interface implementations
@@ -30,56 +31,21 @@ func (item *AnimeListItem) Edit(ctx aero.Context, key string, value reflect.Valu
 			return true, errors.New("Invalid episode number")
 		}
 
-		oldEpisodes := item.Episodes
-		newEpisodes := int(newValue.Float())
-
-		// Fetch last activity
-		lastActivity := user.LastActivityConsumeAnime(item.AnimeID)
-
-		if lastActivity == nil || time.Since(lastActivity.GetCreatedTime()) > 1*time.Hour {
-			// If there is no last activity for the given anime,
-			// or if the last activity happened more than an hour ago,
-			// create a new activity.
-			if newEpisodes > oldEpisodes {
-				activity := NewActivityConsumeAnime(item.AnimeID, newEpisodes, newEpisodes, user.ID)
-				activity.Save()
-
-				// Broadcast event to all users so they can reload the activity page if needed.
-				for receiver := range StreamUsers() {
-					receiver.BroadcastEvent(&aero.Event{
-						Name: "activity",
-						Data: receiver.IsFollowing(user.ID),
-					})
-				}
-			}
-		} else if newEpisodes >= lastActivity.FromEpisode {
-			// Otherwise, update the last activity.
-			lastActivity.ToEpisode = newEpisodes
-			lastActivity.Created = DateTimeUTC()
-			lastActivity.Save()
-		}
-
-		item.Episodes = newEpisodes
-
-		if item.Episodes < 0 {
-			item.Episodes = 0
-		}
-
-		item.OnEpisodesChange()
+		item.SetEpisodes(user, int(newValue.Float()))
 		return true, nil
 
 	case "Status":
-		newStatus := newValue.String()
+		return true, item.SetStatus(newValue.String())
 
-		switch newStatus {
-		case AnimeListStatusWatching, AnimeListStatusCompleted, AnimeListStatusPlanned, AnimeListStatusHold, AnimeListStatusDropped:
-			item.Status = newStatus
-			item.OnStatusChange()
-			return true, nil
+	case "NotificationLeadTime":
+		leadTime := newValue.String()
 
-		default:
-			return true, fmt.Errorf("Invalid anime list item status: %s", newStatus)
+		if leadTime != "" && !IsNotificationLeadTime(leadTime) {
+			return true, fmt.Errorf("Invalid notification lead time: %s", leadTime)
 		}
+
+		item.NotificationLeadTime = leadTime
+		return true, nil
 	}
 
 	return false, nil
@@ -87,7 +53,93 @@ func (item *AnimeListItem) Edit(ctx aero.Context, key string, value reflect.Valu
 
 // AfterEdit is called after the item is edited.
 func (item *AnimeListItem) AfterEdit(ctx aero.Context) error {
+	item.OnEdited(GetUserFromContext(ctx))
+	return nil
+}
+
+// SetEpisodes updates the watched episode count and the activity of the user.
+func (item *AnimeListItem) SetEpisodes(user *User, newEpisodes int) {
+	oldEpisodes := item.Episodes
+
+	// Fetch last activity
+	lastActivity := user.LastActivityConsumeAnime(item.AnimeID)
+
+	if lastActivity == nil || time.Since(lastActivity.GetCreatedTime()) > 1*time.Hour {
+		// If there is no last activity for the given anime,
+		// or if the last activity happened more than an hour ago,
+		// create a new activity.
+		if newEpisodes > oldEpisodes {
+			activity := NewActivityConsumeAnime(item.AnimeID, newEpisodes, newEpisodes, user.ID)
+			activity.Save()
+
+			// Broadcast event to all connected users so they can reload the activity page if needed.
+			for _, receiver := range ConnectedUsers() {
+				receiver.BroadcastEvent(&aero.Event{
+					Name: "activity",
+					Data: receiver.IsFollowing(user.ID),
+				})
+			}
+		}
+	} else if newEpisodes >= lastActivity.FromEpisode {
+		// Otherwise, update the last activity.
+		lastActivity.ToEpisode = newEpisodes
+		lastActivity.Created = DateTimeUTC()
+		lastActivity.Save()
+	}
+
+	item.Episodes = newEpisodes
+
+	if item.Episodes < 0 {
+		item.Episodes = 0
+	}
+
+	item.OnEpisodesChange()
+}
+
+// SetStatus updates the status if it's valid.
+func (item *AnimeListItem) SetStatus(newStatus string) error {
+	switch newStatus {
+	case AnimeListStatusWatching, AnimeListStatusCompleted, AnimeListStatusPlanned, AnimeListStatusHold, AnimeListStatusDropped:
+		item.Status = newStatus
+		item.OnStatusChange()
+		return nil
+
+	default:
+		return fmt.Errorf("Invalid anime list item status: %s", newStatus)
+	}
+}
+
+// OnEdited updates the edit dates and syncs the item with AniList and MyAnimeList.
+// The user is the owner of the list, if known.
+func (item *AnimeListItem) OnEdited(user *User) {
 	item.Rating.Clamp()
 	item.Edited = DateTimeUTC()
-	return nil
+
+	item.updateCompleted(item.Edited)
+
+	// Keep the AniList anime list in sync
+	if user != nil && user.Accounts.AniList.Sync && !item.Private {
+		edited := *item
+
+		go func() {
+			err := user.PushAniListEntry(&edited)
+
+			if err != nil {
+				color.Red("AniList sync failed for user %s: %s", user.Nick, err.Error())
+			}
+		}()
+	}
+
+	// Keep the MyAnimeList anime list in sync
+	if user != nil && user.Accounts.MyAnimeList.Sync && !item.Private {
+		edited := *item
+
+		go func() {
+			err := user.PushMyAnimeListEntry(&edited)
+
+			if err != nil {
+				color.Red("MyAnimeList sync failed for user %s: %s", user.Nick, err.Error())
+			}
+		}()
+	}
 }
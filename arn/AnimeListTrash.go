@@ -0,0 +1,182 @@
+package arn
+
+import (
+	"errors"
+	"time"
+)
+
+// AnimeListTrashDuration is how long removed anime list entries can be restored.
+const AnimeListTrashDuration = 30 * 24 * time.Hour
+
+// AnimeListTrashItem is an anime list entry that has been removed by the user.
+type AnimeListTrashItem struct {
+	Item    *AnimeListItem `json:"item"`
+	Deleted string         `json:"deleted"`
+}
+
+// Anime fetches the associated anime data.
+func (trashed *AnimeListTrashItem) Anime() *Anime {
+	return trashed.Item.Anime()
+}
+
+// DeletedTime returns the time the entry has been removed.
+func (trashed *AnimeListTrashItem) DeletedTime() time.Time {
+	deleted, _ := time.Parse(time.RFC3339, trashed.Deleted)
+	return deleted
+}
+
+// Expires returns the date the entry will be deleted permanently.
+func (trashed *AnimeListTrashItem) Expires() string {
+	return trashed.DeletedTime().Add(AnimeListTrashDuration).Format(time.RFC3339)
+}
+
+// IsExpired tells you whether the entry can't be restored anymore.
+func (trashed *AnimeListTrashItem) IsExpired(now time.Time) bool {
+	return now.Sub(trashed.DeletedTime()) > AnimeListTrashDuration
+}
+
+// MoveToTrash removes the anime from the list and keeps the entry in the trash.
+func (list *AnimeList) MoveToTrash(animeID AnimeID) bool {
+	list.Lock()
+	defer list.Unlock()
+	return list.moveToTrash(animeID)
+}
+
+// moveToTrash removes the anime from the list and keeps the entry in the trash.
+// The caller needs to hold the lock.
+func (list *AnimeList) moveToTrash(animeID AnimeID) bool {
+	for index, item := range list.Items {
+		if item.AnimeID == animeID {
+			list.Items = append(list.Items[:index], list.Items[index+1:]...)
+			list.trash(item)
+			return true
+		}
+	}
+
+	return false
+}
+
+// MoveAllToTrash removes all anime from the list and keeps the entries in the trash.
+func (list *AnimeList) MoveAllToTrash() int {
+	list.Lock()
+	defer list.Unlock()
+
+	count := len(list.Items)
+
+	for _, item := range list.Items {
+		list.trash(item)
+	}
+
+	list.Items = []*AnimeListItem{}
+	return count
+}
+
+// RestoreFromTrash adds the removed entry back to the list.
+func (list *AnimeList) RestoreFromTrash(animeID AnimeID) error {
+	if list.Contains(animeID) {
+		return errors.New("This anime is already in your list")
+	}
+
+	list.Lock()
+	defer list.Unlock()
+
+	for index, trashed := range list.Trash {
+		if trashed.Item.AnimeID == animeID {
+			list.Trash = append(list.Trash[:index], list.Trash[index+1:]...)
+			list.Items = append(list.Items, trashed.Item)
+			return nil
+		}
+	}
+
+	return errors.New("This anime is not in your trash")
+}
+
+// FindInTrash returns the removed entry of the anime or nil if it's not in the trash.
+func (list *AnimeList) FindInTrash(animeID AnimeID) *AnimeListTrashItem {
+	list.Lock()
+	defer list.Unlock()
+
+	for _, trashed := range list.Trash {
+		if trashed.Item.AnimeID == animeID {
+			return trashed
+		}
+	}
+
+	return nil
+}
+
+// DeleteFromTrash deletes the removed entry permanently.
+func (list *AnimeList) DeleteFromTrash(animeID AnimeID) bool {
+	list.Lock()
+	defer list.Unlock()
+	return list.deleteFromTrash(animeID)
+}
+
+// deleteFromTrash deletes the removed entry permanently.
+// The caller needs to hold the lock.
+func (list *AnimeList) deleteFromTrash(animeID AnimeID) bool {
+	for index, trashed := range list.Trash {
+		if trashed.Item.AnimeID == animeID {
+			list.Trash = append(list.Trash[:index], list.Trash[index+1:]...)
+			return true
+		}
+	}
+
+	return false
+}
+
+// TrashItems returns the removed entries that can still be restored, most recently removed first.
+func (list *AnimeList) TrashItems() []*AnimeListTrashItem {
+	list.Lock()
+	defer list.Unlock()
+
+	now := time.Now()
+	items := make([]*AnimeListTrashItem, 0, len(list.Trash))
+
+	for index := len(list.Trash) - 1; index >= 0; index-- {
+		trashed := list.Trash[index]
+
+		if trashed.IsExpired(now) || trashed.Anime() == nil {
+			continue
+		}
+
+		items = append(items, trashed)
+	}
+
+	return items
+}
+
+// PurgeTrash permanently deletes the entries that have been in the trash for too long
+// and returns the number of deleted entries.
+func (list *AnimeList) PurgeTrash(now time.Time) int {
+	list.Lock()
+	defer list.Unlock()
+
+	kept := list.Trash[:0]
+
+	for _, trashed := range list.Trash {
+		if !trashed.IsExpired(now) {
+			kept = append(kept, trashed)
+		}
+	}
+
+	purged := len(list.Trash) - len(kept)
+	list.Trash = kept
+	return purged
+}
+
+// trash adds the entry to the trash, replacing older entries of the same anime.
+// The caller needs to hold the lock.
+func (list *AnimeList) trash(item *AnimeListItem) {
+	for index, trashed := range list.Trash {
+		if trashed.Item.AnimeID == item.AnimeID {
+			list.Trash = append(list.Trash[:index], list.Trash[index+1:]...)
+			break
+		}
+	}
+
+	list.Trash = append(list.Trash, &AnimeListTrashItem{
+		Item:    item,
+		Deleted: DateTimeUTC(),
+	})
+}
@@ -0,0 +1,75 @@
+package arn_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/akyoto/assert"
+	"github.com/animenotifier/notify.moe/arn"
+)
+
+func TestAnimeListTrash(t *testing.T) {
+	list := &arn.AnimeList{
+		Items: []*arn.AnimeListItem{
+			{AnimeID: "a", Status: arn.AnimeListStatusWatching, Episodes: 3},
+			{AnimeID: "b", Status: arn.AnimeListStatusCompleted},
+		},
+	}
+
+	assert.True(t, list.MoveToTrash("a"))
+	assert.False(t, list.MoveToTrash("a"))
+	assert.False(t, list.Contains("a"))
+	assert.Equal(t, len(list.Trash), 1)
+
+	// Restored entries keep their progress
+	assert.Nil(t, list.RestoreFromTrash("a"))
+	assert.NotNil(t, list.RestoreFromTrash("a"))
+	assert.Equal(t, list.Find("a").Episodes, 3)
+	assert.Equal(t, len(list.Trash), 0)
+
+	assert.Equal(t, list.MoveAllToTrash(), 2)
+	assert.Equal(t, len(list.Items), 0)
+	assert.Equal(t, len(list.Trash), 2)
+
+	assert.NotNil(t, list.FindInTrash("b"))
+	assert.True(t, list.DeleteFromTrash("b"))
+	assert.False(t, list.DeleteFromTrash("b"))
+	assert.Nil(t, list.FindInTrash("b"))
+	assert.NotNil(t, list.RestoreFromTrash("b"))
+}
+
+func TestAnimeListTrashRestoreConflict(t *testing.T) {
+	list := &arn.AnimeList{
+		Items: []*arn.AnimeListItem{
+			{AnimeID: "a", Episodes: 3},
+		},
+	}
+
+	assert.True(t, list.MoveToTrash("a"))
+	list.Items = append(list.Items, &arn.AnimeListItem{AnimeID: "a"})
+
+	// The anime has been added again in the meantime
+	assert.NotNil(t, list.RestoreFromTrash("a"))
+	assert.Equal(t, len(list.Trash), 1)
+
+	// Removing it again replaces the old trash entry
+	assert.True(t, list.MoveToTrash("a"))
+	assert.Equal(t, len(list.Trash), 1)
+	assert.Equal(t, list.Trash[0].Item.Episodes, 0)
+}
+
+func TestAnimeListPurgeTrash(t *testing.T) {
+	now := time.Now()
+
+	list := &arn.AnimeList{
+		Trash: []*arn.AnimeListTrashItem{
+			{Item: &arn.AnimeListItem{AnimeID: "old"}, Deleted: now.Add(-arn.AnimeListTrashDuration - time.Hour).UTC().Format(time.RFC3339)},
+			{Item: &arn.AnimeListItem{AnimeID: "new"}, Deleted: now.Add(-time.Hour).UTC().Format(time.RFC3339)},
+		},
+	}
+
+	assert.Equal(t, list.PurgeTrash(now), 1)
+	assert.Equal(t, len(list.Trash), 1)
+	assert.Equal(t, list.Trash[0].Item.AnimeID, "new")
+	assert.Equal(t, list.PurgeTrash(now), 0)
+}
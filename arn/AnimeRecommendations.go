@@ -0,0 +1,250 @@
+package arn
+
+import (
+	"sort"
+)
+
+const (
+	// RecommendationMinRating is the overall rating a completed anime needs to count as liked.
+	RecommendationMinRating = 8.0
+
+	// similarAnimeMinCommonFans is the number of users who need to like both anime to consider them similar.
+	similarAnimeMinCommonFans = 3
+
+	// similarAnimeMaxItems is the number of similar anime we store per anime.
+	similarAnimeMaxItems = 20
+
+	// recommendationsMaxItems is the number of recommendations we store per user.
+	recommendationsMaxItems = 30
+
+	// likedAnimeMaxItems limits the pairs we count for users who rated a huge list highly.
+	likedAnimeMaxItems = 200
+)
+
+// SimilarAnime is the list of anime that the fans of an anime liked as well.
+type SimilarAnime struct {
+	AnimeID AnimeID             `json:"animeId" primary:"true"`
+	Items   []*SimilarAnimeItem `json:"items"`
+}
+
+// SimilarAnimeItem is an anime and its similarity between 0 and 1.
+type SimilarAnimeItem struct {
+	AnimeID    AnimeID `json:"animeId"`
+	Similarity float64 `json:"similarity"`
+}
+
+// UserRecommendations contains the anime suggestions for a user.
+type UserRecommendations struct {
+	UserID  UserID                 `json:"userId" primary:"true"`
+	Items   []*AnimeRecommendation `json:"items"`
+	Updated string                 `json:"updated"`
+}
+
+// AnimeRecommendation suggests an anime because the user liked a similar one.
+type AnimeRecommendation struct {
+	AnimeID   AnimeID `json:"animeId"`
+	BecauseOf AnimeID `json:"becauseOf"`
+	Score     float64 `json:"score"`
+}
+
+// Anime returns the recommended anime.
+func (recommendation *AnimeRecommendation) Anime() *Anime {
+	anime, _ := GetAnime(recommendation.AnimeID)
+	return anime
+}
+
+// LikedAnimeIDs returns the completed anime with a high rating, best rated first.
+func (list *AnimeList) LikedAnimeIDs() []AnimeID {
+	items := []*AnimeListItem{}
+
+	for _, item := range list.Items {
+		if item.Status == AnimeListStatusCompleted && !item.Private && item.Rating.Overall >= RecommendationMinRating {
+			items = append(items, item)
+		}
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].Rating.Overall == items[j].Rating.Overall {
+			return items[i].AnimeID < items[j].AnimeID
+		}
+
+		return items[i].Rating.Overall > items[j].Rating.Overall
+	})
+
+	if len(items) > likedAnimeMaxItems {
+		items = items[:likedAnimeMaxItems]
+	}
+
+	animeIDs := make([]AnimeID, len(items))
+
+	for i, item := range items {
+		animeIDs[i] = item.AnimeID
+	}
+
+	return animeIDs
+}
+
+// ComputeSimilarAnime calculates the Jaccard similarity of each pair of anime
+// based on the users who liked them. The likes map a user to the anime they liked.
+func ComputeSimilarAnime(likes map[UserID][]AnimeID) map[AnimeID][]*SimilarAnimeItem {
+	fans := map[AnimeID]int{}
+	commonFans := map[AnimeID]map[AnimeID]int{}
+
+	for _, liked := range likes {
+		for i, a := range liked {
+			fans[a]++
+
+			for _, b := range liked[i+1:] {
+				// Each pair is only counted once
+				first, second := a, b
+
+				if first > second {
+					first, second = second, first
+				}
+
+				if commonFans[first] == nil {
+					commonFans[first] = map[AnimeID]int{}
+				}
+
+				commonFans[first][second]++
+			}
+		}
+	}
+
+	similar := map[AnimeID][]*SimilarAnimeItem{}
+
+	for a, counts := range commonFans {
+		for b, common := range counts {
+			if common < similarAnimeMinCommonFans {
+				continue
+			}
+
+			similarity := float64(common) / float64(fans[a]+fans[b]-common)
+			similar[a] = append(similar[a], &SimilarAnimeItem{AnimeID: b, Similarity: similarity})
+			similar[b] = append(similar[b], &SimilarAnimeItem{AnimeID: a, Similarity: similarity})
+		}
+	}
+
+	for animeID, items := range similar {
+		sort.Slice(items, func(i, j int) bool {
+			if items[i].Similarity == items[j].Similarity {
+				return items[i].AnimeID < items[j].AnimeID
+			}
+
+			return items[i].Similarity > items[j].Similarity
+		})
+
+		if len(items) > similarAnimeMaxItems {
+			similar[animeID] = items[:similarAnimeMaxItems]
+		}
+	}
+
+	return similar
+}
+
+// RecommendAnime suggests anime that are similar to the liked anime.
+// Anime for which exclude returns true are never recommended.
+// Each recommendation refers to the liked anime that contributed the most to it.
+func RecommendAnime(liked []AnimeID, similar map[AnimeID][]*SimilarAnimeItem, exclude func(AnimeID) bool) []*AnimeRecommendation {
+	recommendations := map[AnimeID]*AnimeRecommendation{}
+	strongest := map[AnimeID]float64{}
+
+	for _, likedID := range liked {
+		for _, item := range similar[likedID] {
+			if exclude(item.AnimeID) {
+				continue
+			}
+
+			recommendation, exists := recommendations[item.AnimeID]
+
+			if !exists {
+				recommendation = &AnimeRecommendation{AnimeID: item.AnimeID}
+				recommendations[item.AnimeID] = recommendation
+			}
+
+			recommendation.Score += item.Similarity
+
+			if item.Similarity > strongest[item.AnimeID] {
+				strongest[item.AnimeID] = item.Similarity
+				recommendation.BecauseOf = likedID
+			}
+		}
+	}
+
+	sorted := make([]*AnimeRecommendation, 0, len(recommendations))
+
+	for _, recommendation := range recommendations {
+		sorted = append(sorted, recommendation)
+	}
+
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Score == sorted[j].Score {
+			return sorted[i].AnimeID < sorted[j].AnimeID
+		}
+
+		return sorted[i].Score > sorted[j].Score
+	})
+
+	if len(sorted) > recommendationsMaxItems {
+		sorted = sorted[:recommendationsMaxItems]
+	}
+
+	return sorted
+}
+
+// Anime returns the similar anime except the ones for which exclude returns true.
+func (similar *SimilarAnime) Anime(maxCount int, exclude func(AnimeID) bool) []*Anime {
+	animes := []*Anime{}
+
+	for _, item := range similar.Items {
+		if len(animes) == maxCount {
+			break
+		}
+
+		if exclude(item.AnimeID) {
+			continue
+		}
+
+		anime, err := GetAnime(item.AnimeID)
+
+		if err != nil || anime.IsDraft {
+			continue
+		}
+
+		animes = append(animes, anime)
+	}
+
+	return animes
+}
+
+// GetSimilarAnime returns the similar anime for the given anime ID.
+func GetSimilarAnime(animeID AnimeID) (*SimilarAnime, error) {
+	obj, err := DB.Get("SimilarAnime", animeID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return obj.(*SimilarAnime), nil
+}
+
+// GetUserRecommendations returns the anime recommendations for the given user ID.
+func GetUserRecommendations(userID UserID) (*UserRecommendations, error) {
+	obj, err := DB.Get("UserRecommendations", userID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return obj.(*UserRecommendations), nil
+}
+
+// Save saves the similar anime in the database.
+func (similar *SimilarAnime) Save() {
+	DB.Set("SimilarAnime", similar.AnimeID, similar)
+}
+
+// Save saves the recommendations in the database.
+func (recommendations *UserRecommendations) Save() {
+	DB.Set("UserRecommendations", recommendations.UserID, recommendations)
+}
@@ -0,0 +1,40 @@
+package arn_test
+
+import (
+	"testing"
+
+	"github.com/akyoto/assert"
+	"github.com/animenotifier/notify.moe/arn"
+)
+
+func TestAnimeRecommendations(t *testing.T) {
+	likes := map[arn.UserID][]arn.AnimeID{
+		"a": {"steins-gate", "erased", "monogatari"},
+		"b": {"steins-gate", "erased"},
+		"c": {"steins-gate", "erased", "monogatari"},
+		"d": {"erased", "steins-gate", "monogatari"},
+		"e": {"steins-gate"},
+	}
+
+	similar := arn.ComputeSimilarAnime(likes)
+
+	// 4 common fans out of 5 fans
+	assert.Equal(t, len(similar["steins-gate"]), 2)
+	assert.Equal(t, similar["steins-gate"][0].AnimeID, "erased")
+	assert.Equal(t, similar["steins-gate"][0].Similarity, 0.8)
+	assert.Equal(t, similar["erased"][0].AnimeID, "steins-gate")
+
+	// Only 3 common fans out of 5 fans
+	assert.Equal(t, similar["steins-gate"][1].AnimeID, "monogatari")
+	assert.Equal(t, similar["steins-gate"][1].Similarity, 0.6)
+
+	// Anime on the user's list are never recommended
+	onList := func(animeID arn.AnimeID) bool {
+		return animeID == "steins-gate" || animeID == "erased"
+	}
+
+	recommendations := arn.RecommendAnime([]arn.AnimeID{"steins-gate", "erased"}, similar, onList)
+	assert.Equal(t, len(recommendations), 1)
+	assert.Equal(t, recommendations[0].AnimeID, "monogatari")
+	assert.Equal(t, recommendations[0].BecauseOf, "erased")
+}
@@ -60,3 +60,23 @@ func HumanReadableAnimeRelation(relationName string) string {
 
 	return relationName
 }
+
+// ReverseAnimeRelation returns the relation type the related anime should have to point back.
+func ReverseAnimeRelation(relationName string) string {
+	switch relationName {
+	case "prequel":
+		return "sequel"
+	case "sequel":
+		return "prequel"
+	case "side story", "spinoff":
+		return "parent story"
+	case "parent story":
+		return "side story"
+	case "summary":
+		return "full story"
+	case "full story":
+		return "summary"
+	}
+
+	return relationName
+}
@@ -100,6 +100,63 @@ func (relations *AnimeRelations) Remove(animeID AnimeID) bool {
 	return false
 }
 
+// MissingReverse returns the relations whose anime doesn't link back to this anime.
+func (relations *AnimeRelations) MissingReverse() []*AnimeRelation {
+	relations.Lock()
+	items := append([]*AnimeRelation(nil), relations.Items...)
+	relations.Unlock()
+
+	var missing []*AnimeRelation
+
+	for _, relation := range items {
+		if relation.AnimeID == "" || relation.AnimeID == relations.AnimeID {
+			continue
+		}
+
+		reverse, err := GetAnimeRelations(relation.AnimeID)
+
+		if err == nil && reverse.Find(relations.AnimeID) != nil {
+			continue
+		}
+
+		missing = append(missing, relation)
+	}
+
+	return missing
+}
+
+// AddReverse lets the related anime link back to this anime
+// and returns the relation lists that have been changed.
+func (relations *AnimeRelations) AddReverse() []*AnimeRelations {
+	var changed []*AnimeRelations
+
+	for _, relation := range relations.MissingReverse() {
+		if relation.Anime() == nil {
+			continue
+		}
+
+		reverse, err := GetAnimeRelations(relation.AnimeID)
+
+		if err != nil {
+			reverse = &AnimeRelations{
+				AnimeID: relation.AnimeID,
+				Items:   []*AnimeRelation{},
+			}
+		}
+
+		reverse.Lock()
+		reverse.Items = append(reverse.Items, &AnimeRelation{
+			AnimeID: relations.AnimeID,
+			Type:    ReverseAnimeRelation(relation.Type),
+		})
+		reverse.Unlock()
+
+		changed = append(changed, reverse)
+	}
+
+	return changed
+}
+
 // GetAnimeRelations ...
 func GetAnimeRelations(animeID AnimeID) (*AnimeRelations, error) {
 	obj, err := DB.Get("AnimeRelations", animeID)
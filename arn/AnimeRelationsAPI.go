@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"strings"
 
 	"github.com/aerogo/aero"
 	"github.com/aerogo/api"
@@ -16,6 +17,30 @@ var (
 	_ api.ArrayEventListener = (*AnimeRelations)(nil)
 )
 
+// Actions
+func init() {
+	API.RegisterActions("AnimeRelations", []*api.Action{
+		// Add reverse relations
+		{
+			Name:  "reverse",
+			Route: "/reverse",
+			Run: func(obj interface{}, ctx aero.Context) error {
+				relations := obj.(*AnimeRelations)
+				user := GetUserFromContext(ctx)
+
+				for _, reverse := range relations.AddReverse() {
+					index := len(reverse.Items) - 1
+					logEntry := NewEditLogEntry(user.ID, "arrayAppend", "AnimeRelations", reverse.AnimeID, fmt.Sprintf("Items[%d]", index), "", fmt.Sprint(*reverse.Items[index]))
+					logEntry.Save()
+					reverse.Save()
+				}
+
+				return nil
+			},
+		},
+	})
+}
+
 // Authorize returns an error if the given API POST request is not authorized.
 func (relations *AnimeRelations) Authorize(ctx aero.Context, action string) error {
 	user := GetUserFromContext(ctx)
@@ -27,8 +52,35 @@ func (relations *AnimeRelations) Authorize(ctx aero.Context, action string) erro
 	return nil
 }
 
-// Edit creates an edit log entry.
+// Edit validates the related anime and creates an edit log entry.
 func (relations *AnimeRelations) Edit(ctx aero.Context, key string, value reflect.Value, newValue reflect.Value) (consumed bool, err error) {
+	if strings.HasPrefix(key, "Items[") && strings.HasSuffix(key, ".AnimeID") {
+		animeID := strings.TrimSpace(newValue.String())
+
+		if animeID == relations.AnimeID {
+			return true, errors.New("An anime can't be related to itself")
+		}
+
+		if animeID != "" && !DB.Exists("Anime", animeID) {
+			return true, errors.New("Anime does not exist")
+		}
+	}
+
+	if strings.HasPrefix(key, "Items[") && strings.HasSuffix(key, ".Type") {
+		found := false
+
+		for _, option := range DataLists["anime-relation-types"] {
+			if option.Value == newValue.String() {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return true, errors.New("Invalid relation type")
+		}
+	}
+
 	return edit(relations, ctx, key, value, newValue)
 }
 
@@ -37,6 +37,20 @@ func SortAnimeByPopularity(animes []*Anime) {
 	})
 }
 
+// SortAnimeByRating sorts the given slice of anime by their average overall rating.
+func SortAnimeByRating(animes []*Anime) {
+	sort.Slice(animes, func(i, j int) bool {
+		aRating := animes[i].Rating.Overall
+		bRating := animes[j].Rating.Overall
+
+		if aRating == bRating {
+			return animes[i].Title.Canonical < animes[j].Title.Canonical
+		}
+
+		return aRating > bRating
+	})
+}
+
 // SortAnimeByQuality sorts the given slice of anime by quality.
 func SortAnimeByQuality(animes []*Anime) {
 	SortAnimeByQualityDetailed(animes, "")
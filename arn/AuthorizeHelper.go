@@ -15,9 +15,7 @@ func AuthorizeIfLoggedInAndOwnData(ctx aero.Context, userIDParameterName string)
 		return err
 	}
 
-	userID := ctx.Session().Get("userId").(string)
-
-	if userID != ctx.Get(userIDParameterName) {
+	if GetUserFromContext(ctx).ID != ctx.Get(userIDParameterName) {
 		return errors.New("Can not modify data from other users")
 	}
 
@@ -30,9 +28,7 @@ func AuthorizeIfLoggedIn(ctx aero.Context) error {
 		return errors.New("Neither logged in nor in session")
 	}
 
-	userID, ok := ctx.Session().Get("userId").(string)
-
-	if !ok || userID == "" {
+	if GetUserFromContext(ctx) == nil {
 		return errors.New("Not logged in")
 	}
 
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"sort"
+	"time"
 
 	"github.com/aerogo/nano"
 )
@@ -13,12 +14,14 @@ type CharacterID = ID
 
 // Character represents an anime or manga character.
 type Character struct {
-	Name        CharacterName         `json:"name" editable:"true"`
-	Image       Image                 `json:"image"`
-	MainQuoteID QuoteID               `json:"mainQuoteId" editable:"true"`
-	Description string                `json:"description" editable:"true" type:"textarea"`
-	Spoilers    []Spoiler             `json:"spoilers" editable:"true"`
-	Attributes  []*CharacterAttribute `json:"attributes" editable:"true"`
+	Name        CharacterName          `json:"name" editable:"true"`
+	Image       Image                  `json:"image"`
+	MainQuoteID QuoteID                `json:"mainQuoteId" editable:"true"`
+	Description string                 `json:"description" editable:"true" type:"textarea"`
+	Spoilers    []Spoiler              `json:"spoilers" editable:"true"`
+	Attributes  []*CharacterAttribute  `json:"attributes" editable:"true"`
+	VoiceActors []*CharacterVoiceActor `json:"voiceActors" editable:"true"`
+	LikeDates   map[UserID]string      `json:"likeDates"`
 
 	hasID
 	hasPosts
@@ -66,6 +69,40 @@ func (character *Character) Self() Loggable {
 	return character
 }
 
+// OnLike is called when the character receives a like.
+func (character *Character) OnLike(likedBy *User) {
+	if character.LikeDates == nil {
+		character.LikeDates = map[UserID]string{}
+	}
+
+	// Forget the dates of users who removed their like
+	for userID := range character.LikeDates {
+		if !character.LikedBy(userID) {
+			delete(character.LikeDates, userID)
+		}
+	}
+
+	character.LikeDates[likedBy.ID] = DateTimeUTC()
+}
+
+// CountLikesSince returns the number of likes the character received after the given date.
+// Likes from before like dates were recorded are not counted.
+func (character *Character) CountLikesSince(since time.Time) int {
+	count := 0
+
+	for userID, date := range character.LikeDates {
+		likeTime, err := time.Parse(time.RFC3339, date)
+
+		if err != nil || likeTime.Before(since) || !character.LikedBy(userID) {
+			continue
+		}
+
+		count++
+	}
+
+	return count
+}
+
 // MainQuote ...
 func (character *Character) MainQuote() *Quote {
 	quote, _ := GetQuote(character.MainQuoteID)
@@ -181,6 +218,14 @@ func (character *Character) Merge(target *Character) {
 	for _, userID := range character.Likes {
 		if !Contains(target.Likes, userID) {
 			target.Likes = append(target.Likes, userID)
+
+			if date, exists := character.LikeDates[userID]; exists {
+				if target.LikeDates == nil {
+					target.LikeDates = map[UserID]string{}
+				}
+
+				target.LikeDates[userID] = date
+			}
 		}
 	}
 
@@ -201,7 +246,7 @@ func (character *Character) DeleteImages() {
 // Quotes returns the list of quotes for this character.
 func (character *Character) Quotes() []*Quote {
 	return FilterQuotes(func(quote *Quote) bool {
-		return !quote.IsDraft && quote.CharacterID == character.ID
+		return quote.IsPublic() && quote.CharacterID == character.ID
 	})
 }
 
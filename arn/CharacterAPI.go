@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"strings"
 
 	"github.com/aerogo/aero"
 	"github.com/aerogo/api"
@@ -11,13 +12,14 @@ import (
 
 // Force interface implementations
 var (
-	_ Likeable      = (*Character)(nil)
-	_ Publishable   = (*Character)(nil)
-	_ PostParent    = (*Character)(nil)
-	_ fmt.Stringer  = (*Character)(nil)
-	_ api.Newable   = (*Character)(nil)
-	_ api.Editable  = (*Character)(nil)
-	_ api.Deletable = (*Character)(nil)
+	_ Likeable          = (*Character)(nil)
+	_ LikeEventReceiver = (*Character)(nil)
+	_ Publishable       = (*Character)(nil)
+	_ PostParent        = (*Character)(nil)
+	_ fmt.Stringer      = (*Character)(nil)
+	_ api.Newable       = (*Character)(nil)
+	_ api.Editable      = (*Character)(nil)
+	_ api.Deletable     = (*Character)(nil)
 )
 
 // Actions
@@ -73,11 +75,32 @@ func (character *Character) Authorize(ctx aero.Context, action string) error {
 		return errors.New("Insufficient permissions")
 	}
 
-	return nil
+	return authorizeArrayEdit(ctx, character, user)
 }
 
 // Edit creates an edit log entry.
 func (character *Character) Edit(ctx aero.Context, key string, value reflect.Value, newValue reflect.Value) (consumed bool, err error) {
+	if strings.HasPrefix(key, "VoiceActors[") {
+		switch {
+		case strings.HasSuffix(key, ".PersonID") && newValue.String() != "":
+			_, err := GetPerson(newValue.String())
+
+			if err != nil {
+				return true, errors.New("Person not found")
+			}
+
+		case strings.HasSuffix(key, ".Language") && !IsVoiceLanguage(newValue.String()):
+			return true, errors.New("Invalid voice language")
+
+		case strings.HasSuffix(key, ".AnimeID") && newValue.String() != "":
+			_, err := GetAnime(newValue.String())
+
+			if err != nil {
+				return true, errors.New("Anime not found")
+			}
+		}
+	}
+
 	return edit(character, ctx, key, value, newValue)
 }
 
@@ -1,15 +1,14 @@
 package arn
 
 import (
-	"bytes"
 	"fmt"
-	"image"
 	"net/http"
 	"path"
 	"time"
 
 	"github.com/aerogo/http/client"
 	"github.com/akyoto/imageserver"
+	"github.com/animenotifier/notify.moe/arn/imageupload"
 )
 
 const (
@@ -36,6 +35,9 @@ const (
 	// CharacterImageWebPQuality is the WebP quality of character images.
 	CharacterImageWebPQuality = 70
 
+	// CharacterImageAVIFQuality is the AVIF quality of character images.
+	CharacterImageAVIFQuality = 55
+
 	// CharacterImageJPEGQuality is the JPEG quality of character images.
 	CharacterImageJPEGQuality = 70
 
@@ -94,6 +96,14 @@ var characterImageOutputs = []imageserver.Output{
 		Quality:   CharacterImageWebPQuality + CharacterImageQualityBonusLowDPI + CharacterImageQualityBonusLarge,
 	},
 
+	// AVIF - Large
+	&imageupload.AVIFFile{
+		Directory: path.Join(Root, "images/characters/large/"),
+		Width:     CharacterImageLargeWidth,
+		Height:    CharacterImageLargeHeight,
+		Quality:   CharacterImageAVIFQuality + CharacterImageQualityBonusLowDPI + CharacterImageQualityBonusLarge,
+	},
+
 	// WebP - Medium
 	&imageserver.WebPFile{
 		Directory: path.Join(Root, "images/characters/medium/"),
@@ -102,6 +112,14 @@ var characterImageOutputs = []imageserver.Output{
 		Quality:   CharacterImageWebPQuality + CharacterImageQualityBonusLowDPI + CharacterImageQualityBonusMedium,
 	},
 
+	// AVIF - Medium
+	&imageupload.AVIFFile{
+		Directory: path.Join(Root, "images/characters/medium/"),
+		Width:     CharacterImageMediumWidth,
+		Height:    CharacterImageMediumHeight,
+		Quality:   CharacterImageAVIFQuality + CharacterImageQualityBonusLowDPI + CharacterImageQualityBonusMedium,
+	},
+
 	// WebP - Small
 	&imageserver.WebPFile{
 		Directory: path.Join(Root, "images/characters/small/"),
@@ -109,6 +127,14 @@ var characterImageOutputs = []imageserver.Output{
 		Height:    CharacterImageSmallHeight,
 		Quality:   CharacterImageWebPQuality + CharacterImageQualityBonusLowDPI + CharacterImageQualityBonusSmall,
 	},
+
+	// AVIF - Small
+	&imageupload.AVIFFile{
+		Directory: path.Join(Root, "images/characters/small/"),
+		Width:     CharacterImageSmallWidth,
+		Height:    CharacterImageSmallHeight,
+		Quality:   CharacterImageAVIFQuality + CharacterImageQualityBonusLowDPI + CharacterImageQualityBonusSmall,
+	},
 }
 
 // Define the high DPI character image outputs
@@ -139,6 +165,14 @@ var characterImageOutputsHighDPI = []imageserver.Output{
 		Quality:   CharacterImageWebPQuality + CharacterImageQualityBonusMedium,
 	},
 
+	// AVIF - Medium
+	&imageupload.AVIFFile{
+		Directory: path.Join(Root, "images/characters/medium/"),
+		Width:     CharacterImageMediumWidth * 2,
+		Height:    CharacterImageMediumHeight * 2,
+		Quality:   CharacterImageAVIFQuality + CharacterImageQualityBonusMedium,
+	},
+
 	// WebP - Small
 	&imageserver.WebPFile{
 		Directory: path.Join(Root, "images/characters/small/"),
@@ -146,52 +180,47 @@ var characterImageOutputsHighDPI = []imageserver.Output{
 		Height:    CharacterImageSmallHeight * 2,
 		Quality:   CharacterImageWebPQuality + CharacterImageQualityBonusSmall,
 	},
+
+	// AVIF - Small
+	&imageupload.AVIFFile{
+		Directory: path.Join(Root, "images/characters/small/"),
+		Width:     CharacterImageSmallWidth * 2,
+		Height:    CharacterImageSmallHeight * 2,
+		Quality:   CharacterImageAVIFQuality + CharacterImageQualityBonusSmall,
+	},
 }
 
 // SetImageBytes accepts a byte buffer that represents an image file and updates the character image.
 func (character *Character) SetImageBytes(data []byte) error {
-	// Decode
-	img, format, err := image.Decode(bytes.NewReader(data))
+	metaImage, err := imageupload.Decode(data)
 
 	if err != nil {
 		return err
 	}
 
-	return character.SetImage(&imageserver.MetaImage{
-		Image:  img,
-		Format: format,
-		Data:   data,
-	})
+	return character.SetImage(metaImage)
 }
 
 // SetImage sets the character image to the given MetaImage.
 func (character *Character) SetImage(metaImage *imageserver.MetaImage) error {
-	var lastError error
-
 	// Save the different image formats and sizes in low DPI
-	for _, output := range characterImageOutputs {
-		err := output.Save(metaImage, character.ID)
-
-		if err != nil {
-			lastError = err
-		}
-	}
+	lowDPIAVIF, lowDPIError := imageupload.Save(metaImage, character.ID, characterImageOutputs)
 
 	// Save the different image formats and sizes in high DPI
-	for _, output := range characterImageOutputsHighDPI {
-		err := output.Save(metaImage, character.ID+"@2")
-
-		if err != nil {
-			lastError = err
-		}
-	}
+	highDPIAVIF, highDPIError := imageupload.Save(metaImage, character.ID+"@2", characterImageOutputsHighDPI)
 
 	character.Image.Extension = metaImage.Extension()
 	character.Image.Width = metaImage.Image.Bounds().Dx()
 	character.Image.Height = metaImage.Image.Bounds().Dy()
 	character.Image.AverageColor = GetAverageColor(metaImage.Image)
 	character.Image.LastModified = time.Now().Unix()
-	return lastError
+	character.Image.AVIF = lowDPIAVIF && highDPIAVIF
+
+	if highDPIError != nil {
+		return highDPIError
+	}
+
+	return lowDPIError
 }
 
 // DownloadImage ...
@@ -214,3 +243,12 @@ func (character *Character) DownloadImage(url string) error {
 func (character *Character) HasImage() bool {
 	return character.Image.Extension != "" && character.Image.Width > 0
 }
+
+// ImageVariants returns the available sizes of the character image.
+func (character *Character) ImageVariants() []*ImageVariant {
+	return []*ImageVariant{
+		{Link: character.ImageLink("small"), Width: CharacterImageSmallWidth, Height: CharacterImageSmallHeight},
+		{Link: character.ImageLink("medium"), Width: CharacterImageMediumWidth, Height: CharacterImageMediumHeight},
+		{Link: character.ImageLink("large"), Width: CharacterImageLargeWidth, Height: CharacterImageLargeHeight},
+	}
+}
@@ -0,0 +1,186 @@
+package arn
+
+import "sort"
+
+// Register a list of supported voice acting languages.
+func init() {
+	DataLists["voice-languages"] = []*Option{
+		{"japanese", "Japanese"},
+		{"english", "English"},
+		{"chinese", "Chinese"},
+		{"french", "French"},
+		{"german", "German"},
+		{"italian", "Italian"},
+		{"korean", "Korean"},
+		{"portuguese", "Portuguese"},
+		{"spanish", "Spanish"},
+	}
+}
+
+// CharacterVoiceActor is a person voicing a character in a specific language.
+// If AnimeID is empty the person voices the character in all of the character's anime.
+type CharacterVoiceActor struct {
+	PersonID ID      `json:"personId" editable:"true"`
+	Language string  `json:"language" editable:"true" datalist:"voice-languages"`
+	AnimeID  AnimeID `json:"animeId" editable:"true" tooltip:"Only needed if the character has a different voice actor in this anime"`
+}
+
+// PersonRole is a character voiced by a person.
+type PersonRole struct {
+	Character *Character
+	Language  string
+	Anime     []*Anime
+}
+
+// Person returns the referenced person.
+func (voiceActor *CharacterVoiceActor) Person() *Person {
+	person, _ := GetPerson(voiceActor.PersonID)
+	return person
+}
+
+// LanguageName returns the display name of the language.
+func (voiceActor *CharacterVoiceActor) LanguageName() string {
+	return VoiceLanguageName(voiceActor.Language)
+}
+
+// AppliesTo tells you whether the voice actor voices the character in the given anime.
+func (voiceActor *CharacterVoiceActor) AppliesTo(animeID AnimeID) bool {
+	return voiceActor.AnimeID == "" || voiceActor.AnimeID == animeID
+}
+
+// IsVoiceLanguage tells you whether the given language is supported for voice actors.
+func IsVoiceLanguage(language string) bool {
+	for _, option := range DataLists["voice-languages"] {
+		if option.Value == language {
+			return true
+		}
+	}
+
+	return false
+}
+
+// VoiceLanguageName returns the display name of the given voice language.
+func VoiceLanguageName(language string) string {
+	for _, option := range DataLists["voice-languages"] {
+		if option.Value == language {
+			return option.Label
+		}
+	}
+
+	return language
+}
+
+// VoiceActorsIn returns the voice actors of the character in the given anime.
+// Voice actors assigned to a specific anime replace the general ones of the same language.
+func (character *Character) VoiceActorsIn(animeID AnimeID) []*CharacterVoiceActor {
+	specific := map[string]bool{}
+
+	for _, voiceActor := range character.VoiceActors {
+		if voiceActor.AnimeID != "" && voiceActor.AnimeID == animeID {
+			specific[voiceActor.Language] = true
+		}
+	}
+
+	var results []*CharacterVoiceActor
+
+	for _, voiceActor := range character.VoiceActors {
+		if !voiceActor.AppliesTo(animeID) {
+			continue
+		}
+
+		if voiceActor.AnimeID == "" && specific[voiceActor.Language] {
+			continue
+		}
+
+		results = append(results, voiceActor)
+	}
+
+	return results
+}
+
+// Roles returns the characters voiced by the person together with the anime they appear in.
+func (person *Person) Roles() []*PersonRole {
+	var roles []*PersonRole
+	rolesByCharacter := map[CharacterID][]*PersonRole{}
+	general := map[*PersonRole]bool{}
+
+	for character := range StreamCharacters() {
+		for _, voiceActor := range character.VoiceActors {
+			if voiceActor.PersonID != person.ID {
+				continue
+			}
+
+			role := &PersonRole{
+				Character: character,
+				Language:  voiceActor.Language,
+			}
+
+			if voiceActor.AnimeID == "" {
+				general[role] = true
+			} else {
+				anime, err := GetAnime(voiceActor.AnimeID)
+
+				if err == nil {
+					role.Anime = append(role.Anime, anime)
+				}
+			}
+
+			roles = append(roles, role)
+			rolesByCharacter[character.ID] = append(rolesByCharacter[character.ID], role)
+		}
+	}
+
+	if len(general) > 0 {
+		for animeCharacters := range StreamAnimeCharacters() {
+			for _, animeCharacter := range animeCharacters.Items {
+				characterRoles := rolesByCharacter[animeCharacter.CharacterID]
+
+				if len(characterRoles) == 0 {
+					continue
+				}
+
+				anime, err := GetAnime(animeCharacters.AnimeID)
+
+				if err != nil {
+					continue
+				}
+
+				for _, role := range characterRoles {
+					if !general[role] || role.Character.voicedBySomeoneElseIn(person.ID, role.Language, anime.ID) {
+						continue
+					}
+
+					role.Anime = append(role.Anime, anime)
+				}
+			}
+		}
+	}
+
+	for _, role := range roles {
+		sort.Slice(role.Anime, func(i, j int) bool {
+			return role.Anime[i].StartDate < role.Anime[j].StartDate
+		})
+	}
+
+	sort.Slice(roles, func(i, j int) bool {
+		if roles[i].Character.Name.Canonical == roles[j].Character.Name.Canonical {
+			return roles[i].Language < roles[j].Language
+		}
+
+		return roles[i].Character.Name.Canonical < roles[j].Character.Name.Canonical
+	})
+
+	return roles
+}
+
+// voicedBySomeoneElseIn tells you whether a different person has been assigned
+// to the character in the given anime and language.
+func (character *Character) voicedBySomeoneElseIn(personID ID, language string, animeID AnimeID) bool {
+	for _, voiceActor := range character.VoiceActors {
+		if voiceActor.AnimeID == animeID && voiceActor.Language == language && voiceActor.PersonID != personID {
+			return true
+		}
+	}
+
+	return false
+}
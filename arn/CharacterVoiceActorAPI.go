@@ -0,0 +1,17 @@
+package arn
+
+import (
+	"github.com/aerogo/aero"
+	"github.com/aerogo/api"
+)
+
+// Force interface implementations
+var (
+	_ api.Creatable = (*CharacterVoiceActor)(nil)
+)
+
+// Create sets the data for new voice actors.
+func (voiceActor *CharacterVoiceActor) Create(ctx aero.Context) error {
+	voiceActor.Language = "japanese"
+	return nil
+}
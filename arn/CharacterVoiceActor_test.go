@@ -0,0 +1,34 @@
+package arn_test
+
+import (
+	"testing"
+
+	"github.com/akyoto/assert"
+	"github.com/animenotifier/notify.moe/arn"
+)
+
+func TestCharacterVoiceActorsIn(t *testing.T) {
+	character := arn.NewCharacter()
+	character.VoiceActors = []*arn.CharacterVoiceActor{
+		{PersonID: "a", Language: "japanese"},
+		{PersonID: "b", Language: "english"},
+		{PersonID: "c", Language: "english", AnimeID: "movie"},
+	}
+
+	series := character.VoiceActorsIn("series")
+	assert.Equal(t, len(series), 2)
+	assert.Equal(t, series[0].PersonID, "a")
+	assert.Equal(t, series[1].PersonID, "b")
+
+	movie := character.VoiceActorsIn("movie")
+	assert.Equal(t, len(movie), 2)
+	assert.Equal(t, movie[0].PersonID, "a")
+	assert.Equal(t, movie[1].PersonID, "c")
+}
+
+func TestVoiceLanguage(t *testing.T) {
+	assert.True(t, arn.IsVoiceLanguage("japanese"))
+	assert.False(t, arn.IsVoiceLanguage("klingon"))
+	assert.Equal(t, arn.VoiceLanguageName("english"), "English")
+	assert.Equal(t, arn.VoiceLanguageName("klingon"), "klingon")
+}
@@ -0,0 +1,322 @@
+package arn
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aerogo/aero"
+	"github.com/aerogo/nano"
+	shortid "github.com/ventu-io/go-shortid"
+)
+
+// Conversation is a private exchange of messages between two or more users.
+type Conversation struct {
+	Subject        string                 `json:"subject"`
+	ParticipantIDs []UserID               `json:"participants"`
+	Messages       []*ConversationMessage `json:"messages"`
+	LastRead       map[UserID]string      `json:"lastRead"`
+
+	hasID
+	hasCreator
+	sync.Mutex
+}
+
+// ConversationMessage is a single message in a conversation.
+type ConversationMessage struct {
+	ID        string `json:"id"`
+	Text      string `json:"text"`
+	CreatedBy UserID `json:"createdBy"`
+	Created   string `json:"created"`
+}
+
+// Creator returns the user who wrote the message.
+func (message *ConversationMessage) Creator() *User {
+	user, _ := GetUser(message.CreatedBy)
+	return user
+}
+
+// HTML returns the HTML representation of the message.
+func (message *ConversationMessage) HTML() string {
+	return RenderMarkdownWithFootnotes(message.Text, "message-"+message.ID+"-")
+}
+
+// Link returns the URI to the conversation page.
+func (conversation *Conversation) Link() string {
+	return "/conversation/" + conversation.ID
+}
+
+// TitleByUser returns the subject or, if it's empty, the names of the other participants.
+func (conversation *Conversation) TitleByUser(user *User) string {
+	if conversation.Subject != "" {
+		return conversation.Subject
+	}
+
+	nicks := []string{}
+
+	for _, participant := range conversation.Participants() {
+		if user != nil && participant.ID == user.ID {
+			continue
+		}
+
+		nicks = append(nicks, participant.Nick)
+	}
+
+	if len(nicks) == 0 {
+		return "untitled"
+	}
+
+	return strings.Join(nicks, ", ")
+}
+
+// HasParticipant tells you whether the user takes part in the conversation.
+func (conversation *Conversation) HasParticipant(userID UserID) bool {
+	conversation.Lock()
+	defer conversation.Unlock()
+
+	return Contains(conversation.ParticipantIDs, userID)
+}
+
+// Participants returns all users taking part in the conversation.
+func (conversation *Conversation) Participants() []*User {
+	objects := DB.GetMany("User", conversation.ParticipantIDs)
+	participants := make([]*User, 0, len(objects))
+
+	for _, obj := range objects {
+		if obj == nil {
+			continue
+		}
+
+		participants = append(participants, obj.(*User))
+	}
+
+	return participants
+}
+
+// IsGroup tells you whether more than two users take part in the conversation.
+func (conversation *Conversation) IsGroup() bool {
+	return len(conversation.ParticipantIDs) > 2
+}
+
+// AddMessage appends a new message by the given user.
+func (conversation *Conversation) AddMessage(userID UserID, text string) *ConversationMessage {
+	id, _ := shortid.Generate()
+
+	message := &ConversationMessage{
+		ID:        id,
+		Text:      text,
+		CreatedBy: userID,
+		Created:   DateTimeUTC(),
+	}
+
+	conversation.Lock()
+	defer conversation.Unlock()
+
+	conversation.Messages = append(conversation.Messages, message)
+	conversation.markAsRead(userID)
+	return message
+}
+
+// LastMessage returns the latest message or nil if there are no messages.
+func (conversation *Conversation) LastMessage() *ConversationMessage {
+	conversation.Lock()
+	defer conversation.Unlock()
+
+	return conversation.lastMessage()
+}
+
+// lastMessage returns the latest message and expects the conversation to be locked.
+func (conversation *Conversation) lastMessage() *ConversationMessage {
+	if len(conversation.Messages) == 0 {
+		return nil
+	}
+
+	return conversation.Messages[len(conversation.Messages)-1]
+}
+
+// Updated returns the date of the latest message.
+func (conversation *Conversation) Updated() string {
+	lastMessage := conversation.LastMessage()
+
+	if lastMessage == nil {
+		return conversation.Created
+	}
+
+	return lastMessage.Created
+}
+
+// IsUnreadBy tells you whether the user hasn't read the latest message of somebody else yet.
+func (conversation *Conversation) IsUnreadBy(userID UserID) bool {
+	conversation.Lock()
+	defer conversation.Unlock()
+
+	lastMessage := conversation.lastMessage()
+
+	if lastMessage == nil || lastMessage.CreatedBy == userID {
+		return false
+	}
+
+	return conversation.LastRead[userID] < lastMessage.Created
+}
+
+// MarkAsRead remembers that the user has read all messages up to now.
+func (conversation *Conversation) MarkAsRead(userID UserID) {
+	conversation.Lock()
+	defer conversation.Unlock()
+
+	conversation.markAsRead(userID)
+}
+
+// markAsRead updates the read time and expects the conversation to be locked.
+func (conversation *Conversation) markAsRead(userID UserID) {
+	if conversation.LastRead == nil {
+		conversation.LastRead = map[UserID]string{}
+	}
+
+	conversation.LastRead[userID] = DateTimeUTC()
+}
+
+// RemoveParticipant removes the user and all of their messages from the conversation.
+func (conversation *Conversation) RemoveParticipant(userID UserID) {
+	conversation.Lock()
+	defer conversation.Unlock()
+
+	participantIDs := conversation.ParticipantIDs[:0]
+
+	for _, participantID := range conversation.ParticipantIDs {
+		if participantID != userID {
+			participantIDs = append(participantIDs, participantID)
+		}
+	}
+
+	messages := conversation.Messages[:0]
+
+	for _, message := range conversation.Messages {
+		if message.CreatedBy != userID {
+			messages = append(messages, message)
+		}
+	}
+
+	conversation.ParticipantIDs = participantIDs
+	conversation.Messages = messages
+	delete(conversation.LastRead, userID)
+}
+
+// String implements the default string serialization.
+func (conversation *Conversation) String() string {
+	return conversation.TitleByUser(nil)
+}
+
+// TypeName returns the type name.
+func (conversation *Conversation) TypeName() string {
+	return "Conversation"
+}
+
+// GetConversation ...
+func GetConversation(id ID) (*Conversation, error) {
+	obj, err := DB.Get("Conversation", id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return obj.(*Conversation), nil
+}
+
+// StreamConversations returns a stream of all conversations.
+func StreamConversations() <-chan *Conversation {
+	channel := make(chan *Conversation, nano.ChannelBufferSize)
+
+	go func() {
+		for obj := range DB.All("Conversation") {
+			channel <- obj.(*Conversation)
+		}
+
+		close(channel)
+	}()
+
+	return channel
+}
+
+// FilterConversations filters all conversations by a custom function.
+func FilterConversations(filter func(*Conversation) bool) []*Conversation {
+	var filtered []*Conversation
+
+	for obj := range StreamConversations() {
+		if filter(obj) {
+			filtered = append(filtered, obj)
+		}
+	}
+
+	return filtered
+}
+
+// GetConversationsByUser returns all conversations the user takes part in.
+func GetConversationsByUser(userID UserID) []*Conversation {
+	objects := DB.GetMany("Conversation", conversationIDsByUser(userID))
+	conversations := make([]*Conversation, 0, len(objects))
+
+	for _, obj := range objects {
+		if obj == nil {
+			continue
+		}
+
+		conversation := obj.(*Conversation)
+
+		// The index isn't updated when somebody leaves a conversation
+		if conversation.HasParticipant(userID) {
+			conversations = append(conversations, conversation)
+		}
+	}
+
+	return conversations
+}
+
+// CountUnreadConversations returns the number of conversations with messages the user hasn't read yet.
+func CountUnreadConversations(userID UserID) int {
+	unread := 0
+
+	for _, conversation := range GetConversationsByUser(userID) {
+		if conversation.IsUnreadBy(userID) {
+			unread++
+		}
+	}
+
+	return unread
+}
+
+// CountMessagesSince returns the number of messages the user sent after the given time.
+func CountMessagesSince(userID UserID, since time.Time) int {
+	sinceText := since.UTC().Format(time.RFC3339)
+	count := 0
+
+	for _, conversation := range GetConversationsByUser(userID) {
+		conversation.Lock()
+
+		for _, message := range conversation.Messages {
+			if message.CreatedBy == userID && message.Created > sinceText {
+				count++
+			}
+		}
+
+		conversation.Unlock()
+	}
+
+	return count
+}
+
+// SortConversationsLatestFirst puts the conversations with the latest messages on top.
+func SortConversationsLatestFirst(conversations []*Conversation) {
+	sort.Slice(conversations, func(i, j int) bool {
+		return conversations[i].Updated() > conversations[j].Updated()
+	})
+}
+
+// BroadcastUnreadConversations sends the number of unread conversations to the user's open tabs.
+func BroadcastUnreadConversations(user *User) {
+	user.BroadcastEvent(&aero.Event{
+		Name: "messageCount",
+		Data: CountUnreadConversations(user.ID),
+	})
+}
@@ -0,0 +1,260 @@
+package arn
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aerogo/aero"
+	"github.com/aerogo/api"
+	"github.com/animenotifier/notify.moe/arn/autocorrect"
+	"github.com/animenotifier/notify.moe/arn/limits"
+)
+
+// Force interface implementations
+var (
+	_ fmt.Stringer   = (*Conversation)(nil)
+	_ api.Newable    = (*Conversation)(nil)
+	_ api.Actionable = (*Conversation)(nil)
+	_ api.Filter     = (*Conversation)(nil)
+)
+
+// Actions
+func init() {
+	API.RegisterActions("Conversation", []*api.Action{
+		// Reply
+		ReplyConversationAction(),
+
+		// Leave
+		LeaveConversationAction(),
+	})
+}
+
+// Authorize returns an error if the given API request is not authorized.
+func (conversation *Conversation) Authorize(ctx aero.Context, action string) error {
+	user := GetUserFromContext(ctx)
+
+	if user == nil {
+		return errors.New("Not logged in")
+	}
+
+	if action != "create" && !conversation.HasParticipant(user.ID) {
+		return errors.New("You're not taking part in this conversation")
+	}
+
+	return nil
+}
+
+// Create sets the data for a new conversation with data we received from the API request.
+func (conversation *Conversation) Create(ctx aero.Context) error {
+	data, err := ctx.Request().Body().JSONObject()
+
+	if err != nil {
+		return err
+	}
+
+	user := GetUserFromContext(ctx)
+
+	if user == nil {
+		return errors.New("Not logged in")
+	}
+
+	conversation.ID = GenerateID("Conversation")
+	conversation.Subject, _ = data["subject"].(string)
+	conversation.Subject = strings.TrimSpace(conversation.Subject)
+	conversation.ParticipantIDs = []UserID{user.ID}
+	conversation.CreatedBy = user.ID
+	conversation.Created = DateTimeUTC()
+
+	if len(conversation.Subject) > limits.DefaultTextMaxLength {
+		return errors.New("Subject too long")
+	}
+
+	// Recipients
+	recipients, _ := data["recipients"].([]interface{})
+
+	for _, recipient := range recipients {
+		nick, _ := recipient.(string)
+		recipientUser, err := GetVisibleUserByNick(strings.TrimSpace(nick))
+
+		if err != nil {
+			return errors.New("User not found: " + nick)
+		}
+
+		if conversation.HasParticipant(recipientUser.ID) {
+			continue
+		}
+
+		if recipientUser.IsBlocking(user.ID) {
+			return errors.New("You can't send messages to " + recipientUser.Nick)
+		}
+
+		conversation.ParticipantIDs = append(conversation.ParticipantIDs, recipientUser.ID)
+	}
+
+	if len(conversation.ParticipantIDs) < 2 {
+		return errors.New("Please add at least one recipient")
+	}
+
+	if len(conversation.ParticipantIDs) > limits.ConversationParticipants {
+		return fmt.Errorf("A conversation can't have more than %d participants", limits.ConversationParticipants)
+	}
+
+	// First message
+	text, _ := data["text"].(string)
+	message, err := conversation.addMessageBy(user, text)
+
+	if err != nil {
+		return err
+	}
+
+	go conversation.NotifyParticipants(message)
+	return nil
+}
+
+// addMessageBy validates the text and adds it as a new message by the user.
+func (conversation *Conversation) addMessageBy(user *User, text string) (*ConversationMessage, error) {
+	text = autocorrect.PostText(text)
+
+	if len(text) < limits.PostMinCharacters {
+		return nil, fmt.Errorf("Text too short: Should be at least %d characters", limits.PostMinCharacters)
+	}
+
+	if len(text) > limits.DefaultTextAreaMaxLength {
+		return nil, errors.New("Text too long")
+	}
+
+	// Prevent spam
+	if CountMessagesSince(user.ID, time.Now().Add(-time.Hour)) >= limits.MessagesPerHour {
+		return nil, fmt.Errorf("You can't send more than %d messages per hour", limits.MessagesPerHour)
+	}
+
+	return conversation.AddMessage(user.ID, text), nil
+}
+
+// NotifyParticipants sends a notification about the new message to all other participants.
+// Participants who blocked the author are not notified.
+func (conversation *Conversation) NotifyParticipants(message *ConversationMessage) {
+	author := message.Creator()
+
+	for _, participant := range conversation.Participants() {
+		if participant.ID == author.ID || participant.IsBlocking(author.ID) {
+			continue
+		}
+
+		title := author.Nick + " sent you a message"
+
+		if conversation.IsGroup() {
+			title = fmt.Sprintf(`%s wrote in "%s"`, author.Nick, conversation.TitleByUser(participant))
+		}
+
+		participant.SendNotification(&PushNotification{
+			Title:   title,
+			Message: message.Text,
+			Icon:    "https:" + author.AvatarLink("large"),
+			Link:    conversation.Link(),
+			Type:    NotificationTypeMessage,
+		})
+
+		BroadcastUnreadConversations(participant)
+	}
+}
+
+// ReplyConversationAction returns an API action that adds a message to the conversation.
+func ReplyConversationAction() *api.Action {
+	return &api.Action{
+		Name:  "reply",
+		Route: "/reply",
+		Run: func(obj interface{}, ctx aero.Context) error {
+			conversation := obj.(*Conversation)
+			user := GetUserFromContext(ctx)
+			data, err := ctx.Request().Body().JSONObject()
+
+			if err != nil {
+				return err
+			}
+
+			// In a direct conversation, blocking stops the other side from answering
+			if !conversation.IsGroup() {
+				for _, participant := range conversation.Participants() {
+					if participant.ID != user.ID && participant.IsBlocking(user.ID) {
+						return errors.New("You can't send messages to " + participant.Nick)
+					}
+				}
+			}
+
+			text, _ := data["text"].(string)
+			message, err := conversation.addMessageBy(user, text)
+
+			if err != nil {
+				return err
+			}
+
+			conversation.Save()
+			go conversation.NotifyParticipants(message)
+			return nil
+		},
+	}
+}
+
+// LeaveConversationAction returns an API action that removes the user from the conversation.
+func LeaveConversationAction() *api.Action {
+	return &api.Action{
+		Name:  "leave",
+		Route: "/leave",
+		Run: func(obj interface{}, ctx aero.Context) error {
+			conversation := obj.(*Conversation)
+			user := GetUserFromContext(ctx)
+			conversation.Lock()
+
+			for index, participantID := range conversation.ParticipantIDs {
+				if participantID == user.ID {
+					conversation.ParticipantIDs = append(conversation.ParticipantIDs[:index], conversation.ParticipantIDs[index+1:]...)
+					break
+				}
+			}
+
+			delete(conversation.LastRead, user.ID)
+			empty := len(conversation.ParticipantIDs) == 0
+			conversation.Unlock()
+
+			// Nobody can read the messages anymore
+			if empty {
+				DB.Delete("Conversation", conversation.ID)
+				return nil
+			}
+
+			conversation.Save()
+			return nil
+		},
+	}
+}
+
+// ShouldFilter tells whether data needs to be filtered in the given context.
+func (conversation *Conversation) ShouldFilter(ctx aero.Context) bool {
+	user := GetUserFromContext(ctx)
+	return user == nil || !conversation.HasParticipant(user.ID)
+}
+
+// Filter removes the messages and participants.
+func (conversation *Conversation) Filter() {
+	conversation.Lock()
+	defer conversation.Unlock()
+
+	conversation.Subject = ""
+	conversation.ParticipantIDs = nil
+	conversation.Messages = nil
+	conversation.LastRead = nil
+	conversation.CreatedBy = ""
+}
+
+// Save saves the conversation in the database.
+func (conversation *Conversation) Save() {
+	conversation.Lock()
+	DB.Set("Conversation", conversation.ID, conversation)
+	participantIDs := append([]UserID{}, conversation.ParticipantIDs...)
+	conversation.Unlock()
+
+	indexConversation(conversation.ID, participantIDs)
+}
@@ -0,0 +1,58 @@
+package arn
+
+import "sync"
+
+// conversationIndex maps users to the IDs of the conversations they take part in
+// so that the inbox doesn't need to stream every conversation in the database.
+// It is built on first use and extended whenever a conversation is saved.
+// Lookups check the participants again, so users leaving a conversation
+// and deleted conversations don't need to be removed from the index.
+var conversationIndex = struct {
+	sync.Mutex
+	byUser map[UserID][]ID
+}{}
+
+// conversationIDsByUser returns the IDs of all conversations the user might take part in.
+func conversationIDsByUser(userID UserID) []ID {
+	conversationIndex.Lock()
+	defer conversationIndex.Unlock()
+
+	if conversationIndex.byUser == nil {
+		buildConversationIndex()
+	}
+
+	return append([]ID{}, conversationIndex.byUser[userID]...)
+}
+
+// indexConversation adds the conversation to the index of all participants.
+func indexConversation(conversationID ID, participantIDs []UserID) {
+	conversationIndex.Lock()
+	defer conversationIndex.Unlock()
+
+	// The conversation is already in the database when the index is built
+	if conversationIndex.byUser == nil {
+		return
+	}
+
+	for _, participantID := range participantIDs {
+		if !Contains(conversationIndex.byUser[participantID], conversationID) {
+			conversationIndex.byUser[participantID] = append(conversationIndex.byUser[participantID], conversationID)
+		}
+	}
+}
+
+// buildConversationIndex loads the participants of all conversations
+// and expects the index to be locked.
+func buildConversationIndex() {
+	conversationIndex.byUser = map[UserID][]ID{}
+
+	for conversation := range StreamConversations() {
+		conversation.Lock()
+
+		for _, participantID := range conversation.ParticipantIDs {
+			conversationIndex.byUser[participantID] = append(conversationIndex.byUser[participantID], conversation.ID)
+		}
+
+		conversation.Unlock()
+	}
+}
@@ -0,0 +1,73 @@
+package arn_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/akyoto/assert"
+	"github.com/animenotifier/notify.moe/arn"
+)
+
+func TestConversationUnread(t *testing.T) {
+	conversation := &arn.Conversation{
+		ParticipantIDs: []arn.UserID{"sender", "receiver"},
+	}
+
+	assert.True(t, conversation.HasParticipant("receiver"))
+	assert.False(t, conversation.HasParticipant("stranger"))
+	assert.False(t, conversation.IsGroup())
+	assert.False(t, conversation.IsUnreadBy("receiver"))
+
+	message := conversation.AddMessage("sender", "Did you watch the new episode?")
+	assert.Equal(t, conversation.LastMessage(), message)
+	assert.Equal(t, conversation.Updated(), message.Created)
+	assert.False(t, conversation.IsUnreadBy("sender"))
+	assert.True(t, conversation.IsUnreadBy("receiver"))
+
+	conversation.MarkAsRead("receiver")
+	assert.False(t, conversation.IsUnreadBy("receiver"))
+}
+
+func TestConversationRemoveParticipant(t *testing.T) {
+	conversation := &arn.Conversation{
+		ParticipantIDs: []arn.UserID{"sender", "receiver", "friend"},
+	}
+
+	conversation.AddMessage("sender", "Did you watch the new episode?")
+	conversation.AddMessage("receiver", "Not yet, no spoilers please.")
+
+	conversation.RemoveParticipant("sender")
+	assert.False(t, conversation.HasParticipant("sender"))
+	assert.Equal(t, len(conversation.ParticipantIDs), 2)
+	assert.Equal(t, len(conversation.Messages), 1)
+	assert.Equal(t, conversation.LastMessage().CreatedBy, "receiver")
+
+	_, exists := conversation.LastRead["sender"]
+	assert.False(t, exists)
+}
+
+func TestConversationConcurrent(t *testing.T) {
+	conversation := &arn.Conversation{
+		ParticipantIDs: []arn.UserID{"sender", "receiver"},
+	}
+
+	wg := sync.WaitGroup{}
+
+	for i := 0; i < 50; i++ {
+		wg.Add(2)
+
+		go func() {
+			defer wg.Done()
+			conversation.AddMessage("sender", "Are you there?")
+		}()
+
+		go func() {
+			defer wg.Done()
+			conversation.IsUnreadBy("receiver")
+			conversation.MarkAsRead("receiver")
+		}()
+	}
+
+	wg.Wait()
+	assert.Equal(t, len(conversation.Messages), 50)
+}
@@ -0,0 +1,211 @@
+package arn
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/aerogo/nano"
+)
+
+// Custom list visibility
+const (
+	CustomListPublic  = "public"
+	CustomListPrivate = "private"
+)
+
+// maxCustomListItems is the maximum number of anime in a custom list.
+const maxCustomListItems = 500
+
+func init() {
+	DataLists["custom-list-visibility"] = []*Option{
+		{CustomListPrivate, "Only me"},
+		{CustomListPublic, "Everyone"},
+	}
+}
+
+// CustomList is a named list of anime that the user put together in a manual order.
+type CustomList struct {
+	Name        string    `json:"name" editable:"true"`
+	Description string    `json:"description" editable:"true" type:"textarea"`
+	Visibility  string    `json:"visibility" editable:"true" datalist:"custom-list-visibility"`
+	AnimeIDs    []AnimeID `json:"items"`
+
+	hasID
+	hasCreator
+	hasEditor
+}
+
+// NewCustomList creates a new, private custom list.
+func NewCustomList(userID UserID, name string) *CustomList {
+	now := DateTimeUTC()
+
+	return &CustomList{
+		Name:       name,
+		Visibility: CustomListPrivate,
+		AnimeIDs:   []AnimeID{},
+		hasID: hasID{
+			ID: GenerateID("CustomList"),
+		},
+		hasCreator: hasCreator{
+			Created:   now,
+			CreatedBy: userID,
+		},
+		hasEditor: hasEditor{
+			Edited:   now,
+			EditedBy: userID,
+		},
+	}
+}
+
+// Link returns the URI to the list page.
+func (list *CustomList) Link() string {
+	return "/customlist/" + list.ID
+}
+
+// IsPublic tells you whether other users can see the list.
+func (list *CustomList) IsPublic() bool {
+	return list.Visibility == CustomListPublic
+}
+
+// IsVisibleTo tells you whether the user is allowed to see the list.
+func (list *CustomList) IsVisibleTo(user *User) bool {
+	return list.IsPublic() || (user != nil && user.ID == list.CreatedBy)
+}
+
+// Anime returns the anime in the list in their manual order.
+func (list *CustomList) Anime() []*Anime {
+	animes := make([]*Anime, 0, len(list.AnimeIDs))
+
+	for _, obj := range DB.GetMany("Anime", list.AnimeIDs) {
+		if obj == nil {
+			continue
+		}
+
+		animes = append(animes, obj.(*Anime))
+	}
+
+	return animes
+}
+
+// Contains tells you whether the anime is in the list.
+func (list *CustomList) Contains(animeID AnimeID) bool {
+	return list.IndexOf(animeID) != -1
+}
+
+// IndexOf returns the position of the anime in the list or -1 if it isn't in the list.
+func (list *CustomList) IndexOf(animeID AnimeID) int {
+	for index, id := range list.AnimeIDs {
+		if id == animeID {
+			return index
+		}
+	}
+
+	return -1
+}
+
+// Add appends the anime to the end of the list.
+func (list *CustomList) Add(animeID AnimeID) error {
+	if list.Contains(animeID) {
+		return errors.New("The anime is already in this list")
+	}
+
+	if len(list.AnimeIDs) >= maxCustomListItems {
+		return fmt.Errorf("Lists can't have more than %d anime", maxCustomListItems)
+	}
+
+	list.AnimeIDs = append(list.AnimeIDs, animeID)
+	return nil
+}
+
+// Remove removes the anime from the list.
+func (list *CustomList) Remove(animeID AnimeID) bool {
+	index := list.IndexOf(animeID)
+
+	if index == -1 {
+		return false
+	}
+
+	list.AnimeIDs = append(list.AnimeIDs[:index], list.AnimeIDs[index+1:]...)
+	return true
+}
+
+// Move moves the anime at the index "from" to the index "to" and shifts the anime in between.
+func (list *CustomList) Move(from int, to int) error {
+	if from < 0 || from >= len(list.AnimeIDs) || to < 0 || to >= len(list.AnimeIDs) {
+		return errors.New("Invalid position")
+	}
+
+	animeID := list.AnimeIDs[from]
+
+	if from < to {
+		copy(list.AnimeIDs[from:to], list.AnimeIDs[from+1:to+1])
+	} else {
+		copy(list.AnimeIDs[to+1:from+1], list.AnimeIDs[to:from])
+	}
+
+	list.AnimeIDs[to] = animeID
+	return nil
+}
+
+// String implements the default string serialization.
+func (list *CustomList) String() string {
+	return list.Name
+}
+
+// TypeName returns the type name.
+func (list *CustomList) TypeName() string {
+	return "CustomList"
+}
+
+// GetCustomList ...
+func GetCustomList(id ID) (*CustomList, error) {
+	obj, err := DB.Get("CustomList", id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return obj.(*CustomList), nil
+}
+
+// StreamCustomLists returns a stream of all custom lists.
+func StreamCustomLists() <-chan *CustomList {
+	channel := make(chan *CustomList, nano.ChannelBufferSize)
+
+	go func() {
+		for obj := range DB.All("CustomList") {
+			channel <- obj.(*CustomList)
+		}
+
+		close(channel)
+	}()
+
+	return channel
+}
+
+// FilterCustomLists filters all custom lists by a custom function.
+func FilterCustomLists(filter func(*CustomList) bool) []*CustomList {
+	var filtered []*CustomList
+
+	for obj := range StreamCustomLists() {
+		if filter(obj) {
+			filtered = append(filtered, obj)
+		}
+	}
+
+	return filtered
+}
+
+// CustomLists returns the custom lists of the user that the viewer is allowed to see, sorted by name.
+func (user *User) CustomLists(viewer *User) []*CustomList {
+	lists := FilterCustomLists(func(list *CustomList) bool {
+		return list.CreatedBy == user.ID && list.IsVisibleTo(viewer)
+	})
+
+	sort.Slice(lists, func(i, j int) bool {
+		return lists[i].Name < lists[j].Name
+	})
+
+	return lists
+}
@@ -0,0 +1,197 @@
+package arn
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/aerogo/aero"
+	"github.com/aerogo/api"
+)
+
+// maxCustomListNameLength is the maximum number of characters in a list name.
+const maxCustomListNameLength = 60
+
+// Force interface implementations
+var (
+	_ fmt.Stringer       = (*CustomList)(nil)
+	_ api.Newable        = (*CustomList)(nil)
+	_ api.Editable       = (*CustomList)(nil)
+	_ api.CustomEditable = (*CustomList)(nil)
+	_ api.Deletable      = (*CustomList)(nil)
+	_ api.Actionable     = (*CustomList)(nil)
+	_ api.Filter         = (*CustomList)(nil)
+)
+
+// Actions
+func init() {
+	API.RegisterActions("CustomList", []*api.Action{
+		// Add anime
+		{
+			Name:  "add",
+			Route: "/add/:animeId",
+			Run: func(obj interface{}, ctx aero.Context) error {
+				list := obj.(*CustomList)
+				animeID := ctx.Get("animeId")
+				_, err := GetAnime(animeID)
+
+				if err != nil {
+					return errors.New("Anime does not exist")
+				}
+
+				err = list.Add(animeID)
+
+				if err != nil {
+					return err
+				}
+
+				list.edited(ctx)
+				list.Save()
+				return nil
+			},
+		},
+
+		// Remove anime
+		{
+			Name:  "remove",
+			Route: "/remove/:animeId",
+			Run: func(obj interface{}, ctx aero.Context) error {
+				list := obj.(*CustomList)
+
+				if !list.Remove(ctx.Get("animeId")) {
+					return errors.New("The anime is not in this list")
+				}
+
+				list.edited(ctx)
+				list.Save()
+				return nil
+			},
+		},
+
+		// Change the position of an anime
+		{
+			Name:  "move",
+			Route: "/move/:from/:to",
+			Run: func(obj interface{}, ctx aero.Context) error {
+				list := obj.(*CustomList)
+				from, err := ctx.GetInt("from")
+
+				if err != nil {
+					return err
+				}
+
+				to, err := ctx.GetInt("to")
+
+				if err != nil {
+					return err
+				}
+
+				err = list.Move(from, to)
+
+				if err != nil {
+					return err
+				}
+
+				list.edited(ctx)
+				list.Save()
+				return nil
+			},
+		},
+	})
+}
+
+// Create sets the data for a new custom list.
+func (list *CustomList) Create(ctx aero.Context) error {
+	user := GetUserFromContext(ctx)
+
+	if user == nil {
+		return errors.New("Not logged in")
+	}
+
+	*list = *NewCustomList(user.ID, "New list")
+	return nil
+}
+
+// Authorize returns an error if the given API request is not authorized.
+func (list *CustomList) Authorize(ctx aero.Context, action string) error {
+	user := GetUserFromContext(ctx)
+
+	if user == nil {
+		return errors.New("Not logged in")
+	}
+
+	if action != "create" && list.CreatedBy != user.ID {
+		return errors.New("Can't modify lists of other users")
+	}
+
+	return nil
+}
+
+// Edit validates the list name and visibility.
+func (list *CustomList) Edit(ctx aero.Context, key string, value reflect.Value, newValue reflect.Value) (bool, error) {
+	switch key {
+	case "Name":
+		name := strings.TrimSpace(newValue.String())
+
+		if name == "" {
+			return true, errors.New("The list needs a name")
+		}
+
+		if len([]rune(name)) > maxCustomListNameLength {
+			return true, fmt.Errorf("List names can't be longer than %d characters", maxCustomListNameLength)
+		}
+
+		list.Name = name
+		list.edited(ctx)
+		return true, nil
+
+	case "Visibility":
+		visibility := newValue.String()
+
+		if visibility != CustomListPublic && visibility != CustomListPrivate {
+			return true, errors.New("Invalid visibility")
+		}
+
+		list.Visibility = visibility
+		list.edited(ctx)
+		return true, nil
+	}
+
+	list.edited(ctx)
+	return false, nil
+}
+
+// edited remembers who changed the list and when.
+func (list *CustomList) edited(ctx aero.Context) {
+	list.Edited = DateTimeUTC()
+	list.EditedBy = GetUserFromContext(ctx).ID
+}
+
+// ShouldFilter tells whether data needs to be filtered in the given context.
+func (list *CustomList) ShouldFilter(ctx aero.Context) bool {
+	return !list.IsVisibleTo(GetUserFromContext(ctx))
+}
+
+// Filter removes the contents of private lists.
+func (list *CustomList) Filter() {
+	list.Name = ""
+	list.Description = ""
+	list.AnimeIDs = nil
+}
+
+// DeleteInContext deletes the list in the given context.
+func (list *CustomList) DeleteInContext(ctx aero.Context) error {
+	return list.Delete()
+}
+
+// Delete deletes the list from the database.
+func (list *CustomList) Delete() error {
+	DB.Delete("CustomList", list.ID)
+	return nil
+}
+
+// Save saves the list in the database.
+func (list *CustomList) Save() {
+	DB.Set("CustomList", list.ID, list)
+}
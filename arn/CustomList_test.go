@@ -0,0 +1,42 @@
+package arn_test
+
+import (
+	"testing"
+
+	"github.com/akyoto/assert"
+	"github.com/animenotifier/notify.moe/arn"
+)
+
+func TestCustomListMove(t *testing.T) {
+	list := &arn.CustomList{AnimeIDs: []arn.AnimeID{"a", "b", "c", "d"}}
+
+	assert.Nil(t, list.Move(0, 2))
+	assert.DeepEqual(t, list.AnimeIDs, []arn.AnimeID{"b", "c", "a", "d"})
+
+	assert.Nil(t, list.Move(3, 0))
+	assert.DeepEqual(t, list.AnimeIDs, []arn.AnimeID{"d", "b", "c", "a"})
+
+	assert.NotNil(t, list.Move(0, 4))
+}
+
+func TestCustomListRemove(t *testing.T) {
+	list := &arn.CustomList{AnimeIDs: []arn.AnimeID{"a", "b", "c"}}
+
+	assert.True(t, list.Remove("b"))
+	assert.False(t, list.Remove("b"))
+	assert.DeepEqual(t, list.AnimeIDs, []arn.AnimeID{"a", "c"})
+	assert.Equal(t, list.IndexOf("c"), 1)
+}
+
+func TestCustomListVisibility(t *testing.T) {
+	owner := &arn.User{ID: "owner"}
+	other := &arn.User{ID: "other"}
+	list := arn.NewCustomList(owner.ID, "Rewatch")
+
+	assert.True(t, list.IsVisibleTo(owner))
+	assert.False(t, list.IsVisibleTo(other))
+	assert.False(t, list.IsVisibleTo(nil))
+
+	list.Visibility = arn.CustomListPublic
+	assert.True(t, list.IsVisibleTo(nil))
+}
@@ -18,6 +18,7 @@ var Node = nano.New(nano.Configuration{
 // DB is the main database client.
 var DB = Node.Namespace("arn").RegisterTypes(
 	(*ActivityCreate)(nil),
+	(*APIKeyToUser)(nil),
 	(*ActivityConsumeAnime)(nil),
 	(*AMV)(nil),
 	(*Analytics)(nil),
@@ -29,32 +30,53 @@ var DB = Node.Namespace("arn").RegisterTypes(
 	(*Character)(nil),
 	(*ClientErrorReport)(nil),
 	(*Company)(nil),
+	(*Conversation)(nil),
+	(*CustomList)(nil),
 	(*DraftIndex)(nil),
 	(*EditLogEntry)(nil),
+	(*EmailDelivery)(nil),
 	(*EmailToUser)(nil),
 	(*Episode)(nil),
 	(*FacebookToUser)(nil),
+	(*FeaturedContent)(nil),
 	(*GoogleToUser)(nil),
 	(*Group)(nil),
+	(*GroupEvent)(nil),
 	(*IDList)(nil),
 	(*IgnoreAnimeDifference)(nil),
 	(*Inventory)(nil),
+	(*JobStatus)(nil),
+	(*ListImport)(nil),
+	(*ModerationLogEntry)(nil),
 	(*NickToUser)(nil),
 	(*Notification)(nil),
+	(*OAuthClient)(nil),
+	(*OAuthCode)(nil),
+	(*OAuthToken)(nil),
 	(*PayPalPayment)(nil),
+	(*PendingEdit)(nil),
 	(*Person)(nil),
 	(*Post)(nil),
 	(*Purchase)(nil),
 	(*PushSubscriptions)(nil),
 	(*Quote)(nil),
+	(*Report)(nil),
+	(*Review)(nil),
 	(*Session)(nil),
 	(*Settings)(nil),
 	(*ShopItem)(nil),
+	(*SimilarAnime)(nil),
 	(*SoundTrack)(nil),
+	(*StreamingLinkSubmission)(nil),
 	(*Thread)(nil),
 	(*TwitterToUser)(nil),
 	(*User)(nil),
+	(*UserAPIKeys)(nil),
+	(*UserDrafts)(nil),
 	(*UserNotifications)(nil),
+	(*UserRecommendations)(nil),
+	(*UserStatistics)(nil),
+	(*YearInReview)(nil),
 )
 
 // MAL is the client for the MyAnimeList database.
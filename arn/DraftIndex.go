@@ -13,6 +13,7 @@ type DraftIndex struct {
 	CompanyID    CompanyID   `json:"companyId"`
 	QuoteID      QuoteID     `json:"quoteId"`
 	CharacterID  CharacterID `json:"characterId"`
+	PersonID     ID          `json:"personId"`
 	AnimeID      AnimeID     `json:"animeId"`
 	AMVID        ID          `json:"amvId"`
 }
@@ -1,6 +1,8 @@
 package arn
 
 import (
+	"errors"
+	"fmt"
 	"reflect"
 	"sort"
 
@@ -16,7 +18,11 @@ type EditLogEntry struct {
 	Key        string `json:"key"`
 	OldValue   string `json:"oldValue"`
 	NewValue   string `json:"newValue"`
+	OldData    string `json:"oldData"` // JSON encoded old value of field edits
+	NewData    string `json:"newData"` // JSON encoded new value of field edits
 	Created    string `json:"created"`
+	RevertedBy UserID `json:"revertedBy"`
+	Reverted   string `json:"reverted"`
 
 	hasID
 }
@@ -38,6 +44,14 @@ func NewEditLogEntry(userID UserID, action string, objectType string, objectID I
 	}
 }
 
+// NewFieldEditLogEntry creates a log entry for a field edit that can be reverted later.
+func NewFieldEditLogEntry(userID UserID, objectType string, objectID ID, key string, value reflect.Value, newValue reflect.Value) *EditLogEntry {
+	entry := NewEditLogEntry(userID, "edit", objectType, objectID, key, fmt.Sprint(value.Interface()), fmt.Sprint(newValue.Interface()))
+	entry.OldData = fieldData(value)
+	entry.NewData = fieldData(newValue)
+	return entry
+}
+
 // User returns the user the log entry belongs to.
 func (entry *EditLogEntry) User() *User {
 	user, _ := GetUser(entry.UserID)
@@ -118,6 +132,53 @@ func (entry *EditLogEntry) ActionHumanReadable() string {
 	}
 }
 
+// IsReverted tells you whether the edit has been reverted.
+func (entry *EditLogEntry) IsReverted() bool {
+	return entry.Reverted != ""
+}
+
+// CanRevert tells you whether the entry is a field edit that can be undone.
+func (entry *EditLogEntry) CanRevert() bool {
+	return entry.Action == "edit" && entry.NewData != "" && !entry.IsReverted()
+}
+
+// RevertedByUser returns the editor who reverted the edit.
+func (entry *EditLogEntry) RevertedByUser() *User {
+	user, _ := GetUser(entry.RevertedBy)
+	return user
+}
+
+// Revert restores the old value of the edited field.
+// It fails if the field has been changed again in the meantime.
+func (entry *EditLogEntry) Revert(user *User) error {
+	if !entry.CanRevert() {
+		return errors.New("This edit can't be reverted")
+	}
+
+	obj := entry.Object()
+
+	if obj == nil {
+		return errors.New(entry.ObjectType + " does not exist anymore")
+	}
+
+	err := setFieldData(obj, entry.Key, entry.NewData, entry.OldData)
+
+	if err != nil {
+		return err
+	}
+
+	entry.RevertedBy = user.ID
+	entry.Reverted = DateTimeUTC()
+	entry.Save()
+
+	// The revert itself is an edit that can be reverted
+	revertEntry := NewEditLogEntry(user.ID, "edit", entry.ObjectType, entry.ObjectID, entry.Key, entry.NewValue, entry.OldValue)
+	revertEntry.OldData = entry.NewData
+	revertEntry.NewData = entry.OldData
+	revertEntry.Save()
+	return nil
+}
+
 // StreamEditLogEntries returns a stream of all log entries.
 func StreamEditLogEntries() <-chan *EditLogEntry {
 	channel := make(chan *EditLogEntry, nano.ChannelBufferSize)
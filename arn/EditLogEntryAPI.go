@@ -1,6 +1,61 @@
 package arn
 
+import (
+	"errors"
+
+	"github.com/aerogo/aero"
+	"github.com/aerogo/api"
+)
+
+// Force interface implementations
+var (
+	_ api.Actionable = (*EditLogEntry)(nil)
+)
+
+// Actions
+func init() {
+	API.RegisterActions("EditLogEntry", []*api.Action{
+		// Undo the edit
+		{
+			Name:  "revert",
+			Route: "/revert",
+			Run: func(obj interface{}, ctx aero.Context) error {
+				entry := obj.(*EditLogEntry)
+				user := GetUserFromContext(ctx)
+				err := entry.Revert(user)
+
+				if err != nil {
+					return err
+				}
+
+				logEntry := NewModerationLogEntry(user.ID, "edit-reverted", entry.ObjectType, entry.ObjectID, entry.Key, entry.NewValue)
+				logEntry.Save()
+				return nil
+			},
+		},
+	})
+}
+
+// Authorize returns an error if the given API request is not authorized.
+func (entry *EditLogEntry) Authorize(ctx aero.Context, action string) error {
+	user := GetUserFromContext(ctx)
+
+	if user == nil {
+		return errors.New("Not logged in")
+	}
+
+	if !user.IsModerator() || !user.IsTrustedEditor() {
+		return errors.New("Only trusted editors can revert edits")
+	}
+
+	return nil
+}
+
 // Save saves the log entry in the database.
 func (entry *EditLogEntry) Save() {
+	if !DB.Exists("EditLogEntry", entry.ID) {
+		countEdit(entry)
+	}
+
 	DB.Set("EditLogEntry", entry.ID, entry)
 }
@@ -0,0 +1,70 @@
+package arn
+
+import "github.com/aerogo/nano"
+
+// EmailDelivery records an email sent to a user.
+type EmailDelivery struct {
+	UserID          UserID   `json:"userId"`
+	Email           string   `json:"email"`
+	Subject         string   `json:"subject"`
+	Digest          string   `json:"digest"`
+	NotificationIDs []string `json:"notifications"`
+	MessageID       string   `json:"messageId"`
+	Created         string   `json:"created"`
+	Sent            string   `json:"sent"`
+	Error           string   `json:"error"`
+
+	hasID
+}
+
+// NewEmailDelivery creates a new email delivery record.
+func NewEmailDelivery(user *User, subject string, digest string, notifications []*Notification) *EmailDelivery {
+	delivery := &EmailDelivery{
+		UserID:          user.ID,
+		Email:           user.Email,
+		Subject:         subject,
+		Digest:          digest,
+		NotificationIDs: make([]string, len(notifications)),
+		Created:         DateTimeUTC(),
+		hasID: hasID{
+			ID: GenerateID("EmailDelivery"),
+		},
+	}
+
+	for i, notification := range notifications {
+		delivery.NotificationIDs[i] = notification.ID
+	}
+
+	return delivery
+}
+
+// User returns the user the email was sent to.
+func (delivery *EmailDelivery) User() *User {
+	user, _ := GetUser(delivery.UserID)
+	return user
+}
+
+// IsSent tells whether the email was accepted by the mail server.
+func (delivery *EmailDelivery) IsSent() bool {
+	return delivery.Sent != ""
+}
+
+// Save saves the email delivery in the database.
+func (delivery *EmailDelivery) Save() {
+	DB.Set("EmailDelivery", delivery.ID, delivery)
+}
+
+// StreamEmailDeliveries returns a stream of all email deliveries.
+func StreamEmailDeliveries() <-chan *EmailDelivery {
+	channel := make(chan *EmailDelivery, nano.ChannelBufferSize)
+
+	go func() {
+		for obj := range DB.All("EmailDelivery") {
+			channel <- obj.(*EmailDelivery)
+		}
+
+		close(channel)
+	}()
+
+	return channel
+}
@@ -0,0 +1,218 @@
+package arn
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Email digest frequencies
+const (
+	EmailDigestNever     = ""
+	EmailDigestImmediate = "immediate"
+	EmailDigestDaily     = "daily"
+	EmailDigestWeekly    = "weekly"
+)
+
+// emailDigestMutex protects the digest state in the settings
+// because immediate digests are sent in the background while other notifications arrive.
+var emailDigestMutex sync.Mutex
+
+func init() {
+	DataLists["email-digests"] = []*Option{
+		{EmailDigestNever, "Never"},
+		{EmailDigestImmediate, "Immediately"},
+		{EmailDigestDaily, "Daily"},
+		{EmailDigestWeekly, "Weekly"},
+	}
+}
+
+// EmailDigestInterval returns the time between two digests of the given frequency.
+func EmailDigestInterval(digest string) time.Duration {
+	switch digest {
+	case EmailDigestDaily:
+		return 24 * time.Hour
+	case EmailDigestWeekly:
+		return 7 * 24 * time.Hour
+	default:
+		return 0
+	}
+}
+
+// IsEmailDigestDue tells whether the user's daily or weekly digest should be sent now.
+func (user *User) IsEmailDigestDue(now time.Time) bool {
+	settings := user.Settings()
+
+	if settings == nil || user.Email == "" {
+		return false
+	}
+
+	emailDigestMutex.Lock()
+	digest := settings.Notification.EmailDigest
+	sent := settings.Notification.EmailDigestSent
+	emailDigestMutex.Unlock()
+
+	interval := EmailDigestInterval(digest)
+
+	if interval == 0 {
+		return false
+	}
+
+	lastSent, err := time.Parse(time.RFC3339, sent)
+	return err != nil || now.Sub(lastSent) >= interval
+}
+
+// EmailDigestNotifications returns the unseen notifications since the last digest.
+func (user *User) EmailDigestNotifications() []*Notification {
+	notifications := []*Notification{}
+	userSettings := user.Settings()
+
+	if userSettings == nil {
+		return notifications
+	}
+
+	emailDigestMutex.Lock()
+	settings := userSettings.Notification
+	emailDigestMutex.Unlock()
+
+	since := settings.EmailDigestSent
+
+	// The first digest only contains the notifications of one interval
+	if since == "" {
+		interval := EmailDigestInterval(settings.EmailDigest)
+
+		if interval == 0 {
+			interval = 24 * time.Hour
+		}
+
+		since = time.Now().UTC().Add(-interval).Format(time.RFC3339)
+	}
+
+	for _, notification := range user.Notifications().Notifications() {
+		if notification.Seen != "" || !settings.Preferences.Allows(notification.Type, NotificationChannelEmail) {
+			continue
+		}
+
+		if notification.Created <= since {
+			continue
+		}
+
+		notifications = append(notifications, notification)
+	}
+
+	return notifications
+}
+
+// SendEmailDigest sends the notifications in a single email and records the delivery.
+// The settings are only locked before and after sending, not while waiting for the mail server.
+func (user *User) SendEmailDigest(notifications []*Notification) error {
+	if user.Email == "" {
+		return errors.New("User has no email address")
+	}
+
+	if len(notifications) == 0 {
+		return nil
+	}
+
+	settings := user.Settings()
+
+	if settings == nil {
+		return errors.New("User has no settings")
+	}
+
+	emailDigestMutex.Lock()
+
+	if settings.Notification.UnsubscribeToken == "" {
+		settings.Notification.UnsubscribeToken = GenerateSecret()
+		settings.Save()
+	}
+
+	digest := settings.Notification.EmailDigest
+	unsubscribeLink := emailUnsubscribeLink(user.ID, settings.Notification.UnsubscribeToken)
+	emailDigestMutex.Unlock()
+
+	subject := notifications[0].Title
+
+	if len(notifications) > 1 {
+		subject = fmt.Sprintf("%d new notifications", len(notifications))
+	}
+
+	delivery := NewEmailDelivery(user, subject, digest, notifications)
+	html := HTMLEmailRenderer.Digest(user, notifications)
+
+	// Mail clients can unsubscribe with a single POST request as defined in RFC 8058
+	messageID, err := sendEmail(user.Email, subject, html, map[string]string{
+		"List-Unsubscribe":      "<" + unsubscribeLink + ">",
+		"List-Unsubscribe-Post": "List-Unsubscribe=One-Click",
+	})
+
+	if err != nil {
+		delivery.Error = err.Error()
+		delivery.Save()
+		return err
+	}
+
+	delivery.MessageID = messageID
+	delivery.Sent = DateTimeUTC()
+	delivery.Save()
+
+	emailDigestMutex.Lock()
+	defer emailDigestMutex.Unlock()
+
+	// A digest that was sent in the meantime might have been more recent
+	if delivery.Sent > settings.Notification.EmailDigestSent {
+		settings.Notification.EmailDigestSent = delivery.Sent
+		settings.Save()
+	}
+
+	return nil
+}
+
+// EmailUnsubscribeLink returns the link that disables the email digest without logging in.
+func (user *User) EmailUnsubscribeLink() string {
+	settings := user.Settings()
+
+	if settings == nil {
+		return ""
+	}
+
+	emailDigestMutex.Lock()
+	defer emailDigestMutex.Unlock()
+	return emailUnsubscribeLink(user.ID, settings.Notification.UnsubscribeToken)
+}
+
+// emailUnsubscribeLink returns the unsubscribe link for the given user ID and token.
+func emailUnsubscribeLink(userID UserID, token string) string {
+	return fmt.Sprintf("https://%s/email/unsubscribe/%s/%s", Domain, userID, token)
+}
+
+// VerifyUnsubscribeToken tells whether the token from the unsubscribe link is valid.
+func (user *User) VerifyUnsubscribeToken(token string) bool {
+	settings := user.Settings()
+
+	if settings == nil {
+		return false
+	}
+
+	emailDigestMutex.Lock()
+	expected := settings.Notification.UnsubscribeToken
+	emailDigestMutex.Unlock()
+
+	return expected != "" && subtle.ConstantTimeCompare([]byte(token), []byte(expected)) == 1
+}
+
+// UnsubscribeEmailDigest disables the email digest if the token is valid.
+func (user *User) UnsubscribeEmailDigest(token string) error {
+	if !user.VerifyUnsubscribeToken(token) {
+		return errors.New("Invalid unsubscribe token")
+	}
+
+	settings := user.Settings()
+	emailDigestMutex.Lock()
+	settings.Notification.EmailDigest = EmailDigestNever
+	emailDigestMutex.Unlock()
+	settings.Save()
+	return nil
+}
@@ -0,0 +1,34 @@
+package arn_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/akyoto/assert"
+	"github.com/animenotifier/notify.moe/arn"
+)
+
+func TestEmailDigestInterval(t *testing.T) {
+	assert.Equal(t, arn.EmailDigestInterval(arn.EmailDigestDaily), 24*time.Hour)
+	assert.Equal(t, arn.EmailDigestInterval(arn.EmailDigestWeekly), 7*24*time.Hour)
+	assert.Equal(t, arn.EmailDigestInterval(arn.EmailDigestImmediate), time.Duration(0))
+	assert.Equal(t, arn.EmailDigestInterval(arn.EmailDigestNever), time.Duration(0))
+}
+
+func TestEmailDigestWithoutSettings(t *testing.T) {
+	user := &arn.User{
+		ID:    "no-settings",
+		Email: "user@example.com",
+	}
+
+	assert.False(t, user.IsEmailDigestDue(time.Now()))
+	assert.Equal(t, len(user.EmailDigestNotifications()), 0)
+	assert.Equal(t, user.EmailUnsubscribeLink(), "")
+	assert.False(t, user.VerifyUnsubscribeToken(""))
+	assert.NotNil(t, user.UnsubscribeEmailDigest("token"))
+
+	// Nothing is sent without notifications or an email address
+	assert.Nil(t, user.SendEmailDigest(nil))
+	user.Email = ""
+	assert.NotNil(t, user.SendEmailDigest([]*arn.Notification{{}}))
+}
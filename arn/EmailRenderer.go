@@ -14,20 +14,31 @@ var HTMLEmailRenderer EmailRenderer
 // EmailRenderer is an interface for rendering HTML emails.
 type EmailRenderer interface {
 	Notification(notification *Notification) string
+	Digest(user *User, notifications []*Notification) string
 }
 
 // SendEmail sends an e-mail.
 func SendEmail(email string, subject string, html string) error {
+	_, err := sendEmail(email, subject, html, nil)
+	return err
+}
+
+// sendEmail sends an e-mail with additional headers and returns the message ID.
+func sendEmail(email string, subject string, html string, headers map[string]string) (string, error) {
 	mg := mailgun.NewMailgun(APIKeys.Mailgun.Domain, APIKeys.Mailgun.PrivateKey)
 	sender := fmt.Sprintf("Anime Notifier <notifications@%s>", APIKeys.Mailgun.Domain)
 	message := mg.NewMessage(sender, subject, "", email)
 	message.SetHtml(html)
 
+	for name, value := range headers {
+		message.AddHeader(name, value)
+	}
+
 	// Allow a 10-second timeout
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
 	defer cancel()
 
 	// Send the message
-	_, _, err := mg.Send(ctx, message)
-	return err
+	_, id, err := mg.Send(ctx, message)
+	return id, err
 }
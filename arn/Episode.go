@@ -18,6 +18,7 @@ type Episode struct {
 	Title      EpisodeTitle      `json:"title" editable:"true"`
 	AiringDate AiringDate        `json:"airingDate" editable:"true"`
 	Links      map[string]string `json:"links"`
+	ThreadID   ThreadID          `json:"threadId"`
 
 	hasID
 }
@@ -0,0 +1,127 @@
+package arn
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/animenotifier/notify.moe/arn/validate"
+)
+
+// EpisodeDiscussionTag is the forum that lists the episode discussion threads.
+const EpisodeDiscussionTag = "episode"
+
+// episodeDiscussionMutex makes sure that we don't create two threads for the same episode.
+var episodeDiscussionMutex sync.Mutex
+
+// HasAired tells you whether the episode started airing.
+func (episode *Episode) HasAired() bool {
+	if !validate.DateTime(episode.AiringDate.Start) {
+		return false
+	}
+
+	start, err := time.Parse(time.RFC3339, episode.AiringDate.Start)
+
+	if err != nil {
+		return false
+	}
+
+	return time.Now().After(start)
+}
+
+// DiscussionThread returns the discussion thread of the episode or nil if it doesn't have one.
+func (episode *Episode) DiscussionThread() *Thread {
+	if episode.ThreadID == "" {
+		return nil
+	}
+
+	thread, err := GetThread(episode.ThreadID)
+
+	if err != nil || thread.IsSoftDeleted() {
+		return nil
+	}
+
+	return thread
+}
+
+// episodeDiscussionThreadID returns the thread ID of the discussion of an episode.
+// The ID is derived from the episode so that the web server and the job,
+// which run in different processes, always refer to the same thread.
+func episodeDiscussionThreadID(episodeID EpisodeID) ThreadID {
+	return "episode-" + episodeID
+}
+
+// CreateDiscussionThread creates the discussion thread for an episode that has aired.
+// If the episode already has a thread, the existing thread is returned.
+func (episode *Episode) CreateDiscussionThread() (*Thread, error) {
+	episodeDiscussionMutex.Lock()
+	defer episodeDiscussionMutex.Unlock()
+
+	if episode.ThreadID != "" {
+		return GetThread(episode.ThreadID)
+	}
+
+	threadID := episodeDiscussionThreadID(episode.ID)
+
+	// Another process might have created the thread already
+	existing, err := GetThread(threadID)
+
+	if err == nil {
+		episode.ThreadID = existing.ID
+		episode.Save()
+		return existing, nil
+	}
+
+	if !episode.HasAired() {
+		return nil, errors.New("Episode hasn't aired yet")
+	}
+
+	anime := episode.Anime()
+
+	if anime == nil {
+		return nil, errors.New("Anime not found: " + episode.AnimeID)
+	}
+
+	thread := &Thread{}
+	thread.ID = threadID
+	thread.Title = fmt.Sprintf("%s - Episode %d", anime.Title.Canonical, episode.Number)
+	thread.Text = fmt.Sprintf("Discussion of episode %d of [%s](%s). Please use spoiler tags when talking about later episodes.", episode.Number, anime.Title.Canonical, episode.Link())
+	thread.Tags = []string{EpisodeDiscussionTag, "anime:" + anime.ID, "episode:" + episode.ID}
+	thread.CreatedBy = BotUserID
+	thread.Created = DateTimeUTC()
+	thread.Save()
+
+	episode.ThreadID = thread.ID
+	episode.Save()
+	return thread, nil
+}
+
+// EpisodeID returns the ID of the episode the thread discusses or an empty string.
+func (thread *Thread) EpisodeID() EpisodeID {
+	for _, tag := range thread.Tags {
+		if strings.HasPrefix(tag, "episode:") {
+			return strings.TrimPrefix(tag, "episode:")
+		}
+	}
+
+	return ""
+}
+
+// Episode returns the episode discussed in the thread or nil if it's not an episode discussion.
+func (thread *Thread) Episode() *Episode {
+	episodeID := thread.EpisodeID()
+
+	if episodeID == "" {
+		return nil
+	}
+
+	episode, err := GetEpisode(episodeID)
+
+	if err != nil {
+		return nil
+	}
+
+	return episode
+}
@@ -0,0 +1,97 @@
+package arn
+
+import (
+	"sync"
+
+	"github.com/aerogo/aero"
+)
+
+// eventHub keeps track of the open event streams of all connected users.
+// Streams are keyed by user ID so they don't depend on the user object that opened them.
+type eventHub struct {
+	sync.Mutex
+	streams map[UserID][]*aero.EventStream
+}
+
+// events is the hub that all server events are published into.
+var events = &eventHub{
+	streams: map[UserID][]*aero.EventStream{},
+}
+
+// add registers an event stream for the given user.
+func (hub *eventHub) add(userID UserID, stream *aero.EventStream) {
+	hub.Lock()
+	defer hub.Unlock()
+
+	hub.streams[userID] = append(hub.streams[userID], stream)
+}
+
+// remove unregisters an event stream of the given user
+// and returns true if it was removed, otherwise false.
+func (hub *eventHub) remove(userID UserID, stream *aero.EventStream) bool {
+	hub.Lock()
+	defer hub.Unlock()
+
+	streams := hub.streams[userID]
+
+	for index, element := range streams {
+		if element != stream {
+			continue
+		}
+
+		streams = append(streams[:index], streams[index+1:]...)
+
+		if len(streams) == 0 {
+			delete(hub.streams, userID)
+		} else {
+			hub.streams[userID] = streams
+		}
+
+		return true
+	}
+
+	return false
+}
+
+// publish sends the event to all event streams of the given user.
+func (hub *eventHub) publish(userID UserID, event *aero.Event) {
+	hub.Lock()
+	defer hub.Unlock()
+
+	for _, stream := range hub.streams[userID] {
+		// Non-blocking send because we don't know if our listeners are still active.
+		select {
+		case stream.Events <- event:
+		default:
+		}
+	}
+}
+
+// userIDs returns the IDs of all users with at least one open event stream.
+func (hub *eventHub) userIDs() []UserID {
+	hub.Lock()
+	defer hub.Unlock()
+
+	userIDs := make([]UserID, 0, len(hub.streams))
+
+	for userID := range hub.streams {
+		userIDs = append(userIDs, userID)
+	}
+
+	return userIDs
+}
+
+// ConnectedUsers returns all users that currently have an open event stream.
+func ConnectedUsers() []*User {
+	var users []*User
+
+	for _, userID := range events.userIDs() {
+		user, err := GetUser(userID)
+
+		if err == nil {
+			users = append(users, user)
+		}
+	}
+
+	return users
+}
@@ -6,6 +6,8 @@ func init() {
 		{"Youtube", "Youtube"},
 		{"SoundCloud", "SoundCloud"},
 		{"DailyMotion", "DailyMotion"},
+		{"Spotify", "Spotify"},
+		{"AppleMusic", "Apple Music"},
 	}
 }
 
@@ -26,7 +28,16 @@ func (media *ExternalMedia) EmbedLink() string {
 		return "//www.dailymotion.com/embed/video/" + media.ServiceID
 	case "NicoVideo":
 		return "//ext.nicovideo.jp/thumb/" + media.ServiceID
+	case "Spotify":
+		return "//open.spotify.com/embed/track/" + media.ServiceID
+	case "AppleMusic":
+		return "//embed.music.apple.com/" + media.ServiceID
 	default:
 		return ""
 	}
 }
+
+// IsAudioOnly tells whether the embedded player has no video and only needs a small height.
+func (media *ExternalMedia) IsAudioOnly() bool {
+	return media.Service == "Spotify" || media.Service == "AppleMusic"
+}
@@ -0,0 +1,209 @@
+package arn
+
+import (
+	"errors"
+	"hash/fnv"
+	"math"
+	"math/rand"
+	"sort"
+	"time"
+)
+
+// FeaturedContentDateFormat is the format of the day a featured content belongs to.
+const FeaturedContentDateFormat = "2006-01-02"
+
+// featuredContentCooldown is the number of days before an object can be featured again.
+const featuredContentCooldown = 30
+
+// FeaturedContent is the anime, character and quote featured on the front page for one day.
+type FeaturedContent struct {
+	Date        string      `json:"date" primary:"true"`
+	AnimeID     AnimeID     `json:"animeId"`
+	CharacterID CharacterID `json:"characterId"`
+	QuoteID     QuoteID     `json:"quoteId"`
+	PinnedBy    UserID      `json:"pinnedBy"`
+	Created     string      `json:"created"`
+}
+
+// GetID returns the primary key which is the date.
+func (featured *FeaturedContent) GetID() string {
+	return featured.Date
+}
+
+// IsPinned tells whether an admin chose the featured content instead of the automatic selection.
+func (featured *FeaturedContent) IsPinned() bool {
+	return featured.PinnedBy != ""
+}
+
+// Anime returns the featured anime.
+func (featured *FeaturedContent) Anime() *Anime {
+	anime, _ := GetAnime(featured.AnimeID)
+	return anime
+}
+
+// Character returns the featured character.
+func (featured *FeaturedContent) Character() *Character {
+	character, _ := GetCharacter(featured.CharacterID)
+	return character
+}
+
+// Quote returns the featured quote.
+func (featured *FeaturedContent) Quote() *Quote {
+	quote, _ := GetQuote(featured.QuoteID)
+	return quote
+}
+
+// Save saves the featured content in the database.
+func (featured *FeaturedContent) Save() {
+	DB.Set("FeaturedContent", featured.Date, featured)
+}
+
+// GetFeaturedContent returns the featured content of the given date.
+func GetFeaturedContent(date string) (*FeaturedContent, error) {
+	obj, err := DB.Get("FeaturedContent", date)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return obj.(*FeaturedContent), nil
+}
+
+// TodaysFeaturedContent returns the featured content of today
+// and selects it if it doesn't exist yet.
+func TodaysFeaturedContent() (*FeaturedContent, error) {
+	now := time.Now().UTC()
+	featured, err := GetFeaturedContent(now.Format(FeaturedContentDateFormat))
+
+	if err == nil {
+		return featured, nil
+	}
+
+	featured, err = SelectFeaturedContent(now)
+
+	if err != nil {
+		return nil, err
+	}
+
+	featured.Save()
+	return featured, nil
+}
+
+// SelectFeaturedContent picks a random anime, character and quote for the given day.
+// Popular and recently added objects are more likely to be picked
+// and objects featured in the last days are skipped.
+// The selection is deterministic for each day.
+func SelectFeaturedContent(day time.Time) (*FeaturedContent, error) {
+	date := day.Format(FeaturedContentDateFormat)
+	seed := fnv.New64a()
+	_, _ = seed.Write([]byte(date))
+	random := rand.New(rand.NewSource(int64(seed.Sum64())))
+	recent := recentlyFeatured(day)
+
+	animes := FilterAnime(func(anime *Anime) bool {
+		return !anime.IsDraft && anime.HasImage() && !recent[anime.ID]
+	})
+
+	characters := FilterCharacters(func(character *Character) bool {
+		return !character.IsDraft && character.HasImage() && !recent[character.ID]
+	})
+
+	quotes := FilterQuotes(func(quote *Quote) bool {
+		return quote.IsPublic() && quote.Text.English != "" && !recent[quote.ID] && quote.IsValid()
+	})
+
+	if len(animes) == 0 || len(characters) == 0 || len(quotes) == 0 {
+		return nil, errors.New("Not enough content to feature")
+	}
+
+	// The database returns objects in random order
+	sort.Slice(animes, func(i, j int) bool { return animes[i].ID < animes[j].ID })
+	sort.Slice(characters, func(i, j int) bool { return characters[i].ID < characters[j].ID })
+	sort.Slice(quotes, func(i, j int) bool { return quotes[i].ID < quotes[j].ID })
+
+	anime := animes[weightedIndex(random, len(animes), func(i int) float64 {
+		return featuredWeight(animes[i].Popularity.Total()+animes[i].CountLikes(), animes[i].StartDateTime(), day)
+	})]
+
+	character := characters[weightedIndex(random, len(characters), func(i int) float64 {
+		return featuredWeight(characters[i].CountLikes(), parseDate(characters[i].Created), day)
+	})]
+
+	quote := quotes[weightedIndex(random, len(quotes), func(i int) float64 {
+		return featuredWeight(quotes[i].CountLikes(), parseDate(quotes[i].Created), day)
+	})]
+
+	return &FeaturedContent{
+		Date:        date,
+		AnimeID:     anime.ID,
+		CharacterID: character.ID,
+		QuoteID:     quote.ID,
+		Created:     DateTimeUTC(),
+	}, nil
+}
+
+// recentlyFeatured returns the IDs of the objects featured in the days before the given day.
+func recentlyFeatured(day time.Time) map[ID]bool {
+	recent := map[ID]bool{}
+
+	for i := 1; i <= featuredContentCooldown; i++ {
+		featured, err := GetFeaturedContent(day.AddDate(0, 0, -i).Format(FeaturedContentDateFormat))
+
+		if err != nil {
+			continue
+		}
+
+		recent[featured.AnimeID] = true
+		recent[featured.CharacterID] = true
+		recent[featured.QuoteID] = true
+	}
+
+	return recent
+}
+
+// featuredWeight calculates how likely an object is featured.
+// The weight grows with the number of likes and halves every year after the object was created.
+func featuredWeight(likes int, created time.Time, day time.Time) float64 {
+	weight := 1 + math.Log1p(float64(likes))
+
+	if created.IsZero() {
+		return weight
+	}
+
+	age := day.Sub(created).Hours() / 24 / 365
+
+	if age < 0 {
+		age = 0
+	}
+
+	return weight * math.Pow(0.5, age)
+}
+
+// weightedIndex returns a random index where each index is picked with a probability proportional to its weight.
+func weightedIndex(random *rand.Rand, count int, weight func(int) float64) int {
+	weights := make([]float64, count)
+	total := 0.0
+
+	for i := range weights {
+		weights[i] = weight(i)
+		total += weights[i]
+	}
+
+	target := random.Float64() * total
+
+	for i, w := range weights {
+		target -= w
+
+		if target < 0 {
+			return i
+		}
+	}
+
+	return count - 1
+}
+
+// parseDate parses a date in the database format and returns the zero time for invalid dates.
+func parseDate(date string) time.Time {
+	parsed, _ := time.Parse(time.RFC3339, date)
+	return parsed
+}
@@ -0,0 +1,69 @@
+package arn
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+
+	"github.com/aerogo/mirror"
+)
+
+// fieldData returns the JSON encoding of a field value.
+func fieldData(value reflect.Value) string {
+	data, err := json.Marshal(value.Interface())
+
+	if err != nil {
+		return ""
+	}
+
+	return string(data)
+}
+
+// fieldEditListener is implemented by types that have side effects when a field is edited.
+type fieldEditListener interface {
+	afterFieldEdit(key string, value reflect.Value, newValue reflect.Value)
+}
+
+// setFieldData sets a field of the object to a JSON encoded value and saves the object.
+// The field must still contain the expected value, otherwise newer edits would get lost.
+func setFieldData(obj interface{}, key string, expected string, data string) error {
+	_, fieldType, value, err := mirror.GetField(obj, key)
+
+	if err != nil {
+		return err
+	}
+
+	if !value.CanSet() {
+		return errors.New("Field " + key + " is not settable")
+	}
+
+	if fieldData(value) != expected {
+		return errors.New("The field " + key + " has been changed since then")
+	}
+
+	newValue := reflect.New(fieldType)
+	err = json.Unmarshal([]byte(data), newValue.Interface())
+
+	if err != nil {
+		return err
+	}
+
+	oldValue := reflect.New(fieldType).Elem()
+	oldValue.Set(value)
+	value.Set(newValue.Elem())
+
+	listener, hasSideEffects := obj.(fieldEditListener)
+
+	if hasSideEffects {
+		listener.afterFieldEdit(key, oldValue, newValue.Elem())
+	}
+
+	saver, ok := obj.(interface{ Save() })
+
+	if !ok {
+		return errors.New("Can't save objects of this type")
+	}
+
+	saver.Save()
+	return nil
+}
@@ -5,6 +5,7 @@ var forumIcons = map[string]string{
 	"general":    "paperclip",
 	"news":       "newspaper-o",
 	"anime":      "television",
+	"episode":    "play-circle",
 	"update":     "cubes",
 	"suggestion": "lightbulb-o",
 	"bug":        "bug",
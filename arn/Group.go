@@ -16,15 +16,19 @@ type GroupID = ID
 
 // Group represents a group of users.
 type Group struct {
-	Name        string         `json:"name" editable:"true"`
-	Tagline     string         `json:"tagline" editable:"true"`
-	Image       Image          `json:"image"`
-	Description string         `json:"description" editable:"true" type:"textarea"`
-	Rules       string         `json:"rules" editable:"true" type:"textarea"`
-	Tags        []string       `json:"tags" editable:"true"`
-	Members     []*GroupMember `json:"members"`
-	Neighbors   []GroupID      `json:"neighbors"`
-	// Applications []UserApplication `json:"applications"`
+	Name         string              `json:"name" editable:"true"`
+	Tagline      string              `json:"tagline" editable:"true"`
+	Image        Image               `json:"image"`
+	Description  string              `json:"description" editable:"true" type:"textarea"`
+	Rules        string              `json:"rules" editable:"true" type:"textarea"`
+	Tags         []string            `json:"tags" editable:"true"`
+	JoinMode     string              `json:"joinMode" editable:"true" datalist:"group-join-modes" tooltip:"Decides whether users can join directly, need to be accepted by a moderator or need an invitation."`
+	Members      []*GroupMember      `json:"members"`
+	JoinRequests []*GroupJoinRequest `json:"joinRequests"`
+	Invites      []*GroupInvite      `json:"invites"`
+	Announcement *GroupAnnouncement  `json:"announcement"`
+	ActivityLog  []*GroupActivity    `json:"activityLog"`
+	Neighbors    []GroupID           `json:"neighbors"`
 
 	// Mixins
 	hasID
@@ -35,9 +39,6 @@ type Group struct {
 
 	// Mutex
 	membersMutex sync.Mutex
-
-	// Moved this boolean field to the bottom because the structure consumes less bytes that way
-	Restricted bool `json:"restricted" editable:"true" tooltip:"Restricted groups can only be joined with the founder's permission."`
 }
 
 // Link returns the URI to the group page.
@@ -139,66 +140,25 @@ func (group *Group) Unpublish() error {
 	return unpublish(group)
 }
 
-// Join makes the given user join the group.
-func (group *Group) Join(user *User) error {
-	// Check if the user is already a member
-	member := group.FindMember(user.ID)
-
-	if member != nil {
-		return errors.New("Already a member of this group")
-	}
-
-	// Add user to the members list
-	group.membersMutex.Lock()
-
-	group.Members = append(group.Members, &GroupMember{
-		UserID: user.ID,
-		Joined: DateTimeUTC(),
-	})
-
-	group.membersMutex.Unlock()
-
-	// Trigger notifications
-	group.OnJoin(user)
-	return nil
-}
-
-// Leave makes the given user leave the group.
-func (group *Group) Leave(user *User) error {
-	group.membersMutex.Lock()
-	defer group.membersMutex.Unlock()
-
-	for index, member := range group.Members {
-		if member.UserID == user.ID {
-			if member.UserID == group.CreatedBy {
-				return errors.New("The founder can not leave the group, please contact a staff member")
-			}
-
-			group.Members = append(group.Members[:index], group.Members[index+1:]...)
-			return nil
-		}
-	}
-
-	return nil
-}
-
-// OnJoin sends notifications to the creator.
+// OnJoin sends notifications to the owner and the moderators.
 func (group *Group) OnJoin(user *User) {
 	go func() {
-		group.Creator().SendNotification(&PushNotification{
-			Title:   fmt.Sprintf(`%s joined your group!`, user.Nick),
-			Message: fmt.Sprintf(`%s has joined your group "%s"`, user.Nick, group.Name),
-			Icon:    "https:" + user.AvatarLink("large"),
-			Link:    "https://notify.moe" + group.Link() + "/members",
-			Type:    NotificationTypeGroupJoin,
-		})
+		for _, moderator := range group.Moderators() {
+			moderator.SendNotification(&PushNotification{
+				Title:   fmt.Sprintf(`%s joined your group!`, user.Nick),
+				Message: fmt.Sprintf(`%s has joined your group "%s"`, user.Nick, group.Name),
+				Icon:    "https:" + user.AvatarLink("large"),
+				Link:    "https://notify.moe" + group.Link() + "/members",
+				Type:    NotificationTypeGroupJoin,
+			})
+		}
 	}()
 }
 
 // SendNotification sends a notification to all group members except for the excluded user ID.
 func (group *Group) SendNotification(notification *PushNotification, excludeUserID UserID) {
 	for _, user := range group.Users() {
-		if user.ID == excludeUserID {
+		if user == nil || user.ID == excludeUserID {
 			continue
 		}
 
@@ -253,12 +213,16 @@ func (group *Group) DeleteImages() {
 	os.Remove(path.Join(Root, "images/groups/small/", group.ID+"@2.jpg"))
 	os.Remove(path.Join(Root, "images/groups/small/", group.ID+".webp"))
 	os.Remove(path.Join(Root, "images/groups/small/", group.ID+"@2.webp"))
+	os.Remove(path.Join(Root, "images/groups/small/", group.ID+".avif"))
+	os.Remove(path.Join(Root, "images/groups/small/", group.ID+"@2.avif"))
 
 	// Large
 	os.Remove(path.Join(Root, "images/groups/large/", group.ID+".jpg"))
 	os.Remove(path.Join(Root, "images/groups/large/", group.ID+"@2.jpg"))
 	os.Remove(path.Join(Root, "images/groups/large/", group.ID+".webp"))
 	os.Remove(path.Join(Root, "images/groups/large/", group.ID+"@2.webp"))
+	os.Remove(path.Join(Root, "images/groups/large/", group.ID+".avif"))
+	os.Remove(path.Join(Root, "images/groups/large/", group.ID+"@2.avif"))
 }
 
 // GetGroup ...
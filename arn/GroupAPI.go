@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"strings"
 
 	"github.com/aerogo/aero"
 	"github.com/aerogo/api"
@@ -34,9 +35,86 @@ func init() {
 
 		// Leave
 		LeaveAction(),
+
+		// Accept join request
+		groupMemberAction("accept", func(group *Group, user *User, data map[string]interface{}) error {
+			return group.AcceptJoinRequest(stringValue(data, "userId"))
+		}),
+
+		// Decline join request
+		groupMemberAction("decline", func(group *Group, user *User, data map[string]interface{}) error {
+			return group.DeclineJoinRequest(stringValue(data, "userId"))
+		}),
+
+		// Invite
+		groupMemberAction("invite", func(group *Group, user *User, data map[string]interface{}) error {
+			invitedUser, err := GetVisibleUserByNick(strings.TrimSpace(stringValue(data, "nick")))
+
+			if err != nil {
+				return errors.New("User not found")
+			}
+
+			return group.Invite(invitedUser, user)
+		}),
+
+		// Remove member
+		groupMemberAction("remove", func(group *Group, user *User, data map[string]interface{}) error {
+			userID := stringValue(data, "userId")
+			target := group.FindMember(userID)
+			remover := group.FindMember(user.ID)
+
+			// Moderators can't remove each other
+			if target != nil && target.CanModerate() && (remover == nil || !remover.IsOwner()) {
+				return errors.New("Only the owner of the group can remove moderators")
+			}
+
+			return group.RemoveMember(userID)
+		}),
+
+		// Change role
+		groupMemberAction("role", func(group *Group, user *User, data map[string]interface{}) error {
+			return group.SetRole(stringValue(data, "userId"), stringValue(data, "role"), user.ID)
+		}),
+
+		// Announce
+		groupMemberAction("announce", func(group *Group, user *User, data map[string]interface{}) error {
+			return group.Announce(user, stringValue(data, "text"))
+		}),
 	})
 }
 
+// stringValue returns the string stored under the key or an empty string.
+func stringValue(data map[string]interface{}, key string) string {
+	value, _ := data[key].(string)
+	return value
+}
+
+// groupMemberAction returns an API action for group moderators that reads its parameters from the JSON body.
+func groupMemberAction(name string, run func(*Group, *User, map[string]interface{}) error) *api.Action {
+	return &api.Action{
+		Name:  name,
+		Route: "/" + name,
+		Run: func(obj interface{}, ctx aero.Context) error {
+			group := obj.(*Group)
+			user := GetUserFromContext(ctx)
+			data, err := ctx.Request().Body().JSONObject()
+
+			if err != nil {
+				return err
+			}
+
+			err = run(group, user, data)
+
+			if err != nil {
+				return err
+			}
+
+			group.Save()
+			return nil
+		},
+	}
+}
+
 // Create ...
 func (group *Group) Create(ctx aero.Context) error {
 	user := GetUserFromContext(ctx)
@@ -58,7 +136,7 @@ func (group *Group) Create(ctx aero.Context) error {
 	group.Members = []*GroupMember{
 		{
 			UserID: user.ID,
-			Role:   "founder",
+			Role:   GroupRoleOwner,
 			Joined: group.Created,
 		},
 	}
@@ -93,6 +171,15 @@ func (group *Group) Delete() error {
 		draftIndex.Save()
 	}
 
+	// Delete events
+	for _, event := range group.Events() {
+		err := event.Delete()
+
+		if err != nil {
+			return err
+		}
+	}
+
 	// Delete image files
 	group.DeleteImages()
 
@@ -120,12 +207,18 @@ func (group *Group) Authorize(ctx aero.Context, action string) error {
 		return errors.New("Not logged in")
 	}
 
-	if action == "edit" && group.CreatedBy != user.ID {
-		return errors.New("Can't edit groups from other people")
-	}
+	switch action {
+	case "edit", "role":
+		member := group.FindMember(user.ID)
+
+		if member == nil || !member.IsOwner() {
+			return errors.New("Only the owner of the group can do this")
+		}
 
-	if action == "join" && group.Restricted {
-		return errors.New("Can't join restricted groups")
+	case "accept", "decline", "invite", "remove", "announce":
+		if !group.CanModerate(user.ID) {
+			return errors.New("Only moderators of the group can do this")
+		}
 	}
 
 	return nil
@@ -0,0 +1,209 @@
+package arn
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Group activity types
+const (
+	GroupActivityJoin         = "join"
+	GroupActivityPost         = "post"
+	GroupActivityEdit         = "edit"
+	GroupActivityAnnouncement = "announcement"
+	GroupActivityRole         = "role"
+)
+
+// maxGroupActivityLogLength is the number of logged activities that a group keeps.
+const maxGroupActivityLogLength = 200
+
+// groupEditIndexRefreshInterval defines how often the index of group edits is rebuilt.
+const groupEditIndexRefreshInterval = 10 * time.Minute
+
+// groupEditIndex maps each group to the edits made to it.
+type groupEditIndex struct {
+	edits   map[GroupID][]*GroupActivity
+	created time.Time
+}
+
+var (
+	groupEditsCache      *groupEditIndex
+	groupEditsRebuilding bool
+	groupEditsMutex      sync.Mutex
+)
+
+// GroupActivity is an entry in the activity feed of a group.
+type GroupActivity struct {
+	Type    string `json:"type"`
+	ActorID UserID `json:"actor"`
+	Target  string `json:"target"`
+	Role    string `json:"role,omitempty"`
+	Created string `json:"created"`
+}
+
+// Cursor returns the pagination cursor pointing to this activity.
+// Dates only have a precision of one second, so the actor is needed to tell apart
+// activities of the same type and target, e.g. two members joining at the same time.
+func (activity *GroupActivity) Cursor() string {
+	return activity.Created + "|" + activity.Type + "|" + activity.Target + "|" + activity.ActorID
+}
+
+// Activities returns the activities of the group, latest first.
+// Targets are the joined group ID, the post ID, the edited field,
+// the group ID for announcements or the member whose role changed.
+// Edits are read from an index that is rebuilt every few minutes.
+func (group *Group) Activities() []*GroupActivity {
+	var activities []*GroupActivity
+
+	group.membersMutex.Lock()
+
+	for _, member := range group.Members {
+		activities = append(activities, &GroupActivity{
+			Type:    GroupActivityJoin,
+			ActorID: member.UserID,
+			Target:  group.ID,
+			Created: member.Joined,
+		})
+	}
+
+	activities = append(activities, group.ActivityLog...)
+	group.membersMutex.Unlock()
+
+	for _, post := range group.Posts() {
+		activities = append(activities, &GroupActivity{
+			Type:    GroupActivityPost,
+			ActorID: post.CreatedBy,
+			Target:  post.ID,
+			Created: post.Created,
+		})
+	}
+
+	activities = append(activities, groupEdits(group.ID)...)
+
+	SortGroupActivitiesLatestFirst(activities)
+	return activities
+}
+
+// logActivity adds an activity that can't be derived from the group data to the activity log.
+// Only the latest activities are kept.
+func (group *Group) logActivity(activityType string, actorID UserID, target string, role string) {
+	group.membersMutex.Lock()
+	defer group.membersMutex.Unlock()
+
+	group.ActivityLog = append(group.ActivityLog, &GroupActivity{
+		Type:    activityType,
+		ActorID: actorID,
+		Target:  target,
+		Role:    role,
+		Created: DateTimeUTC(),
+	})
+
+	if len(group.ActivityLog) > maxGroupActivityLogLength {
+		group.ActivityLog = group.ActivityLog[len(group.ActivityLog)-maxGroupActivityLogLength:]
+	}
+}
+
+// groupEdits returns the edit activities of the group.
+// Only the first request waits for the index, outdated indices
+// are rebuilt in the background while the old one is still served.
+func groupEdits(groupID GroupID) []*GroupActivity {
+	groupEditsMutex.Lock()
+	defer groupEditsMutex.Unlock()
+
+	if groupEditsCache == nil {
+		groupEditsCache = buildGroupEditIndex()
+	} else if time.Since(groupEditsCache.created) > groupEditIndexRefreshInterval && !groupEditsRebuilding {
+		groupEditsRebuilding = true
+		go rebuildGroupEditIndex()
+	}
+
+	return groupEditsCache.edits[groupID]
+}
+
+// rebuildGroupEditIndex replaces the index with a new one.
+func rebuildGroupEditIndex() {
+	rebuilt := buildGroupEditIndex()
+
+	groupEditsMutex.Lock()
+	groupEditsCache = rebuilt
+	groupEditsRebuilding = false
+	groupEditsMutex.Unlock()
+}
+
+// buildGroupEditIndex collects the field edits of all groups from the edit log.
+func buildGroupEditIndex() *groupEditIndex {
+	built := &groupEditIndex{
+		edits:   map[GroupID][]*GroupActivity{},
+		created: time.Now(),
+	}
+
+	for entry := range StreamEditLogEntries() {
+		if entry.ObjectType != "Group" || entry.Action != "edit" {
+			continue
+		}
+
+		built.edits[entry.ObjectID] = append(built.edits[entry.ObjectID], &GroupActivity{
+			Type:    GroupActivityEdit,
+			ActorID: entry.UserID,
+			Target:  entry.Key,
+			Created: entry.Created,
+		})
+	}
+
+	return built
+}
+
+// ActivitiesVisibleTo tells whether the given user can see the activities of the group.
+// Activities of groups that can't be joined freely are only visible to their members.
+func (group *Group) ActivitiesVisibleTo(user *User) bool {
+	if group.JoinMode == GroupJoinModeOpen {
+		return true
+	}
+
+	return user != nil && group.HasMember(user.ID)
+}
+
+// SortGroupActivitiesLatestFirst sorts the activities by date
+// and uses the cursor as a tie-breaker to get a stable order.
+func SortGroupActivitiesLatestFirst(activities []*GroupActivity) {
+	sort.Slice(activities, func(i, j int) bool {
+		a := activities[i]
+		b := activities[j]
+
+		if a.Created != b.Created {
+			return a.Created > b.Created
+		}
+
+		return a.Cursor() < b.Cursor()
+	})
+}
+
+// PaginateGroupActivities returns up to maxLength activities following the cursor
+// and the cursor for the next page, which is empty if there are no more activities.
+func PaginateGroupActivities(activities []*GroupActivity, cursor string, maxLength int) ([]*GroupActivity, string) {
+	start := 0
+
+	if cursor != "" {
+		created := strings.SplitN(cursor, "|", 2)[0]
+		start = sort.Search(len(activities), func(i int) bool {
+			activity := activities[i]
+
+			if activity.Created != created {
+				return activity.Created < created
+			}
+
+			return activity.Cursor() > cursor
+		})
+	}
+
+	activities = activities[start:]
+
+	if len(activities) <= maxLength {
+		return activities, ""
+	}
+
+	activities = activities[:maxLength]
+	return activities, activities[len(activities)-1].Cursor()
+}
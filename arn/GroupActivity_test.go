@@ -0,0 +1,115 @@
+package arn_test
+
+import (
+	"testing"
+
+	"github.com/akyoto/assert"
+	"github.com/animenotifier/notify.moe/arn"
+)
+
+func TestGroupActivities(t *testing.T) {
+	group := &arn.Group{
+		Members: []*arn.GroupMember{
+			{UserID: "founder", Joined: "2019-01-01T00:00:00Z"},
+			{UserID: "member", Joined: "2019-02-01T00:00:00Z"},
+		},
+	}
+
+	group.ID = "test-group-activities"
+	activities := group.Activities()
+
+	assert.Equal(t, len(activities), 2)
+	assert.Equal(t, activities[0].Type, arn.GroupActivityJoin)
+	assert.Equal(t, activities[0].ActorID, "member")
+	assert.Equal(t, activities[0].Target, group.ID)
+	assert.Equal(t, activities[1].ActorID, "founder")
+
+	// Cursor based pagination
+	page, cursor := arn.PaginateGroupActivities(activities, "", 1)
+	assert.Equal(t, len(page), 1)
+	assert.Equal(t, page[0].ActorID, "member")
+	assert.NotEqual(t, cursor, "")
+
+	page, cursor = arn.PaginateGroupActivities(activities, cursor, 1)
+	assert.Equal(t, len(page), 1)
+	assert.Equal(t, page[0].ActorID, "founder")
+	assert.Equal(t, cursor, "")
+}
+
+func TestGroupActivitiesLogged(t *testing.T) {
+	group := &arn.Group{
+		Members: []*arn.GroupMember{
+			{UserID: "founder", Joined: "2019-01-01T00:00:00Z"},
+			{UserID: "member", Joined: "2019-02-01T00:00:00Z"},
+		},
+		ActivityLog: []*arn.GroupActivity{
+			{Type: arn.GroupActivityRole, ActorID: "founder", Target: "member", Role: arn.GroupRoleModerator, Created: "2019-03-01T00:00:00Z"},
+			{Type: arn.GroupActivityAnnouncement, ActorID: "member", Target: "test-group-activities-logged", Created: "2019-04-01T00:00:00Z"},
+		},
+	}
+
+	group.ID = "test-group-activities-logged"
+	activities := group.Activities()
+
+	assert.Equal(t, len(activities), 4)
+	assert.Equal(t, activities[0].Type, arn.GroupActivityAnnouncement)
+	assert.Equal(t, activities[0].ActorID, "member")
+	assert.Equal(t, activities[0].Target, group.ID)
+	assert.Equal(t, activities[1].Type, arn.GroupActivityRole)
+	assert.Equal(t, activities[1].ActorID, "founder")
+	assert.Equal(t, activities[1].Target, "member")
+	assert.Equal(t, activities[1].Role, arn.GroupRoleModerator)
+	assert.Equal(t, activities[2].Type, arn.GroupActivityJoin)
+	assert.Equal(t, activities[3].Type, arn.GroupActivityJoin)
+}
+
+func TestGroupActivitiesSameSecond(t *testing.T) {
+	group := &arn.Group{
+		Members: []*arn.GroupMember{
+			{UserID: "first", Joined: "2019-01-01T00:00:00Z"},
+			{UserID: "second", Joined: "2019-01-01T00:00:00Z"},
+			{UserID: "third", Joined: "2019-01-01T00:00:00Z"},
+		},
+	}
+
+	group.ID = "test-group-activities-same-second"
+	activities := group.Activities()
+	assert.Equal(t, len(activities), 3)
+
+	// Joins in the same second must all appear exactly once across page boundaries
+	seen := map[string]bool{}
+	cursor := ""
+
+	for {
+		var page []*arn.GroupActivity
+		page, cursor = arn.PaginateGroupActivities(activities, cursor, 1)
+		assert.Equal(t, len(page), 1)
+		assert.False(t, seen[page[0].ActorID])
+		seen[page[0].ActorID] = true
+
+		if cursor == "" {
+			break
+		}
+	}
+
+	assert.Equal(t, len(seen), 3)
+}
+
+func TestGroupActivitiesPrivacy(t *testing.T) {
+	member := &arn.User{ID: "member"}
+	outsider := &arn.User{ID: "outsider"}
+
+	group := &arn.Group{
+		Members: []*arn.GroupMember{
+			{UserID: member.ID},
+		},
+	}
+
+	assert.True(t, group.ActivitiesVisibleTo(nil))
+	assert.True(t, group.ActivitiesVisibleTo(outsider))
+
+	group.JoinMode = arn.GroupJoinModeRequest
+	assert.False(t, group.ActivitiesVisibleTo(nil))
+	assert.False(t, group.ActivitiesVisibleTo(outsider))
+	assert.True(t, group.ActivitiesVisibleTo(member))
+}
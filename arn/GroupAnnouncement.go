@@ -0,0 +1,54 @@
+package arn
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// GroupAnnouncement is a message from the group moderators that is pinned on top of the group feed.
+type GroupAnnouncement struct {
+	Text      string `json:"text"`
+	CreatedBy UserID `json:"createdBy"`
+	Created   string `json:"created"`
+}
+
+// Creator returns the user who wrote the announcement.
+func (announcement *GroupAnnouncement) Creator() *User {
+	user, _ := GetUser(announcement.CreatedBy)
+	return user
+}
+
+// Announce pins the announcement in the group, adds it to the group activities
+// and notifies all members except the author.
+// An empty text removes the current announcement.
+func (group *Group) Announce(user *User, text string) error {
+	text = strings.TrimSpace(text)
+
+	if text == "" {
+		group.Announcement = nil
+		return nil
+	}
+
+	if len(text) > 1000 {
+		return errors.New("Announcement too long: Should not be more than 1000 characters")
+	}
+
+	group.Announcement = &GroupAnnouncement{
+		Text:      text,
+		CreatedBy: user.ID,
+		Created:   DateTimeUTC(),
+	}
+
+	group.logActivity(GroupActivityAnnouncement, user.ID, group.ID, "")
+
+	go group.SendNotification(&PushNotification{
+		Title:   fmt.Sprintf(`New announcement in "%s"`, group.Name),
+		Message: text,
+		Icon:    "https:" + group.ImageLink("small"),
+		Link:    "https://" + Domain + group.Link(),
+		Type:    NotificationTypeGroupPost,
+	}, user.ID)
+
+	return nil
+}
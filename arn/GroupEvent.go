@@ -0,0 +1,345 @@
+package arn
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"net/url"
+	"sort"
+	"time"
+
+	"github.com/aerogo/nano"
+)
+
+// GroupEventReminderTime is how long before the start of an event the attendees get reminded.
+const GroupEventReminderTime = time.Hour
+
+// RSVP status values
+const (
+	GroupEventGoing    = "going"
+	GroupEventMaybe    = "maybe"
+	GroupEventDeclined = "declined"
+)
+
+// GroupEventRSVP is the response of a group member to an event invitation.
+type GroupEventRSVP struct {
+	UserID  UserID `json:"userId"`
+	Status  string `json:"status"`
+	Created string `json:"created"`
+}
+
+// User returns the user who responded.
+func (rsvp *GroupEventRSVP) User() *User {
+	user, _ := GetUser(rsvp.UserID)
+	return user
+}
+
+// GroupEvent is a scheduled event of a group, e.g. a watch party.
+type GroupEvent struct {
+	GroupID     GroupID           `json:"groupId"`
+	Title       string            `json:"title" editable:"true" maxLength:"100"`
+	Description string            `json:"description" editable:"true" type:"textarea" maxLength:"5000"`
+	AnimeID     AnimeID           `json:"animeId" editable:"true" tooltip:"The anime you're going to watch, if any."`
+	Start       string            `json:"start" editable:"true" tooltip:"Date and time in RFC 3339 format, e.g. 2019-04-20T18:00:00Z"`
+	End         string            `json:"end" editable:"true" tooltip:"Date and time in RFC 3339 format, e.g. 2019-04-20T20:00:00Z"`
+	Location    string            `json:"location" editable:"true" tooltip:"Where the event takes place, e.g. a Discord channel or a stream link."`
+	RSVPs       []*GroupEventRSVP `json:"rsvps"`
+
+	hasID
+	hasCreator
+	hasEditor
+
+	// Moved this boolean field to the bottom because the structure consumes less bytes that way
+	Reminded bool `json:"reminded"`
+}
+
+// NewGroupEvent creates a new event in the group.
+func NewGroupEvent(groupID GroupID, userID UserID) *GroupEvent {
+	return &GroupEvent{
+		GroupID: groupID,
+		RSVPs:   []*GroupEventRSVP{},
+		hasID: hasID{
+			ID: GenerateID("GroupEvent"),
+		},
+		hasCreator: hasCreator{
+			Created:   DateTimeUTC(),
+			CreatedBy: userID,
+		},
+	}
+}
+
+// Link returns the URI to the event page.
+func (event *GroupEvent) Link() string {
+	return "/group/" + event.GroupID + "/event/" + event.ID
+}
+
+// CalendarLink returns the URI to the calendar file of the event for the given user.
+func (event *GroupEvent) CalendarLink(user *User) string {
+	group := event.Group()
+
+	if group == nil {
+		return event.Link() + "/calendar.ics"
+	}
+
+	return event.Link() + "/calendar.ics" + group.calendarQuery(user)
+}
+
+// Group returns the group that hosts the event.
+func (event *GroupEvent) Group() *Group {
+	group, _ := GetGroup(event.GroupID)
+	return group
+}
+
+// Anime returns the anime that will be watched, if specified.
+func (event *GroupEvent) Anime() *Anime {
+	if event.AnimeID == "" {
+		return nil
+	}
+
+	anime, _ := GetAnime(event.AnimeID)
+	return anime
+}
+
+// StartTime returns the parsed start time.
+func (event *GroupEvent) StartTime() time.Time {
+	start, _ := time.Parse(time.RFC3339, event.Start)
+	return start
+}
+
+// EndTime returns the parsed end time. Events without an end last for two hours.
+func (event *GroupEvent) EndTime() time.Time {
+	end, err := time.Parse(time.RFC3339, event.End)
+
+	if err != nil {
+		return event.StartTime().Add(2 * time.Hour)
+	}
+
+	return end
+}
+
+// IsUpcoming tells you whether the event hasn't ended yet.
+func (event *GroupEvent) IsUpcoming() bool {
+	return event.EndTime().After(time.Now())
+}
+
+// NeedsReminder tells you whether the event starts soon and the attendees haven't been reminded yet.
+func (event *GroupEvent) NeedsReminder(now time.Time) bool {
+	if event.Reminded {
+		return false
+	}
+
+	start := event.StartTime()
+	return start.After(now) && start.Sub(now) <= GroupEventReminderTime
+}
+
+// Remind notifies everyone who is going or might go to the event.
+func (event *GroupEvent) Remind() {
+	notification := &PushNotification{
+		Title:   fmt.Sprintf("%s starts soon", event.Title),
+		Message: fmt.Sprintf("%s starts at %s.", event.Title, event.StartTime().UTC().Format("15:04 MST")),
+		Link:    "https://" + Domain + event.Link(),
+		Type:    NotificationTypeGroupEvent,
+	}
+
+	group := event.Group()
+
+	if group != nil {
+		notification.Icon = "https:" + group.ImageLink("small")
+	}
+
+	for _, rsvp := range event.RSVPs {
+		if rsvp.Status != GroupEventGoing && rsvp.Status != GroupEventMaybe {
+			continue
+		}
+
+		user := rsvp.User()
+
+		if user == nil {
+			continue
+		}
+
+		user.SendNotification(notification)
+	}
+
+	event.Reminded = true
+}
+
+// RSVPStatus returns the response of the user or an empty string if the user didn't respond yet.
+func (event *GroupEvent) RSVPStatus(userID UserID) string {
+	for _, rsvp := range event.RSVPs {
+		if rsvp.UserID == userID {
+			return rsvp.Status
+		}
+	}
+
+	return ""
+}
+
+// SetRSVP sets the response of the user. An empty status removes the response.
+func (event *GroupEvent) SetRSVP(userID UserID, status string) {
+	for index, rsvp := range event.RSVPs {
+		if rsvp.UserID == userID {
+			event.RSVPs = append(event.RSVPs[:index], event.RSVPs[index+1:]...)
+			break
+		}
+	}
+
+	if status == "" {
+		return
+	}
+
+	event.RSVPs = append(event.RSVPs, &GroupEventRSVP{
+		UserID:  userID,
+		Status:  status,
+		Created: DateTimeUTC(),
+	})
+}
+
+// RSVPsWithStatus returns the responses with the given status.
+func (event *GroupEvent) RSVPsWithStatus(status string) []*GroupEventRSVP {
+	var responses []*GroupEventRSVP
+
+	for _, rsvp := range event.RSVPs {
+		if rsvp.Status == status {
+			responses = append(responses, rsvp)
+		}
+	}
+
+	return responses
+}
+
+// CountRSVPs returns the number of responses with the given status.
+func (event *GroupEvent) CountRSVPs(status string) int {
+	return len(event.RSVPsWithStatus(status))
+}
+
+// TitleByUser returns the preferred title for the given user.
+func (event *GroupEvent) TitleByUser(user *User) string {
+	if event.Title == "" {
+		return "untitled"
+	}
+
+	return event.Title
+}
+
+// String implements the default string serialization.
+func (event *GroupEvent) String() string {
+	return event.TitleByUser(nil)
+}
+
+// TypeName returns the type name.
+func (event *GroupEvent) TypeName() string {
+	return "GroupEvent"
+}
+
+// Self returns the object itself.
+func (event *GroupEvent) Self() Loggable {
+	return event
+}
+
+// Events returns the events of the group, sorted by start time.
+func (group *Group) Events() []*GroupEvent {
+	events := FilterGroupEvents(func(event *GroupEvent) bool {
+		return event.GroupID == group.ID
+	})
+
+	SortGroupEventsByStart(events)
+	return events
+}
+
+// SortGroupEventsByStart puts the earliest events on top.
+func SortGroupEventsByStart(events []*GroupEvent) {
+	sort.Slice(events, func(i, j int) bool {
+		if events[i].Start == events[j].Start {
+			return events[i].Created < events[j].Created
+		}
+
+		return events[i].Start < events[j].Start
+	})
+}
+
+// GetGroupEvent ...
+func GetGroupEvent(id ID) (*GroupEvent, error) {
+	obj, err := DB.Get("GroupEvent", id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return obj.(*GroupEvent), nil
+}
+
+// StreamGroupEvents returns a stream of all group events.
+func StreamGroupEvents() <-chan *GroupEvent {
+	channel := make(chan *GroupEvent, nano.ChannelBufferSize)
+
+	go func() {
+		for obj := range DB.All("GroupEvent") {
+			channel <- obj.(*GroupEvent)
+		}
+
+		close(channel)
+	}()
+
+	return channel
+}
+
+// FilterGroupEvents filters all group events by a custom function.
+func FilterGroupEvents(filter func(*GroupEvent) bool) []*GroupEvent {
+	var filtered []*GroupEvent
+
+	for obj := range StreamGroupEvents() {
+		if filter(obj) {
+			filtered = append(filtered, obj)
+		}
+	}
+
+	return filtered
+}
+
+// CalendarLink returns the URI to the calendar feed of the group events for the given user.
+func (group *Group) CalendarLink(user *User) string {
+	return group.Link() + "/events.ics" + group.calendarQuery(user)
+}
+
+// calendarQuery returns the query that identifies the member in calendar links.
+// Calendar apps don't send the session cookie, so the events of groups
+// that can't be joined freely need the calendar token of the member.
+func (group *Group) calendarQuery(user *User) string {
+	if group.JoinMode == GroupJoinModeOpen || user == nil || !group.HasMember(user.ID) {
+		return ""
+	}
+
+	query := url.Values{}
+	query.Set("user", user.ID)
+	query.Set("token", user.CalendarToken())
+	return "?" + query.Encode()
+}
+
+// CalendarToken returns the secret that lets calendar apps download the group events of the user.
+// It is created when it's needed for the first time.
+func (user *User) CalendarToken() string {
+	settings := user.Settings()
+
+	if settings == nil {
+		return ""
+	}
+
+	if settings.Calendar.Token == "" {
+		settings.Calendar.Token = GenerateSecret()
+		settings.Save()
+	}
+
+	return settings.Calendar.Token
+}
+
+// VerifyCalendarToken tells you whether the token is the calendar token of the user.
+func (user *User) VerifyCalendarToken(token string) bool {
+	settings := user.Settings()
+
+	if settings == nil {
+		return false
+	}
+
+	expected := settings.Calendar.Token
+	return expected != "" && subtle.ConstantTimeCompare([]byte(token), []byte(expected)) == 1
+}
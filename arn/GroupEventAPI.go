@@ -0,0 +1,264 @@
+package arn
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/aerogo/aero"
+	"github.com/aerogo/api"
+	"github.com/animenotifier/notify.moe/arn/validate"
+)
+
+// Group event limits
+const (
+	maxGroupEventTitleLength       = 100
+	maxGroupEventDescriptionLength = 5000
+)
+
+// Force interface implementations
+var (
+	_ fmt.Stringer       = (*GroupEvent)(nil)
+	_ api.Newable        = (*GroupEvent)(nil)
+	_ api.Editable       = (*GroupEvent)(nil)
+	_ api.CustomEditable = (*GroupEvent)(nil)
+	_ api.Deletable      = (*GroupEvent)(nil)
+	_ api.Actionable     = (*GroupEvent)(nil)
+	_ api.Filter         = (*GroupEvent)(nil)
+)
+
+// Actions
+func init() {
+	API.RegisterActions("GroupEvent", []*api.Action{
+		// RSVP
+		{
+			Name:  "rsvp",
+			Route: "/rsvp",
+			Run: func(obj interface{}, ctx aero.Context) error {
+				event := obj.(*GroupEvent)
+				user := GetUserFromContext(ctx)
+				data, err := ctx.Request().Body().JSONObject()
+
+				if err != nil {
+					return err
+				}
+
+				status := stringValue(data, "status")
+
+				switch status {
+				case GroupEventGoing, GroupEventMaybe, GroupEventDeclined, "":
+				default:
+					return errors.New("Invalid RSVP status")
+				}
+
+				event.SetRSVP(user.ID, status)
+				event.Save()
+				return nil
+			},
+		},
+	})
+}
+
+// Create sets the data for a new group event with data we received from the API request.
+func (event *GroupEvent) Create(ctx aero.Context) error {
+	data, err := ctx.Request().Body().JSONObject()
+
+	if err != nil {
+		return err
+	}
+
+	user := GetUserFromContext(ctx)
+
+	if user == nil {
+		return errors.New("Not logged in")
+	}
+
+	group, err := GetGroup(stringValue(data, "groupId"))
+
+	if err != nil {
+		return errors.New("Group does not exist")
+	}
+
+	if !group.CanModerate(user.ID) {
+		return errors.New("Only moderators of the group can create events")
+	}
+
+	*event = *NewGroupEvent(group.ID, user.ID)
+	event.Title = strings.TrimSpace(stringValue(data, "title"))
+	event.Description = strings.TrimSpace(stringValue(data, "description"))
+	event.Location = strings.TrimSpace(stringValue(data, "location"))
+	event.AnimeID = stringValue(data, "animeId")
+	event.Start = stringValue(data, "start")
+	event.End = stringValue(data, "end")
+
+	err = event.Validate()
+
+	if err != nil {
+		return err
+	}
+
+	// Write log entry
+	logEntry := NewEditLogEntry(user.ID, "create", "GroupEvent", event.ID, "", "", "")
+	logEntry.Save()
+
+	go group.SendNotification(&PushNotification{
+		Title:   fmt.Sprintf(`New event in "%s"`, group.Name),
+		Message: fmt.Sprintf(`%s starts on %s.`, event.Title, event.StartTime().Format("Jan 2 at 15:04 MST")),
+		Icon:    "https:" + group.ImageLink("small"),
+		Link:    "https://" + Domain + event.Link(),
+		Type:    NotificationTypeGroupEvent,
+	}, user.ID)
+
+	return nil
+}
+
+// Validate returns an error if the event data is incomplete or inconsistent.
+func (event *GroupEvent) Validate() error {
+	if event.Title == "" {
+		return errors.New("Your event needs a title")
+	}
+
+	if len([]rune(event.Title)) > maxGroupEventTitleLength {
+		return fmt.Errorf("Titles can't be longer than %d characters", maxGroupEventTitleLength)
+	}
+
+	if len([]rune(event.Description)) > maxGroupEventDescriptionLength {
+		return fmt.Errorf("Descriptions can't be longer than %d characters", maxGroupEventDescriptionLength)
+	}
+
+	if !validate.DateTime(event.Start) {
+		return errors.New("Invalid start date")
+	}
+
+	if event.End != "" {
+		if !validate.DateTime(event.End) {
+			return errors.New("Invalid end date")
+		}
+
+		if !event.EndTime().After(event.StartTime()) {
+			return errors.New("The event needs to end after it started")
+		}
+	}
+
+	if event.AnimeID != "" && event.Anime() == nil {
+		return errors.New("Anime does not exist")
+	}
+
+	return nil
+}
+
+// Authorize returns an error if the given API request is not authorized.
+func (event *GroupEvent) Authorize(ctx aero.Context, action string) error {
+	user := GetUserFromContext(ctx)
+
+	if user == nil {
+		return errors.New("Not logged in")
+	}
+
+	// Group membership is checked when the event data is known
+	if action == "create" {
+		return nil
+	}
+
+	group := event.Group()
+
+	if group == nil {
+		return errors.New("Group does not exist")
+	}
+
+	switch action {
+	case "rsvp":
+		if !group.HasMember(user.ID) {
+			return errors.New("Only members of the group can respond to events")
+		}
+
+		return nil
+
+	case "delete":
+		if !group.CanModerate(user.ID) && !user.IsModerator() {
+			return errors.New("Only moderators of the group can delete events")
+		}
+
+		return nil
+	}
+
+	if !group.CanModerate(user.ID) {
+		return errors.New("Only moderators of the group can edit events")
+	}
+
+	return nil
+}
+
+// Edit validates the new values and creates an edit log entry.
+func (event *GroupEvent) Edit(ctx aero.Context, key string, value reflect.Value, newValue reflect.Value) (bool, error) {
+	changed := *event
+
+	switch key {
+	case "Title":
+		changed.Title = strings.TrimSpace(newValue.String())
+	case "Description":
+		changed.Description = strings.TrimSpace(newValue.String())
+	case "AnimeID":
+		changed.AnimeID = newValue.String()
+	case "Start":
+		changed.Start = newValue.String()
+	case "End":
+		changed.End = newValue.String()
+	}
+
+	err := changed.Validate()
+
+	if err != nil {
+		return true, err
+	}
+
+	// Changed dates need a new reminder
+	if key == "Start" {
+		event.Reminded = false
+	}
+
+	event.Edited = DateTimeUTC()
+	event.EditedBy = GetUserFromContext(ctx).ID
+	return edit(event, ctx, key, value, newValue)
+}
+
+// DeleteInContext deletes the event in the given context.
+func (event *GroupEvent) DeleteInContext(ctx aero.Context) error {
+	user := GetUserFromContext(ctx)
+
+	// Write log entry
+	logEntry := NewEditLogEntry(user.ID, "delete", "GroupEvent", event.ID, "", fmt.Sprint(event), "")
+	logEntry.Save()
+
+	return event.Delete()
+}
+
+// Delete deletes the event from the database.
+func (event *GroupEvent) Delete() error {
+	DB.Delete("GroupEvent", event.ID)
+	return nil
+}
+
+// ShouldFilter tells whether data needs to be filtered in the given context.
+// Events of groups that can't be joined freely are only visible to their members.
+func (event *GroupEvent) ShouldFilter(ctx aero.Context) bool {
+	group := event.Group()
+	return group == nil || !group.ActivitiesVisibleTo(GetUserFromContext(ctx))
+}
+
+// Filter removes the event details and the attendees.
+func (event *GroupEvent) Filter() {
+	event.Title = ""
+	event.Description = ""
+	event.AnimeID = ""
+	event.Start = ""
+	event.End = ""
+	event.Location = ""
+	event.RSVPs = nil
+}
+
+// Save saves the event in the database.
+func (event *GroupEvent) Save() {
+	DB.Set("GroupEvent", event.ID, event)
+}
@@ -0,0 +1,81 @@
+package arn_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/akyoto/assert"
+	"github.com/animenotifier/notify.moe/arn"
+)
+
+func TestGroupEventRSVP(t *testing.T) {
+	event := arn.NewGroupEvent("test-group", "test-group-owner")
+
+	event.SetRSVP("a", arn.GroupEventGoing)
+	event.SetRSVP("b", arn.GroupEventMaybe)
+	event.SetRSVP("c", arn.GroupEventGoing)
+	assert.Equal(t, event.CountRSVPs(arn.GroupEventGoing), 2)
+
+	// Changing the response replaces the old one
+	event.SetRSVP("c", arn.GroupEventDeclined)
+	assert.Equal(t, event.CountRSVPs(arn.GroupEventGoing), 1)
+	assert.Equal(t, event.RSVPStatus("c"), arn.GroupEventDeclined)
+	assert.Equal(t, len(event.RSVPs), 3)
+
+	event.SetRSVP("c", "")
+	assert.Equal(t, event.RSVPStatus("c"), "")
+	assert.Equal(t, len(event.RSVPs), 2)
+}
+
+func TestGroupEventValidate(t *testing.T) {
+	event := arn.NewGroupEvent("test-group", "test-group-owner")
+	assert.NotNil(t, event.Validate())
+
+	event.Title = "Watch party"
+	event.Start = "tomorrow"
+	assert.NotNil(t, event.Validate())
+
+	event.Start = "2019-04-20T18:00:00Z"
+	assert.Nil(t, event.Validate())
+	assert.Equal(t, event.EndTime(), event.StartTime().Add(2*time.Hour))
+
+	event.End = "2019-04-20T17:00:00Z"
+	assert.NotNil(t, event.Validate())
+
+	event.End = "2019-04-20T20:00:00Z"
+	assert.Nil(t, event.Validate())
+}
+
+func TestGroupEventNeedsReminder(t *testing.T) {
+	now := time.Date(2019, 4, 20, 17, 30, 0, 0, time.UTC)
+	event := arn.NewGroupEvent("test-group", "test-group-owner")
+	event.Start = "2019-04-20T18:00:00Z"
+	assert.True(t, event.NeedsReminder(now))
+
+	assert.False(t, event.NeedsReminder(now.Add(-time.Hour)))
+	assert.False(t, event.NeedsReminder(now.Add(time.Hour)))
+
+	event.Reminded = true
+	assert.False(t, event.NeedsReminder(now))
+}
+
+func TestGroupCalendarLink(t *testing.T) {
+	member := &arn.User{ID: "member"}
+	outsider := &arn.User{ID: "outsider"}
+
+	group := &arn.Group{
+		JoinMode: arn.GroupJoinModeOpen,
+		Members: []*arn.GroupMember{
+			{UserID: member.ID},
+		},
+	}
+
+	group.ID = "test-group"
+	assert.Equal(t, group.CalendarLink(member), "/group/test-group/events.ics")
+
+	// Outsiders never get a token, even if the feed requires one
+	group.JoinMode = arn.GroupJoinModeRequest
+	assert.Equal(t, group.CalendarLink(outsider), "/group/test-group/events.ics")
+	assert.Equal(t, group.CalendarLink(nil), "/group/test-group/events.ics")
+	assert.False(t, outsider.VerifyCalendarToken(""))
+}
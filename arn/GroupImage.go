@@ -1,12 +1,11 @@
 package arn
 
 import (
-	"bytes"
-	"image"
 	"path"
 	"time"
 
 	"github.com/akyoto/imageserver"
+	"github.com/animenotifier/notify.moe/arn/imageupload"
 )
 
 const (
@@ -25,6 +24,9 @@ const (
 	// GroupImageWebPQuality is the WebP quality of group images.
 	GroupImageWebPQuality = 70
 
+	// GroupImageAVIFQuality is the AVIF quality of group images.
+	GroupImageAVIFQuality = 55
+
 	// GroupImageJPEGQuality is the JPEG quality of group images.
 	GroupImageJPEGQuality = 70
 
@@ -72,6 +74,14 @@ var groupImageOutputs = []imageserver.Output{
 		Quality:   GroupImageWebPQuality + GroupImageQualityBonusLowDPI + GroupImageQualityBonusSmall,
 	},
 
+	// AVIF - Small
+	&imageupload.AVIFFile{
+		Directory: path.Join(Root, "images/groups/small/"),
+		Width:     GroupImageSmallWidth,
+		Height:    GroupImageSmallHeight,
+		Quality:   GroupImageAVIFQuality + GroupImageQualityBonusLowDPI + GroupImageQualityBonusSmall,
+	},
+
 	// WebP - Large
 	&imageserver.WebPFile{
 		Directory: path.Join(Root, "images/groups/large/"),
@@ -79,6 +89,14 @@ var groupImageOutputs = []imageserver.Output{
 		Height:    GroupImageLargeHeight,
 		Quality:   GroupImageWebPQuality + GroupImageQualityBonusLowDPI + GroupImageQualityBonusLarge,
 	},
+
+	// AVIF - Large
+	&imageupload.AVIFFile{
+		Directory: path.Join(Root, "images/groups/large/"),
+		Width:     GroupImageLargeWidth,
+		Height:    GroupImageLargeHeight,
+		Quality:   GroupImageAVIFQuality + GroupImageQualityBonusLowDPI + GroupImageQualityBonusLarge,
+	},
 }
 
 // Define the high DPI group image outputs
@@ -107,6 +125,14 @@ var groupImageOutputsHighDPI = []imageserver.Output{
 		Quality:   GroupImageWebPQuality + GroupImageQualityBonusSmall,
 	},
 
+	// AVIF - Small
+	&imageupload.AVIFFile{
+		Directory: path.Join(Root, "images/groups/small/"),
+		Width:     GroupImageSmallWidth * 2,
+		Height:    GroupImageSmallHeight * 2,
+		Quality:   GroupImageAVIFQuality + GroupImageQualityBonusSmall,
+	},
+
 	// WebP - Large
 	&imageserver.WebPFile{
 		Directory: path.Join(Root, "images/groups/large/"),
@@ -114,55 +140,58 @@ var groupImageOutputsHighDPI = []imageserver.Output{
 		Height:    GroupImageLargeHeight * 2,
 		Quality:   GroupImageWebPQuality + GroupImageQualityBonusLarge,
 	},
+
+	// AVIF - Large
+	&imageupload.AVIFFile{
+		Directory: path.Join(Root, "images/groups/large/"),
+		Width:     GroupImageLargeWidth * 2,
+		Height:    GroupImageLargeHeight * 2,
+		Quality:   GroupImageAVIFQuality + GroupImageQualityBonusLarge,
+	},
 }
 
 // SetImageBytes accepts a byte buffer that represents an image file and updates the group image.
 func (group *Group) SetImageBytes(data []byte) error {
-	// Decode
-	img, format, err := image.Decode(bytes.NewReader(data))
+	metaImage, err := imageupload.Decode(data)
 
 	if err != nil {
 		return err
 	}
 
-	return group.SetImage(&imageserver.MetaImage{
-		Image:  img,
-		Format: format,
-		Data:   data,
-	})
+	return group.SetImage(metaImage)
 }
 
 // SetImage sets the group image to the given MetaImage.
 func (group *Group) SetImage(metaImage *imageserver.MetaImage) error {
-	var lastError error
-
 	// Save the different image formats and sizes in low DPI
-	for _, output := range groupImageOutputs {
-		err := output.Save(metaImage, group.ID)
-
-		if err != nil {
-			lastError = err
-		}
-	}
+	lowDPIAVIF, lowDPIError := imageupload.Save(metaImage, group.ID, groupImageOutputs)
 
 	// Save the different image formats and sizes in high DPI
-	for _, output := range groupImageOutputsHighDPI {
-		err := output.Save(metaImage, group.ID+"@2")
-
-		if err != nil {
-			lastError = err
-		}
-	}
+	highDPIAVIF, highDPIError := imageupload.Save(metaImage, group.ID+"@2", groupImageOutputsHighDPI)
 
 	group.Image.Extension = metaImage.Extension()
 	group.Image.Width = metaImage.Image.Bounds().Dx()
 	group.Image.Height = metaImage.Image.Bounds().Dy()
 	group.Image.AverageColor = GetAverageColor(metaImage.Image)
 	group.Image.LastModified = time.Now().Unix()
-	return lastError
+	group.Image.AVIF = lowDPIAVIF && highDPIAVIF
+
+	if highDPIError != nil {
+		return highDPIError
+	}
+
+	return lowDPIError
 }
 
 // HasImage returns true if the group has an image.
 func (group *Group) HasImage() bool {
 	return group.Image.Extension != "" && group.Image.Width > 0
 }
+
+// ImageVariants returns the available sizes of the group image.
+func (group *Group) ImageVariants() []*ImageVariant {
+	return []*ImageVariant{
+		{Link: group.ImageLink("small"), Width: GroupImageSmallWidth, Height: GroupImageSmallHeight},
+		{Link: group.ImageLink("large"), Width: GroupImageLargeWidth, Height: GroupImageLargeHeight},
+	}
+}
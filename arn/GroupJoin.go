@@ -0,0 +1,331 @@
+package arn
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Group join modes
+const (
+	GroupJoinModeOpen    = ""
+	GroupJoinModeRequest = "request"
+	GroupJoinModeInvite  = "invite"
+)
+
+func init() {
+	DataLists["group-join-modes"] = []*Option{
+		{GroupJoinModeOpen, "Everyone can join"},
+		{GroupJoinModeRequest, "Moderators accept join requests"},
+		{GroupJoinModeInvite, "Invite only"},
+	}
+}
+
+// GroupJoinRequest is a request to join a group that a moderator needs to accept.
+type GroupJoinRequest struct {
+	UserID  UserID `json:"userId"`
+	Created string `json:"created"`
+}
+
+// User returns the user who wants to join the group.
+func (request *GroupJoinRequest) User() *User {
+	user, _ := GetUser(request.UserID)
+	return user
+}
+
+// GroupInvite is an invitation to join a group.
+type GroupInvite struct {
+	UserID    UserID `json:"userId"`
+	InvitedBy UserID `json:"invitedBy"`
+	Created   string `json:"created"`
+}
+
+// Join makes the given user join the group.
+// Depending on the join mode, users without an invitation send a join request instead.
+func (group *Group) Join(user *User) error {
+	if group.HasMember(user.ID) {
+		return errors.New("Already a member of this group")
+	}
+
+	switch {
+	case group.JoinMode == GroupJoinModeOpen || group.IsInvited(user.ID):
+		group.addMember(user.ID, GroupRoleMember)
+		group.OnJoin(user)
+		return nil
+
+	case group.JoinMode == GroupJoinModeRequest:
+		return group.RequestJoin(user)
+
+	default:
+		return errors.New("This group can only be joined with an invitation")
+	}
+}
+
+// Leave makes the given user leave the group.
+// Users who aren't a member yet withdraw their join request.
+func (group *Group) Leave(user *User) error {
+	group.membersMutex.Lock()
+	defer group.membersMutex.Unlock()
+
+	for index, member := range group.Members {
+		if member.UserID == user.ID {
+			if member.IsOwner() {
+				return errors.New("The owner can not leave the group, please contact a staff member")
+			}
+
+			group.Members = append(group.Members[:index], group.Members[index+1:]...)
+			return nil
+		}
+	}
+
+	group.JoinRequests = removeJoinRequest(group.JoinRequests, user.ID)
+	return nil
+}
+
+// RequestJoin adds a join request for the user and informs the moderators.
+func (group *Group) RequestJoin(user *User) error {
+	if group.FindJoinRequest(user.ID) != nil {
+		return errors.New("You already requested to join this group")
+	}
+
+	group.membersMutex.Lock()
+	group.JoinRequests = append(group.JoinRequests, &GroupJoinRequest{
+		UserID:  user.ID,
+		Created: DateTimeUTC(),
+	})
+	group.membersMutex.Unlock()
+
+	go func() {
+		for _, moderator := range group.Moderators() {
+			moderator.SendNotification(&PushNotification{
+				Title:   fmt.Sprintf(`%s wants to join your group`, user.Nick),
+				Message: fmt.Sprintf(`%s requested to join the group "%s".`, user.Nick, group.Name),
+				Icon:    "https:" + user.AvatarLink("large"),
+				Link:    "https://" + Domain + group.Link() + "/members",
+				Type:    NotificationTypeGroupJoin,
+			})
+		}
+	}()
+
+	return nil
+}
+
+// AcceptJoinRequest makes the user who requested to join a member of the group.
+func (group *Group) AcceptJoinRequest(userID UserID) error {
+	if group.FindJoinRequest(userID) == nil {
+		return errors.New("Join request not found")
+	}
+
+	group.addMember(userID, GroupRoleMember)
+	user, err := GetUser(userID)
+
+	if err == nil {
+		go user.SendNotification(&PushNotification{
+			Title:   "Welcome to " + group.Name,
+			Message: fmt.Sprintf(`Your request to join the group "%s" has been accepted.`, group.Name),
+			Icon:    "https:" + group.ImageLink("small"),
+			Link:    "https://" + Domain + group.Link(),
+			Type:    NotificationTypeGroupJoin,
+		})
+	}
+
+	return nil
+}
+
+// DeclineJoinRequest removes the join request of the user.
+func (group *Group) DeclineJoinRequest(userID UserID) error {
+	if group.FindJoinRequest(userID) == nil {
+		return errors.New("Join request not found")
+	}
+
+	group.membersMutex.Lock()
+	group.JoinRequests = removeJoinRequest(group.JoinRequests, userID)
+	group.membersMutex.Unlock()
+	return nil
+}
+
+// Invite invites the user to the group.
+// Invitations are accepted by joining the group, pending join requests are accepted immediately.
+func (group *Group) Invite(user *User, invitedBy *User) error {
+	if group.HasMember(user.ID) {
+		return errors.New("Already a member of this group")
+	}
+
+	if group.FindJoinRequest(user.ID) != nil {
+		return group.AcceptJoinRequest(user.ID)
+	}
+
+	if group.IsInvited(user.ID) {
+		return errors.New("This user has already been invited")
+	}
+
+	group.membersMutex.Lock()
+	group.Invites = append(group.Invites, &GroupInvite{
+		UserID:    user.ID,
+		InvitedBy: invitedBy.ID,
+		Created:   DateTimeUTC(),
+	})
+	group.membersMutex.Unlock()
+
+	go user.SendNotification(&PushNotification{
+		Title:   fmt.Sprintf(`%s invited you to a group`, invitedBy.Nick),
+		Message: fmt.Sprintf(`%s invited you to join the group "%s".`, invitedBy.Nick, group.Name),
+		Icon:    "https:" + group.ImageLink("small"),
+		Link:    "https://" + Domain + group.Link(),
+		Type:    NotificationTypeGroupJoin,
+	})
+
+	return nil
+}
+
+// SetRole changes the role of a member and logs the change in the group activities.
+// The role of the owner can't be changed.
+func (group *Group) SetRole(userID UserID, role string, changedBy UserID) error {
+	if role != GroupRoleModerator && role != GroupRoleMember {
+		return errors.New("Invalid role")
+	}
+
+	member := group.FindMember(userID)
+
+	if member == nil {
+		return errors.New("Not a member of this group")
+	}
+
+	if member.IsOwner() {
+		return errors.New("The role of the owner can't be changed")
+	}
+
+	if member.Role == role {
+		return nil
+	}
+
+	member.Role = role
+	group.logActivity(GroupActivityRole, changedBy, userID, role)
+	return nil
+}
+
+// RemoveMember removes the user from the group. The owner can't be removed.
+func (group *Group) RemoveMember(userID UserID) error {
+	member := group.FindMember(userID)
+
+	if member == nil {
+		return errors.New("Not a member of this group")
+	}
+
+	if member.IsOwner() {
+		return errors.New("The owner can't be removed from the group")
+	}
+
+	group.membersMutex.Lock()
+	defer group.membersMutex.Unlock()
+
+	for index, member := range group.Members {
+		if member.UserID == userID {
+			group.Members = append(group.Members[:index], group.Members[index+1:]...)
+			break
+		}
+	}
+
+	return nil
+}
+
+// FindJoinRequest returns the join request of the user, if available.
+func (group *Group) FindJoinRequest(userID UserID) *GroupJoinRequest {
+	group.membersMutex.Lock()
+	defer group.membersMutex.Unlock()
+
+	for _, request := range group.JoinRequests {
+		if request.UserID == userID {
+			return request
+		}
+	}
+
+	return nil
+}
+
+// IsInvited returns true if the user has been invited to the group.
+func (group *Group) IsInvited(userID UserID) bool {
+	group.membersMutex.Lock()
+	defer group.membersMutex.Unlock()
+
+	for _, invite := range group.Invites {
+		if invite.UserID == userID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// CanModerate returns true if the user is the owner or a moderator of the group.
+func (group *Group) CanModerate(userID UserID) bool {
+	member := group.FindMember(userID)
+	return member != nil && member.CanModerate()
+}
+
+// Moderators returns the owner and the moderators of the group.
+func (group *Group) Moderators() []*User {
+	group.membersMutex.Lock()
+	defer group.membersMutex.Unlock()
+	var moderators []*User
+
+	for _, member := range group.Members {
+		if !member.CanModerate() {
+			continue
+		}
+
+		user := member.User()
+
+		if user != nil {
+			moderators = append(moderators, user)
+		}
+	}
+
+	return moderators
+}
+
+// MembersWithRole returns the members that have the given role.
+func (group *Group) MembersWithRole(role string) []*GroupMember {
+	group.membersMutex.Lock()
+	defer group.membersMutex.Unlock()
+	var members []*GroupMember
+
+	for _, member := range group.Members {
+		if member.Role == role {
+			members = append(members, member)
+		}
+	}
+
+	return members
+}
+
+// addMember adds the user to the members and removes open requests and invitations.
+func (group *Group) addMember(userID UserID, role string) {
+	group.membersMutex.Lock()
+	defer group.membersMutex.Unlock()
+
+	group.Members = append(group.Members, &GroupMember{
+		UserID: userID,
+		Role:   role,
+		Joined: DateTimeUTC(),
+	})
+
+	group.JoinRequests = removeJoinRequest(group.JoinRequests, userID)
+
+	for index, invite := range group.Invites {
+		if invite.UserID == userID {
+			group.Invites = append(group.Invites[:index], group.Invites[index+1:]...)
+			break
+		}
+	}
+}
+
+// removeJoinRequest returns the requests without the one of the given user.
+func removeJoinRequest(requests []*GroupJoinRequest, userID UserID) []*GroupJoinRequest {
+	for index, request := range requests {
+		if request.UserID == userID {
+			return append(requests[:index], requests[index+1:]...)
+		}
+	}
+
+	return requests
+}
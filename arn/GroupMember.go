@@ -1,5 +1,12 @@
 package arn
 
+// Group member roles
+const (
+	GroupRoleOwner     = "owner"
+	GroupRoleModerator = "moderator"
+	GroupRoleMember    = "member"
+)
+
 // GroupMember ...
 type GroupMember struct {
 	UserID UserID `json:"userId"`
@@ -18,3 +25,13 @@ func (member *GroupMember) User() *User {
 	member.user, _ = GetUser(member.UserID)
 	return member.user
 }
+
+// IsOwner returns true if the member owns the group.
+func (member *GroupMember) IsOwner() bool {
+	return member.Role == GroupRoleOwner
+}
+
+// CanModerate returns true if the member can manage members, announcements and events.
+func (member *GroupMember) CanModerate() bool {
+	return member.Role == GroupRoleOwner || member.Role == GroupRoleModerator
+}
@@ -0,0 +1,126 @@
+package arn_test
+
+import (
+	"testing"
+
+	"github.com/akyoto/assert"
+	"github.com/animenotifier/notify.moe/arn"
+)
+
+func newTestGroup(joinMode string) *arn.Group {
+	group := &arn.Group{
+		Name:     "Test group",
+		JoinMode: joinMode,
+		Members: []*arn.GroupMember{
+			{UserID: "test-group-owner", Role: arn.GroupRoleOwner},
+		},
+	}
+
+	group.ID = "test-group"
+	return group
+}
+
+func TestGroupJoinModes(t *testing.T) {
+	user := &arn.User{ID: "test-group-user", Nick: "TestGroupUser"}
+
+	// Open
+	group := newTestGroup(arn.GroupJoinModeOpen)
+	assert.Nil(t, group.Join(user))
+	assert.True(t, group.HasMember(user.ID))
+	assert.Equal(t, group.FindMember(user.ID).Role, arn.GroupRoleMember)
+	assert.NotNil(t, group.Join(user))
+
+	// Request
+	group = newTestGroup(arn.GroupJoinModeRequest)
+	assert.Nil(t, group.Join(user))
+	assert.False(t, group.HasMember(user.ID))
+	assert.NotNil(t, group.FindJoinRequest(user.ID))
+	assert.NotNil(t, group.Join(user))
+
+	// Leaving withdraws the request
+	assert.Nil(t, group.Leave(user))
+	assert.Nil(t, group.FindJoinRequest(user.ID))
+
+	// Invite
+	group = newTestGroup(arn.GroupJoinModeInvite)
+	assert.NotNil(t, group.Join(user))
+	assert.False(t, group.HasMember(user.ID))
+
+	group.Invites = append(group.Invites, &arn.GroupInvite{UserID: user.ID})
+	assert.True(t, group.IsInvited(user.ID))
+	assert.Nil(t, group.Join(user))
+	assert.True(t, group.HasMember(user.ID))
+	assert.False(t, group.IsInvited(user.ID))
+}
+
+func TestGroupJoinRequests(t *testing.T) {
+	accepted := &arn.User{ID: "test-group-accepted", Nick: "Accepted"}
+	declined := &arn.User{ID: "test-group-declined", Nick: "Declined"}
+	group := newTestGroup(arn.GroupJoinModeRequest)
+
+	assert.Nil(t, group.RequestJoin(accepted))
+	assert.Nil(t, group.RequestJoin(declined))
+	assert.Equal(t, len(group.JoinRequests), 2)
+
+	assert.Nil(t, group.AcceptJoinRequest(accepted.ID))
+	assert.True(t, group.HasMember(accepted.ID))
+
+	assert.Nil(t, group.DeclineJoinRequest(declined.ID))
+	assert.False(t, group.HasMember(declined.ID))
+	assert.Equal(t, len(group.JoinRequests), 0)
+
+	assert.NotNil(t, group.AcceptJoinRequest(declined.ID))
+}
+
+func TestGroupRoles(t *testing.T) {
+	owner := &arn.User{ID: "test-group-owner"}
+	user := &arn.User{ID: "test-group-user", Nick: "TestGroupUser"}
+	group := newTestGroup(arn.GroupJoinModeOpen)
+	assert.Nil(t, group.Join(user))
+
+	assert.True(t, group.CanModerate(owner.ID))
+	assert.False(t, group.CanModerate(user.ID))
+
+	assert.Nil(t, group.SetRole(user.ID, arn.GroupRoleModerator, owner.ID))
+	assert.True(t, group.CanModerate(user.ID))
+	assert.Equal(t, len(group.MembersWithRole(arn.GroupRoleModerator)), 1)
+
+	// Role changes appear in the activities
+	assert.Nil(t, group.SetRole(user.ID, arn.GroupRoleModerator, owner.ID))
+	assert.Nil(t, group.SetRole(user.ID, arn.GroupRoleMember, owner.ID))
+	assert.Nil(t, group.SetRole(user.ID, arn.GroupRoleModerator, owner.ID))
+	assert.Equal(t, len(group.ActivityLog), 3)
+	assert.Equal(t, group.ActivityLog[0].Type, arn.GroupActivityRole)
+	assert.Equal(t, group.ActivityLog[0].ActorID, owner.ID)
+	assert.Equal(t, group.ActivityLog[0].Target, user.ID)
+	assert.Equal(t, group.ActivityLog[0].Role, arn.GroupRoleModerator)
+	assert.Equal(t, group.ActivityLog[1].Role, arn.GroupRoleMember)
+
+	// The owner stays the owner
+	assert.NotNil(t, group.SetRole(owner.ID, arn.GroupRoleMember, owner.ID))
+	assert.NotNil(t, group.SetRole(user.ID, arn.GroupRoleOwner, owner.ID))
+	assert.Equal(t, len(group.ActivityLog), 3)
+	assert.NotNil(t, group.Leave(owner))
+	assert.NotNil(t, group.RemoveMember(owner.ID))
+
+	assert.Nil(t, group.RemoveMember(user.ID))
+	assert.False(t, group.HasMember(user.ID))
+	assert.NotNil(t, group.RemoveMember(user.ID))
+}
+
+func TestGroupAnnouncementActivity(t *testing.T) {
+	owner := &arn.User{ID: "test-group-owner"}
+	group := newTestGroup(arn.GroupJoinModeOpen)
+
+	assert.Nil(t, group.Announce(owner, "Watch party on Saturday"))
+	assert.NotNil(t, group.Announcement)
+	assert.Equal(t, len(group.ActivityLog), 1)
+	assert.Equal(t, group.ActivityLog[0].Type, arn.GroupActivityAnnouncement)
+	assert.Equal(t, group.ActivityLog[0].ActorID, owner.ID)
+	assert.Equal(t, group.ActivityLog[0].Target, group.ID)
+
+	// Removing the announcement is not an activity
+	assert.Nil(t, group.Announce(owner, ""))
+	assert.Nil(t, group.Announcement)
+	assert.Equal(t, len(group.ActivityLog), 1)
+}
@@ -0,0 +1,32 @@
+package arn
+
+// hasSoftDeleted implements common methods for objects that moderators can remove without deleting them.
+type hasSoftDeleted struct {
+	Deleted      string `json:"deleted"`
+	DeletedBy    UserID `json:"deletedBy"`
+	DeleteReason string `json:"deleteReason"`
+}
+
+// SoftDelete hides the object and remembers who removed it and why.
+func (obj *hasSoftDeleted) SoftDelete(userID UserID, reason string) {
+	obj.Deleted = DateTimeUTC()
+	obj.DeletedBy = userID
+	obj.DeleteReason = reason
+}
+
+// Restore makes a removed object visible again.
+func (obj *hasSoftDeleted) Restore() {
+	obj.Deleted = ""
+	obj.DeletedBy = ""
+	obj.DeleteReason = ""
+}
+
+// IsSoftDeleted implements the SoftDeletable interface.
+func (obj *hasSoftDeleted) IsSoftDeleted() bool {
+	return obj.Deleted != ""
+}
+
+// SoftDeleteReason returns the reason the moderator gave for removing the object.
+func (obj *hasSoftDeleted) SoftDeleteReason() string {
+	return obj.DeleteReason
+}
@@ -0,0 +1,38 @@
+package arn
+
+// hasSubscribers implements common subscribe and unsubscribe methods.
+type hasSubscribers struct {
+	Subscribers []UserID `json:"subscribers"`
+}
+
+// Subscribe makes the given user ID receive notifications about new posts.
+func (obj *hasSubscribers) Subscribe(userID UserID) {
+	for _, id := range obj.Subscribers {
+		if id == userID {
+			return
+		}
+	}
+
+	obj.Subscribers = append(obj.Subscribers, userID)
+}
+
+// Unsubscribe stops the notifications for the given user ID.
+func (obj *hasSubscribers) Unsubscribe(userID UserID) {
+	for index, id := range obj.Subscribers {
+		if id == userID {
+			obj.Subscribers = append(obj.Subscribers[:index], obj.Subscribers[index+1:]...)
+			return
+		}
+	}
+}
+
+// IsSubscribed checks to see if the user receives notifications about new posts.
+func (obj *hasSubscribers) IsSubscribed(userID UserID) bool {
+	for _, id := range obj.Subscribers {
+		if id == userID {
+			return true
+		}
+	}
+
+	return false
+}
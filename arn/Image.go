@@ -7,4 +7,5 @@ type Image struct {
 	Height       int      `json:"height"`
 	AverageColor HSLColor `json:"averageColor"`
 	LastModified int64    `json:"lastModified"`
+	AVIF         bool     `json:"avif"`
 }
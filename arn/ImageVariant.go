@@ -0,0 +1,23 @@
+package arn
+
+// ImageVariant is one of the available sizes of an image.
+type ImageVariant struct {
+	Link   string
+	Width  int
+	Height int
+}
+
+// Ratio returns the aspect ratio (width / height) of the image variant.
+func (variant *ImageVariant) Ratio() float64 {
+	if variant.Height == 0 {
+		return 0
+	}
+
+	return float64(variant.Width) / float64(variant.Height)
+}
+
+// ImageAsset defines an object whose image is available in multiple variants.
+// The original upload is not a variant because it can be arbitrarily large.
+type ImageAsset interface {
+	ImageVariants() []*ImageVariant
+}
@@ -0,0 +1,199 @@
+package arn
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/aerogo/nano"
+)
+
+// JobStatus contains the schedule, the lock and the timing statistics of a background job.
+// The ID is the name of the job.
+// Jobs are run by the scheduler process, so the statistics are saved in the database
+// to make them available to the web server.
+type JobStatus struct {
+	Schedule      string  `json:"schedule"`
+	NextRun       string  `json:"nextRun"`
+	LockedBy      string  `json:"lockedBy"`
+	LockedUntil   string  `json:"lockedUntil"`
+	Attempt       int     `json:"attempt"`
+	LastStarted   string  `json:"lastStarted"`
+	LastFinished  string  `json:"lastFinished"`
+	LastDuration  float64 `json:"lastDuration"`
+	LastError     string  `json:"lastError"`
+	Runs          int     `json:"runs"`
+	Failures      int     `json:"failures"`
+	TotalDuration float64 `json:"totalDuration"`
+
+	hasID
+}
+
+// jobLockSettleTime is how long LockJob waits before it reads the lock back.
+// Runners in other processes locking the job at the same time have saved their lock by then.
+const jobLockSettleTime = time.Second
+
+// jobLockMutex makes checking and setting a lock atomic within the process.
+var jobLockMutex sync.Mutex
+
+// LockJob makes sure that only one instance of the job runs at the same time.
+// It returns the token needed to unlock the job or false if the job is already locked.
+// The lock expires after the given duration so that crashed runners don't block the job forever.
+func LockJob(name string, owner string, duration time.Duration) (string, bool) {
+	token := owner + "/" + GenerateSecret()[:16]
+
+	jobLockMutex.Lock()
+	status := getOrCreateJobStatus(name)
+
+	if status.IsRunning() {
+		jobLockMutex.Unlock()
+		return "", false
+	}
+
+	status.LockedBy = token
+	status.LockedUntil = time.Now().Add(duration).UTC().Format(time.RFC3339)
+	status.Save()
+	jobLockMutex.Unlock()
+
+	// If runners in other processes locked the job at the same time,
+	// the last lock wins and only its owner will read back its own token.
+	time.Sleep(jobLockSettleTime)
+	jobLockMutex.Lock()
+	defer jobLockMutex.Unlock()
+	status, err := GetJobStatus(name)
+
+	if err != nil || status.LockedBy != token {
+		return "", false
+	}
+
+	return token, true
+}
+
+// UnlockJob releases the lock of the job if it's held by the given token.
+func UnlockJob(name string, token string) {
+	jobLockMutex.Lock()
+	defer jobLockMutex.Unlock()
+
+	status, err := GetJobStatus(name)
+
+	if err != nil || status.LockedBy != token {
+		return
+	}
+
+	status.LockedBy = ""
+	status.LockedUntil = ""
+	status.Save()
+}
+
+// ScheduleJob saves the schedule and the next run time of the job.
+// The attempt is the number of retries of the next run.
+func ScheduleJob(name string, schedule string, next time.Time, attempt int) {
+	status := getOrCreateJobStatus(name)
+	status.Schedule = schedule
+	status.NextRun = next.UTC().Format(time.RFC3339)
+	status.Attempt = attempt
+	status.Save()
+}
+
+// RecordJobRun updates the statistics of the job after it finished.
+// The error is the one returned by the job process, if any.
+func RecordJobRun(name string, started time.Time, err error) {
+	status := getOrCreateJobStatus(name)
+	duration := time.Since(started).Seconds()
+
+	status.LastStarted = started.UTC().Format(time.RFC3339)
+	status.LastFinished = DateTimeUTC()
+	status.LastDuration = duration
+	status.Runs++
+	status.TotalDuration += duration
+
+	if err != nil {
+		status.LastError = err.Error()
+		status.Failures++
+	} else {
+		status.LastError = ""
+	}
+
+	status.Save()
+}
+
+// IsRunning tells you whether the job is currently locked by a runner.
+func (status *JobStatus) IsRunning() bool {
+	return status.LockedUntil != "" && status.LockedUntil > DateTimeUTC()
+}
+
+// AverageDuration returns the average duration of a run in seconds.
+func (status *JobStatus) AverageDuration() float64 {
+	if status.Runs == 0 {
+		return 0
+	}
+
+	return status.TotalDuration / float64(status.Runs)
+}
+
+// LastFinishedTime returns the time when the last run finished.
+func (status *JobStatus) LastFinishedTime() time.Time {
+	finished, _ := time.Parse(time.RFC3339, status.LastFinished)
+	return finished
+}
+
+// Save saves the job status in the database.
+func (status *JobStatus) Save() {
+	DB.Set("JobStatus", status.ID, status)
+}
+
+// getOrCreateJobStatus returns the statistics of the job or empty statistics if it never ran.
+func getOrCreateJobStatus(name string) *JobStatus {
+	status, err := GetJobStatus(name)
+
+	if err == nil {
+		return status
+	}
+
+	return &JobStatus{
+		hasID: hasID{
+			ID: name,
+		},
+	}
+}
+
+// GetJobStatus returns the statistics of the job with the given name.
+func GetJobStatus(name string) (*JobStatus, error) {
+	obj, err := DB.Get("JobStatus", name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return obj.(*JobStatus), nil
+}
+
+// StreamJobStatuses returns a stream of all job statistics.
+func StreamJobStatuses() <-chan *JobStatus {
+	channel := make(chan *JobStatus, nano.ChannelBufferSize)
+
+	go func() {
+		for obj := range DB.All("JobStatus") {
+			channel <- obj.(*JobStatus)
+		}
+
+		close(channel)
+	}()
+
+	return channel
+}
+
+// AllJobStatuses returns the statistics of all jobs sorted by name.
+func AllJobStatuses() []*JobStatus {
+	var all []*JobStatus
+
+	for obj := range StreamJobStatuses() {
+		all = append(all, obj)
+	}
+
+	sort.Slice(all, func(i, j int) bool {
+		return all[i].ID < all[j].ID
+	})
+
+	return all
+}
@@ -0,0 +1,201 @@
+package arn
+
+import (
+	"math"
+
+	"github.com/animenotifier/notify.moe/arn/limits"
+)
+
+// Sources of an uploaded anime list
+const (
+	ListImportSourceMyAnimeList = "myanimelist"
+	ListImportSourceAniList     = "anilist"
+	ListImportSourceKitsu       = "kitsu"
+)
+
+// Strategies for anime that are already in the anime list
+const (
+	// ListImportKeepMine only adds new anime and leaves existing entries untouched.
+	ListImportKeepMine = "keep-mine"
+
+	// ListImportTakeTheirs overwrites existing entries with the imported ones.
+	ListImportTakeTheirs = "take-theirs"
+
+	// ListImportTakeHigherProgress keeps the entry that is further ahead.
+	ListImportTakeHigherProgress = "take-higher-progress"
+)
+
+// ListImport is an anime list export from another website that the user uploaded.
+// It is kept until the user confirms the import in the preview.
+type ListImport struct {
+	UserID  UserID            `json:"userId" primary:"true"`
+	Source  string            `json:"source"`
+	Items   []*ListImportItem `json:"items"`
+	Created string            `json:"created"`
+}
+
+// ListImportItem is an entry of the uploaded anime list.
+// The rating has already been converted to the notify.moe scale.
+type ListImportItem struct {
+	ServiceID    string  `json:"serviceId"`
+	MALID        string  `json:"malId"`
+	Title        string  `json:"title"`
+	Status       string  `json:"status"`
+	Episodes     int     `json:"episodes"`
+	Rating       float64 `json:"rating"`
+	RewatchCount int     `json:"rewatchCount"`
+	Notes        string  `json:"notes"`
+}
+
+// ListImportMatch is an uploaded entry and the anime it refers to.
+// Anime is nil if the anime couldn't be found on notify.moe.
+type ListImportMatch struct {
+	Item  *ListImportItem
+	Anime *Anime
+}
+
+// NewListImport parses an anime list export and detects its source automatically.
+func NewListImport(userID UserID, data []byte) (*ListImport, error) {
+	source, items, err := parseListExport(data)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &ListImport{
+		UserID:  userID,
+		Source:  source,
+		Items:   items,
+		Created: DateTimeUTC(),
+	}, nil
+}
+
+// SourceName returns the name of the website the list was exported from.
+func (listImport *ListImport) SourceName() string {
+	switch listImport.Source {
+	case ListImportSourceMyAnimeList:
+		return "MyAnimeList"
+	case ListImportSourceAniList:
+		return "AniList"
+	case ListImportSourceKitsu:
+		return "Kitsu"
+	default:
+		return listImport.Source
+	}
+}
+
+// Matches finds the notify.moe anime for the uploaded entries using the anime mappings.
+func (listImport *ListImport) Matches() []*ListImportMatch {
+	matches := make([]*ListImportMatch, 0, len(listImport.Items))
+	var find func(item *ListImportItem) *Anime
+
+	switch listImport.Source {
+	case ListImportSourceAniList:
+		finder := NewAniListAnimeFinder()
+
+		find = func(item *ListImportItem) *Anime {
+			return finder.GetAnime(item.ServiceID, item.MALID)
+		}
+
+	default:
+		finder := NewAnimeFinder(listImport.Source + "/anime")
+
+		find = func(item *ListImportItem) *Anime {
+			return finder.GetAnime(item.ServiceID)
+		}
+	}
+
+	for _, item := range listImport.Items {
+		matches = append(matches, &ListImportMatch{
+			Item:  item,
+			Anime: find(item),
+		})
+	}
+
+	return matches
+}
+
+// Apply merges the matched entries into the anime list
+// and returns the number of entries that were added or changed.
+func (listImport *ListImport) Apply(animeList *AnimeList, matches []*ListImportMatch, strategy string) int {
+	changed := 0
+
+	for _, match := range matches {
+		if match.Anime == nil {
+			continue
+		}
+
+		item := &AnimeListItem{
+			AnimeID:  match.Anime.ID,
+			Status:   match.Item.Status,
+			Episodes: match.Item.Episodes,
+			Notes:    match.Item.Notes,
+			Rating: AnimeListItemRating{
+				Overall: match.Item.Rating,
+			},
+			RewatchCount: match.Item.RewatchCount,
+			Created:      DateTimeUTC(),
+			Edited:       DateTimeUTC(),
+		}
+
+		item.Rating.Clamp()
+
+		if item.Episodes < 0 {
+			item.Episodes = 0
+		}
+
+		if match.Anime.EpisodeCount != 0 && item.Episodes > match.Anime.EpisodeCount {
+			item.Episodes = match.Anime.EpisodeCount
+		}
+
+		if len([]rune(item.Notes)) > limits.DefaultTextAreaMaxLength {
+			item.Notes = string([]rune(item.Notes)[:limits.DefaultTextAreaMaxLength])
+		}
+
+		if animeList.Merge(item, strategy) {
+			changed++
+		}
+	}
+
+	return changed
+}
+
+// Save saves the uploaded list in the database.
+func (listImport *ListImport) Save() {
+	DB.Set("ListImport", listImport.UserID, listImport)
+}
+
+// Delete deletes the uploaded list from the database.
+func (listImport *ListImport) Delete() {
+	DB.Delete("ListImport", listImport.UserID)
+}
+
+// GetListImport returns the anime list that the user uploaded.
+func GetListImport(userID UserID) (*ListImport, error) {
+	obj, err := DB.Get("ListImport", userID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return obj.(*ListImport), nil
+}
+
+// IsValidListImportStrategy tells you whether the strategy exists.
+func IsValidListImportStrategy(strategy string) bool {
+	switch strategy {
+	case ListImportKeepMine, ListImportTakeTheirs, ListImportTakeHigherProgress:
+		return true
+	default:
+		return false
+	}
+}
+
+// listImportRating converts a rating to the notify.moe scale.
+func listImportRating(rating float64, maxRating float64) float64 {
+	if rating <= 0 || maxRating <= 0 {
+		return 0
+	}
+
+	return math.Round(rating/maxRating*MaxRating*10) / 10
+}
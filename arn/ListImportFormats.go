@@ -0,0 +1,214 @@
+package arn
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/animenotifier/anilist"
+	"github.com/animenotifier/kitsu"
+)
+
+// myAnimeListExport is the XML file that MyAnimeList offers as a list export.
+type myAnimeListExport struct {
+	Anime []struct {
+		ID             int    `xml:"series_animedb_id"`
+		Title          string `xml:"series_title"`
+		WatchedEpisode int    `xml:"my_watched_episodes"`
+		Score          int    `xml:"my_score"`
+		Status         string `xml:"my_status"`
+		TimesWatched   int    `xml:"my_times_watched"`
+		Comments       string `xml:"my_comments"`
+	} `xml:"anime"`
+}
+
+// aniListExport is the JSON file of an AniList list export or API response.
+type aniListExport struct {
+	anilist.AnimeList
+
+	Data *struct {
+		MediaListCollection *anilist.AnimeList `json:"MediaListCollection"`
+	} `json:"data"`
+}
+
+// parseListExport detects the format of an anime list export and returns its entries.
+func parseListExport(data []byte) (string, []*ListImportItem, error) {
+	data = bytes.TrimSpace(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf")))
+	var source string
+	var items []*ListImportItem
+	var err error
+
+	switch {
+	case bytes.HasPrefix(data, []byte("<")):
+		source = ListImportSourceMyAnimeList
+		items, err = parseMyAnimeListExport(data)
+
+	case bytes.HasPrefix(data, []byte("{")):
+		probe := struct {
+			Lists json.RawMessage `json:"lists"`
+			Data  json.RawMessage `json:"data"`
+		}{}
+
+		err = json.Unmarshal(data, &probe)
+
+		if err != nil {
+			return "", nil, errors.New("The file is not valid JSON")
+		}
+
+		if probe.Lists == nil && bytes.HasPrefix(probe.Data, []byte("[")) {
+			source = ListImportSourceKitsu
+			items, err = parseKitsuExport(data)
+		} else {
+			source = ListImportSourceAniList
+			items, err = parseAniListExport(data)
+		}
+
+	default:
+		return "", nil, errors.New("Unknown file format, please upload a MyAnimeList XML or an AniList or Kitsu JSON export")
+	}
+
+	if err != nil {
+		return "", nil, err
+	}
+
+	if len(items) == 0 {
+		return "", nil, errors.New("The file doesn't contain any anime")
+	}
+
+	return source, items, nil
+}
+
+// parseMyAnimeListExport reads the entries of a MyAnimeList XML export.
+func parseMyAnimeListExport(data []byte) ([]*ListImportItem, error) {
+	export := &myAnimeListExport{}
+	err := xml.Unmarshal(data, export)
+
+	if err != nil {
+		return nil, errors.New("The file is not a valid MyAnimeList XML export")
+	}
+
+	items := make([]*ListImportItem, 0, len(export.Anime))
+
+	for _, anime := range export.Anime {
+		items = append(items, &ListImportItem{
+			ServiceID:    strconv.Itoa(anime.ID),
+			Title:        strings.TrimSpace(anime.Title),
+			Status:       myAnimeListExportStatus(anime.Status),
+			Episodes:     anime.WatchedEpisode,
+			Rating:       listImportRating(float64(anime.Score), 10),
+			RewatchCount: anime.TimesWatched,
+			Notes:        strings.TrimSpace(anime.Comments),
+		})
+	}
+
+	return items, nil
+}
+
+// parseAniListExport reads the entries of an AniList JSON export.
+func parseAniListExport(data []byte) ([]*ListImportItem, error) {
+	export := &aniListExport{}
+	err := json.Unmarshal(data, export)
+
+	if err != nil {
+		return nil, errors.New("The file is not a valid AniList JSON export")
+	}
+
+	animeList := &export.AnimeList
+
+	if export.Data != nil && export.Data.MediaListCollection != nil {
+		animeList = export.Data.MediaListCollection
+	}
+
+	items := []*ListImportItem{}
+
+	for _, list := range animeList.Lists {
+		for _, entry := range list.Entries {
+			if entry.Anime == nil {
+				continue
+			}
+
+			rating := listImportRating(float64(entry.ScoreRaw), 100)
+
+			if rating == 0 {
+				rating = listImportRating(entry.Score, 10)
+			}
+
+			items = append(items, &ListImportItem{
+				ServiceID:    strconv.Itoa(entry.Anime.ID),
+				MALID:        strconv.Itoa(entry.Anime.MALID),
+				Title:        entry.Anime.Title.Romaji,
+				Status:       AniListAnimeListStatus(entry),
+				Episodes:     entry.Progress,
+				Rating:       rating,
+				RewatchCount: entry.Repeat,
+				Notes:        entry.Notes,
+			})
+		}
+	}
+
+	return items, nil
+}
+
+// parseKitsuExport reads the entries of a Kitsu library JSON export.
+func parseKitsuExport(data []byte) ([]*ListImportItem, error) {
+	export := &kitsu.LibraryEntryPage{}
+	err := json.Unmarshal(data, export)
+
+	if err != nil {
+		return nil, errors.New("The file is not a valid Kitsu JSON export")
+	}
+
+	titles := map[string]string{}
+
+	for _, anime := range export.Included {
+		titles[anime.ID] = anime.Attributes.CanonicalTitle
+	}
+
+	items := []*ListImportItem{}
+
+	for _, entry := range export.Data {
+		// Ignore non-anime entries
+		anime := entry.Relationships.Anime.Data
+
+		if anime == nil {
+			continue
+		}
+
+		status := KitsuStatusToARNStatus(entry.Attributes.Status)
+
+		if status == "" {
+			status = AnimeListStatusPlanned
+		}
+
+		items = append(items, &ListImportItem{
+			ServiceID:    anime.ID,
+			Title:        titles[anime.ID],
+			Status:       status,
+			Episodes:     entry.Attributes.Progress,
+			Rating:       listImportRating(float64(entry.Attributes.RatingTwenty), 20),
+			RewatchCount: entry.Attributes.ReconsumeCount,
+			Notes:        entry.Attributes.Notes,
+		})
+	}
+
+	return items, nil
+}
+
+// myAnimeListExportStatus converts the status names of MyAnimeList exports.
+func myAnimeListExportStatus(status string) string {
+	switch strings.ToLower(strings.TrimSpace(status)) {
+	case "watching", "1":
+		return AnimeListStatusWatching
+	case "completed", "2":
+		return AnimeListStatusCompleted
+	case "on-hold", "3":
+		return AnimeListStatusHold
+	case "dropped", "4":
+		return AnimeListStatusDropped
+	default:
+		return AnimeListStatusPlanned
+	}
+}
@@ -0,0 +1,157 @@
+package arn_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/akyoto/assert"
+	"github.com/animenotifier/notify.moe/arn"
+	"github.com/animenotifier/notify.moe/arn/limits"
+)
+
+func TestListImportMyAnimeList(t *testing.T) {
+	data := `<?xml version="1.0" encoding="UTF-8" ?>
+<myanimelist>
+	<myinfo><user_name>test</user_name></myinfo>
+	<anime>
+		<series_animedb_id>1</series_animedb_id>
+		<series_title><![CDATA[Cowboy Bebop]]></series_title>
+		<my_watched_episodes>26</my_watched_episodes>
+		<my_score>9</my_score>
+		<my_status>Completed</my_status>
+		<my_times_watched>1</my_times_watched>
+		<my_comments><![CDATA[Classic]]></my_comments>
+	</anime>
+	<anime>
+		<series_animedb_id>5</series_animedb_id>
+		<series_title><![CDATA[Cowboy Bebop: Tengoku no Tobira]]></series_title>
+		<my_watched_episodes>0</my_watched_episodes>
+		<my_score>0</my_score>
+		<my_status>Plan to Watch</my_status>
+	</anime>
+</myanimelist>`
+
+	listImport, err := arn.NewListImport("4J6qpK1ve", []byte(data))
+	assert.Nil(t, err)
+	assert.Equal(t, listImport.Source, arn.ListImportSourceMyAnimeList)
+	assert.Equal(t, len(listImport.Items), 2)
+
+	item := listImport.Items[0]
+	assert.Equal(t, item.ServiceID, "1")
+	assert.Equal(t, item.Title, "Cowboy Bebop")
+	assert.Equal(t, item.Status, arn.AnimeListStatusCompleted)
+	assert.Equal(t, item.Episodes, 26)
+	assert.Equal(t, item.Rating, 9.0)
+	assert.Equal(t, item.RewatchCount, 1)
+	assert.Equal(t, item.Notes, "Classic")
+	assert.Equal(t, listImport.Items[1].Status, arn.AnimeListStatusPlanned)
+}
+
+func TestListImportAniList(t *testing.T) {
+	data := `{"data":{"MediaListCollection":{"lists":[{"name":"Watching","entries":[
+		{"status":"CURRENT","scoreRaw":85,"progress":3,"repeat":0,"notes":"","media":{"id":21,"idMal":21,"title":{"romaji":"One Piece"}}}
+	]}]}}}`
+
+	listImport, err := arn.NewListImport("4J6qpK1ve", []byte(data))
+	assert.Nil(t, err)
+	assert.Equal(t, listImport.Source, arn.ListImportSourceAniList)
+	assert.Equal(t, len(listImport.Items), 1)
+	assert.Equal(t, listImport.Items[0].ServiceID, "21")
+	assert.Equal(t, listImport.Items[0].MALID, "21")
+	assert.Equal(t, listImport.Items[0].Status, arn.AnimeListStatusWatching)
+	assert.Equal(t, listImport.Items[0].Rating, 8.5)
+}
+
+func TestListImportKitsu(t *testing.T) {
+	data := `{
+		"data": [
+			{"id":"1","type":"libraryEntries","attributes":{"status":"on_hold","progress":4,"ratingTwenty":14,"reconsumeCount":0,"notes":"Later"},"relationships":{"anime":{"data":{"id":"7442","type":"anime"}}}},
+			{"id":"2","type":"libraryEntries","attributes":{"status":"current","progress":10},"relationships":{"manga":{}}}
+		],
+		"included": [
+			{"id":"7442","type":"anime","attributes":{"canonicalTitle":"Attack on Titan"}}
+		]
+	}`
+
+	listImport, err := arn.NewListImport("4J6qpK1ve", []byte(data))
+	assert.Nil(t, err)
+	assert.Equal(t, listImport.Source, arn.ListImportSourceKitsu)
+	assert.Equal(t, len(listImport.Items), 1)
+	assert.Equal(t, listImport.Items[0].Title, "Attack on Titan")
+	assert.Equal(t, listImport.Items[0].Status, arn.AnimeListStatusHold)
+	assert.Equal(t, listImport.Items[0].Rating, 7.0)
+	assert.Equal(t, listImport.Items[0].Notes, "Later")
+}
+
+func TestListImportInvalid(t *testing.T) {
+	_, err := arn.NewListImport("4J6qpK1ve", []byte("anime,episodes"))
+	assert.NotNil(t, err)
+
+	_, err = arn.NewListImport("4J6qpK1ve", []byte(`{"lists":[]}`))
+	assert.NotNil(t, err)
+}
+
+func TestAnimeListMerge(t *testing.T) {
+	newList := func() *arn.AnimeList {
+		return &arn.AnimeList{
+			Items: []*arn.AnimeListItem{
+				{AnimeID: "74y2cFiiR", Status: arn.AnimeListStatusWatching, Episodes: 10},
+			},
+		}
+	}
+
+	imported := func() *arn.AnimeListItem {
+		return &arn.AnimeListItem{AnimeID: "74y2cFiiR", Status: arn.AnimeListStatusWatching, Episodes: 4, Notes: "Imported"}
+	}
+
+	animeList := newList()
+	assert.False(t, animeList.Merge(imported(), arn.ListImportKeepMine))
+	assert.Equal(t, animeList.Items[0].Episodes, 10)
+
+	animeList = newList()
+	assert.True(t, animeList.Merge(imported(), arn.ListImportTakeTheirs))
+	assert.Equal(t, animeList.Items[0].Episodes, 4)
+	assert.Equal(t, animeList.Items[0].Notes, "Imported")
+
+	animeList = newList()
+	assert.False(t, animeList.Merge(imported(), arn.ListImportTakeHigherProgress))
+	assert.Equal(t, animeList.Items[0].Episodes, 10)
+
+	ahead := imported()
+	ahead.Episodes = 12
+	assert.True(t, animeList.Merge(ahead, arn.ListImportTakeHigherProgress))
+	assert.Equal(t, animeList.Items[0].Episodes, 12)
+
+	// Completing an entry remembers the date
+	completed := imported()
+	completed.Status = arn.AnimeListStatusCompleted
+	assert.True(t, animeList.Merge(completed, arn.ListImportTakeTheirs))
+	assert.NotEqual(t, animeList.Items[0].Completed, "")
+
+	added := imported()
+	added.AnimeID = "GUTck3qiR"
+	assert.True(t, animeList.Merge(added, arn.ListImportKeepMine))
+	assert.Equal(t, len(animeList.Items), 2)
+	assert.Equal(t, animeList.Items[1].Completed, "")
+}
+
+func TestListImportApplyLimits(t *testing.T) {
+	listImport := &arn.ListImport{}
+	animeList := &arn.AnimeList{}
+	anime := &arn.Anime{ID: "74y2cFiiR", EpisodeCount: 12}
+
+	matches := []*arn.ListImportMatch{
+		{
+			Item:  &arn.ListImportItem{Status: arn.AnimeListStatusCompleted, Episodes: 9000, Notes: strings.Repeat("ä", 30000)},
+			Anime: anime,
+		},
+	}
+
+	assert.Equal(t, listImport.Apply(animeList, matches, arn.ListImportTakeTheirs), 1)
+	assert.Equal(t, animeList.Items[0].Episodes, 12)
+	assert.Equal(t, len([]rune(animeList.Items[0].Notes)), limits.DefaultTextAreaMaxLength)
+
+	matches[0].Item.Episodes = -5
+	assert.Equal(t, listImport.Apply(animeList, matches, arn.ListImportTakeTheirs), 1)
+	assert.Equal(t, animeList.Items[0].Episodes, 0)
+}
@@ -0,0 +1,272 @@
+package arn
+
+import "time"
+
+// Directions of a list sync change
+const (
+	// ListSyncPull means the external entry overwrites the notify.moe entry.
+	ListSyncPull = "pull"
+
+	// ListSyncPush means the notify.moe entry overwrites the external entry.
+	ListSyncPush = "push"
+
+	// ListSyncDeleteLocal means the entry has been removed on the external list and is moved to the trash on notify.moe.
+	ListSyncDeleteLocal = "delete-local"
+
+	// ListSyncDeleteRemote means the entry has been removed on notify.moe and is deleted on the external list.
+	ListSyncDeleteRemote = "delete-remote"
+)
+
+// ListSyncEntry is an entry of an external anime list converted to the notify.moe format.
+type ListSyncEntry struct {
+	ID           int
+	Anime        *Anime
+	Status       string
+	Episodes     int
+	Score        int
+	RewatchCount int
+	Updated      time.Time
+}
+
+// StatusHumanReadable returns the human readable representation of the status.
+func (entry *ListSyncEntry) StatusHumanReadable() string {
+	item := AnimeListItem{Status: entry.Status}
+	return item.StatusHumanReadable()
+}
+
+// ListSyncChange is an entry that differs between notify.moe and an external anime list.
+// The most recently modified entry wins.
+type ListSyncChange struct {
+	Anime     *Anime
+	Local     *AnimeListItem
+	Remote    *ListSyncEntry
+	Direction string
+}
+
+// listSyncService is an external anime list that can be kept in sync with the anime list of a user.
+type listSyncService interface {
+	// entries loads the external anime list.
+	entries() ([]*ListSyncEntry, error)
+
+	// push saves the anime list item in the external anime list.
+	push(item *AnimeListItem) error
+
+	// remove deletes the entry from the external anime list.
+	remove(entry *ListSyncEntry) error
+
+	// mapping returns the name of the anime mapping of the service.
+	mapping() string
+
+	// score converts a notify.moe rating to the score format of the service.
+	score(rating float64) int
+
+	// rating converts a score of the service to a notify.moe rating.
+	rating(score int) float64
+
+	// synced returns the anime that were on both lists after the last synchronization.
+	// The caller needs to hold the lock of the anime list.
+	synced(animeList *AnimeList) *[]AnimeID
+}
+
+// listSyncChanges compares the anime list with the external anime list
+// and returns the entries that need to be synchronized.
+// Entries that were removed on one side since the last synchronization
+// are removed on the other side instead of being added again.
+func listSyncChanges(animeList *AnimeList, service listSyncService) ([]*ListSyncChange, error) {
+	remoteEntries, err := service.entries()
+
+	if err != nil {
+		return nil, err
+	}
+
+	changes := []*ListSyncChange{}
+	onRemote := map[AnimeID]bool{}
+	synced := map[AnimeID]bool{}
+
+	animeList.Lock()
+	for _, animeID := range *service.synced(animeList) {
+		synced[animeID] = true
+	}
+	animeList.Unlock()
+
+	for _, remote := range remoteEntries {
+		anime := remote.Anime
+
+		if onRemote[anime.ID] {
+			continue
+		}
+
+		onRemote[anime.ID] = true
+		local := animeList.Find(anime.ID)
+
+		if local == nil {
+			direction := ListSyncPull
+
+			if listSyncEntryRemovedLocally(animeList, anime.ID, remote, synced) {
+				direction = ListSyncDeleteRemote
+			}
+
+			changes = append(changes, &ListSyncChange{
+				Anime:     anime,
+				Remote:    remote,
+				Direction: direction,
+			})
+
+			continue
+		}
+
+		if !listSyncEntryDiffers(local, remote, service) {
+			continue
+		}
+
+		direction := ListSyncPush
+
+		if remote.Updated.After(local.EditedTime()) {
+			direction = ListSyncPull
+		}
+
+		changes = append(changes, &ListSyncChange{
+			Anime:     anime,
+			Local:     local,
+			Remote:    remote,
+			Direction: direction,
+		})
+	}
+
+	animeList.Lock()
+	items := make([]*AnimeListItem, len(animeList.Items))
+	copy(items, animeList.Items)
+	animeList.Unlock()
+
+	for _, local := range items {
+		if onRemote[local.AnimeID] || local.Private {
+			continue
+		}
+
+		anime := local.Anime()
+
+		if anime == nil || anime.GetMapping(service.mapping()) == "" {
+			continue
+		}
+
+		direction := ListSyncPush
+
+		if synced[local.AnimeID] {
+			direction = ListSyncDeleteLocal
+		}
+
+		changes = append(changes, &ListSyncChange{
+			Anime:     anime,
+			Local:     local,
+			Direction: direction,
+		})
+	}
+
+	return changes, nil
+}
+
+// applyListSync applies the changes to both anime lists
+// and remembers which entries are on both lists afterwards.
+// Local changes are applied while holding the anime list lock,
+// the external list is updated afterwards with copies of the entries.
+func applyListSync(animeList *AnimeList, service listSyncService, changes []*ListSyncChange) error {
+	var pushes []*AnimeListItem
+
+	animeList.Lock()
+
+	for _, change := range changes {
+		switch change.Direction {
+		case ListSyncPull:
+			local := animeList.find(change.Anime.ID)
+
+			if local == nil {
+				local = &AnimeListItem{
+					AnimeID: change.Anime.ID,
+					Created: DateTimeUTC(),
+				}
+
+				animeList.deleteFromTrash(change.Anime.ID)
+				animeList.Items = append(animeList.Items, local)
+			}
+
+			local.Status = change.Remote.Status
+			local.Episodes = change.Remote.Episodes
+			local.Rating.Overall = service.rating(change.Remote.Score)
+			local.Rating.Clamp()
+			local.RewatchCount = change.Remote.RewatchCount
+			local.Edited = DateTimeUTC()
+			local.updateCompleted(local.Edited)
+
+		case ListSyncPush:
+			local := animeList.find(change.Anime.ID)
+
+			// The entry has been removed since the changes were calculated
+			if local == nil {
+				continue
+			}
+
+			pushed := *local
+			pushes = append(pushes, &pushed)
+
+		case ListSyncDeleteLocal:
+			animeList.moveToTrash(change.Anime.ID)
+		}
+	}
+
+	animeList.Unlock()
+
+	for _, item := range pushes {
+		err := service.push(item)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, change := range changes {
+		if change.Direction != ListSyncDeleteRemote {
+			continue
+		}
+
+		err := service.remove(change.Remote)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	animeList.Lock()
+	synced := service.synced(animeList)
+	*synced = make([]AnimeID, 0, len(animeList.Items))
+
+	for _, item := range animeList.Items {
+		if !item.Private {
+			*synced = append(*synced, item.AnimeID)
+		}
+	}
+
+	animeList.Unlock()
+	animeList.Save()
+	return nil
+}
+
+// listSyncEntryRemovedLocally tells whether the external entry is missing
+// in the anime list because it has been removed on notify.moe.
+// Entries in the trash only count as removed if they haven't been edited externally since then.
+func listSyncEntryRemovedLocally(animeList *AnimeList, animeID AnimeID, remote *ListSyncEntry, synced map[AnimeID]bool) bool {
+	trashed := animeList.FindInTrash(animeID)
+
+	if trashed != nil {
+		return !remote.Updated.After(trashed.DeletedTime())
+	}
+
+	return synced[animeID]
+}
+
+// listSyncEntryDiffers tells whether the synchronized fields of both entries differ.
+func listSyncEntryDiffers(local *AnimeListItem, remote *ListSyncEntry, service listSyncService) bool {
+	return local.Status != remote.Status ||
+		local.Episodes != remote.Episodes ||
+		service.score(local.Rating.Overall) != remote.Score ||
+		local.RewatchCount != remote.RewatchCount
+}
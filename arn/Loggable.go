@@ -17,11 +17,15 @@ type Loggable interface {
 // edit creates an edit log entry.
 func edit(loggable Loggable, ctx aero.Context, key string, value reflect.Value, newValue reflect.Value) (consumed bool, err error) {
 	user := GetUserFromContext(ctx)
+	logEntry := NewFieldEditLogEntry(user.ID, loggable.TypeName(), loggable.GetID(), key, value, newValue)
+
+	// Changes by low-trust editors need to be reviewed first
+	if queuePendingEdit(loggable, user, logEntry) {
+		return true, nil
+	}
 
 	// Write log entry
-	logEntry := NewEditLogEntry(user.ID, "edit", loggable.TypeName(), loggable.GetID(), key, fmt.Sprint(value.Interface()), fmt.Sprint(newValue.Interface()))
 	logEntry.Save()
-
 	return false, nil
 }
 
@@ -0,0 +1,231 @@
+package arn
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/akyoto/stringutils/unsafe"
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/russross/blackfriday"
+	"golang.org/x/net/html"
+)
+
+var (
+	markdownPolicy     = newMarkdownPolicy()
+	markdownExtensions = blackfriday.CommonExtensions | blackfriday.Footnotes
+	markdownFlags      = blackfriday.CommonHTMLFlags | blackfriday.FootnoteReturnLinks
+)
+
+var (
+	// bareWebAddress finds addresses like "www.example.com" that don't specify a scheme.
+	bareWebAddress = regexp.MustCompile(`\bwww\.[^\s<>()\[\]]+`)
+
+	// externalLinkRelation is the rel attribute of links to other websites.
+	externalLinkRelation = regexp.MustCompile(`^(nofollow|ugc|noopener|noreferrer)( (nofollow|ugc|noopener|noreferrer))*$`)
+)
+
+// selfClosingTags don't need a closing tag.
+var selfClosingTags = map[string]bool{
+	"area":    true,
+	"base":    true,
+	"br":      true,
+	"col":     true,
+	"command": true,
+	"embed":   true,
+	"hr":      true,
+	"img":     true,
+	"input":   true,
+	"link":    true,
+	"meta":    true,
+	"param":   true,
+	"source":  true,
+}
+
+// RenderMarkdownWithFootnotes converts markdown to HTML and supports footnotes.
+// The anchor prefix keeps the footnote IDs unique when multiple texts appear on the same page.
+// Bare web addresses are turned into links.
+func RenderMarkdownWithFootnotes(code string, anchorPrefix string) string {
+	renderer := &markdownRenderer{
+		HTMLRenderer: blackfriday.NewHTMLRenderer(blackfriday.HTMLRendererParameters{
+			Flags:                      markdownFlags,
+			FootnoteAnchorPrefix:       anchorPrefix,
+			FootnoteReturnLinkContents: "↩",
+		}),
+	}
+
+	parser := blackfriday.New(blackfriday.WithExtensions(markdownExtensions))
+	document := parser.Parse(unsafe.StringToBytes(code))
+	linkBareWebAddresses(document)
+
+	var buffer bytes.Buffer
+	renderer.RenderHeader(&buffer, document)
+
+	document.Walk(func(node *blackfriday.Node, entering bool) blackfriday.WalkStatus {
+		return renderer.RenderNode(&buffer, node, entering)
+	})
+
+	renderer.RenderFooter(&buffer, document)
+	codeBytes := markdownPolicy.SanitizeBytes(buffer.Bytes())
+	rendered := unsafe.BytesToString(codeBytes)
+	err := checkUnclosedTags(rendered)
+
+	if err != nil {
+		return err.Error()
+	}
+
+	return rendered
+}
+
+// newMarkdownPolicy creates the sanitization policy for user generated HTML.
+// It extends the UGC policy to keep the attributes of external links.
+func newMarkdownPolicy() *bluemonday.Policy {
+	policy := bluemonday.UGCPolicy()
+	policy.AllowAttrs("rel").Matching(externalLinkRelation).OnElements("a")
+	policy.AllowAttrs("target").Matching(regexp.MustCompile(`^_blank$`)).OnElements("a")
+	return policy
+}
+
+// markdownRenderer renders links to other websites with nofollow and in a new tab.
+type markdownRenderer struct {
+	*blackfriday.HTMLRenderer
+}
+
+// RenderNode renders a single node.
+func (renderer *markdownRenderer) RenderNode(w io.Writer, node *blackfriday.Node, entering bool) blackfriday.WalkStatus {
+	if node.Type != blackfriday.Link || node.NoteID != 0 || !isExternalLink(node.Destination) {
+		return renderer.HTMLRenderer.RenderNode(w, node, entering)
+	}
+
+	if !entering {
+		_, _ = io.WriteString(w, "</a>")
+		return blackfriday.GoToNext
+	}
+
+	_, _ = io.WriteString(w, `<a href="`)
+	_, _ = io.WriteString(w, html.EscapeString(string(node.Destination)))
+	_, _ = io.WriteString(w, `"`)
+
+	if len(node.Title) > 0 {
+		_, _ = io.WriteString(w, ` title="`)
+		_, _ = io.WriteString(w, html.EscapeString(string(node.Title)))
+		_, _ = io.WriteString(w, `"`)
+	}
+
+	_, _ = io.WriteString(w, ` rel="nofollow ugc noopener" target="_blank">`)
+	return blackfriday.GoToNext
+}
+
+// isExternalLink tells whether the link points to a different website than the one we're running on.
+func isExternalLink(destination []byte) bool {
+	link, err := url.Parse(string(destination))
+
+	if err != nil || link.Host == "" {
+		return false
+	}
+
+	if link.Scheme != "http" && link.Scheme != "https" {
+		return false
+	}
+
+	host := strings.ToLower(link.Hostname())
+	return host != Domain && !strings.HasSuffix(host, "."+Domain)
+}
+
+// linkBareWebAddresses converts addresses like "www.example.com" in text nodes to links.
+// Addresses with a scheme are already handled by the autolink extension.
+func linkBareWebAddresses(document *blackfriday.Node) {
+	textNodes := []*blackfriday.Node{}
+
+	document.Walk(func(node *blackfriday.Node, entering bool) blackfriday.WalkStatus {
+		switch node.Type {
+		case blackfriday.Link, blackfriday.Image, blackfriday.Code, blackfriday.CodeBlock, blackfriday.HTMLSpan, blackfriday.HTMLBlock:
+			return blackfriday.SkipChildren
+
+		case blackfriday.Text:
+			if entering {
+				textNodes = append(textNodes, node)
+			}
+		}
+
+		return blackfriday.GoToNext
+	})
+
+	for _, node := range textNodes {
+		text := node.Literal
+		matches := bareWebAddress.FindAllIndex(text, -1)
+
+		if len(matches) == 0 {
+			continue
+		}
+
+		position := 0
+
+		for _, match := range matches {
+			start := match[0]
+			end := start + len(bytes.TrimRight(text[start:match[1]], ".,;:!?'\"*_"))
+
+			if start > position {
+				node.InsertBefore(newTextNode(text[position:start]))
+			}
+
+			address := text[start:end]
+			link := blackfriday.NewNode(blackfriday.Link)
+			link.Destination = append([]byte("http://"), address...)
+			link.AppendChild(newTextNode(address))
+			node.InsertBefore(link)
+			position = end
+		}
+
+		if position < len(text) {
+			node.InsertBefore(newTextNode(text[position:]))
+		}
+
+		node.Unlink()
+	}
+}
+
+// newTextNode creates a text node with the given contents.
+func newTextNode(text []byte) *blackfriday.Node {
+	node := blackfriday.NewNode(blackfriday.Text)
+	node.Literal = text
+	return node
+}
+
+// checkUnclosedTags makes sure that user generated HTML can't break the page layout.
+func checkUnclosedTags(code string) error {
+	tokenizer := html.NewTokenizer(strings.NewReader(code))
+	stack := []string{}
+
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			return nil
+
+		case html.StartTagToken:
+			tagName, _ := tokenizer.TagName()
+
+			if !selfClosingTags[string(tagName)] {
+				stack = append(stack, string(tagName))
+			}
+
+		case html.EndTagToken:
+			tagName, _ := tokenizer.TagName()
+
+			if len(stack) == 0 {
+				return fmt.Errorf("Closing tag without opening tag: %s", tagName)
+			}
+
+			lastTagName := stack[len(stack)-1]
+
+			if lastTagName != string(tagName) {
+				return fmt.Errorf("Unclosed HTML tag: %s", lastTagName)
+			}
+
+			stack = stack[:len(stack)-1]
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package arn_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/akyoto/assert"
+	"github.com/animenotifier/notify.moe/arn"
+)
+
+func TestFootnotes(t *testing.T) {
+	text := "Episode 3 was great[^1].\n\n[^1]: Especially the ending."
+	replies := []*arn.Post{{}, {}}
+	replies[0].ID = "first"
+	replies[0].Text = text
+	replies[1].ID = "second"
+	replies[1].Text = text
+
+	first := replies[0].HTML()
+	second := replies[1].HTML()
+
+	// Links resolve to the footnote of the same post
+	assert.Contains(t, first, `href="#fn:post-first-1"`)
+	assert.Contains(t, first, `id="fn:post-first-1"`)
+	assert.Contains(t, first, `id="fnref:post-first-1"`)
+	assert.Contains(t, first, "Especially the ending.")
+
+	// Footnote IDs don't collide across replies
+	assert.Contains(t, second, `id="fn:post-second-1"`)
+	assert.NotContains(t, second, `id="fn:post-first-1"`)
+}
+
+func TestAutoLink(t *testing.T) {
+	render := func(text string) string {
+		thread := &arn.Thread{}
+		thread.ID = "autolink"
+		thread.Text = text
+		return thread.HTML()
+	}
+
+	rendered := render("Trailer: https://example.com/pv and www.example.org/news.")
+	assert.Contains(t, rendered, `<a href="https://example.com/pv" rel="nofollow ugc noopener" target="_blank">https://example.com/pv</a>`)
+	assert.Contains(t, rendered, `<a href="http://www.example.org/news" rel="nofollow ugc noopener" target="_blank">www.example.org/news</a>.`)
+
+	// Code spans stay untouched
+	rendered = render("Run `curl https://example.com` or `ping www.example.com`")
+	assert.NotContains(t, rendered, "<a ")
+	assert.Contains(t, rendered, "<code>curl https://example.com</code>")
+
+	// Markdown links are not linked twice
+	rendered = render("[www.example.com](https://www.example.com)")
+	assert.Equal(t, strings.Count(rendered, "<a "), 1)
+
+	// Internal links open in the same tab
+	rendered = render("See https://" + arn.Domain + "/forum")
+	assert.Contains(t, rendered, `href="https://`+arn.Domain+`/forum"`)
+	assert.NotContains(t, rendered, "_blank")
+}
+
+func TestAutoLinkOtherDomain(t *testing.T) {
+	domain := arn.Domain
+	arn.Domain = "beta.notify.moe"
+	defer func() { arn.Domain = domain }()
+
+	thread := &arn.Thread{}
+	thread.ID = "autolink-beta"
+	thread.Text = "See https://beta.notify.moe/forum"
+
+	rendered := thread.HTML()
+	assert.Contains(t, rendered, `href="https://beta.notify.moe/forum"`)
+	assert.NotContains(t, rendered, "_blank")
+}
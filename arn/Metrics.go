@@ -0,0 +1,89 @@
+package arn
+
+import (
+	"sort"
+
+	"github.com/animenotifier/notify.moe/arn/metrics"
+)
+
+// Push delivery results
+const (
+	PushDeliverySuccess = "success"
+	PushDeliveryFailure = "failure"
+	PushDeliveryExpired = "expired"
+)
+
+var (
+	// NotificationsSent counts the notifications that have been sent to users by notification type.
+	NotificationsSent = metrics.NewCounter(
+		"notify_notifications_sent_total",
+		"Number of notifications sent to users by this process.",
+		"type",
+	)
+
+	// PushDeliveries counts the push notification deliveries to browsers by result.
+	PushDeliveries = metrics.NewCounter(
+		"notify_push_deliveries_total",
+		"Number of push notification deliveries by this process.",
+		"result",
+	)
+)
+
+// Database and job metrics are read from the database when the metrics are requested.
+func init() {
+	metrics.NewGaugeFunc("notify_database_objects", "Number of objects in the database.", DatabaseObjectCounts, "type")
+
+	metrics.NewGaugeFunc("notify_job_last_duration_seconds", "Duration of the last run of the background job.", jobSamples(func(status *JobStatus) float64 {
+		return status.LastDuration
+	}), "job")
+
+	metrics.NewGaugeFunc("notify_job_last_finished_timestamp_seconds", "Time when the last run of the background job finished.", jobSamples(func(status *JobStatus) float64 {
+		return float64(status.LastFinishedTime().Unix())
+	}), "job")
+
+	metrics.NewCounterFunc("notify_job_runs_total", "Number of runs of the background job.", jobSamples(func(status *JobStatus) float64 {
+		return float64(status.Runs)
+	}), "job")
+
+	metrics.NewCounterFunc("notify_job_failures_total", "Number of runs of the background job that exited with an error.", jobSamples(func(status *JobStatus) float64 {
+		return float64(status.Failures)
+	}), "job")
+
+	metrics.NewCounterFunc("notify_job_duration_seconds_total", "Total duration of all runs of the background job.", jobSamples(func(status *JobStatus) float64 {
+		return status.TotalDuration
+	}), "job")
+}
+
+// jobSamples returns a collect function that reads one value of each job status.
+func jobSamples(value func(*JobStatus) float64) func() []metrics.Sample {
+	return func() []metrics.Sample {
+		var samples []metrics.Sample
+
+		for status := range StreamJobStatuses() {
+			samples = append(samples, metrics.Sample{
+				LabelValues: []string{status.ID},
+				Value:       value(status),
+			})
+		}
+
+		return samples
+	}
+}
+
+// DatabaseObjectCounts returns the number of objects per type, sorted by type name.
+func DatabaseObjectCounts() []metrics.Sample {
+	var samples []metrics.Sample
+
+	for typeName := range DB.Types() {
+		samples = append(samples, metrics.Sample{
+			LabelValues: []string{typeName},
+			Value:       float64(DB.Collection(typeName).Count()),
+		})
+	}
+
+	sort.Slice(samples, func(i, j int) bool {
+		return samples[i].LabelValues[0] < samples[j].LabelValues[0]
+	})
+
+	return samples
+}
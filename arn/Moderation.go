@@ -0,0 +1,23 @@
+package arn
+
+// moderatorActions are the API actions on threads and posts that only moderators may use.
+var moderatorActions = map[string]bool{
+	"delete":     true,
+	"lock":       true,
+	"unlock":     true,
+	"pin":        true,
+	"unpin":      true,
+	"move":       true,
+	"softdelete": true,
+	"restore":    true,
+}
+
+// IsModeratorAction tells you whether the API action requires moderator rights.
+func IsModeratorAction(action string) bool {
+	return moderatorActions[action]
+}
+
+// IsModerator returns true if the user can moderate the forum.
+func (user *User) IsModerator() bool {
+	return user.Role == "admin" || user.Role == "editor"
+}
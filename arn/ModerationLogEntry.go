@@ -0,0 +1,130 @@
+package arn
+
+import (
+	"sort"
+
+	"github.com/aerogo/nano"
+)
+
+// ModerationLogEntry records an action a moderator took on a thread or post.
+type ModerationLogEntry struct {
+	UserID     UserID `json:"userId"`
+	Action     string `json:"action"`
+	ObjectID   ID     `json:"objectId"`
+	ObjectType string `json:"objectType"`
+	Reason     string `json:"reason"`
+	Details    string `json:"details"`
+	Created    string `json:"created"`
+
+	hasID
+}
+
+// NewModerationLogEntry creates a new moderation log entry.
+func NewModerationLogEntry(userID UserID, action string, objectType string, objectID ID, reason string, details string) *ModerationLogEntry {
+	return &ModerationLogEntry{
+		hasID: hasID{
+			ID: GenerateID("ModerationLogEntry"),
+		},
+		UserID:     userID,
+		Action:     action,
+		ObjectType: objectType,
+		ObjectID:   objectID,
+		Reason:     reason,
+		Details:    details,
+		Created:    DateTimeUTC(),
+	}
+}
+
+// User returns the moderator who took the action.
+func (entry *ModerationLogEntry) User() *User {
+	user, _ := GetUser(entry.UserID)
+	return user
+}
+
+// Object returns the thread or post the action was taken on.
+func (entry *ModerationLogEntry) Object() interface{} {
+	obj, _ := DB.Get(entry.ObjectType, entry.ObjectID)
+	return obj
+}
+
+// ActionHumanReadable returns the human readable version of the action.
+func (entry *ModerationLogEntry) ActionHumanReadable() string {
+	switch entry.Action {
+	case "lock":
+		return "Locked"
+
+	case "unlock":
+		return "Unlocked"
+
+	case "pin":
+		return "Pinned"
+
+	case "unpin":
+		return "Unpinned"
+
+	case "move":
+		return "Moved"
+
+	case "softdelete":
+		return "Removed"
+
+	case "restore":
+		return "Restored"
+
+	case "delete":
+		return "Deleted permanently"
+
+	case "report-" + ReportStatusDismissed:
+		return "Dismissed a report"
+
+	case "report-" + ReportStatusActioned:
+		return "Handled a report"
+
+	case "edit-reverted":
+		return "Reverted an edit"
+
+	case "edit-rejected":
+		return "Rejected an edit"
+
+	default:
+		return entry.Action
+	}
+}
+
+// Save saves the log entry in the database.
+func (entry *ModerationLogEntry) Save() {
+	DB.Set("ModerationLogEntry", entry.ID, entry)
+}
+
+// StreamModerationLogEntries returns a stream of all moderation log entries.
+func StreamModerationLogEntries() <-chan *ModerationLogEntry {
+	channel := make(chan *ModerationLogEntry, nano.ChannelBufferSize)
+
+	go func() {
+		for obj := range DB.All("ModerationLogEntry") {
+			channel <- obj.(*ModerationLogEntry)
+		}
+
+		close(channel)
+	}()
+
+	return channel
+}
+
+// AllModerationLogEntries returns a slice of all moderation log entries.
+func AllModerationLogEntries() []*ModerationLogEntry {
+	all := make([]*ModerationLogEntry, 0, DB.Collection("ModerationLogEntry").Count())
+
+	for obj := range StreamModerationLogEntries() {
+		all = append(all, obj)
+	}
+
+	return all
+}
+
+// SortModerationLogEntriesLatestFirst puts the latest entries on top.
+func SortModerationLogEntriesLatestFirst(entries []*ModerationLogEntry) {
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Created > entries[j].Created
+	})
+}
@@ -0,0 +1,299 @@
+package arn
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/aerogo/http/client"
+	"golang.org/x/oauth2"
+)
+
+// myAnimeListAPI is the base URL of the MyAnimeList API.
+const myAnimeListAPI = "https://api.myanimelist.net/v2"
+
+// myAnimeListListPage is a page of an anime list returned by the MyAnimeList API.
+type myAnimeListListPage struct {
+	Data []struct {
+		Node struct {
+			ID int `json:"id"`
+		} `json:"node"`
+
+		ListStatus struct {
+			Status       string `json:"status"`
+			Score        int    `json:"score"`
+			Episodes     int    `json:"num_episodes_watched"`
+			IsRewatching bool   `json:"is_rewatching"`
+			RewatchCount int    `json:"num_times_rewatched"`
+			UpdatedAt    string `json:"updated_at"`
+		} `json:"list_status"`
+	} `json:"data"`
+
+	Paging struct {
+		Next string `json:"next"`
+	} `json:"paging"`
+}
+
+// MyAnimeListOAuth returns the OAuth configuration for linking MyAnimeList accounts.
+func MyAnimeListOAuth() *oauth2.Config {
+	return &oauth2.Config{
+		ClientID:     APIKeys.MyAnimeList.ID,
+		ClientSecret: APIKeys.MyAnimeList.Secret,
+		RedirectURL:  "https://" + Domain + "/auth/myanimelist/callback",
+		Endpoint: oauth2.Endpoint{
+			AuthURL:   "https://myanimelist.net/v1/oauth2/authorize",
+			TokenURL:  "https://myanimelist.net/v1/oauth2/token",
+			AuthStyle: oauth2.AuthStyleInParams,
+		},
+	}
+}
+
+// IsMyAnimeListLinked tells whether the user authorized notify.moe to edit the MyAnimeList anime list.
+func (user *User) IsMyAnimeListLinked() bool {
+	return user.Accounts.MyAnimeList.ID != 0 && user.Accounts.MyAnimeList.AccessToken != ""
+}
+
+// SetMyAnimeListToken saves the OAuth token of the linked MyAnimeList account.
+func (user *User) SetMyAnimeListToken(token *oauth2.Token) {
+	user.Accounts.MyAnimeList.AccessToken = token.AccessToken
+	user.Accounts.MyAnimeList.RefreshToken = token.RefreshToken
+	user.Accounts.MyAnimeList.TokenExpiry = token.Expiry.UTC().Format(time.RFC3339)
+}
+
+// MyAnimeListSyncChanges compares the anime list with the MyAnimeList anime list
+// and returns the entries that need to be synchronized.
+func (user *User) MyAnimeListSyncChanges() ([]*ListSyncChange, error) {
+	if !user.IsMyAnimeListLinked() {
+		return nil, errors.New("MyAnimeList account is not linked")
+	}
+
+	return listSyncChanges(user.AnimeList(), &myAnimeListSync{user: user})
+}
+
+// SyncMyAnimeList applies the changes to both anime lists.
+func (user *User) SyncMyAnimeList(changes []*ListSyncChange) error {
+	return applyListSync(user.AnimeList(), &myAnimeListSync{user: user}, changes)
+}
+
+// PushMyAnimeListEntry saves the anime list item in the MyAnimeList anime list of the user.
+func (user *User) PushMyAnimeListEntry(item *AnimeListItem) error {
+	if !user.IsMyAnimeListLinked() {
+		return errors.New("MyAnimeList account is not linked")
+	}
+
+	anime := item.Anime()
+
+	if anime == nil {
+		return errors.New("Anime not found")
+	}
+
+	malID := anime.GetMapping("myanimelist/anime")
+
+	if malID == "" {
+		return fmt.Errorf("Anime %s has no MyAnimeList mapping", anime.ID)
+	}
+
+	form := url.Values{}
+	form.Set("status", MyAnimeListStatus(item.Status))
+	form.Set("score", strconv.Itoa(int(math.Round(item.Rating.Overall))))
+	form.Set("num_watched_episodes", strconv.Itoa(item.Episodes))
+	form.Set("num_times_rewatched", strconv.Itoa(item.RewatchCount))
+
+	_, err := user.myAnimeListRequest("PATCH", myAnimeListAPI+"/anime/"+malID+"/my_list_status", form)
+	return err
+}
+
+// DeleteMyAnimeListEntry deletes the anime with the given MyAnimeList ID from the MyAnimeList anime list of the user.
+func (user *User) DeleteMyAnimeListEntry(malID int) error {
+	if !user.IsMyAnimeListLinked() {
+		return errors.New("MyAnimeList account is not linked")
+	}
+
+	_, err := user.myAnimeListRequest("DELETE", myAnimeListAPI+"/anime/"+strconv.Itoa(malID)+"/my_list_status", nil)
+	return err
+}
+
+// myAnimeListRequest sends an authorized request to the MyAnimeList API.
+// Expired access tokens are refreshed and saved before the request is sent.
+func (user *User) myAnimeListRequest(method string, address string, form url.Values) (*client.Response, error) {
+	accessToken, err := user.myAnimeListAccessToken()
+
+	if err != nil {
+		return nil, err
+	}
+
+	request := client.WithMethod(address, method).
+		Header("Authorization", "Bearer "+accessToken).
+		Header("Accept", "application/json")
+
+	if form != nil {
+		request = request.
+			Header("Content-Type", "application/x-www-form-urlencoded").
+			BodyString(form.Encode())
+	}
+
+	response, err := request.End()
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Deleting an entry that doesn't exist anymore is not an error
+	if method == "DELETE" && response.StatusCode() == http.StatusNotFound {
+		return response, nil
+	}
+
+	if response.StatusCode() != http.StatusOK {
+		return nil, fmt.Errorf("MyAnimeList responded with status %d: %s", response.StatusCode(), response.String())
+	}
+
+	return response, nil
+}
+
+// myAnimeListAccessToken returns the access token of the linked MyAnimeList account
+// and refreshes it if it expired.
+func (user *User) myAnimeListAccessToken() (string, error) {
+	account := &user.Accounts.MyAnimeList
+	expiry, _ := time.Parse(time.RFC3339, account.TokenExpiry)
+
+	token, err := MyAnimeListOAuth().TokenSource(context.Background(), &oauth2.Token{
+		AccessToken:  account.AccessToken,
+		RefreshToken: account.RefreshToken,
+		Expiry:       expiry,
+	}).Token()
+
+	if err != nil {
+		return "", fmt.Errorf("Couldn't refresh the MyAnimeList authorization: %s", err.Error())
+	}
+
+	if token.AccessToken != account.AccessToken {
+		user.SetMyAnimeListToken(token)
+		user.Save()
+	}
+
+	return token.AccessToken, nil
+}
+
+// myAnimeListSync keeps the anime list of a user in sync with MyAnimeList.
+type myAnimeListSync struct {
+	user *User
+}
+
+// entries loads the MyAnimeList anime list of the user.
+func (service *myAnimeListSync) entries() ([]*ListSyncEntry, error) {
+	finder := NewAnimeFinder("myanimelist/anime")
+	entries := []*ListSyncEntry{}
+	next := myAnimeListAPI + "/users/@me/animelist?fields=list_status%7Bnum_times_rewatched%7D&limit=1000&nsfw=true"
+
+	for next != "" {
+		response, err := service.user.myAnimeListRequest("GET", next, nil)
+
+		if err != nil {
+			return nil, fmt.Errorf("Couldn't load your anime list from MyAnimeList: %s", err.Error())
+		}
+
+		page := myAnimeListListPage{}
+		err = response.Unmarshal(&page)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, remote := range page.Data {
+			anime := finder.GetAnime(strconv.Itoa(remote.Node.ID))
+
+			if anime == nil {
+				continue
+			}
+
+			status := MyAnimeListAnimeListStatus(remote.ListStatus.Status)
+
+			if remote.ListStatus.IsRewatching {
+				status = AnimeListStatusWatching
+			}
+
+			updated, _ := time.Parse(time.RFC3339, remote.ListStatus.UpdatedAt)
+
+			entries = append(entries, &ListSyncEntry{
+				ID:           remote.Node.ID,
+				Anime:        anime,
+				Status:       status,
+				Episodes:     remote.ListStatus.Episodes,
+				Score:        remote.ListStatus.Score,
+				RewatchCount: remote.ListStatus.RewatchCount,
+				Updated:      updated,
+			})
+		}
+
+		next = page.Paging.Next
+	}
+
+	return entries, nil
+}
+
+// push saves the anime list item on MyAnimeList.
+func (service *myAnimeListSync) push(item *AnimeListItem) error {
+	return service.user.PushMyAnimeListEntry(item)
+}
+
+// remove deletes the entry on MyAnimeList.
+func (service *myAnimeListSync) remove(entry *ListSyncEntry) error {
+	return service.user.DeleteMyAnimeListEntry(entry.ID)
+}
+
+// mapping returns the name of the MyAnimeList anime mapping.
+func (service *myAnimeListSync) mapping() string {
+	return "myanimelist/anime"
+}
+
+// score converts the rating to the MyAnimeList score from 0 to 10.
+func (service *myAnimeListSync) score(rating float64) int {
+	return int(math.Round(rating))
+}
+
+// rating converts the MyAnimeList score to a rating.
+func (service *myAnimeListSync) rating(score int) float64 {
+	return float64(score)
+}
+
+// synced returns the anime that were on both lists after the last MyAnimeList synchronization.
+func (service *myAnimeListSync) synced(animeList *AnimeList) *[]AnimeID {
+	return &animeList.MyAnimeListSynced
+}
+
+// MyAnimeListStatus converts an anime list status to the MyAnimeList status.
+func MyAnimeListStatus(status string) string {
+	switch status {
+	case AnimeListStatusWatching:
+		return "watching"
+	case AnimeListStatusCompleted:
+		return "completed"
+	case AnimeListStatusHold:
+		return "on_hold"
+	case AnimeListStatusDropped:
+		return "dropped"
+	default:
+		return "plan_to_watch"
+	}
+}
+
+// MyAnimeListAnimeListStatus converts a MyAnimeList status to the anime list status.
+func MyAnimeListAnimeListStatus(status string) string {
+	switch status {
+	case "watching":
+		return AnimeListStatusWatching
+	case "completed":
+		return AnimeListStatusCompleted
+	case "on_hold":
+		return AnimeListStatusHold
+	case "dropped":
+		return AnimeListStatusDropped
+	default:
+		return AnimeListStatusPlanned
+	}
+}
@@ -0,0 +1,25 @@
+package arn_test
+
+import (
+	"testing"
+
+	"github.com/akyoto/assert"
+	"github.com/animenotifier/notify.moe/arn"
+)
+
+func TestMyAnimeListStatus(t *testing.T) {
+	statuses := []string{
+		arn.AnimeListStatusWatching,
+		arn.AnimeListStatusCompleted,
+		arn.AnimeListStatusPlanned,
+		arn.AnimeListStatusHold,
+		arn.AnimeListStatusDropped,
+	}
+
+	for _, status := range statuses {
+		assert.Equal(t, arn.MyAnimeListAnimeListStatus(arn.MyAnimeListStatus(status)), status)
+	}
+
+	assert.Equal(t, arn.MyAnimeListStatus(arn.AnimeListStatusHold), "on_hold")
+	assert.Equal(t, arn.MyAnimeListStatus(arn.AnimeListStatusPlanned), "plan_to_watch")
+}
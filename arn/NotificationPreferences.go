@@ -0,0 +1,97 @@
+package arn
+
+// Notification channels
+const (
+	NotificationChannelPush    = "push"
+	NotificationChannelEmail   = "email"
+	NotificationChannelWebhook = "webhook"
+)
+
+// NotificationChannels tells which channels deliver the notifications of an event type.
+type NotificationChannels struct {
+	Push    bool `json:"push" editable:"true"`
+	Email   bool `json:"email" editable:"true"`
+	Webhook bool `json:"webhook" editable:"true"`
+}
+
+// NotificationPreferences is the matrix of event types and the channels the user wants to use for them.
+type NotificationPreferences struct {
+	AnimeEpisode NotificationChannels `json:"animeEpisode"`
+	ForumReply   NotificationChannels `json:"forumReply"`
+	Follow       NotificationChannels `json:"follow"`
+	GroupPost    NotificationChannels `json:"groupPost"`
+	Message      NotificationChannels `json:"message"`
+}
+
+// NotificationEvent is a row of the preference matrix in the settings.
+type NotificationEvent struct {
+	Type  string
+	Field string
+	Label string
+}
+
+// NotificationEvents are the event types that can be configured per channel.
+var NotificationEvents = []*NotificationEvent{
+	{NotificationTypeAnimeEpisode, "AnimeEpisode", "New episodes"},
+	{NotificationTypeForumReply, "ForumReply", "Replies"},
+	{NotificationTypeFollow, "Follow", "New followers"},
+	{NotificationTypeGroupPost, "GroupPost", "Group posts"},
+	{NotificationTypeMessage, "Message", "Messages"},
+}
+
+// DefaultNotificationPreferences returns the channels that are enabled for new users.
+func DefaultNotificationPreferences() NotificationPreferences {
+	return NotificationPreferences{
+		AnimeEpisode: NotificationChannels{Push: true, Email: true, Webhook: true},
+		ForumReply:   NotificationChannels{Push: true, Email: true},
+		Follow:       NotificationChannels{Push: true, Email: true},
+		GroupPost:    NotificationChannels{Push: true},
+		Message:      NotificationChannels{Push: true, Email: true},
+	}
+}
+
+// Channels returns the channels of the event type or nil if the type is not part of the matrix.
+func (preferences *NotificationPreferences) Channels(notificationType string) *NotificationChannels {
+	switch notificationType {
+	case NotificationTypeAnimeEpisode:
+		return &preferences.AnimeEpisode
+	case NotificationTypeForumReply:
+		return &preferences.ForumReply
+	case NotificationTypeFollow:
+		return &preferences.Follow
+	case NotificationTypeGroupPost:
+		return &preferences.GroupPost
+	case NotificationTypeMessage:
+		return &preferences.Message
+	default:
+		return nil
+	}
+}
+
+// Allows tells whether notifications of the given type should be delivered via the channel.
+// Types that can't be configured, e.g. likes, are only delivered as push notifications.
+func (preferences *NotificationPreferences) Allows(notificationType string, channel string) bool {
+	channels := preferences.Channels(notificationType)
+
+	if channels == nil {
+		return channel == NotificationChannelPush
+	}
+
+	switch channel {
+	case NotificationChannelPush:
+		return channels.Push
+	case NotificationChannelEmail:
+		return channels.Email
+	case NotificationChannelWebhook:
+		return channels.Webhook
+	default:
+		return false
+	}
+}
+
+// AllowsAny tells whether notifications of the given type are delivered via at least one channel.
+func (preferences *NotificationPreferences) AllowsAny(notificationType string) bool {
+	return preferences.Allows(notificationType, NotificationChannelPush) ||
+		preferences.Allows(notificationType, NotificationChannelEmail) ||
+		preferences.Allows(notificationType, NotificationChannelWebhook)
+}
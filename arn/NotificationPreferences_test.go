@@ -0,0 +1,40 @@
+package arn_test
+
+import (
+	"testing"
+
+	"github.com/akyoto/assert"
+	"github.com/animenotifier/notify.moe/arn"
+)
+
+func TestNotificationPreferencesAllows(t *testing.T) {
+	preferences := arn.DefaultNotificationPreferences()
+
+	assert.True(t, preferences.Allows(arn.NotificationTypeAnimeEpisode, arn.NotificationChannelWebhook))
+	assert.True(t, preferences.Allows(arn.NotificationTypeMessage, arn.NotificationChannelEmail))
+	assert.False(t, preferences.Allows(arn.NotificationTypeGroupPost, arn.NotificationChannelEmail))
+	assert.False(t, preferences.Allows(arn.NotificationTypeForumReply, arn.NotificationChannelWebhook))
+
+	// Disabling one channel keeps the others
+	preferences.Channels(arn.NotificationTypeFollow).Push = false
+	assert.False(t, preferences.Allows(arn.NotificationTypeFollow, arn.NotificationChannelPush))
+	assert.True(t, preferences.Allows(arn.NotificationTypeFollow, arn.NotificationChannelEmail))
+	assert.True(t, preferences.AllowsAny(arn.NotificationTypeFollow))
+
+	preferences.Follow.Email = false
+	assert.False(t, preferences.AllowsAny(arn.NotificationTypeFollow))
+
+	// Types outside of the matrix are only sent as push notifications
+	assert.Nil(t, preferences.Channels(arn.NotificationTypeLike))
+	assert.True(t, preferences.Allows(arn.NotificationTypeLike, arn.NotificationChannelPush))
+	assert.False(t, preferences.Allows(arn.NotificationTypeLike, arn.NotificationChannelEmail))
+	assert.False(t, preferences.Allows(arn.NotificationTypeLike, arn.NotificationChannelWebhook))
+}
+
+func TestNotificationEvents(t *testing.T) {
+	preferences := arn.DefaultNotificationPreferences()
+
+	for _, event := range arn.NotificationEvents {
+		assert.NotNil(t, preferences.Channels(event.Type))
+	}
+}
@@ -11,4 +11,9 @@ const (
 	NotificationTypePurchase      = "purchase"
 	NotificationTypePackageTest   = "package-test"
 	NotificationTypeGroupJoin     = "group-join"
+	NotificationTypeGroupPost     = "group-post"
+	NotificationTypeGroupEvent    = "group-event"
+	NotificationTypeReport        = "report"
+	NotificationTypeMessage       = "message"
+	NotificationTypeQuoteApproved = "quote-approved"
 )
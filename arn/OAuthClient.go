@@ -0,0 +1,190 @@
+package arn
+
+import (
+	"crypto/subtle"
+	"errors"
+	"net/url"
+	"strings"
+
+	"github.com/aerogo/nano"
+)
+
+// Limits for registered OAuth apps
+const (
+	MaxUserOAuthClients   = 5
+	MaxOAuthRedirectURIs  = 5
+	maxOAuthClientNameLen = 50
+)
+
+// OAuthClient is a third-party app that can act on behalf of users who authorized it.
+type OAuthClient struct {
+	Name         string   `json:"name"`
+	Website      string   `json:"website"`
+	RedirectURIs []string `json:"redirectURIs"`
+	SecretHash   string   `json:"secretHash" private:"true"`
+
+	hasID
+	hasCreator
+}
+
+// NewOAuthClient creates a new OAuth app for the given user.
+// It also returns the client secret which is only stored as a hash and can't be shown again.
+func NewOAuthClient(name string, website string, redirectURIs []string, userID UserID) (*OAuthClient, string, error) {
+	name = strings.TrimSpace(name)
+
+	if name == "" {
+		return nil, "", errors.New("The app needs a name")
+	}
+
+	if len([]rune(name)) > maxOAuthClientNameLen {
+		name = string([]rune(name)[:maxOAuthClientNameLen])
+	}
+
+	website = strings.TrimSpace(website)
+
+	if website != "" {
+		parsed, err := url.Parse(website)
+
+		if err != nil || (parsed.Scheme != "https" && parsed.Scheme != "http") || parsed.Host == "" {
+			return nil, "", errors.New("Website must be a valid http:// or https:// URL")
+		}
+	}
+
+	if len(redirectURIs) == 0 {
+		return nil, "", errors.New("At least one redirect URI is required")
+	}
+
+	if len(redirectURIs) > MaxOAuthRedirectURIs {
+		return nil, "", errors.New("You can't add more than 5 redirect URIs")
+	}
+
+	for _, uri := range redirectURIs {
+		err := ValidateOAuthRedirectURI(uri)
+
+		if err != nil {
+			return nil, "", err
+		}
+	}
+
+	secret := GenerateSecret()
+
+	return &OAuthClient{
+		Name:         name,
+		Website:      website,
+		RedirectURIs: redirectURIs,
+		SecretHash:   HashSecret(secret),
+		hasID: hasID{
+			ID: GenerateID("OAuthClient"),
+		},
+		hasCreator: hasCreator{
+			Created:   DateTimeUTC(),
+			CreatedBy: userID,
+		},
+	}, secret, nil
+}
+
+// ValidateOAuthRedirectURI returns an error if users can't be redirected to the given URI.
+// Plain HTTP is only allowed for apps running on the local machine.
+func ValidateOAuthRedirectURI(uri string) error {
+	parsed, err := url.Parse(uri)
+
+	if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+		return errors.New("Redirect URI must be an absolute URL")
+	}
+
+	if parsed.Fragment != "" {
+		return errors.New("Redirect URI must not contain a fragment")
+	}
+
+	switch parsed.Scheme {
+	case "https":
+		return nil
+
+	case "http":
+		host := parsed.Hostname()
+
+		if host == "localhost" || host == "127.0.0.1" || host == "::1" {
+			return nil
+		}
+
+		return errors.New("Redirect URI must use https:// unless it points to localhost")
+
+	default:
+		return errors.New("Redirect URI must use https://")
+	}
+}
+
+// HasRedirectURI tells whether the URI is one of the registered redirect URIs.
+func (client *OAuthClient) HasRedirectURI(uri string) bool {
+	return Contains(client.RedirectURIs, uri)
+}
+
+// VerifySecret tells whether the secret matches the client secret.
+func (client *OAuthClient) VerifySecret(secret string) bool {
+	return secret != "" && subtle.ConstantTimeCompare([]byte(client.SecretHash), []byte(HashSecret(secret))) == 1
+}
+
+// Save saves the app in the database.
+func (client *OAuthClient) Save() {
+	DB.Set("OAuthClient", client.ID, client)
+}
+
+// Delete deletes the app together with all codes and tokens that were issued to it.
+func (client *OAuthClient) Delete() {
+	for obj := range DB.All("OAuthCode") {
+		code := obj.(*OAuthCode)
+
+		if code.ClientID == client.ID {
+			code.Delete()
+		}
+	}
+
+	tokens := FilterOAuthTokens(func(token *OAuthToken) bool {
+		return token.ClientID == client.ID
+	})
+
+	for _, token := range tokens {
+		token.Delete()
+	}
+
+	DB.Delete("OAuthClient", client.ID)
+}
+
+// GetOAuthClient returns the OAuth app with the given ID.
+func GetOAuthClient(id ID) (*OAuthClient, error) {
+	obj, err := DB.Get("OAuthClient", id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return obj.(*OAuthClient), nil
+}
+
+// StreamOAuthClients returns a stream of all OAuth apps.
+func StreamOAuthClients() <-chan *OAuthClient {
+	channel := make(chan *OAuthClient, nano.ChannelBufferSize)
+
+	go func() {
+		for obj := range DB.All("OAuthClient") {
+			channel <- obj.(*OAuthClient)
+		}
+
+		close(channel)
+	}()
+
+	return channel
+}
+
+// FilterOAuthClients filters all OAuth apps by a custom function.
+func FilterOAuthClients(filter func(*OAuthClient) bool) []*OAuthClient {
+	var filtered []*OAuthClient
+
+	for client := range StreamOAuthClients() {
+		if filter(client) {
+			filtered = append(filtered, client)
+		}
+	}
+
+	return filtered
+}
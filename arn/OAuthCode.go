@@ -0,0 +1,81 @@
+package arn
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"time"
+)
+
+// OAuthCodeDuration is how long an authorization code can be exchanged for an access token.
+const OAuthCodeDuration = 10 * time.Minute
+
+// OAuthCode is a short-lived, single-use code that an app exchanges for an access token
+// after the user approved the authorization request.
+type OAuthCode struct {
+	Code                string   `json:"code" primary:"true"`
+	ClientID            ID       `json:"clientId"`
+	UserID              UserID   `json:"userId"`
+	RedirectURI         string   `json:"redirectURI"`
+	Scopes              []string `json:"scopes"`
+	CodeChallenge       string   `json:"codeChallenge"`
+	CodeChallengeMethod string   `json:"codeChallengeMethod"`
+	Expires             string   `json:"expires"`
+}
+
+// NewOAuthCode creates a new authorization code.
+func NewOAuthCode(clientID ID, userID UserID, redirectURI string, scopes []string) *OAuthCode {
+	return &OAuthCode{
+		Code:        GenerateSecret(),
+		ClientID:    clientID,
+		UserID:      userID,
+		RedirectURI: redirectURI,
+		Scopes:      scopes,
+		Expires:     time.Now().Add(OAuthCodeDuration).UTC().Format(time.RFC3339),
+	}
+}
+
+// IsExpired tells whether the code can't be used anymore.
+func (code *OAuthCode) IsExpired() bool {
+	expires, err := time.Parse(time.RFC3339, code.Expires)
+	return err != nil || time.Now().After(expires)
+}
+
+// VerifyChallenge checks the PKCE code verifier against the challenge of the authorization request.
+// Only the S256 method is supported because plain challenges would reveal the verifier.
+func (code *OAuthCode) VerifyChallenge(verifier string) bool {
+	if code.CodeChallenge == "" || code.CodeChallengeMethod != "S256" || verifier == "" {
+		return false
+	}
+
+	hash := sha256.Sum256([]byte(verifier))
+	expected := base64.RawURLEncoding.EncodeToString(hash[:])
+
+	return subtle.ConstantTimeCompare([]byte(expected), []byte(code.CodeChallenge)) == 1
+}
+
+// GetID returns the primary key which is the code.
+func (code *OAuthCode) GetID() string {
+	return code.Code
+}
+
+// Save saves the code in the database.
+func (code *OAuthCode) Save() {
+	DB.Set("OAuthCode", code.Code, code)
+}
+
+// Delete deletes the code from the database.
+func (code *OAuthCode) Delete() {
+	DB.Delete("OAuthCode", code.Code)
+}
+
+// GetOAuthCode returns the authorization code.
+func GetOAuthCode(code string) (*OAuthCode, error) {
+	obj, err := DB.Get("OAuthCode", code)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return obj.(*OAuthCode), nil
+}
@@ -0,0 +1,69 @@
+package arn_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/akyoto/assert"
+	"github.com/animenotifier/notify.moe/arn"
+)
+
+func TestOAuthCodeVerifyChallenge(t *testing.T) {
+	// Example from RFC 7636, appendix B
+	code := arn.NewOAuthCode("client", "user", "https://example.com/callback", []string{arn.OAuthScopeReadList})
+	code.CodeChallenge = "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM"
+	code.CodeChallengeMethod = "S256"
+
+	assert.True(t, code.VerifyChallenge("dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"))
+	assert.False(t, code.VerifyChallenge("dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXl"))
+	assert.False(t, code.VerifyChallenge(""))
+	assert.False(t, code.IsExpired())
+
+	code.CodeChallengeMethod = "plain"
+	assert.False(t, code.VerifyChallenge(code.CodeChallenge))
+}
+
+func TestOAuthRequestRedirect(t *testing.T) {
+	request := &arn.OAuthRequest{
+		RedirectURI: "https://example.com/callback?app=1",
+		State:       "xyz",
+	}
+
+	assert.Equal(t, request.Redirect(map[string][]string{"code": {"abc"}}), "https://example.com/callback?app=1&code=abc&state=xyz")
+}
+
+func TestOAuthRequestRejectsPlainChallenge(t *testing.T) {
+	request := &arn.OAuthRequest{
+		Scope:               arn.OAuthScopeReadList,
+		CodeChallenge:       "verifier",
+		CodeChallengeMethod: "plain",
+	}
+
+	_, err := request.Scopes()
+	assert.NotNil(t, err)
+
+	request.CodeChallengeMethod = ""
+	_, err = request.Scopes()
+	assert.NotNil(t, err)
+}
+
+func TestNewOAuthClient(t *testing.T) {
+	client, secret, err := arn.NewOAuthClient(strings.Repeat("ä", 60), "", []string{"https://example.com/callback"}, "user")
+	assert.Nil(t, err)
+	assert.Equal(t, client.Name, strings.Repeat("ä", 50))
+
+	// Only the hash of the secret is stored
+	assert.NotEqual(t, client.SecretHash, secret)
+	assert.True(t, client.VerifySecret(secret))
+	assert.False(t, client.VerifySecret(client.SecretHash))
+	assert.False(t, client.VerifySecret(""))
+}
+
+func TestNewOAuthToken(t *testing.T) {
+	token, accessToken := arn.NewOAuthToken("client", "user", []string{arn.OAuthScopeReadList})
+	assert.Equal(t, token.Hash, arn.HashSecret(accessToken))
+	assert.False(t, token.IsExpired())
+
+	token.Expires = "2000-01-01T00:00:00Z"
+	assert.True(t, token.IsExpired())
+}
@@ -0,0 +1,88 @@
+package arn
+
+import (
+	"errors"
+	"net/url"
+)
+
+// OAuthRequest contains the parameters of an authorization request made by an app.
+type OAuthRequest struct {
+	ClientID            ID     `json:"clientId"`
+	RedirectURI         string `json:"redirectURI"`
+	Scope               string `json:"scope"`
+	State               string `json:"state"`
+	CodeChallenge       string `json:"codeChallenge"`
+	CodeChallengeMethod string `json:"codeChallengeMethod"`
+}
+
+// NewOAuthRequest reads the authorization request from the query parameters.
+func NewOAuthRequest(query func(string) string) *OAuthRequest {
+	return &OAuthRequest{
+		ClientID:            query("client_id"),
+		RedirectURI:         query("redirect_uri"),
+		Scope:               query("scope"),
+		State:               query("state"),
+		CodeChallenge:       query("code_challenge"),
+		CodeChallengeMethod: query("code_challenge_method"),
+	}
+}
+
+// Client returns the app that made the request.
+// If this fails the user must not be redirected because the redirect URI can't be trusted.
+func (request *OAuthRequest) Client() (*OAuthClient, error) {
+	client, err := GetOAuthClient(request.ClientID)
+
+	if err != nil {
+		return nil, errors.New("Unknown app")
+	}
+
+	if !client.HasRedirectURI(request.RedirectURI) {
+		return nil, errors.New("The redirect URI is not registered for this app")
+	}
+
+	return client, nil
+}
+
+// Scopes returns the requested scopes after checking the PKCE parameters.
+func (request *OAuthRequest) Scopes() ([]string, error) {
+	switch request.CodeChallengeMethod {
+	case "", "S256":
+	default:
+		return nil, errors.New("Unsupported code challenge method")
+	}
+
+	if request.CodeChallengeMethod == "" && request.CodeChallenge != "" {
+		return nil, errors.New("The code challenge method must be S256")
+	}
+
+	if request.CodeChallengeMethod != "" && request.CodeChallenge == "" {
+		return nil, errors.New("Missing code challenge")
+	}
+
+	return ParseOAuthScopes(request.Scope)
+}
+
+// NewCode creates the authorization code for the given user.
+func (request *OAuthRequest) NewCode(userID UserID, scopes []string) *OAuthCode {
+	code := NewOAuthCode(request.ClientID, userID, request.RedirectURI, scopes)
+	code.CodeChallenge = request.CodeChallenge
+	code.CodeChallengeMethod = request.CodeChallengeMethod
+	return code
+}
+
+// Redirect returns the redirect URI with the given response parameters and the state added.
+func (request *OAuthRequest) Redirect(params url.Values) string {
+	uri, _ := url.Parse(request.RedirectURI)
+	query := uri.Query()
+
+	for key, values := range params {
+		query[key] = values
+	}
+
+	if request.State != "" {
+		query.Set("state", request.State)
+	}
+
+	uri.RawQuery = query.Encode()
+	return uri.String()
+}
@@ -0,0 +1,72 @@
+package arn
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// OAuth scopes define what a third-party app can do on behalf of a user.
+const (
+	OAuthScopeReadList          = "read-list"
+	OAuthScopeWriteList         = "write-list"
+	OAuthScopeReadNotifications = "read-notifications"
+)
+
+// OAuthScopes contains the descriptions of all scopes in the order they are shown to users.
+var OAuthScopes = []*OAuthScopeInfo{
+	{OAuthScopeReadList, "Read your anime list, including private entries"},
+	{OAuthScopeWriteList, "Add, edit and remove anime on your list"},
+	{OAuthScopeReadNotifications, "Read your notifications"},
+}
+
+// OAuthScopeInfo describes a scope on the consent page.
+type OAuthScopeInfo struct {
+	Name        string
+	Description string
+}
+
+// ParseOAuthScopes parses a space-separated list of scopes and removes duplicates.
+func ParseOAuthScopes(scope string) ([]string, error) {
+	var scopes []string
+
+	for _, name := range strings.Fields(scope) {
+		if !IsOAuthScope(name) {
+			return nil, fmt.Errorf("Unknown scope: %s", name)
+		}
+
+		if !Contains(scopes, name) {
+			scopes = append(scopes, name)
+		}
+	}
+
+	if len(scopes) == 0 {
+		return nil, errors.New("At least one scope is required")
+	}
+
+	return scopes, nil
+}
+
+// IsOAuthScope tells whether the given scope exists.
+func IsOAuthScope(name string) bool {
+	for _, scope := range OAuthScopes {
+		if scope.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// FilterOAuthScopes returns the scope descriptions for the given scope names.
+func FilterOAuthScopes(names []string) []*OAuthScopeInfo {
+	var filtered []*OAuthScopeInfo
+
+	for _, scope := range OAuthScopes {
+		if Contains(names, scope.Name) {
+			filtered = append(filtered, scope)
+		}
+	}
+
+	return filtered
+}
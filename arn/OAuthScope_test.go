@@ -0,0 +1,30 @@
+package arn_test
+
+import (
+	"testing"
+
+	"github.com/akyoto/assert"
+	"github.com/animenotifier/notify.moe/arn"
+)
+
+func TestParseOAuthScopes(t *testing.T) {
+	scopes, err := arn.ParseOAuthScopes("read-list  write-list read-list")
+	assert.Nil(t, err)
+	assert.DeepEqual(t, scopes, []string{arn.OAuthScopeReadList, arn.OAuthScopeWriteList})
+
+	_, err = arn.ParseOAuthScopes("read-list delete-account")
+	assert.NotNil(t, err)
+
+	_, err = arn.ParseOAuthScopes(" ")
+	assert.NotNil(t, err)
+}
+
+func TestValidateOAuthRedirectURI(t *testing.T) {
+	assert.Nil(t, arn.ValidateOAuthRedirectURI("https://example.com/callback"))
+	assert.Nil(t, arn.ValidateOAuthRedirectURI("http://localhost:8080/callback"))
+	assert.Nil(t, arn.ValidateOAuthRedirectURI("http://127.0.0.1/callback"))
+	assert.NotNil(t, arn.ValidateOAuthRedirectURI("http://example.com/callback"))
+	assert.NotNil(t, arn.ValidateOAuthRedirectURI("https://example.com/callback#token"))
+	assert.NotNil(t, arn.ValidateOAuthRedirectURI("javascript:alert(1)"))
+	assert.NotNil(t, arn.ValidateOAuthRedirectURI("/callback"))
+}
@@ -0,0 +1,152 @@
+package arn
+
+import "time"
+
+// OAuthTokenDuration is how long an access token is valid.
+// Apps need to ask the user for authorization again after the token expired.
+const OAuthTokenDuration = 90 * 24 * time.Hour
+
+// OAuthToken is an access token that lets an app act on behalf of a user within the granted scopes.
+// Tokens stay valid until they expire or the user or the app revokes them.
+// Only the hash of the token is stored.
+type OAuthToken struct {
+	Hash     string   `json:"hash" primary:"true"`
+	ClientID ID       `json:"clientId"`
+	UserID   UserID   `json:"userId"`
+	Scopes   []string `json:"scopes"`
+	Created  string   `json:"created"`
+	Expires  string   `json:"expires"`
+}
+
+// OAuthAuthorization lists the scopes a user granted to an app.
+type OAuthAuthorization struct {
+	Client *OAuthClient
+	Scopes []string
+}
+
+// NewOAuthToken creates a new access token and returns it together with the token string for the app.
+func NewOAuthToken(clientID ID, userID UserID, scopes []string) (*OAuthToken, string) {
+	secret := GenerateSecret()
+	now := time.Now().UTC()
+
+	return &OAuthToken{
+		Hash:     HashSecret(secret),
+		ClientID: clientID,
+		UserID:   userID,
+		Scopes:   scopes,
+		Created:  now.Format(time.RFC3339),
+		Expires:  now.Add(OAuthTokenDuration).Format(time.RFC3339),
+	}, secret
+}
+
+// IsExpired tells whether the token can't be used anymore.
+func (token *OAuthToken) IsExpired() bool {
+	expires, err := time.Parse(time.RFC3339, token.Expires)
+	return err != nil || time.Now().After(expires)
+}
+
+// HasScope tells whether the token grants the given scope.
+func (token *OAuthToken) HasScope(scope string) bool {
+	return Contains(token.Scopes, scope)
+}
+
+// User returns the user who authorized the token.
+func (token *OAuthToken) User() *User {
+	user, _ := GetUser(token.UserID)
+	return user
+}
+
+// GetID returns the primary key which is the hash of the token.
+func (token *OAuthToken) GetID() string {
+	return token.Hash
+}
+
+// Save saves the token in the database.
+func (token *OAuthToken) Save() {
+	DB.Set("OAuthToken", token.Hash, token)
+}
+
+// Delete revokes the token.
+func (token *OAuthToken) Delete() {
+	DB.Delete("OAuthToken", token.Hash)
+}
+
+// GetOAuthToken returns the access token for the token string sent by the app.
+func GetOAuthToken(token string) (*OAuthToken, error) {
+	obj, err := DB.Get("OAuthToken", HashSecret(token))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return obj.(*OAuthToken), nil
+}
+
+// FilterOAuthTokens filters all access tokens by a custom function.
+func FilterOAuthTokens(filter func(*OAuthToken) bool) []*OAuthToken {
+	var filtered []*OAuthToken
+
+	for obj := range DB.All("OAuthToken") {
+		token := obj.(*OAuthToken)
+
+		if filter(token) {
+			filtered = append(filtered, token)
+		}
+	}
+
+	return filtered
+}
+
+// RevokeOAuthTokens revokes all access tokens the user granted to the app.
+func RevokeOAuthTokens(userID UserID, clientID ID) int {
+	tokens := FilterOAuthTokens(func(token *OAuthToken) bool {
+		return token.UserID == userID && token.ClientID == clientID
+	})
+
+	for _, token := range tokens {
+		token.Delete()
+	}
+
+	return len(tokens)
+}
+
+// OAuthAuthorizations returns the apps the user authorized, together with the granted scopes.
+func (user *User) OAuthAuthorizations() []*OAuthAuthorization {
+	var authorizations []*OAuthAuthorization
+	byClient := map[ID]*OAuthAuthorization{}
+
+	tokens := FilterOAuthTokens(func(token *OAuthToken) bool {
+		return token.UserID == user.ID
+	})
+
+	for _, token := range tokens {
+		authorization, exists := byClient[token.ClientID]
+
+		if !exists {
+			client, err := GetOAuthClient(token.ClientID)
+
+			if err != nil {
+				continue
+			}
+
+			authorization = &OAuthAuthorization{Client: client}
+			byClient[token.ClientID] = authorization
+			authorizations = append(authorizations, authorization)
+		}
+
+		for _, scope := range token.Scopes {
+			if !Contains(authorization.Scopes, scope) {
+				authorization.Scopes = append(authorization.Scopes, scope)
+			}
+		}
+	}
+
+	return authorizations
+}
+
+// OAuthClients returns the OAuth apps registered by the user.
+func (user *User) OAuthClients() []*OAuthClient {
+	return FilterOAuthClients(func(client *OAuthClient) bool {
+		return client.CreatedBy == user.ID
+	})
+}
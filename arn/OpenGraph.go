@@ -4,4 +4,7 @@ package arn
 type OpenGraph struct {
 	Tags map[string]string
 	Meta map[string]string
+
+	// StructuredData is embedded as JSON-LD for search engines.
+	StructuredData StructuredData
 }
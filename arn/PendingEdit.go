@@ -0,0 +1,261 @@
+package arn
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/aerogo/aero"
+	"github.com/aerogo/nano"
+)
+
+// Pending edit states
+const (
+	PendingEditOpen     = "open"
+	PendingEditApproved = "approved"
+	PendingEditRejected = "rejected"
+)
+
+// TrustedEditorMinEdits is the number of field edits an editor needs
+// before their changes are applied without being reviewed first.
+const TrustedEditorMinEdits = 50
+
+// pendingEditTypes are the object types whose field edits need a review when made by low-trust editors.
+var pendingEditTypes = map[string]bool{
+	"Anime":     true,
+	"Character": true,
+}
+
+// editCounts caches the number of field edits per user.
+// It is built on first use and updated whenever a new edit log entry is saved.
+var editCounts = struct {
+	sync.Mutex
+	byUser map[UserID]int
+}{}
+
+// PendingEdit is a field change made by a low-trust editor that another editor needs to approve
+// before it is applied.
+type PendingEdit struct {
+	ObjectType string `json:"objectType"`
+	ObjectID   ID     `json:"objectId"`
+	Key        string `json:"key"`
+	OldValue   string `json:"oldValue"`
+	NewValue   string `json:"newValue"`
+	OldData    string `json:"oldData"`
+	NewData    string `json:"newData"`
+	Status     string `json:"status"`
+	ResolvedBy UserID `json:"resolvedBy"`
+	Resolved   string `json:"resolved"`
+
+	hasID
+	hasCreator
+}
+
+// IsTrustedEditor tells you whether the user's edits are applied instantly.
+func (user *User) IsTrustedEditor() bool {
+	if user.Role == "admin" {
+		return true
+	}
+
+	editCounts.Lock()
+	defer editCounts.Unlock()
+
+	if editCounts.byUser == nil {
+		editCounts.byUser = map[UserID]int{}
+
+		for entry := range StreamEditLogEntries() {
+			if entry.Action == "edit" {
+				editCounts.byUser[entry.UserID]++
+			}
+		}
+	}
+
+	return editCounts.byUser[user.ID] >= TrustedEditorMinEdits
+}
+
+// countEdit adds a new edit log entry to the edit count of its author.
+func countEdit(entry *EditLogEntry) {
+	if entry.Action != "edit" {
+		return
+	}
+
+	editCounts.Lock()
+	defer editCounts.Unlock()
+
+	// The entry will be counted when the cache is built
+	if editCounts.byUser == nil {
+		return
+	}
+
+	editCounts.byUser[entry.UserID]++
+}
+
+// IsPendingEditType tells you whether field edits of the given type need a review when made by low-trust editors.
+func IsPendingEditType(typeName string) bool {
+	return pendingEditTypes[typeName]
+}
+
+// authorizeArrayEdit returns an error if a low-trust editor tries to add or remove array elements.
+// Unlike field edits, these changes are applied before we're notified and can't be queued for a review.
+func authorizeArrayEdit(ctx aero.Context, loggable Loggable, user *User) error {
+	path := ctx.Path()
+	isArrayEdit := strings.Contains(path, "/field/") && (strings.HasSuffix(path, "/append") || strings.Contains(path, "/remove/"))
+
+	if !isArrayEdit || !IsPendingEditType(loggable.TypeName()) || user.IsTrustedEditor() {
+		return nil
+	}
+
+	return errors.New("Only trusted editors can add or remove list entries")
+}
+
+// queuePendingEdit saves the field edit described by the log entry as a pending edit
+// if the user is not trusted and tells you whether the edit has been queued instead of being applied.
+func queuePendingEdit(loggable Loggable, user *User, logEntry *EditLogEntry) bool {
+	if !IsPendingEditType(loggable.TypeName()) || user.IsTrustedEditor() {
+		return false
+	}
+
+	pending := &PendingEdit{
+		ObjectType: logEntry.ObjectType,
+		ObjectID:   logEntry.ObjectID,
+		Key:        logEntry.Key,
+		OldValue:   logEntry.OldValue,
+		NewValue:   logEntry.NewValue,
+		OldData:    logEntry.OldData,
+		NewData:    logEntry.NewData,
+		Status:     PendingEditOpen,
+		hasID: hasID{
+			ID: GenerateID("PendingEdit"),
+		},
+		hasCreator: hasCreator{
+			Created:   DateTimeUTC(),
+			CreatedBy: user.ID,
+		},
+	}
+
+	pending.Save()
+	return true
+}
+
+// IsOpen tells you whether the edit still needs to be reviewed.
+func (pending *PendingEdit) IsOpen() bool {
+	return pending.Status == PendingEditOpen
+}
+
+// Approve applies the change and writes it to the edit log in the name of its author.
+func (pending *PendingEdit) Approve(user *User) error {
+	if !pending.IsOpen() {
+		return errors.New("This edit has already been reviewed")
+	}
+
+	obj, err := DB.Get(pending.ObjectType, pending.ObjectID)
+
+	if err != nil {
+		return errors.New(pending.ObjectType + " does not exist anymore")
+	}
+
+	err = setFieldData(obj, pending.Key, pending.OldData, pending.NewData)
+
+	if err != nil {
+		return err
+	}
+
+	logEntry := NewEditLogEntry(pending.CreatedBy, "edit", pending.ObjectType, pending.ObjectID, pending.Key, pending.OldValue, pending.NewValue)
+	logEntry.OldData = pending.OldData
+	logEntry.NewData = pending.NewData
+	logEntry.Save()
+
+	pending.Resolve(user.ID, PendingEditApproved)
+	return nil
+}
+
+// Reject discards the change.
+func (pending *PendingEdit) Reject(user *User) error {
+	if !pending.IsOpen() {
+		return errors.New("This edit has already been reviewed")
+	}
+
+	pending.Resolve(user.ID, PendingEditRejected)
+	return nil
+}
+
+// Resolve closes the pending edit with the given status.
+func (pending *PendingEdit) Resolve(userID UserID, status string) {
+	pending.Status = status
+	pending.ResolvedBy = userID
+	pending.Resolved = DateTimeUTC()
+}
+
+// ResolvedByUser returns the editor who reviewed the edit.
+func (pending *PendingEdit) ResolvedByUser() *User {
+	user, _ := GetUser(pending.ResolvedBy)
+	return user
+}
+
+// ObjectTitle returns the title of the edited object.
+func (pending *PendingEdit) ObjectTitle() string {
+	return GetObjectTitle(pending.ObjectType, pending.ObjectID)
+}
+
+// ObjectLink returns the link to the edited object.
+func (pending *PendingEdit) ObjectLink() string {
+	return GetObjectLink(pending.ObjectType, pending.ObjectID)
+}
+
+// String implements the default string serialization.
+func (pending *PendingEdit) String() string {
+	return fmt.Sprintf("%s edit of %s", pending.Key, pending.ObjectTitle())
+}
+
+// TypeName returns the type name.
+func (pending *PendingEdit) TypeName() string {
+	return "PendingEdit"
+}
+
+// GetPendingEdit ...
+func GetPendingEdit(id ID) (*PendingEdit, error) {
+	obj, err := DB.Get("PendingEdit", id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return obj.(*PendingEdit), nil
+}
+
+// StreamPendingEdits returns a stream of all pending edits.
+func StreamPendingEdits() <-chan *PendingEdit {
+	channel := make(chan *PendingEdit, nano.ChannelBufferSize)
+
+	go func() {
+		for obj := range DB.All("PendingEdit") {
+			channel <- obj.(*PendingEdit)
+		}
+
+		close(channel)
+	}()
+
+	return channel
+}
+
+// FilterPendingEdits filters all pending edits by a custom function.
+func FilterPendingEdits(filter func(*PendingEdit) bool) []*PendingEdit {
+	var filtered []*PendingEdit
+
+	for obj := range StreamPendingEdits() {
+		if filter(obj) {
+			filtered = append(filtered, obj)
+		}
+	}
+
+	return filtered
+}
+
+// SortPendingEditsLatestFirst puts the latest pending edits on top.
+func SortPendingEditsLatestFirst(edits []*PendingEdit) {
+	sort.Slice(edits, func(i, j int) bool {
+		return edits[i].Created > edits[j].Created
+	})
+}
@@ -0,0 +1,83 @@
+package arn
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/aerogo/aero"
+	"github.com/aerogo/api"
+)
+
+// Force interface implementations
+var (
+	_ fmt.Stringer   = (*PendingEdit)(nil)
+	_ api.Actionable = (*PendingEdit)(nil)
+)
+
+// Actions
+func init() {
+	API.RegisterActions("PendingEdit", []*api.Action{
+		// Apply the edit
+		{
+			Name:  "approve",
+			Route: "/approve",
+			Run: func(obj interface{}, ctx aero.Context) error {
+				pending := obj.(*PendingEdit)
+				user := GetUserFromContext(ctx)
+				err := pending.Approve(user)
+
+				if err != nil {
+					return err
+				}
+
+				pending.Save()
+				return nil
+			},
+		},
+
+		// Discard the edit
+		{
+			Name:  "reject",
+			Route: "/reject",
+			Run: func(obj interface{}, ctx aero.Context) error {
+				pending := obj.(*PendingEdit)
+				user := GetUserFromContext(ctx)
+				err := pending.Reject(user)
+
+				if err != nil {
+					return err
+				}
+
+				pending.Save()
+
+				logEntry := NewModerationLogEntry(user.ID, "edit-rejected", pending.ObjectType, pending.ObjectID, pending.Key, pending.NewValue)
+				logEntry.Save()
+				return nil
+			},
+		},
+	})
+}
+
+// Authorize returns an error if the given API request is not authorized.
+func (pending *PendingEdit) Authorize(ctx aero.Context, action string) error {
+	user := GetUserFromContext(ctx)
+
+	if user == nil {
+		return errors.New("Not logged in")
+	}
+
+	if !user.IsModerator() || !user.IsTrustedEditor() {
+		return errors.New("Only trusted editors can review edits")
+	}
+
+	if pending.CreatedBy == user.ID {
+		return errors.New("You can't review your own edits")
+	}
+
+	return nil
+}
+
+// Save saves the pending edit in the database.
+func (pending *PendingEdit) Save() {
+	DB.Set("PendingEdit", pending.ID, pending)
+}
@@ -0,0 +1,39 @@
+package arn_test
+
+import (
+	"testing"
+
+	"github.com/akyoto/assert"
+	"github.com/animenotifier/notify.moe/arn"
+)
+
+func TestEditLogEntryCanRevert(t *testing.T) {
+	entry := arn.NewEditLogEntry("editor", "edit", "Anime", "anime", "Summary", "old", "new")
+	assert.False(t, entry.CanRevert())
+
+	entry.OldData = `"old"`
+	entry.NewData = `"new"`
+	assert.True(t, entry.CanRevert())
+
+	entry.Reverted = arn.DateTimeUTC()
+	assert.True(t, entry.IsReverted())
+	assert.False(t, entry.CanRevert())
+
+	created := arn.NewEditLogEntry("editor", "create", "Anime", "anime", "", "", "")
+	assert.False(t, created.CanRevert())
+}
+
+func TestPendingEditReject(t *testing.T) {
+	reviewer := &arn.User{}
+	reviewer.ID = "reviewer"
+
+	pending := &arn.PendingEdit{Status: arn.PendingEditOpen}
+	assert.True(t, pending.IsOpen())
+	assert.Nil(t, pending.Reject(reviewer))
+	assert.False(t, pending.IsOpen())
+	assert.Equal(t, pending.Status, arn.PendingEditRejected)
+	assert.Equal(t, pending.ResolvedBy, "reviewer")
+
+	assert.NotNil(t, pending.Reject(reviewer))
+	assert.NotNil(t, pending.Approve(reviewer))
+}
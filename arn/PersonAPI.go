@@ -97,10 +97,26 @@ func (person *Person) DeleteInContext(ctx aero.Context) error {
 func (person *Person) Delete() error {
 	if person.IsDraft {
 		draftIndex := person.Creator().DraftIndex()
-		draftIndex.CharacterID = ""
+		draftIndex.PersonID = ""
 		draftIndex.Save()
 	}
 
+	// Delete from character voice actors
+	for character := range StreamCharacters() {
+		voiceActors := character.VoiceActors[:0]
+
+		for _, voiceActor := range character.VoiceActors {
+			if voiceActor.PersonID != person.ID {
+				voiceActors = append(voiceActors, voiceActor)
+			}
+		}
+
+		if len(voiceActors) != len(character.VoiceActors) {
+			character.VoiceActors = voiceActors
+			character.Save()
+		}
+	}
+
 	// Delete image files
 	person.DeleteImages()
 
@@ -6,7 +6,6 @@ import (
 	"sort"
 	"strings"
 
-	"github.com/aerogo/markdown"
 	"github.com/aerogo/nano"
 )
 
@@ -25,6 +24,7 @@ type Post struct {
 	hasPosts
 	hasCreator
 	hasLikes
+	hasSoftDeleted
 
 	html string
 }
@@ -106,10 +106,15 @@ func (post *Post) HTML() string {
 		return post.html
 	}
 
-	post.html = markdown.Render(post.Text)
+	post.html = RenderMarkdownWithFootnotes(post.Text, post.FootnotePrefix())
 	return post.html
 }
 
+// FootnotePrefix returns the prefix that makes the footnote anchors of the post unique.
+func (post *Post) FootnotePrefix() string {
+	return "post-" + post.ID + "-"
+}
+
 // String implements the default string serialization.
 func (post *Post) String() string {
 	const maxLen = 170
@@ -8,7 +8,6 @@ import (
 
 	"github.com/aerogo/aero"
 	"github.com/aerogo/api"
-	"github.com/aerogo/markdown"
 	"github.com/animenotifier/notify.moe/arn/autocorrect"
 	"github.com/animenotifier/notify.moe/arn/limits"
 )
@@ -19,6 +18,7 @@ var (
 	_ Likeable          = (*Post)(nil)
 	_ LikeEventReceiver = (*Post)(nil)
 	_ PostParent        = (*Post)(nil)
+	_ SoftDeletable     = (*Post)(nil)
 	_ fmt.Stringer      = (*Post)(nil)
 	_ api.Newable       = (*Post)(nil)
 	_ api.Editable      = (*Post)(nil)
@@ -34,6 +34,12 @@ func init() {
 
 		// Unlike post
 		UnlikeAction(),
+
+		// Remove post
+		SoftDeleteAction(),
+
+		// Restore post
+		RestoreAction(),
 	})
 }
 
@@ -43,9 +49,13 @@ func (post *Post) Authorize(ctx aero.Context, action string) error {
 		return errors.New("Neither logged in nor in session")
 	}
 
-	if action == "edit" {
-		user := GetUserFromContext(ctx)
+	user := GetUserFromContext(ctx)
 
+	if IsModeratorAction(action) && (user == nil || !user.IsModerator()) {
+		return errors.New("Only moderators can do this")
+	}
+
+	if action == "edit" {
 		if post.CreatedBy != user.ID && user.Role != "admin" {
 			return errors.New("Can't edit the posts of other users")
 		}
@@ -119,16 +129,49 @@ func (post *Post) Create(ctx aero.Context) error {
 		}
 	}
 
+	// Don't allow posting on the profile of a user who blocked you
+	if topMostParent.TypeName() == "User" && topMostParent.(*User).IsBlocking(user.ID) {
+		return errors.New("You can't post on this profile")
+	}
+
 	// Append to posts
 	parent.AddPost(post.ID)
 
 	// Save the parent thread
 	parent.Save()
 
+	// The draft has been posted
+	user.DeleteDraft(DraftKey(parent))
+
+	// Posting in a thread subscribes to it
+	thread, inThread := topMostParent.(*Thread)
+
+	if inThread && !thread.IsSubscribed(user.ID) {
+		thread.Subscribe(user.ID)
+		thread.Save()
+	}
+
+	// Open thread pages load the new post, so it needs to be saved before they are informed
+	if inThread {
+		post.Save()
+		thread.BroadcastNewPost(post)
+	}
+
 	// Send notification to the author of the parent post
 	go func() {
 		notifyUser := parent.Creator()
 
+		// Notify thread subscribers, the author of the parent post receives a more specific message
+		if inThread {
+			thread.NotifySubscribers(&PushNotification{
+				Title:   user.Nick + " replied",
+				Message: fmt.Sprintf(`%s replied in the thread "%s".`, user.Nick, thread.Title),
+				Icon:    "https:" + user.AvatarLink("large"),
+				Link:    thread.PostLink(post.ID),
+				Type:    NotificationTypeForumReply,
+			}, user.ID, parentCreatorID(parent))
+		}
+
 		// Does the parent have a creator?
 		if notifyUser == nil {
 			return
@@ -139,6 +182,11 @@ func (post *Post) Create(ctx aero.Context) error {
 			return
 		}
 
+		// Don't notify users who blocked the author
+		if notifyUser.IsBlocking(user.ID) {
+			return
+		}
+
 		title := user.Nick + " replied"
 		message := ""
 
@@ -167,6 +215,7 @@ func (post *Post) Create(ctx aero.Context) error {
 		// all members except the author will receive a notification.
 		if post.ParentType == "Group" {
 			group := parent.(*Group)
+			notification.Type = NotificationTypeGroupPost
 			group.SendNotification(notification, user.ID)
 			return
 		}
@@ -185,6 +234,17 @@ func (post *Post) Create(ctx aero.Context) error {
 	return nil
 }
 
+// parentCreatorID returns the ID of the user who created the parent or an empty string.
+func parentCreatorID(parent PostParent) UserID {
+	creator := parent.Creator()
+
+	if creator == nil {
+		return ""
+	}
+
+	return creator.ID
+}
+
 // Edit saves a log entry for the edit.
 func (post *Post) Edit(ctx aero.Context, key string, value reflect.Value, newValue reflect.Value) (bool, error) {
 	consumed := false
@@ -241,7 +301,7 @@ func (post *Post) OnRemove(ctx aero.Context, key string, index int, obj interfac
 // AfterEdit sets the edited date on the post object.
 func (post *Post) AfterEdit(ctx aero.Context) error {
 	post.Edited = DateTimeUTC()
-	post.html = markdown.Render(post.Text)
+	post.html = RenderMarkdownWithFootnotes(post.Text, post.FootnotePrefix())
 	return nil
 }
 
@@ -253,6 +313,9 @@ func (post *Post) DeleteInContext(ctx aero.Context) error {
 	logEntry := NewEditLogEntry(user.ID, "delete", "Post", post.ID, "", fmt.Sprint(post), "")
 	logEntry.Save()
 
+	moderationLogEntry := NewModerationLogEntry(user.ID, "delete", "Post", post.ID, "", "")
+	moderationLogEntry.Save()
+
 	return post.Delete()
 }
 
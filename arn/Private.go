@@ -2,16 +2,31 @@ package arn
 
 var (
 	privateCollections = map[string]bool{
-		"Analytics":         true,
-		"Crash":             true,
-		"ClientErrorReport": true,
-		"EditLogEntry":      true,
-		"EmailToUser":       true,
-		"FacebookToUser":    true,
-		"PayPalPayment":     true,
-		"Purchase":          true,
-		"Session":           true,
-		"TwitterToUser":     true,
+		"Analytics":               true,
+		"APIKeyToUser":            true,
+		"Crash":                   true,
+		"ClientErrorReport":       true,
+		"Conversation":            true,
+		"EditLogEntry":            true,
+		"EmailDelivery":           true,
+		"EmailToUser":             true,
+		"FacebookToUser":          true,
+		"JobStatus":               true,
+		"ListImport":              true,
+		"ModerationLogEntry":      true,
+		"OAuthClient":             true,
+		"OAuthCode":               true,
+		"OAuthToken":              true,
+		"PayPalPayment":           true,
+		"PendingEdit":             true,
+		"Purchase":                true,
+		"Report":                  true,
+		"Session":                 true,
+		"StreamingLinkSubmission": true,
+		"TwitterToUser":           true,
+		"UserAPIKeys":             true,
+		"UserDrafts":              true,
+		"UserRecommendations":     true,
 	}
 )
 
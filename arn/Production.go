@@ -4,6 +4,9 @@ import (
 	"os"
 )
 
+// Domain is the host name used in absolute links, e.g. in notifications and emails.
+var Domain = "notify.moe"
+
 // IsProduction returns true if PRODUCTION is set to 1.
 func IsProduction() bool {
 	return os.Getenv("PRODUCTION") == "1"
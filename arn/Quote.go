@@ -20,6 +20,10 @@ type Quote struct {
 	AnimeID       AnimeID     `json:"animeId" editable:"true"`
 	EpisodeNumber int         `json:"episode" editable:"true"`
 	Time          int         `json:"time" editable:"true"`
+	IsPending     bool        `json:"isPending"`
+	IsRejected    bool        `json:"isRejected"`
+	ReviewedBy    UserID      `json:"reviewedBy"`
+	Reviewed      string      `json:"reviewed"`
 
 	hasID
 	hasPosts
@@ -98,12 +102,40 @@ func (quote *Quote) Publish() error {
 		return errors.New("Invalid episode number")
 	}
 
-	return publish(quote)
+	err := publish(quote)
+
+	if err != nil {
+		return err
+	}
+
+	// Quotes by normal users need to be approved by an editor
+	creator := quote.Creator()
+	quote.IsPending = creator == nil || !creator.IsModerator()
+	quote.IsRejected = false
+	return nil
 }
 
-// Unpublish ...
+// Unpublish turns the quote back into a draft.
 func (quote *Quote) Unpublish() error {
-	return unpublish(quote)
+	err := unpublish(quote)
+
+	if err != nil {
+		return err
+	}
+
+	quote.IsPending = false
+	return nil
+}
+
+// IsPublic tells you whether the quote is published and approved.
+func (quote *Quote) IsPublic() bool {
+	return !quote.IsDraft && !quote.IsPending && !quote.IsRejected
+}
+
+// Reviewer returns the editor who approved or rejected the quote.
+func (quote *Quote) Reviewer() *User {
+	user, _ := GetUser(quote.ReviewedBy)
+	return user
 }
 
 // TypeName returns the type name.
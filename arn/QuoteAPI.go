@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"strings"
 
 	"github.com/aerogo/aero"
 	"github.com/aerogo/api"
@@ -35,9 +36,86 @@ func init() {
 
 		// Unlike
 		UnlikeAction(),
+
+		// Approve
+		{
+			Name:  "approve",
+			Route: "/approve",
+			Run: func(obj interface{}, ctx aero.Context) error {
+				quote := obj.(*Quote)
+				user, err := quote.reviewer(ctx)
+
+				if err != nil {
+					return err
+				}
+
+				quote.IsPending = false
+				quote.markReviewed(user, "approved")
+				quote.Save()
+
+				creator := quote.Creator()
+
+				if creator != nil {
+					go creator.SendNotification(&PushNotification{
+						Title:   "Your quote has been approved",
+						Message: quote.Text.English,
+						Icon:    "https:" + user.AvatarLink("large"),
+						Link:    "https://" + Domain + quote.Link(),
+						Type:    NotificationTypeQuoteApproved,
+					})
+				}
+
+				return nil
+			},
+		},
+
+		// Reject
+		{
+			Name:  "reject",
+			Route: "/reject",
+			Run: func(obj interface{}, ctx aero.Context) error {
+				quote := obj.(*Quote)
+				user, err := quote.reviewer(ctx)
+
+				if err != nil {
+					return err
+				}
+
+				// Rejected quotes stay hidden until the author edits them
+				quote.IsPending = false
+				quote.IsRejected = true
+				quote.markReviewed(user, "rejected")
+				quote.Save()
+				return nil
+			},
+		},
 	})
 }
 
+// reviewer returns the user reviewing the quote if it's waiting for approval.
+func (quote *Quote) reviewer(ctx aero.Context) (*User, error) {
+	user := GetUserFromContext(ctx)
+
+	if user == nil {
+		return nil, errors.New("Not logged in")
+	}
+
+	if !quote.IsPending {
+		return nil, errors.New("This quote has already been reviewed")
+	}
+
+	return user, nil
+}
+
+// markReviewed remembers who reviewed the quote and writes a moderation log entry.
+func (quote *Quote) markReviewed(user *User, status string) {
+	quote.ReviewedBy = user.ID
+	quote.Reviewed = DateTimeUTC()
+
+	logEntry := NewModerationLogEntry(user.ID, "quote-"+status, "Quote", quote.ID, "", quote.Text.English)
+	logEntry.Save()
+}
+
 // Create sets the data for a new quote with data we received from the API request.
 func (quote *Quote) Create(ctx aero.Context) error {
 	user := GetUserFromContext(ctx)
@@ -63,6 +141,12 @@ func (quote *Quote) Create(ctx aero.Context) error {
 func (quote *Quote) Edit(ctx aero.Context, key string, value reflect.Value, newValue reflect.Value) (bool, error) {
 	user := GetUserFromContext(ctx)
 
+	// Changed texts of published quotes need to be approved again
+	if !quote.IsDraft && !user.IsModerator() && (strings.HasPrefix(key, "Text") || key == "CharacterID") {
+		quote.IsPending = true
+		quote.IsRejected = false
+	}
+
 	// Write log entry
 	logEntry := NewEditLogEntry(user.ID, "edit", "Quote", quote.ID, key, fmt.Sprint(value.Interface()), fmt.Sprint(newValue.Interface()))
 	logEntry.Save()
@@ -123,7 +207,7 @@ func (quote *Quote) Authorize(ctx aero.Context, action string) error {
 		return errors.New("Not logged in")
 	}
 
-	if action == "delete" {
+	if action == "delete" || action == "approve" || action == "reject" {
 		if user.Role != "editor" && user.Role != "admin" {
 			return errors.New("Insufficient permissions")
 		}
@@ -0,0 +1,25 @@
+package arn_test
+
+import (
+	"testing"
+
+	"github.com/akyoto/assert"
+	"github.com/animenotifier/notify.moe/arn"
+)
+
+func TestQuoteIsPublic(t *testing.T) {
+	quote := &arn.Quote{}
+	assert.True(t, quote.IsPublic())
+
+	quote.IsPending = true
+	assert.False(t, quote.IsPublic())
+
+	quote.IsPending = false
+	quote.IsDraft = true
+	assert.False(t, quote.IsPublic())
+}
+
+func TestQuoteIsRejected(t *testing.T) {
+	quote := &arn.Quote{IsRejected: true}
+	assert.False(t, quote.IsPublic())
+}
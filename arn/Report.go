@@ -0,0 +1,185 @@
+package arn
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/aerogo/nano"
+)
+
+// Report reasons
+const (
+	ReportReasonSpam          = "spam"
+	ReportReasonAbuse         = "abuse"
+	ReportReasonSpoiler       = "spoiler"
+	ReportReasonInappropriate = "inappropriate"
+	ReportReasonOther         = "other"
+)
+
+// Report states
+const (
+	ReportStatusOpen      = "open"
+	ReportStatusDismissed = "dismissed"
+	ReportStatusActioned  = "actioned"
+)
+
+// reportableTypes are the object types that users can report.
+var reportableTypes = map[string]bool{
+	"Post":   true,
+	"Review": true,
+	"Thread": true,
+	"User":   true,
+}
+
+func init() {
+	DataLists["report-reasons"] = []*Option{
+		{ReportReasonSpam, "Spam or advertising"},
+		{ReportReasonAbuse, "Harassment or abuse"},
+		{ReportReasonSpoiler, "Unmarked spoilers"},
+		{ReportReasonInappropriate, "Inappropriate content"},
+		{ReportReasonOther, "Something else"},
+	}
+}
+
+// Report is a user's complaint about a post, thread, review or profile that moderators need to look at.
+type Report struct {
+	ObjectType string `json:"objectType"`
+	ObjectID   ID     `json:"objectId"`
+	Reason     string `json:"reason"`
+	Text       string `json:"text"`
+	Status     string `json:"status"`
+	ResolvedBy UserID `json:"resolvedBy"`
+	Resolved   string `json:"resolved"`
+
+	hasID
+	hasCreator
+}
+
+// IsReportableType tells you whether objects of the given type can be reported.
+func IsReportableType(typeName string) bool {
+	return reportableTypes[typeName]
+}
+
+// IsReportReason tells you whether the reason is one of the report categories.
+func IsReportReason(reason string) bool {
+	for _, option := range DataLists["report-reasons"] {
+		if option.Value == reason {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ReasonHumanReadable returns the label of the report category.
+func (report *Report) ReasonHumanReadable() string {
+	for _, option := range DataLists["report-reasons"] {
+		if option.Value == report.Reason {
+			return option.Label
+		}
+	}
+
+	return report.Reason
+}
+
+// IsOpen tells you whether the report still needs to be handled.
+func (report *Report) IsOpen() bool {
+	return report.Status == ReportStatusOpen
+}
+
+// Resolve closes the report with the given status.
+func (report *Report) Resolve(userID UserID, status string) {
+	report.Status = status
+	report.ResolvedBy = userID
+	report.Resolved = DateTimeUTC()
+}
+
+// Object returns the reported object.
+func (report *Report) Object() interface{} {
+	obj, _ := DB.Get(report.ObjectType, report.ObjectID)
+	return obj
+}
+
+// ObjectTitle returns a short description of the reported object.
+func (report *Report) ObjectTitle() string {
+	return GetObjectTitle(report.ObjectType, report.ObjectID)
+}
+
+// ObjectLink returns the link to the reported object.
+func (report *Report) ObjectLink() string {
+	return GetObjectLink(report.ObjectType, report.ObjectID)
+}
+
+// ObjectText returns the text of a reported post, thread or review or an empty string.
+func (report *Report) ObjectText() string {
+	switch obj := report.Object().(type) {
+	case Postable:
+		return obj.GetText()
+	case *Review:
+		return obj.Text
+	default:
+		return ""
+	}
+}
+
+// ResolvedByUser returns the moderator who resolved the report.
+func (report *Report) ResolvedByUser() *User {
+	user, _ := GetUser(report.ResolvedBy)
+	return user
+}
+
+// String implements the default string serialization.
+func (report *Report) String() string {
+	return fmt.Sprintf("%s report on %s %s", report.Reason, report.ObjectType, report.ObjectID)
+}
+
+// TypeName returns the type name.
+func (report *Report) TypeName() string {
+	return "Report"
+}
+
+// GetReport ...
+func GetReport(id ID) (*Report, error) {
+	obj, err := DB.Get("Report", id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return obj.(*Report), nil
+}
+
+// StreamReports returns a stream of all reports.
+func StreamReports() <-chan *Report {
+	channel := make(chan *Report, nano.ChannelBufferSize)
+
+	go func() {
+		for obj := range DB.All("Report") {
+			channel <- obj.(*Report)
+		}
+
+		close(channel)
+	}()
+
+	return channel
+}
+
+// FilterReports filters all reports by a custom function.
+func FilterReports(filter func(*Report) bool) []*Report {
+	var filtered []*Report
+
+	for obj := range StreamReports() {
+		if filter(obj) {
+			filtered = append(filtered, obj)
+		}
+	}
+
+	return filtered
+}
+
+// SortReportsLatestFirst puts the latest reports on top.
+func SortReportsLatestFirst(reports []*Report) {
+	sort.Slice(reports, func(i, j int) bool {
+		return reports[i].Created > reports[j].Created
+	})
+}
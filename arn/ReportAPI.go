@@ -0,0 +1,185 @@
+package arn
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/aerogo/aero"
+	"github.com/aerogo/api"
+	"github.com/animenotifier/notify.moe/arn/limits"
+)
+
+// Force interface implementations
+var (
+	_ fmt.Stringer   = (*Report)(nil)
+	_ api.Newable    = (*Report)(nil)
+	_ api.Actionable = (*Report)(nil)
+	_ api.Filter     = (*Report)(nil)
+)
+
+// Actions
+func init() {
+	API.RegisterActions("Report", []*api.Action{
+		// Dismiss report
+		ResolveReportAction("dismiss", ReportStatusDismissed),
+
+		// Mark report as actioned
+		ResolveReportAction("action", ReportStatusActioned),
+	})
+}
+
+// Authorize returns an error if the given API request is not authorized.
+func (report *Report) Authorize(ctx aero.Context, action string) error {
+	user := GetUserFromContext(ctx)
+
+	if user == nil {
+		return errors.New("Not logged in")
+	}
+
+	if action != "create" && !user.IsModerator() {
+		return errors.New("Only moderators can do this")
+	}
+
+	return nil
+}
+
+// Create sets the data for a new report with data we received from the API request.
+func (report *Report) Create(ctx aero.Context) error {
+	data, err := ctx.Request().Body().JSONObject()
+
+	if err != nil {
+		return err
+	}
+
+	user := GetUserFromContext(ctx)
+
+	if user == nil {
+		return errors.New("Not logged in")
+	}
+
+	report.ID = GenerateID("Report")
+	report.ObjectType, _ = data["objectType"].(string)
+	report.ObjectID, _ = data["objectId"].(string)
+	report.Reason, _ = data["reason"].(string)
+	report.Text, _ = data["text"].(string)
+	report.Text = strings.TrimSpace(report.Text)
+	report.Status = ReportStatusOpen
+	report.CreatedBy = user.ID
+	report.Created = DateTimeUTC()
+
+	if !IsReportableType(report.ObjectType) {
+		return errors.New("This can't be reported")
+	}
+
+	if !IsReportReason(report.Reason) {
+		return errors.New("Invalid reason")
+	}
+
+	if len(report.Text) > limits.DefaultTextAreaMaxLength {
+		return errors.New("Text too long")
+	}
+
+	if report.Object() == nil {
+		return errors.New(report.ObjectType + " does not exist")
+	}
+
+	if report.ObjectCreatorID() == user.ID {
+		return errors.New("You can't report yourself")
+	}
+
+	// One open report per user is enough
+	for existing := range StreamReports() {
+		if existing.IsOpen() && existing.CreatedBy == user.ID && existing.ObjectType == report.ObjectType && existing.ObjectID == report.ObjectID {
+			return errors.New("You already reported this")
+		}
+	}
+
+	// Alert the staff
+	go report.NotifyModerators()
+	return nil
+}
+
+// ObjectCreatorID returns the ID of the user responsible for the reported object.
+func (report *Report) ObjectCreatorID() UserID {
+	switch obj := report.Object().(type) {
+	case *User:
+		return obj.ID
+	case *Review:
+		return obj.CreatedBy
+	case Postable:
+		creator := obj.Creator()
+
+		if creator == nil {
+			return ""
+		}
+
+		return creator.ID
+	default:
+		return ""
+	}
+}
+
+// NotifyModerators sends a notification about the report to all moderators.
+func (report *Report) NotifyModerators() {
+	reporter := report.Creator()
+
+	moderators := FilterUsers(func(user *User) bool {
+		return user.IsModerator() && user.ID != reporter.ID
+	})
+
+	for _, moderator := range moderators {
+		moderator.SendNotification(&PushNotification{
+			Title:   "New report: " + report.ReasonHumanReadable(),
+			Message: fmt.Sprintf(`%s reported %s "%s".`, reporter.Nick, strings.ToLower(report.ObjectType), report.ObjectTitle()),
+			Icon:    "https:" + reporter.AvatarLink("large"),
+			Link:    "https://" + Domain + "/reports",
+			Type:    NotificationTypeReport,
+		})
+	}
+}
+
+// ResolveReportAction closes the report with the given status.
+func ResolveReportAction(name string, status string) *api.Action {
+	return &api.Action{
+		Name:  name,
+		Route: "/" + name,
+		Run: func(obj interface{}, ctx aero.Context) error {
+			report := obj.(*Report)
+			user := GetUserFromContext(ctx)
+
+			if user == nil {
+				return errors.New("Not logged in")
+			}
+
+			if !report.IsOpen() {
+				return errors.New("This report has already been resolved")
+			}
+
+			report.Resolve(user.ID, status)
+			report.Save()
+
+			logEntry := NewModerationLogEntry(user.ID, "report-"+status, report.ObjectType, report.ObjectID, report.ReasonHumanReadable(), report.Text)
+			logEntry.Save()
+			return nil
+		},
+	}
+}
+
+// ShouldFilter tells whether data needs to be filtered in the given context.
+func (report *Report) ShouldFilter(ctx aero.Context) bool {
+	user := GetUserFromContext(ctx)
+	return user == nil || !user.IsModerator()
+}
+
+// Filter removes who reported the object and why.
+func (report *Report) Filter() {
+	report.CreatedBy = ""
+	report.Text = ""
+	report.ResolvedBy = ""
+}
+
+// Save saves the report in the database.
+func (report *Report) Save() {
+	DB.Set("Report", report.ID, report)
+}
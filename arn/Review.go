@@ -0,0 +1,244 @@
+package arn
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/aerogo/nano"
+)
+
+// ReviewScores are the sub-scores of a review, each one ranging from 0 to 10.
+// A score of 0 means that the reviewer didn't rate that aspect.
+type ReviewScores struct {
+	Story      float64 `json:"story" editable:"true"`
+	Animation  float64 `json:"animation" editable:"true"`
+	Sound      float64 `json:"sound" editable:"true"`
+	Characters float64 `json:"characters" editable:"true"`
+}
+
+// Overall returns the average of all sub-scores that have been rated.
+func (scores *ReviewScores) Overall() float64 {
+	sum := 0.0
+	count := 0
+
+	for _, score := range []float64{scores.Story, scores.Animation, scores.Sound, scores.Characters} {
+		if score == 0 {
+			continue
+		}
+
+		sum += score
+		count++
+	}
+
+	if count == 0 {
+		return 0
+	}
+
+	return sum / float64(count)
+}
+
+// Review is a long-form review of an anime written by a user.
+type Review struct {
+	AnimeID    AnimeID      `json:"animeId"`
+	Title      string       `json:"title" editable:"true" maxLength:"100"`
+	Text       string       `json:"text" editable:"true" type:"textarea" maxLength:"20000"`
+	Scores     ReviewScores `json:"scores" editable:"true"`
+	Helpful    []UserID     `json:"helpful"`
+	NotHelpful []UserID     `json:"notHelpful"`
+
+	hasID
+	hasCreator
+	hasEditor
+}
+
+// NewReview creates a new, empty review of the anime.
+func NewReview(userID UserID, animeID AnimeID) *Review {
+	return &Review{
+		AnimeID:    animeID,
+		Helpful:    []UserID{},
+		NotHelpful: []UserID{},
+		hasID: hasID{
+			ID: GenerateID("Review"),
+		},
+		hasCreator: hasCreator{
+			Created:   DateTimeUTC(),
+			CreatedBy: userID,
+		},
+	}
+}
+
+// Link returns the URI to the review page.
+func (review *Review) Link() string {
+	return "/review/" + review.ID
+}
+
+// Anime returns the reviewed anime.
+func (review *Review) Anime() *Anime {
+	anime, _ := GetAnime(review.AnimeID)
+	return anime
+}
+
+// IsEmpty tells you whether the reviewer hasn't written anything yet.
+func (review *Review) IsEmpty() bool {
+	return review.Text == ""
+}
+
+// ListItem returns the entry of the reviewed anime in the reviewer's anime list.
+func (review *Review) ListItem() *AnimeListItem {
+	creator := review.Creator()
+
+	if creator == nil {
+		return nil
+	}
+
+	return creator.AnimeList().Find(review.AnimeID)
+}
+
+// Vote sets the user's vote on how helpful the review is.
+// Voting the same way twice removes the vote.
+func (review *Review) Vote(userID UserID, helpful bool) {
+	alreadyHelpful := Contains(review.Helpful, userID)
+	alreadyNotHelpful := Contains(review.NotHelpful, userID)
+
+	review.Helpful = removeUserID(review.Helpful, userID)
+	review.NotHelpful = removeUserID(review.NotHelpful, userID)
+
+	switch {
+	case helpful && !alreadyHelpful:
+		review.Helpful = append(review.Helpful, userID)
+	case !helpful && !alreadyNotHelpful:
+		review.NotHelpful = append(review.NotHelpful, userID)
+	}
+}
+
+// IsHelpfulTo tells you whether the user voted that the review is helpful.
+func (review *Review) IsHelpfulTo(userID UserID) bool {
+	return Contains(review.Helpful, userID)
+}
+
+// IsNotHelpfulTo tells you whether the user voted that the review is not helpful.
+func (review *Review) IsNotHelpfulTo(userID UserID) bool {
+	return Contains(review.NotHelpful, userID)
+}
+
+// Helpfulness returns the number of helpful votes minus the number of not helpful votes.
+func (review *Review) Helpfulness() int {
+	return len(review.Helpful) - len(review.NotHelpful)
+}
+
+// TitleByUser returns the preferred title for the given user.
+func (review *Review) TitleByUser(user *User) string {
+	if review.Title != "" {
+		return review.Title
+	}
+
+	anime := review.Anime()
+
+	if anime == nil {
+		return "Review"
+	}
+
+	return fmt.Sprintf("Review of %s", anime.Title.ByUser(user))
+}
+
+// String implements the default string serialization.
+func (review *Review) String() string {
+	return review.TitleByUser(nil)
+}
+
+// TypeName returns the type name.
+func (review *Review) TypeName() string {
+	return "Review"
+}
+
+// Self returns the object itself.
+func (review *Review) Self() Loggable {
+	return review
+}
+
+// removeUserID returns the list without the given user ID.
+func removeUserID(userIDs []UserID, userID UserID) []UserID {
+	for index, id := range userIDs {
+		if id == userID {
+			return append(userIDs[:index], userIDs[index+1:]...)
+		}
+	}
+
+	return userIDs
+}
+
+// SortReviewsByHelpfulness puts the most helpful reviews on top.
+func SortReviewsByHelpfulness(reviews []*Review) {
+	sort.Slice(reviews, func(i, j int) bool {
+		a := reviews[i].Helpfulness()
+		b := reviews[j].Helpfulness()
+
+		if a == b {
+			return reviews[i].Created > reviews[j].Created
+		}
+
+		return a > b
+	})
+}
+
+// GetReview ...
+func GetReview(id ID) (*Review, error) {
+	obj, err := DB.Get("Review", id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return obj.(*Review), nil
+}
+
+// StreamReviews returns a stream of all reviews.
+func StreamReviews() <-chan *Review {
+	channel := make(chan *Review, nano.ChannelBufferSize)
+
+	go func() {
+		for obj := range DB.All("Review") {
+			channel <- obj.(*Review)
+		}
+
+		close(channel)
+	}()
+
+	return channel
+}
+
+// FilterReviews filters all reviews by a custom function.
+func FilterReviews(filter func(*Review) bool) []*Review {
+	var filtered []*Review
+
+	for obj := range StreamReviews() {
+		if filter(obj) {
+			filtered = append(filtered, obj)
+		}
+	}
+
+	return filtered
+}
+
+// Reviews returns the reviews of the anime that have a text, most helpful first.
+func (anime *Anime) Reviews() []*Review {
+	reviews := FilterReviews(func(review *Review) bool {
+		return review.AnimeID == anime.ID && !review.IsEmpty()
+	})
+
+	SortReviewsByHelpfulness(reviews)
+	return reviews
+}
+
+// ReviewBy returns the review of the anime written by the given user.
+func (anime *Anime) ReviewBy(userID UserID) *Review {
+	reviews := FilterReviews(func(review *Review) bool {
+		return review.AnimeID == anime.ID && review.CreatedBy == userID
+	})
+
+	if len(reviews) == 0 {
+		return nil
+	}
+
+	return reviews[0]
+}
@@ -0,0 +1,195 @@
+package arn
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/aerogo/aero"
+	"github.com/aerogo/api"
+)
+
+// Review limits
+const (
+	maxReviewTitleLength = 100
+	maxReviewTextLength  = 20000
+	maxReviewScore       = 10
+)
+
+// reviewCreationMutex makes sure that a user can't create two reviews for the same anime.
+var reviewCreationMutex sync.Mutex
+
+// Force interface implementations
+var (
+	_ fmt.Stringer       = (*Review)(nil)
+	_ api.Newable        = (*Review)(nil)
+	_ api.Editable       = (*Review)(nil)
+	_ api.CustomEditable = (*Review)(nil)
+	_ api.Deletable      = (*Review)(nil)
+	_ api.Actionable     = (*Review)(nil)
+)
+
+// Actions
+func init() {
+	API.RegisterActions("Review", []*api.Action{
+		// Mark review as helpful
+		ReviewVoteAction("helpful", true),
+
+		// Mark review as not helpful
+		ReviewVoteAction("nothelpful", false),
+	})
+}
+
+// ReviewVoteAction toggles the helpful or not helpful vote of the user.
+func ReviewVoteAction(name string, helpful bool) *api.Action {
+	return &api.Action{
+		Name:  name,
+		Route: "/" + name,
+		Run: func(obj interface{}, ctx aero.Context) error {
+			review := obj.(*Review)
+			user := GetUserFromContext(ctx)
+
+			if review.IsEmpty() {
+				return errors.New("This review doesn't have any text yet")
+			}
+
+			review.Vote(user.ID, helpful)
+			review.Save()
+			return nil
+		},
+	}
+}
+
+// Create sets the data for a new review with data we received from the API request.
+func (review *Review) Create(ctx aero.Context) error {
+	data, err := ctx.Request().Body().JSONObject()
+
+	if err != nil {
+		return err
+	}
+
+	user := GetUserFromContext(ctx)
+
+	if user == nil {
+		return errors.New("Not logged in")
+	}
+
+	animeID, _ := data["animeId"].(string)
+	anime, err := GetAnime(animeID)
+
+	if err != nil {
+		return errors.New("Anime does not exist")
+	}
+
+	reviewCreationMutex.Lock()
+	defer reviewCreationMutex.Unlock()
+
+	if anime.ReviewBy(user.ID) != nil {
+		return errors.New("You already wrote a review for this anime")
+	}
+
+	*review = *NewReview(user.ID, anime.ID)
+
+	// Save right away so that parallel requests see the review
+	review.Save()
+	return nil
+}
+
+// Authorize returns an error if the given API request is not authorized.
+func (review *Review) Authorize(ctx aero.Context, action string) error {
+	user := GetUserFromContext(ctx)
+
+	if user == nil {
+		return errors.New("Not logged in")
+	}
+
+	switch action {
+	case "create":
+		return nil
+
+	case "helpful", "nothelpful":
+		if review.CreatedBy == user.ID {
+			return errors.New("You can't vote on your own review")
+		}
+
+		return nil
+
+	case "delete":
+		if review.CreatedBy != user.ID && !user.IsModerator() {
+			return errors.New("Can't delete reviews of other users")
+		}
+
+		return nil
+	}
+
+	if review.CreatedBy != user.ID {
+		return errors.New("Can't edit reviews of other users")
+	}
+
+	return nil
+}
+
+// Edit validates the review title, text and scores.
+func (review *Review) Edit(ctx aero.Context, key string, value reflect.Value, newValue reflect.Value) (bool, error) {
+	switch key {
+	case "Title":
+		title := strings.TrimSpace(newValue.String())
+
+		if len([]rune(title)) > maxReviewTitleLength {
+			return true, fmt.Errorf("Titles can't be longer than %d characters", maxReviewTitleLength)
+		}
+
+		review.Title = title
+
+	case "Text":
+		text := strings.TrimSpace(newValue.String())
+
+		if len([]rune(text)) > maxReviewTextLength {
+			return true, fmt.Errorf("Reviews can't be longer than %d characters", maxReviewTextLength)
+		}
+
+		review.Text = text
+
+	case "Scores.Story", "Scores.Animation", "Scores.Sound", "Scores.Characters":
+		score := newValue.Float()
+
+		if score < 0 || score > maxReviewScore {
+			return true, fmt.Errorf("Scores need to be between 0 and %d", maxReviewScore)
+		}
+
+		value.SetFloat(score)
+
+	default:
+		return false, nil
+	}
+
+	review.Edited = DateTimeUTC()
+	review.EditedBy = GetUserFromContext(ctx).ID
+	return true, nil
+}
+
+// DeleteInContext deletes the review in the given context.
+func (review *Review) DeleteInContext(ctx aero.Context) error {
+	user := GetUserFromContext(ctx)
+
+	// Moderators removing abusive reviews
+	if user.ID != review.CreatedBy {
+		logEntry := NewModerationLogEntry(user.ID, "delete", "Review", review.ID, "", review.TitleByUser(nil))
+		logEntry.Save()
+	}
+
+	return review.Delete()
+}
+
+// Delete deletes the review from the database.
+func (review *Review) Delete() error {
+	DB.Delete("Review", review.ID)
+	return nil
+}
+
+// Save saves the review in the database.
+func (review *Review) Save() {
+	DB.Set("Review", review.ID, review)
+}
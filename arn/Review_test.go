@@ -0,0 +1,48 @@
+package arn_test
+
+import (
+	"testing"
+
+	"github.com/akyoto/assert"
+	"github.com/animenotifier/notify.moe/arn"
+)
+
+func TestReviewScoresOverall(t *testing.T) {
+	scores := &arn.ReviewScores{}
+	assert.Equal(t, scores.Overall(), 0.0)
+
+	scores.Story = 8
+	scores.Sound = 6
+	assert.Equal(t, scores.Overall(), 7.0)
+}
+
+func TestReviewVote(t *testing.T) {
+	review := arn.NewReview("author", "anime")
+
+	review.Vote("a", true)
+	review.Vote("b", true)
+	review.Vote("c", false)
+	assert.Equal(t, review.Helpfulness(), 1)
+	assert.True(t, review.IsHelpfulTo("a"))
+
+	// Changing the vote
+	review.Vote("a", false)
+	assert.False(t, review.IsHelpfulTo("a"))
+	assert.True(t, review.IsNotHelpfulTo("a"))
+	assert.Equal(t, review.Helpfulness(), -1)
+
+	// Voting the same way again removes the vote
+	review.Vote("a", false)
+	assert.False(t, review.IsNotHelpfulTo("a"))
+	assert.Equal(t, review.Helpfulness(), 0)
+}
+
+func TestSortReviewsByHelpfulness(t *testing.T) {
+	a := arn.NewReview("a", "anime")
+	b := arn.NewReview("b", "anime")
+	b.Vote("x", true)
+
+	reviews := []*arn.Review{a, b}
+	arn.SortReviewsByHelpfulness(reviews)
+	assert.Equal(t, reviews[0], b)
+}
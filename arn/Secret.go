@@ -0,0 +1,26 @@
+package arn
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// GenerateSecret generates a random hex encoded secret, e.g. for signing webhooks or API keys.
+func GenerateSecret() string {
+	secret := make([]byte, 32)
+	_, err := rand.Read(secret)
+
+	if err != nil {
+		panic(err)
+	}
+
+	return hex.EncodeToString(secret)
+}
+
+// HashSecret returns the hash of a secret that is stored in the database instead of the secret itself.
+// Generated secrets are random enough to not need a salt.
+func HashSecret(secret string) string {
+	hash := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(hash[:])
+}
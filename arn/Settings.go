@@ -1,6 +1,11 @@
 package arn
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
 
 const (
 	// SortByAiringDate sorts your watching list by airing date.
@@ -38,7 +43,9 @@ type Settings struct {
 	Editor        EditorSettings       `json:"editor"`
 	Privacy       PrivacySettings      `json:"privacy"`
 	Calendar      CalendarSettings     `json:"calendar" editable:"true"`
+	Forum         ForumSettings        `json:"forum"`
 	Theme         string               `json:"theme" editable:"true"`
+	Locale        string               `json:"locale" editable:"true" datalist:"locales"`
 }
 
 // PrivacySettings ...
@@ -50,14 +57,19 @@ type PrivacySettings struct {
 
 // NotificationSettings ...
 type NotificationSettings struct {
-	Email                string `json:"email" private:"true"`
-	NewFollowers         bool   `json:"newFollowers" editable:"true"`
-	AnimeEpisodeReleases bool   `json:"animeEpisodeReleases" editable:"true"`
-	AnimeFinished        bool   `json:"animeFinished" editable:"true"`
-	ForumLikes           bool   `json:"forumLikes" editable:"true"`
-	GroupPostLikes       bool   `json:"groupPostLikes" editable:"true"`
-	QuoteLikes           bool   `json:"quoteLikes" editable:"true"`
-	SoundTrackLikes      bool   `json:"soundTrackLikes" editable:"true"`
+	Email                       string                  `json:"email" private:"true"`
+	Preferences                 NotificationPreferences `json:"preferences"`
+	AnimeFinished               bool                    `json:"animeFinished" editable:"true"`
+	ForumLikes                  bool                    `json:"forumLikes" editable:"true"`
+	GroupPostLikes              bool                    `json:"groupPostLikes" editable:"true"`
+	QuoteLikes                  bool                    `json:"quoteLikes" editable:"true"`
+	SoundTrackLikes             bool                    `json:"soundTrackLikes" editable:"true"`
+	WebhookURL                  string                  `json:"webhookURL" editable:"true" private:"true"`
+	WebhookSecret               string                  `json:"webhookSecret" private:"true"`
+	EmailDigest                 string                  `json:"emailDigest" editable:"true" datalist:"email-digests"`
+	EpisodeNotificationLeadTime string                  `json:"episodeNotificationLeadTime" editable:"true" datalist:"notification-lead-times"`
+	EmailDigestSent             string                  `json:"emailDigestSent" private:"true"`
+	UnsubscribeToken            string                  `json:"unsubscribeToken" private:"true"`
 }
 
 // EditorSettings ...
@@ -111,7 +123,74 @@ type ServiceProviders struct {
 
 // CalendarSettings ...
 type CalendarSettings struct {
-	ShowAddedAnimeOnly bool `json:"showAddedAnimeOnly" editable:"true"`
+	ShowAddedAnimeOnly bool   `json:"showAddedAnimeOnly" editable:"true"`
+	Token              string `json:"token" private:"true"`
+}
+
+// ForumSettings ...
+type ForumSettings struct {
+	MutedKeywords   []string `json:"mutedKeywords" editable:"true"`
+	MutePartialWord bool     `json:"mutePartialWord" editable:"true"`
+}
+
+// IsMuted returns true if the text contains one of the muted keywords.
+// Keywords are case-insensitive and need to match a whole word
+// unless partial matching has been enabled.
+func (forum *ForumSettings) IsMuted(text string) bool {
+	text = strings.ToLower(text)
+
+	for _, keyword := range forum.MutedKeywords {
+		keyword = strings.ToLower(strings.TrimSpace(keyword))
+
+		if keyword == "" {
+			continue
+		}
+
+		if forum.MutePartialWord {
+			if strings.Contains(text, keyword) {
+				return true
+			}
+
+			continue
+		}
+
+		if containsWord(text, keyword) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// containsWord returns true if the word appears in the text
+// and is not directly surrounded by other letters or digits.
+func containsWord(text string, word string) bool {
+	offset := 0
+
+	for {
+		index := strings.Index(text[offset:], word)
+
+		if index == -1 {
+			return false
+		}
+
+		start := offset + index
+		end := start + len(word)
+		before, _ := utf8.DecodeLastRuneInString(text[:start])
+		after, _ := utf8.DecodeRuneInString(text[end:])
+
+		if !isWordCharacter(before) && !isWordCharacter(after) {
+			return true
+		}
+
+		_, size := utf8.DecodeRuneInString(text[start:])
+		offset = start + size
+	}
+}
+
+// isWordCharacter tells whether the rune is part of a word.
+func isWordCharacter(r rune) bool {
+	return r != utf8.RuneError && (unicode.IsLetter(r) || unicode.IsDigit(r))
 }
 
 // NewSettings creates the default settings for a new user.
@@ -140,14 +219,14 @@ func NewSettings(user *User) *Settings {
 // DefaultNotificationSettings returns the default notification settings.
 func DefaultNotificationSettings() NotificationSettings {
 	return NotificationSettings{
-		Email:                "",
-		NewFollowers:         true,
-		AnimeEpisodeReleases: true,
-		AnimeFinished:        false,
-		ForumLikes:           true,
-		GroupPostLikes:       true,
-		QuoteLikes:           true,
-		SoundTrackLikes:      true,
+		Email:                       "",
+		Preferences:                 DefaultNotificationPreferences(),
+		AnimeFinished:               false,
+		ForumLikes:                  true,
+		GroupPostLikes:              true,
+		QuoteLikes:                  true,
+		SoundTrackLikes:             true,
+		EpisodeNotificationLeadTime: NotificationLeadTimeRelease,
 	}
 }
 
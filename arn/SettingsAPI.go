@@ -3,6 +3,7 @@ package arn
 import (
 	"errors"
 	"reflect"
+	"strings"
 
 	"github.com/aerogo/aero"
 	"github.com/aerogo/api"
@@ -22,8 +23,6 @@ func (settings *Settings) Authorize(ctx aero.Context, action string) error {
 
 // Edit updates the settings object.
 func (settings *Settings) Edit(ctx aero.Context, key string, value reflect.Value, newValue reflect.Value) (bool, error) {
-	// This should stay as a switch statement.
-	// nolint:gocritic
 	switch key {
 	case "Theme":
 		if settings.User().IsPro() {
@@ -33,6 +32,34 @@ func (settings *Settings) Edit(ctx aero.Context, key string, value reflect.Value
 		}
 
 		return true, nil
+
+	case "Notification.WebhookURL":
+		webhookURL := strings.TrimSpace(newValue.String())
+
+		if webhookURL != "" {
+			err := ValidateWebhookURL(webhookURL)
+
+			if err != nil {
+				return true, err
+			}
+		}
+
+		if settings.Notification.WebhookSecret == "" {
+			settings.Notification.WebhookSecret = GenerateSecret()
+		}
+
+		settings.Notification.WebhookURL = webhookURL
+		return true, nil
+
+	case "Notification.EpisodeNotificationLeadTime":
+		leadTime := newValue.String()
+
+		if !IsNotificationLeadTime(leadTime) {
+			return true, errors.New("Invalid notification lead time")
+		}
+
+		settings.Notification.EpisodeNotificationLeadTime = leadTime
+		return true, nil
 	}
 
 	return false, nil
@@ -41,6 +68,11 @@ func (settings *Settings) Edit(ctx aero.Context, key string, value reflect.Value
 // Filter removes privacy critical fields from the settings object.
 func (settings *Settings) Filter() {
 	settings.Notification.Email = ""
+	settings.Notification.WebhookURL = ""
+	settings.Notification.WebhookSecret = ""
+	settings.Notification.EmailDigestSent = ""
+	settings.Notification.UnsubscribeToken = ""
+	settings.Calendar.Token = ""
 }
 
 // ShouldFilter tells whether data needs to be filtered in the given context.
@@ -0,0 +1,97 @@
+package arn
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/aerogo/aero"
+	"github.com/aerogo/api"
+)
+
+// SoftDeletable is an object that moderators can remove while keeping it in the database.
+type SoftDeletable interface {
+	SoftDelete(userID UserID, reason string)
+	Restore()
+	IsSoftDeleted() bool
+	SoftDeleteReason() string
+	TypeName() string
+	GetID() ID
+	Save()
+}
+
+// SoftDeleteAction ...
+func SoftDeleteAction() *api.Action {
+	return &api.Action{
+		Name:  "softdelete",
+		Route: "/softdelete",
+		Run: func(obj interface{}, ctx aero.Context) error {
+			user := GetUserFromContext(ctx)
+
+			if user == nil {
+				return errors.New("Not logged in")
+			}
+
+			body, err := ctx.Request().Body().JSONObject()
+
+			if err != nil {
+				return err
+			}
+
+			reason, _ := body["reason"].(string)
+			reason = strings.TrimSpace(reason)
+
+			if reason == "" {
+				return errors.New("Please specify a reason")
+			}
+
+			deletable := obj.(SoftDeletable)
+			deletable.SoftDelete(user.ID, reason)
+			deletable.Save()
+
+			logEntry := NewModerationLogEntry(user.ID, "softdelete", deletable.TypeName(), deletable.GetID(), reason, "")
+			logEntry.Save()
+			return nil
+		},
+	}
+}
+
+// RestoreAction ...
+func RestoreAction() *api.Action {
+	return &api.Action{
+		Name:  "restore",
+		Route: "/restore",
+		Run: func(obj interface{}, ctx aero.Context) error {
+			user := GetUserFromContext(ctx)
+
+			if user == nil {
+				return errors.New("Not logged in")
+			}
+
+			deletable := obj.(SoftDeletable)
+			reason := deletable.SoftDeleteReason()
+			deletable.Restore()
+			deletable.Save()
+
+			logEntry := NewModerationLogEntry(user.ID, "restore", deletable.TypeName(), deletable.GetID(), "", "Removed for: "+reason)
+			logEntry.Save()
+			return nil
+		},
+	}
+}
+
+// IsSoftDeleted returns true if the given object has been removed by a moderator.
+func IsSoftDeleted(obj interface{}) bool {
+	deletable, isDeletable := obj.(SoftDeletable)
+	return isDeletable && deletable.IsSoftDeleted()
+}
+
+// SoftDeleteReason returns the reason the given object has been removed for.
+func SoftDeleteReason(obj interface{}) string {
+	deletable, isDeletable := obj.(SoftDeletable)
+
+	if !isDeletable {
+		return ""
+	}
+
+	return deletable.SoftDeleteReason()
+}
@@ -6,6 +6,7 @@ import (
 	"os/exec"
 	"path"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/aerogo/nano"
@@ -13,6 +14,13 @@ import (
 	"github.com/animenotifier/notify.moe/arn/autocorrect"
 )
 
+// SoundTrack types as shown on the anime soundtrack page
+const (
+	SoundTrackTypeOpening = "opening"
+	SoundTrackTypeEnding  = "ending"
+	SoundTrackTypeOST     = "ost"
+)
+
 // SoundTrack is a soundtrack used in one or multiple anime.
 type SoundTrack struct {
 	Title  SoundTrackTitle  `json:"title" editable:"true"`
@@ -75,6 +83,43 @@ func (track *SoundTrack) HasTag(search string) bool {
 	return false
 }
 
+// Type returns whether the track is an opening, an ending or part of the original soundtrack.
+func (track *SoundTrack) Type() string {
+	switch {
+	case track.HasTag(SoundTrackTypeOpening):
+		return SoundTrackTypeOpening
+	case track.HasTag(SoundTrackTypeEnding):
+		return SoundTrackTypeEnding
+	default:
+		return SoundTrackTypeOST
+	}
+}
+
+// Sequence returns the number of the opening or ending from the "op:NUMBER" or "ed:NUMBER" tag.
+// Openings and endings without the tag are the first one, other tracks return 0.
+func (track *SoundTrack) Sequence() int {
+	prefix := ""
+
+	switch track.Type() {
+	case SoundTrackTypeOpening:
+		prefix = "op"
+	case SoundTrackTypeEnding:
+		prefix = "ed"
+	default:
+		return 0
+	}
+
+	for _, id := range FilterIDTags(track.Tags, prefix) {
+		number, err := strconv.Atoi(id)
+
+		if err == nil && number > 0 {
+			return number
+		}
+	}
+
+	return 1
+}
+
 // HasLyrics returns true if the track has lyrics in any language.
 func (track *SoundTrack) HasLyrics() bool {
 	return track.Lyrics.Native != "" || track.Lyrics.Romaji != ""
@@ -346,6 +391,28 @@ func SortSoundTracksPopularFirst(tracks []*SoundTrack) {
 	})
 }
 
+// SortSoundTracksBySequence sorts openings and endings by their number
+// and tracks with the same number by popularity.
+func SortSoundTracksBySequence(tracks []*SoundTrack) {
+	sort.SliceStable(tracks, func(i, j int) bool {
+		aSequence := tracks[i].Sequence()
+		bSequence := tracks[j].Sequence()
+
+		if aSequence != bSequence {
+			return aSequence < bSequence
+		}
+
+		aLikes := len(tracks[i].Likes)
+		bLikes := len(tracks[j].Likes)
+
+		if aLikes != bLikes {
+			return aLikes > bLikes
+		}
+
+		return tracks[i].Created < tracks[j].Created
+	})
+}
+
 // GetSoundTrack ...
 func GetSoundTrack(id ID) (*SoundTrack, error) {
 	track, err := DB.Get("SoundTrack", id)
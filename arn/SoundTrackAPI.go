@@ -73,7 +73,7 @@ func (track *SoundTrack) Edit(ctx aero.Context, key string, value reflect.Value,
 		found := false
 
 		for _, option := range DataLists["media-services"] {
-			if option.Label == newService {
+			if option.Value == newService {
 				found = true
 				break
 			}
@@ -5,7 +5,11 @@ import (
 	"regexp"
 )
 
-var youtubeIDRegex = regexp.MustCompile(`youtu(?:.*\/v\/|.*v=|\.be\/)([A-Za-z0-9_-]{11})`)
+var (
+	youtubeIDRegex    = regexp.MustCompile(`youtu(?:.*\/v\/|.*v=|\.be\/)([A-Za-z0-9_-]{11})`)
+	spotifyIDRegex    = regexp.MustCompile(`(?:open\.spotify\.com\/(?:intl-[a-z-]+\/)?track\/|spotify:track:)([A-Za-z0-9]{22})`)
+	appleMusicIDRegex = regexp.MustCompile(`music\.apple\.com\/([a-z]{2}\/(?:album\/[^\/?#]+\/[0-9]+\?i=[0-9]+|song\/[^\/?#]+\/[0-9]+|song\/[0-9]+))`)
+)
 
 // GetYoutubeMedia returns an ExternalMedia object for the given Youtube link.
 func GetYoutubeMedia(url string) (*ExternalMedia, error) {
@@ -24,3 +28,49 @@ func GetYoutubeMedia(url string) (*ExternalMedia, error) {
 
 	return media, nil
 }
+
+// GetSpotifyMedia returns an ExternalMedia object for the given Spotify track link.
+func GetSpotifyMedia(url string) (*ExternalMedia, error) {
+	matches := spotifyIDRegex.FindStringSubmatch(url)
+
+	if len(matches) < 2 {
+		return nil, errors.New("Invalid Spotify track URL")
+	}
+
+	media := &ExternalMedia{
+		Service:   "Spotify",
+		ServiceID: matches[1],
+	}
+
+	return media, nil
+}
+
+// GetAppleMusicMedia returns an ExternalMedia object for the given Apple Music song link.
+// The service ID is the path of the song including the storefront, e.g. "jp/album/name/123?i=456".
+func GetAppleMusicMedia(url string) (*ExternalMedia, error) {
+	matches := appleMusicIDRegex.FindStringSubmatch(url)
+
+	if len(matches) < 2 {
+		return nil, errors.New("Invalid Apple Music song URL")
+	}
+
+	media := &ExternalMedia{
+		Service:   "AppleMusic",
+		ServiceID: matches[1],
+	}
+
+	return media, nil
+}
+
+// GetExternalMedia returns an ExternalMedia object for a Youtube, Spotify or Apple Music link.
+func GetExternalMedia(url string) (*ExternalMedia, error) {
+	for _, parse := range []func(string) (*ExternalMedia, error){GetYoutubeMedia, GetSpotifyMedia, GetAppleMusicMedia} {
+		media, err := parse(url)
+
+		if err == nil {
+			return media, nil
+		}
+	}
+
+	return nil, errors.New("Unsupported link, please use a Youtube, Spotify or Apple Music link")
+}
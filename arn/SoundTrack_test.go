@@ -0,0 +1,65 @@
+package arn_test
+
+import (
+	"testing"
+
+	"github.com/akyoto/assert"
+	"github.com/animenotifier/notify.moe/arn"
+)
+
+func TestGetExternalMedia(t *testing.T) {
+	links := map[string]*arn.ExternalMedia{
+		"https://www.youtube.com/watch?v=dQw4w9WgXcQ":                      {Service: "Youtube", ServiceID: "dQw4w9WgXcQ"},
+		"https://youtu.be/dQw4w9WgXcQ":                                     {Service: "Youtube", ServiceID: "dQw4w9WgXcQ"},
+		"https://open.spotify.com/track/4uLU6hMCjMI75M1A2tKUQC?si=abc":     {Service: "Spotify", ServiceID: "4uLU6hMCjMI75M1A2tKUQC"},
+		"https://open.spotify.com/intl-de/track/4uLU6hMCjMI75M1A2tKUQC":    {Service: "Spotify", ServiceID: "4uLU6hMCjMI75M1A2tKUQC"},
+		"https://music.apple.com/jp/album/gurenge/1491063195?i=1491063199": {Service: "AppleMusic", ServiceID: "jp/album/gurenge/1491063195?i=1491063199"},
+	}
+
+	for link, expected := range links {
+		media, err := arn.GetExternalMedia(link)
+		assert.Nil(t, err)
+		assert.DeepEqual(t, media, expected)
+	}
+
+	_, err := arn.GetExternalMedia("https://open.spotify.com/album/4uLU6hMCjMI75M1A2tKUQC")
+	assert.NotNil(t, err)
+
+	_, err = arn.GetExternalMedia("https://music.apple.com/jp/album/gurenge/1491063195")
+	assert.NotNil(t, err)
+}
+
+func TestSoundTrackSequence(t *testing.T) {
+	newTrack := func(id string, likes int, tags ...string) *arn.SoundTrack {
+		track := &arn.SoundTrack{Tags: tags}
+		track.ID = id
+
+		for i := 0; i < likes; i++ {
+			track.Likes = append(track.Likes, arn.GenerateID("User"))
+		}
+
+		return track
+	}
+
+	tracks := []*arn.SoundTrack{
+		newTrack("op3", 0, "anime:1", "opening", "op:3"),
+		newTrack("op1", 1, "anime:1", "opening"),
+		newTrack("op1-popular", 5, "anime:1", "opening", "op:1"),
+		newTrack("op2", 0, "anime:1", "opening", "op:2"),
+	}
+
+	assert.Equal(t, tracks[0].Type(), arn.SoundTrackTypeOpening)
+	assert.Equal(t, tracks[0].Sequence(), 3)
+	assert.Equal(t, newTrack("ed", 0, "ending", "ed:2").Sequence(), 2)
+	assert.Equal(t, newTrack("ost", 0, "anime:1").Type(), arn.SoundTrackTypeOST)
+	assert.Equal(t, newTrack("ost", 0, "anime:1").Sequence(), 0)
+
+	arn.SortSoundTracksBySequence(tracks)
+	ids := []string{}
+
+	for _, track := range tracks {
+		ids = append(ids, track.ID)
+	}
+
+	assert.DeepEqual(t, ids, []string{"op1-popular", "op1", "op2", "op3"})
+}
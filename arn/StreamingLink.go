@@ -0,0 +1,91 @@
+package arn
+
+import "strings"
+
+func init() {
+	DataLists["streaming-services"] = []*Option{
+		{"crunchyroll", "Crunchyroll"},
+		{"netflix", "Netflix"},
+		{"hidive", "HIDIVE"},
+		{"funimation", "Funimation"},
+		{"amazon", "Prime Video"},
+		{"hulu", "Hulu"},
+		{"disney", "Disney+"},
+		{"youtube", "YouTube"},
+		{"other", "Other"},
+	}
+}
+
+// StreamingLink is a link to a legal streaming service that has the anime.
+// Regions contains the ISO country codes where it can be watched, an empty list means worldwide.
+type StreamingLink struct {
+	Service string   `json:"service" editable:"true" datalist:"streaming-services"`
+	URL     string   `json:"url" editable:"true"`
+	Regions []string `json:"regions" editable:"true"`
+}
+
+// ServiceName returns the display name of the streaming service.
+func (link *StreamingLink) ServiceName() string {
+	for _, option := range DataLists["streaming-services"] {
+		if option.Value == link.Service {
+			return option.Label
+		}
+	}
+
+	return link.Service
+}
+
+// IsAvailableIn tells you whether the link works in the given country.
+// Links without regions and users without a known country always match.
+func (link *StreamingLink) IsAvailableIn(countryCode string) bool {
+	if len(link.Regions) == 0 || countryCode == "" {
+		return true
+	}
+
+	for _, region := range link.Regions {
+		if strings.EqualFold(region, countryCode) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// RegionsText returns the regions as a comma separated list.
+func (link *StreamingLink) RegionsText() string {
+	if len(link.Regions) == 0 {
+		return "Worldwide"
+	}
+
+	return strings.Join(link.Regions, ", ")
+}
+
+// IsStreamingService tells you whether the service is one of the known streaming services.
+func IsStreamingService(service string) bool {
+	for _, option := range DataLists["streaming-services"] {
+		if option.Value == service {
+			return true
+		}
+	}
+
+	return false
+}
+
+// NormalizeRegions converts the regions to upper case country codes and removes empty and duplicate entries.
+func NormalizeRegions(regions []string) []string {
+	normalized := make([]string, 0, len(regions))
+	seen := map[string]bool{}
+
+	for _, region := range regions {
+		region = strings.ToUpper(strings.TrimSpace(region))
+
+		if region == "" || seen[region] {
+			continue
+		}
+
+		seen[region] = true
+		normalized = append(normalized, region)
+	}
+
+	return normalized
+}
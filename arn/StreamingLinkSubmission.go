@@ -0,0 +1,119 @@
+package arn
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/aerogo/nano"
+)
+
+// Streaming link submission states
+const (
+	StreamingLinkSubmissionOpen     = "open"
+	StreamingLinkSubmissionApproved = "approved"
+	StreamingLinkSubmissionRejected = "rejected"
+)
+
+// StreamingLinkSubmission is a streaming link suggested by a user that an editor needs to approve
+// before it is shown on the anime page.
+type StreamingLinkSubmission struct {
+	AnimeID    AnimeID  `json:"animeId"`
+	Service    string   `json:"service"`
+	URL        string   `json:"url"`
+	Regions    []string `json:"regions"`
+	Status     string   `json:"status"`
+	ResolvedBy UserID   `json:"resolvedBy"`
+	Resolved   string   `json:"resolved"`
+
+	hasID
+	hasCreator
+}
+
+// Anime returns the anime the link was submitted for.
+func (submission *StreamingLinkSubmission) Anime() *Anime {
+	anime, _ := GetAnime(submission.AnimeID)
+	return anime
+}
+
+// Link returns the suggested streaming link.
+func (submission *StreamingLinkSubmission) Link() *StreamingLink {
+	return &StreamingLink{
+		Service: submission.Service,
+		URL:     submission.URL,
+		Regions: submission.Regions,
+	}
+}
+
+// IsOpen tells you whether the submission still needs to be reviewed.
+func (submission *StreamingLinkSubmission) IsOpen() bool {
+	return submission.Status == StreamingLinkSubmissionOpen
+}
+
+// Resolve closes the submission with the given status.
+func (submission *StreamingLinkSubmission) Resolve(userID UserID, status string) {
+	submission.Status = status
+	submission.ResolvedBy = userID
+	submission.Resolved = DateTimeUTC()
+}
+
+// ResolvedByUser returns the editor who reviewed the submission.
+func (submission *StreamingLinkSubmission) ResolvedByUser() *User {
+	user, _ := GetUser(submission.ResolvedBy)
+	return user
+}
+
+// String implements the default string serialization.
+func (submission *StreamingLinkSubmission) String() string {
+	return fmt.Sprintf("%s link for anime %s", submission.Service, submission.AnimeID)
+}
+
+// TypeName returns the type name.
+func (submission *StreamingLinkSubmission) TypeName() string {
+	return "StreamingLinkSubmission"
+}
+
+// GetStreamingLinkSubmission ...
+func GetStreamingLinkSubmission(id ID) (*StreamingLinkSubmission, error) {
+	obj, err := DB.Get("StreamingLinkSubmission", id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return obj.(*StreamingLinkSubmission), nil
+}
+
+// StreamStreamingLinkSubmissions returns a stream of all streaming link submissions.
+func StreamStreamingLinkSubmissions() <-chan *StreamingLinkSubmission {
+	channel := make(chan *StreamingLinkSubmission, nano.ChannelBufferSize)
+
+	go func() {
+		for obj := range DB.All("StreamingLinkSubmission") {
+			channel <- obj.(*StreamingLinkSubmission)
+		}
+
+		close(channel)
+	}()
+
+	return channel
+}
+
+// FilterStreamingLinkSubmissions filters all streaming link submissions by a custom function.
+func FilterStreamingLinkSubmissions(filter func(*StreamingLinkSubmission) bool) []*StreamingLinkSubmission {
+	var filtered []*StreamingLinkSubmission
+
+	for obj := range StreamStreamingLinkSubmissions() {
+		if filter(obj) {
+			filtered = append(filtered, obj)
+		}
+	}
+
+	return filtered
+}
+
+// SortStreamingLinkSubmissionsLatestFirst puts the latest submissions on top.
+func SortStreamingLinkSubmissionsLatestFirst(submissions []*StreamingLinkSubmission) {
+	sort.Slice(submissions, func(i, j int) bool {
+		return submissions[i].Created > submissions[j].Created
+	})
+}
@@ -0,0 +1,173 @@
+package arn
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/aerogo/aero"
+	"github.com/aerogo/api"
+)
+
+// maxStreamingLinkRegions is the maximum number of regions a streaming link can be tagged with.
+const maxStreamingLinkRegions = 50
+
+// Force interface implementations
+var (
+	_ fmt.Stringer   = (*StreamingLinkSubmission)(nil)
+	_ api.Newable    = (*StreamingLinkSubmission)(nil)
+	_ api.Actionable = (*StreamingLinkSubmission)(nil)
+)
+
+// Actions
+func init() {
+	API.RegisterActions("StreamingLinkSubmission", []*api.Action{
+		// Approve submission
+		{
+			Name:  "approve",
+			Route: "/approve",
+			Run: func(obj interface{}, ctx aero.Context) error {
+				submission := obj.(*StreamingLinkSubmission)
+				anime := submission.Anime()
+
+				if anime == nil {
+					return errors.New("Anime does not exist")
+				}
+
+				if anime.HasStreamingLink(submission.URL) {
+					return errors.New("The anime already has this link")
+				}
+
+				user, err := submission.resolve(ctx, StreamingLinkSubmissionApproved)
+
+				if err != nil {
+					return err
+				}
+
+				anime.Streaming = append(anime.Streaming, submission.Link())
+				anime.Save()
+
+				logEntry := NewEditLogEntry(user.ID, "arrayAppend", "Anime", anime.ID, "Streaming", "", submission.URL)
+				logEntry.Save()
+				return nil
+			},
+		},
+
+		// Reject submission
+		{
+			Name:  "reject",
+			Route: "/reject",
+			Run: func(obj interface{}, ctx aero.Context) error {
+				submission := obj.(*StreamingLinkSubmission)
+				_, err := submission.resolve(ctx, StreamingLinkSubmissionRejected)
+				return err
+			},
+		},
+	})
+}
+
+// resolve closes an open submission and logs who reviewed it.
+func (submission *StreamingLinkSubmission) resolve(ctx aero.Context, status string) (*User, error) {
+	user := GetUserFromContext(ctx)
+
+	if user == nil {
+		return nil, errors.New("Not logged in")
+	}
+
+	if !submission.IsOpen() {
+		return nil, errors.New("This submission has already been reviewed")
+	}
+
+	submission.Resolve(user.ID, status)
+	submission.Save()
+
+	logEntry := NewModerationLogEntry(user.ID, "streaming-link-"+status, "Anime", submission.AnimeID, submission.Link().ServiceName(), submission.URL)
+	logEntry.Save()
+	return user, nil
+}
+
+// Authorize returns an error if the given API request is not authorized.
+func (submission *StreamingLinkSubmission) Authorize(ctx aero.Context, action string) error {
+	user := GetUserFromContext(ctx)
+
+	if user == nil {
+		return errors.New("Not logged in")
+	}
+
+	if action != "create" && !user.IsModerator() {
+		return errors.New("Only editors can do this")
+	}
+
+	return nil
+}
+
+// Create sets the data for a new submission with data we received from the API request.
+func (submission *StreamingLinkSubmission) Create(ctx aero.Context) error {
+	data, err := ctx.Request().Body().JSONObject()
+
+	if err != nil {
+		return err
+	}
+
+	user := GetUserFromContext(ctx)
+
+	if user == nil {
+		return errors.New("Not logged in")
+	}
+
+	regions, _ := data["regions"].(string)
+
+	submission.ID = GenerateID("StreamingLinkSubmission")
+	submission.AnimeID, _ = data["animeId"].(string)
+	submission.Service, _ = data["service"].(string)
+	submission.URL, _ = data["url"].(string)
+	submission.URL = strings.TrimSpace(submission.URL)
+	submission.Regions = NormalizeRegions(strings.Split(regions, ","))
+	submission.Status = StreamingLinkSubmissionOpen
+	submission.CreatedBy = user.ID
+	submission.Created = DateTimeUTC()
+
+	if !IsStreamingService(submission.Service) {
+		return errors.New("Invalid streaming service")
+	}
+
+	link, err := url.Parse(submission.URL)
+
+	if err != nil || (link.Scheme != "https" && link.Scheme != "http") || link.Host == "" {
+		return errors.New("Invalid link")
+	}
+
+	if len(submission.Regions) > maxStreamingLinkRegions {
+		return errors.New("Too many regions")
+	}
+
+	for _, region := range submission.Regions {
+		if len(region) != 2 {
+			return errors.New("Regions need to be two-letter country codes like US or DE")
+		}
+	}
+
+	anime := submission.Anime()
+
+	if anime == nil {
+		return errors.New("Anime does not exist")
+	}
+
+	if anime.HasStreamingLink(submission.URL) {
+		return errors.New("The anime already has this link")
+	}
+
+	for existing := range StreamStreamingLinkSubmissions() {
+		if existing.IsOpen() && existing.AnimeID == submission.AnimeID && existing.URL == submission.URL {
+			return errors.New("This link has already been suggested")
+		}
+	}
+
+	return nil
+}
+
+// Save saves the submission in the database.
+func (submission *StreamingLinkSubmission) Save() {
+	DB.Set("StreamingLinkSubmission", submission.ID, submission)
+}
@@ -0,0 +1,38 @@
+package arn_test
+
+import (
+	"testing"
+
+	"github.com/akyoto/assert"
+	"github.com/animenotifier/notify.moe/arn"
+)
+
+func TestStreamingLinkIsAvailableIn(t *testing.T) {
+	worldwide := &arn.StreamingLink{Service: "crunchyroll"}
+	regional := &arn.StreamingLink{Service: "hidive", Regions: []string{"US", "CA"}}
+
+	assert.True(t, worldwide.IsAvailableIn("DE"))
+	assert.True(t, regional.IsAvailableIn("us"))
+	assert.False(t, regional.IsAvailableIn("DE"))
+	assert.True(t, regional.IsAvailableIn(""))
+	assert.Equal(t, regional.RegionsText(), "US, CA")
+	assert.Equal(t, regional.ServiceName(), "HIDIVE")
+}
+
+func TestStreamingLinksFor(t *testing.T) {
+	anime := &arn.Anime{
+		Streaming: []*arn.StreamingLink{
+			{Service: "netflix"},
+			{Service: "hidive", Regions: []string{"US"}},
+		},
+	}
+
+	user := &arn.User{Location: &arn.Location{CountryCode: "DE"}}
+	assert.Equal(t, len(anime.StreamingLinksFor(user)), 1)
+	assert.Equal(t, len(anime.StreamingLinksFor(nil)), 2)
+}
+
+func TestNormalizeRegions(t *testing.T) {
+	regions := arn.NormalizeRegions([]string{" us", "", "CA", "US "})
+	assert.DeepEqual(t, regions, []string{"US", "CA"})
+}
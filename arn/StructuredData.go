@@ -0,0 +1,22 @@
+package arn
+
+// StructuredData is a schema.org object describing the content of a page.
+// See https://schema.org for the available types and properties.
+type StructuredData map[string]interface{}
+
+// NewStructuredData creates a schema.org object of the given type.
+func NewStructuredData(schemaType string) StructuredData {
+	return StructuredData{
+		"@context": "https://schema.org",
+		"@type":    schemaType,
+	}
+}
+
+// NewStructuredPerson returns a schema.org person that refers to a user profile.
+func NewStructuredPerson(user *User) StructuredData {
+	return StructuredData{
+		"@type": "Person",
+		"name":  user.Nick,
+		"url":   "https://" + Domain + user.Link(),
+	}
+}
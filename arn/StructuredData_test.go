@@ -0,0 +1,19 @@
+package arn_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/akyoto/assert"
+	"github.com/animenotifier/notify.moe/arn"
+)
+
+func TestStructuredData(t *testing.T) {
+	data := arn.NewStructuredData("TVSeries")
+	data["name"] = "Steins;Gate"
+	data["description"] = "</script>"
+
+	encoded, err := json.Marshal(data)
+	assert.Nil(t, err)
+	assert.Equal(t, string(encoded), `{"@context":"https://schema.org","@type":"TVSeries","description":"\u003c/script\u003e","name":"Steins;Gate"}`)
+}
@@ -0,0 +1,56 @@
+package arn
+
+import (
+	"errors"
+
+	"github.com/aerogo/aero"
+	"github.com/aerogo/api"
+)
+
+// Subscribable is an object that users can watch for new posts.
+type Subscribable interface {
+	Subscribe(userID UserID)
+	Unsubscribe(userID UserID)
+	IsSubscribed(userID UserID) bool
+	Save()
+}
+
+// SubscribeAction ...
+func SubscribeAction() *api.Action {
+	return &api.Action{
+		Name:  "subscribe",
+		Route: "/subscribe",
+		Run: func(obj interface{}, ctx aero.Context) error {
+			user := GetUserFromContext(ctx)
+
+			if user == nil {
+				return errors.New("Not logged in")
+			}
+
+			subscribable := obj.(Subscribable)
+			subscribable.Subscribe(user.ID)
+			subscribable.Save()
+			return nil
+		},
+	}
+}
+
+// UnsubscribeAction ...
+func UnsubscribeAction() *api.Action {
+	return &api.Action{
+		Name:  "unsubscribe",
+		Route: "/unsubscribe",
+		Run: func(obj interface{}, ctx aero.Context) error {
+			user := GetUserFromContext(ctx)
+
+			if user == nil {
+				return errors.New("Not logged in")
+			}
+
+			subscribable := obj.(Subscribable)
+			subscribable.Unsubscribe(user.ID)
+			subscribable.Save()
+			return nil
+		},
+	}
+}
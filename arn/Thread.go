@@ -3,7 +3,7 @@ package arn
 import (
 	"sort"
 
-	"github.com/aerogo/markdown"
+	"github.com/aerogo/aero"
 	"github.com/aerogo/nano"
 )
 
@@ -17,12 +17,17 @@ type Thread struct {
 	Tags   []string `json:"tags" editable:"true"`
 	Edited string   `json:"edited"`
 
+	// Revision is incremented on every edit
+	Revision int `json:"revision"`
+
 	hasID
 	hasText
 	hasPosts
 	hasCreator
 	hasLikes
 	hasLocked
+	hasSubscribers
+	hasSoftDeleted
 
 	html string
 }
@@ -38,10 +43,15 @@ func (thread *Thread) HTML() string {
 		return thread.html
 	}
 
-	thread.html = markdown.Render(thread.Text)
+	thread.html = RenderMarkdownWithFootnotes(thread.Text, thread.FootnotePrefix())
 	return thread.html
 }
 
+// FootnotePrefix returns the prefix that makes the footnote anchors of the thread unique.
+func (thread *Thread) FootnotePrefix() string {
+	return "thread-" + thread.ID + "-"
+}
+
 // String implements the default string serialization.
 func (thread *Thread) String() string {
 	return thread.Title
@@ -93,12 +103,18 @@ func (thread *Thread) OnLike(likedBy *User) {
 func (thread *Thread) OnLock(user *User) {
 	logEntry := NewEditLogEntry(user.ID, "edit", "Thread", thread.ID, "Locked", "false", "true")
 	logEntry.Save()
+
+	moderationLogEntry := NewModerationLogEntry(user.ID, "lock", "Thread", thread.ID, "", "")
+	moderationLogEntry.Save()
 }
 
 // OnUnlock is called when the thread is unlocked.
 func (thread *Thread) OnUnlock(user *User) {
 	logEntry := NewEditLogEntry(user.ID, "edit", "Thread", thread.ID, "Locked", "true", "false")
 	logEntry.Save()
+
+	moderationLogEntry := NewModerationLogEntry(user.ID, "unlock", "Thread", thread.ID, "", "")
+	moderationLogEntry.Save()
 }
 
 // TitleByUser returns the title of the thread,
@@ -108,6 +124,54 @@ func (thread *Thread) TitleByUser(user *User) string {
 	return thread.Title
 }
 
+// NotifySubscribers sends the notification about a post by the given author to all subscribers except the given users.
+// Subscribers who blocked the author are not notified.
+func (thread *Thread) NotifySubscribers(notification *PushNotification, authorID UserID, exceptUserIDs ...UserID) {
+	for _, subscriber := range thread.SubscriberUsers() {
+		if subscriber.ID == authorID || subscriber.IsBlocking(authorID) || Contains(exceptUserIDs, subscriber.ID) {
+			continue
+		}
+
+		subscriber.SendNotification(notification)
+	}
+}
+
+// BroadcastNewPost tells the users viewing the thread that a new post has been written
+// so that open thread pages can show it without a refresh.
+func (thread *Thread) BroadcastNewPost(post *Post) {
+	event := &aero.Event{
+		Name: "threadPost",
+		Data: struct {
+			ThreadID ThreadID `json:"threadId"`
+			PostID   PostID   `json:"postId"`
+			ParentID ID       `json:"parentId"`
+		}{
+			ThreadID: thread.ID,
+			PostID:   post.ID,
+			ParentID: post.ParentID,
+		},
+	}
+
+	for _, userID := range thread.viewerIDs() {
+		events.publish(userID, event)
+	}
+}
+
+// SubscriberUsers returns the users who receive notifications about new posts.
+func (thread *Thread) SubscriberUsers() []*User {
+	users := []*User{}
+
+	for _, obj := range DB.GetMany("User", thread.Subscribers) {
+		if obj == nil {
+			continue
+		}
+
+		users = append(users, obj.(*User))
+	}
+
+	return users
+}
+
 // GetThread ...
 func GetThread(id ThreadID) (*Thread, error) {
 	obj, err := DB.Get("Thread", id)
@@ -125,7 +189,12 @@ func GetThreadsByTag(tag string) []*Thread {
 	allTags := (tag == "" || tag == "<nil>")
 
 	for thread := range StreamThreads() {
-		if (allTags && !Contains(thread.Tags, "update")) || Contains(thread.Tags, tag) {
+		if thread.IsSoftDeleted() {
+			continue
+		}
+
+		// Updates and episode discussions would flood the list of all threads
+		if (allTags && !Contains(thread.Tags, "update") && !Contains(thread.Tags, EpisodeDiscussionTag)) || Contains(thread.Tags, tag) {
 			threads = append(threads, thread)
 		}
 	}
@@ -138,7 +207,7 @@ func GetThreadsByUser(user *User) []*Thread {
 	var threads []*Thread
 
 	for thread := range StreamThreads() {
-		if thread.CreatedBy == user.ID {
+		if thread.CreatedBy == user.ID && !thread.IsSoftDeleted() {
 			threads = append(threads, thread)
 		}
 	}
@@ -172,6 +241,57 @@ func AllThreads() []*Thread {
 	return all
 }
 
+// FilterThreads filters all threads by a custom function.
+func FilterThreads(filter func(*Thread) bool) []*Thread {
+	var filtered []*Thread
+
+	for thread := range StreamThreads() {
+		if filter(thread) {
+			filtered = append(filtered, thread)
+		}
+	}
+
+	return filtered
+}
+
+// FilterMutedThreads returns the threads whose titles don't contain any of the muted keywords.
+func FilterMutedThreads(threads []*Thread, forum *ForumSettings) []*Thread {
+	if len(forum.MutedKeywords) == 0 {
+		return threads
+	}
+
+	filtered := make([]*Thread, 0, len(threads))
+
+	for _, thread := range threads {
+		if forum.IsMuted(thread.Title) {
+			continue
+		}
+
+		filtered = append(filtered, thread)
+	}
+
+	return filtered
+}
+
+// FilterBlockedThreads returns the threads that weren't created by users the given user blocked.
+func FilterBlockedThreads(threads []*Thread, user *User) []*Thread {
+	if len(user.BlockIDs) == 0 {
+		return threads
+	}
+
+	filtered := make([]*Thread, 0, len(threads))
+
+	for _, thread := range threads {
+		if user.IsBlocking(thread.CreatedBy) {
+			continue
+		}
+
+		filtered = append(filtered, thread)
+	}
+
+	return filtered
+}
+
 // SortThreads sorts a slice of threads for the forum view (stickies first).
 func SortThreads(threads []*Thread) {
 	sort.Slice(threads, func(i, j int) bool {
@@ -4,10 +4,10 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"sync"
 
 	"github.com/aerogo/aero"
 	"github.com/aerogo/api"
-	"github.com/aerogo/markdown"
 	"github.com/animenotifier/notify.moe/arn/autocorrect"
 )
 
@@ -18,6 +18,8 @@ var (
 	_ LikeEventReceiver = (*Thread)(nil)
 	_ Lockable          = (*Thread)(nil)
 	_ LockEventReceiver = (*Thread)(nil)
+	_ Subscribable      = (*Thread)(nil)
+	_ SoftDeletable     = (*Thread)(nil)
 	_ PostParent        = (*Thread)(nil)
 	_ fmt.Stringer      = (*Thread)(nil)
 	_ api.Newable       = (*Thread)(nil)
@@ -26,6 +28,12 @@ var (
 	_ api.Deletable     = (*Thread)(nil)
 )
 
+// ErrRevisionConflict is returned when an edit is based on an outdated revision of the thread.
+var ErrRevisionConflict = errors.New("Thread has been edited in the meantime")
+
+// threadRevisionMutex makes sure that the revision check and the edit happen atomically.
+var threadRevisionMutex sync.Mutex
+
 // Actions
 func init() {
 	API.RegisterActions("Thread", []*api.Action{
@@ -40,6 +48,27 @@ func init() {
 
 		// Unlock thread
 		UnlockAction(),
+
+		// Subscribe to thread
+		SubscribeAction(),
+
+		// Unsubscribe from thread
+		UnsubscribeAction(),
+
+		// Pin thread
+		PinAction(),
+
+		// Unpin thread
+		UnpinAction(),
+
+		// Move thread to a different forum
+		MoveAction(),
+
+		// Remove thread
+		SoftDeleteAction(),
+
+		// Restore thread
+		RestoreAction(),
 	})
 }
 
@@ -49,12 +78,21 @@ func (thread *Thread) Authorize(ctx aero.Context, action string) error {
 		return errors.New("Neither logged in nor in session")
 	}
 
-	if action == "edit" {
-		user := GetUserFromContext(ctx)
+	user := GetUserFromContext(ctx)
+
+	if IsModeratorAction(action) && (user == nil || !user.IsModerator()) {
+		return errors.New("Only moderators can do this")
+	}
 
+	if action == "edit" {
 		if thread.CreatedBy != user.ID && user.Role != "admin" {
 			return errors.New("Can't edit the threads of other users")
 		}
+
+		// The generic edit route doesn't know the revision the edit is based on
+		if ctx.Path() == "/api/thread/"+thread.ID {
+			return errors.New("Threads need to be edited via /api/thread/" + thread.ID + "/save")
+		}
 	}
 
 	return nil
@@ -68,23 +106,22 @@ func (thread *Thread) Create(ctx aero.Context) error {
 		return err
 	}
 
-	userID, ok := ctx.Session().Get("userId").(string)
+	user := GetUserFromContext(ctx)
 
-	if !ok || userID == "" {
+	if user == nil {
 		return errors.New("Not logged in")
 	}
 
-	user, err := GetUser(userID)
-
-	if err != nil {
-		return err
-	}
-
 	thread.ID = GenerateID("Thread")
 	thread.Title, _ = data["title"].(string)
 	thread.Text, _ = data["text"].(string)
 	thread.CreatedBy = user.ID
-	thread.Sticky, _ = data["sticky"].(int)
+
+	// Only moderators can pin threads
+	if user.IsModerator() {
+		thread.Sticky, _ = data["sticky"].(int)
+	}
+
 	thread.Created = DateTimeUTC()
 	thread.Edited = ""
 
@@ -112,6 +149,9 @@ func (thread *Thread) Create(ctx aero.Context) error {
 		return errors.New("Text too short: Should be at least 10 characters")
 	}
 
+	// The author receives notifications about replies
+	thread.Subscribe(user.ID)
+
 	// Write log entry
 	logEntry := NewEditLogEntry(user.ID, "create", "Thread", thread.ID, "", "", "")
 	logEntry.Save()
@@ -120,11 +160,21 @@ func (thread *Thread) Create(ctx aero.Context) error {
 	activity := NewActivityCreate("Thread", thread.ID, user.ID)
 	activity.Save()
 
+	// The draft has been posted
+	user.DeleteDraft(NewThreadDraftKey)
 	return nil
 }
 
 // Edit creates an edit log entry.
 func (thread *Thread) Edit(ctx aero.Context, key string, value reflect.Value, newValue reflect.Value) (consumed bool, err error) {
+	if key == "Sticky" {
+		user := GetUserFromContext(ctx)
+
+		if user == nil || !user.IsModerator() {
+			return true, errors.New("Only moderators can pin threads")
+		}
+	}
+
 	return edit(thread, ctx, key, value, newValue)
 }
 
@@ -141,7 +191,28 @@ func (thread *Thread) OnRemove(ctx aero.Context, key string, index int, obj inte
 // AfterEdit sets the edited date on the thread object.
 func (thread *Thread) AfterEdit(ctx aero.Context) error {
 	thread.Edited = DateTimeUTC()
-	thread.html = markdown.Render(thread.Text)
+	thread.Revision++
+	thread.html = RenderMarkdownWithFootnotes(thread.Text, thread.FootnotePrefix())
+	return nil
+}
+
+// EditRevision applies the edits only if they are based on the current revision of the thread.
+// This prevents concurrent edits from silently overwriting each other.
+func (thread *Thread) EditRevision(ctx aero.Context, edits map[string]interface{}, revision int) error {
+	threadRevisionMutex.Lock()
+	defer threadRevisionMutex.Unlock()
+
+	if revision != thread.Revision {
+		return ErrRevisionConflict
+	}
+
+	err := api.SetObjectProperties(thread, edits, ctx)
+
+	if err != nil {
+		return err
+	}
+
+	thread.Save()
 	return nil
 }
 
@@ -158,6 +229,9 @@ func (thread *Thread) DeleteInContext(ctx aero.Context) error {
 	logEntry := NewEditLogEntry(user.ID, "delete", "Thread", thread.ID, "", fmt.Sprint(thread), "")
 	logEntry.Save()
 
+	moderationLogEntry := NewModerationLogEntry(user.ID, "delete", "Thread", thread.ID, "", thread.Title)
+	moderationLogEntry.Save()
+
 	return thread.Delete()
 }
 
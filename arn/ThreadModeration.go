@@ -0,0 +1,132 @@
+package arn
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/aerogo/aero"
+	"github.com/aerogo/api"
+)
+
+// Pin shows the thread on top of the forum listings.
+func (thread *Thread) Pin() {
+	thread.Sticky = 1
+}
+
+// Unpin puts the thread back into the regular order.
+func (thread *Thread) Unpin() {
+	thread.Sticky = 0
+}
+
+// Tag returns the forum the thread is listed in.
+func (thread *Thread) Tag() string {
+	if len(thread.Tags) == 0 {
+		return ""
+	}
+
+	return thread.Tags[0]
+}
+
+// Move lists the thread in a different forum.
+func (thread *Thread) Move(tag string) {
+	if len(thread.Tags) == 0 {
+		thread.Tags = []string{tag}
+		return
+	}
+
+	thread.Tags[0] = tag
+}
+
+// IsForumTag tells you whether the tag is one of the forums.
+func IsForumTag(tag string) bool {
+	_, exists := forumIcons[tag]
+	return exists
+}
+
+// PinAction ...
+func PinAction() *api.Action {
+	return &api.Action{
+		Name:  "pin",
+		Route: "/pin",
+		Run: func(obj interface{}, ctx aero.Context) error {
+			return setThreadPinned(obj.(*Thread), ctx, true)
+		},
+	}
+}
+
+// UnpinAction ...
+func UnpinAction() *api.Action {
+	return &api.Action{
+		Name:  "unpin",
+		Route: "/unpin",
+		Run: func(obj interface{}, ctx aero.Context) error {
+			return setThreadPinned(obj.(*Thread), ctx, false)
+		},
+	}
+}
+
+// MoveAction ...
+func MoveAction() *api.Action {
+	return &api.Action{
+		Name:  "move",
+		Route: "/move",
+		Run: func(obj interface{}, ctx aero.Context) error {
+			thread := obj.(*Thread)
+			user := GetUserFromContext(ctx)
+
+			if user == nil {
+				return errors.New("Not logged in")
+			}
+
+			body, err := ctx.Request().Body().JSONObject()
+
+			if err != nil {
+				return err
+			}
+
+			tag, _ := body["tag"].(string)
+			tag = strings.TrimSpace(tag)
+
+			if !IsForumTag(tag) {
+				return errors.New("Invalid forum: " + tag)
+			}
+
+			oldTag := thread.Tag()
+
+			if oldTag == tag {
+				return nil
+			}
+
+			thread.Move(tag)
+			thread.Save()
+
+			logEntry := NewModerationLogEntry(user.ID, "move", "Thread", thread.ID, "", oldTag+" → "+tag)
+			logEntry.Save()
+			return nil
+		},
+	}
+}
+
+// setThreadPinned pins or unpins the thread and writes a log entry.
+func setThreadPinned(thread *Thread, ctx aero.Context, pinned bool) error {
+	user := GetUserFromContext(ctx)
+
+	if user == nil {
+		return errors.New("Not logged in")
+	}
+
+	action := "unpin"
+
+	if pinned {
+		action = "pin"
+		thread.Pin()
+	} else {
+		thread.Unpin()
+	}
+
+	thread.Save()
+
+	logEntry := NewModerationLogEntry(user.ID, action, "Thread", thread.ID, "", "")
+	logEntry.Save()
+	return nil
+}
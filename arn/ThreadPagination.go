@@ -0,0 +1,82 @@
+package arn
+
+import (
+	"strconv"
+)
+
+// ThreadPostsPerPage is the number of replies shown on a single thread page.
+// Nested replies are displayed together with the post they belong to.
+const ThreadPostsPerPage = 30
+
+// PageCount returns the number of pages needed to display all replies.
+func (thread *Thread) PageCount() int {
+	count := (len(thread.PostIDs) + ThreadPostsPerPage - 1) / ThreadPostsPerPage
+
+	if count < 1 {
+		return 1
+	}
+
+	return count
+}
+
+// PostsOnPage returns the replies displayed on the given page, starting at 1.
+func (thread *Thread) PostsOnPage(page int) []*Post {
+	start := (page - 1) * ThreadPostsPerPage
+
+	if page < 1 || start >= len(thread.PostIDs) {
+		return nil
+	}
+
+	end := start + ThreadPostsPerPage
+
+	if end > len(thread.PostIDs) {
+		end = len(thread.PostIDs)
+	}
+
+	objects := DB.GetMany("Post", thread.PostIDs[start:end])
+	posts := make([]*Post, 0, len(objects))
+
+	for _, obj := range objects {
+		if obj == nil {
+			continue
+		}
+
+		posts = append(posts, obj.(*Post))
+	}
+
+	return posts
+}
+
+// PageLink returns the relative URL of the given page.
+func (thread *Thread) PageLink(page int) string {
+	if page <= 1 {
+		return thread.Link()
+	}
+
+	return thread.Link() + "/page/" + strconv.Itoa(page)
+}
+
+// PostLink returns the permalink of a reply which resolves to the page it's displayed on.
+func (thread *Thread) PostLink(postID PostID) string {
+	return thread.Link() + "/post/" + postID
+}
+
+// PostPage returns the page that displays the given reply or 0 if it isn't part of the thread.
+// Nested replies are found on the page of the post they belong to.
+func (thread *Thread) PostPage(postID PostID) int {
+	for {
+		for index, id := range thread.PostIDs {
+			if id == postID {
+				return index/ThreadPostsPerPage + 1
+			}
+		}
+
+		post, err := GetPost(postID)
+
+		if err != nil || post.ParentType != "Post" {
+			return 0
+		}
+
+		postID = post.ParentID
+	}
+}
@@ -0,0 +1,58 @@
+package arn
+
+import (
+	"sync"
+	"time"
+)
+
+// threadViewerDuration is how long a user counts as a viewer after opening a thread page.
+const threadViewerDuration = time.Hour
+
+// threadViewers remembers which users opened which threads recently
+// so that new posts are only sent to the users who might be looking at them.
+var threadViewers = struct {
+	sync.Mutex
+	byThread map[ThreadID]map[UserID]time.Time
+}{
+	byThread: map[ThreadID]map[UserID]time.Time{},
+}
+
+// AddViewer registers the user as a viewer of the thread.
+func (thread *Thread) AddViewer(userID UserID) {
+	threadViewers.Lock()
+	defer threadViewers.Unlock()
+
+	viewers := threadViewers.byThread[thread.ID]
+
+	if viewers == nil {
+		viewers = map[UserID]time.Time{}
+		threadViewers.byThread[thread.ID] = viewers
+	}
+
+	viewers[userID] = time.Now()
+}
+
+// viewerIDs returns the users who opened the thread recently and forgets the others.
+func (thread *Thread) viewerIDs() []UserID {
+	threadViewers.Lock()
+	defer threadViewers.Unlock()
+
+	viewers := threadViewers.byThread[thread.ID]
+	userIDs := make([]UserID, 0, len(viewers))
+	now := time.Now()
+
+	for userID, viewed := range viewers {
+		if now.Sub(viewed) > threadViewerDuration {
+			delete(viewers, userID)
+			continue
+		}
+
+		userIDs = append(userIDs, userID)
+	}
+
+	if len(viewers) == 0 {
+		delete(threadViewers.byThread, thread.ID)
+	}
+
+	return userIDs
+}
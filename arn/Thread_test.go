@@ -0,0 +1,107 @@
+package arn_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/akyoto/assert"
+	"github.com/animenotifier/notify.moe/arn"
+)
+
+func TestFilterMutedThreads(t *testing.T) {
+	threads := []*arn.Thread{
+		{Title: "Episode 12 SPOILER discussion"},
+		{Title: "Best opening songs of the season"},
+		{Title: "Spoilers ahead for the manga"},
+	}
+
+	muting := &arn.ForumSettings{MutedKeywords: []string{"spoiler"}}
+	other := &arn.ForumSettings{}
+
+	// Muting user: case-insensitive, whole-word match
+	filtered := arn.FilterMutedThreads(threads, muting)
+	assert.Equal(t, len(filtered), 2)
+	assert.Equal(t, filtered[0].Title, threads[1].Title)
+	assert.Equal(t, filtered[1].Title, threads[2].Title)
+
+	// Other users still see every thread
+	assert.Equal(t, len(arn.FilterMutedThreads(threads, other)), len(threads))
+
+	// Partial matching also hides words containing the keyword
+	muting.MutePartialWord = true
+	filtered = arn.FilterMutedThreads(threads, muting)
+	assert.Equal(t, len(filtered), 1)
+	assert.Equal(t, filtered[0].Title, threads[1].Title)
+}
+
+func TestThreadEditRevisionConflict(t *testing.T) {
+	thread := &arn.Thread{Title: "Staff announcement", Revision: 2}
+	thread.Text = "Latest text"
+
+	// An edit based on an older revision must not overwrite the thread
+	err := thread.EditRevision(nil, map[string]interface{}{"Text": "Stale text"}, 1)
+	assert.Equal(t, err, arn.ErrRevisionConflict)
+	assert.Equal(t, thread.Text, "Latest text")
+	assert.Equal(t, thread.Revision, 2)
+}
+
+func TestThreadPages(t *testing.T) {
+	thread := &arn.Thread{}
+	thread.ID = "pages"
+
+	// Empty threads still have a first page
+	assert.Equal(t, thread.PageCount(), 1)
+	assert.Equal(t, thread.PageLink(1), "/thread/pages")
+
+	for i := 0; i <= arn.ThreadPostsPerPage; i++ {
+		thread.AddPost(fmt.Sprintf("post-%d", i))
+	}
+
+	assert.Equal(t, thread.PageCount(), 2)
+	assert.Equal(t, thread.PageLink(2), "/thread/pages/page/2")
+	assert.Equal(t, thread.PostPage("post-0"), 1)
+	assert.Equal(t, thread.PostPage(fmt.Sprintf("post-%d", arn.ThreadPostsPerPage)), 2)
+}
+
+func TestThreadModeration(t *testing.T) {
+	thread := &arn.Thread{Tags: []string{"general", "anime:74"}}
+
+	// Moving only changes the forum
+	assert.True(t, arn.IsForumTag("news"))
+	assert.False(t, arn.IsForumTag("anime:74"))
+	thread.Move("news")
+	assert.Equal(t, thread.Tag(), "news")
+	assert.Equal(t, thread.Tags[1], "anime:74")
+
+	// Pinned threads are listed first
+	other := &arn.Thread{}
+	other.Created = "2020-01-02T00:00:00Z"
+	thread.Created = "2020-01-01T00:00:00Z"
+	thread.Pin()
+	threads := []*arn.Thread{other, thread}
+	arn.SortThreads(threads)
+	assert.Equal(t, threads[0], thread)
+
+	// Removed threads keep the reason until they're restored
+	thread.SoftDelete("4J6qpK1ve", "Spam")
+	assert.True(t, arn.IsSoftDeleted(thread))
+	assert.Equal(t, arn.SoftDeleteReason(thread), "Spam")
+	thread.Restore()
+	assert.False(t, arn.IsSoftDeleted(thread))
+	assert.Equal(t, thread.DeletedBy, "")
+}
+
+func TestEpisodeDiscussion(t *testing.T) {
+	episode := &arn.Episode{}
+	assert.False(t, episode.HasAired())
+	assert.Nil(t, episode.DiscussionThread())
+
+	episode.AiringDate.Start = "2020-01-01T12:00:00Z"
+	assert.True(t, episode.HasAired())
+
+	// The thread knows the episode it belongs to
+	thread := &arn.Thread{Tags: []string{arn.EpisodeDiscussionTag, "anime:74", "episode:74-1"}}
+	assert.True(t, arn.IsForumTag(thread.Tag()))
+	assert.Equal(t, thread.EpisodeID(), "74-1")
+	assert.Equal(t, (&arn.Thread{}).EpisodeID(), "")
+}
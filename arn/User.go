@@ -56,13 +56,11 @@ type User struct {
 	OS           UserOS       `json:"os" private:"true"`
 	Location     *Location    `json:"location" private:"true"`
 	FollowIDs    []UserID     `json:"follows"`
+	BlockIDs     []UserID     `json:"blocks" private:"true"`
+	Deactivated  string       `json:"deactivated" private:"true"`
+	Deleted      string       `json:"deleted"`
 
 	hasPosts
-
-	eventStreams struct {
-		sync.Mutex
-		value []*aero.EventStream
-	}
 }
 
 // NewUser creates an empty user object with a unique ID.
@@ -97,6 +95,28 @@ func RegisterUser(user *User) {
 		})
 	}
 
+	user.createDefaultData()
+
+	// Fetch gravatar
+	if user.Email != "" && !IsDevelopment() {
+		gravatarURL := gravatar.Url(user.Email) + "?s=" + fmt.Sprint(AvatarMaxSize) + "&d=404&r=pg"
+		gravatarURL = strings.Replace(gravatarURL, "http://", "https://", 1)
+
+		response, err := client.Get(gravatarURL).End()
+
+		if err == nil && response.StatusCode() == http.StatusOK {
+			data := response.Bytes()
+			err = user.SetImageBytes(data)
+
+			if err != nil {
+				fmt.Println(err)
+			}
+		}
+	}
+}
+
+// createDefaultData creates the empty settings, lists and indices every user needs.
+func (user *User) createDefaultData() {
 	// Create default settings
 	NewSettings(user).Save()
 
@@ -120,36 +140,40 @@ func RegisterUser(user *User) {
 
 	// Add empty notifications list
 	NewUserNotifications(user.ID).Save()
-
-	// Fetch gravatar
-	if user.Email != "" && !IsDevelopment() {
-		gravatarURL := gravatar.Url(user.Email) + "?s=" + fmt.Sprint(AvatarMaxSize) + "&d=404&r=pg"
-		gravatarURL = strings.Replace(gravatarURL, "http://", "https://", 1)
-
-		response, err := client.Get(gravatarURL).End()
-
-		if err == nil && response.StatusCode() == http.StatusOK {
-			data := response.Bytes()
-			err = user.SetImageBytes(data)
-
-			if err != nil {
-				fmt.Println(err)
-			}
-		}
-	}
 }
 
 // SendNotification accepts a PushNotification and generates a new Notification object.
-// The notification is then sent to all registered push devices.
+// The notification is then delivered via the channels enabled in the notification preferences of the user.
 func (user *User) SendNotification(pushNotification *PushNotification) {
 	// Don't ever send notifications in development mode
 	if IsDevelopment() && user.ID != "4J6qpK1ve" {
 		return
 	}
 
+	// Deleted accounts don't receive notifications
+	if user.IsDeleted() {
+		return
+	}
+
+	preferences := &user.Settings().Notification.Preferences
+	sendPush := preferences.Allows(pushNotification.Type, NotificationChannelPush)
+	sendEmail := preferences.Allows(pushNotification.Type, NotificationChannelEmail)
+
+	// Episode webhooks contain the episode links and are sent by RefreshEpisodes on release
+	if pushNotification.Type != NotificationTypeAnimeEpisode {
+		go user.SendWebhook(NewNotificationWebhookPayload(pushNotification))
+	}
+
+	// Email digests are created from the saved notifications,
+	// so the notification is only dropped when neither push nor email is enabled.
+	if !sendPush && !sendEmail {
+		return
+	}
+
 	// Save notification in database
 	notification := NewNotification(user.ID, pushNotification)
 	notification.Save()
+	NotificationsSent.Inc(pushNotification.Type)
 
 	userNotifications := user.Notifications()
 	err := userNotifications.Add(notification.ID)
@@ -162,25 +186,59 @@ func (user *User) SendNotification(pushNotification *PushNotification) {
 	userNotifications.Save()
 
 	// Send push notification
+	if sendPush {
+		user.sendPushNotification(pushNotification)
+	}
+
+	// Send email notification
+	if sendEmail && user.Email != "" && user.Settings().Notification.EmailDigest == EmailDigestImmediate {
+		go func() {
+			err := user.SendEmailDigest([]*Notification{notification})
+
+			if err != nil {
+				fmt.Println(err)
+			}
+		}()
+	}
+
+	// Send an event to the user's open tabs
+	user.BroadcastEvent(&aero.Event{
+		Name: "notificationCount",
+		Data: userNotifications.CountUnseen(),
+	})
+}
+
+// sendPushNotification sends the notification to all push subscriptions of the user
+// and removes the expired subscriptions.
+func (user *User) sendPushNotification(pushNotification *PushNotification) {
 	subs := user.PushSubscriptions()
 	expired := []*PushSubscription{}
 
 	for _, sub := range subs.Items {
 		if sub.Endpoint == "" {
 			expired = append(expired, sub)
+			PushDeliveries.Inc(PushDeliveryExpired)
 			continue
 		}
 
 		response, err := sub.SendNotification(pushNotification)
 
 		// It is possible to receive a non-nil response with an error, so check the status.
-		if response != nil && (response.StatusCode == http.StatusGone || response.StatusCode == http.StatusForbidden) {
+		isExpired := response != nil && (response.StatusCode == http.StatusGone || response.StatusCode == http.StatusForbidden)
+
+		if isExpired {
 			expired = append(expired, sub)
+			PushDeliveries.Inc(PushDeliveryExpired)
 		}
 
 		// Print errors
 		if err != nil {
 			fmt.Println(err)
+
+			if !isExpired {
+				PushDeliveries.Inc(PushDeliveryFailure)
+			}
+
 			continue
 		}
 
@@ -191,10 +249,16 @@ func (user *User) SendNotification(pushNotification *PushNotification) {
 		if response.StatusCode != http.StatusOK && response.StatusCode != http.StatusCreated {
 			body, _ := ioutil.ReadAll(response.Body)
 			fmt.Println(response.StatusCode, string(body))
+
+			if !isExpired {
+				PushDeliveries.Inc(PushDeliveryFailure)
+			}
+
 			continue
 		}
 
 		sub.LastSuccess = DateTimeUTC()
+		PushDeliveries.Inc(PushDeliverySuccess)
 	}
 
 	// Remove expired items
@@ -206,23 +270,6 @@ func (user *User) SendNotification(pushNotification *PushNotification) {
 
 	// Save changes
 	subs.Save()
-
-	// Send email notification
-	// if IsDevelopment() && user.ID == "4J6qpK1ve" {
-	// 	subject := notification.Title
-	// 	html := HTMLEmailRenderer.Notification(notification)
-	// 	err := SendEmail(user.Email, subject, html)
-
-	// 	if err != nil {
-	// 		fmt.Println(err)
-	// 	}
-	// }
-
-	// Send an event to the user's open tabs
-	user.BroadcastEvent(&aero.Event{
-		Name: "notificationCount",
-		Data: userNotifications.CountUnseen(),
-	})
 }
 
 // RealName returns the real name of the user.
@@ -257,6 +304,10 @@ func (user *User) AgeInYears() int {
 
 // IsActive tells you whether the user is active.
 func (user *User) IsActive() bool {
+	if user.IsHidden() {
+		return false
+	}
+
 	lastSeen, _ := time.Parse(time.RFC3339, user.LastSeen)
 	twoWeeksAgo := time.Now().Add(-14 * 24 * time.Hour)
 
@@ -17,5 +17,11 @@ func init() {
 
 		// Remove follow
 		UnfollowAction(),
+
+		// Add block
+		BlockAction(),
+
+		// Remove block
+		UnblockAction(),
 	})
 }
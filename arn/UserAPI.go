@@ -103,6 +103,33 @@ func (user *User) Edit(ctx aero.Context, key string, value reflect.Value, newVal
 			return true, errors.New("Not authorized to edit")
 		}
 
+	case "Accounts.AniList.Nick":
+		newNick := newValue.String()
+
+		// Linked accounts must be linked again after a nickname change.
+		if newNick != user.Accounts.AniList.Nick {
+			user.Accounts.AniList.ID = 0
+			user.Accounts.AniList.AccessToken = ""
+			user.Accounts.AniList.Sync = false
+		}
+
+		value.SetString(newNick)
+		return true, nil
+
+	case "Accounts.MyAnimeList.Nick":
+		newNick := newValue.String()
+
+		// Linked accounts must be linked again after a nickname change.
+		if newNick != user.Accounts.MyAnimeList.Nick {
+			user.Accounts.MyAnimeList.ID = 0
+			user.Accounts.MyAnimeList.AccessToken = ""
+			user.Accounts.MyAnimeList.RefreshToken = ""
+			user.Accounts.MyAnimeList.Sync = false
+		}
+
+		value.SetString(newNick)
+		return true, nil
+
 	case "Accounts.Discord.Nick":
 		newNick := newValue.String()
 
@@ -228,10 +255,14 @@ func (user *User) Filter() {
 	user.Accounts.Facebook.ID = ""
 	user.Accounts.Google.ID = ""
 	user.Accounts.Twitter.ID = ""
+	user.Accounts.AniList.AccessToken = ""
+	user.Accounts.MyAnimeList.AccessToken = ""
+	user.Accounts.MyAnimeList.RefreshToken = ""
 	user.BirthDay = ""
 	user.Location = &Location{}
 	user.Browser = UserBrowser{}
 	user.OS = UserOS{}
+	user.BlockIDs = nil
 }
 
 // ShouldFilter tells whether data needs to be filtered in the given context.
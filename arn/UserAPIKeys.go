@@ -0,0 +1,145 @@
+package arn
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// MaxUserAPIKeys is the maximum number of API keys per user.
+const MaxUserAPIKeys = 5
+
+// maxUserAPIKeyNameLength is the maximum length of the API key description.
+const maxUserAPIKeyNameLength = 50
+
+// DefaultUserAPIKeyScopes are the scopes of keys created without explicit scopes.
+// Keys can end up in logs and shared scripts, so they are read-only unless requested otherwise.
+var DefaultUserAPIKeyScopes = []string{
+	OAuthScopeReadList,
+	OAuthScopeReadNotifications,
+}
+
+// UserAPIKeys is the list of API keys a user created for the public API.
+type UserAPIKeys struct {
+	UserID UserID        `json:"userId" primary:"true"`
+	Items  []*UserAPIKey `json:"items"`
+}
+
+// UserAPIKey is a single API key.
+// Only the hash of the key is stored, the ID identifies the key in the settings.
+// The scopes limit what the key can do, just like the scopes of OAuth access tokens.
+type UserAPIKey struct {
+	ID      string   `json:"id"`
+	Hash    string   `json:"hash" private:"true"`
+	Name    string   `json:"name"`
+	Scopes  []string `json:"scopes"`
+	Created string   `json:"created"`
+}
+
+// HasScope tells whether the key grants the given scope.
+// Keys created before scopes existed only grant the default scopes.
+func (key *UserAPIKey) HasScope(scope string) bool {
+	if key.Scopes == nil {
+		return Contains(DefaultUserAPIKeyScopes, scope)
+	}
+
+	return Contains(key.Scopes, scope)
+}
+
+// NewUserAPIKeys creates a new empty list of API keys.
+func NewUserAPIKeys(userID UserID) *UserAPIKeys {
+	return &UserAPIKeys{
+		UserID: userID,
+		Items:  []*UserAPIKey{},
+	}
+}
+
+// Create generates a new API key with the given scopes and adds it to the list.
+// Without scopes, the key gets the read-only default scopes.
+// It also returns the key itself which is only stored as a hash and can't be shown again.
+func (list *UserAPIKeys) Create(name string, scopes []string) (*UserAPIKey, string, error) {
+	if len(list.Items) >= MaxUserAPIKeys {
+		return nil, "", errors.New("You can't create more than 5 API keys")
+	}
+
+	if len(scopes) == 0 {
+		scopes = DefaultUserAPIKeyScopes
+	}
+
+	keyScopes := make([]string, 0, len(scopes))
+
+	for _, scope := range scopes {
+		if !IsOAuthScope(scope) {
+			return nil, "", fmt.Errorf("Unknown scope: %s", scope)
+		}
+
+		if !Contains(keyScopes, scope) {
+			keyScopes = append(keyScopes, scope)
+		}
+	}
+
+	name = strings.TrimSpace(name)
+
+	if len([]rune(name)) > maxUserAPIKeyNameLength {
+		name = string([]rune(name)[:maxUserAPIKeyNameLength])
+	}
+
+	secret := GenerateSecret()
+
+	key := &UserAPIKey{
+		ID:      GenerateSecret()[:16],
+		Hash:    HashSecret(secret),
+		Name:    name,
+		Scopes:  keyScopes,
+		Created: DateTimeUTC(),
+	}
+
+	list.Items = append(list.Items, key)
+
+	DB.Set("APIKeyToUser", key.Hash, &APIKeyToUser{
+		Hash:   key.Hash,
+		UserID: list.UserID,
+	})
+
+	return key, secret, nil
+}
+
+// Revoke removes the API key with the given ID from the list so that it can't be used anymore.
+func (list *UserAPIKeys) Revoke(id string) bool {
+	for index, item := range list.Items {
+		if item.ID == id {
+			list.Items = append(list.Items[:index], list.Items[index+1:]...)
+			DB.Delete("APIKeyToUser", item.Hash)
+			return true
+		}
+	}
+
+	return false
+}
+
+// findByHash returns the API key with the given hash, if available.
+func (list *UserAPIKeys) findByHash(hash string) *UserAPIKey {
+	for _, item := range list.Items {
+		if item.Hash == hash {
+			return item
+		}
+	}
+
+	return nil
+}
+
+// GetID returns the ID.
+func (list *UserAPIKeys) GetID() string {
+	return list.UserID
+}
+
+// GetUserAPIKeys returns the API keys of the given user.
+func GetUserAPIKeys(userID UserID) (*UserAPIKeys, error) {
+	obj, err := DB.Get("UserAPIKeys", userID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return obj.(*UserAPIKeys), nil
+}
@@ -0,0 +1,29 @@
+package arn
+
+import (
+	"github.com/aerogo/aero"
+	"github.com/aerogo/api"
+)
+
+// Force interface implementations
+var (
+	_ Identifiable = (*UserAPIKeys)(nil)
+	_ api.Filter   = (*UserAPIKeys)(nil)
+)
+
+// Filter removes privacy critical fields from the API keys.
+func (list *UserAPIKeys) Filter() {
+	for _, item := range list.Items {
+		item.Hash = ""
+	}
+}
+
+// ShouldFilter tells whether data needs to be filtered in the given context.
+func (list *UserAPIKeys) ShouldFilter(ctx aero.Context) bool {
+	return true
+}
+
+// Save saves the API keys in the database.
+func (list *UserAPIKeys) Save() {
+	DB.Set("UserAPIKeys", list.UserID, list)
+}
@@ -0,0 +1,20 @@
+package arn_test
+
+import (
+	"testing"
+
+	"github.com/akyoto/assert"
+	"github.com/animenotifier/notify.moe/arn"
+)
+
+func TestUserAPIKeyScopes(t *testing.T) {
+	// Keys without scopes are read-only
+	key := &arn.UserAPIKey{}
+	assert.True(t, key.HasScope(arn.OAuthScopeReadList))
+	assert.True(t, key.HasScope(arn.OAuthScopeReadNotifications))
+	assert.False(t, key.HasScope(arn.OAuthScopeWriteList))
+
+	key.Scopes = []string{arn.OAuthScopeReadList, arn.OAuthScopeWriteList}
+	assert.True(t, key.HasScope(arn.OAuthScopeWriteList))
+	assert.False(t, key.HasScope(arn.OAuthScopeReadNotifications))
+}
@@ -115,7 +115,10 @@ type UserAccounts struct {
 	} `json:"ffxiv"`
 
 	AniList struct {
-		Nick string `json:"nick" editable:"true"`
+		Nick        string `json:"nick" editable:"true"`
+		ID          int    `json:"id"`
+		AccessToken string `json:"accessToken" private:"true"`
+		Sync        bool   `json:"sync"`
 	} `json:"anilist"`
 
 	AnimePlanet struct {
@@ -123,7 +126,12 @@ type UserAccounts struct {
 	} `json:"animeplanet"`
 
 	MyAnimeList struct {
-		Nick string `json:"nick" editable:"true"`
+		Nick         string `json:"nick" editable:"true"`
+		ID           int    `json:"id"`
+		AccessToken  string `json:"accessToken" private:"true"`
+		RefreshToken string `json:"refreshToken" private:"true"`
+		TokenExpiry  string `json:"tokenExpiry"`
+		Sync         bool   `json:"sync"`
 	} `json:"myanimelist"`
 
 	Kitsu struct {
@@ -1,12 +1,11 @@
 package arn
 
 import (
-	"bytes"
-	"image"
 	"path"
 	"time"
 
 	"github.com/akyoto/imageserver"
+	"github.com/animenotifier/notify.moe/arn/imageupload"
 )
 
 const (
@@ -19,6 +18,9 @@ const (
 	// AvatarWebPQuality is the WebP quality of avatars.
 	AvatarWebPQuality = 80
 
+	// AvatarAVIFQuality is the AVIF quality of avatars.
+	AvatarAVIFQuality = 60
+
 	// AvatarJPEGQuality is the JPEG quality of avatars.
 	AvatarJPEGQuality = 80
 )
@@ -49,6 +51,14 @@ var avatarOutputs = []imageserver.Output{
 		Quality:   AvatarWebPQuality,
 	},
 
+	// AVIF - Large
+	&imageupload.AVIFFile{
+		Directory: path.Join(Root, "images/avatars/large/"),
+		Width:     AvatarMaxSize,
+		Height:    AvatarMaxSize,
+		Quality:   AvatarAVIFQuality,
+	},
+
 	// WebP - Small
 	&imageserver.WebPFile{
 		Directory: path.Join(Root, "images/avatars/small/"),
@@ -56,44 +66,54 @@ var avatarOutputs = []imageserver.Output{
 		Height:    AvatarSmallSize,
 		Quality:   AvatarWebPQuality,
 	},
+
+	// AVIF - Small
+	&imageupload.AVIFFile{
+		Directory: path.Join(Root, "images/avatars/small/"),
+		Width:     AvatarSmallSize,
+		Height:    AvatarSmallSize,
+		Quality:   AvatarAVIFQuality,
+	},
 }
 
 // UserAvatar ...
 type UserAvatar struct {
 	Extension    string `json:"extension" editable:"true"`
 	LastModified int64  `json:"lastModified" editable:"true"`
+	AVIF         bool   `json:"avif"`
 }
 
 // SetImageBytes accepts a byte buffer that represents an image file and updates the avatar.
 func (user *User) SetImageBytes(data []byte) error {
-	// Decode
-	img, format, err := image.Decode(bytes.NewReader(data))
+	metaImage, err := imageupload.Decode(data)
 
 	if err != nil {
 		return err
 	}
 
-	return user.SetAvatar(&imageserver.MetaImage{
-		Image:  img,
-		Format: format,
-		Data:   data,
-	})
+	return user.SetAvatar(metaImage)
 }
 
 // SetAvatar sets the avatar to the given MetaImage.
 func (user *User) SetAvatar(avatar *imageserver.MetaImage) error {
-	var lastError error
-
 	// Save the different image formats and sizes
-	for _, output := range avatarOutputs {
-		err := output.Save(avatar, user.ID)
-
-		if err != nil {
-			lastError = err
-		}
-	}
+	avif, err := imageupload.Save(avatar, user.ID, avatarOutputs)
 
 	user.Avatar.Extension = avatar.Extension()
+	user.Avatar.AVIF = avif
 	user.Avatar.LastModified = time.Now().Unix()
-	return lastError
+	return err
+}
+
+// ImageVariants returns the available sizes of the user avatar and cover.
+func (user *User) ImageVariants() []*ImageVariant {
+	variants := []*ImageVariant{
+		{Link: user.AvatarLink("large"), Width: AvatarMaxSize, Height: AvatarMaxSize},
+	}
+
+	if user.Cover.Extension != "" && user.IsPro() {
+		variants = append(variants, &ImageVariant{Link: user.CoverLink("large"), Width: CoverMaxWidth, Height: CoverMaxHeight})
+	}
+
+	return variants
 }
@@ -0,0 +1,113 @@
+package arn
+
+import (
+	"errors"
+
+	"github.com/aerogo/aero"
+	"github.com/aerogo/api"
+)
+
+// Block hides the posts and activity of the given user and stops them from following you.
+func (user *User) Block(blockUserID UserID) error {
+	if blockUserID == user.ID {
+		return errors.New("You can't block yourself")
+	}
+
+	if user.IsBlocking(blockUserID) {
+		return errors.New("User " + blockUserID + " has already been blocked")
+	}
+
+	blockedUser, err := GetUser(blockUserID)
+
+	if err != nil {
+		return err
+	}
+
+	user.BlockIDs = append(user.BlockIDs, blockUserID)
+
+	// Blocking ends the follow relationship in both directions
+	user.Unfollow(blockUserID)
+
+	if blockedUser.Unfollow(user.ID) {
+		blockedUser.Save()
+	}
+
+	return nil
+}
+
+// Unblock removes the user ID from the block list.
+func (user *User) Unblock(userID UserID) bool {
+	for index, item := range user.BlockIDs {
+		if item == userID {
+			user.BlockIDs = append(user.BlockIDs[:index], user.BlockIDs[index+1:]...)
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsBlocking checks if the user blocked the given user ID.
+func (user *User) IsBlocking(userID UserID) bool {
+	for _, item := range user.BlockIDs {
+		if item == userID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Blocks returns a slice of all the users you blocked.
+func (user *User) Blocks() []*User {
+	blocksObj := DB.GetMany("User", user.BlockIDs)
+	blocks := make([]*User, 0, len(blocksObj))
+
+	for _, obj := range blocksObj {
+		if obj == nil {
+			continue
+		}
+
+		blocks = append(blocks, obj.(*User))
+	}
+
+	return blocks
+}
+
+// BlockAction returns an API action that adds a user ID to the block list.
+func BlockAction() *api.Action {
+	return &api.Action{
+		Name:  "block",
+		Route: "/block/:block-id",
+		Run: func(obj interface{}, ctx aero.Context) error {
+			user := obj.(*User)
+			blockID := ctx.Get("block-id")
+			err := user.Block(blockID)
+
+			if err != nil {
+				return err
+			}
+
+			user.Save()
+			return nil
+		},
+	}
+}
+
+// UnblockAction returns an API action that removes a user ID from the block list.
+func UnblockAction() *api.Action {
+	return &api.Action{
+		Name:  "unblock",
+		Route: "/unblock/:block-id",
+		Run: func(obj interface{}, ctx aero.Context) error {
+			user := obj.(*User)
+
+			if !user.Unblock(ctx.Get("block-id")) {
+				return errors.New("This user has not been blocked")
+			}
+
+			user.Save()
+			return nil
+		},
+	}
+}
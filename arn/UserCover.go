@@ -1,12 +1,11 @@
 package arn
 
 import (
-	"bytes"
-	"image"
 	"path"
 	"time"
 
 	"github.com/akyoto/imageserver"
+	"github.com/animenotifier/notify.moe/arn/imageupload"
 )
 
 const (
@@ -25,6 +24,9 @@ const (
 	// CoverWebPQuality is the WebP quality of cover images.
 	CoverWebPQuality = AvatarWebPQuality
 
+	// CoverAVIFQuality is the AVIF quality of cover images.
+	CoverAVIFQuality = AvatarAVIFQuality
+
 	// CoverJPEGQuality is the JPEG quality of cover images.
 	CoverJPEGQuality = CoverWebPQuality
 )
@@ -55,6 +57,14 @@ var coverImageOutputs = []imageserver.Output{
 		Quality:   CoverWebPQuality,
 	},
 
+	// AVIF - Large
+	&imageupload.AVIFFile{
+		Directory: path.Join(Root, "images/covers/large/"),
+		Width:     CoverMaxWidth,
+		Height:    CoverMaxHeight,
+		Quality:   CoverAVIFQuality,
+	},
+
 	// WebP - Small
 	&imageserver.WebPFile{
 		Directory: path.Join(Root, "images/covers/small/"),
@@ -62,44 +72,46 @@ var coverImageOutputs = []imageserver.Output{
 		Height:    CoverSmallHeight,
 		Quality:   CoverWebPQuality,
 	},
+
+	// AVIF - Small
+	&imageupload.AVIFFile{
+		Directory: path.Join(Root, "images/covers/small/"),
+		Width:     CoverSmallWidth,
+		Height:    CoverSmallHeight,
+		Quality:   CoverAVIFQuality,
+	},
 }
 
 // UserCover ...
 type UserCover struct {
 	Extension    string `json:"extension"`
 	LastModified int64  `json:"lastModified"`
+	AVIF         bool   `json:"avif"`
+}
+
+// HasCoverAVIF tells you whether the image returned by CoverLink has an AVIF version.
+func (user *User) HasCoverAVIF() bool {
+	return user.Cover.AVIF && user.Cover.Extension != "" && user.IsPro()
 }
 
 // SetCoverBytes accepts a byte buffer that represents an image file and updates the cover image.
 func (user *User) SetCoverBytes(data []byte) error {
-	// Decode
-	img, format, err := image.Decode(bytes.NewReader(data))
+	metaImage, err := imageupload.Decode(data)
 
 	if err != nil {
 		return err
 	}
 
-	return user.SetCover(&imageserver.MetaImage{
-		Image:  img,
-		Format: format,
-		Data:   data,
-	})
+	return user.SetCover(metaImage)
 }
 
 // SetCover sets the cover image to the given MetaImage.
 func (user *User) SetCover(cover *imageserver.MetaImage) error {
-	var lastError error
-
 	// Save the different image formats and sizes
-	for _, output := range coverImageOutputs {
-		err := output.Save(cover, user.ID)
-
-		if err != nil {
-			lastError = err
-		}
-	}
+	avif, err := imageupload.Save(cover, user.ID, coverImageOutputs)
 
 	user.Cover.Extension = ".jpg"
+	user.Cover.AVIF = avif
 	user.Cover.LastModified = time.Now().Unix()
-	return lastError
+	return err
 }
@@ -0,0 +1,214 @@
+package arn
+
+import "time"
+
+// UserDeletionGracePeriod is how long a deactivated account can be restored by logging in
+// before all of its data is deleted.
+const UserDeletionGracePeriod = 14 * 24 * time.Hour
+
+// userCollections are the collections that store private data under the user ID.
+var userCollections = []string{
+	"AnimeList",
+	"Analytics",
+	"DraftIndex",
+	"Inventory",
+	"ListImport",
+	"PushSubscriptions",
+	"Settings",
+	"UserDrafts",
+	"UserNotifications",
+	"UserRecommendations",
+	"UserStatistics",
+}
+
+// UserDataCollections are all collections that contain private data of users.
+// Purge removes the data of the user from every one of them.
+var UserDataCollections = append([]string{
+	"APIKeyToUser",
+	"Conversation",
+	"CustomList",
+	"EmailDelivery",
+	"EmailToUser",
+	"FacebookToUser",
+	"GoogleToUser",
+	"NickToUser",
+	"Notification",
+	"OAuthClient",
+	"OAuthCode",
+	"OAuthToken",
+	"TwitterToUser",
+	"UserAPIKeys",
+	"YearInReview",
+}, userCollections...)
+
+// Deactivate hides the account and schedules the deletion of its data.
+func (user *User) Deactivate() {
+	user.Deactivated = DateTimeUTC()
+}
+
+// Reactivate cancels the scheduled deletion and returns true if the account was deactivated.
+// It is called whenever the user logs in.
+func (user *User) Reactivate() bool {
+	if user.Deactivated == "" {
+		return false
+	}
+
+	user.Deactivated = ""
+	return true
+}
+
+// IsDeactivated tells you whether the account is scheduled for deletion.
+func (user *User) IsDeactivated() bool {
+	return user.Deactivated != ""
+}
+
+// IsDeleted tells you whether the data of the account has been deleted.
+func (user *User) IsDeleted() bool {
+	return user.Deleted != ""
+}
+
+// IsHidden tells you whether the account should be hidden from other users.
+// This is the case for deactivated and deleted accounts.
+func (user *User) IsHidden() bool {
+	return user.IsDeactivated() || user.IsDeleted()
+}
+
+// DeletionTime returns the time the data of a deactivated account will be deleted.
+func (user *User) DeletionTime() time.Time {
+	deactivated, _ := time.Parse(time.RFC3339, user.Deactivated)
+	return deactivated.Add(UserDeletionGracePeriod)
+}
+
+// ShouldPurge tells you whether the grace period of a deactivated account is over.
+func (user *User) ShouldPurge(now time.Time) bool {
+	return user.IsDeactivated() && !user.IsDeleted() && !now.Before(user.DeletionTime())
+}
+
+// Purge deletes all private data of the user.
+// The user object itself is kept as an anonymous placeholder with empty default data
+// so that posts, edits and other public contributions still have a creator.
+func (user *User) Purge() {
+	// Login references
+	if user.Nick != "" {
+		DB.Delete("NickToUser", user.Nick)
+	}
+
+	if user.Email != "" {
+		DB.Delete("EmailToUser", user.Email)
+	}
+
+	if user.Accounts.Google.ID != "" {
+		DB.Delete("GoogleToUser", user.Accounts.Google.ID)
+	}
+
+	if user.Accounts.Facebook.ID != "" {
+		DB.Delete("FacebookToUser", user.Accounts.Facebook.ID)
+	}
+
+	if user.Accounts.Twitter.ID != "" {
+		DB.Delete("TwitterToUser", user.Accounts.Twitter.ID)
+	}
+
+	// Notifications
+	notifications := user.Notifications()
+
+	if notifications != nil {
+		for _, notificationID := range notifications.Items {
+			DB.Delete("Notification", notificationID)
+		}
+	}
+
+	// API keys and app authorizations
+	keys := user.APIKeys()
+
+	for len(keys.Items) > 0 {
+		keys.Revoke(keys.Items[0].ID)
+	}
+
+	DB.Delete("UserAPIKeys", user.ID)
+
+	for _, token := range FilterOAuthTokens(func(token *OAuthToken) bool {
+		return token.UserID == user.ID
+	}) {
+		token.Delete()
+	}
+
+	for obj := range DB.All("OAuthCode") {
+		code := obj.(*OAuthCode)
+
+		if code.UserID == user.ID {
+			code.Delete()
+		}
+	}
+
+	for _, client := range FilterOAuthClients(func(client *OAuthClient) bool {
+		return client.CreatedBy == user.ID
+	}) {
+		client.Delete()
+	}
+
+	// Private messages
+	for _, conversation := range GetConversationsByUser(user.ID) {
+		conversation.RemoveParticipant(user.ID)
+
+		if len(conversation.ParticipantIDs) < 2 {
+			DB.Delete("Conversation", conversation.ID)
+		} else {
+			conversation.Save()
+		}
+	}
+
+	// Lists, reviews of the year and sent emails
+	for _, list := range FilterCustomLists(func(list *CustomList) bool {
+		return list.CreatedBy == user.ID
+	}) {
+		list.Delete()
+	}
+
+	for obj := range DB.All("YearInReview") {
+		review := obj.(*YearInReview)
+
+		if review.UserID == user.ID {
+			DB.Delete("YearInReview", review.ID)
+		}
+	}
+
+	for delivery := range StreamEmailDeliveries() {
+		if delivery.UserID == user.ID {
+			DB.Delete("EmailDelivery", delivery.ID)
+		}
+	}
+
+	for _, collection := range userCollections {
+		DB.Delete(collection, user.ID)
+	}
+
+	// Relationships with other users
+	for other := range StreamUsers() {
+		unfollowed := other.Unfollow(user.ID)
+		unblocked := other.Unblock(user.ID)
+
+		if unfollowed || unblocked {
+			other.Save()
+		}
+	}
+
+	for group := range StreamGroups() {
+		if group.RemoveMember(user.ID) == nil {
+			group.Save()
+		}
+	}
+
+	// Anonymous placeholder, this also removes linked accounts like the AniList sync token
+	*user = User{
+		ID:         user.ID,
+		Nick:       "Deleted user",
+		Registered: user.Registered,
+		Location:   &Location{},
+		Deleted:    DateTimeUTC(),
+		hasPosts:   user.hasPosts,
+	}
+
+	user.Save()
+	user.createDefaultData()
+}
@@ -0,0 +1,58 @@
+package arn_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/akyoto/assert"
+	"github.com/animenotifier/notify.moe/arn"
+)
+
+func TestUserDeletion(t *testing.T) {
+	user := &arn.User{}
+	now := time.Now()
+	assert.False(t, user.ShouldPurge(now))
+	assert.False(t, user.Reactivate())
+
+	user.Deactivate()
+	assert.True(t, user.IsDeactivated())
+	assert.False(t, user.ShouldPurge(now))
+	assert.True(t, user.ShouldPurge(now.Add(arn.UserDeletionGracePeriod)))
+
+	// Logging in within the grace period cancels the deletion
+	assert.True(t, user.Reactivate())
+	assert.False(t, user.ShouldPurge(now.Add(arn.UserDeletionGracePeriod)))
+}
+
+func TestUserDataCollections(t *testing.T) {
+	// These keep a user ID for accountability and bookkeeping
+	records := []string{
+		"EditLogEntry",
+		"ModerationLogEntry",
+		"PayPalPayment",
+		"Purchase",
+	}
+
+	// Collections referencing the owner in other ways
+	for _, collection := range []string{"Conversation", "CustomList", "OAuthClient"} {
+		assert.Contains(t, arn.UserDataCollections, collection)
+	}
+
+	// Every collection storing a user ID must be purged unless it's a record
+	for name, typ := range arn.DB.Types() {
+		if typ.Kind() != reflect.Struct {
+			continue
+		}
+
+		if _, hasUserID := typ.FieldByName("UserID"); !hasUserID {
+			continue
+		}
+
+		if arn.Contains(records, name) {
+			continue
+		}
+
+		assert.Contains(t, arn.UserDataCollections, name)
+	}
+}
@@ -0,0 +1,125 @@
+package arn
+
+import (
+	"sort"
+	"sync"
+)
+
+// MaxUserDrafts is the number of drafts kept per user.
+// When it's exceeded, the least recently edited draft is removed.
+const MaxUserDrafts = 50
+
+// NewThreadDraftKey identifies the draft of a thread that hasn't been created yet.
+const NewThreadDraftKey = "new-thread"
+
+// PostDraft is an unposted text of a thread or post.
+type PostDraft struct {
+	Title  string `json:"title"`
+	Text   string `json:"text"`
+	Tag    string `json:"tag"`
+	Edited string `json:"edited"`
+}
+
+// UserDrafts contains the drafts of a user, indexed by the place they were written in.
+type UserDrafts struct {
+	UserID UserID                `json:"userId" primary:"true"`
+	Items  map[string]*PostDraft `json:"items"`
+
+	sync.Mutex
+}
+
+// NewUserDrafts creates a new list of drafts.
+func NewUserDrafts(userID UserID) *UserDrafts {
+	return &UserDrafts{
+		UserID: userID,
+		Items:  map[string]*PostDraft{},
+	}
+}
+
+// DraftKey returns the key of the draft for a reply to the given parent.
+func DraftKey(parent PostParent) string {
+	return parent.TypeName() + ":" + parent.GetID()
+}
+
+// Get returns the draft with the given key or nil if it doesn't exist.
+func (drafts *UserDrafts) Get(key string) *PostDraft {
+	drafts.Lock()
+	defer drafts.Unlock()
+
+	return drafts.Items[key]
+}
+
+// Set stores the draft under the given key.
+func (drafts *UserDrafts) Set(key string, draft *PostDraft) {
+	draft.Edited = DateTimeUTC()
+
+	drafts.Lock()
+	defer drafts.Unlock()
+
+	drafts.Items[key] = draft
+
+	if len(drafts.Items) <= MaxUserDrafts {
+		return
+	}
+
+	keys := make([]string, 0, len(drafts.Items))
+
+	for key := range drafts.Items {
+		keys = append(keys, key)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		return drafts.Items[keys[i]].Edited < drafts.Items[keys[j]].Edited
+	})
+
+	for _, key := range keys[:len(keys)-MaxUserDrafts] {
+		delete(drafts.Items, key)
+	}
+}
+
+// Remove deletes the draft with the given key.
+func (drafts *UserDrafts) Remove(key string) bool {
+	drafts.Lock()
+	defer drafts.Unlock()
+
+	_, exists := drafts.Items[key]
+	delete(drafts.Items, key)
+	return exists
+}
+
+// GetID returns the ID.
+func (drafts *UserDrafts) GetID() string {
+	return drafts.UserID
+}
+
+// Save saves the drafts in the database.
+func (drafts *UserDrafts) Save() {
+	drafts.Lock()
+	defer drafts.Unlock()
+
+	DB.Set("UserDrafts", drafts.UserID, drafts)
+}
+
+// GetUserDrafts ...
+func GetUserDrafts(id UserID) (*UserDrafts, error) {
+	obj, err := DB.Get("UserDrafts", id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return obj.(*UserDrafts), nil
+}
+
+// DeleteDraft removes the draft with the given key once the text has been posted.
+func (user *User) DeleteDraft(key string) {
+	drafts, err := GetUserDrafts(user.ID)
+
+	if err != nil {
+		return
+	}
+
+	if drafts.Remove(key) {
+		drafts.Save()
+	}
+}
@@ -0,0 +1,50 @@
+package arn_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/akyoto/assert"
+	"github.com/animenotifier/notify.moe/arn"
+)
+
+func TestUserDrafts(t *testing.T) {
+	drafts := arn.NewUserDrafts("4J6qpK1ve")
+	drafts.Set(arn.NewThreadDraftKey, &arn.PostDraft{Title: "Winter season", Text: "Which shows are you watching?"})
+	assert.Equal(t, drafts.Get(arn.NewThreadDraftKey).Title, "Winter season")
+	assert.NotEqual(t, drafts.Get(arn.NewThreadDraftKey).Edited, "")
+
+	// The least recently edited draft is removed when the limit is exceeded
+	drafts.Get(arn.NewThreadDraftKey).Edited = "2000-01-01T00:00:00Z"
+
+	for i := 0; i < arn.MaxUserDrafts; i++ {
+		drafts.Set(fmt.Sprintf("Thread:%d", i), &arn.PostDraft{Text: "Reply"})
+	}
+
+	assert.Equal(t, len(drafts.Items), arn.MaxUserDrafts)
+	assert.Nil(t, drafts.Get(arn.NewThreadDraftKey))
+
+	assert.True(t, drafts.Remove("Thread:0"))
+	assert.False(t, drafts.Remove("Thread:0"))
+}
+
+func TestUserDraftsConcurrent(t *testing.T) {
+	drafts := arn.NewUserDrafts("4J6qpK1ve")
+	wg := sync.WaitGroup{}
+
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+			key := fmt.Sprintf("Thread:%d", i%10)
+			drafts.Set(key, &arn.PostDraft{Text: "Reply"})
+			drafts.Get(key)
+			drafts.Remove(key)
+		}(i)
+	}
+
+	wg.Wait()
+	assert.True(t, len(drafts.Items) <= 10)
+}
@@ -23,13 +23,13 @@ func (user *User) Follow(followUserID UserID) error {
 		return err
 	}
 
+	if followedUser.IsBlocking(user.ID) {
+		return errors.New("You can't follow this user")
+	}
+
 	user.FollowIDs = append(user.FollowIDs, followUserID)
 
 	// Send notification
-	if !followedUser.Settings().Notification.NewFollowers {
-		return nil
-	}
-
 	followedUser.SendNotification(&PushNotification{
 		Title:   "You have a new follower!",
 		Message: user.Nick + " started following you.",
@@ -67,10 +67,15 @@ func (user *User) IsFollowing(userID UserID) bool {
 // Follows returns a slice of all the users you are following.
 func (user *User) Follows() []*User {
 	followsObj := DB.GetMany("User", user.FollowIDs)
-	follows := make([]*User, len(followsObj))
 
-	for i, user := range followsObj {
-		follows[i] = user.(*User)
+	follows := make([]*User, 0, len(followsObj))
+
+	for _, obj := range followsObj {
+		followed := obj.(*User)
+
+		if !followed.IsHidden() {
+			follows = append(follows, followed)
+		}
 	}
 
 	return follows
@@ -84,7 +89,7 @@ func (user *User) Friends() []*User {
 	for _, friendObj := range followsObj {
 		friend := friendObj.(*User)
 
-		if friend.IsFollowing(user.ID) {
+		if friend.IsFollowing(user.ID) && !friend.IsHidden() {
 			friends = append(friends, friend)
 		}
 	}
@@ -97,7 +102,7 @@ func (user *User) Followers() []*User {
 	var followerIDs []string
 
 	for follower := range StreamUsers() {
-		if follower.IsFollowing(user.ID) {
+		if follower.IsFollowing(user.ID) && !follower.IsHidden() {
 			followerIDs = append(followerIDs, follower.ID)
 		}
 	}
@@ -117,7 +122,7 @@ func (user *User) FollowersCount() int {
 	count := 0
 
 	for follower := range StreamUsers() {
-		if follower.IsFollowing(user.ID) {
+		if follower.IsFollowing(user.ID) && !follower.IsHidden() {
 			count++
 		}
 	}
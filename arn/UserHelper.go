@@ -32,6 +32,23 @@ func GetUserByNick(nick string) (*User, error) {
 	return user, err
 }
 
+// GetVisibleUserByNick fetches the user with the given nick
+// and returns an error if the account has been deactivated or deleted.
+// Pages showing the data of a user to others should use this instead of GetUserByNick.
+func GetVisibleUserByNick(nick string) (*User, error) {
+	user, err := GetUserByNick(nick)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if user.IsHidden() {
+		return nil, errors.New("This account has been deactivated")
+	}
+
+	return user, nil
+}
+
 // GetUserByEmail fetches the user with the given email from the database.
 func GetUserByEmail(email string) (*User, error) {
 	if email == "" {
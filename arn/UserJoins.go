@@ -30,12 +30,29 @@ func (user *User) AnimeList() *AnimeList {
 	return animeList
 }
 
+// Statistics returns the anime statistics of the user or nil if they haven't been calculated yet.
+func (user *User) Statistics() *UserStatistics {
+	stats, _ := GetUserStatistics(user.ID)
+	return stats
+}
+
 // PushSubscriptions ...
 func (user *User) PushSubscriptions() *PushSubscriptions {
 	subs, _ := GetPushSubscriptions(user.ID)
 	return subs
 }
 
+// APIKeys returns the API keys of the user.
+func (user *User) APIKeys() *UserAPIKeys {
+	keys, err := GetUserAPIKeys(user.ID)
+
+	if err != nil {
+		return NewUserAPIKeys(user.ID)
+	}
+
+	return keys
+}
+
 // Inventory ...
 func (user *User) Inventory() *Inventory {
 	inventory, _ := GetInventory(user.ID)
@@ -48,6 +65,28 @@ func (user *User) Notifications() *UserNotifications {
 	return notifications
 }
 
+// Drafts returns the unposted texts of the user.
+func (user *User) Drafts() *UserDrafts {
+	drafts, err := GetUserDrafts(user.ID)
+
+	if err != nil {
+		return NewUserDrafts(user.ID)
+	}
+
+	return drafts
+}
+
+// Draft returns the draft with the given key or an empty draft.
+func (user *User) Draft(key string) *PostDraft {
+	draft := user.Drafts().Get(key)
+
+	if draft == nil {
+		return &PostDraft{}
+	}
+
+	return draft
+}
+
 // DraftIndex ...
 func (user *User) DraftIndex() *DraftIndex {
 	draftIndex, _ := GetDraftIndex(user.ID)
@@ -0,0 +1,185 @@
+package arn
+
+import "time"
+
+// userStatisticsPaceMonths is the number of months shown in the watching pace.
+const userStatisticsPaceMonths = 12
+
+// UserStatistics contains the aggregated anime statistics of a user.
+// They are calculated by a background job because they need the whole anime list
+// and the activity history of the user. Ratings[i] is the number of anime rated i + 1.
+type UserStatistics struct {
+	UserID          UserID                 `json:"userId" primary:"true"`
+	EpisodesWatched int                    `json:"episodesWatched"`
+	MinutesWatched  int                    `json:"minutesWatched"`
+	Ratings         []int                  `json:"ratings"`
+	Genres          map[string]int         `json:"genres"`
+	Pace            []*UserStatisticsMonth `json:"pace"`
+	CompletionRate  float64                `json:"completionRate"`
+	Updated         string                 `json:"updated"`
+}
+
+// UserStatisticsMonth is the number of episodes a user watched in a month.
+type UserStatisticsMonth struct {
+	Month    string `json:"month"`
+	Episodes int    `json:"episodes"`
+}
+
+// NewUserStatistics calculates the statistics of an anime list.
+// Private list items are ignored because the statistics are public.
+// The activities are used to determine the watching pace of the months before now.
+func NewUserStatistics(animeList *AnimeList, activities []*ActivityConsumeAnime, now time.Time) *UserStatistics {
+	stats := &UserStatistics{
+		UserID:  animeList.UserID,
+		Ratings: make([]int, 10),
+		Genres:  map[string]int{},
+		Updated: now.UTC().Format(time.RFC3339),
+	}
+
+	animeList = animeList.WithoutPrivateItems()
+	started := 0
+	completed := 0
+
+	for _, item := range animeList.Items {
+		if item.Status == AnimeListStatusPlanned {
+			continue
+		}
+
+		started++
+
+		if item.Status == AnimeListStatusCompleted {
+			completed++
+		}
+
+		if item.Rating.Overall != 0 {
+			rating := int(item.Rating.Overall + 0.5)
+
+			if rating < 1 {
+				rating = 1
+			}
+
+			stats.Ratings[rating-1]++
+		}
+
+		anime := item.Anime()
+
+		if anime == nil {
+			continue
+		}
+
+		episodes := item.Episodes + item.RewatchCount*anime.EpisodeCount
+		stats.EpisodesWatched += episodes
+		stats.MinutesWatched += episodes * anime.EpisodeLength
+
+		for _, genre := range anime.Genres {
+			stats.Genres[genre]++
+		}
+	}
+
+	if started > 0 {
+		stats.CompletionRate = float64(completed) / float64(started)
+	}
+
+	// Watching pace
+	monthToEpisodes := map[string]int{}
+
+	for _, activity := range activities {
+		if activity.ToEpisode < activity.FromEpisode || !animeList.Contains(activity.AnimeID) {
+			continue
+		}
+
+		monthToEpisodes[activity.Created[:len("2006-01")]] += activity.ToEpisode - activity.FromEpisode + 1
+	}
+
+	firstOfMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	for i := userStatisticsPaceMonths - 1; i >= 0; i-- {
+		month := firstOfMonth.AddDate(0, -i, 0).Format("2006-01")
+
+		stats.Pace = append(stats.Pace, &UserStatisticsMonth{
+			Month:    month,
+			Episodes: monthToEpisodes[month],
+		})
+	}
+
+	return stats
+}
+
+// HoursWatched returns the number of hours the user spent watching anime.
+func (stats *UserStatistics) HoursWatched() int {
+	return stats.MinutesWatched / 60
+}
+
+// RatedCount returns the number of rated anime.
+func (stats *UserStatistics) RatedCount() int {
+	count := 0
+
+	for _, ratings := range stats.Ratings {
+		count += ratings
+	}
+
+	return count
+}
+
+// RatingPercentage returns the number of anime with the given rating from 1 to 10
+// relative to the most common rating in percent.
+func (stats *UserStatistics) RatingPercentage(rating int) int {
+	maxCount := 0
+
+	for _, count := range stats.Ratings {
+		if count > maxCount {
+			maxCount = count
+		}
+	}
+
+	if maxCount == 0 {
+		return 0
+	}
+
+	return stats.Ratings[rating-1] * 100 / maxCount
+}
+
+// PacePercentage returns the episodes watched in the given month
+// relative to the month with the most episodes in percent.
+func (stats *UserStatistics) PacePercentage(month *UserStatisticsMonth) int {
+	maxEpisodes := 0
+
+	for _, other := range stats.Pace {
+		if other.Episodes > maxEpisodes {
+			maxEpisodes = other.Episodes
+		}
+	}
+
+	if maxEpisodes == 0 {
+		return 0
+	}
+
+	return month.Episodes * 100 / maxEpisodes
+}
+
+// GenresPieChart returns a pie chart of the genre breakdown.
+func (stats *UserStatistics) GenresPieChart() *PieChart {
+	data := map[string]float64{}
+
+	for genre, count := range stats.Genres {
+		data[genre] = float64(count)
+	}
+
+	return NewPieChart("Genres", data)
+}
+
+// GetUserStatistics ...
+func GetUserStatistics(userID UserID) (*UserStatistics, error) {
+	obj, err := DB.Get("UserStatistics", userID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return obj.(*UserStatistics), nil
+}
+
+// Save saves the statistics in the database.
+func (stats *UserStatistics) Save() {
+	DB.Set("UserStatistics", stats.UserID, stats)
+}
@@ -0,0 +1,56 @@
+package arn_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/akyoto/assert"
+	"github.com/animenotifier/notify.moe/arn"
+)
+
+func TestUserStatistics(t *testing.T) {
+	animeList := &arn.AnimeList{
+		UserID: "4J6qpK1ve",
+		Items: []*arn.AnimeListItem{
+			{AnimeID: "completed", Status: arn.AnimeListStatusCompleted, Rating: arn.AnimeListItemRating{Overall: 8.6}},
+			{AnimeID: "dropped", Status: arn.AnimeListStatusDropped, Rating: arn.AnimeListItemRating{Overall: 0.2}},
+			{AnimeID: "planned", Status: arn.AnimeListStatusPlanned, Rating: arn.AnimeListItemRating{Overall: 5}},
+			{AnimeID: "private", Status: arn.AnimeListStatusCompleted, Rating: arn.AnimeListItemRating{Overall: 9}, Private: true},
+		},
+	}
+
+	watch := func(animeID arn.AnimeID, from int, to int, created string) *arn.ActivityConsumeAnime {
+		activity := arn.NewActivityConsumeAnime(animeID, from, to, animeList.UserID)
+		activity.Created = created
+		return activity
+	}
+
+	activities := []*arn.ActivityConsumeAnime{
+		watch("completed", 1, 3, "2026-10-02T10:00:00Z"),
+		watch("completed", 4, 4, "2026-10-03T10:00:00Z"),
+		watch("dropped", 1, 2, "2026-08-15T10:00:00Z"),
+		watch("private", 1, 12, "2026-10-04T10:00:00Z"),
+		watch("completed", 1, 1, "2024-01-01T10:00:00Z"),
+	}
+
+	now := time.Date(2026, time.October, 14, 12, 0, 0, 0, time.UTC)
+	stats := arn.NewUserStatistics(animeList, activities, now)
+
+	// Planned and private anime don't count as started
+	assert.Equal(t, stats.CompletionRate, 0.5)
+
+	// Ratings are rounded to the nearest score from 1 to 10
+	assert.Equal(t, stats.Ratings[8], 1)
+	assert.Equal(t, stats.Ratings[0], 1)
+	assert.Equal(t, stats.RatedCount(), 2)
+	assert.Equal(t, stats.RatingPercentage(9), 100)
+	assert.Equal(t, stats.RatingPercentage(5), 0)
+
+	// Watching pace of the last 12 months
+	assert.Equal(t, len(stats.Pace), 12)
+	assert.Equal(t, stats.Pace[0].Month, "2025-11")
+	assert.Equal(t, stats.Pace[11].Month, "2026-10")
+	assert.Equal(t, stats.Pace[11].Episodes, 4)
+	assert.Equal(t, stats.Pace[9].Episodes, 2)
+	assert.Equal(t, stats.PacePercentage(stats.Pace[9]), 50)
+}
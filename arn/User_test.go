@@ -21,3 +21,15 @@ func TestDatabaseErrorMessages(t *testing.T) {
 	assert.NotNil(t, err)
 	assert.Contains(t, err.Error(), "not found")
 }
+
+func TestUserBlocks(t *testing.T) {
+	user := arn.NewUser()
+	assert.NotNil(t, user.Block(user.ID))
+	assert.False(t, user.IsBlocking(user.ID))
+
+	user.BlockIDs = []arn.UserID{"blocked"}
+	assert.True(t, user.IsBlocking("blocked"))
+	assert.True(t, user.Unblock("blocked"))
+	assert.False(t, user.IsBlocking("blocked"))
+	assert.False(t, user.Unblock("blocked"))
+}
@@ -30,6 +30,7 @@ var (
 )
 
 // GetUserFromContext returns the logged in user for the given context.
+// Sessions of deactivated or deleted accounts are treated as logged out.
 func GetUserFromContext(ctx aero.Context) *User {
 	if !ctx.HasSession() {
 		return nil
@@ -43,7 +44,7 @@ func GetUserFromContext(ctx aero.Context) *User {
 
 	user, err := GetUser(userID)
 
-	if err != nil {
+	if err != nil || user.IsHidden() {
 		return nil
 	}
 
@@ -200,13 +201,6 @@ func DateToSeason(date time.Time) string {
 	}
 }
 
-// BroadcastEvent sends the given event to the event streams of all users.
-func BroadcastEvent(event *aero.Event) {
-	for user := range StreamUsers() {
-		user.BroadcastEvent(event)
-	}
-}
-
 // AnimeRatingStars displays the rating in Unicode stars.
 func AnimeRatingStars(rating float64) string {
 	stars := int(rating/20 + 0.5)
@@ -336,12 +330,18 @@ func deleteImages(folderName string, id string, originalExtension string) {
 	os.Remove(path.Join(Root, "images", folderName, "large", id+"@2.jpg"))
 	os.Remove(path.Join(Root, "images", folderName, "large", id+".webp"))
 	os.Remove(path.Join(Root, "images", folderName, "large", id+"@2.webp"))
+	os.Remove(path.Join(Root, "images", folderName, "large", id+".avif"))
+	os.Remove(path.Join(Root, "images", folderName, "large", id+"@2.avif"))
 	os.Remove(path.Join(Root, "images", folderName, "medium", id+".jpg"))
 	os.Remove(path.Join(Root, "images", folderName, "medium", id+"@2.jpg"))
 	os.Remove(path.Join(Root, "images", folderName, "medium", id+".webp"))
 	os.Remove(path.Join(Root, "images", folderName, "medium", id+"@2.webp"))
+	os.Remove(path.Join(Root, "images", folderName, "medium", id+".avif"))
+	os.Remove(path.Join(Root, "images", folderName, "medium", id+"@2.avif"))
 	os.Remove(path.Join(Root, "images", folderName, "small", id+".jpg"))
 	os.Remove(path.Join(Root, "images", folderName, "small", id+"@2.jpg"))
 	os.Remove(path.Join(Root, "images", folderName, "small", id+".webp"))
 	os.Remove(path.Join(Root, "images", folderName, "small", id+"@2.webp"))
+	os.Remove(path.Join(Root, "images", folderName, "small", id+".avif"))
+	os.Remove(path.Join(Root, "images", folderName, "small", id+"@2.avif"))
 }
@@ -0,0 +1,233 @@
+package arn
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+	"time"
+
+	"github.com/akyoto/color"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// WebhookSignatureHeader contains the HMAC-SHA256 signature of the request body.
+const WebhookSignatureHeader = "X-Notify-Signature"
+
+// webhookRetryDelays are the waiting times before each retry of a failed webhook delivery.
+var webhookRetryDelays = []time.Duration{
+	10 * time.Second,
+	time.Minute,
+	10 * time.Minute,
+}
+
+// WebhookPayload is the JSON body sent to a user's webhook when a new episode is released
+// or another notification with the webhook channel enabled is received.
+type WebhookPayload struct {
+	Type          string            `json:"type"`
+	Title         string            `json:"title,omitempty"`
+	Message       string            `json:"message,omitempty"`
+	AnimeID       AnimeID           `json:"animeId"`
+	AnimeTitle    string            `json:"animeTitle"`
+	EpisodeNumber int               `json:"episodeNumber"`
+	Link          string            `json:"link"`
+	Links         map[string]string `json:"links"`
+	Created       string            `json:"created"`
+}
+
+// NewEpisodeWebhookPayload creates the webhook payload for a released episode.
+func NewEpisodeWebhookPayload(anime *Anime, episodeNumber int, user *User) *WebhookPayload {
+	payload := &WebhookPayload{
+		Type:          NotificationTypeAnimeEpisode,
+		AnimeID:       anime.ID,
+		AnimeTitle:    anime.Title.ByUser(user),
+		EpisodeNumber: episodeNumber,
+		Link:          "https://" + Domain + anime.Link(),
+		Links:         map[string]string{},
+		Created:       DateTimeUTC(),
+	}
+
+	episode, _ := anime.Episodes().Find(episodeNumber)
+
+	if episode != nil {
+		for name, link := range episode.Links {
+			payload.Links[name] = link
+		}
+	}
+
+	return payload
+}
+
+// NewNotificationWebhookPayload creates the webhook payload for a notification that isn't about an episode.
+func NewNotificationWebhookPayload(notification *PushNotification) *WebhookPayload {
+	return &WebhookPayload{
+		Type:    notification.Type,
+		Title:   notification.Title,
+		Message: notification.Message,
+		Link:    notification.Link,
+		Links:   map[string]string{},
+		Created: DateTimeUTC(),
+	}
+}
+
+// SignWebhookBody returns the signature of the request body for the given secret.
+func SignWebhookBody(body []byte, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// ValidateWebhookURL returns an error if webhooks can't be delivered to the given URL.
+func ValidateWebhookURL(webhookURL string) error {
+	parsed, err := url.Parse(webhookURL)
+
+	if err != nil {
+		return err
+	}
+
+	if parsed.Scheme != "https" && parsed.Scheme != "http" {
+		return errors.New("Webhook URL must start with https:// or http://")
+	}
+
+	if parsed.Hostname() == "" {
+		return errors.New("Webhook URL is missing the host name")
+	}
+
+	return nil
+}
+
+// webhookClient delivers the webhooks. Redirects are not followed
+// and connections to the internal network are refused.
+var webhookClient = &http.Client{
+	Timeout: 30 * time.Second,
+	CheckRedirect: func(request *http.Request, via []*http.Request) error {
+		return http.ErrUseLastResponse
+	},
+	Transport: &http.Transport{
+		DialContext: (&net.Dialer{
+			Timeout: 10 * time.Second,
+			Control: checkWebhookAddress,
+		}).DialContext,
+	},
+}
+
+// checkWebhookAddress makes sure that webhooks are never delivered to the internal network.
+// It's called with the resolved IP address right before connecting,
+// so the host name can't resolve to a different address after the check.
+func checkWebhookAddress(network string, address string, connection syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+
+	if err != nil {
+		return err
+	}
+
+	ip := net.ParseIP(host)
+
+	if ip == nil || !isPublicIP(ip) {
+		return fmt.Errorf("Webhook address %s is not a public address", host)
+	}
+
+	return nil
+}
+
+// isPublicIP tells whether the IP address is reachable on the public internet.
+func isPublicIP(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsUnspecified() || ip.IsMulticast() {
+		return false
+	}
+
+	for _, network := range privateNetworks {
+		if network.Contains(ip) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// privateNetworks are the IP ranges reserved for private use.
+var privateNetworks = func() []*net.IPNet {
+	var networks []*net.IPNet
+
+	for _, cidr := range []string{"10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16", "100.64.0.0/10", "fc00::/7"} {
+		_, network, _ := net.ParseCIDR(cidr)
+		networks = append(networks, network)
+	}
+
+	return networks
+}()
+
+// SendWebhook delivers the payload to the webhook of the user.
+// Failed deliveries are retried with an increasing delay.
+func (user *User) SendWebhook(payload *WebhookPayload) {
+	settings := user.Settings().Notification
+
+	if settings.WebhookURL == "" || settings.WebhookSecret == "" {
+		return
+	}
+
+	if !settings.Preferences.Allows(payload.Type, NotificationChannelWebhook) {
+		return
+	}
+
+	body, err := jsoniter.Marshal(payload)
+
+	if err != nil {
+		color.Red(err.Error())
+		return
+	}
+
+	signature := SignWebhookBody(body, settings.WebhookSecret)
+
+	for attempt := 0; ; attempt++ {
+		err = deliverWebhook(settings.WebhookURL, body, signature)
+
+		if err == nil {
+			return
+		}
+
+		if attempt >= len(webhookRetryDelays) {
+			color.Red("Webhook delivery for user %s failed: %s", user.Nick, err.Error())
+			return
+		}
+
+		time.Sleep(webhookRetryDelays[attempt])
+	}
+}
+
+// deliverWebhook sends a single webhook request.
+func deliverWebhook(webhookURL string, body []byte, signature string) error {
+	err := ValidateWebhookURL(webhookURL)
+
+	if err != nil {
+		return err
+	}
+
+	request, err := http.NewRequest(http.MethodPost, webhookURL, bytes.NewReader(body))
+
+	if err != nil {
+		return err
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(WebhookSignatureHeader, signature)
+	response, err := webhookClient.Do(request)
+
+	if err != nil {
+		return err
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return fmt.Errorf("Webhook responded with status %d", response.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,24 @@
+package arn_test
+
+import (
+	"testing"
+
+	"github.com/akyoto/assert"
+	"github.com/animenotifier/notify.moe/arn"
+)
+
+func TestSignWebhookBody(t *testing.T) {
+	body := []byte(`{"animeId":"74y2cFiiR","episodeNumber":3}`)
+	signature := arn.SignWebhookBody(body, "secret")
+
+	assert.Equal(t, signature, arn.SignWebhookBody(body, "secret"))
+	assert.NotEqual(t, signature, arn.SignWebhookBody(body, "other secret"))
+	assert.Equal(t, len(signature), len("sha256=")+64)
+}
+
+func TestValidateWebhookURL(t *testing.T) {
+	assert.Nil(t, arn.ValidateWebhookURL("https://example.com/hooks/anime"))
+	assert.NotNil(t, arn.ValidateWebhookURL("ftp://example.com"))
+	assert.NotNil(t, arn.ValidateWebhookURL("https://"))
+	assert.NotEqual(t, arn.GenerateSecret(), arn.GenerateSecret())
+}
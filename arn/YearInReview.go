@@ -0,0 +1,268 @@
+package arn
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	yearInReviewMaxGenres       = 5
+	yearInReviewMaxHighestRated = 5
+	yearInReviewMaxRewatched    = 3
+)
+
+// YearInReview summarizes the anime a user watched during a year.
+type YearInReview struct {
+	ID              string                  `json:"id" primary:"true"`
+	UserID          UserID                  `json:"userId"`
+	Year            int                     `json:"year"`
+	AnimeWatched    int                     `json:"animeWatched"`
+	EpisodesWatched int                     `json:"episodesWatched"`
+	MinutesWatched  int                     `json:"minutesWatched"`
+	TopGenres       []string                `json:"topGenres"`
+	HighestRated    []AnimeID               `json:"highestRated"`
+	MostRewatched   []AnimeID               `json:"mostRewatched"`
+	FirstCompleted  *YearInReviewCompletion `json:"firstCompleted"`
+	LastCompleted   *YearInReviewCompletion `json:"lastCompleted"`
+	Created         string                  `json:"created"`
+}
+
+// YearInReviewCompletion is an anime that the user completed on the given date.
+type YearInReviewCompletion struct {
+	AnimeID AnimeID `json:"animeId"`
+	Date    string  `json:"date"`
+}
+
+// YearInReviewID returns the database key for the review of a user's year.
+func YearInReviewID(userID UserID, year int) string {
+	return userID + ":" + strconv.Itoa(year)
+}
+
+// NewYearInReview summarizes the given year of an anime list.
+// The episodes come from the watching activities and the completions
+// from the completion dates of the list items. Private items are ignored.
+func NewYearInReview(animeList *AnimeList, activities []*ActivityConsumeAnime, year int) *YearInReview {
+	review := &YearInReview{
+		ID:      YearInReviewID(animeList.UserID, year),
+		UserID:  animeList.UserID,
+		Year:    year,
+		Created: DateTimeUTC(),
+	}
+
+	prefix := strconv.Itoa(year) + "-"
+	animeList = animeList.WithoutPrivateItems()
+	items := map[AnimeID]*AnimeListItem{}
+	watched := []*AnimeListItem{}
+
+	for _, item := range animeList.Items {
+		items[item.AnimeID] = item
+	}
+
+	addWatched := func(item *AnimeListItem) {
+		for _, existing := range watched {
+			if existing == item {
+				return
+			}
+		}
+
+		watched = append(watched, item)
+	}
+
+	// Episodes
+	for _, activity := range activities {
+		item, exists := items[activity.AnimeID]
+
+		if !exists || activity.ToEpisode < activity.FromEpisode || !strings.HasPrefix(activity.Created, prefix) {
+			continue
+		}
+
+		episodes := activity.ToEpisode - activity.FromEpisode + 1
+		review.EpisodesWatched += episodes
+
+		anime := item.Anime()
+
+		if anime != nil {
+			review.MinutesWatched += episodes * anime.EpisodeLength
+		}
+
+		addWatched(item)
+	}
+
+	// Completions
+	completed := []*AnimeListItem{}
+
+	for _, item := range animeList.Items {
+		if item.Status == AnimeListStatusCompleted && strings.HasPrefix(item.Completed, prefix) {
+			completed = append(completed, item)
+			addWatched(item)
+		}
+	}
+
+	sort.Slice(completed, func(i, j int) bool {
+		return completed[i].Completed < completed[j].Completed
+	})
+
+	if len(completed) > 0 {
+		first := completed[0]
+		last := completed[len(completed)-1]
+		review.FirstCompleted = &YearInReviewCompletion{AnimeID: first.AnimeID, Date: first.Completed}
+		review.LastCompleted = &YearInReviewCompletion{AnimeID: last.AnimeID, Date: last.Completed}
+	}
+
+	review.AnimeWatched = len(watched)
+
+	// Genres
+	genres := map[string]int{}
+
+	for _, item := range watched {
+		anime := item.Anime()
+
+		if anime == nil {
+			continue
+		}
+
+		for _, genre := range anime.Genres {
+			if genres[genre] == 0 {
+				review.TopGenres = append(review.TopGenres, genre)
+			}
+
+			genres[genre]++
+		}
+	}
+
+	sort.Slice(review.TopGenres, func(i, j int) bool {
+		if genres[review.TopGenres[i]] == genres[review.TopGenres[j]] {
+			return review.TopGenres[i] < review.TopGenres[j]
+		}
+
+		return genres[review.TopGenres[i]] > genres[review.TopGenres[j]]
+	})
+
+	if len(review.TopGenres) > yearInReviewMaxGenres {
+		review.TopGenres = review.TopGenres[:yearInReviewMaxGenres]
+	}
+
+	// Highest rated
+	sort.SliceStable(watched, func(i, j int) bool {
+		return watched[i].Rating.Overall > watched[j].Rating.Overall
+	})
+
+	for _, item := range watched {
+		if item.Rating.Overall == 0 || len(review.HighestRated) == yearInReviewMaxHighestRated {
+			break
+		}
+
+		review.HighestRated = append(review.HighestRated, item.AnimeID)
+	}
+
+	// Most rewatched
+	sort.SliceStable(watched, func(i, j int) bool {
+		return watched[i].RewatchCount > watched[j].RewatchCount
+	})
+
+	for _, item := range watched {
+		if item.RewatchCount == 0 || len(review.MostRewatched) == yearInReviewMaxRewatched {
+			break
+		}
+
+		review.MostRewatched = append(review.MostRewatched, item.AnimeID)
+	}
+
+	return review
+}
+
+// IsEmpty tells you whether the user didn't watch anything during the year.
+func (review *YearInReview) IsEmpty() bool {
+	return review.AnimeWatched == 0
+}
+
+// HoursWatched returns the number of hours the user spent watching anime during the year.
+func (review *YearInReview) HoursWatched() int {
+	return review.MinutesWatched / 60
+}
+
+// User returns the user the review belongs to.
+func (review *YearInReview) User() *User {
+	user, _ := GetUser(review.UserID)
+	return user
+}
+
+// Link returns the URI to the review page.
+func (review *YearInReview) Link() string {
+	user := review.User()
+
+	if user == nil {
+		return ""
+	}
+
+	return fmt.Sprintf("/+%s/year/%d", user.Nick, review.Year)
+}
+
+// HighestRatedAnime returns the best rated anime of the year.
+func (review *YearInReview) HighestRatedAnime() []*Anime {
+	return review.animeByIDs(review.HighestRated)
+}
+
+// MostRewatchedAnime returns the anime of the year that the user rewatched the most.
+func (review *YearInReview) MostRewatchedAnime() []*Anime {
+	return review.animeByIDs(review.MostRewatched)
+}
+
+// animeByIDs returns the existing anime for the given IDs.
+func (review *YearInReview) animeByIDs(animeIDs []AnimeID) []*Anime {
+	objects := DB.GetMany("Anime", animeIDs)
+	animes := make([]*Anime, 0, len(animeIDs))
+
+	for _, obj := range objects {
+		if obj == nil {
+			continue
+		}
+
+		animes = append(animes, obj.(*Anime))
+	}
+
+	return animes
+}
+
+// Anime returns the completed anime.
+func (completion *YearInReviewCompletion) Anime() *Anime {
+	anime, _ := GetAnime(completion.AnimeID)
+	return anime
+}
+
+// GetYearInReview returns the review of a user's year.
+func GetYearInReview(userID UserID, year int) (*YearInReview, error) {
+	obj, err := DB.Get("YearInReview", YearInReviewID(userID, year))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return obj.(*YearInReview), nil
+}
+
+// GetYearInReviewsByUser returns all reviews of the user, latest year first.
+func GetYearInReviewsByUser(userID UserID) []*YearInReview {
+	reviews := []*YearInReview{}
+
+	for obj := range DB.All("YearInReview") {
+		review := obj.(*YearInReview)
+
+		if review.UserID == userID && !review.IsEmpty() {
+			reviews = append(reviews, review)
+		}
+	}
+
+	sort.Slice(reviews, func(i, j int) bool {
+		return reviews[i].Year > reviews[j].Year
+	})
+
+	return reviews
+}
+
+// Save saves the review in the database.
+func (review *YearInReview) Save() {
+	DB.Set("YearInReview", review.ID, review)
+}
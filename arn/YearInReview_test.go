@@ -0,0 +1,51 @@
+package arn_test
+
+import (
+	"testing"
+
+	"github.com/akyoto/assert"
+	"github.com/animenotifier/notify.moe/arn"
+)
+
+func TestYearInReview(t *testing.T) {
+	animeList := &arn.AnimeList{
+		UserID: "4J6qpK1ve",
+		Items: []*arn.AnimeListItem{
+			{AnimeID: "first", Status: arn.AnimeListStatusCompleted, Completed: "2025-02-01T10:00:00Z", Rating: arn.AnimeListItemRating{Overall: 7}},
+			{AnimeID: "last", Status: arn.AnimeListStatusCompleted, Completed: "2025-11-20T10:00:00Z", Rating: arn.AnimeListItemRating{Overall: 9}, RewatchCount: 2},
+			{AnimeID: "last-year", Status: arn.AnimeListStatusCompleted, Completed: "2024-12-31T10:00:00Z", Rating: arn.AnimeListItemRating{Overall: 10}},
+			{AnimeID: "watching", Status: arn.AnimeListStatusWatching},
+			{AnimeID: "private", Status: arn.AnimeListStatusCompleted, Completed: "2025-06-01T10:00:00Z", Private: true},
+		},
+	}
+
+	watch := func(animeID arn.AnimeID, from int, to int, created string) *arn.ActivityConsumeAnime {
+		activity := arn.NewActivityConsumeAnime(animeID, from, to, animeList.UserID)
+		activity.Created = created
+		return activity
+	}
+
+	activities := []*arn.ActivityConsumeAnime{
+		watch("watching", 1, 5, "2025-03-01T10:00:00Z"),
+		watch("last", 1, 12, "2025-11-19T10:00:00Z"),
+		watch("last-year", 1, 12, "2024-12-30T10:00:00Z"),
+		watch("private", 1, 12, "2025-06-01T10:00:00Z"),
+	}
+
+	review := arn.NewYearInReview(animeList, activities, 2025)
+	assert.Equal(t, review.ID, "4J6qpK1ve:2025")
+	assert.False(t, review.IsEmpty())
+	assert.Equal(t, review.AnimeWatched, 3)
+	assert.Equal(t, review.EpisodesWatched, 17)
+
+	// Highest rated first, unrated anime are left out
+	assert.DeepEqual(t, review.HighestRated, []arn.AnimeID{"last", "first"})
+	assert.DeepEqual(t, review.MostRewatched, []arn.AnimeID{"last"})
+
+	// Completions of other years don't count
+	assert.Equal(t, review.FirstCompleted.AnimeID, "first")
+	assert.Equal(t, review.LastCompleted.AnimeID, "last")
+
+	// Nothing happened in this year
+	assert.True(t, arn.NewYearInReview(animeList, activities, 2023).IsEmpty())
+}
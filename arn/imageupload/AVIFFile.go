@@ -0,0 +1,34 @@
+package imageupload
+
+import (
+	"os"
+	"path"
+
+	"github.com/akyoto/imageserver"
+)
+
+// AVIFFile is an output that writes the image in AVIF format.
+// It is optional because not every image server can encode AVIF.
+// Browsers fall back to the WebP or JPEG version of the image if the file doesn't exist.
+type AVIFFile struct {
+	Directory string
+	Width     int
+	Height    int
+	Quality   int
+}
+
+// Save writes the image in AVIF format to the file system.
+func (output *AVIFFile) Save(metaImage *imageserver.MetaImage, baseName string) error {
+	fileName := path.Join(output.Directory, baseName+".avif")
+	return metaImage.ConvertToFile("avif", output.Width, output.Height, output.Quality, fileName)
+}
+
+// Delete deletes the file from the file system.
+func (output *AVIFFile) Delete(baseName string) error {
+	return os.Remove(path.Join(output.Directory, baseName+".avif"))
+}
+
+// Optional tells you that a failed AVIF conversion doesn't fail the upload.
+func (output *AVIFFile) Optional() bool {
+	return true
+}
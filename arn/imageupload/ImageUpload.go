@@ -0,0 +1,153 @@
+package imageupload
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"net/http"
+
+	// We need these to decode uploaded images.
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+
+	"github.com/akyoto/imageserver"
+)
+
+const (
+	// MaxFileSize is the maximum size in bytes of an uploaded image.
+	MaxFileSize = 10 * 1024 * 1024
+
+	// MaxPixels is the maximum number of pixels of an uploaded image.
+	// This protects the image server from small files that decode to huge images.
+	MaxPixels = 25 * 1000 * 1000
+)
+
+var (
+	// ErrEmpty is returned when the upload doesn't contain any data.
+	ErrEmpty = errors.New("The uploaded file is empty")
+
+	// ErrTooLarge is returned when the file exceeds MaxFileSize.
+	ErrTooLarge = fmt.Errorf("The image is too large, the maximum file size is %d MB", MaxFileSize/1024/1024)
+
+	// ErrTooManyPixels is returned when the image dimensions exceed MaxPixels.
+	ErrTooManyPixels = fmt.Errorf("The image dimensions are too large, the maximum is %d megapixels", MaxPixels/1000/1000)
+
+	// ErrUnsupportedFormat is returned when the file is not an image in one of the supported formats.
+	ErrUnsupportedFormat = errors.New("Unsupported file type, please upload a JPEG, PNG or GIF image")
+)
+
+// optionalOutput is an output that is allowed to fail, e.g. a format the image server might not support.
+type optionalOutput interface {
+	Optional() bool
+}
+
+// contentTypes maps the detected content type to the format name of the image decoder.
+var contentTypes = map[string]string{
+	"image/jpeg": "jpeg",
+	"image/png":  "png",
+	"image/gif":  "gif",
+}
+
+// Decode validates the uploaded file and decodes the image.
+// Metadata like EXIF is removed from the file and the EXIF orientation is applied to the image.
+func Decode(data []byte) (*imageserver.MetaImage, error) {
+	if len(data) == 0 {
+		return nil, ErrEmpty
+	}
+
+	if len(data) > MaxFileSize {
+		return nil, ErrTooLarge
+	}
+
+	format, supported := contentTypes[http.DetectContentType(data)]
+
+	if !supported {
+		return nil, ErrUnsupportedFormat
+	}
+
+	// Check the dimensions before decoding the whole image
+	config, decodedFormat, err := image.DecodeConfig(bytes.NewReader(data))
+
+	if err != nil || decodedFormat != format {
+		return nil, ErrUnsupportedFormat
+	}
+
+	if config.Width*config.Height > MaxPixels {
+		return nil, ErrTooManyPixels
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+
+	if err != nil {
+		return nil, ErrUnsupportedFormat
+	}
+
+	switch format {
+	case "jpeg":
+		orientation := jpegOrientation(data)
+		stripped, wellFormed := stripJPEGMetadata(data)
+		data = stripped
+
+		// Files we can't strip safely are encoded again without any metadata
+		if orientation > 1 || !wellFormed {
+			if orientation > 1 {
+				img = applyOrientation(img, orientation)
+			}
+
+			data, err = encodeJPEG(img)
+
+			if err != nil {
+				return nil, err
+			}
+		}
+
+	case "png":
+		data = stripPNGMetadata(data)
+	}
+
+	return &imageserver.MetaImage{
+		Image:  img,
+		Format: format,
+		Data:   data,
+	}, nil
+}
+
+// Save writes the image to all outputs under the given base name
+// and tells you whether the optional outputs have been saved as well.
+// Failing optional outputs don't cause an error, but their files from previous uploads are deleted.
+// Otherwise the last error is returned.
+func Save(metaImage *imageserver.MetaImage, baseName string, outputs []imageserver.Output) (bool, error) {
+	var lastError error
+	optionalSaved := true
+
+	for _, output := range outputs {
+		err := output.Save(metaImage, baseName)
+
+		if err == nil {
+			continue
+		}
+
+		if optional, isOptional := output.(optionalOutput); isOptional && optional.Optional() {
+			_ = output.Delete(baseName)
+			optionalSaved = false
+			continue
+		}
+
+		lastError = err
+	}
+
+	return optionalSaved, lastError
+}
+
+// IsUserError tells you whether the error was caused by the uploaded file
+// as opposed to a failure while processing the image.
+func IsUserError(err error) bool {
+	switch err {
+	case ErrEmpty, ErrTooLarge, ErrTooManyPixels, ErrUnsupportedFormat:
+		return true
+	default:
+		return false
+	}
+}
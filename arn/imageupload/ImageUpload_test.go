@@ -0,0 +1,197 @@
+package imageupload_test
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/akyoto/assert"
+	"github.com/akyoto/imageserver"
+	"github.com/animenotifier/notify.moe/arn/imageupload"
+)
+
+// testImage creates a white image with a red top left quarter.
+func testImage(width int, height int) image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			if x < width/2 && y < height/2 {
+				img.Set(x, y, color.RGBA{R: 255, A: 255})
+			} else {
+				img.Set(x, y, color.White)
+			}
+		}
+	}
+
+	return img
+}
+
+func encodeJPEG(t *testing.T, img image.Image) []byte {
+	buffer := bytes.Buffer{}
+	assert.Nil(t, jpeg.Encode(&buffer, img, &jpeg.Options{Quality: 100}))
+	return buffer.Bytes()
+}
+
+// withEXIF inserts an EXIF segment with the given orientation after the SOI marker.
+func withEXIF(data []byte, orientation byte) []byte {
+	tiff := []byte{
+		'M', 'M', 0, 42, 0, 0, 0, 8,
+		0, 1,
+		0x01, 0x12, 0, 3, 0, 0, 0, 1, 0, orientation, 0, 0,
+		0, 0, 0, 0,
+	}
+
+	payload := append([]byte("Exif\x00\x00"), tiff...)
+	segment := []byte{0xFF, 0xE1, byte((len(payload) + 2) >> 8), byte(len(payload) + 2)}
+	segment = append(segment, payload...)
+
+	result := append([]byte{}, data[:2]...)
+	result = append(result, segment...)
+	return append(result, data[2:]...)
+}
+
+func TestDecodeInvalid(t *testing.T) {
+	_, err := imageupload.Decode(nil)
+	assert.Equal(t, err, imageupload.ErrEmpty)
+
+	_, err = imageupload.Decode(make([]byte, imageupload.MaxFileSize+1))
+	assert.Equal(t, err, imageupload.ErrTooLarge)
+
+	_, err = imageupload.Decode([]byte("<html><body>Not an image</body></html>"))
+	assert.Equal(t, err, imageupload.ErrUnsupportedFormat)
+
+	// Valid header but broken image data
+	data := encodeJPEG(t, testImage(8, 8))
+	_, err = imageupload.Decode(data[:len(data)/2])
+	assert.Equal(t, err, imageupload.ErrUnsupportedFormat)
+
+	assert.True(t, imageupload.IsUserError(imageupload.ErrTooLarge))
+	assert.False(t, imageupload.IsUserError(errors.New("Image server not available")))
+}
+
+func TestDecodeTooManyPixels(t *testing.T) {
+	buffer := bytes.Buffer{}
+	img := image.NewGray(image.Rect(0, 0, 5001, 5000))
+	assert.Nil(t, png.Encode(&buffer, img))
+
+	_, err := imageupload.Decode(buffer.Bytes())
+	assert.Equal(t, err, imageupload.ErrTooManyPixels)
+}
+
+func TestDecodeStripsEXIF(t *testing.T) {
+	data := withEXIF(encodeJPEG(t, testImage(8, 4)), 1)
+	assert.True(t, bytes.Contains(data, []byte("Exif")))
+
+	metaImage, err := imageupload.Decode(data)
+	assert.Nil(t, err)
+	assert.Equal(t, metaImage.Format, "jpeg")
+	assert.Equal(t, metaImage.Extension(), ".jpg")
+	assert.False(t, bytes.Contains(metaImage.Data, []byte("Exif")))
+
+	// The stripped file is still a valid image
+	img, err := jpeg.Decode(bytes.NewReader(metaImage.Data))
+	assert.Nil(t, err)
+	assert.Equal(t, img.Bounds().Dx(), 8)
+	assert.Equal(t, img.Bounds().Dy(), 4)
+}
+
+func TestDecodeMalformedHeader(t *testing.T) {
+	// A restart marker and junk bytes before the first segment are accepted by the decoder
+	valid := withEXIF(encodeJPEG(t, testImage(8, 4)), 1)
+	data := append([]byte{0xFF, 0xD8, 0xFF, 0xD0, 0x00, 0x00}, valid[2:]...)
+
+	_, _, err := image.DecodeConfig(bytes.NewReader(data))
+	assert.Nil(t, err)
+
+	metaImage, err := imageupload.Decode(data)
+	assert.Nil(t, err)
+	assert.False(t, bytes.Contains(metaImage.Data, []byte("Exif")))
+
+	img, err := jpeg.Decode(bytes.NewReader(metaImage.Data))
+	assert.Nil(t, err)
+	assert.Equal(t, img.Bounds().Dx(), 8)
+}
+
+func TestDecodeOrientation(t *testing.T) {
+	// Orientation 6 means the image needs to be rotated 90 degrees clockwise
+	data := withEXIF(encodeJPEG(t, testImage(64, 32)), 6)
+
+	metaImage, err := imageupload.Decode(data)
+	assert.Nil(t, err)
+	assert.Equal(t, metaImage.Image.Bounds().Dx(), 32)
+	assert.Equal(t, metaImage.Image.Bounds().Dy(), 64)
+	assert.False(t, bytes.Contains(metaImage.Data, []byte("Exif")))
+
+	// The red quarter moved from the top left to the top right
+	_, green, _, _ := metaImage.Image.At(24, 8).RGBA()
+	assert.True(t, green < 0x4000)
+	_, green, _, _ = metaImage.Image.At(8, 8).RGBA()
+	assert.True(t, green > 0xC000)
+
+	img, err := jpeg.Decode(bytes.NewReader(metaImage.Data))
+	assert.Nil(t, err)
+	assert.Equal(t, img.Bounds().Dx(), 32)
+}
+
+func TestDecodePNG(t *testing.T) {
+	buffer := bytes.Buffer{}
+	assert.Nil(t, png.Encode(&buffer, testImage(4, 4)))
+
+	metaImage, err := imageupload.Decode(buffer.Bytes())
+	assert.Nil(t, err)
+	assert.Equal(t, metaImage.Format, "png")
+
+	_, err = png.Decode(bytes.NewReader(metaImage.Data))
+	assert.Nil(t, err)
+}
+
+type testOutput struct {
+	err      error
+	optional bool
+	saved    []string
+	deleted  []string
+}
+
+func (output *testOutput) Save(metaImage *imageserver.MetaImage, baseName string) error {
+	output.saved = append(output.saved, baseName)
+	return output.err
+}
+
+func (output *testOutput) Delete(baseName string) error {
+	output.deleted = append(output.deleted, baseName)
+	return nil
+}
+
+func (output *testOutput) Optional() bool {
+	return output.optional
+}
+
+func TestSave(t *testing.T) {
+	metaImage := &imageserver.MetaImage{Format: "jpeg"}
+	working := &testOutput{}
+	optional := &testOutput{optional: true}
+	saved, err := imageupload.Save(metaImage, "id", []imageserver.Output{working, optional})
+	assert.Nil(t, err)
+	assert.True(t, saved)
+	assert.DeepEqual(t, working.saved, []string{"id"})
+
+	// Failing optional outputs delete their old files
+	optional.err = errors.New("AVIF not supported")
+	saved, err = imageupload.Save(metaImage, "id", []imageserver.Output{working, optional})
+	assert.Nil(t, err)
+	assert.False(t, saved)
+	assert.DeepEqual(t, optional.deleted, []string{"id"})
+	assert.Equal(t, len(working.deleted), 0)
+
+	// Required outputs report errors but don't stop the other outputs
+	failing := &testOutput{err: errors.New("Disk full")}
+	last := &testOutput{}
+	_, err = imageupload.Save(metaImage, "id", []imageserver.Output{failing, last})
+	assert.Equal(t, err, failing.err)
+	assert.DeepEqual(t, last.saved, []string{"id"})
+}
@@ -0,0 +1,218 @@
+package imageupload
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/jpeg"
+)
+
+// JPEG markers
+const (
+	jpegMarkerSOS  = 0xDA
+	jpegMarkerAPP1 = 0xE1
+	jpegMarkerIPTC = 0xED
+	jpegMarkerCOM  = 0xFE
+)
+
+// exifOrientationTag is the EXIF tag that describes how the camera was rotated.
+const exifOrientationTag = 0x0112
+
+// reencodeQuality is the JPEG quality used when the image has to be rotated.
+const reencodeQuality = 95
+
+// pngMetadataChunks are the PNG chunks that contain metadata like EXIF or text comments.
+var pngMetadataChunks = map[string]bool{
+	"eXIf": true,
+	"tEXt": true,
+	"zTXt": true,
+	"iTXt": true,
+	"tIME": true,
+}
+
+// jpegSegments calls the function for every segment in the JPEG header
+// with the marker, the offset of the segment and its length including the marker.
+// It stops at the start of the image data or when the function returns false
+// and returns false if the header is malformed.
+func jpegSegments(data []byte, segment func(marker byte, offset int, length int) bool) bool {
+	offset := 2
+
+	for offset+4 <= len(data) && data[offset] == 0xFF {
+		marker := data[offset+1]
+
+		if marker == jpegMarkerSOS {
+			return true
+		}
+
+		length := 2 + int(binary.BigEndian.Uint16(data[offset+2:]))
+
+		// Markers without a length like RST are not expected in the header
+		if length < 4 || offset+length > len(data) {
+			return false
+		}
+
+		if !segment(marker, offset, length) {
+			return true
+		}
+
+		offset += length
+	}
+
+	return false
+}
+
+// jpegOrientation returns the EXIF orientation of a JPEG file or 0 if it's not specified.
+func jpegOrientation(data []byte) int {
+	orientation := 0
+
+	jpegSegments(data, func(marker byte, offset int, length int) bool {
+		payload := data[offset+4 : offset+length]
+
+		if marker != jpegMarkerAPP1 || !bytes.HasPrefix(payload, []byte("Exif\x00\x00")) {
+			return true
+		}
+
+		orientation = exifOrientation(payload[6:])
+		return false
+	})
+
+	return orientation
+}
+
+// exifOrientation reads the orientation from the first IFD of the TIFF structure inside EXIF data.
+func exifOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 0
+	}
+
+	var order binary.ByteOrder
+
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 0
+	}
+
+	ifd := int(order.Uint32(tiff[4:]))
+
+	if ifd+2 > len(tiff) {
+		return 0
+	}
+
+	count := int(order.Uint16(tiff[ifd:]))
+
+	for index := 0; index < count; index++ {
+		entry := ifd + 2 + index*12
+
+		if entry+12 > len(tiff) {
+			return 0
+		}
+
+		if order.Uint16(tiff[entry:]) == exifOrientationTag {
+			return int(order.Uint16(tiff[entry+8:]))
+		}
+	}
+
+	return 0
+}
+
+// stripJPEGMetadata removes EXIF, XMP, IPTC and comment segments from a JPEG file.
+// Color profiles and the segments needed for decoding are kept.
+// If the header is malformed, metadata might be left over and false is returned.
+func stripJPEGMetadata(data []byte) ([]byte, bool) {
+	stripped := make([]byte, 0, len(data))
+	stripped = append(stripped, data[:2]...)
+	end := 2
+
+	wellFormed := jpegSegments(data, func(marker byte, offset int, length int) bool {
+		if marker != jpegMarkerAPP1 && marker != jpegMarkerIPTC && marker != jpegMarkerCOM {
+			stripped = append(stripped, data[offset:offset+length]...)
+		}
+
+		end = offset + length
+		return true
+	})
+
+	return append(stripped, data[end:]...), wellFormed
+}
+
+// stripPNGMetadata removes EXIF and text chunks from a PNG file.
+func stripPNGMetadata(data []byte) []byte {
+	const signatureLength = 8
+	stripped := make([]byte, 0, len(data))
+	stripped = append(stripped, data[:signatureLength]...)
+	offset := signatureLength
+
+	// Each chunk consists of length, type, data and checksum
+	for offset+12 <= len(data) {
+		length := 12 + int(binary.BigEndian.Uint32(data[offset:]))
+
+		if length < 12 || offset+length > len(data) {
+			break
+		}
+
+		if !pngMetadataChunks[string(data[offset+4:offset+8])] {
+			stripped = append(stripped, data[offset:offset+length]...)
+		}
+
+		offset += length
+	}
+
+	return append(stripped, data[offset:]...)
+}
+
+// applyOrientation rotates and flips the image according to the EXIF orientation.
+func applyOrientation(img image.Image, orientation int) image.Image {
+	bounds := img.Bounds()
+	width := bounds.Dx()
+	height := bounds.Dy()
+	rotated := orientation >= 5 && orientation <= 8
+
+	if rotated {
+		width, height = height, width
+	}
+
+	result := image.NewRGBA(image.Rect(0, 0, width, height))
+
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			srcX, srcY := orientedSource(x, y, bounds.Dx(), bounds.Dy(), orientation)
+			result.Set(x, y, img.At(bounds.Min.X+srcX, bounds.Min.Y+srcY))
+		}
+	}
+
+	return result
+}
+
+// orientedSource returns the pixel position in the original image
+// for the given position in the correctly oriented image.
+func orientedSource(x int, y int, width int, height int, orientation int) (int, int) {
+	switch orientation {
+	case 2:
+		return width - 1 - x, y
+	case 3:
+		return width - 1 - x, height - 1 - y
+	case 4:
+		return x, height - 1 - y
+	case 5:
+		return y, x
+	case 6:
+		return y, height - 1 - x
+	case 7:
+		return width - 1 - y, height - 1 - x
+	case 8:
+		return width - 1 - y, x
+	default:
+		return x, y
+	}
+}
+
+// encodeJPEG encodes the image as a JPEG file without metadata.
+func encodeJPEG(img image.Image) ([]byte, error) {
+	buffer := bytes.Buffer{}
+	err := jpeg.Encode(&buffer, img, &jpeg.Options{Quality: reencodeQuality})
+	return buffer.Bytes(), err
+}
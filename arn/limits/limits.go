@@ -4,4 +4,6 @@ const (
 	DefaultTextMaxLength     = 100
 	DefaultTextAreaMaxLength = 20000
 	PostMinCharacters        = 5
+	ConversationParticipants = 10
+	MessagesPerHour          = 30
 )
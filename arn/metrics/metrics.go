@@ -0,0 +1,445 @@
+// Package metrics implements counters, histograms and gauges
+// that can be exported in the Prometheus text format.
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Metric kinds
+const (
+	KindCounter   = "counter"
+	KindGauge     = "gauge"
+	KindHistogram = "histogram"
+)
+
+// DefaultBuckets are the histogram buckets for request and job durations in seconds.
+var DefaultBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// Metric is a named collection of samples.
+type Metric interface {
+	Name() string
+	write(writer *bufio.Writer)
+}
+
+// registry contains all metrics that have been created.
+var registry = struct {
+	sync.Mutex
+	metrics map[string]Metric
+}{
+	metrics: map[string]Metric{},
+}
+
+// register adds the metric to the registry.
+// Metric names must be unique because they are defined once at package level.
+func register(metric Metric) {
+	registry.Lock()
+	defer registry.Unlock()
+
+	if _, exists := registry.metrics[metric.Name()]; exists {
+		panic("Metric has been registered twice: " + metric.Name())
+	}
+
+	registry.metrics[metric.Name()] = metric
+}
+
+// Write writes all metrics in the Prometheus text format.
+func Write(out io.Writer) error {
+	registry.Lock()
+	metrics := make([]Metric, 0, len(registry.metrics))
+
+	for _, metric := range registry.metrics {
+		metrics = append(metrics, metric)
+	}
+
+	registry.Unlock()
+
+	sort.Slice(metrics, func(i, j int) bool {
+		return metrics[i].Name() < metrics[j].Name()
+	})
+
+	writer := bufio.NewWriter(out)
+
+	for _, metric := range metrics {
+		metric.write(writer)
+	}
+
+	return writer.Flush()
+}
+
+// Sample is a single value with the values of its labels.
+type Sample struct {
+	LabelValues []string
+	Value       float64
+}
+
+// family is the common part of all metrics.
+type family struct {
+	name       string
+	help       string
+	kind       string
+	labelNames []string
+}
+
+// Name returns the name of the metric.
+func (family *family) Name() string {
+	return family.name
+}
+
+// writeHeader writes the help text and the type of the metric.
+func (family *family) writeHeader(writer *bufio.Writer) {
+	fmt.Fprintf(writer, "# HELP %s %s\n", family.name, strings.Replace(family.help, "\n", " ", -1))
+	fmt.Fprintf(writer, "# TYPE %s %s\n", family.name, family.kind)
+}
+
+// writeSample writes a single line with the given name suffix, labels and value.
+func (family *family) writeSample(writer *bufio.Writer, suffix string, labelValues []string, extraName string, extraValue string, value float64) {
+	writer.WriteString(family.name)
+	writer.WriteString(suffix)
+
+	if len(labelValues) > 0 || extraName != "" {
+		writer.WriteByte('{')
+
+		for index, labelValue := range labelValues {
+			if index > 0 {
+				writer.WriteByte(',')
+			}
+
+			writeLabel(writer, family.labelNames[index], labelValue)
+		}
+
+		if extraName != "" {
+			if len(labelValues) > 0 {
+				writer.WriteByte(',')
+			}
+
+			writeLabel(writer, extraName, extraValue)
+		}
+
+		writer.WriteByte('}')
+	}
+
+	writer.WriteByte(' ')
+	writer.WriteString(formatValue(value))
+	writer.WriteByte('\n')
+}
+
+// checkLabels makes sure the label values match the label names of the metric.
+func (family *family) checkLabels(labelValues []string) {
+	if len(labelValues) != len(family.labelNames) {
+		panic(fmt.Sprintf("Metric %s expects %d label values, got %d", family.name, len(family.labelNames), len(labelValues)))
+	}
+}
+
+// Counter is a value that can only increase.
+type Counter struct {
+	family
+	mutex  sync.Mutex
+	values map[string]*Sample
+}
+
+// NewCounter creates and registers a new counter.
+func NewCounter(name string, help string, labelNames ...string) *Counter {
+	counter := &Counter{
+		family: family{
+			name:       name,
+			help:       help,
+			kind:       KindCounter,
+			labelNames: labelNames,
+		},
+		values: map[string]*Sample{},
+	}
+
+	register(counter)
+	return counter
+}
+
+// Inc increases the counter for the given label values by one.
+func (counter *Counter) Inc(labelValues ...string) {
+	counter.Add(1, labelValues...)
+}
+
+// Add increases the counter for the given label values.
+func (counter *Counter) Add(value float64, labelValues ...string) {
+	counter.checkLabels(labelValues)
+
+	counter.mutex.Lock()
+	defer counter.mutex.Unlock()
+
+	key := labelKey(labelValues)
+	sample := counter.values[key]
+
+	if sample == nil {
+		sample = &Sample{LabelValues: append([]string(nil), labelValues...)}
+		counter.values[key] = sample
+	}
+
+	sample.Value += value
+}
+
+// Value returns the current value for the given label values.
+func (counter *Counter) Value(labelValues ...string) float64 {
+	counter.mutex.Lock()
+	defer counter.mutex.Unlock()
+
+	sample := counter.values[labelKey(labelValues)]
+
+	if sample == nil {
+		return 0
+	}
+
+	return sample.Value
+}
+
+// Samples returns a copy of all values sorted by their labels.
+func (counter *Counter) Samples() []Sample {
+	counter.mutex.Lock()
+	samples := make([]Sample, 0, len(counter.values))
+
+	for _, sample := range counter.values {
+		samples = append(samples, *sample)
+	}
+
+	counter.mutex.Unlock()
+
+	sortSamples(samples)
+	return samples
+}
+
+// write writes the counter in the Prometheus text format.
+func (counter *Counter) write(writer *bufio.Writer) {
+	counter.writeHeader(writer)
+
+	for _, sample := range counter.Samples() {
+		counter.writeSample(writer, "", sample.LabelValues, "", "", sample.Value)
+	}
+}
+
+// Func is a metric whose samples are collected each time the metrics are requested.
+type Func struct {
+	family
+	collect func() []Sample
+}
+
+// NewGaugeFunc creates and registers a gauge that calls the collect function to read its values.
+func NewGaugeFunc(name string, help string, collect func() []Sample, labelNames ...string) *Func {
+	return newFunc(KindGauge, name, help, collect, labelNames)
+}
+
+// NewCounterFunc creates and registers a counter that calls the collect function to read its values.
+// This is useful for counters that are stored somewhere else, e.g. in the database.
+func NewCounterFunc(name string, help string, collect func() []Sample, labelNames ...string) *Func {
+	return newFunc(KindCounter, name, help, collect, labelNames)
+}
+
+// newFunc creates and registers a function based metric.
+func newFunc(kind string, name string, help string, collect func() []Sample, labelNames []string) *Func {
+	metric := &Func{
+		family: family{
+			name:       name,
+			help:       help,
+			kind:       kind,
+			labelNames: labelNames,
+		},
+		collect: collect,
+	}
+
+	register(metric)
+	return metric
+}
+
+// Samples returns the current values sorted by their labels.
+func (metric *Func) Samples() []Sample {
+	samples := metric.collect()
+
+	for _, sample := range samples {
+		metric.checkLabels(sample.LabelValues)
+	}
+
+	sortSamples(samples)
+	return samples
+}
+
+// write writes the metric in the Prometheus text format.
+func (metric *Func) write(writer *bufio.Writer) {
+	metric.writeHeader(writer)
+
+	for _, sample := range metric.Samples() {
+		metric.writeSample(writer, "", sample.LabelValues, "", "", sample.Value)
+	}
+}
+
+// Histogram counts observations like request durations in buckets.
+type Histogram struct {
+	family
+	buckets []float64
+	mutex   sync.Mutex
+	series  map[string]*HistogramSeries
+}
+
+// HistogramSeries contains the observations for one set of label values.
+type HistogramSeries struct {
+	LabelValues []string
+	Count       uint64
+	Sum         float64
+
+	// BucketCounts contains the number of observations
+	// less than or equal to the bucket bounds at the same index.
+	BucketCounts []uint64
+	Buckets      []float64
+}
+
+// NewHistogram creates and registers a new histogram.
+// The buckets are the upper bounds and must be sorted in increasing order.
+func NewHistogram(name string, help string, buckets []float64, labelNames ...string) *Histogram {
+	histogram := &Histogram{
+		family: family{
+			name:       name,
+			help:       help,
+			kind:       KindHistogram,
+			labelNames: labelNames,
+		},
+		buckets: buckets,
+		series:  map[string]*HistogramSeries{},
+	}
+
+	register(histogram)
+	return histogram
+}
+
+// Observe adds a single observation for the given label values.
+func (histogram *Histogram) Observe(value float64, labelValues ...string) {
+	histogram.checkLabels(labelValues)
+
+	histogram.mutex.Lock()
+	defer histogram.mutex.Unlock()
+
+	key := labelKey(labelValues)
+	series := histogram.series[key]
+
+	if series == nil {
+		series = &HistogramSeries{
+			LabelValues:  append([]string(nil), labelValues...),
+			BucketCounts: make([]uint64, len(histogram.buckets)),
+			Buckets:      histogram.buckets,
+		}
+
+		histogram.series[key] = series
+	}
+
+	series.Count++
+	series.Sum += value
+
+	for index, bound := range histogram.buckets {
+		if value <= bound {
+			series.BucketCounts[index]++
+		}
+	}
+}
+
+// Series returns a copy of all series sorted by their labels.
+func (histogram *Histogram) Series() []*HistogramSeries {
+	histogram.mutex.Lock()
+	all := make([]*HistogramSeries, 0, len(histogram.series))
+
+	for _, series := range histogram.series {
+		copied := *series
+		copied.BucketCounts = append([]uint64(nil), series.BucketCounts...)
+		all = append(all, &copied)
+	}
+
+	histogram.mutex.Unlock()
+
+	sort.Slice(all, func(i, j int) bool {
+		return labelKey(all[i].LabelValues) < labelKey(all[j].LabelValues)
+	})
+
+	return all
+}
+
+// write writes the histogram in the Prometheus text format.
+func (histogram *Histogram) write(writer *bufio.Writer) {
+	histogram.writeHeader(writer)
+
+	for _, series := range histogram.Series() {
+		for index, bound := range series.Buckets {
+			histogram.writeSample(writer, "_bucket", series.LabelValues, "le", formatValue(bound), float64(series.BucketCounts[index]))
+		}
+
+		histogram.writeSample(writer, "_bucket", series.LabelValues, "le", "+Inf", float64(series.Count))
+		histogram.writeSample(writer, "_sum", series.LabelValues, "", "", series.Sum)
+		histogram.writeSample(writer, "_count", series.LabelValues, "", "", float64(series.Count))
+	}
+}
+
+// Average returns the mean of all observations.
+func (series *HistogramSeries) Average() float64 {
+	if series.Count == 0 {
+		return 0
+	}
+
+	return series.Sum / float64(series.Count)
+}
+
+// Quantile estimates the given quantile (0 to 1) by returning the upper bound
+// of the first bucket that contains enough observations.
+// If the quantile lies above the largest bucket, +Inf is returned.
+func (series *HistogramSeries) Quantile(quantile float64) float64 {
+	if series.Count == 0 {
+		return 0
+	}
+
+	rank := uint64(math.Ceil(quantile * float64(series.Count)))
+
+	for index, count := range series.BucketCounts {
+		if count >= rank {
+			return series.Buckets[index]
+		}
+	}
+
+	return math.Inf(1)
+}
+
+// labelKey joins the label values to a map key.
+func labelKey(labelValues []string) string {
+	return strings.Join(labelValues, "\xff")
+}
+
+// sortSamples sorts the samples by their label values.
+func sortSamples(samples []Sample) {
+	sort.Slice(samples, func(i, j int) bool {
+		return labelKey(samples[i].LabelValues) < labelKey(samples[j].LabelValues)
+	})
+}
+
+// writeLabel writes a label with an escaped value.
+func writeLabel(writer *bufio.Writer, name string, value string) {
+	writer.WriteString(name)
+	writer.WriteString(`="`)
+	writer.WriteString(labelValueEscaper.Replace(value))
+	writer.WriteByte('"')
+}
+
+// labelValueEscaper escapes label values as defined in the Prometheus text format.
+var labelValueEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// formatValue formats a sample value.
+func formatValue(value float64) string {
+	switch {
+	case math.IsInf(value, 1):
+		return "+Inf"
+	case math.IsInf(value, -1):
+		return "-Inf"
+	case math.IsNaN(value):
+		return "NaN"
+	default:
+		return strconv.FormatFloat(value, 'g', -1, 64)
+	}
+}
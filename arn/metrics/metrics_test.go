@@ -0,0 +1,64 @@
+package metrics_test
+
+import (
+	"bytes"
+	"math"
+	"testing"
+
+	"github.com/akyoto/assert"
+	"github.com/animenotifier/notify.moe/arn/metrics"
+)
+
+func TestCounter(t *testing.T) {
+	counter := metrics.NewCounter("test_requests_total", "Number of test requests.", "route")
+	counter.Inc("/anime/:id")
+	counter.Inc("/anime/:id")
+	counter.Add(3, "/")
+
+	assert.Equal(t, counter.Value("/anime/:id"), 2.0)
+	assert.Equal(t, counter.Value("/"), 3.0)
+	assert.Equal(t, counter.Value("/missing"), 0.0)
+	assert.Equal(t, len(counter.Samples()), 2)
+}
+
+func TestHistogram(t *testing.T) {
+	histogram := metrics.NewHistogram("test_duration_seconds", "Duration of test requests.", []float64{0.1, 1}, "route")
+	histogram.Observe(0.05, "/")
+	histogram.Observe(0.5, "/")
+	histogram.Observe(2, "/")
+
+	series := histogram.Series()
+	assert.Equal(t, len(series), 1)
+	assert.Equal(t, series[0].Count, uint64(3))
+	assert.DeepEqual(t, series[0].BucketCounts, []uint64{1, 2})
+	assert.Equal(t, series[0].Quantile(0.5), 1.0)
+	assert.True(t, math.IsInf(series[0].Quantile(1), 1))
+	assert.True(t, math.Abs(series[0].Average()-0.85) < 0.0001)
+}
+
+func TestWrite(t *testing.T) {
+	counter := metrics.NewCounter("test_write_total", "Written \"samples\".", "label")
+	counter.Inc(`quote"d`)
+
+	metrics.NewGaugeFunc("test_write_objects", "Number of objects.", func() []metrics.Sample {
+		return []metrics.Sample{
+			{LabelValues: []string{"Anime"}, Value: 42},
+		}
+	}, "type")
+
+	histogram := metrics.NewHistogram("test_write_seconds", "Durations.", []float64{1})
+	histogram.Observe(0.5)
+
+	buffer := bytes.Buffer{}
+	assert.Nil(t, metrics.Write(&buffer))
+	output := buffer.String()
+
+	assert.Contains(t, output, "# TYPE test_write_total counter\n")
+	assert.Contains(t, output, `test_write_total{label="quote\"d"} 1`+"\n")
+	assert.Contains(t, output, "# TYPE test_write_objects gauge\n")
+	assert.Contains(t, output, `test_write_objects{type="Anime"} 42`+"\n")
+	assert.Contains(t, output, `test_write_seconds_bucket{le="1"} 1`+"\n")
+	assert.Contains(t, output, `test_write_seconds_bucket{le="+Inf"} 1`+"\n")
+	assert.Contains(t, output, "test_write_seconds_sum 0.5\n")
+	assert.Contains(t, output, "test_write_seconds_count 1\n")
+}
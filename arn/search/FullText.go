@@ -0,0 +1,189 @@
+package search
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/aerogo/flow"
+	"github.com/animenotifier/notify.moe/arn"
+)
+
+// IndexRefreshInterval defines how often the full-text indexes are rebuilt.
+const IndexRefreshInterval = 15 * time.Minute
+
+// Weights of the indexed fields.
+const (
+	titleWeight       = 3.0
+	synonymWeight     = 2.0
+	descriptionWeight = 1.0
+)
+
+// FullTextResults contains the results of a full-text search split by category.
+type FullTextResults struct {
+	Anime      []*arn.Anime
+	Characters []*arn.Character
+	Threads    []*arn.Thread
+	Users      []*arn.User
+}
+
+// fullTextIndexes are the inverted indexes used by the full-text search.
+type fullTextIndexes struct {
+	anime      *Index
+	characters *Index
+	threads    *Index
+	users      *Index
+	created    time.Time
+}
+
+var (
+	indexes         *fullTextIndexes
+	indexesMutex    sync.Mutex
+	indexesBuilding bool
+)
+
+// FullText searches anime titles and summaries, character names,
+// forum threads and user nicknames using the full-text indexes.
+func FullText(term string, maxAnime, maxCharacters, maxThreads, maxUsers int) *FullTextResults {
+	results := &FullTextResults{}
+
+	if term == "" || len(term) > MaxSearchTermLength {
+		return results
+	}
+
+	current := currentIndexes()
+
+	for _, id := range current.anime.Search(term, maxAnime) {
+		anime, err := arn.GetAnime(id)
+
+		if err == nil {
+			results.Anime = append(results.Anime, anime)
+		}
+	}
+
+	for _, id := range current.characters.Search(term, maxCharacters) {
+		character, err := arn.GetCharacter(id)
+
+		if err == nil {
+			results.Characters = append(results.Characters, character)
+		}
+	}
+
+	for _, id := range current.threads.Search(term, maxThreads) {
+		thread, err := arn.GetThread(id)
+
+		if err == nil {
+			results.Threads = append(results.Threads, thread)
+		}
+	}
+
+	for _, id := range current.users.Search(term, maxUsers) {
+		user, err := arn.GetUser(id)
+
+		if err == nil {
+			results.Users = append(results.Users, user)
+		}
+	}
+
+	return results
+}
+
+// currentIndexes returns the full-text indexes.
+// The first call builds them, outdated indexes are rebuilt in the background.
+func currentIndexes() *fullTextIndexes {
+	indexesMutex.Lock()
+	defer indexesMutex.Unlock()
+
+	if indexes == nil {
+		indexes = buildIndexes()
+		return indexes
+	}
+
+	if time.Since(indexes.created) > IndexRefreshInterval && !indexesBuilding {
+		indexesBuilding = true
+
+		go func() {
+			rebuilt := buildIndexes()
+
+			indexesMutex.Lock()
+			indexes = rebuilt
+			indexesBuilding = false
+			indexesMutex.Unlock()
+		}()
+	}
+
+	return indexes
+}
+
+// buildIndexes creates the full-text indexes from the database.
+func buildIndexes() *fullTextIndexes {
+	built := &fullTextIndexes{
+		anime:      NewIndex(),
+		characters: NewIndex(),
+		threads:    NewIndex(),
+		users:      NewIndex(),
+		created:    time.Now(),
+	}
+
+	flow.Parallel(func() {
+		for anime := range arn.StreamAnime() {
+			built.anime.Add(anime.ID, anime.Title.Canonical, titleWeight)
+			built.anime.Add(anime.ID, anime.Title.Romaji, titleWeight)
+			built.anime.Add(anime.ID, anime.Title.English, titleWeight)
+			built.anime.Add(anime.ID, anime.Title.Japanese, titleWeight)
+
+			for _, synonym := range anime.Title.Synonyms {
+				built.anime.Add(anime.ID, synonym, synonymWeight)
+			}
+
+			built.anime.Add(anime.ID, anime.Summary, descriptionWeight)
+			built.anime.Boost(anime.ID, popularityBoost(anime.Popularity.Total()))
+		}
+
+		built.anime.Finalize()
+	}, func() {
+		for character := range arn.StreamCharacters() {
+			built.characters.Add(character.ID, character.Name.Canonical, titleWeight)
+			built.characters.Add(character.ID, character.Name.English, titleWeight)
+			built.characters.Add(character.ID, character.Name.Japanese, titleWeight)
+
+			for _, synonym := range character.Name.Synonyms {
+				built.characters.Add(character.ID, synonym, synonymWeight)
+			}
+
+			built.characters.Boost(character.ID, popularityBoost(len(character.Likes)))
+		}
+
+		built.characters.Finalize()
+	}, func() {
+		for thread := range arn.StreamThreads() {
+			if thread.IsSoftDeleted() {
+				continue
+			}
+
+			built.threads.Add(thread.ID, thread.Title, titleWeight)
+			built.threads.Add(thread.ID, thread.Text, descriptionWeight)
+			built.threads.Boost(thread.ID, popularityBoost(thread.CountLikes()))
+		}
+
+		built.threads.Finalize()
+	}, func() {
+		for user := range arn.StreamUsers() {
+			if user.Nick == "" || user.IsHidden() {
+				continue
+			}
+
+			built.users.Add(user.ID, user.Nick, titleWeight)
+		}
+
+		built.users.Finalize()
+	})
+
+	return built
+}
+
+// popularityBoost converts a popularity count to a small score bonus
+// that decides the order of results with a similar text score.
+func popularityBoost(count int) float64 {
+	return math.Log1p(float64(count)) * 0.1
+}
@@ -0,0 +1,187 @@
+package search
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/animenotifier/notify.moe/arn/stringutils"
+	"github.com/xrash/smetrics"
+)
+
+// Score factors for the different kinds of word matches.
+const (
+	exactMatchScore  = 1.0
+	prefixMatchScore = 0.6
+	typoMatchScore   = 0.4
+)
+
+// Index is an inverted index that maps each word to the documents containing it.
+type Index struct {
+	postings   map[string]map[string]float64
+	boosts     map[string]float64
+	vocabulary []string
+}
+
+// NewIndex creates an empty index.
+func NewIndex() *Index {
+	return &Index{
+		postings: map[string]map[string]float64{},
+		boosts:   map[string]float64{},
+	}
+}
+
+// Add indexes the words of the text for the given document.
+// The weight defines how important the text is, e.g. titles weigh more than descriptions.
+func (index *Index) Add(id string, text string, weight float64) {
+	for _, word := range Tokenize(text) {
+		documents, exists := index.postings[word]
+
+		if !exists {
+			documents = map[string]float64{}
+			index.postings[word] = documents
+			index.vocabulary = append(index.vocabulary, word)
+		}
+
+		if weight > documents[id] {
+			documents[id] = weight
+		}
+	}
+}
+
+// Boost increases the score of a document in every search, e.g. for popular documents.
+func (index *Index) Boost(id string, boost float64) {
+	index.boosts[id] = boost
+}
+
+// Finalize needs to be called after all documents have been added.
+func (index *Index) Finalize() {
+	sort.Strings(index.vocabulary)
+}
+
+// Search returns the IDs of the documents containing all words of the term, best results first.
+// Words that are prefixes or misspellings of indexed words are matched with a lower score.
+func (index *Index) Search(term string, maxLength int) []string {
+	words := Tokenize(term)
+
+	if len(words) == 0 || maxLength <= 0 {
+		return nil
+	}
+
+	var scores map[string]float64
+
+	for _, word := range words {
+		wordScores := index.searchWord(word)
+
+		if scores == nil {
+			scores = wordScores
+			continue
+		}
+
+		for id, score := range scores {
+			wordScore, found := wordScores[id]
+
+			if !found {
+				delete(scores, id)
+				continue
+			}
+
+			scores[id] = score + wordScore
+		}
+	}
+
+	ids := make([]string, 0, len(scores))
+
+	for id, score := range scores {
+		scores[id] = score + index.boosts[id]
+		ids = append(ids, id)
+	}
+
+	sort.Slice(ids, func(i, j int) bool {
+		if scores[ids[i]] == scores[ids[j]] {
+			return ids[i] < ids[j]
+		}
+
+		return scores[ids[i]] > scores[ids[j]]
+	})
+
+	if len(ids) > maxLength {
+		ids = ids[:maxLength]
+	}
+
+	return ids
+}
+
+// searchWord returns the score of every document matching a single word.
+func (index *Index) searchWord(word string) map[string]float64 {
+	scores := map[string]float64{}
+
+	add := func(indexed string, factor float64) {
+		for id, weight := range index.postings[indexed] {
+			score := weight * factor
+
+			if score > scores[id] {
+				scores[id] = score
+			}
+		}
+	}
+
+	add(word, exactMatchScore)
+
+	// Prefixes
+	start := sort.SearchStrings(index.vocabulary, word)
+
+	for _, indexed := range index.vocabulary[start:] {
+		if !strings.HasPrefix(indexed, word) {
+			break
+		}
+
+		if indexed != word {
+			add(indexed, prefixMatchScore)
+		}
+	}
+
+	// Typos
+	maxTypos := allowedTypos(word)
+
+	if maxTypos == 0 {
+		return scores
+	}
+
+	for _, indexed := range index.vocabulary {
+		lengthDifference := len(indexed) - len(word)
+
+		if lengthDifference > maxTypos || -lengthDifference > maxTypos || indexed == word {
+			continue
+		}
+
+		distance := smetrics.WagnerFischer(word, indexed, 1, 1, 1)
+
+		if distance <= maxTypos {
+			add(indexed, typoMatchScore/float64(distance))
+		}
+	}
+
+	return scores
+}
+
+// allowedTypos returns the number of typos tolerated in a word.
+// Words with Japanese characters need to match exactly.
+func allowedTypos(word string) int {
+	if stringutils.ContainsUnicodeLetters(word) {
+		return 0
+	}
+
+	switch {
+	case len(word) >= 8:
+		return 2
+	case len(word) >= 4:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Tokenize splits the text into lowercase words without special characters.
+func Tokenize(text string) []string {
+	return strings.Fields(strings.ToLower(stringutils.RemoveSpecialCharacters(text)))
+}
@@ -0,0 +1,38 @@
+package search_test
+
+import (
+	"testing"
+
+	"github.com/akyoto/assert"
+	"github.com/animenotifier/notify.moe/arn/search"
+)
+
+func TestIndex(t *testing.T) {
+	index := search.NewIndex()
+	index.Add("bleach", "Bleach", 3)
+	index.Add("bleach", "A teenager gains the powers of a Soul Reaper.", 1)
+	index.Add("soul-eater", "Soul Eater", 3)
+	index.Add("fmab", "Fullmetal Alchemist: Brotherhood", 3)
+	index.Boost("soul-eater", 0.1)
+	index.Finalize()
+
+	// Titles rank higher than descriptions
+	assert.DeepEqual(t, index.Search("soul", 10), []string{"soul-eater", "bleach"})
+
+	// All words need to match
+	assert.DeepEqual(t, index.Search("soul reaper", 10), []string{"bleach"})
+
+	// Prefixes
+	assert.DeepEqual(t, index.Search("full", 10), []string{"fmab"})
+
+	// Typos
+	assert.DeepEqual(t, index.Search("fulmetal alchemist", 10), []string{"fmab"})
+	assert.DeepEqual(t, index.Search("bleah", 10), []string{"bleach"})
+
+	// Short words need to match exactly
+	assert.Equal(t, len(index.Search("sol", 10)), 0)
+
+	// Limit
+	assert.Equal(t, len(index.Search("soul", 1)), 1)
+	assert.Equal(t, len(index.Search("", 10)), 0)
+}
@@ -14,6 +14,10 @@ func Threads(originalTerm string, maxLength int) []*arn.Thread {
 	results := make([]*arn.Thread, 0, maxLength)
 
 	for thread := range arn.StreamThreads() {
+		if thread.IsSoftDeleted() {
+			continue
+		}
+
 		if thread.ID == originalTerm {
 			return []*arn.Thread{thread}
 		}
@@ -14,6 +14,10 @@ func Users(originalTerm string, maxLength int) []*arn.User {
 	results := make([]*Result, 0, maxLength)
 
 	for user := range arn.StreamUsers() {
+		if user.IsHidden() {
+			continue
+		}
+
 		if user.ID == originalTerm {
 			return []*arn.User{user}
 		}
@@ -19,7 +19,7 @@ var (
 	CSS           string
 	ServiceWorker string
 	Organization  string
-	Domain        = "notify.moe"
+	Domain        = arn.Domain
 )
 
 // load loads all the necessary assets into memory.
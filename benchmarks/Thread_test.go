@@ -10,7 +10,7 @@ import (
 func BenchmarkRenderThread(b *testing.B) {
 	thread, _ := arn.GetThread("HJgS7c2K")
 	thread.HTML() // Pre-render markdown
-	replies := thread.Posts()
+	replies := thread.PostsOnPage(1)
 
 	for _, reply := range replies {
 		reply.HTML() // Pre-render markdown
@@ -20,6 +20,6 @@ func BenchmarkRenderThread(b *testing.B) {
 	b.ResetTimer()
 
 	for i := 0; i < b.N; i++ {
-		components.Thread(thread, nil)
+		components.Thread(thread, replies, 1, thread.PageCount(), nil)
 	}
 }
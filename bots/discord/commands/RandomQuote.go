@@ -15,7 +15,7 @@ func RandomQuote(s *discordgo.Session, msg *discordgo.MessageCreate) bool {
 	}
 
 	allQuotes := arn.FilterQuotes(func(quote *arn.Quote) bool {
-		return !quote.IsDraft && quote.IsValid()
+		return quote.IsPublic() && quote.IsValid()
 	})
 
 	quote := allQuotes[rand.Intn(len(allQuotes))]
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"time"
+
+	"github.com/aerogo/log"
+	"github.com/animenotifier/notify.moe/arn"
+	"github.com/animenotifier/notify.moe/utils/cron"
+)
+
+const (
+	// defaultTimeout is the time after which a job process is killed.
+	// It's also the duration of the job lock.
+	defaultTimeout = 1 * time.Hour
+
+	// Failed runs are retried after the minimum delay which doubles with each attempt.
+	minRetryDelay = 1 * time.Minute
+	maxRetryDelay = 30 * time.Minute
+)
+
+// errLocked is returned when a different runner is already executing the job.
+var errLocked = errors.New("Job is locked by a different runner")
+
+// Job is a background job that is run by the scheduler.
+type Job struct {
+	Name     string
+	Schedule string
+	Retries  int
+	Timeout  time.Duration
+
+	schedule     cron.Schedule
+	executable   string
+	owner        string
+	color        func(...interface{}) string
+	log          *log.Log
+	schedulerLog *log.Log
+}
+
+// Loop runs the job according to its schedule.
+func (job *Job) Loop() {
+	for {
+		next := job.schedule.Next(time.Now().UTC())
+
+		if next.IsZero() {
+			job.schedulerLog.Error("Schedule of %s never matches: %s", job.color(job.Name), job.Schedule)
+			return
+		}
+
+		arn.ScheduleJob(job.Name, job.Schedule, next, 0)
+		time.Sleep(time.Until(next))
+		job.RunWithRetries()
+	}
+}
+
+// RunWithRetries runs the job and retries it with an increasing delay if it fails.
+func (job *Job) RunWithRetries() {
+	for attempt := 0; ; attempt++ {
+		err := job.Run()
+
+		if err == nil || err == errLocked || attempt >= job.Retries {
+			return
+		}
+
+		delay := retryDelay(attempt)
+		job.schedulerLog.Info("Retrying %s in %v (attempt %d of %d)", job.color(job.Name), delay, attempt+1, job.Retries)
+		arn.ScheduleJob(job.Name, job.Schedule, time.Now().Add(delay), attempt+1)
+		time.Sleep(delay)
+	}
+}
+
+// Run executes the job process once if no other runner is executing it.
+func (job *Job) Run() error {
+	token, locked := arn.LockJob(job.Name, job.owner, job.Timeout)
+
+	if !locked {
+		job.schedulerLog.Info("Skipping %s because it is already running", job.color(job.Name))
+		return errLocked
+	}
+
+	defer arn.UnlockJob(job.Name, token)
+
+	job.schedulerLog.Info("Starting %s", job.color(job.Name))
+	started := time.Now()
+
+	ctx, cancel := context.WithTimeout(context.Background(), job.Timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, job.executable)
+	cmd.Stdout = job.log
+	cmd.Stderr = job.log
+	err := cmd.Run()
+
+	if err != nil {
+		job.schedulerLog.Error("Job %s exited with error %v", job.color(job.Name), err)
+	}
+
+	// Save the duration for the status page and the metrics of the web server
+	arn.RecordJobRun(job.Name, started, err)
+
+	job.schedulerLog.Info("Finished %s", job.color(job.Name))
+	job.log.Info("--------------------------------------------------------------------------------")
+	return err
+}
+
+// retryDelay returns the waiting time before the retry that follows the given attempt.
+func retryDelay(attempt int) time.Duration {
+	delay := minRetryDelay << uint(attempt)
+
+	if delay > maxRetryDelay || delay <= 0 {
+		return maxRetryDelay
+	}
+
+	return delay
+}
@@ -0,0 +1,25 @@
+package main
+
+import (
+	"time"
+
+	"github.com/akyoto/color"
+	"github.com/animenotifier/notify.moe/arn"
+)
+
+func main() {
+	color.Yellow("Sending airing notifications")
+
+	defer color.Green("Finished.")
+	defer arn.Node.Close()
+
+	now := time.Now().UTC()
+
+	for user := range arn.StreamUsers() {
+		count := user.SendAiringNotifications(now)
+
+		if count > 0 {
+			color.Cyan("%s: %d notifications", user.Nick, count)
+		}
+	}
+}
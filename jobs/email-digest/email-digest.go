@@ -0,0 +1,38 @@
+package main
+
+import (
+	"time"
+
+	"github.com/akyoto/color"
+	"github.com/animenotifier/notify.moe/arn"
+)
+
+func main() {
+	color.Yellow("Sending email digests")
+
+	defer color.Green("Finished.")
+	defer arn.Node.Close()
+
+	now := time.Now().UTC()
+
+	for user := range arn.StreamUsers() {
+		if !user.IsEmailDigestDue(now) {
+			continue
+		}
+
+		notifications := user.EmailDigestNotifications()
+
+		if len(notifications) == 0 {
+			continue
+		}
+
+		err := user.SendEmailDigest(notifications)
+
+		if err != nil {
+			color.Red("%s: %s", user.Nick, err.Error())
+			continue
+		}
+
+		color.Cyan("%s: %d notifications", user.Nick, len(notifications))
+	}
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"time"
+
+	"github.com/akyoto/color"
+	"github.com/animenotifier/notify.moe/arn"
+)
+
+// maxAge prevents the job from creating threads for the whole back catalogue.
+// Older episodes get their thread when somebody visits the episode page.
+const maxAge = 3 * 24 * time.Hour
+
+func main() {
+	color.Yellow("Creating episode discussion threads")
+
+	defer color.Green("Finished.")
+	defer arn.Node.Close()
+
+	oldest := time.Now().UTC().Add(-maxAge).Format(time.RFC3339)
+
+	for episode := range arn.StreamEpisodes() {
+		if episode.ThreadID != "" || !episode.HasAired() || episode.AiringDate.Start < oldest {
+			continue
+		}
+
+		anime := episode.Anime()
+
+		if anime == nil || anime.IsDraft {
+			continue
+		}
+
+		thread, err := episode.CreateDiscussionThread()
+
+		if err != nil {
+			color.Red("%s: %s", episode.ID, err.Error())
+			continue
+		}
+
+		color.Cyan("%s: %s", episode.ID, thread.Title)
+	}
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"time"
+
+	"github.com/akyoto/color"
+	"github.com/animenotifier/notify.moe/arn"
+)
+
+// daysAhead is the number of upcoming days that get their featured content selected in advance.
+const daysAhead = 2
+
+func main() {
+	color.Yellow("Selecting featured content")
+
+	defer color.Green("Finished.")
+	defer arn.Node.Close()
+
+	today := time.Now().UTC()
+
+	for i := 0; i <= daysAhead; i++ {
+		day := today.AddDate(0, 0, i)
+		date := day.Format(arn.FeaturedContentDateFormat)
+
+		// Keep existing selections, especially pinned ones
+		if _, err := arn.GetFeaturedContent(date); err == nil {
+			continue
+		}
+
+		featured, err := arn.SelectFeaturedContent(day)
+
+		if err != nil {
+			color.Red(err.Error())
+			return
+		}
+
+		featured.Save()
+		color.Cyan("%s: %s, %s, %s", date, featured.AnimeID, featured.CharacterID, featured.QuoteID)
+	}
+}
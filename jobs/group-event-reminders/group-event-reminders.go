@@ -0,0 +1,27 @@
+package main
+
+import (
+	"time"
+
+	"github.com/akyoto/color"
+	"github.com/animenotifier/notify.moe/arn"
+)
+
+func main() {
+	color.Yellow("Sending group event reminders")
+
+	defer color.Green("Finished.")
+	defer arn.Node.Close()
+
+	now := time.Now()
+
+	for event := range arn.StreamGroupEvents() {
+		if !event.NeedsReminder(now) {
+			continue
+		}
+
+		event.Remind()
+		event.Save()
+		color.Cyan("%s: %s", event.ID, event.Title)
+	}
+}
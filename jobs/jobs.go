@@ -3,7 +3,6 @@ package main
 import (
 	"fmt"
 	"os"
-	"os/exec"
 	"os/signal"
 	"path"
 	"syscall"
@@ -12,6 +11,7 @@ import (
 	"github.com/aerogo/log"
 	"github.com/akyoto/color"
 	"github.com/animenotifier/notify.moe/arn"
+	"github.com/animenotifier/notify.moe/utils/cron"
 )
 
 var colorPool = []*color.Color{
@@ -23,14 +23,27 @@ var colorPool = []*color.Color{
 	color.New(color.FgGreen),
 }
 
-var jobs = map[string]time.Duration{
-	"anime-ratings": 10 * time.Minute,
-	"twist":         2 * time.Hour,
-	"refresh-games": 6 * time.Hour,
+// Schedules use the cron syntax in UTC, see utils/cron for details.
+// Jobs depending on external services are retried when they fail.
+var jobs = []*Job{
+	{Name: "airing-notifications", Schedule: "@every 5m"},
+	{Name: "anime-ratings", Schedule: "@every 10m"},
+	{Name: "anilist-sync", Schedule: "@every 30m", Retries: 3},
+	{Name: "email-digest", Schedule: "@hourly", Retries: 3},
+	{Name: "episode-discussions", Schedule: "*/30 * * * *"},
+	{Name: "featured-content", Schedule: "@hourly"},
+	{Name: "group-event-reminders", Schedule: "@every 5m"},
+	{Name: "myanimelist-sync", Schedule: "@every 30m", Retries: 3},
+	{Name: "purge-deleted", Schedule: "30 3 * * *"},
+	{Name: "twist", Schedule: "0 */2 * * *", Retries: 3},
+	{Name: "user-statistics", Schedule: "15 */3 * * *"},
+	{Name: "refresh-games", Schedule: "45 */6 * * *", Retries: 3},
+	{Name: "recommendations", Schedule: "0 4 * * *", Timeout: 3 * time.Hour},
+	{Name: "year-in-review", Schedule: "0 5 * * *", Timeout: 3 * time.Hour},
 }
 
 func main() {
-	// Start all jobs defined in the map above
+	// Start all jobs defined in the list above
 	startJobs()
 
 	// Wait for program termination
@@ -49,52 +62,30 @@ func startJobs() {
 	mainLog.AddWriter(log.File(path.Join(logsPath, "scheduler.log")))
 	schedulerLog := mainLog
 
+	// Locks are owned by this process
+	hostName, _ := os.Hostname()
+	owner := fmt.Sprintf("%s:%d", hostName, os.Getpid())
+
 	// Color index
 	colorIndex := 0
 
 	// Start each job
-	for job, interval := range jobs {
-		jobName := job
-		jobInterval := interval
-		executable := path.Join(arn.Root, "jobs", jobName, jobName)
-		jobColor := colorPool[colorIndex].SprintFunc()
-
-		jobLog := log.New()
-		jobLog.AddWriter(log.File(path.Join(jobLogsPath, jobName+".log")))
-
-		fmt.Printf("Registered job %s for execution every %v\n", jobColor(jobName), interval)
-
-		go func() {
-			ticker := time.NewTicker(jobInterval)
-			defer ticker.Stop()
-
-			for {
-				// Wait for the given interval first
-				<-ticker.C
-
-				// Now start
-				schedulerLog.Info("Starting %s", jobColor(jobName))
-
-				cmd := exec.Command(executable)
-				cmd.Stdout = jobLog
-				cmd.Stderr = jobLog
-
-				err := cmd.Start()
-
-				if err != nil {
-					schedulerLog.Error("Error starting job %s %v", jobColor(jobName), err)
-				}
-
-				err = cmd.Wait()
-
-				if err != nil {
-					schedulerLog.Error("Job %s exited with error %v", jobColor(jobName), err)
-				}
-
-				schedulerLog.Info("Finished %s", jobColor(jobName))
-				jobLog.Info("--------------------------------------------------------------------------------")
-			}
-		}()
+	for _, job := range jobs {
+		job.schedule = cron.MustParse(job.Schedule)
+		job.executable = path.Join(arn.Root, "jobs", job.Name, job.Name)
+		job.owner = owner
+		job.color = colorPool[colorIndex].SprintFunc()
+		job.schedulerLog = schedulerLog
+
+		job.log = log.New()
+		job.log.AddWriter(log.File(path.Join(jobLogsPath, job.Name+".log")))
+
+		if job.Timeout == 0 {
+			job.Timeout = defaultTimeout
+		}
+
+		fmt.Printf("Registered job %s with schedule %s\n", job.color(job.Name), job.Schedule)
+		go job.Loop()
 
 		colorIndex = (colorIndex + 1) % len(colorPool)
 	}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"github.com/akyoto/color"
+	"github.com/animenotifier/notify.moe/arn"
+)
+
+func main() {
+	color.Yellow("Syncing anime lists with MyAnimeList")
+
+	defer color.Green("Finished.")
+	defer arn.Node.Close()
+
+	for user := range arn.StreamUsers() {
+		if !user.Accounts.MyAnimeList.Sync || !user.IsMyAnimeListLinked() {
+			continue
+		}
+
+		changes, err := user.MyAnimeListSyncChanges()
+
+		if err != nil {
+			color.Red("%s: %s", user.Nick, err.Error())
+			continue
+		}
+
+		if len(changes) == 0 {
+			continue
+		}
+
+		err = user.SyncMyAnimeList(changes)
+
+		if err != nil {
+			color.Red("%s: %s", user.Nick, err.Error())
+			continue
+		}
+
+		color.Cyan("%s: %d changes", user.Nick, len(changes))
+	}
+}
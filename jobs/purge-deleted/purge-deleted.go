@@ -0,0 +1,40 @@
+package main
+
+import (
+	"time"
+
+	"github.com/akyoto/color"
+	"github.com/animenotifier/notify.moe/arn"
+)
+
+func main() {
+	color.Yellow("Purging deleted data")
+
+	defer color.Green("Finished.")
+	defer arn.Node.Close()
+
+	now := time.Now()
+
+	// Collect the accounts first because purging modifies other users
+	var purge []*arn.User
+
+	for user := range arn.StreamUsers() {
+		if user.ShouldPurge(now) {
+			purge = append(purge, user)
+		}
+	}
+
+	for _, user := range purge {
+		color.Cyan("Deleting account %s (%s)", user.Nick, user.ID)
+		user.Purge()
+	}
+
+	for animeList := range arn.StreamAnimeLists() {
+		purged := animeList.PurgeTrash(now)
+
+		if purged > 0 {
+			animeList.Save()
+			color.Cyan("%s: %d anime list entries", animeList.UserID, purged)
+		}
+	}
+}
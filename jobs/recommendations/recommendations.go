@@ -0,0 +1,63 @@
+package main
+
+import (
+	"github.com/akyoto/color"
+	"github.com/animenotifier/notify.moe/arn"
+)
+
+func main() {
+	color.Yellow("Calculating anime recommendations")
+
+	defer color.Green("Finished.")
+	defer arn.Node.Close()
+
+	// Find the anime each user liked
+	likes := map[arn.UserID][]arn.AnimeID{}
+	lists := []*arn.AnimeList{}
+
+	for animeList := range arn.StreamAnimeLists() {
+		liked := animeList.LikedAnimeIDs()
+
+		if len(liked) == 0 {
+			continue
+		}
+
+		likes[animeList.UserID] = liked
+		lists = append(lists, animeList)
+	}
+
+	color.Cyan("%d users liked at least one anime", len(likes))
+
+	// Similar anime
+	similar := arn.ComputeSimilarAnime(likes)
+	arn.DB.Clear("SimilarAnime")
+
+	for animeID, items := range similar {
+		similarAnime := &arn.SimilarAnime{
+			AnimeID: animeID,
+			Items:   items,
+		}
+
+		similarAnime.Save()
+	}
+
+	color.Cyan("%d anime have similar anime", len(similar))
+
+	// Personal recommendations
+	updated := arn.DateTimeUTC()
+	arn.DB.Clear("UserRecommendations")
+
+	for _, animeList := range lists {
+		recommendations := &arn.UserRecommendations{
+			UserID:  animeList.UserID,
+			Items:   arn.RecommendAnime(likes[animeList.UserID], similar, animeList.Contains),
+			Updated: updated,
+		}
+
+		if len(recommendations.Items) == 0 {
+			continue
+		}
+
+		recommendations.Save()
+	}
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"time"
+
+	"github.com/akyoto/color"
+	"github.com/animenotifier/notify.moe/arn"
+)
+
+func main() {
+	color.Yellow("Calculating user statistics")
+
+	defer color.Green("Finished.")
+	defer arn.Node.Close()
+
+	// Group the watching activities by user
+	activities := map[arn.UserID][]*arn.ActivityConsumeAnime{}
+
+	for activity := range arn.StreamActivities() {
+		consume, ok := activity.(*arn.ActivityConsumeAnime)
+
+		if !ok {
+			continue
+		}
+
+		activities[consume.CreatedBy] = append(activities[consume.CreatedBy], consume)
+	}
+
+	now := time.Now()
+	count := 0
+
+	for animeList := range arn.StreamAnimeLists() {
+		stats := arn.NewUserStatistics(animeList, activities[animeList.UserID], now)
+		stats.Save()
+		count++
+	}
+
+	color.Cyan("Calculated statistics for %d users", count)
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/akyoto/color"
+	"github.com/animenotifier/notify.moe/arn"
+)
+
+// Reviews are generated for the previous year once it ended.
+// A different year can be passed as an argument.
+func main() {
+	year := time.Now().UTC().Year() - 1
+
+	if len(os.Args) > 1 {
+		var err error
+		year, err = strconv.Atoi(os.Args[1])
+
+		if err != nil {
+			color.Red("Invalid year: %s", os.Args[1])
+			return
+		}
+	}
+
+	color.Yellow("Generating year in review for %d", year)
+
+	defer color.Green("Finished.")
+	defer arn.Node.Close()
+
+	// Group the watching activities by user
+	activities := map[arn.UserID][]*arn.ActivityConsumeAnime{}
+
+	for activity := range arn.StreamActivities() {
+		consume, ok := activity.(*arn.ActivityConsumeAnime)
+
+		if !ok {
+			continue
+		}
+
+		activities[consume.CreatedBy] = append(activities[consume.CreatedBy], consume)
+	}
+
+	count := 0
+
+	for animeList := range arn.StreamAnimeLists() {
+		// Reviews don't change after they have been published
+		_, err := arn.GetYearInReview(animeList.UserID, year)
+
+		if err == nil {
+			continue
+		}
+
+		review := arn.NewYearInReview(animeList, activities[animeList.UserID], year)
+
+		if review.IsEmpty() {
+			continue
+		}
+
+		review.Save()
+		count++
+	}
+
+	color.Cyan("Generated %d reviews", count)
+}
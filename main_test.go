@@ -9,6 +9,7 @@ import (
 	"github.com/akyoto/assert"
 	"github.com/animenotifier/notify.moe/arn"
 	"github.com/animenotifier/notify.moe/server"
+	"github.com/animenotifier/notify.moe/server/middleware"
 	"github.com/animenotifier/notify.moe/utils/routetests"
 )
 
@@ -108,6 +109,56 @@ func TestUsers(t *testing.T) {
 	}
 }
 
+func TestConditionalRequestETag(t *testing.T) {
+	etag := `"abc"`
+
+	// Strong and weak validators use the weak comparison
+	assert.True(t, middleware.IsNotModified(ifNoneMatchHeader(`"abc"`), etag, ""))
+	assert.True(t, middleware.IsNotModified(ifNoneMatchHeader(`W/"abc"`), etag, ""))
+	assert.True(t, middleware.IsNotModified(ifNoneMatchHeader(`"abc"`), `W/"abc"`, ""))
+	assert.False(t, middleware.IsNotModified(ifNoneMatchHeader(`"abd"`), etag, ""))
+
+	// Unquoted ETags sent by older versions
+	assert.True(t, middleware.IsNotModified(ifNoneMatchHeader("abc"), etag, ""))
+
+	// Lists and wildcards
+	assert.True(t, middleware.IsNotModified(ifNoneMatchHeader(`"xyz", W/"abc"`), etag, ""))
+	assert.True(t, middleware.IsNotModified(ifNoneMatchHeader(`"xyz","abc"`), etag, ""))
+	assert.False(t, middleware.IsNotModified(ifNoneMatchHeader(`"xyz", "123"`), etag, ""))
+	assert.True(t, middleware.IsNotModified(ifNoneMatchHeader("*"), etag, ""))
+
+	// Responses without an ETag never match
+	assert.False(t, middleware.IsNotModified(ifNoneMatchHeader("*"), "", ""))
+}
+
+func TestConditionalRequestModifiedSince(t *testing.T) {
+	lastModified := "Mon, 04 Mar 2019 12:00:00 GMT"
+	header := http.Header{}
+
+	header.Set("If-Modified-Since", "Mon, 04 Mar 2019 12:00:00 GMT")
+	assert.True(t, middleware.IsNotModified(header, "", lastModified))
+
+	header.Set("If-Modified-Since", "Tue, 05 Mar 2019 12:00:00 GMT")
+	assert.True(t, middleware.IsNotModified(header, "", lastModified))
+
+	header.Set("If-Modified-Since", "Sun, 03 Mar 2019 12:00:00 GMT")
+	assert.False(t, middleware.IsNotModified(header, "", lastModified))
+
+	// Invalid dates and responses without a Last-Modified date
+	header.Set("If-Modified-Since", "yesterday")
+	assert.False(t, middleware.IsNotModified(header, "", lastModified))
+
+	header.Set("If-Modified-Since", "Tue, 05 Mar 2019 12:00:00 GMT")
+	assert.False(t, middleware.IsNotModified(header, "", ""))
+
+	// If-None-Match takes precedence over If-Modified-Since
+	header.Set("If-None-Match", `"xyz"`)
+	assert.False(t, middleware.IsNotModified(header, `"abc"`, lastModified))
+
+	// No conditional headers
+	assert.False(t, middleware.IsNotModified(http.Header{}, `"abc"`, lastModified))
+}
+
 func fetch(t *testing.T, app http.Handler, route string) {
 	request := httptest.NewRequest("GET", strings.ReplaceAll(route, " ", "%20"), nil)
 	response := httptest.NewRecorder()
@@ -121,3 +172,9 @@ func fetch(t *testing.T, app http.Handler, route string) {
 		t.Fatalf("%s | Wrong status code | %v instead of %v", route, status, http.StatusOK)
 	}
 }
+
+func ifNoneMatchHeader(value string) http.Header {
+	header := http.Header{}
+	header.Set("If-None-Match", value)
+	return header
+}
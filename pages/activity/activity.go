@@ -7,37 +7,56 @@ import (
 
 // Global activity page.
 func Global(ctx aero.Context) error {
-	user := arn.GetUserFromContext(ctx)
-	activities := fetchActivities(user, false)
-	return render(ctx, activities)
+	return render(ctx, false)
 }
 
 // Followed activity page.
 func Followed(ctx aero.Context) error {
-	user := arn.GetUserFromContext(ctx)
-	activities := fetchActivities(user, true)
-	return render(ctx, activities)
+	return render(ctx, true)
 }
 
 // fetchActivities filters the activities by the given filters.
 func fetchActivities(user *arn.User, followedOnly bool) []arn.Activity {
-	activities := arn.FilterActivityCreates(func(activity arn.Activity) bool {
+	activities := arn.FilterActivities(func(activity arn.Activity) bool {
 		if followedOnly && user != nil && !user.IsFollowing(activity.GetCreatedBy()) {
 			return false
 		}
 
+		if user != nil && user.IsBlocking(activity.GetCreatedBy()) {
+			return false
+		}
+
 		if !activity.Creator().HasNick() {
 			return false
 		}
 
-		obj := activity.(*arn.ActivityCreate).Object()
+		switch activity := activity.(type) {
+		case *arn.ActivityCreate:
+			obj := activity.Object()
+
+			if obj == nil {
+				return false
+			}
+
+			draft, isDraftable := obj.(arn.Draftable)
+			return !isDraftable || !draft.GetIsDraft()
 
-		if obj == nil {
+		case *arn.ActivityConsumeAnime:
+			if activity.Anime() == nil {
+				return false
+			}
+
+			// Progress on private list entries is only visible to the owner
+			if user != nil && user.ID == activity.CreatedBy {
+				return true
+			}
+
+			item := activity.Creator().AnimeList().Find(activity.AnimeID)
+			return item == nil || !item.Private
+
+		default:
 			return false
 		}
-
-		draft, isDraftable := obj.(arn.Draftable)
-		return !isDraftable || !draft.GetIsDraft()
 	})
 
 	arn.SortActivitiesLatestFirst(activities)
@@ -0,0 +1,77 @@
+package activity
+
+import (
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/animenotifier/notify.moe/arn"
+)
+
+const (
+	// fragmentCacheDuration defines how long a rendered part of the feed is reused.
+	fragmentCacheDuration = time.Minute
+
+	// fragmentCacheMaxEntries limits the memory used by the cache.
+	fragmentCacheMaxEntries = 5000
+)
+
+// fragment is a rendered part of the activity feed.
+type fragment struct {
+	html       string
+	nextCursor string
+	created    time.Time
+}
+
+var (
+	fragments      = map[string]*fragment{}
+	fragmentsMutex sync.Mutex
+)
+
+// fragmentKey returns the cache key for the feed part of the given user.
+// The number of activities is part of the key so that new activities show up immediately.
+func fragmentKey(user *arn.User, followedOnly bool, cursor string) string {
+	userID := ""
+
+	if user != nil {
+		userID = user.ID
+	}
+
+	count := arn.DB.Collection("ActivityCreate").Count() + arn.DB.Collection("ActivityConsumeAnime").Count()
+	return userID + "|" + strconv.FormatBool(followedOnly) + "|" + cursor + "|" + strconv.FormatInt(count, 10)
+}
+
+// cachedFragment returns the rendered feed part if it's still valid.
+func cachedFragment(key string) (*fragment, bool) {
+	fragmentsMutex.Lock()
+	defer fragmentsMutex.Unlock()
+
+	cached, exists := fragments[key]
+
+	if !exists || time.Since(cached.created) > fragmentCacheDuration {
+		return nil, false
+	}
+
+	return cached, true
+}
+
+// cacheFragment saves the rendered feed part.
+func cacheFragment(key string, rendered *fragment) {
+	fragmentsMutex.Lock()
+	defer fragmentsMutex.Unlock()
+
+	if len(fragments) >= fragmentCacheMaxEntries {
+		for key, cached := range fragments {
+			if time.Since(cached.created) > fragmentCacheDuration {
+				delete(fragments, key)
+			}
+		}
+
+		// Everything is still valid, start from scratch
+		if len(fragments) >= fragmentCacheMaxEntries {
+			fragments = map[string]*fragment{}
+		}
+	}
+
+	fragments[key] = rendered
+}
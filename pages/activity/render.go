@@ -1,6 +1,9 @@
 package activity
 
 import (
+	"strings"
+	"time"
+
 	"github.com/aerogo/aero"
 	"github.com/animenotifier/notify.moe/arn"
 	"github.com/animenotifier/notify.moe/components"
@@ -12,31 +15,79 @@ const (
 	activitiesPerScroll = 20
 )
 
-// render renders the activities page with the given activities.
-func render(ctx aero.Context, allActivities []arn.Activity) error {
+// render renders the activities page starting after the activity in the cursor.
+func render(ctx aero.Context, followedOnly bool) error {
 	user := arn.GetUserFromContext(ctx)
-	index, _ := ctx.GetInt("index")
+	cursor := ctx.Get("cursor")
+	key := fragmentKey(user, followedOnly, cursor)
+	rendered, found := cachedFragment(key)
+
+	if !found {
+		rendered = renderFragment(fetchActivities(user, followedOnly), cursor, user)
+		cacheFragment(key, rendered)
+	}
 
-	// Slice the part that we need
-	activities := allActivities[index:]
-	maxLength := activitiesFirstLoad
+	// Send the cursor for the next request
+	infinitescroll.NextCursor(ctx, rendered.nextCursor)
 
-	if index > 0 {
-		maxLength = activitiesPerScroll
+	// In case we're scrolling, send activities only (without the page frame)
+	if cursor != "" {
+		return ctx.HTML(rendered.html)
 	}
 
-	if len(activities) > maxLength {
-		activities = activities[:maxLength]
+	// Otherwise, send the full page
+	return ctx.HTML(components.ActivityFeed(rendered.html, rendered.nextCursor, user))
+}
+
+// renderFragment groups the activities following the cursor and renders them.
+func renderFragment(allActivities []arn.Activity, cursor string, user *arn.User) *fragment {
+	start := 0
+	maxGroups := activitiesFirstLoad
+
+	if cursor != "" {
+		start = cursorStart(allActivities, cursor)
+		maxGroups = activitiesPerScroll
 	}
 
-	// Next index
-	nextIndex := infinitescroll.NextIndex(ctx, len(allActivities), maxLength, index)
+	groups, consumed := arn.GroupActivities(allActivities[start:], maxGroups)
+	nextCursor := ""
 
-	// In case we're scrolling, send activities only (without the page frame)
-	if index > 0 {
-		return ctx.HTML(components.ActivitiesScrollable(activities, user))
+	if start+consumed < len(allActivities) {
+		oldest := groups[len(groups)-1].Oldest()
+		nextCursor = oldest.GetCreated() + "_" + oldest.GetID()
 	}
 
-	// Otherwise, send the full page
-	return ctx.HTML(components.ActivityFeed(activities, nextIndex, user))
+	return &fragment{
+		html:       components.ActivitiesScrollable(groups, user),
+		nextCursor: nextCursor,
+		created:    time.Now(),
+	}
+}
+
+// cursorStart returns the index of the first activity after the cursor.
+// The cursor contains the creation date and the ID of the last activity on the previous page.
+// If that activity has been deleted in the meantime, the feed continues with the next older one.
+func cursorStart(allActivities []arn.Activity, cursor string) int {
+	created := ""
+	id := cursor
+	separator := strings.Index(cursor, "_")
+
+	if separator != -1 {
+		created = cursor[:separator]
+		id = cursor[separator+1:]
+	}
+
+	for index, activity := range allActivities {
+		if activity.GetID() == id {
+			return index + 1
+		}
+	}
+
+	for index, activity := range allActivities {
+		if activity.GetCreated() < created {
+			return index
+		}
+	}
+
+	return len(allActivities)
 }
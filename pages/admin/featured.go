@@ -0,0 +1,131 @@
+package admin
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aerogo/aero"
+	"github.com/animenotifier/notify.moe/arn"
+	"github.com/animenotifier/notify.moe/components"
+)
+
+// Featured shows today's featured content and the pinned features.
+func Featured(ctx aero.Context) error {
+	user := arn.GetUserFromContext(ctx)
+
+	if user == nil || user.Role != "admin" {
+		return ctx.Redirect(http.StatusTemporaryRedirect, "/")
+	}
+
+	today, _ := arn.TodaysFeaturedContent()
+	pinned := []*arn.FeaturedContent{}
+	todayDate := time.Now().UTC().Format(arn.FeaturedContentDateFormat)
+
+	for obj := range arn.DB.All("FeaturedContent") {
+		featured := obj.(*arn.FeaturedContent)
+
+		if featured.IsPinned() && featured.Date >= todayDate {
+			pinned = append(pinned, featured)
+		}
+	}
+
+	sort.Slice(pinned, func(i, j int) bool {
+		return pinned[i].Date < pinned[j].Date
+	})
+
+	return ctx.HTML(components.FeaturedContentAdmin(today, pinned))
+}
+
+// PinFeatured overrides the automatic selection of the featured content for a day.
+// Fields that are left empty keep the automatically selected object.
+func PinFeatured(ctx aero.Context) error {
+	user := arn.GetUserFromContext(ctx)
+
+	if user == nil || user.Role != "admin" {
+		return ctx.Error(http.StatusUnauthorized, "Not authorized")
+	}
+
+	body, err := ctx.Request().Body().JSONObject()
+
+	if err != nil {
+		return ctx.Error(http.StatusBadRequest, err)
+	}
+
+	date, _ := body["date"].(string)
+	day, err := time.Parse(arn.FeaturedContentDateFormat, date)
+
+	if err != nil {
+		return ctx.Error(http.StatusBadRequest, "Invalid date, expected YYYY-MM-DD")
+	}
+
+	featured, err := arn.GetFeaturedContent(date)
+
+	if err != nil {
+		featured, err = arn.SelectFeaturedContent(day)
+
+		if err != nil {
+			return ctx.Error(http.StatusInternalServerError, err)
+		}
+	}
+
+	if id := field(body, "animeId"); id != "" {
+		if _, err := arn.GetAnime(id); err != nil {
+			return ctx.Error(http.StatusNotFound, "Anime not found", err)
+		}
+
+		featured.AnimeID = id
+	}
+
+	if id := field(body, "characterId"); id != "" {
+		if _, err := arn.GetCharacter(id); err != nil {
+			return ctx.Error(http.StatusNotFound, "Character not found", err)
+		}
+
+		featured.CharacterID = id
+	}
+
+	if id := field(body, "quoteId"); id != "" {
+		if _, err := arn.GetQuote(id); err != nil {
+			return ctx.Error(http.StatusNotFound, "Quote not found", err)
+		}
+
+		featured.QuoteID = id
+	}
+
+	featured.PinnedBy = user.ID
+	featured.Save()
+	return nil
+}
+
+// UnpinFeatured removes the featured content of a day so that it will be selected automatically again.
+func UnpinFeatured(ctx aero.Context) error {
+	user := arn.GetUserFromContext(ctx)
+
+	if user == nil || user.Role != "admin" {
+		return ctx.Error(http.StatusUnauthorized, "Not authorized")
+	}
+
+	body, err := ctx.Request().Body().JSONObject()
+
+	if err != nil {
+		return ctx.Error(http.StatusBadRequest, err)
+	}
+
+	date := field(body, "date")
+	featured, err := arn.GetFeaturedContent(date)
+
+	if err != nil || !featured.IsPinned() {
+		return ctx.Error(http.StatusNotFound, "No pinned content on this date")
+	}
+
+	arn.DB.Delete("FeaturedContent", date)
+	return nil
+}
+
+// field returns the trimmed string value of a JSON field.
+func field(body map[string]interface{}, name string) string {
+	value, _ := body[name].(string)
+	return strings.TrimSpace(value)
+}
@@ -0,0 +1,20 @@
+package admin
+
+import (
+	"net/http"
+
+	"github.com/aerogo/aero"
+	"github.com/animenotifier/notify.moe/arn"
+	"github.com/animenotifier/notify.moe/components"
+)
+
+// Jobs shows the schedule, the last run and the last error of each background job.
+func Jobs(ctx aero.Context) error {
+	user := arn.GetUserFromContext(ctx)
+
+	if user == nil || user.Role != "admin" {
+		return ctx.Redirect(http.StatusTemporaryRedirect, "/")
+	}
+
+	return ctx.HTML(components.AdminJobs(arn.AllJobStatuses()))
+}
@@ -0,0 +1,124 @@
+package admin
+
+import (
+	"bytes"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/aerogo/aero"
+	"github.com/animenotifier/notify.moe/arn"
+	"github.com/animenotifier/notify.moe/arn/metrics"
+	"github.com/animenotifier/notify.moe/components"
+	"github.com/animenotifier/notify.moe/server/middleware"
+	"github.com/animenotifier/notify.moe/utils"
+)
+
+const maxMetricsRoutes = 50
+
+// Metrics shows the request, cache, notification and database metrics.
+func Metrics(ctx aero.Context) error {
+	user := arn.GetUserFromContext(ctx)
+
+	if user == nil || user.Role != "admin" {
+		return ctx.Redirect(http.StatusTemporaryRedirect, "/")
+	}
+
+	dashboard := &utils.MetricsDashboard{
+		Routes:            routeStatistics(),
+		CacheHits:         middleware.ConditionalRequestResults.Value(middleware.CacheHit),
+		CacheMisses:       middleware.ConditionalRequestResults.Value(middleware.CacheMiss),
+		NotificationsSent: total(arn.NotificationsSent.Samples()),
+		PushSuccess:       arn.PushDeliveries.Value(arn.PushDeliverySuccess),
+		PushFailure:       arn.PushDeliveries.Value(arn.PushDeliveryFailure),
+		PushExpired:       arn.PushDeliveries.Value(arn.PushDeliveryExpired),
+	}
+
+	for _, sample := range arn.DatabaseObjectCounts() {
+		dashboard.ObjectCounts = append(dashboard.ObjectCounts, &utils.ObjectCount{
+			Type:  sample.LabelValues[0],
+			Count: int64(sample.Value),
+		})
+	}
+
+	return ctx.HTML(components.AdminMetrics(dashboard))
+}
+
+// PrometheusMetrics exports all metrics in the Prometheus text format.
+// Scrapers can authenticate with the API key of an admin via "Authorization: Bearer <key>".
+func PrometheusMetrics(ctx aero.Context) error {
+	user := arn.GetUserFromContext(ctx)
+	authorization := ctx.Request().Header("Authorization")
+
+	if user == nil && strings.HasPrefix(authorization, "Bearer ") {
+		user, _ = arn.GetUserByAPIKey(strings.TrimSpace(strings.TrimPrefix(authorization, "Bearer ")))
+	}
+
+	if user == nil || user.Role != "admin" {
+		return ctx.Error(http.StatusUnauthorized, "Not authorized")
+	}
+
+	buffer := bytes.Buffer{}
+	err := metrics.Write(&buffer)
+
+	if err != nil {
+		return ctx.Error(http.StatusInternalServerError, "Could not write metrics", err)
+	}
+
+	ctx.Response().SetHeader("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	middleware.SetCacheControl(ctx, "no-store")
+	return ctx.Bytes(buffer.Bytes())
+}
+
+// routeStatistics returns the busiest routes first.
+func routeStatistics() []*utils.RouteStatistics {
+	serverErrors := map[string]float64{}
+
+	for _, sample := range middleware.Requests.Samples() {
+		status, _ := strconv.Atoi(sample.LabelValues[1])
+
+		if status >= http.StatusInternalServerError {
+			serverErrors[sample.LabelValues[0]] += sample.Value
+		}
+	}
+
+	routes := []*utils.RouteStatistics{}
+
+	for _, series := range middleware.RequestDuration.Series() {
+		route := series.LabelValues[0]
+
+		routes = append(routes, &utils.RouteStatistics{
+			Route:    route,
+			Requests: series.Count,
+			Errors:   serverErrors[route],
+			Average:  series.Average(),
+			P95:      series.Quantile(0.95),
+		})
+	}
+
+	sort.Slice(routes, func(i, j int) bool {
+		if routes[i].Requests == routes[j].Requests {
+			return routes[i].Route < routes[j].Route
+		}
+
+		return routes[i].Requests > routes[j].Requests
+	})
+
+	if len(routes) > maxMetricsRoutes {
+		routes = routes[:maxMetricsRoutes]
+	}
+
+	return routes
+}
+
+// total returns the sum of all sample values.
+func total(samples []metrics.Sample) float64 {
+	sum := 0.0
+
+	for _, sample := range samples {
+		sum += sample.Value
+	}
+
+	return sum
+}
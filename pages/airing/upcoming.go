@@ -0,0 +1,20 @@
+package airing
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/aerogo/aero"
+	"github.com/animenotifier/notify.moe/arn"
+)
+
+// Upcoming returns the next episode airing times of the anime in the user's watching list.
+func Upcoming(ctx aero.Context) error {
+	user := arn.GetUserFromContext(ctx)
+
+	if user == nil {
+		return ctx.Error(http.StatusUnauthorized, "Not logged in")
+	}
+
+	return ctx.JSON(user.AnimeList().AiringCountdowns(user, time.Now().UTC()))
+}
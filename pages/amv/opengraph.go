@@ -4,34 +4,28 @@ import (
 	"strings"
 
 	"github.com/animenotifier/notify.moe/arn"
-	"github.com/animenotifier/notify.moe/assets"
+	"github.com/animenotifier/notify.moe/utils/opengraph"
 )
 
 func getOpenGraph(amv *arn.AMV) *arn.OpenGraph {
-	openGraph := &arn.OpenGraph{
-		Tags: map[string]string{
-			"og:title":     amv.Title.ByUser(nil) + " (AMV)",
-			"og:url":       "https://" + assets.Domain + amv.Link(),
-			"og:site_name": assets.Domain,
-			"og:type":      "video.other",
-		},
-		Meta: map[string]string{},
-	}
-
-	openGraph.Tags["og:description"] = strings.Join(amv.Tags, ", ")
+	builder := opengraph.New(amv.Title.ByUser(nil)+" (AMV)", strings.Join(amv.Tags, ", ")).
+		URL(amv.Link()).
+		Type("video.other")
 
 	if amv.File != "" {
-		openGraph.Tags["og:video"] = amv.VideoLink()
-		openGraph.Tags["og:video:type"] = "video/webm"
-		openGraph.Tags["og:video:width"] = "640"
-		openGraph.Tags["og:video:height"] = "360"
+		video := amv.VideoLink()
 
-		openGraph.Meta["twitter:player"] = openGraph.Tags["og:video"]
-		openGraph.Meta["twitter:player:width"] = openGraph.Tags["og:video:width"]
-		openGraph.Meta["twitter:player:height"] = openGraph.Tags["og:video:height"]
-		openGraph.Meta["twitter:player:stream"] = openGraph.Tags["og:video"]
-		openGraph.Meta["twitter:player:stream:content_type"] = openGraph.Tags["og:video:type"]
+		builder.
+			Tag("og:video", video).
+			Tag("og:video:type", "video/webm").
+			Tag("og:video:width", "640").
+			Tag("og:video:height", "360").
+			Meta("twitter:player", video).
+			Meta("twitter:player:width", "640").
+			Meta("twitter:player:height", "360").
+			Meta("twitter:player:stream", video).
+			Meta("twitter:player:stream:content_type", "video/webm")
 	}
 
-	return openGraph
+	return builder.Build()
 }
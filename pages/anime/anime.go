@@ -13,8 +13,8 @@ import (
 const (
 	maxEpisodes           = 26
 	maxEpisodesLongSeries = 12
-	maxDescriptionLength  = 170
 	maxFriendsPerEpisode  = 9
+	maxSimilarAnime       = 6
 )
 
 // Get anime page.
@@ -119,9 +119,19 @@ func Get(ctx aero.Context) error {
 		return len(amvs[i].Likes) > len(amvs[j].Likes)
 	})
 
+	// Similar anime that aren't on the user's list yet
+	similar := []*arn.Anime{}
+	similarAnime, err := arn.GetSimilarAnime(anime.ID)
+
+	if err == nil {
+		similar = similarAnime.Anime(maxSimilarAnime, func(animeID arn.AnimeID) bool {
+			return user != nil && user.AnimeList().Contains(animeID)
+		})
+	}
+
 	// Open Graph
 	customCtx := ctx.(*middleware.OpenGraphContext)
 	customCtx.OpenGraph = getOpenGraph(anime)
 
-	return ctx.HTML(components.Anime(anime, animeListItem, tracks, amvs, amvAppearances, episodes, friends, friendsAnimeListItems, episodeToFriends, user))
+	return ctx.HTML(components.Anime(anime, animeListItem, tracks, amvs, amvAppearances, episodes, friends, friendsAnimeListItems, episodeToFriends, similar, user))
 }
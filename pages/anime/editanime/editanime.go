@@ -90,7 +90,7 @@ func Relations(ctx aero.Context) error {
 		return ctx.Error(http.StatusNotFound, "Anime relations not found", err)
 	}
 
-	return ctx.HTML(components.EditAnimeTabs(anime) + editform.Render(animeRelations, "Edit anime relations", user))
+	return ctx.HTML(components.EditAnimeTabs(anime) + editform.Render(animeRelations, "Edit anime relations", user) + components.EditAnimeReverseRelations(animeRelations, animeRelations.MissingReverse(), user))
 }
 
 // Episodes anime episodes edit page.
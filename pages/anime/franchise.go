@@ -0,0 +1,39 @@
+package anime
+
+import (
+	"net/http"
+
+	"github.com/aerogo/aero"
+	"github.com/animenotifier/notify.moe/arn"
+	"github.com/animenotifier/notify.moe/components"
+)
+
+// Franchise shows the suggested watch order and the relation graph of all related anime.
+func Franchise(ctx aero.Context) error {
+	user := arn.GetUserFromContext(ctx)
+	anime, err := arn.GetAnime(ctx.Get("id"))
+
+	if err != nil {
+		return ctx.Error(http.StatusNotFound, "Anime not found", err)
+	}
+
+	franchise := anime.Franchise()
+	order := franchise.WatchOrder()
+
+	// Show the progress of the user next to each anime
+	statuses := map[string]string{}
+
+	if user != nil {
+		animeList := user.AnimeList()
+
+		for _, entry := range order {
+			item := animeList.Find(entry.Anime.ID)
+
+			if item != nil {
+				statuses[entry.Anime.ID] = item.Status
+			}
+		}
+	}
+
+	return ctx.HTML(components.AnimeFranchise(anime, franchise, order, franchise.Graph(), statuses, user))
+}
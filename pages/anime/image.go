@@ -0,0 +1,69 @@
+package anime
+
+import (
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/aerogo/aero"
+	"github.com/animenotifier/notify.moe/arn"
+	"github.com/animenotifier/notify.moe/server/middleware"
+	"github.com/animenotifier/notify.moe/utils"
+	"github.com/animenotifier/notify.moe/utils/ogimage"
+)
+
+// OpenGraphImage renders the preview image that is shown when the anime page is shared.
+func OpenGraphImage(ctx aero.Context) error {
+	id := strings.TrimSuffix(ctx.Get("id"), ".png")
+	anime, err := arn.GetAnime(id)
+
+	if err != nil {
+		return ctx.Error(http.StatusNotFound, "Anime not found", err)
+	}
+
+	key := fmt.Sprintf("anime:%s:%s:%d", anime.ID, anime.Edited, anime.Image.LastModified)
+
+	data, err := ogimage.Cached(key, func() ([]byte, error) {
+		return renderImage(anime)
+	})
+
+	if err != nil {
+		return ctx.Error(http.StatusInternalServerError, "Could not render the image", err)
+	}
+
+	ctx.Response().SetHeader("Content-Type", "image/png")
+	middleware.SetCacheControl(ctx, "public, max-age=86400")
+	middleware.SetLastModified(ctx, anime.Edited)
+	return ctx.Bytes(data)
+}
+
+// renderImage draws the poster, the title and the most important facts of the anime.
+func renderImage(anime *arn.Anime) ([]byte, error) {
+	lines := []string{}
+
+	if anime.Rating.Overall != 0 {
+		lines = append(lines, "Score: "+utils.FormatRating(anime.Rating.Overall)+" / 10")
+	}
+
+	if anime.EpisodeCount > 0 {
+		lines = append(lines, fmt.Sprintf("%d episodes", anime.EpisodeCount))
+	}
+
+	details := []string{anime.TypeHumanReadable()}
+
+	if len(anime.StartDate) >= 4 {
+		details = append(details, anime.StartDate[:4])
+	}
+
+	details = append(details, anime.StatusHumanReadable())
+	lines = append(lines, strings.Join(details, " | "))
+
+	card := &ogimage.Card{
+		Poster: ogimage.LoadImage(path.Join(arn.Root, "images", "anime", "large", anime.ID+".jpg")),
+		Title:  ogimage.Readable(anime.Title.Canonical, anime.Title.Romaji, anime.Title.English),
+		Lines:  lines,
+	}
+
+	return card.PNG()
+}
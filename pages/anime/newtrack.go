@@ -0,0 +1,65 @@
+package anime
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/aerogo/aero"
+	"github.com/animenotifier/notify.moe/arn"
+)
+
+// NewTrack creates a soundtrack draft for the anime from a Youtube, Spotify or Apple Music link.
+// The draft is completed and published in the soundtrack editor.
+func NewTrack(ctx aero.Context) error {
+	user := arn.GetUserFromContext(ctx)
+
+	if user == nil {
+		return ctx.Error(http.StatusUnauthorized, "Not logged in")
+	}
+
+	if user.DraftIndex().SoundTrackID != "" {
+		return ctx.Error(http.StatusBadRequest, "You still have an unfinished draft")
+	}
+
+	anime, err := arn.GetAnime(ctx.Get("id"))
+
+	if err != nil {
+		return ctx.Error(http.StatusNotFound, "Anime not found", err)
+	}
+
+	body, err := ctx.Request().Body().JSONObject()
+
+	if err != nil {
+		return ctx.Error(http.StatusBadRequest, err)
+	}
+
+	url, _ := body["url"].(string)
+	trackType, _ := body["type"].(string)
+	media, err := arn.GetExternalMedia(strings.TrimSpace(url))
+
+	if err != nil {
+		return ctx.Error(http.StatusBadRequest, err)
+	}
+
+	track := &arn.SoundTrack{
+		Media: []*arn.ExternalMedia{media},
+		Tags:  []string{"anime:" + anime.ID},
+	}
+
+	switch trackType {
+	case arn.SoundTrackTypeOpening, arn.SoundTrackTypeEnding:
+		track.Tags = append(track.Tags, trackType)
+	case arn.SoundTrackTypeOST:
+	default:
+		return ctx.Error(http.StatusBadRequest, "Invalid soundtrack type")
+	}
+
+	err = track.Create(ctx)
+
+	if err != nil {
+		return ctx.Error(http.StatusBadRequest, err)
+	}
+
+	track.Save()
+	return ctx.JSON(track)
+}
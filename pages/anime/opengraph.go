@@ -2,36 +2,78 @@ package anime
 
 import (
 	"github.com/animenotifier/notify.moe/arn"
+	"github.com/animenotifier/notify.moe/arn/validate"
 	"github.com/animenotifier/notify.moe/assets"
+	"github.com/animenotifier/notify.moe/utils"
+	"github.com/animenotifier/notify.moe/utils/ogimage"
+	"github.com/animenotifier/notify.moe/utils/opengraph"
 )
 
 func getOpenGraph(anime *arn.Anime) *arn.OpenGraph {
-	description := anime.Summary
-
-	if len(description) > maxDescriptionLength {
-		description = description[:maxDescriptionLength-3] + "..."
-	}
-
-	openGraph := &arn.OpenGraph{
-		Tags: map[string]string{
-			"og:title":       anime.Title.Canonical,
-			"og:image":       "https:" + anime.ImageLink("large"),
-			"og:url":         "https://" + assets.Domain + anime.Link(),
-			"og:site_name":   "notify.moe",
-			"og:description": description,
-		},
-		Meta: map[string]string{
-			"description": description,
-			"keywords":    anime.Title.Canonical + ",anime",
-		},
-	}
+	description := utils.CutLongDescription(anime.Summary)
+	builder := opengraph.New(anime.Title.Canonical, description).
+		URL(anime.Link()).
+		Keywords(anime.Title.Canonical, "anime").
+		StructuredData(getStructuredData(anime, description))
 
 	switch anime.Type {
 	case "tv":
-		openGraph.Tags["og:type"] = "video.tv_show"
+		builder.Type("video.tv_show")
 	case "movie":
-		openGraph.Tags["og:type"] = "video.movie"
+		builder.Type("video.movie")
 	}
 
+	openGraph := builder.Build()
+	ogimage.SetImage(openGraph, "/og-image/anime/"+anime.ID+".png")
 	return openGraph
 }
+
+// getStructuredData describes the anime as a schema.org TV series or movie.
+func getStructuredData(anime *arn.Anime, description string) arn.StructuredData {
+	schemaType := "TVSeries"
+
+	if anime.Type == "movie" {
+		schemaType = "Movie"
+	}
+
+	data := arn.NewStructuredData(schemaType)
+	data["name"] = anime.Title.Canonical
+	data["url"] = "https://" + assets.Domain + anime.Link()
+	data["image"] = "https:" + anime.ImageLink("large")
+
+	if description != "" {
+		data["description"] = description
+	}
+
+	if anime.Title.Japanese != "" {
+		data["alternateName"] = anime.Title.Japanese
+	}
+
+	if len(anime.Genres) > 0 {
+		data["genre"] = anime.Genres
+	}
+
+	if validate.Date(anime.StartDate) {
+		data["startDate"] = anime.StartDate
+	}
+
+	if validate.Date(anime.EndDate) {
+		data["endDate"] = anime.EndDate
+	}
+
+	if schemaType == "TVSeries" && anime.EpisodeCount > 0 {
+		data["numberOfEpisodes"] = anime.EpisodeCount
+	}
+
+	if anime.Rating.Count.Overall > 0 {
+		data["aggregateRating"] = arn.StructuredData{
+			"@type":       "AggregateRating",
+			"ratingValue": anime.Rating.Overall,
+			"ratingCount": anime.Rating.Count.Overall,
+			"bestRating":  arn.MaxRating,
+			"worstRating": 0,
+		}
+	}
+
+	return data
+}
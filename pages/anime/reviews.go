@@ -0,0 +1,28 @@
+package anime
+
+import (
+	"net/http"
+
+	"github.com/aerogo/aero"
+	"github.com/animenotifier/notify.moe/arn"
+	"github.com/animenotifier/notify.moe/components"
+)
+
+// Reviews shows all reviews of the anime, most helpful first.
+func Reviews(ctx aero.Context) error {
+	user := arn.GetUserFromContext(ctx)
+	id := ctx.Get("id")
+	anime, err := arn.GetAnime(id)
+
+	if err != nil {
+		return ctx.Error(http.StatusNotFound, "Anime not found", err)
+	}
+
+	var ownReview *arn.Review
+
+	if user != nil {
+		ownReview = anime.ReviewBy(user.ID)
+	}
+
+	return ctx.HTML(components.AnimeReviewsPage(anime, anime.Reviews(), ownReview, user))
+}
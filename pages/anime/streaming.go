@@ -0,0 +1,27 @@
+package anime
+
+import (
+	"net/http"
+
+	"github.com/aerogo/aero"
+	"github.com/animenotifier/notify.moe/arn"
+	"github.com/animenotifier/notify.moe/components"
+)
+
+// SuggestStreamingLink shows the form to suggest a streaming link for the anime.
+func SuggestStreamingLink(ctx aero.Context) error {
+	id := ctx.Get("id")
+	user := arn.GetUserFromContext(ctx)
+
+	if user == nil {
+		return ctx.Error(http.StatusUnauthorized, "Not logged in")
+	}
+
+	anime, err := arn.GetAnime(id)
+
+	if err != nil {
+		return ctx.Error(http.StatusNotFound, "Anime not found", err)
+	}
+
+	return ctx.HTML(components.SuggestStreamingLink(anime, user))
+}
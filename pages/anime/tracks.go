@@ -9,7 +9,7 @@ import (
 	"github.com/animenotifier/notify.moe/arn"
 )
 
-// Tracks ...
+// Tracks renders the openings, endings and the original soundtrack of an anime.
 func Tracks(ctx aero.Context) error {
 	id := ctx.Get("id")
 	user := arn.GetUserFromContext(ctx)
@@ -24,5 +24,21 @@ func Tracks(ctx aero.Context) error {
 		return !track.IsDraft && len(track.Media) > 0 && arn.Contains(track.Tags, "anime:"+anime.ID)
 	})
 
-	return ctx.HTML(components.AnimeTracks(anime, tracks, user, true))
+	arn.SortSoundTracksBySequence(tracks)
+	openings := []*arn.SoundTrack{}
+	endings := []*arn.SoundTrack{}
+	ost := []*arn.SoundTrack{}
+
+	for _, track := range tracks {
+		switch track.Type() {
+		case arn.SoundTrackTypeOpening:
+			openings = append(openings, track)
+		case arn.SoundTrackTypeEnding:
+			endings = append(endings, track)
+		default:
+			ost = append(ost, track)
+		}
+	}
+
+	return ctx.HTML(components.AnimeSoundTracks(anime, openings, endings, ost, user))
 }
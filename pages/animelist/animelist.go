@@ -6,10 +6,11 @@ import (
 
 	"github.com/aerogo/aero"
 	"github.com/animenotifier/notify.moe/arn"
-	"github.com/animenotifier/notify.moe/assets"
 	"github.com/animenotifier/notify.moe/components"
 	"github.com/animenotifier/notify.moe/server/middleware"
+	"github.com/animenotifier/notify.moe/utils"
 	"github.com/animenotifier/notify.moe/utils/infinitescroll"
+	"github.com/animenotifier/notify.moe/utils/opengraph"
 )
 
 const (
@@ -34,7 +35,7 @@ func Filter(ctx aero.Context) error {
 func AnimeList(ctx aero.Context, user *arn.User, status string, sortBy string) error {
 	nick := ctx.Get("nick")
 	index, _ := ctx.GetInt("index")
-	viewUser, err := arn.GetUserByNick(nick)
+	viewUser, err := arn.GetVisibleUserByNick(nick)
 
 	if err != nil {
 		return ctx.Error(http.StatusNotFound, "User not found", err)
@@ -83,23 +84,15 @@ func AnimeList(ctx aero.Context, user *arn.User, status string, sortBy string) e
 
 	// OpenGraph data
 	customCtx := ctx.(*middleware.OpenGraphContext)
-	customCtx.OpenGraph = &arn.OpenGraph{
-		Tags: map[string]string{
-			"og:title":       viewUser.Nick + "'s anime list",
-			"og:image":       "https:" + viewUser.AvatarLink("large"),
-			"og:url":         "https://" + assets.Domain + viewUser.Link(),
-			"og:site_name":   "notify.moe",
-			"og:description": strconv.Itoa(len(animeList.Items)) + " anime",
-
-			// The OpenGraph type "profile" is meant for real-life persons but I think it's okay in this context.
-			// An alternative would be to use "article" which is mostly used for blog posts and news.
-			"og:type": "profile",
-		},
-		Meta: map[string]string{
-			"description": viewUser.Nick + "'s anime list",
-			"keywords":    "anime list",
-		},
-	}
+	customCtx.OpenGraph = opengraph.New(viewUser.Nick+"'s anime list", strconv.Itoa(len(animeList.Items))+" anime").
+		URL(viewUser.Link()).
+		Image(utils.BestImageVariant(viewUser, utils.OpenGraphImageRatio)).
+		// The OpenGraph type "profile" is meant for real-life persons but I think it's okay in this context.
+		// An alternative would be to use "article" which is mostly used for blog posts and news.
+		Type("profile").
+		Meta("description", viewUser.Nick+"'s anime list").
+		Keywords("anime list").
+		Build()
 
 	// In case we're scrolling, send items only (without the page frame)
 	if index > 0 {
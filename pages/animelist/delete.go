@@ -19,7 +19,7 @@ func DeleteConfirmation(ctx aero.Context) error {
 	return ctx.HTML(components.DeleteAnimeList(user))
 }
 
-// Delete deletes your entire anime list.
+// Delete moves your entire anime list to the trash.
 func Delete(ctx aero.Context) error {
 	user := arn.GetUserFromContext(ctx)
 
@@ -28,9 +28,8 @@ func Delete(ctx aero.Context) error {
 	}
 
 	animeList := user.AnimeList()
-	animeList.Lock()
-	animeList.Items = nil
-	animeList.Unlock()
+	animeList.MoveAllToTrash()
+	animeList.Save()
 
 	return ctx.String("ok")
 }
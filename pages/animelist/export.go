@@ -0,0 +1,49 @@
+package animelist
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+
+	"github.com/aerogo/aero"
+	"github.com/animenotifier/notify.moe/arn"
+)
+
+// Export sends the anime list of the logged in user as a file download.
+// The format can be "xml" (MyAnimeList compatible), "csv" or "json".
+func Export(ctx aero.Context) error {
+	user := arn.GetUserFromContext(ctx)
+
+	if user == nil {
+		return ctx.Error(http.StatusUnauthorized, "Not logged in")
+	}
+
+	format := ctx.Get("format")
+	animeList := user.AnimeList()
+	buffer := bytes.Buffer{}
+	var contentType string
+	var err error
+
+	switch format {
+	case "xml":
+		contentType = "application/xml"
+		err = animeList.WriteMyAnimeListXML(&buffer, user.Nick)
+	case "csv":
+		contentType = "text/csv"
+		err = animeList.WriteCSV(&buffer)
+	case "json":
+		contentType = "application/json"
+		err = animeList.WriteJSON(&buffer)
+	default:
+		return ctx.Error(http.StatusNotFound, "Unknown export format")
+	}
+
+	if err != nil {
+		return ctx.Error(http.StatusInternalServerError, "Could not export the anime list", err)
+	}
+
+	// Send headers necessary for file downloads
+	ctx.Response().SetHeader("Content-Type", contentType+"; charset=utf-8")
+	ctx.Response().SetHeader("Content-Disposition", fmt.Sprintf(`attachment; filename="%s-animelist.%s"`, user.Nick, format))
+	return ctx.Bytes(buffer.Bytes())
+}
@@ -13,7 +13,7 @@ import (
 func Get(ctx aero.Context) error {
 	user := arn.GetUserFromContext(ctx)
 	nick := ctx.Get("nick")
-	viewUser, err := arn.GetUserByNick(nick)
+	viewUser, err := arn.GetVisibleUserByNick(nick)
 
 	if err != nil {
 		return ctx.Error(http.StatusNotFound, "User not found", err)
@@ -0,0 +1,19 @@
+package apiv1
+
+import (
+	"net/http"
+
+	"github.com/aerogo/aero"
+	"github.com/animenotifier/notify.moe/arn"
+)
+
+// Anime returns a single anime by ID.
+func Anime(ctx aero.Context) error {
+	anime, err := arn.GetAnime(ctx.Get("id"))
+
+	if err != nil || anime.IsDraft {
+		return ctx.Error(http.StatusNotFound, "Anime not found", err)
+	}
+
+	return ctx.JSON(anime)
+}
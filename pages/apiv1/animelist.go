@@ -0,0 +1,65 @@
+package apiv1
+
+import (
+	"net/http"
+
+	"github.com/aerogo/aero"
+	"github.com/animenotifier/notify.moe/arn"
+)
+
+// AnimeList returns the public anime list entries of a user.
+// The "status" query parameter filters the entries by status.
+func AnimeList(ctx aero.Context) error {
+	viewUser, err := arn.GetVisibleUserByNick(ctx.Get("nick"))
+
+	if err != nil {
+		return ctx.Error(http.StatusNotFound, "User not found", err)
+	}
+
+	limit, offset, err := pagination(ctx.Query)
+
+	if err != nil {
+		return ctx.Error(http.StatusBadRequest, err)
+	}
+
+	animeList := viewUser.AnimeList()
+
+	if animeList == nil {
+		return ctx.Error(http.StatusNotFound, "Anime list not found")
+	}
+
+	status := ctx.Query("status")
+	items := []*arn.AnimeListItem{}
+
+	for _, item := range animeList.Items {
+		if item.Private || (status != "" && item.Status != status) {
+			continue
+		}
+
+		items = append(items, item)
+	}
+
+	return ctx.JSON(paginateListItems(items, limit, offset))
+}
+
+// paginateListItems returns the requested page of anime list items.
+func paginateListItems(items []*arn.AnimeListItem, limit int, offset int) *page {
+	total := len(items)
+
+	if offset > len(items) {
+		offset = len(items)
+	}
+
+	items = items[offset:]
+
+	if len(items) > limit {
+		items = items[:limit]
+	}
+
+	return &page{
+		Items:  items,
+		Total:  total,
+		Limit:  limit,
+		Offset: offset,
+	}
+}
@@ -0,0 +1,168 @@
+package apiv1
+
+import (
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/aerogo/aero"
+	"github.com/animenotifier/notify.moe/arn"
+)
+
+// UserHandler handles a request made on behalf of the authenticated user.
+type UserHandler func(ctx aero.Context, user *arn.User) error
+
+// credentials is the result of authenticating a request.
+// API keys belong to the user who created them and grant the scopes chosen when creating them.
+type credentials struct {
+	user  *arn.User
+	token *arn.OAuthToken
+	key   *arn.UserAPIKey
+}
+
+// hasScope tells whether the credentials grant the given scope.
+func (creds *credentials) hasScope(scope string) bool {
+	if creds.token != nil {
+		return creds.token.HasScope(scope)
+	}
+
+	return creds.key.HasScope(scope)
+}
+
+// Authorized makes sure the request has a valid API key or OAuth access token and is within the rate limit.
+// The key can be passed via "Authorization: Bearer <key>" or, for these public endpoints, the "key" query parameter.
+func Authorized(handler aero.Handler) aero.Handler {
+	return func(ctx aero.Context) error {
+		_, err := authenticate(ctx, requestKey(ctx))
+
+		if err != nil {
+			return err
+		}
+
+		return handler(ctx)
+	}
+}
+
+// Public allows requests without an API key for read-only endpoints.
+// Anonymous requests are rate limited per IP address, requests with a key are handled like in Authorized.
+func Public(handler aero.Handler) aero.Handler {
+	return func(ctx aero.Context) error {
+		if requestKey(ctx) != "" {
+			return Authorized(handler)(ctx)
+		}
+
+		remaining, allowed := anonymousLimiter.Allow(clientIP(ctx))
+		ctx.Response().SetHeader("X-RateLimit-Limit", strconv.Itoa(anonymousRequestsPerMinute))
+		ctx.Response().SetHeader("X-RateLimit-Remaining", strconv.Itoa(remaining))
+
+		if !allowed {
+			return ctx.Error(http.StatusTooManyRequests, "Rate limit exceeded, please use an API key for more requests")
+		}
+
+		return handler(ctx)
+	}
+}
+
+// Scoped works like Authorized but also requires the given scope
+// and passes the user the request was made for to the handler.
+// URLs end up in logs and referrers, so these endpoints only accept the "Authorization" header.
+func Scoped(scope string, handler UserHandler) aero.Handler {
+	return func(ctx aero.Context) error {
+		key := bearerKey(ctx)
+
+		if key == "" && ctx.Query("key") != "" {
+			return ctx.Error(http.StatusUnauthorized, "This endpoint requires the key in the Authorization header")
+		}
+
+		creds, err := authenticate(ctx, key)
+
+		if err != nil {
+			return err
+		}
+
+		if !creds.hasScope(scope) {
+			ctx.Response().SetHeader("WWW-Authenticate", `Bearer error="insufficient_scope", scope="`+scope+`"`)
+			return ctx.Error(http.StatusForbidden, "Missing scope: "+scope)
+		}
+
+		return handler(ctx, creds.user)
+	}
+}
+
+// authenticate checks the key of the request and applies the rate limit.
+// The returned error has already been written to the response.
+func authenticate(ctx aero.Context, key string) (*credentials, error) {
+	if key == "" {
+		return nil, ctx.Error(http.StatusUnauthorized, "Missing API key")
+	}
+
+	creds := &credentials{}
+	token, err := arn.GetOAuthToken(key)
+
+	if err == nil {
+		if token.IsExpired() {
+			token.Delete()
+			ctx.Response().SetHeader("WWW-Authenticate", `Bearer error="invalid_token", error_description="The access token expired"`)
+			return nil, ctx.Error(http.StatusUnauthorized, "The access token has expired")
+		}
+
+		creds.token = token
+		creds.user, err = arn.GetUser(token.UserID)
+	} else {
+		creds.user, creds.key, err = arn.GetUserAPIKey(key)
+	}
+
+	if err != nil {
+		return nil, ctx.Error(http.StatusUnauthorized, "Invalid API key")
+	}
+
+	// Keys of deactivated accounts stop working until the user logs in again
+	if creds.user.IsHidden() {
+		return nil, ctx.Error(http.StatusUnauthorized, "This account has been deactivated")
+	}
+
+	remaining, allowed := limiter.Allow(key)
+	ctx.Response().SetHeader("X-RateLimit-Limit", strconv.Itoa(requestsPerMinute))
+	ctx.Response().SetHeader("X-RateLimit-Remaining", strconv.Itoa(remaining))
+
+	if !allowed {
+		return nil, ctx.Error(http.StatusTooManyRequests, "Rate limit exceeded")
+	}
+
+	return creds, nil
+}
+
+// requestKey returns the API key of the request from the header or the query.
+func requestKey(ctx aero.Context) string {
+	key := bearerKey(ctx)
+
+	if key != "" {
+		return key
+	}
+
+	return ctx.Query("key")
+}
+
+// bearerKey returns the API key sent in the "Authorization" header.
+func bearerKey(ctx aero.Context) string {
+	authorization := ctx.Request().Header("Authorization")
+
+	if !strings.HasPrefix(authorization, "Bearer ") {
+		return ""
+	}
+
+	return strings.TrimSpace(strings.TrimPrefix(authorization, "Bearer "))
+}
+
+// clientIP returns the IP address the request was sent from.
+func clientIP(ctx aero.Context) string {
+	address := ctx.Request().Internal().RemoteAddr
+	host, _, err := net.SplitHostPort(address)
+
+	if err != nil {
+		return address
+	}
+
+	return host
+}
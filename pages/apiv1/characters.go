@@ -0,0 +1,42 @@
+package apiv1
+
+import (
+	"net/http"
+
+	"github.com/aerogo/aero"
+	"github.com/animenotifier/notify.moe/pages/characters"
+)
+
+// Characters returns characters filtered by the "q", "trait" and "anime" query parameters.
+// The "sort" parameter accepts "latest", "best" and "name".
+func Characters(ctx aero.Context) error {
+	limit, offset, err := pagination(ctx.Query)
+
+	if err != nil {
+		return ctx.Error(http.StatusBadRequest, err)
+	}
+
+	sortMode := ctx.Query("sort")
+
+	switch sortMode {
+	case "", characters.SortLatest, characters.SortBest, characters.SortName:
+	default:
+		return ctx.Error(http.StatusBadRequest, "Invalid sort mode")
+	}
+
+	result := characters.Query(characters.QueryOptions{
+		Trait:   ctx.Query("trait"),
+		AnimeID: ctx.Query("anime"),
+		Search:  ctx.Query("q"),
+		Sort:    sortMode,
+		Index:   offset,
+		Limit:   limit,
+	})
+
+	return ctx.JSON(&page{
+		Items:  result.Characters,
+		Total:  result.Total,
+		Limit:  limit,
+		Offset: offset,
+	})
+}
@@ -0,0 +1,73 @@
+package apiv1
+
+import (
+	"net/http"
+
+	"github.com/aerogo/aero"
+	"github.com/animenotifier/notify.moe/arn"
+)
+
+// NewKey creates a new API key for the logged in user.
+// The optional "scopes" in the body default to read-only access.
+func NewKey(ctx aero.Context) error {
+	user := arn.GetUserFromContext(ctx)
+
+	if user == nil {
+		return ctx.Error(http.StatusUnauthorized, "Not logged in")
+	}
+
+	body, err := ctx.Request().Body().JSONObject()
+
+	if err != nil {
+		return ctx.Error(http.StatusBadRequest, err)
+	}
+
+	name, _ := body["name"].(string)
+	scopes := []string{}
+	requestedScopes, _ := body["scopes"].([]interface{})
+
+	for _, scope := range requestedScopes {
+		scopeName, _ := scope.(string)
+		scopes = append(scopes, scopeName)
+	}
+
+	keys := user.APIKeys()
+	key, secret, err := keys.Create(name, scopes)
+
+	if err != nil {
+		return ctx.Error(http.StatusBadRequest, err)
+	}
+
+	keys.Save()
+
+	// The key is only stored as a hash, so this is the only time it can be shown
+	return ctx.JSON(map[string]string{
+		"id":  key.ID,
+		"key": secret,
+	})
+}
+
+// RevokeKey deletes an API key of the logged in user.
+func RevokeKey(ctx aero.Context) error {
+	user := arn.GetUserFromContext(ctx)
+
+	if user == nil {
+		return ctx.Error(http.StatusUnauthorized, "Not logged in")
+	}
+
+	body, err := ctx.Request().Body().JSONObject()
+
+	if err != nil {
+		return ctx.Error(http.StatusBadRequest, err)
+	}
+
+	id, _ := body["id"].(string)
+	keys := user.APIKeys()
+
+	if !keys.Revoke(id) {
+		return ctx.Error(http.StatusNotFound, "API key not found")
+	}
+
+	keys.Save()
+	return nil
+}
@@ -0,0 +1,163 @@
+package apiv1
+
+import (
+	"net/http"
+	"sort"
+
+	"github.com/aerogo/aero"
+	"github.com/animenotifier/notify.moe/arn"
+	"github.com/animenotifier/notify.moe/arn/limits"
+)
+
+// maxNotifications is the maximum number of notifications returned.
+const maxNotifications = 50
+
+// MyAnimeList returns all anime list entries of the user, including private ones.
+// Requires the "read-list" scope.
+func MyAnimeList(ctx aero.Context, user *arn.User) error {
+	limit, offset, err := pagination(ctx.Query)
+
+	if err != nil {
+		return ctx.Error(http.StatusBadRequest, err)
+	}
+
+	animeList := user.AnimeList()
+
+	if animeList == nil {
+		return ctx.Error(http.StatusNotFound, "Anime list not found")
+	}
+
+	status := ctx.Query("status")
+	items := []*arn.AnimeListItem{}
+
+	for _, item := range animeList.Items {
+		if status != "" && item.Status != status {
+			continue
+		}
+
+		items = append(items, item)
+	}
+
+	return ctx.JSON(paginateListItems(items, limit, offset))
+}
+
+// EditMyAnimeListItem changes an anime list entry of the user and adds the anime if needed.
+// The JSON body can contain "status", "episodes", "rating", "notes" and "private".
+// Notes are cut off at the same length as in list imports.
+// Requires the "write-list" scope.
+func EditMyAnimeListItem(ctx aero.Context, user *arn.User) error {
+	animeList := user.AnimeList()
+
+	if animeList == nil {
+		return ctx.Error(http.StatusNotFound, "Anime list not found")
+	}
+
+	body, err := ctx.Request().Body().JSONObject()
+
+	if err != nil {
+		return ctx.Error(http.StatusBadRequest, err)
+	}
+
+	animeID := ctx.Get("id")
+	item := animeList.Find(animeID)
+
+	if item == nil {
+		err = animeList.Add(animeID)
+
+		if err != nil {
+			return ctx.Error(http.StatusBadRequest, err)
+		}
+
+		item = animeList.Find(animeID)
+	}
+
+	if status, exists := body["status"]; exists {
+		statusText, _ := status.(string)
+		err = item.SetStatus(statusText)
+
+		if err != nil {
+			return ctx.Error(http.StatusBadRequest, err)
+		}
+	}
+
+	if episodes, exists := body["episodes"]; exists {
+		number, isNumber := episodes.(float64)
+
+		if !isNumber {
+			return ctx.Error(http.StatusBadRequest, "Invalid episode number")
+		}
+
+		item.SetEpisodes(user, int(number))
+	}
+
+	if rating, exists := body["rating"]; exists {
+		values, isObject := rating.(map[string]interface{})
+
+		if !isObject {
+			return ctx.Error(http.StatusBadRequest, "Rating must be an object")
+		}
+
+		fields := map[string]*float64{
+			"overall":    &item.Rating.Overall,
+			"story":      &item.Rating.Story,
+			"visuals":    &item.Rating.Visuals,
+			"soundtrack": &item.Rating.Soundtrack,
+		}
+
+		for name, value := range values {
+			field, known := fields[name]
+			number, isNumber := value.(float64)
+
+			if !known || !isNumber {
+				return ctx.Error(http.StatusBadRequest, "Invalid rating: "+name)
+			}
+
+			*field = number
+		}
+	}
+
+	if notes, exists := body["notes"]; exists {
+		item.Notes, _ = notes.(string)
+
+		if len([]rune(item.Notes)) > limits.DefaultTextAreaMaxLength {
+			item.Notes = string([]rune(item.Notes)[:limits.DefaultTextAreaMaxLength])
+		}
+	}
+
+	if private, exists := body["private"]; exists {
+		item.Private, _ = private.(bool)
+	}
+
+	item.OnEdited(user)
+	animeList.Save()
+	return ctx.JSON(item)
+}
+
+// RemoveMyAnimeListItem removes an anime from the list of the user.
+// Requires the "write-list" scope.
+func RemoveMyAnimeListItem(ctx aero.Context, user *arn.User) error {
+	animeList := user.AnimeList()
+
+	if animeList == nil || !animeList.MoveToTrash(ctx.Get("id")) {
+		return ctx.Error(http.StatusNotFound, "Anime not found in the list")
+	}
+
+	animeList.Save()
+	return nil
+}
+
+// MyNotifications returns the latest notifications of the user.
+// Requires the "read-notifications" scope.
+func MyNotifications(ctx aero.Context, user *arn.User) error {
+	notifications := user.Notifications().Notifications()
+
+	sort.Slice(notifications, func(i, j int) bool {
+		return notifications[i].Created > notifications[j].Created
+	})
+
+	if len(notifications) > maxNotifications {
+		notifications = notifications[:maxNotifications]
+	}
+
+	return ctx.JSON(notifications)
+}
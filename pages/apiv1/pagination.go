@@ -0,0 +1,43 @@
+package apiv1
+
+import (
+	"errors"
+	"strconv"
+)
+
+// Pagination limits
+const (
+	defaultLimit = 20
+	maxLimit     = 100
+)
+
+// page is the response of endpoints returning lists.
+type page struct {
+	Items  interface{} `json:"items"`
+	Total  int         `json:"total"`
+	Limit  int         `json:"limit"`
+	Offset int         `json:"offset"`
+}
+
+// pagination reads the "limit" and "offset" query parameters.
+func pagination(query func(string) string) (limit int, offset int, err error) {
+	limit = defaultLimit
+
+	if value := query("limit"); value != "" {
+		limit, err = strconv.Atoi(value)
+
+		if err != nil || limit < 1 || limit > maxLimit {
+			return 0, 0, errors.New("Limit must be a number between 1 and 100")
+		}
+	}
+
+	if value := query("offset"); value != "" {
+		offset, err = strconv.Atoi(value)
+
+		if err != nil || offset < 0 {
+			return 0, 0, errors.New("Offset must be a positive number")
+		}
+	}
+
+	return limit, offset, nil
+}
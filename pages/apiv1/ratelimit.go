@@ -0,0 +1,73 @@
+package apiv1
+
+import (
+	"sync"
+	"time"
+)
+
+// requestsPerMinute is the number of requests allowed per API key and minute.
+const requestsPerMinute = 60
+
+// anonymousRequestsPerMinute is the number of requests allowed per IP address and minute without an API key.
+const anonymousRequestsPerMinute = 20
+
+var (
+	limiter          = newRateLimiter(requestsPerMinute, time.Minute)
+	anonymousLimiter = newRateLimiter(anonymousRequestsPerMinute, time.Minute)
+)
+
+// rateLimiter counts requests per key in fixed time windows.
+type rateLimiter struct {
+	limit    int
+	interval time.Duration
+	windows  map[string]*rateLimitWindow
+	mutex    sync.Mutex
+}
+
+// rateLimitWindow is the request count of a key since the window started.
+type rateLimitWindow struct {
+	start time.Time
+	count int
+}
+
+// newRateLimiter creates a rate limiter that allows limit requests per interval.
+func newRateLimiter(limit int, interval time.Duration) *rateLimiter {
+	return &rateLimiter{
+		limit:    limit,
+		interval: interval,
+		windows:  map[string]*rateLimitWindow{},
+	}
+}
+
+// Allow counts the request and tells whether it's within the limit.
+// It also returns the number of remaining requests in the current window.
+func (limiter *rateLimiter) Allow(key string) (int, bool) {
+	now := time.Now()
+
+	limiter.mutex.Lock()
+	defer limiter.mutex.Unlock()
+
+	window, exists := limiter.windows[key]
+
+	if !exists || now.Sub(window.start) >= limiter.interval {
+		limiter.removeExpired(now)
+		window = &rateLimitWindow{start: now}
+		limiter.windows[key] = window
+	}
+
+	if window.count >= limiter.limit {
+		return 0, false
+	}
+
+	window.count++
+	return limiter.limit - window.count, true
+}
+
+// removeExpired deletes windows that have ended so that the map doesn't grow forever.
+func (limiter *rateLimiter) removeExpired(now time.Time) {
+	for key, window := range limiter.windows {
+		if now.Sub(window.start) >= limiter.interval {
+			delete(limiter.windows, key)
+		}
+	}
+}
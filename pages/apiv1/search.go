@@ -0,0 +1,46 @@
+package apiv1
+
+import (
+	"net/http"
+
+	"github.com/aerogo/aero"
+	"github.com/animenotifier/notify.moe/arn/search"
+)
+
+// maxSearchResults is the maximum number of search results that can be paginated.
+const maxSearchResults = 500
+
+// SearchAnime returns the anime matching the "q" query parameter, ordered by relevance.
+func SearchAnime(ctx aero.Context) error {
+	term := ctx.Query("q")
+
+	if term == "" {
+		return ctx.Error(http.StatusBadRequest, "Missing search term")
+	}
+
+	limit, offset, err := pagination(ctx.Query)
+
+	if err != nil {
+		return ctx.Error(http.StatusBadRequest, err)
+	}
+
+	results := search.Anime(term, maxSearchResults)
+	total := len(results)
+
+	if offset > len(results) {
+		offset = len(results)
+	}
+
+	results = results[offset:]
+
+	if len(results) > limit {
+		results = results[:limit]
+	}
+
+	return ctx.JSON(&page{
+		Items:  results,
+		Total:  total,
+		Limit:  limit,
+		Offset: offset,
+	})
+}
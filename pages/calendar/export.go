@@ -0,0 +1,89 @@
+package calendar
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/aerogo/aero"
+	"github.com/animenotifier/notify.moe/arn"
+	"github.com/animenotifier/notify.moe/arn/validate"
+	"github.com/animenotifier/notify.moe/assets"
+	"github.com/animenotifier/notify.moe/utils/ical"
+)
+
+// Export renders the upcoming episodes of the anime a user is watching as an iCalendar feed.
+func Export(ctx aero.Context) error {
+	nick := ctx.Get("nick")
+	user := arn.GetUserFromContext(ctx)
+	viewUser, err := arn.GetVisibleUserByNick(nick)
+
+	if err != nil {
+		return ctx.Error(http.StatusNotFound, "User not found", err)
+	}
+
+	isOwner := user != nil && user.ID == viewUser.ID
+
+	feed := Feed(viewUser, viewUser.AnimeList(), isOwner, func(item *arn.AnimeListItem) []*ical.Event {
+		anime := item.Anime()
+
+		if anime == nil {
+			return nil
+		}
+
+		return episodeEvents(anime, viewUser)
+	})
+
+	ctx.Response().SetHeader("Content-Type", "text/calendar; charset=utf-8")
+	return ctx.String(feed.String())
+}
+
+// Feed builds the calendar from the events of each item on the watching list.
+// Private items are only included when the feed is requested by the list owner.
+func Feed(viewUser *arn.User, animeList *arn.AnimeList, includePrivate bool, events func(*arn.AnimeListItem) []*ical.Event) *ical.Calendar {
+	feed := &ical.Calendar{
+		Name: viewUser.Nick + " - Anime",
+	}
+
+	if !includePrivate {
+		animeList = animeList.WithoutPrivateItems()
+	}
+
+	for _, item := range animeList.Watching().Items {
+		feed.Events = append(feed.Events, events(item)...)
+	}
+
+	return feed
+}
+
+// episodeEvents returns one event for each upcoming episode of the anime.
+func episodeEvents(anime *arn.Anime, viewUser *arn.User) []*ical.Event {
+	var events []*ical.Event
+
+	for _, upcoming := range anime.UpcomingEpisodes() {
+		episode := upcoming.Episode
+
+		// Since UpcomingEpisodes validated the date, we can ignore the error value.
+		start, _ := time.Parse(time.RFC3339, episode.AiringDate.Start)
+		end := start.Add(time.Duration(anime.EpisodeLength) * time.Minute)
+
+		if validate.DateTime(episode.AiringDate.End) {
+			airingEnd, _ := time.Parse(time.RFC3339, episode.AiringDate.End)
+
+			if airingEnd.After(start) {
+				end = airingEnd
+			}
+		}
+
+		events = append(events, &ical.Event{
+			UID:         episode.ID + "@notify.moe",
+			Start:       start,
+			End:         end,
+			Summary:     fmt.Sprintf("%s - Episode %d", anime.TitleByUser(viewUser), episode.Number),
+			Description: episode.Title.Romaji,
+			URL:         "https://" + assets.Domain + anime.Link(),
+		})
+	}
+
+	return events
+}
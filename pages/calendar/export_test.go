@@ -0,0 +1,44 @@
+package calendar_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/akyoto/assert"
+	"github.com/animenotifier/notify.moe/arn"
+	"github.com/animenotifier/notify.moe/pages/calendar"
+	"github.com/animenotifier/notify.moe/utils/ical"
+)
+
+func TestFeedWithoutPrivateItems(t *testing.T) {
+	viewUser := &arn.User{ID: "u", Nick: "Test"}
+	start := time.Date(2019, 10, 5, 15, 30, 0, 0, time.UTC)
+
+	animeList := &arn.AnimeList{
+		UserID: viewUser.ID,
+		Items: []*arn.AnimeListItem{
+			{AnimeID: "public", Status: arn.AnimeListStatusWatching},
+			{AnimeID: "private", Status: arn.AnimeListStatusWatching, Private: true},
+		},
+	}
+
+	events := func(item *arn.AnimeListItem) []*ical.Event {
+		return []*ical.Event{
+			{
+				UID:     "episode-" + item.AnimeID + "@notify.moe",
+				Start:   start,
+				End:     start.Add(24 * time.Minute),
+				Summary: item.AnimeID,
+			},
+		}
+	}
+
+	feed := calendar.Feed(viewUser, animeList, false, events).String()
+	assert.Equal(t, strings.Count(feed, "BEGIN:VEVENT"), 1)
+	assert.Contains(t, feed, "episode-public@notify.moe")
+	assert.NotContains(t, feed, "episode-private@notify.moe")
+
+	feed = calendar.Feed(viewUser, animeList, true, events).String()
+	assert.Equal(t, strings.Count(feed, "BEGIN:VEVENT"), 2)
+}
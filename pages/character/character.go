@@ -11,6 +11,7 @@ import (
 	"github.com/animenotifier/notify.moe/components"
 	"github.com/animenotifier/notify.moe/server/middleware"
 	"github.com/animenotifier/notify.moe/utils"
+	"github.com/animenotifier/notify.moe/utils/opengraph"
 )
 
 const (
@@ -104,23 +105,15 @@ func Get(ctx aero.Context) error {
 	description := utils.CutLongDescription(character.Description)
 
 	customCtx := ctx.(*middleware.OpenGraphContext)
-	customCtx.OpenGraph = &arn.OpenGraph{
-		Tags: map[string]string{
-			"og:title":       character.Name.Canonical,
-			"og:image":       "https:" + character.ImageLink("large"),
-			"og:url":         "https://" + assets.Domain + character.Link(),
-			"og:site_name":   "notify.moe",
-			"og:description": description,
-
-			// The OpenGraph type "profile" is meant for real-life persons but I think it's okay in this context.
-			// An alternative would be to use "article" which is mostly used for blog posts and news.
-			"og:type": "profile",
-		},
-		Meta: map[string]string{
-			"description": description,
-			"keywords":    character.Name.Canonical + ",anime,character",
-		},
-	}
+	customCtx.OpenGraph = opengraph.New(character.Name.Canonical, description).
+		URL(character.Link()).
+		Image(utils.BestImageVariant(character, utils.OpenGraphImageRatio)).
+		// The OpenGraph type "profile" is meant for real-life persons but I think it's okay in this context.
+		// An alternative would be to use "article" which is mostly used for blog posts and news.
+		Type("profile").
+		Keywords(character.Name.Canonical, "anime", "character").
+		StructuredData(getStructuredData(character, description)).
+		Build()
 
 	// Friends
 	var friends []*arn.User
@@ -140,3 +133,21 @@ func Get(ctx aero.Context) error {
 
 	return ctx.HTML(components.CharacterDetails(character, characterAnime, quotes, friends, relevantCharacters, mainQuote, user))
 }
+
+// getStructuredData describes the character as a schema.org person.
+func getStructuredData(character *arn.Character, description string) arn.StructuredData {
+	data := arn.NewStructuredData("Person")
+	data["name"] = character.Name.Canonical
+	data["url"] = "https://" + assets.Domain + character.Link()
+	data["image"] = "https:" + character.ImageLink("large")
+
+	if description != "" {
+		data["description"] = description
+	}
+
+	if character.Name.Japanese != "" {
+		data["alternateName"] = character.Name.Japanese
+	}
+
+	return data
+}
@@ -1,15 +1,25 @@
 package characters
 
-import (
-	"github.com/aerogo/aero"
-	"github.com/animenotifier/notify.moe/arn"
-)
+import "github.com/aerogo/aero"
 
-// Best characters.
+// Best characters of all time.
 func Best(ctx aero.Context) error {
-	characters := fetchAll()
+	return renderRanking(ctx, WindowAllTime)
+}
+
+// BestThisWeek shows the most liked characters of the last 7 days.
+func BestThisWeek(ctx aero.Context) error {
+	return renderRanking(ctx, WindowWeek)
+}
 
-	arn.SortCharactersByLikes(characters)
+// BestThisMonth shows the most liked characters of the last month.
+func BestThisMonth(ctx aero.Context) error {
+	return renderRanking(ctx, WindowMonth)
+}
 
-	return render(ctx, characters)
+// renderRanking renders the best characters within the time window.
+func renderRanking(ctx aero.Context, window string) error {
+	return render(ctx, func(index int, limit int) *QueryResult {
+		return Ranking(window, index, limit)
+	})
 }
@@ -1,18 +1,14 @@
 package characters
 
-import (
-	"sort"
-
-	"github.com/aerogo/aero"
-)
+import "github.com/aerogo/aero"
 
 // Latest characters.
 func Latest(ctx aero.Context) error {
-	characters := fetchAll()
-
-	sort.Slice(characters, func(i, j int) bool {
-		return characters[i].Created > characters[j].Created
+	return render(ctx, func(index int, limit int) *QueryResult {
+		return Query(QueryOptions{
+			Sort:  SortLatest,
+			Index: index,
+			Limit: limit,
+		})
 	})
-
-	return render(ctx, characters)
 }
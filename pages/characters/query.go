@@ -0,0 +1,152 @@
+package characters
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/animenotifier/notify.moe/arn"
+	"github.com/animenotifier/notify.moe/arn/stringutils"
+)
+
+// Sort modes for character queries.
+const (
+	SortLatest = "latest"
+	SortBest   = "best"
+	SortName   = "name"
+)
+
+// QueryOptions describes which characters to list and in what order.
+// Empty filters are ignored.
+type QueryOptions struct {
+	// Trait only accepts characters with an attribute of that name or value, e.g. "Twin-tails".
+	Trait string
+
+	// AnimeID only accepts characters appearing in the given anime.
+	AnimeID arn.AnimeID
+
+	// Search only accepts characters whose name contains the search term.
+	Search string
+
+	// Sort is one of the sort modes. Defaults to SortLatest.
+	Sort string
+
+	// Index is the position of the first result.
+	Index int
+
+	// Limit is the maximum number of results. Zero means no limit.
+	Limit int
+}
+
+// QueryResult is the requested page of characters.
+type QueryResult struct {
+	Characters []*arn.Character
+
+	// Total is the number of characters matching the filters before pagination.
+	Total int
+}
+
+// Query returns the published characters matching the options.
+func Query(options QueryOptions) *QueryResult {
+	return QueryCharacters(arn.FilterCharacters(func(character *arn.Character) bool {
+		return !character.IsDraft
+	}), options)
+}
+
+// QueryCharacters filters, sorts and paginates the given characters.
+func QueryCharacters(allCharacters []*arn.Character, options QueryOptions) *QueryResult {
+	var inAnime func(arn.CharacterID) bool
+
+	if options.AnimeID != "" {
+		animeCharacters, err := arn.GetAnimeCharacters(options.AnimeID)
+
+		if err != nil {
+			return &QueryResult{}
+		}
+
+		inAnime = animeCharacters.Contains
+	}
+
+	trait := strings.ToLower(options.Trait)
+	term := strings.TrimSpace(strings.ToLower(stringutils.RemoveSpecialCharacters(options.Search)))
+	characters := make([]*arn.Character, 0, len(allCharacters))
+
+	for _, character := range allCharacters {
+		if inAnime != nil && !inAnime(character.ID) {
+			continue
+		}
+
+		if trait != "" && !hasTrait(character, trait) {
+			continue
+		}
+
+		if term != "" && !nameContains(character, term) {
+			continue
+		}
+
+		characters = append(characters, character)
+	}
+
+	switch options.Sort {
+	case SortBest:
+		arn.SortCharactersByLikes(characters)
+
+	case SortName:
+		sort.Slice(characters, func(i, j int) bool {
+			if characters[i].Name.Canonical == characters[j].Name.Canonical {
+				return characters[i].ID < characters[j].ID
+			}
+
+			return characters[i].Name.Canonical < characters[j].Name.Canonical
+		})
+
+	default:
+		sort.Slice(characters, func(i, j int) bool {
+			if characters[i].Created == characters[j].Created {
+				return characters[i].ID < characters[j].ID
+			}
+
+			return characters[i].Created > characters[j].Created
+		})
+	}
+
+	result := &QueryResult{
+		Total: len(characters),
+	}
+
+	if options.Index >= len(characters) {
+		return result
+	}
+
+	characters = characters[options.Index:]
+
+	if options.Limit > 0 && len(characters) > options.Limit {
+		characters = characters[:options.Limit]
+	}
+
+	result.Characters = characters
+	return result
+}
+
+// hasTrait tells whether the character has an attribute with the given lowercase name or value.
+func hasTrait(character *arn.Character, trait string) bool {
+	for _, attribute := range character.Attributes {
+		if strings.ToLower(attribute.Name) == trait || strings.ToLower(attribute.Value) == trait {
+			return true
+		}
+	}
+
+	return false
+}
+
+// nameContains tells whether any of the character names contains the given search term.
+func nameContains(character *arn.Character, term string) bool {
+	names := append([]string{character.Name.Canonical, character.Name.English, character.Name.Japanese}, character.Name.Synonyms...)
+
+	for _, name := range names {
+		if name != "" && strings.Contains(strings.ToLower(stringutils.RemoveSpecialCharacters(name)), term) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,98 @@
+package characters_test
+
+import (
+	"testing"
+
+	"github.com/akyoto/assert"
+	"github.com/animenotifier/notify.moe/arn"
+	"github.com/animenotifier/notify.moe/pages/characters"
+)
+
+func newCharacter(id string, name string, created string, likes int, traits ...string) *arn.Character {
+	character := &arn.Character{}
+	character.ID = id
+	character.Name.Canonical = name
+	character.Created = created
+
+	for i := 0; i < likes; i++ {
+		character.Likes = append(character.Likes, arn.GenerateID("User"))
+	}
+
+	for _, trait := range traits {
+		character.Attributes = append(character.Attributes, &arn.CharacterAttribute{Name: "Hair", Value: trait})
+	}
+
+	return character
+}
+
+func testCharacters() []*arn.Character {
+	return []*arn.Character{
+		newCharacter("a", "Asuka Langley", "2019-01-01T00:00:00Z", 5, "Twin-tails"),
+		newCharacter("b", "Rei Ayanami", "2019-02-01T00:00:00Z", 9),
+		newCharacter("c", "Kagami Hiiragi", "2019-03-01T00:00:00Z", 2, "Twin-tails"),
+		newCharacter("d", "Tsukasa Hiiragi", "2019-04-01T00:00:00Z", 7),
+		newCharacter("e", "Rin Tohsaka", "2019-05-01T00:00:00Z", 8, "Twin-tails"),
+	}
+}
+
+func ids(characters []*arn.Character) []string {
+	result := make([]string, len(characters))
+
+	for i, character := range characters {
+		result[i] = character.ID
+	}
+
+	return result
+}
+
+func TestQueryCombinedFilters(t *testing.T) {
+	// Newest characters with a trait, second page
+	result := characters.QueryCharacters(testCharacters(), characters.QueryOptions{
+		Trait: "twin-tails",
+		Sort:  characters.SortLatest,
+		Index: 1,
+		Limit: 1,
+	})
+
+	assert.Equal(t, result.Total, 3)
+	assert.DeepEqual(t, ids(result.Characters), []string{"c"})
+
+	// Best characters with a trait
+	result = characters.QueryCharacters(testCharacters(), characters.QueryOptions{
+		Trait: "Twin-tails",
+		Sort:  characters.SortBest,
+	})
+
+	assert.Equal(t, result.Total, 3)
+	assert.DeepEqual(t, ids(result.Characters), []string{"e", "a", "c"})
+
+	// Search sorted by name
+	result = characters.QueryCharacters(testCharacters(), characters.QueryOptions{
+		Search: "hiiragi",
+		Sort:   characters.SortName,
+		Limit:  10,
+	})
+
+	assert.Equal(t, result.Total, 2)
+	assert.DeepEqual(t, ids(result.Characters), []string{"c", "d"})
+}
+
+func TestQueryTotal(t *testing.T) {
+	// The total ignores pagination
+	result := characters.QueryCharacters(testCharacters(), characters.QueryOptions{
+		Index: 3,
+		Limit: 10,
+	})
+
+	assert.Equal(t, result.Total, 5)
+	assert.DeepEqual(t, ids(result.Characters), []string{"b", "a"})
+
+	// Pages beyond the end are empty but still report the total
+	result = characters.QueryCharacters(testCharacters(), characters.QueryOptions{
+		Search: "rei",
+		Index:  5,
+	})
+
+	assert.Equal(t, result.Total, 1)
+	assert.Equal(t, len(result.Characters), 0)
+}
@@ -0,0 +1,137 @@
+package characters
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/animenotifier/notify.moe/arn"
+)
+
+// Time windows for the best characters ranking.
+const (
+	WindowAllTime = ""
+	WindowWeek    = "week"
+	WindowMonth   = "month"
+)
+
+// rankingRefreshInterval defines how often the rankings are recomputed.
+const rankingRefreshInterval = 10 * time.Minute
+
+// rankings contains the best characters for each time window.
+type rankings struct {
+	windows map[string][]*arn.Character
+	created time.Time
+}
+
+var (
+	currentRankings    *rankings
+	rankingsRebuilding bool
+	rankingsMutex      sync.Mutex
+)
+
+// Ranking returns the requested page of the best characters within the time window.
+func Ranking(window string, index int, limit int) *QueryResult {
+	ranked := rankedCharacters(window)
+	total := len(ranked)
+
+	if index >= total {
+		return &QueryResult{Total: total}
+	}
+
+	ranked = ranked[index:]
+
+	if limit > 0 && len(ranked) > limit {
+		ranked = ranked[:limit]
+	}
+
+	return &QueryResult{
+		Characters: ranked,
+		Total:      total,
+	}
+}
+
+// rankedCharacters returns the precomputed ranking of the time window.
+// Only the first request waits for the rankings, outdated rankings
+// are recomputed in the background while the old ones are still served.
+func rankedCharacters(window string) []*arn.Character {
+	rankingsMutex.Lock()
+	defer rankingsMutex.Unlock()
+
+	if currentRankings == nil {
+		currentRankings = buildRankings()
+	} else if time.Since(currentRankings.created) > rankingRefreshInterval && !rankingsRebuilding {
+		rankingsRebuilding = true
+		go rebuildRankings()
+	}
+
+	return currentRankings.windows[window]
+}
+
+// rebuildRankings replaces the rankings with new ones.
+func rebuildRankings() {
+	rebuilt := buildRankings()
+
+	rankingsMutex.Lock()
+	currentRankings = rebuilt
+	rankingsRebuilding = false
+	rankingsMutex.Unlock()
+}
+
+// buildRankings computes the rankings of all published characters.
+func buildRankings() *rankings {
+	return computeRankings(arn.FilterCharacters(func(character *arn.Character) bool {
+		return !character.IsDraft
+	}), time.Now())
+}
+
+// computeRankings sorts the characters by likes for every time window.
+func computeRankings(characters []*arn.Character, now time.Time) *rankings {
+	allTime := make([]*arn.Character, len(characters))
+	copy(allTime, characters)
+	arn.SortCharactersByLikes(allTime)
+
+	return &rankings{
+		windows: map[string][]*arn.Character{
+			WindowAllTime: allTime,
+			WindowWeek:    RankCharactersSince(characters, now.AddDate(0, 0, -7)),
+			WindowMonth:   RankCharactersSince(characters, now.AddDate(0, -1, 0)),
+		},
+		created: now,
+	}
+}
+
+// RankCharactersSince returns the characters that received likes after the given date,
+// sorted by the number of these likes.
+func RankCharactersSince(characters []*arn.Character, since time.Time) []*arn.Character {
+	likes := map[arn.CharacterID]int{}
+	ranked := []*arn.Character{}
+
+	for _, character := range characters {
+		count := character.CountLikesSince(since)
+
+		if count == 0 {
+			continue
+		}
+
+		likes[character.ID] = count
+		ranked = append(ranked, character)
+	}
+
+	sort.Slice(ranked, func(i, j int) bool {
+		a := ranked[i]
+		b := ranked[j]
+
+		if likes[a.ID] != likes[b.ID] {
+			return likes[a.ID] > likes[b.ID]
+		}
+
+		if len(a.Likes) != len(b.Likes) {
+			return len(a.Likes) > len(b.Likes)
+		}
+
+		return a.Created > b.Created
+	})
+
+	return ranked
+}
@@ -0,0 +1,38 @@
+package characters_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/akyoto/assert"
+	"github.com/animenotifier/notify.moe/arn"
+	"github.com/animenotifier/notify.moe/pages/characters"
+)
+
+func TestRankCharactersSince(t *testing.T) {
+	now := time.Date(2019, 6, 30, 0, 0, 0, 0, time.UTC)
+	recently := now.AddDate(0, 0, -2).Format(time.RFC3339)
+	longAgo := now.AddDate(0, -3, 0).Format(time.RFC3339)
+	all := testCharacters()
+
+	likeDates := func(character *arn.Character, dates ...string) {
+		character.LikeDates = map[arn.UserID]string{}
+
+		for i, date := range dates {
+			character.LikeDates[character.Likes[i]] = date
+		}
+	}
+
+	likeDates(all[0], recently, recently)
+	likeDates(all[1], longAgo, longAgo, recently)
+	likeDates(all[2], recently, recently)
+
+	// Likes that were removed afterwards don't count
+	all[2].Unlike(all[2].Likes[0])
+
+	ranked := characters.RankCharactersSince(all, now.AddDate(0, 0, -7))
+	assert.DeepEqual(t, ids(ranked), []string{"a", "b", "c"})
+
+	ranked = characters.RankCharactersSince(all, now.AddDate(0, -6, 0))
+	assert.DeepEqual(t, ids(ranked), []string{"b", "a", "c"})
+}
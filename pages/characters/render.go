@@ -12,32 +12,29 @@ const (
 	charactersPerScroll = 39
 )
 
-// render renders the characters page with the given characters.
-func render(ctx aero.Context, allCharacters []*arn.Character) error {
+// render renders the characters page with the page of characters returned by fetch.
+func render(ctx aero.Context, fetch func(index int, limit int) *QueryResult) error {
 	user := arn.GetUserFromContext(ctx)
 	index, _ := ctx.GetInt("index")
 	tag := ctx.Get("tag")
 
-	// Slice the part that we need
-	characters := allCharacters[index:]
+	// Query the part that we need
 	maxLength := charactersFirstLoad
 
 	if index > 0 {
 		maxLength = charactersPerScroll
 	}
 
-	if len(characters) > maxLength {
-		characters = characters[:maxLength]
-	}
+	result := fetch(index, maxLength)
 
 	// Next index
-	nextIndex := infinitescroll.NextIndex(ctx, len(allCharacters), maxLength, index)
+	nextIndex := infinitescroll.NextIndex(ctx, result.Total, maxLength, index)
 
 	// In case we're scrolling, send Characters only (without the page frame)
 	if index > 0 {
-		return ctx.HTML(components.CharactersScrollable(characters, user))
+		return ctx.HTML(components.CharactersScrollable(result.Characters, user))
 	}
 
 	// Otherwise, send the full page
-	return ctx.HTML(components.Characters(characters, nextIndex, tag, user))
+	return ctx.HTML(components.Characters(result.Characters, nextIndex, tag, user))
 }
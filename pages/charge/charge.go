@@ -16,5 +16,5 @@ func Get(ctx aero.Context) error {
 		return ctx.Error(http.StatusUnauthorized, "Not logged in")
 	}
 
-	return ctx.HTML(components.Charge(user))
+	return ctx.HTML(components.Charge(ctx, user))
 }
@@ -6,10 +6,9 @@ import (
 
 	"github.com/aerogo/aero"
 	"github.com/animenotifier/notify.moe/arn"
-	"github.com/animenotifier/notify.moe/assets"
 	"github.com/animenotifier/notify.moe/components"
 	"github.com/animenotifier/notify.moe/server/middleware"
-	"github.com/animenotifier/notify.moe/utils"
+	"github.com/animenotifier/notify.moe/utils/opengraph"
 )
 
 // Get renders a company page.
@@ -22,29 +21,17 @@ func Get(ctx aero.Context) error {
 		return ctx.Error(http.StatusNotFound, "Company not found", err)
 	}
 
-	description := utils.CutLongDescription(company.Description)
+	description := company.Description
 
-	openGraph := &arn.OpenGraph{
-		Tags: map[string]string{
-			"og:title":     company.Name.English,
-			"og:url":       "https://" + assets.Domain + company.Link(),
-			"og:site_name": "notify.moe",
-			"og:type":      "article",
-		},
-	}
-
-	// if company.Image != "" {
-	// 	openGraph.Tags["og:image"] = company.Image
-	// }
-
-	if description != "" {
-		openGraph.Tags["og:description"] = description
-	} else {
-		openGraph.Tags["og:description"] = company.Name.English + " company information."
+	if description == "" {
+		description = company.Name.English + " company information."
 	}
 
 	customCtx := ctx.(*middleware.OpenGraphContext)
-	customCtx.OpenGraph = openGraph
+	customCtx.OpenGraph = opengraph.New(company.Name.English, description).
+		URL(company.Link()).
+		Type("article").
+		Build()
 
 	studioAnime, producedAnime, licensedAnime := company.Anime()
 
@@ -5,10 +5,10 @@ import (
 
 	"github.com/aerogo/aero"
 	"github.com/animenotifier/notify.moe/arn"
-	"github.com/animenotifier/notify.moe/assets"
 	"github.com/animenotifier/notify.moe/components"
 	"github.com/animenotifier/notify.moe/server/middleware"
 	"github.com/animenotifier/notify.moe/utils/editform"
+	"github.com/animenotifier/notify.moe/utils/opengraph"
 )
 
 // Edit company.
@@ -22,14 +22,9 @@ func Edit(ctx aero.Context) error {
 	}
 
 	customCtx := ctx.(*middleware.OpenGraphContext)
-	customCtx.OpenGraph = &arn.OpenGraph{
-		Tags: map[string]string{
-			"og:title":     company.Name.English,
-			"og:url":       "https://" + assets.Domain + company.Link(),
-			"og:site_name": "notify.moe",
-			// "og:image":     company.Image,
-		},
-	}
+	customCtx.OpenGraph = opengraph.New(company.Name.English, "").
+		URL(company.Link()).
+		Build()
 
 	return ctx.HTML(components.CompanyTabs(company, user) + editform.Render(company, "Edit company", user))
 }
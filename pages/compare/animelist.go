@@ -17,13 +17,13 @@ func AnimeList(ctx aero.Context) error {
 	nickA := ctx.Get("nick-1")
 	nickB := ctx.Get("nick-2")
 
-	a, err := arn.GetUserByNick(nickA)
+	a, err := arn.GetVisibleUserByNick(nickA)
 
 	if err != nil || a == nil {
 		return ctx.Error(http.StatusNotFound, "User not found: "+nickA, err)
 	}
 
-	b, err := arn.GetUserByNick(nickB)
+	b, err := arn.GetVisibleUserByNick(nickB)
 
 	if err != nil || b == nil {
 		return ctx.Error(http.StatusNotFound, "User not found: "+nickB, err)
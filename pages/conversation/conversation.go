@@ -0,0 +1,33 @@
+package conversation
+
+import (
+	"net/http"
+
+	"github.com/aerogo/aero"
+	"github.com/animenotifier/notify.moe/arn"
+	"github.com/animenotifier/notify.moe/components"
+)
+
+// Get shows a conversation and marks it as read.
+func Get(ctx aero.Context) error {
+	user := arn.GetUserFromContext(ctx)
+
+	if user == nil {
+		return ctx.Error(http.StatusUnauthorized, "Not logged in")
+	}
+
+	conversation, err := arn.GetConversation(ctx.Get("id"))
+
+	// Don't reveal that a conversation exists to users outside of it
+	if err != nil || !conversation.HasParticipant(user.ID) {
+		return ctx.Error(http.StatusNotFound, "Conversation not found", err)
+	}
+
+	if conversation.IsUnreadBy(user.ID) {
+		conversation.MarkAsRead(user.ID)
+		conversation.Save()
+		arn.BroadcastUnreadConversations(user)
+	}
+
+	return ctx.HTML(components.Conversation(conversation, user))
+}
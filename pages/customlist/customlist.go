@@ -0,0 +1,47 @@
+package customlist
+
+import (
+	"net/http"
+
+	"github.com/aerogo/aero"
+	"github.com/animenotifier/notify.moe/arn"
+	"github.com/animenotifier/notify.moe/components"
+	"github.com/animenotifier/notify.moe/server/middleware"
+	"github.com/animenotifier/notify.moe/utils/opengraph"
+)
+
+// Get shows a custom list.
+func Get(ctx aero.Context) error {
+	user := arn.GetUserFromContext(ctx)
+	list, err := arn.GetCustomList(ctx.Get("id"))
+
+	if err != nil || !list.IsVisibleTo(user) {
+		return ctx.Error(http.StatusNotFound, "List not found", err)
+	}
+
+	owner := list.Creator()
+	animes := list.Anime()
+
+	builder := opengraph.New(list.Name, list.Description).URL(list.Link())
+
+	if len(animes) > 0 {
+		builder.Image(animes[0].ImageLink("large"))
+	}
+
+	customCtx := ctx.(*middleware.OpenGraphContext)
+	customCtx.OpenGraph = builder.Build()
+
+	return ctx.HTML(components.CustomList(list, animes, owner, user))
+}
+
+// ByUser shows all custom lists of a user.
+func ByUser(ctx aero.Context) error {
+	user := arn.GetUserFromContext(ctx)
+	viewUser, err := arn.GetVisibleUserByNick(ctx.Get("nick"))
+
+	if err != nil {
+		return ctx.Error(http.StatusNotFound, "User not found", err)
+	}
+
+	return ctx.HTML(components.CustomLists(viewUser.CustomLists(user), viewUser, user))
+}
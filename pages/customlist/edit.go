@@ -0,0 +1,27 @@
+package customlist
+
+import (
+	"net/http"
+
+	"github.com/aerogo/aero"
+	"github.com/animenotifier/notify.moe/arn"
+	"github.com/animenotifier/notify.moe/components"
+	"github.com/animenotifier/notify.moe/utils/editform"
+)
+
+// Edit shows the form to change the name, description and visibility of a custom list.
+func Edit(ctx aero.Context) error {
+	user := arn.GetUserFromContext(ctx)
+
+	if user == nil {
+		return ctx.Error(http.StatusUnauthorized, "Not logged in")
+	}
+
+	list, err := arn.GetCustomList(ctx.Get("id"))
+
+	if err != nil || list.CreatedBy != user.ID {
+		return ctx.Error(http.StatusNotFound, "List not found", err)
+	}
+
+	return ctx.HTML(components.CustomListTabs(list, user) + editform.Render(list, "Edit list", user))
+}
@@ -0,0 +1,82 @@
+package drafts
+
+import (
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/aerogo/aero"
+	"github.com/animenotifier/notify.moe/arn"
+	"github.com/animenotifier/notify.moe/arn/limits"
+)
+
+// Preview renders markdown the same way as a posted text.
+func Preview(ctx aero.Context) error {
+	user := arn.GetUserFromContext(ctx)
+
+	if user == nil {
+		return ctx.Error(http.StatusUnauthorized, "Not logged in")
+	}
+
+	body, err := ctx.Request().Body().JSONObject()
+
+	if err != nil {
+		return ctx.Error(http.StatusBadRequest, err)
+	}
+
+	text, _ := body["text"].(string)
+
+	if utf8.RuneCountInString(text) > limits.DefaultTextAreaMaxLength {
+		return ctx.Error(http.StatusBadRequest, "Text too long")
+	}
+
+	return ctx.HTML(arn.RenderMarkdownWithFootnotes(text, "preview-"))
+}
+
+// Save stores the unposted text of the user.
+func Save(ctx aero.Context) error {
+	user := arn.GetUserFromContext(ctx)
+
+	if user == nil {
+		return ctx.Error(http.StatusUnauthorized, "Not logged in")
+	}
+
+	body, err := ctx.Request().Body().JSONObject()
+
+	if err != nil {
+		return ctx.Error(http.StatusBadRequest, err)
+	}
+
+	key, _ := body["key"].(string)
+	title, _ := body["title"].(string)
+	text, _ := body["text"].(string)
+	tag, _ := body["tag"].(string)
+
+	if key == "" || len(key) > limits.DefaultTextMaxLength {
+		return ctx.Error(http.StatusBadRequest, "Invalid draft key")
+	}
+
+	if utf8.RuneCountInString(title) > limits.DefaultTextMaxLength || utf8.RuneCountInString(text) > limits.DefaultTextAreaMaxLength {
+		return ctx.Error(http.StatusBadRequest, "Text too long")
+	}
+
+	drafts := user.Drafts()
+
+	// Empty texts don't need to be restored
+	if strings.TrimSpace(title) == "" && strings.TrimSpace(text) == "" {
+		if drafts.Remove(key) {
+			drafts.Save()
+		}
+
+		return nil
+	}
+
+	drafts.Set(key, &arn.PostDraft{
+		Title: title,
+		Text:  text,
+		Tag:   tag,
+	})
+
+	drafts.Save()
+	return nil
+}
@@ -0,0 +1,44 @@
+package edits
+
+import (
+	"sort"
+
+	"github.com/aerogo/aero"
+	"github.com/animenotifier/notify.moe/arn"
+	"github.com/animenotifier/notify.moe/components"
+)
+
+// maxRecentEdits is the number of field edits shown on the review page.
+const maxRecentEdits = 100
+
+// Recent shows the latest field edits so that editors can revert vandalism.
+func Recent(ctx aero.Context) error {
+	user := arn.GetUserFromContext(ctx)
+
+	entries := arn.FilterEditLogEntries(func(entry *arn.EditLogEntry) bool {
+		return entry.Action == "edit"
+	})
+
+	arn.SortEditLogEntriesLatestFirst(entries)
+
+	if len(entries) > maxRecentEdits {
+		entries = entries[:maxRecentEdits]
+	}
+
+	return ctx.HTML(components.RecentEdits(entries, user))
+}
+
+// Pending shows the edits of low-trust editors that need to be reviewed, oldest first.
+func Pending(ctx aero.Context) error {
+	user := arn.GetUserFromContext(ctx)
+
+	pending := arn.FilterPendingEdits(func(edit *arn.PendingEdit) bool {
+		return edit.IsOpen()
+	})
+
+	sort.Slice(pending, func(i, j int) bool {
+		return pending[i].Created < pending[j].Created
+	})
+
+	return ctx.HTML(components.PendingEdits(pending, user))
+}
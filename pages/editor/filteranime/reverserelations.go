@@ -0,0 +1,22 @@
+package filteranime
+
+import (
+	"github.com/aerogo/aero"
+	"github.com/animenotifier/notify.moe/arn"
+	"github.com/animenotifier/notify.moe/assets"
+)
+
+// ReverseRelations lists anime whose related anime don't link back to them.
+func ReverseRelations(ctx aero.Context) error {
+	return editorList(
+		ctx,
+		"Anime with missing reverse relations",
+		func(anime *arn.Anime) bool {
+			relations := anime.Relations()
+			return relations != nil && len(relations.MissingReverse()) > 0
+		},
+		func(anime *arn.Anime) string {
+			return "https://" + assets.Domain + anime.Link() + "/edit/relations"
+		},
+	)
+}
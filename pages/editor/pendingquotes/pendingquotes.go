@@ -0,0 +1,24 @@
+package pendingquotes
+
+import (
+	"sort"
+
+	"github.com/aerogo/aero"
+	"github.com/animenotifier/notify.moe/arn"
+	"github.com/animenotifier/notify.moe/components"
+)
+
+// Get shows the quotes that need to be approved, oldest first.
+func Get(ctx aero.Context) error {
+	user := arn.GetUserFromContext(ctx)
+
+	quotes := arn.FilterQuotes(func(quote *arn.Quote) bool {
+		return !quote.IsDraft && quote.IsPending
+	})
+
+	sort.Slice(quotes, func(i, j int) bool {
+		return quotes[i].Created < quotes[j].Created
+	})
+
+	return ctx.HTML(components.PendingQuotes(quotes, user))
+}
@@ -0,0 +1,44 @@
+package streaminglinks
+
+import (
+	"sort"
+
+	"github.com/aerogo/aero"
+	"github.com/animenotifier/notify.moe/arn"
+	"github.com/animenotifier/notify.moe/components"
+)
+
+// maxResolvedSubmissions is the number of reviewed submissions shown in the history.
+const maxResolvedSubmissions = 100
+
+// Open shows the streaming link suggestions that need to be reviewed, oldest first.
+func Open(ctx aero.Context) error {
+	user := arn.GetUserFromContext(ctx)
+
+	submissions := arn.FilterStreamingLinkSubmissions(func(submission *arn.StreamingLinkSubmission) bool {
+		return submission.IsOpen()
+	})
+
+	sort.Slice(submissions, func(i, j int) bool {
+		return submissions[i].Created < submissions[j].Created
+	})
+
+	return ctx.HTML(components.StreamingLinkSubmissions(submissions, false, user))
+}
+
+// Resolved shows the latest approved and rejected streaming link suggestions.
+func Resolved(ctx aero.Context) error {
+	user := arn.GetUserFromContext(ctx)
+
+	submissions := arn.FilterStreamingLinkSubmissions(func(submission *arn.StreamingLinkSubmission) bool {
+		return !submission.IsOpen()
+	})
+
+	arn.SortStreamingLinkSubmissionsLatestFirst(submissions)
+
+	if len(submissions) > maxResolvedSubmissions {
+		submissions = submissions[:maxResolvedSubmissions]
+	}
+
+	return ctx.HTML(components.StreamingLinkSubmissions(submissions, true, user))
+}
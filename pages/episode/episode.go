@@ -29,6 +29,15 @@ func Get(ctx aero.Context) error {
 		return ctx.Error(http.StatusNotFound, "Anime not found", err)
 	}
 
+	// The first logged in visitor of an aired episode creates the discussion thread
+	if user != nil && episode.ThreadID == "" && episode.HasAired() {
+		_, err := episode.CreateDiscussionThread()
+
+		if err != nil {
+			fmt.Println(err)
+		}
+	}
+
 	// Does the episode exist?
 	uploaded := false
 
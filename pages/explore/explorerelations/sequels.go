@@ -15,7 +15,7 @@ import (
 func Sequels(ctx aero.Context) error {
 	nick := ctx.Get("nick")
 	user := arn.GetUserFromContext(ctx)
-	viewUser, err := arn.GetUserByNick(nick)
+	viewUser, err := arn.GetVisibleUserByNick(nick)
 
 	if err != nil {
 		return ctx.Error(http.StatusNotFound, "User not found", err)
@@ -0,0 +1,81 @@
+package feeds
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/aerogo/aero"
+	"github.com/animenotifier/notify.moe/arn"
+	"github.com/animenotifier/notify.moe/assets"
+	"github.com/animenotifier/notify.moe/utils"
+)
+
+// AnimeList sends a feed of the latest changes in the anime list of a user.
+func AnimeList(ctx aero.Context) error {
+	nick := ctx.Get("nick")
+	viewUser, err := arn.GetVisibleUserByNick(nick)
+
+	if err != nil {
+		return ctx.Error(http.StatusNotFound, "User not found", err)
+	}
+
+	animeList := viewUser.AnimeList()
+
+	if animeList == nil {
+		return ctx.Error(http.StatusNotFound, "Anime list not found")
+	}
+
+	listItems := make([]*arn.AnimeListItem, 0, len(animeList.Items))
+
+	for _, item := range animeList.Items {
+		if !item.Private {
+			listItems = append(listItems, item)
+		}
+	}
+
+	sort.Slice(listItems, func(i, j int) bool {
+		return lastChange(listItems[i]) > lastChange(listItems[j])
+	})
+
+	if len(listItems) > maxItems {
+		listItems = listItems[:maxItems]
+	}
+
+	items := make([]*feedItem, 0, len(listItems))
+
+	for _, item := range listItems {
+		anime := item.Anime()
+
+		if anime == nil {
+			continue
+		}
+
+		date := lastChange(item)
+
+		items = append(items, &feedItem{
+			ID:          fmt.Sprintf("https://%s%s#%s", assets.Domain, item.Link(viewUser.Nick), date),
+			Title:       fmt.Sprintf("%s: %s, episode %d", anime.Title.Canonical, item.StatusHumanReadable(), item.Episodes),
+			Description: utils.CutLongDescription(anime.Summary),
+			Link:        "https://" + assets.Domain + anime.Link(),
+			Author:      viewUser.Nick,
+			Date:        date,
+		})
+	}
+
+	return render(ctx, &feed{
+		Title:       viewUser.Nick + "'s anime list - " + assets.Domain,
+		Description: "Latest changes in the anime list of " + viewUser.Nick + ".",
+		Link:        "https://" + assets.Domain + "/+" + viewUser.Nick + "/animelist/watching",
+		Items:       items,
+	})
+}
+
+// lastChange returns the date of the latest change of the list item.
+func lastChange(item *arn.AnimeListItem) string {
+	if item.Edited != "" {
+		return item.Edited
+	}
+
+	return item.Created
+}
@@ -0,0 +1,69 @@
+package feeds
+
+import (
+	"encoding/xml"
+
+	"github.com/animenotifier/notify.moe/arn"
+)
+
+// atomFeed is an Atom 1.0 document.
+type atomFeed struct {
+	XMLName  xml.Name     `xml:"http://www.w3.org/2005/Atom feed"`
+	ID       string       `xml:"id"`
+	Title    string       `xml:"title"`
+	Subtitle string       `xml:"subtitle,omitempty"`
+	Updated  string       `xml:"updated"`
+	Link     atomLink     `xml:"link"`
+	Entries  []*atomEntry `xml:"entry"`
+}
+
+type atomEntry struct {
+	ID      string      `xml:"id"`
+	Title   string      `xml:"title"`
+	Updated string      `xml:"updated"`
+	Link    atomLink    `xml:"link"`
+	Summary string      `xml:"summary,omitempty"`
+	Author  *atomAuthor `xml:"author,omitempty"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+// newAtomFeed converts the feed to Atom.
+func newAtomFeed(feed *feed) *atomFeed {
+	atom := &atomFeed{
+		ID:       feed.Link,
+		Title:    feed.Title,
+		Subtitle: feed.Description,
+		Updated:  feed.updated(),
+		Link:     atomLink{Href: feed.Link},
+		Entries:  make([]*atomEntry, 0, len(feed.Items)),
+	}
+
+	if atom.Updated == "" {
+		atom.Updated = arn.DateTimeUTC()
+	}
+
+	for _, item := range feed.Items {
+		entry := &atomEntry{
+			ID:      item.ID,
+			Title:   item.Title,
+			Updated: item.Date,
+			Link:    atomLink{Href: item.Link},
+			Summary: item.Description,
+		}
+
+		if item.Author != "" {
+			entry.Author = &atomAuthor{Name: item.Author}
+		}
+
+		atom.Entries = append(atom.Entries, entry)
+	}
+
+	return atom
+}
@@ -0,0 +1,90 @@
+package feeds
+
+import (
+	"encoding/xml"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/aerogo/aero"
+)
+
+// maxItems is the maximum number of entries in a feed.
+const maxItems = 50
+
+// feed is the format independent representation of a feed.
+type feed struct {
+	Title       string
+	Description string
+	Link        string
+	Items       []*feedItem
+}
+
+// feedItem is a single entry of a feed.
+type feedItem struct {
+	ID          string
+	Title       string
+	Description string
+	Link        string
+	Author      string
+	Date        string
+}
+
+// updated returns the date of the latest item.
+func (feed *feed) updated() string {
+	latest := ""
+
+	for _, item := range feed.Items {
+		if item.Date > latest {
+			latest = item.Date
+		}
+	}
+
+	return latest
+}
+
+// render sends the feed in the format requested by the client.
+// The ".rss" and ".atom" extensions take precedence over the Accept header.
+func render(ctx aero.Context, feed *feed) error {
+	var (
+		data        []byte
+		err         error
+		contentType string
+	)
+
+	if wantsAtom(ctx) {
+		contentType = "application/atom+xml; charset=utf-8"
+		data, err = xml.MarshalIndent(newAtomFeed(feed), "", "\t")
+	} else {
+		contentType = "application/rss+xml; charset=utf-8"
+		data, err = xml.MarshalIndent(newRSSFeed(feed), "", "\t")
+	}
+
+	if err != nil {
+		return ctx.Error(http.StatusInternalServerError, err)
+	}
+
+	ctx.Response().SetHeader("Content-Type", contentType)
+	return ctx.Bytes(append([]byte(xml.Header), data...))
+}
+
+// wantsAtom tells whether the client requested an Atom feed instead of RSS.
+func wantsAtom(ctx aero.Context) bool {
+	path := ctx.Path()
+
+	switch {
+	case strings.HasSuffix(path, ".atom"):
+		return true
+	case strings.HasSuffix(path, ".rss"):
+		return false
+	}
+
+	accept := ctx.Request().Header("Accept")
+	return strings.Contains(accept, "application/atom+xml") && !strings.Contains(accept, "application/rss+xml")
+}
+
+// parseDate parses a date in the database format.
+func parseDate(date string) time.Time {
+	parsed, _ := time.Parse(time.RFC3339, date)
+	return parsed
+}
@@ -0,0 +1,65 @@
+package feeds
+
+import (
+	"encoding/xml"
+	"time"
+)
+
+// rssFeed is an RSS 2.0 document.
+type rssFeed struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string     `xml:"title"`
+	Link          string     `xml:"link"`
+	Description   string     `xml:"description"`
+	LastBuildDate string     `xml:"lastBuildDate,omitempty"`
+	Items         []*rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string  `xml:"title"`
+	Link        string  `xml:"link"`
+	Description string  `xml:"description"`
+	Author      string  `xml:"author,omitempty"`
+	GUID        rssGUID `xml:"guid"`
+	PubDate     string  `xml:"pubDate"`
+}
+
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+// newRSSFeed converts the feed to RSS 2.0.
+func newRSSFeed(feed *feed) *rssFeed {
+	rss := &rssFeed{
+		Version: "2.0",
+		Channel: rssChannel{
+			Title:       feed.Title,
+			Link:        feed.Link,
+			Description: feed.Description,
+			Items:       make([]*rssItem, 0, len(feed.Items)),
+		},
+	}
+
+	if updated := feed.updated(); updated != "" {
+		rss.Channel.LastBuildDate = parseDate(updated).Format(time.RFC1123Z)
+	}
+
+	for _, item := range feed.Items {
+		rss.Channel.Items = append(rss.Channel.Items, &rssItem{
+			Title:       item.Title,
+			Link:        item.Link,
+			Description: item.Description,
+			Author:      item.Author,
+			GUID:        rssGUID{Value: item.ID},
+			PubDate:     parseDate(item.Date).Format(time.RFC1123Z),
+		})
+	}
+
+	return rss
+}
@@ -0,0 +1,92 @@
+package feeds
+
+import (
+	"sort"
+
+	"github.com/aerogo/aero"
+	"github.com/animenotifier/notify.moe/arn"
+	"github.com/animenotifier/notify.moe/assets"
+	"github.com/animenotifier/notify.moe/utils"
+)
+
+// Threads sends a feed of the latest forum threads and replies.
+func Threads(ctx aero.Context) error {
+	threads := arn.FilterThreads(func(thread *arn.Thread) bool {
+		return !thread.IsSoftDeleted()
+	})
+
+	arn.SortThreadsLatestFirst(threads)
+
+	if len(threads) > maxItems {
+		threads = threads[:maxItems]
+	}
+
+	posts, err := arn.FilterPosts(func(post *arn.Post) bool {
+		return post.ParentType == "Thread" && !post.IsSoftDeleted()
+	})
+
+	if err != nil {
+		return err
+	}
+
+	arn.SortPostsLatestFirst(posts)
+
+	if len(posts) > maxItems {
+		posts = posts[:maxItems]
+	}
+
+	items := make([]*feedItem, 0, len(threads)+len(posts))
+
+	for _, thread := range threads {
+		items = append(items, &feedItem{
+			ID:          "https://" + assets.Domain + thread.Link(),
+			Title:       thread.Title,
+			Description: utils.CutLongDescription(utils.RemoveFootnotes(thread.Text)),
+			Link:        "https://" + assets.Domain + thread.Link(),
+			Author:      authorName(thread.Creator()),
+			Date:        thread.Created,
+		})
+	}
+
+	for _, post := range posts {
+		thread, err := arn.GetThread(post.ParentID)
+
+		// Replies in removed threads are hidden as well
+		if err != nil || thread.IsSoftDeleted() {
+			continue
+		}
+
+		items = append(items, &feedItem{
+			ID:          "https://" + assets.Domain + post.Link(),
+			Title:       "Re: " + thread.Title,
+			Description: utils.CutLongDescription(utils.RemoveFootnotes(post.Text)),
+			Link:        "https://" + assets.Domain + post.Link(),
+			Author:      authorName(post.Creator()),
+			Date:        post.Created,
+		})
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].Date > items[j].Date
+	})
+
+	if len(items) > maxItems {
+		items = items[:maxItems]
+	}
+
+	return render(ctx, &feed{
+		Title:       "Forum - " + assets.Domain,
+		Description: "New threads and replies in the forum.",
+		Link:        "https://" + assets.Domain + "/forum",
+		Items:       items,
+	})
+}
+
+// authorName returns the nickname of the user or an empty string if the user doesn't exist.
+func authorName(user *arn.User) string {
+	if user == nil {
+		return ""
+	}
+
+	return user.Nick
+}
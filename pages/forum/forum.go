@@ -12,7 +12,15 @@ const ThreadsPerPage = 20
 // Get forum category.
 func Get(ctx aero.Context) error {
 	tag := ctx.Get("tag")
+	user := arn.GetUserFromContext(ctx)
 	threads := arn.GetThreadsByTag(tag)
+
+	// Hide threads with muted keywords or by blocked users before we count the page
+	if user != nil {
+		threads = arn.FilterMutedThreads(threads, &user.Settings().Forum)
+		threads = arn.FilterBlockedThreads(threads, user)
+	}
+
 	arn.SortThreads(threads)
 
 	if len(threads) > ThreadsPerPage {
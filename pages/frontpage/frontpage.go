@@ -6,6 +6,7 @@ import (
 	"github.com/animenotifier/notify.moe/assets"
 	"github.com/animenotifier/notify.moe/components"
 	"github.com/animenotifier/notify.moe/server/middleware"
+	"github.com/animenotifier/notify.moe/utils/opengraph"
 )
 
 // Get ...
@@ -13,19 +14,10 @@ func Get(ctx aero.Context) error {
 	description := "Anime list, tracker, database and notifier for new anime episodes. Create your own anime list and keep track of your progress as you watch."
 
 	customCtx := ctx.(*middleware.OpenGraphContext)
-	customCtx.OpenGraph = &arn.OpenGraph{
-		Tags: map[string]string{
-			"og:title":       assets.Manifest.Name,
-			"og:description": description,
-			"og:type":        "website",
-			"og:url":         "https://" + assets.Domain,
-			"og:image":       "https://" + assets.Domain + "/images/brand/220.png",
-		},
-		Meta: map[string]string{
-			"description": description,
-			"keywords":    "anime,list,tracker,notifier",
-		},
-	}
+	customCtx.OpenGraph = opengraph.New(assets.Manifest.Name, description).
+		Keywords("anime", "list", "tracker", "notifier").
+		Build()
 
-	return ctx.HTML(components.FrontPage())
+	featured, _ := arn.TodaysFeaturedContent()
+	return ctx.HTML(components.FrontPage(featured))
 }
@@ -0,0 +1,37 @@
+package group
+
+import (
+	"net/http"
+
+	"github.com/aerogo/aero"
+	"github.com/animenotifier/notify.moe/arn"
+)
+
+// activitiesPerPage is the number of activities sent in a single response.
+const activitiesPerPage = 50
+
+// Activity sends the latest group activities as JSON.
+// The "cursor" query parameter continues where the previous response ended.
+func Activity(ctx aero.Context) error {
+	user := arn.GetUserFromContext(ctx)
+	id := ctx.Get("id")
+	group, err := arn.GetGroup(id)
+
+	if err != nil {
+		return ctx.Error(http.StatusNotFound, "Group not found", err)
+	}
+
+	if !group.ActivitiesVisibleTo(user) {
+		return ctx.Error(http.StatusForbidden, "Only members can see the activity of this group")
+	}
+
+	activities, cursor := arn.PaginateGroupActivities(group.Activities(), ctx.Query("cursor"), activitiesPerPage)
+
+	return ctx.JSON(struct {
+		Items  []*arn.GroupActivity `json:"items"`
+		Cursor string               `json:"cursor"`
+	}{
+		Items:  activities,
+		Cursor: cursor,
+	})
+}
@@ -0,0 +1,92 @@
+package group
+
+import (
+	"net/http"
+
+	"github.com/aerogo/aero"
+	"github.com/animenotifier/notify.moe/arn"
+	"github.com/animenotifier/notify.moe/assets"
+	"github.com/animenotifier/notify.moe/utils/ical"
+)
+
+// EventsCalendar renders all events of the group as an iCalendar feed.
+func EventsCalendar(ctx aero.Context) error {
+	group, err := arn.GetGroup(ctx.Get("id"))
+
+	if err != nil {
+		return ctx.Error(http.StatusNotFound, "Group not found", err)
+	}
+
+	if !group.ActivitiesVisibleTo(calendarUser(ctx)) {
+		return ctx.Error(http.StatusForbidden, "Only members can see the events of this group")
+	}
+
+	feed := &ical.Calendar{
+		Name: group.Name + " - Events",
+	}
+
+	for _, event := range group.Events() {
+		feed.Events = append(feed.Events, calendarEvent(event))
+	}
+
+	ctx.Response().SetHeader("Content-Type", "text/calendar; charset=utf-8")
+	return ctx.String(feed.String())
+}
+
+// EventCalendar renders a single group event as an iCalendar file.
+func EventCalendar(ctx aero.Context) error {
+	group, err := arn.GetGroup(ctx.Get("id"))
+
+	if err != nil {
+		return ctx.Error(http.StatusNotFound, "Group not found", err)
+	}
+
+	event, err := arn.GetGroupEvent(ctx.Get("event"))
+
+	if err != nil || event.GroupID != group.ID {
+		return ctx.Error(http.StatusNotFound, "Event not found", err)
+	}
+
+	if !group.ActivitiesVisibleTo(calendarUser(ctx)) {
+		return ctx.Error(http.StatusForbidden, "Only members can see the events of this group")
+	}
+
+	feed := &ical.Calendar{
+		Name:   group.Name + " - " + event.Title,
+		Events: []*ical.Event{calendarEvent(event)},
+	}
+
+	ctx.Response().SetHeader("Content-Type", "text/calendar; charset=utf-8")
+	return ctx.String(feed.String())
+}
+
+// calendarUser returns the user requesting the calendar.
+// Calendar apps don't send the session cookie, so members identify themselves with their calendar token.
+func calendarUser(ctx aero.Context) *arn.User {
+	user := arn.GetUserFromContext(ctx)
+
+	if user != nil {
+		return user
+	}
+
+	user, err := arn.GetUser(ctx.Query("user"))
+
+	if err != nil || user.IsHidden() || !user.VerifyCalendarToken(ctx.Query("token")) {
+		return nil
+	}
+
+	return user
+}
+
+// calendarEvent converts the group event to a calendar entry.
+func calendarEvent(event *arn.GroupEvent) *ical.Event {
+	return &ical.Event{
+		UID:         event.ID + "@notify.moe",
+		Start:       event.StartTime(),
+		End:         event.EndTime(),
+		Summary:     event.Title,
+		Description: event.Description,
+		Location:    event.Location,
+		URL:         "https://" + assets.Domain + event.Link(),
+	}
+}
@@ -0,0 +1,104 @@
+package group
+
+import (
+	"net/http"
+
+	"github.com/aerogo/aero"
+	"github.com/animenotifier/notify.moe/arn"
+	"github.com/animenotifier/notify.moe/components"
+	"github.com/animenotifier/notify.moe/server/middleware"
+	"github.com/animenotifier/notify.moe/utils/editform"
+)
+
+// Events shows the upcoming and past events of the group.
+func Events(ctx aero.Context) error {
+	user := arn.GetUserFromContext(ctx)
+	group, err := arn.GetGroup(ctx.Get("id"))
+
+	if err != nil {
+		return ctx.Error(http.StatusNotFound, "Group not found", err)
+	}
+
+	if !group.ActivitiesVisibleTo(user) {
+		return ctx.Error(http.StatusForbidden, "Only members can see the events of this group")
+	}
+
+	var member *arn.GroupMember
+
+	if user != nil {
+		member = group.FindMember(user.ID)
+	}
+
+	var upcoming []*arn.GroupEvent
+	var past []*arn.GroupEvent
+
+	for _, event := range group.Events() {
+		if event.IsUpcoming() {
+			upcoming = append(upcoming, event)
+		} else {
+			past = append([]*arn.GroupEvent{event}, past...)
+		}
+	}
+
+	customCtx := ctx.(*middleware.OpenGraphContext)
+	customCtx.OpenGraph = getOpenGraph(group)
+	return ctx.HTML(components.GroupEvents(group, upcoming, past, member, user))
+}
+
+// Event shows the details and the attendees of a group event.
+func Event(ctx aero.Context) error {
+	user := arn.GetUserFromContext(ctx)
+	group, err := arn.GetGroup(ctx.Get("id"))
+
+	if err != nil {
+		return ctx.Error(http.StatusNotFound, "Group not found", err)
+	}
+
+	event, err := arn.GetGroupEvent(ctx.Get("event"))
+
+	if err != nil || event.GroupID != group.ID {
+		return ctx.Error(http.StatusNotFound, "Event not found", err)
+	}
+
+	if !group.ActivitiesVisibleTo(user) {
+		return ctx.Error(http.StatusForbidden, "Only members can see the events of this group")
+	}
+
+	var member *arn.GroupMember
+
+	if user != nil {
+		member = group.FindMember(user.ID)
+	}
+
+	customCtx := ctx.(*middleware.OpenGraphContext)
+	customCtx.OpenGraph = getEventOpenGraph(group, event)
+	return ctx.HTML(components.GroupEvent(group, event, member, user))
+}
+
+// EditEvent renders the form to edit a group event.
+func EditEvent(ctx aero.Context) error {
+	user := arn.GetUserFromContext(ctx)
+
+	if user == nil {
+		return ctx.Error(http.StatusUnauthorized, "Not logged in")
+	}
+
+	group, err := arn.GetGroup(ctx.Get("id"))
+
+	if err != nil {
+		return ctx.Error(http.StatusNotFound, "Group not found", err)
+	}
+
+	event, err := arn.GetGroupEvent(ctx.Get("event"))
+
+	if err != nil || event.GroupID != group.ID {
+		return ctx.Error(http.StatusNotFound, "Event not found", err)
+	}
+
+	if !group.CanModerate(user.ID) {
+		return ctx.Error(http.StatusUnauthorized, "Only moderators of the group can edit events")
+	}
+
+	member := group.FindMember(user.ID)
+	return ctx.HTML(components.GroupHeader(group, member, user) + editform.Render(event, "Edit event", user))
+}
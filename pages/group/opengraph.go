@@ -2,17 +2,21 @@ package group
 
 import (
 	"github.com/animenotifier/notify.moe/arn"
-	"github.com/animenotifier/notify.moe/assets"
+	"github.com/animenotifier/notify.moe/utils"
+	"github.com/animenotifier/notify.moe/utils/opengraph"
 )
 
 func getOpenGraph(group *arn.Group) *arn.OpenGraph {
-	return &arn.OpenGraph{
-		Tags: map[string]string{
-			"og:title":       group.Name,
-			"og:description": group.Tagline,
-			"og:image":       "https:" + group.ImageLink("large"),
-			"og:url":         "https://" + assets.Domain + group.Link(),
-			"og:site_name":   "notify.moe",
-		},
-	}
+	return opengraph.New(group.Name, group.Tagline).
+		URL(group.Link()).
+		Image(utils.BestImageVariant(group, utils.OpenGraphImageRatio)).
+		Build()
+}
+
+func getEventOpenGraph(group *arn.Group, event *arn.GroupEvent) *arn.OpenGraph {
+	return opengraph.New(event.Title, event.Description).
+		URL(event.Link()).
+		Image(utils.BestImageVariant(group, utils.OpenGraphImageRatio)).
+		Keywords(group.Name, "event", "watch party").
+		Build()
 }
@@ -68,6 +68,17 @@ func Rewrite(ctx aero.RewriteContext) {
 		return
 	}
 
+	// Seasons
+	if strings.HasPrefix(requestURI, "/anime/season/") {
+		ctx.SetPath(requestURI[len("/anime"):])
+		return
+	}
+
+	if strings.HasPrefix(requestURI, "/_/anime/season/") {
+		ctx.SetPath("/_" + requestURI[len("/_/anime"):])
+		return
+	}
+
 	// Analytics
 	if requestURI == "/dark-flame-master" {
 		ctx.SetPath("/api/new/analytics")
@@ -9,7 +9,7 @@ import (
 // Register registers the page routes.
 func Register(app *aero.Application) {
 	page.Get(app, "/activity", activity.Global)
-	page.Get(app, "/activity/from/:index", activity.Global)
+	page.Get(app, "/activity/from/:cursor", activity.Global)
 	page.Get(app, "/activity/followed", activity.Followed)
-	page.Get(app, "/activity/followed/from/:index", activity.Followed)
+	page.Get(app, "/activity/followed/from/:cursor", activity.Followed)
 }
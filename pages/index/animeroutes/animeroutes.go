@@ -8,6 +8,7 @@ import (
 	"github.com/animenotifier/notify.moe/pages/episode"
 	"github.com/animenotifier/notify.moe/pages/genre"
 	"github.com/animenotifier/notify.moe/pages/genres"
+	"github.com/animenotifier/notify.moe/pages/review"
 	"github.com/animenotifier/notify.moe/pages/statistics"
 	"github.com/animenotifier/notify.moe/utils/page"
 )
@@ -20,9 +21,17 @@ func Register(app *aero.Application) {
 	page.Get(app, "/anime/:id/characters", anime.Characters)
 	page.Get(app, "/anime/:id/tracks", anime.Tracks)
 	page.Get(app, "/anime/:id/relations", anime.Relations)
+	page.Get(app, "/anime/:id/franchise", anime.Franchise)
 	page.Get(app, "/anime/:id/comments", anime.Comments)
+	page.Get(app, "/anime/:id/reviews", anime.Reviews)
+	page.Get(app, "/anime/:id/streaming/suggest", anime.SuggestStreamingLink)
 	page.Get(app, "/episode/:id", episode.Get)
 	app.Get("/episode/:id/subtitles/:language", episode.Subtitles)
+	app.Get("/og-image/anime/:id", anime.OpenGraphImage)
+
+	// Review
+	page.Get(app, "/review/:id", review.Get)
+	page.Get(app, "/review/:id/edit", review.Edit)
 
 	// Anime redirects
 	page.Get(app, "/kitsu/anime/:id", anime.RedirectByMapping("kitsu/anime"))
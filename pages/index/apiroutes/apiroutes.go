@@ -5,18 +5,26 @@ import (
 
 	"github.com/aerogo/aero"
 	"github.com/animenotifier/notify.moe/arn"
+	"github.com/animenotifier/notify.moe/pages/admin"
+	"github.com/animenotifier/notify.moe/pages/airing"
 	"github.com/animenotifier/notify.moe/pages/anime"
 	"github.com/animenotifier/notify.moe/pages/animeimport"
 	"github.com/animenotifier/notify.moe/pages/animelist"
 	"github.com/animenotifier/notify.moe/pages/api"
 	"github.com/animenotifier/notify.moe/pages/api/apitype"
+	"github.com/animenotifier/notify.moe/pages/apiv1"
 	"github.com/animenotifier/notify.moe/pages/character"
 	"github.com/animenotifier/notify.moe/pages/database"
+	"github.com/animenotifier/notify.moe/pages/drafts"
 	"github.com/animenotifier/notify.moe/pages/editor/jobs"
+	"github.com/animenotifier/notify.moe/pages/group"
 	"github.com/animenotifier/notify.moe/pages/me"
+	"github.com/animenotifier/notify.moe/pages/messages"
 	"github.com/animenotifier/notify.moe/pages/notifications"
+	"github.com/animenotifier/notify.moe/pages/oauth"
 	"github.com/animenotifier/notify.moe/pages/popular"
 	"github.com/animenotifier/notify.moe/pages/post"
+	"github.com/animenotifier/notify.moe/pages/settings"
 	"github.com/animenotifier/notify.moe/pages/soundtrack"
 	"github.com/animenotifier/notify.moe/pages/sse"
 	"github.com/animenotifier/notify.moe/pages/thread"
@@ -39,6 +47,8 @@ func Register(app *aero.Application) {
 	app.Get("/api/test/notification", notifications.Test)
 	app.Get("/api/count/notifications/unseen", notifications.CountUnseen)
 	app.Get("/api/mark/notifications/seen", notifications.MarkNotificationsAsSeen)
+	app.Get("/api/count/messages/unread", messages.CountUnread)
+	app.Get("/api/airing/upcoming", airing.Upcoming)
 	app.Get("/api/user/:id/notifications/latest", notifications.Latest)
 	app.Get("/api/random/soundtrack", soundtrack.Random)
 	app.Get("/api/next/soundtrack", soundtrack.Next)
@@ -49,9 +59,39 @@ func Register(app *aero.Application) {
 
 	// Thread
 	app.Get("/api/thread/:id/reply/ui", thread.ReplyUI)
+	app.Post("/api/thread/:id/save", thread.Save)
 
 	// Post
 	app.Get("/api/post/:id/reply/ui", post.ReplyUI)
+	app.Get("/api/post/:id/ui", post.UI)
+
+	// Drafts
+	app.Post("/api/markdown/preview", drafts.Preview)
+	app.Post("/api/draft/save", drafts.Save)
+
+	// Group
+	app.Get("/api/group/:id/activity", group.Activity)
+
+	// Public API
+	app.Get("/api/v1/anime/:id", apiv1.Authorized(apiv1.Anime))
+	app.Get("/api/v1/search/anime", apiv1.Authorized(apiv1.SearchAnime))
+	app.Get("/api/v1/characters", apiv1.Authorized(apiv1.Characters))
+	app.Get("/api/v1/user/:nick/animelist", apiv1.Authorized(apiv1.AnimeList))
+	app.Post("/api/v1/keys/new", apiv1.NewKey)
+	app.Post("/api/v1/keys/revoke", apiv1.RevokeKey)
+	app.Get("/api/v1/me/animelist", apiv1.Scoped(arn.OAuthScopeReadList, apiv1.MyAnimeList))
+	app.Post("/api/v1/me/animelist/:id", apiv1.Scoped(arn.OAuthScopeWriteList, apiv1.EditMyAnimeListItem))
+	app.Post("/api/v1/me/animelist/:id/remove", apiv1.Scoped(arn.OAuthScopeWriteList, apiv1.RemoveMyAnimeListItem))
+	app.Get("/api/v1/me/notifications", apiv1.Scoped(arn.OAuthScopeReadNotifications, apiv1.MyNotifications))
+
+	// OAuth
+	app.Post("/oauth/token", oauth.Token)
+	app.Post("/oauth/revoke", oauth.Revoke)
+	app.Post("/api/oauth/authorize", oauth.Approve)
+	app.Post("/api/oauth/deny", oauth.Deny)
+	app.Post("/api/oauth/clients/new", oauth.NewClient)
+	app.Post("/api/oauth/clients/delete", oauth.DeleteClient)
+	app.Post("/api/oauth/authorizations/revoke", oauth.RevokeAuthorization)
 
 	// Types
 	app.Get("/api/types", database.Types)
@@ -62,6 +102,10 @@ func Register(app *aero.Application) {
 
 	// AnimeList
 	app.Post("/api/delete/animelist", animelist.Delete)
+	app.Get("/api/export/animelist/:format", animelist.Export)
+
+	// Account
+	app.Post("/api/delete/account", settings.DeleteAccount)
 
 	// Upload
 	app.Post("/api/upload/user/image", upload.UserImage)
@@ -78,4 +122,9 @@ func Register(app *aero.Application) {
 	// Jobs
 	app.Post("/api/job/:job/start", jobs.Start)
 	app.Post("/api/anime/:id/sync-episodes", anime.SyncEpisodes)
+	app.Post("/api/anime/:id/soundtrack/new", anime.NewTrack)
+
+	// Featured content
+	app.Post("/api/featured/pin", admin.PinFeatured)
+	app.Post("/api/featured/unpin", admin.UnpinFeatured)
 }
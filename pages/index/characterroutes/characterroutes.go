@@ -4,6 +4,7 @@ import (
 	"github.com/aerogo/aero"
 	"github.com/animenotifier/notify.moe/pages/character"
 	"github.com/animenotifier/notify.moe/pages/characters"
+	"github.com/animenotifier/notify.moe/pages/person"
 	"github.com/animenotifier/notify.moe/utils/page"
 )
 
@@ -14,10 +15,19 @@ func Register(app *aero.Application) {
 	page.Get(app, "/characters/from/:index", characters.Latest)
 	page.Get(app, "/characters/best", characters.Best)
 	page.Get(app, "/characters/best/from/:index", characters.Best)
+	page.Get(app, "/characters/best/week", characters.BestThisWeek)
+	page.Get(app, "/characters/best/week/from/:index", characters.BestThisWeek)
+	page.Get(app, "/characters/best/month", characters.BestThisMonth)
+	page.Get(app, "/characters/best/month/from/:index", characters.BestThisMonth)
 
 	// Character
 	page.Get(app, "/character/:id", character.Get)
 	page.Get(app, "/character/:id/edit", character.Edit)
 	page.Get(app, "/character/:id/edit/images", character.EditImages)
 	page.Get(app, "/character/:id/history", character.History)
+
+	// Person
+	page.Get(app, "/person/:id", person.Get)
+	page.Get(app, "/person/:id/edit", person.Edit)
+	page.Get(app, "/person/:id/history", person.History)
 }
@@ -5,6 +5,7 @@ import (
 	"github.com/animenotifier/notify.moe/pages/embed"
 	"github.com/animenotifier/notify.moe/pages/home"
 	"github.com/animenotifier/notify.moe/pages/login"
+	"github.com/animenotifier/notify.moe/pages/oauth"
 	"github.com/animenotifier/notify.moe/pages/terms"
 	"github.com/animenotifier/notify.moe/pages/welcome"
 	"github.com/animenotifier/notify.moe/utils/page"
@@ -17,6 +18,9 @@ func Register(app *aero.Application) {
 	page.Get(app, "/welcome", welcome.Get)
 	page.Get(app, "/terms", terms.Get)
 
+	// OAuth consent page
+	page.Get(app, "/oauth/authorize", oauth.Authorize)
+
 	// Browser extension
 	page.Get(app, "/extension/embed", embed.Get)
 }
@@ -5,6 +5,7 @@ import (
 	"github.com/animenotifier/notify.moe/pages/explore"
 	"github.com/animenotifier/notify.moe/pages/explore/explorecolor"
 	"github.com/animenotifier/notify.moe/pages/explore/halloffame"
+	"github.com/animenotifier/notify.moe/pages/season"
 	"github.com/animenotifier/notify.moe/utils/page"
 )
 
@@ -16,4 +17,8 @@ func Register(app *aero.Application) {
 	page.Get(app, "/explore/color/:color/anime", explorecolor.AnimeByAverageColor)
 	page.Get(app, "/explore/color/:color/anime/from/:index", explorecolor.AnimeByAverageColor)
 	page.Get(app, "/halloffame", halloffame.Get)
+
+	// Seasons.
+	// "/anime/season/..." is rewritten to this route because it would conflict with "/anime/:id".
+	page.Get(app, "/season/:year/:season", season.Get)
 }
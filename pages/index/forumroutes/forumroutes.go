@@ -2,10 +2,12 @@ package forumroutes
 
 import (
 	"github.com/aerogo/aero"
+	"github.com/animenotifier/notify.moe/pages/feeds"
 	"github.com/animenotifier/notify.moe/pages/forum"
 	"github.com/animenotifier/notify.moe/pages/newthread"
 	"github.com/animenotifier/notify.moe/pages/post"
 	"github.com/animenotifier/notify.moe/pages/post/editpost"
+	"github.com/animenotifier/notify.moe/pages/report"
 	"github.com/animenotifier/notify.moe/pages/thread"
 	"github.com/animenotifier/notify.moe/pages/thread/editthread"
 	"github.com/animenotifier/notify.moe/utils/page"
@@ -17,12 +19,22 @@ func Register(app *aero.Application) {
 	page.Get(app, "/forum", forum.Get)
 	page.Get(app, "/forum/:tag", forum.Get)
 
+	// Feeds
+	app.Get("/threads.rss", feeds.Threads)
+	app.Get("/threads.atom", feeds.Threads)
+	app.Get("/threads.xml", feeds.Threads)
+
 	// Thread
 	page.Get(app, "/thread/:id", thread.Get)
+	page.Get(app, "/thread/:id/page/:page", thread.Get)
+	page.Get(app, "/thread/:id/post/:post", thread.Permalink)
 	page.Get(app, "/thread/:id/edit", editthread.Get)
 	page.Get(app, "/new/thread", newthread.Get)
 
 	// Post
 	page.Get(app, "/post/:id", post.Get)
 	page.Get(app, "/post/:id/edit", editpost.Get)
+
+	// Report
+	page.Get(app, "/report/:type/:id", report.Get)
 }
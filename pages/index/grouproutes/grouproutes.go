@@ -19,7 +19,14 @@ func Register(app *aero.Application) {
 	page.Get(app, "/group/:id", group.Feed)
 	page.Get(app, "/group/:id/info", group.Info)
 	page.Get(app, "/group/:id/members", group.Members)
+	page.Get(app, "/group/:id/events", group.Events)
+	page.Get(app, "/group/:id/event/:event", group.Event)
+	page.Get(app, "/group/:id/event/:event/edit", group.EditEvent)
 	page.Get(app, "/group/:id/edit", group.Edit)
 	page.Get(app, "/group/:id/edit/image", group.EditImage)
 	page.Get(app, "/group/:id/history", group.History)
+
+	// Calendar exports
+	app.Get("/group/:id/events.ics", group.EventsCalendar)
+	app.Get("/group/:id/event/:event/calendar.ics", group.EventCalendar)
 }
@@ -4,6 +4,7 @@ import (
 	"github.com/aerogo/aero"
 	"github.com/animenotifier/notify.moe/pages/listimport"
 	"github.com/animenotifier/notify.moe/pages/listimport/listimportanilist"
+	"github.com/animenotifier/notify.moe/pages/listimport/listimportfile"
 	"github.com/animenotifier/notify.moe/pages/listimport/listimportkitsu"
 	"github.com/animenotifier/notify.moe/pages/listimport/listimportmyanimelist"
 	"github.com/animenotifier/notify.moe/utils/page"
@@ -15,8 +16,17 @@ func Register(app *aero.Application) {
 	page.Get(app, "/import", listimport.Get)
 	page.Get(app, "/import/anilist/animelist", listimportanilist.Preview)
 	page.Get(app, "/import/anilist/animelist/finish", listimportanilist.Finish)
+	page.Get(app, "/import/anilist/sync", listimportanilist.SyncPreview)
 	page.Get(app, "/import/myanimelist/animelist", listimportmyanimelist.Preview)
 	page.Get(app, "/import/myanimelist/animelist/finish", listimportmyanimelist.Finish)
+	page.Get(app, "/import/myanimelist/sync", listimportmyanimelist.SyncPreview)
 	page.Get(app, "/import/kitsu/animelist", listimportkitsu.Preview)
 	page.Get(app, "/import/kitsu/animelist/finish", listimportkitsu.Finish)
+	page.Get(app, "/import/file", listimportfile.Preview)
+	page.Get(app, "/import/file/finish/:strategy", listimportfile.Finish)
+	app.Post("/api/import/file", listimportfile.Upload)
+	app.Post("/api/import/anilist/sync/enable", listimportanilist.SyncEnable)
+	app.Post("/api/import/anilist/sync/disable", listimportanilist.SyncDisable)
+	app.Post("/api/import/myanimelist/sync/enable", listimportmyanimelist.SyncEnable)
+	app.Post("/api/import/myanimelist/sync/disable", listimportmyanimelist.SyncDisable)
 }
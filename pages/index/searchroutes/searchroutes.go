@@ -10,6 +10,7 @@ import (
 // Register registers the page routes.
 func Register(app *aero.Application) {
 	// Search
+	page.Get(app, "/search", search.FullText)
 	page.Get(app, "/search/*term", search.Get)
 	page.Get(app, "/empty-search", search.GetEmptySearch)
 	page.Get(app, "/anime-search/*term", search.Anime)
@@ -16,4 +16,12 @@ func Register(app *aero.Application) {
 	page.Get(app, "/settings/info", settings.Get(components.SettingsInfo))
 	page.Get(app, "/settings/style", settings.Get(components.SettingsStyle))
 	page.Get(app, "/settings/extras", settings.Get(components.SettingsExtras))
+	page.Get(app, "/settings/subscriptions", settings.Subscriptions)
+	page.Get(app, "/settings/blocks", settings.Blocks)
+	page.Get(app, "/settings/apps", settings.Apps)
+	page.Get(app, "/settings/trash", settings.Trash)
+
+	// Email
+	page.Get(app, "/email/unsubscribe/:id/:token", settings.Unsubscribe)
+	app.Post("/email/unsubscribe/:id/:token", settings.UnsubscribeConfirm)
 }
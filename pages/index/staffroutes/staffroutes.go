@@ -5,11 +5,17 @@ import (
 	"github.com/animenotifier/notify.moe/pages/admin"
 	"github.com/animenotifier/notify.moe/pages/editlog"
 	"github.com/animenotifier/notify.moe/pages/editor"
+	"github.com/animenotifier/notify.moe/pages/editor/edits"
 	"github.com/animenotifier/notify.moe/pages/editor/filteranime"
 	"github.com/animenotifier/notify.moe/pages/editor/filtercharacters"
 	"github.com/animenotifier/notify.moe/pages/editor/filtercompanies"
 	"github.com/animenotifier/notify.moe/pages/editor/filtersoundtracks"
 	"github.com/animenotifier/notify.moe/pages/editor/jobs"
+	"github.com/animenotifier/notify.moe/pages/editor/pendingquotes"
+	"github.com/animenotifier/notify.moe/pages/editor/streaminglinks"
+	"github.com/animenotifier/notify.moe/pages/moderationlog"
+	"github.com/animenotifier/notify.moe/pages/reports"
+	"github.com/animenotifier/notify.moe/server/middleware"
 	"github.com/animenotifier/notify.moe/utils/page"
 )
 
@@ -38,6 +44,7 @@ func Register(app *aero.Application) {
 	editorFilterable("/editor/anime/companies/licensors", filteranime.Licensors)
 
 	editorFilterable("/editor/anime/connections/relations", filteranime.Relations)
+	editorFilterable("/editor/anime/connections/reverserelations", filteranime.ReverseRelations)
 	editorFilterable("/editor/anime/connections/characters", filteranime.Characters)
 
 	editorFilterable("/editor/anime/details/synopsis", filteranime.Synopsis)
@@ -71,6 +78,15 @@ func Register(app *aero.Application) {
 	page.Get(app, "/editor/soundtracks/tags", filtersoundtracks.Tags)
 	page.Get(app, "/editor/soundtracks/file", filtersoundtracks.File)
 
+	// Editor - Quotes
+	page.Get(app, "/editor/quotes", middleware.Moderator(pendingquotes.Get))
+
+	// Editor - Streaming links
+	page.Get(app, "/editor/streaming", middleware.Moderator(streaminglinks.Open))
+	page.Get(app, "/editor/streaming/resolved", middleware.Moderator(streaminglinks.Resolved))
+	page.Get(app, "/editor/edits", middleware.Moderator(edits.Recent))
+	page.Get(app, "/editor/edits/pending", middleware.Moderator(edits.Pending))
+
 	// Editor - Jobs
 	page.Get(app, "/editor/jobs", jobs.Overview)
 
@@ -79,6 +95,12 @@ func Register(app *aero.Application) {
 	page.Get(app, "/log/from/:index", editlog.Full)
 	page.Get(app, "/log/compact", editlog.Compact)
 	page.Get(app, "/log/compact/from/:index", editlog.Compact)
+	page.Get(app, "/log/moderation", middleware.Moderator(moderationlog.Get))
+	page.Get(app, "/log/moderation/from/:index", middleware.Moderator(moderationlog.Get))
+
+	// Reports
+	page.Get(app, "/reports", middleware.Moderator(reports.Open))
+	page.Get(app, "/reports/resolved", middleware.Moderator(reports.Resolved))
 	page.Get(app, "/user/:nick/log", editlog.Full)
 	page.Get(app, "/user/:nick/log/from/:index", editlog.Full)
 	page.Get(app, "/user/:nick/log/compact", editlog.Compact)
@@ -86,10 +108,16 @@ func Register(app *aero.Application) {
 
 	// Admin
 	page.Get(app, "/admin", admin.Get)
+	page.Get(app, "/admin/metrics", admin.Metrics)
+	page.Get(app, "/admin/jobs", admin.Jobs)
 	page.Get(app, "/admin/webdev", admin.WebDev)
 	page.Get(app, "/admin/registrations", admin.UserRegistrations)
 	page.Get(app, "/admin/crashes", admin.Crashes)
 	page.Get(app, "/admin/errors/client", admin.ClientErrors)
 	page.Get(app, "/admin/purchases", admin.PurchaseHistory)
 	page.Get(app, "/admin/payments", admin.PaymentHistory)
+	page.Get(app, "/admin/featured", admin.Featured)
+
+	// Prometheus
+	app.Get("/metrics", admin.PrometheusMetrics)
 }
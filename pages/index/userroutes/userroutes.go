@@ -4,13 +4,19 @@ import (
 	"github.com/aerogo/aero"
 	"github.com/animenotifier/notify.moe/pages/animelist"
 	"github.com/animenotifier/notify.moe/pages/animelistitem"
+	"github.com/animenotifier/notify.moe/pages/calendar"
 	"github.com/animenotifier/notify.moe/pages/compare"
+	"github.com/animenotifier/notify.moe/pages/conversation"
+	"github.com/animenotifier/notify.moe/pages/customlist"
 	"github.com/animenotifier/notify.moe/pages/explore/explorerelations"
+	"github.com/animenotifier/notify.moe/pages/feeds"
+	"github.com/animenotifier/notify.moe/pages/messages"
 	"github.com/animenotifier/notify.moe/pages/notifications"
 	"github.com/animenotifier/notify.moe/pages/profile"
 	"github.com/animenotifier/notify.moe/pages/profile/profilecharacters"
 	"github.com/animenotifier/notify.moe/pages/recommended"
 	"github.com/animenotifier/notify.moe/pages/user"
+	"github.com/animenotifier/notify.moe/pages/yearinreview"
 	"github.com/animenotifier/notify.moe/utils/page"
 )
 
@@ -30,18 +36,39 @@ func Register(app *aero.Application) {
 	// page.Get(app, "/user/:nick/quotes/added/from/:index", profilequotes.Added)
 	// page.Get(app, "/user/:nick/quotes/liked", profilequotes.Liked)
 	// page.Get(app, "/user/:nick/quotes/liked/from/:index", profilequotes.Liked)
-	// page.Get(app, "/user/:nick/stats", profile.GetStatsByUser)
+	page.Get(app, "/user/:nick/stats", profile.GetStatsByUser)
+	page.Get(app, "/user/:nick/year/:year", yearinreview.Get)
 	// page.Get(app, "/user/:nick/followers", profile.GetFollowers)
 	page.Get(app, "/user/:nick/animelist/anime/:id", animelistitem.Get)
 	page.Get(app, "/user/:nick/anime/recommended", recommended.Anime)
+	page.Get(app, "/recommendations", recommended.Personal)
 	page.Get(app, "/user/:nick/anime/sequels", explorerelations.Sequels)
 	page.Get(app, "/user/:nick/notifications", notifications.ByUser)
 	page.Get(app, "/user/:nick/edit", user.Edit)
 
+	// Custom lists
+	page.Get(app, "/user/:nick/lists", customlist.ByUser)
+	page.Get(app, "/customlist/:id", customlist.Get)
+	page.Get(app, "/customlist/:id/edit", customlist.Edit)
+
 	// Anime list
 	page.Get(app, "/user/:nick/animelist/:status", animelist.Filter)
 	page.Get(app, "/user/:nick/animelist/:status/from/:index", animelist.Filter)
 
+	// Anime list feeds
+	app.Get("/user/:nick/animelist.rss", feeds.AnimeList)
+	app.Get("/user/:nick/animelist.atom", feeds.AnimeList)
+	app.Get("/user/:nick/animelist.xml", feeds.AnimeList)
+
+	// Preview images
+	app.Get("/og-image/user/:nick", profile.OpenGraphImage)
+
+	// Year in review image
+	app.Get("/user/:nick/year/:year/image.jpg", yearinreview.Image)
+
+	// Calendar feed
+	app.Get("/user/:nick/calendar.ics", calendar.Export)
+
 	// Redirects
 	page.Get(app, "/animelist/watching", animelist.Redirect)
 	page.Get(app, "/animelist/completed", animelist.Redirect)
@@ -58,4 +85,11 @@ func Register(app *aero.Application) {
 	// Notifications
 	page.Get(app, "/notifications", notifications.ByUser)
 	page.Get(app, "/notifications/all", notifications.All)
+
+	// Messages
+	page.Get(app, "/messages", messages.Inbox)
+	page.Get(app, "/messages/sent", messages.Sent)
+	page.Get(app, "/messages/new", messages.New)
+	page.Get(app, "/messages/new/:nick", messages.New)
+	page.Get(app, "/conversation/:id", conversation.Get)
 }
@@ -0,0 +1,81 @@
+package listimportanilist
+
+import (
+	"net/http"
+
+	"github.com/aerogo/aero"
+	"github.com/animenotifier/notify.moe/arn"
+	"github.com/animenotifier/notify.moe/components"
+)
+
+// SyncPreview shows the differences between both anime lists before the synchronization is enabled.
+func SyncPreview(ctx aero.Context) error {
+	user := arn.GetUserFromContext(ctx)
+
+	if user == nil {
+		return ctx.Error(http.StatusBadRequest, "Not logged in")
+	}
+
+	if !user.IsAniListLinked() {
+		return ctx.Redirect(http.StatusTemporaryRedirect, "/auth/anilist")
+	}
+
+	changes, err := user.AniListSyncChanges()
+
+	if err != nil {
+		return ctx.Error(http.StatusBadRequest, err)
+	}
+
+	return ctx.HTML(components.ListSync("anilist.co", user.Accounts.AniList.Nick, "enableAniListSync", changes))
+}
+
+// SyncEnable synchronizes both anime lists and keeps them in sync from now on.
+func SyncEnable(ctx aero.Context) error {
+	user := arn.GetUserFromContext(ctx)
+
+	if user == nil {
+		return ctx.Error(http.StatusBadRequest, "Not logged in")
+	}
+
+	changes, err := user.AniListSyncChanges()
+
+	if err != nil {
+		return ctx.Error(http.StatusBadRequest, err)
+	}
+
+	err = user.SyncAniList(changes)
+
+	if err != nil {
+		return ctx.Error(http.StatusBadRequest, "Couldn't synchronize with AniList", err)
+	}
+
+	user.Accounts.AniList.Sync = true
+	user.Save()
+	return nil
+}
+
+// SyncDisable stops the synchronization and unlinks the AniList account.
+// The account needs to be authorized again before the next synchronization.
+func SyncDisable(ctx aero.Context) error {
+	user := arn.GetUserFromContext(ctx)
+
+	if user == nil {
+		return ctx.Error(http.StatusBadRequest, "Not logged in")
+	}
+
+	user.Accounts.AniList.Sync = false
+	user.Accounts.AniList.ID = 0
+	user.Accounts.AniList.AccessToken = ""
+	user.Save()
+
+	animeList := user.AnimeList()
+
+	if animeList != nil {
+		animeList.Lock()
+		animeList.AniListSynced = nil
+		animeList.Unlock()
+		animeList.Save()
+	}
+
+	return nil
+}
@@ -0,0 +1,91 @@
+package listimportfile
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/aerogo/aero"
+	"github.com/animenotifier/notify.moe/arn"
+	"github.com/animenotifier/notify.moe/components"
+)
+
+// maxFileSize is the maximum size of an uploaded anime list in bytes.
+const maxFileSize = 20 * 1024 * 1024
+
+// Upload parses an uploaded anime list export and keeps it for the preview.
+func Upload(ctx aero.Context) error {
+	user := arn.GetUserFromContext(ctx)
+
+	if user == nil {
+		return ctx.Error(http.StatusUnauthorized, "Not logged in")
+	}
+
+	reader := ctx.Request().Body().Reader()
+	defer reader.Close()
+
+	// Files exceeding the maximum size are rejected without reading the whole body
+	data, err := ioutil.ReadAll(io.LimitReader(reader, maxFileSize+1))
+
+	if err != nil {
+		return ctx.Error(http.StatusInternalServerError, "Reading request body failed", err)
+	}
+
+	if len(data) > maxFileSize {
+		return ctx.Error(http.StatusRequestEntityTooLarge, "The file is too large")
+	}
+
+	listImport, err := arn.NewListImport(user.ID, data)
+
+	if err != nil {
+		return ctx.Error(http.StatusBadRequest, err)
+	}
+
+	listImport.Save()
+	return nil
+}
+
+// Preview shows which entries of the uploaded anime list could be found.
+func Preview(ctx aero.Context) error {
+	user := arn.GetUserFromContext(ctx)
+
+	if user == nil {
+		return ctx.Error(http.StatusBadRequest, "Not logged in")
+	}
+
+	listImport, err := arn.GetListImport(user.ID)
+
+	if err != nil {
+		return ctx.Error(http.StatusNotFound, "Please upload your anime list first", err)
+	}
+
+	return ctx.HTML(components.ImportFile(user, listImport, listImport.Matches()))
+}
+
+// Finish merges the uploaded anime list into the anime list of the user.
+func Finish(ctx aero.Context) error {
+	user := arn.GetUserFromContext(ctx)
+
+	if user == nil {
+		return ctx.Error(http.StatusBadRequest, "Not logged in")
+	}
+
+	strategy := ctx.Get("strategy")
+
+	if !arn.IsValidListImportStrategy(strategy) {
+		return ctx.Error(http.StatusBadRequest, "Invalid import strategy")
+	}
+
+	listImport, err := arn.GetListImport(user.ID)
+
+	if err != nil {
+		return ctx.Error(http.StatusNotFound, "Please upload your anime list first", err)
+	}
+
+	animeList := user.AnimeList()
+	listImport.Apply(animeList, listImport.Matches(), strategy)
+	animeList.Save()
+	listImport.Delete()
+
+	return ctx.HTML(components.ImportFinished(user))
+}
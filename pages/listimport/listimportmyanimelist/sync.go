@@ -0,0 +1,82 @@
+package listimportmyanimelist
+
+import (
+	"net/http"
+
+	"github.com/aerogo/aero"
+	"github.com/animenotifier/notify.moe/arn"
+	"github.com/animenotifier/notify.moe/components"
+)
+
+// SyncPreview shows the differences between both anime lists before the synchronization is enabled.
+func SyncPreview(ctx aero.Context) error {
+	user := arn.GetUserFromContext(ctx)
+
+	if user == nil {
+		return ctx.Error(http.StatusBadRequest, "Not logged in")
+	}
+
+	if !user.IsMyAnimeListLinked() {
+		return ctx.Redirect(http.StatusTemporaryRedirect, "/auth/myanimelist")
+	}
+
+	changes, err := user.MyAnimeListSyncChanges()
+
+	if err != nil {
+		return ctx.Error(http.StatusBadRequest, err)
+	}
+
+	return ctx.HTML(components.ListSync("myanimelist.net", user.Accounts.MyAnimeList.Nick, "enableMyAnimeListSync", changes))
+}
+
+// SyncEnable synchronizes both anime lists and keeps them in sync from now on.
+func SyncEnable(ctx aero.Context) error {
+	user := arn.GetUserFromContext(ctx)
+
+	if user == nil {
+		return ctx.Error(http.StatusBadRequest, "Not logged in")
+	}
+
+	changes, err := user.MyAnimeListSyncChanges()
+
+	if err != nil {
+		return ctx.Error(http.StatusBadRequest, err)
+	}
+
+	err = user.SyncMyAnimeList(changes)
+
+	if err != nil {
+		return ctx.Error(http.StatusBadRequest, "Couldn't synchronize with MyAnimeList", err)
+	}
+
+	user.Accounts.MyAnimeList.Sync = true
+	user.Save()
+	return nil
+}
+
+// SyncDisable stops the synchronization and unlinks the MyAnimeList account.
+// The account needs to be authorized again before the next synchronization.
+func SyncDisable(ctx aero.Context) error {
+	user := arn.GetUserFromContext(ctx)
+
+	if user == nil {
+		return ctx.Error(http.StatusBadRequest, "Not logged in")
+	}
+
+	user.Accounts.MyAnimeList.Sync = false
+	user.Accounts.MyAnimeList.ID = 0
+	user.Accounts.MyAnimeList.AccessToken = ""
+	user.Accounts.MyAnimeList.RefreshToken = ""
+	user.Save()
+
+	animeList := user.AnimeList()
+
+	if animeList != nil {
+		animeList.Lock()
+		animeList.MyAnimeListSynced = nil
+		animeList.Unlock()
+		animeList.Save()
+	}
+
+	return nil
+}
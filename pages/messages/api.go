@@ -0,0 +1,21 @@
+package messages
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/aerogo/aero"
+	"github.com/animenotifier/notify.moe/arn"
+)
+
+// CountUnread sends the number of conversations with unread messages.
+func CountUnread(ctx aero.Context) error {
+	user := arn.GetUserFromContext(ctx)
+
+	if user == nil {
+		return ctx.Error(http.StatusBadRequest, "Not logged in")
+	}
+
+	unread := arn.CountUnreadConversations(user.ID)
+	return ctx.Text(strconv.Itoa(unread))
+}
@@ -0,0 +1,41 @@
+package messages
+
+import (
+	"net/http"
+
+	"github.com/aerogo/aero"
+	"github.com/animenotifier/notify.moe/arn"
+	"github.com/animenotifier/notify.moe/components"
+)
+
+// Inbox shows the conversations waiting for the user's answer.
+func Inbox(ctx aero.Context) error {
+	return render(ctx, false)
+}
+
+// Sent shows the conversations where the user wrote the latest message.
+func Sent(ctx aero.Context) error {
+	return render(ctx, true)
+}
+
+// render shows the user's conversations split by the author of the latest message.
+func render(ctx aero.Context, sent bool) error {
+	user := arn.GetUserFromContext(ctx)
+
+	if user == nil {
+		return ctx.Error(http.StatusUnauthorized, "Not logged in")
+	}
+
+	conversations := []*arn.Conversation{}
+
+	for _, conversation := range arn.GetConversationsByUser(user.ID) {
+		lastMessage := conversation.LastMessage()
+
+		if lastMessage != nil && (lastMessage.CreatedBy == user.ID) == sent {
+			conversations = append(conversations, conversation)
+		}
+	}
+
+	arn.SortConversationsLatestFirst(conversations)
+	return ctx.HTML(components.Messages(conversations, sent, user))
+}
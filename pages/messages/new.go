@@ -0,0 +1,33 @@
+package messages
+
+import (
+	"net/http"
+
+	"github.com/aerogo/aero"
+	"github.com/animenotifier/notify.moe/arn"
+	"github.com/animenotifier/notify.moe/components"
+)
+
+// New shows the form to start a conversation.
+func New(ctx aero.Context) error {
+	user := arn.GetUserFromContext(ctx)
+
+	if user == nil {
+		return ctx.Error(http.StatusUnauthorized, "Not logged in")
+	}
+
+	recipient := ""
+	nick := ctx.Get("nick")
+
+	if nick != "" {
+		recipientUser, err := arn.GetVisibleUserByNick(nick)
+
+		if err != nil {
+			return ctx.Error(http.StatusNotFound, "User not found", err)
+		}
+
+		recipient = recipientUser.Nick
+	}
+
+	return ctx.HTML(components.NewConversation(recipient, user))
+}
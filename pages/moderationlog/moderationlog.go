@@ -0,0 +1,48 @@
+package moderationlog
+
+import (
+	"github.com/aerogo/aero"
+	"github.com/animenotifier/notify.moe/arn"
+	"github.com/animenotifier/notify.moe/components"
+	"github.com/animenotifier/notify.moe/utils/infinitescroll"
+)
+
+const (
+	entriesFirstLoad = 120
+	entriesPerScroll = 40
+)
+
+// Get moderation log.
+func Get(ctx aero.Context) error {
+	user := arn.GetUserFromContext(ctx)
+	index, _ := ctx.GetInt("index")
+	allEntries := arn.AllModerationLogEntries()
+	arn.SortModerationLogEntriesLatestFirst(allEntries)
+
+	// Slice the part that we need
+	if index > len(allEntries) {
+		index = len(allEntries)
+	}
+
+	entries := allEntries[index:]
+	maxLength := entriesFirstLoad
+
+	if index > 0 {
+		maxLength = entriesPerScroll
+	}
+
+	if len(entries) > maxLength {
+		entries = entries[:maxLength]
+	}
+
+	// Next index
+	nextIndex := infinitescroll.NextIndex(ctx, len(allEntries), maxLength, index)
+
+	// In case we're scrolling, send log entries only (without the page frame)
+	if index > 0 {
+		return ctx.HTML(components.ModerationLogScrollable(entries, user))
+	}
+
+	// Otherwise, send the full page
+	return ctx.HTML(components.ModerationLogPage(entries, nextIndex, user))
+}
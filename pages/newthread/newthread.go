@@ -16,5 +16,13 @@ func Get(ctx aero.Context) error {
 		return ctx.Error(http.StatusBadRequest, "Not logged in")
 	}
 
-	return ctx.HTML(components.NewThread(user))
+	// Restore the unposted thread
+	draft := user.Draft(arn.NewThreadDraftKey)
+	tag := draft.Tag
+
+	if tag == "" {
+		tag = "general"
+	}
+
+	return ctx.HTML(components.NewThread(draft, tag, user))
 }
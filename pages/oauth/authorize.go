@@ -0,0 +1,156 @@
+package oauth
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/aerogo/aero"
+	"github.com/animenotifier/notify.moe/arn"
+	"github.com/animenotifier/notify.moe/components"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// loginRedirectKey is the session key for the page shown after logging in.
+const loginRedirectKey = "loginRedirect"
+
+// Authorize shows the consent page where the user can approve the request of an app.
+func Authorize(ctx aero.Context) error {
+	request := arn.NewOAuthRequest(ctx.Query)
+	client, err := request.Client()
+
+	if err != nil {
+		return ctx.HTML(components.OAuthError(err.Error()))
+	}
+
+	if ctx.Query("response_type") != "code" {
+		return ctx.Redirect(http.StatusFound, request.Redirect(url.Values{
+			"error":             {"unsupported_response_type"},
+			"error_description": {"Only the authorization code flow is supported"},
+		}))
+	}
+
+	scopes, err := request.Scopes()
+
+	if err != nil {
+		return ctx.Redirect(http.StatusFound, request.Redirect(url.Values{
+			"error":             {"invalid_scope"},
+			"error_description": {err.Error()},
+		}))
+	}
+
+	user := arn.GetUserFromContext(ctx)
+
+	if user == nil {
+		ctx.Session().Set(loginRedirectKey, ctx.Path()+"?"+ctx.Request().Internal().URL.RawQuery)
+		return ctx.HTML(components.OAuthLogin(client))
+	}
+
+	denyFraming(ctx)
+	return ctx.HTML(components.OAuthAuthorize(client, arn.FilterOAuthScopes(scopes), request, user))
+}
+
+// Approve creates an authorization code and returns the redirect URI the user should be sent to.
+func Approve(ctx aero.Context) error {
+	user, request, err := consentRequest(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	scopes, err := request.Scopes()
+
+	if err != nil {
+		return ctx.Error(http.StatusBadRequest, err)
+	}
+
+	code := request.NewCode(user.ID, scopes)
+	code.Save()
+
+	return ctx.JSON(map[string]string{
+		"redirect": request.Redirect(url.Values{
+			"code": {code.Code},
+		}),
+	})
+}
+
+// Deny returns the redirect URI that informs the app that the user declined the request.
+func Deny(ctx aero.Context) error {
+	_, request, err := consentRequest(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(map[string]string{
+		"redirect": request.Redirect(url.Values{
+			"error":             {"access_denied"},
+			"error_description": {"The user denied the request"},
+		}),
+	})
+}
+
+// consentRequest reads the authorization request sent by the consent page.
+// The returned error has already been written to the response.
+func consentRequest(ctx aero.Context) (*arn.User, *arn.OAuthRequest, error) {
+	user := arn.GetUserFromContext(ctx)
+
+	if user == nil {
+		return nil, nil, ctx.Error(http.StatusUnauthorized, "Not logged in")
+	}
+
+	if !isSameOrigin(ctx) {
+		return nil, nil, ctx.Error(http.StatusForbidden, "Invalid origin")
+	}
+
+	body, err := ctx.Request().Body().Bytes()
+
+	if err != nil {
+		return nil, nil, ctx.Error(http.StatusBadRequest, err)
+	}
+
+	request := &arn.OAuthRequest{}
+	err = jsoniter.Unmarshal(body, request)
+
+	if err != nil {
+		return nil, nil, ctx.Error(http.StatusBadRequest, err)
+	}
+
+	_, err = request.Client()
+
+	if err != nil {
+		return nil, nil, ctx.Error(http.StatusBadRequest, err)
+	}
+
+	return user, request, nil
+}
+
+// isSameOrigin tells whether the request has been sent by our own page.
+// Requests without an Origin or Referer header are rejected.
+func isSameOrigin(ctx aero.Context) bool {
+	source := ctx.Request().Header("Origin")
+
+	if source == "" || source == "null" {
+		source = ctx.Request().Header("Referer")
+	}
+
+	parsed, err := url.Parse(source)
+	return err == nil && parsed.Host != "" && parsed.Host == ctx.Request().Host()
+}
+
+// denyFraming forbids other sites to embed the consent page in a frame
+// where they could trick the user into clicking the approve button.
+// The content security policy is written when the response is sent, so it's extended in a modifier.
+func denyFraming(ctx aero.Context) {
+	ctx.Response().SetHeader("X-Frame-Options", "DENY")
+
+	ctx.AddModifier(func(content []byte) []byte {
+		policy := ctx.Response().Header("Content-Security-Policy")
+
+		if policy != "" {
+			policy += "; "
+		}
+
+		ctx.Response().SetHeader("Content-Security-Policy", policy+"frame-ancestors 'none'")
+		return content
+	})
+}
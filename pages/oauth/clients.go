@@ -0,0 +1,102 @@
+package oauth
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/aerogo/aero"
+	"github.com/animenotifier/notify.moe/arn"
+)
+
+// NewClient registers a new app for the logged in user.
+func NewClient(ctx aero.Context) error {
+	user := arn.GetUserFromContext(ctx)
+
+	if user == nil {
+		return ctx.Error(http.StatusUnauthorized, "Not logged in")
+	}
+
+	if len(user.OAuthClients()) >= arn.MaxUserOAuthClients {
+		return ctx.Error(http.StatusBadRequest, "You can't register more than 5 apps")
+	}
+
+	body, err := ctx.Request().Body().JSONObject()
+
+	if err != nil {
+		return ctx.Error(http.StatusBadRequest, err)
+	}
+
+	name, _ := body["name"].(string)
+	website, _ := body["website"].(string)
+	redirectURIs, _ := body["redirectURIs"].(string)
+
+	client, secret, err := arn.NewOAuthClient(name, website, strings.Fields(redirectURIs), user.ID)
+
+	if err != nil {
+		return ctx.Error(http.StatusBadRequest, err)
+	}
+
+	client.Save()
+
+	// The secret is only stored as a hash, so this is the only time it can be shown
+	return ctx.JSON(map[string]string{
+		"id":     client.ID,
+		"secret": secret,
+	})
+}
+
+// DeleteClient deletes an app of the logged in user and revokes all of its tokens.
+func DeleteClient(ctx aero.Context) error {
+	user, client, err := requestClient(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	if client.CreatedBy != user.ID {
+		return ctx.Error(http.StatusForbidden, "Not your app")
+	}
+
+	client.Delete()
+	return nil
+}
+
+// RevokeAuthorization revokes all tokens the logged in user granted to an app.
+func RevokeAuthorization(ctx aero.Context) error {
+	user, client, err := requestClient(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	if arn.RevokeOAuthTokens(user.ID, client.ID) == 0 {
+		return ctx.Error(http.StatusNotFound, "You haven't authorized this app")
+	}
+
+	return nil
+}
+
+// requestClient returns the logged in user and the app specified in the request body.
+// The returned error has already been written to the response.
+func requestClient(ctx aero.Context) (*arn.User, *arn.OAuthClient, error) {
+	user := arn.GetUserFromContext(ctx)
+
+	if user == nil {
+		return nil, nil, ctx.Error(http.StatusUnauthorized, "Not logged in")
+	}
+
+	body, err := ctx.Request().Body().JSONObject()
+
+	if err != nil {
+		return nil, nil, ctx.Error(http.StatusBadRequest, err)
+	}
+
+	clientID, _ := body["clientId"].(string)
+	client, err := arn.GetOAuthClient(clientID)
+
+	if err != nil {
+		return nil, nil, ctx.Error(http.StatusNotFound, "App not found", err)
+	}
+
+	return user, client, nil
+}
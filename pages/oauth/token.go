@@ -0,0 +1,144 @@
+package oauth
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/aerogo/aero"
+	"github.com/animenotifier/notify.moe/arn"
+)
+
+// tokenResponse is returned when an authorization code was exchanged successfully.
+type tokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int    `json:"expires_in"`
+	Scope       string `json:"scope"`
+}
+
+// errorResponse describes a failed token request as defined in RFC 6749.
+type errorResponse struct {
+	Error       string `json:"error"`
+	Description string `json:"error_description"`
+}
+
+// Token exchanges an authorization code for an access token.
+// Apps that can't keep their client secret private must use PKCE instead.
+func Token(ctx aero.Context) error {
+	ctx.Response().SetHeader("Cache-Control", "no-store")
+	form, err := readForm(ctx)
+
+	if err != nil {
+		return oauthError(ctx, http.StatusBadRequest, "invalid_request", err.Error())
+	}
+
+	if form.Get("grant_type") != "authorization_code" {
+		return oauthError(ctx, http.StatusBadRequest, "unsupported_grant_type", "Only the authorization_code grant type is supported")
+	}
+
+	client, clientSecret, err := requestClientCredentials(ctx, form)
+
+	if err != nil {
+		return oauthError(ctx, http.StatusUnauthorized, "invalid_client", "Unknown client")
+	}
+
+	code, err := arn.GetOAuthCode(form.Get("code"))
+
+	if err != nil || code.ClientID != client.ID {
+		return oauthError(ctx, http.StatusBadRequest, "invalid_grant", "Invalid authorization code")
+	}
+
+	// Codes can only be used once
+	code.Delete()
+
+	if code.IsExpired() {
+		return oauthError(ctx, http.StatusBadRequest, "invalid_grant", "The authorization code has expired")
+	}
+
+	if code.RedirectURI != form.Get("redirect_uri") {
+		return oauthError(ctx, http.StatusBadRequest, "invalid_grant", "The redirect URI does not match the authorization request")
+	}
+
+	if code.CodeChallenge != "" {
+		if !code.VerifyChallenge(form.Get("code_verifier")) {
+			return oauthError(ctx, http.StatusBadRequest, "invalid_grant", "Invalid code verifier")
+		}
+	} else if !client.VerifySecret(clientSecret) {
+		return oauthError(ctx, http.StatusUnauthorized, "invalid_client", "Invalid client secret")
+	}
+
+	token, accessToken := arn.NewOAuthToken(client.ID, code.UserID, code.Scopes)
+	token.Save()
+
+	return ctx.JSON(&tokenResponse{
+		AccessToken: accessToken,
+		TokenType:   "Bearer",
+		ExpiresIn:   int(arn.OAuthTokenDuration.Seconds()),
+		Scope:       strings.Join(token.Scopes, " "),
+	})
+}
+
+// Revoke revokes an access token as defined in RFC 7009.
+// The app needs to authenticate and can only revoke its own tokens.
+// Unknown tokens are not an error because the result is the same.
+func Revoke(ctx aero.Context) error {
+	form, err := readForm(ctx)
+
+	if err != nil {
+		return oauthError(ctx, http.StatusBadRequest, "invalid_request", err.Error())
+	}
+
+	client, clientSecret, err := requestClientCredentials(ctx, form)
+
+	if err != nil || !client.VerifySecret(clientSecret) {
+		return oauthError(ctx, http.StatusUnauthorized, "invalid_client", "Invalid client credentials")
+	}
+
+	token, err := arn.GetOAuthToken(form.Get("token"))
+
+	if err == nil && token.ClientID == client.ID {
+		token.Delete()
+	}
+
+	return nil
+}
+
+// requestClientCredentials returns the app and the client secret sent with the request.
+// The credentials can be sent via HTTP basic authentication or in the form.
+func requestClientCredentials(ctx aero.Context, form url.Values) (*arn.OAuthClient, string, error) {
+	clientID, clientSecret, hasBasicAuth := ctx.Request().Internal().BasicAuth()
+
+	if !hasBasicAuth {
+		clientID = form.Get("client_id")
+		clientSecret = form.Get("client_secret")
+	}
+
+	client, err := arn.GetOAuthClient(clientID)
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	return client, clientSecret, nil
+}
+
+// readForm parses the URL-encoded body of the request.
+func readForm(ctx aero.Context) (url.Values, error) {
+	body, err := ctx.Request().Body().String()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return url.ParseQuery(body)
+}
+
+// oauthError sends an error in the format that OAuth clients expect.
+func oauthError(ctx aero.Context, status int, code string, description string) error {
+	ctx.SetStatus(status)
+	return ctx.JSON(&errorResponse{
+		Error:       code,
+		Description: description,
+	})
+}
@@ -0,0 +1,23 @@
+package person
+
+import (
+	"net/http"
+
+	"github.com/aerogo/aero"
+	"github.com/animenotifier/notify.moe/arn"
+	"github.com/animenotifier/notify.moe/components"
+	"github.com/animenotifier/notify.moe/utils/editform"
+)
+
+// Edit person.
+func Edit(ctx aero.Context) error {
+	id := ctx.Get("id")
+	person, err := arn.GetPerson(id)
+	user := arn.GetUserFromContext(ctx)
+
+	if err != nil {
+		return ctx.Error(http.StatusNotFound, "Person not found", err)
+	}
+
+	return ctx.HTML(components.PersonTabs(person, user) + editform.Render(person, "Edit person", user))
+}
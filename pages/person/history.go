@@ -0,0 +1,14 @@
+package person
+
+import (
+	"github.com/animenotifier/notify.moe/arn"
+	"github.com/animenotifier/notify.moe/components"
+	"github.com/animenotifier/notify.moe/utils/history"
+)
+
+// History of the edits.
+var History = history.Handler(renderHistory, "Person")
+
+func renderHistory(obj interface{}, entries []*arn.EditLogEntry, user *arn.User) string {
+	return components.PersonTabs(obj.(*arn.Person), user) + components.EditLog(entries, user)
+}
@@ -0,0 +1,37 @@
+package person
+
+import (
+	"net/http"
+
+	"github.com/aerogo/aero"
+	"github.com/animenotifier/notify.moe/arn"
+	"github.com/animenotifier/notify.moe/components"
+	"github.com/animenotifier/notify.moe/server/middleware"
+	"github.com/animenotifier/notify.moe/utils/opengraph"
+)
+
+// Get renders a person page.
+func Get(ctx aero.Context) error {
+	user := arn.GetUserFromContext(ctx)
+	id := ctx.Get("id")
+	person, err := arn.GetPerson(id)
+
+	if err != nil {
+		return ctx.Error(http.StatusNotFound, "Person not found", err)
+	}
+
+	roles := person.Roles()
+
+	customCtx := ctx.(*middleware.OpenGraphContext)
+	openGraph := opengraph.New(person.String(), person.String()+" voice acting roles.").
+		URL(person.Link()).
+		Type("profile").
+		Keywords(person.String(), "anime", "voice actor")
+
+	if person.HasImage() {
+		openGraph = openGraph.Image(person.ImageLink("large"))
+	}
+
+	customCtx.OpenGraph = openGraph.Build()
+	return ctx.HTML(components.PersonDetails(person, roles, user))
+}
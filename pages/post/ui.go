@@ -0,0 +1,29 @@
+package post
+
+import (
+	"net/http"
+
+	"github.com/aerogo/aero"
+	"github.com/animenotifier/notify.moe/arn"
+	"github.com/animenotifier/notify.moe/components"
+)
+
+// UI renders a single post of a thread so that open thread pages can add new posts.
+func UI(ctx aero.Context) error {
+	id := ctx.Get("id")
+	user := arn.GetUserFromContext(ctx)
+	post, err := arn.GetPost(id)
+
+	if err != nil {
+		return ctx.Error(http.StatusNotFound, "Post not found", err)
+	}
+
+	thread, inThread := post.TopMostParent().(*arn.Thread)
+
+	// Removed threads are only visible to moderators
+	if !inThread || (thread.IsSoftDeleted() && (user == nil || !user.IsModerator())) {
+		return ctx.Error(http.StatusNotFound, "Post not found")
+	}
+
+	return ctx.HTML(components.Postable(post, user, false, false, thread.Creator().ID))
+}
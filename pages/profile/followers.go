@@ -12,7 +12,7 @@ package profile
 // // GetFollowers shows the followers of a particular user.
 // func GetFollowers(ctx aero.Context) error {
 // 	nick := ctx.Get("nick")
-// 	viewUser, err := arn.GetUserByNick(nick)
+// 	viewUser, err := arn.GetVisibleUserByNick(nick)
 
 // 	if err != nil {
 // 		return ctx.Error(http.StatusNotFound, "User not found", err)
@@ -0,0 +1,60 @@
+package profile
+
+import (
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/aerogo/aero"
+	"github.com/animenotifier/notify.moe/arn"
+	"github.com/animenotifier/notify.moe/server/middleware"
+	"github.com/animenotifier/notify.moe/utils/ogimage"
+)
+
+// OpenGraphImage renders the preview image that is shown when the profile is shared.
+func OpenGraphImage(ctx aero.Context) error {
+	nick := strings.TrimSuffix(ctx.Get("nick"), ".png")
+	viewUser, err := arn.GetVisibleUserByNick(nick)
+
+	if err != nil {
+		return ctx.Error(http.StatusNotFound, "User not found", err)
+	}
+
+	animeList := viewUser.AnimeList().WithoutPrivateItems()
+	completed := animeList.FilterStatus(arn.AnimeListStatusCompleted)
+	lines := []string{
+		fmt.Sprintf("%d anime, %d completed", len(animeList.Items), len(completed.Items)),
+	}
+
+	stats := viewUser.Statistics()
+
+	if stats != nil {
+		lines = append(lines, fmt.Sprintf("%d episodes watched", stats.EpisodesWatched))
+	}
+
+	if viewUser.Registered != "" {
+		lines = append(lines, "Member since "+viewUser.RegisteredTime().Format("Jan 2006"))
+	}
+
+	// Users have no edit date, so the key includes the rendered texts instead
+	key := fmt.Sprintf("user:%s:%s:%d:%s", viewUser.ID, viewUser.Nick, viewUser.Avatar.LastModified, strings.Join(lines, "\n"))
+
+	data, err := ogimage.Cached(key, func() ([]byte, error) {
+		card := &ogimage.Card{
+			Poster: ogimage.LoadImage(path.Join(arn.Root, "images", "avatars", "large", viewUser.ID+viewUser.Avatar.Extension)),
+			Title:  viewUser.Nick,
+			Lines:  lines,
+		}
+
+		return card.PNG()
+	})
+
+	if err != nil {
+		return ctx.Error(http.StatusInternalServerError, "Could not render the image", err)
+	}
+
+	ctx.Response().SetHeader("Content-Type", "image/png")
+	middleware.SetCacheControl(ctx, "public, max-age=3600")
+	return ctx.Bytes(data)
+}
@@ -14,7 +14,7 @@ package profile
 // // GetPostsByUser shows all forum posts of a particular user.
 // func GetPostsByUser(ctx aero.Context) error {
 // 	nick := ctx.Get("nick")
-// 	viewUser, err := arn.GetUserByNick(nick)
+// 	viewUser, err := arn.GetVisibleUserByNick(nick)
 
 // 	if err != nil {
 // 		return ctx.Error(http.StatusNotFound, "User not found", err)
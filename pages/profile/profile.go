@@ -6,10 +6,11 @@ import (
 
 	"github.com/aerogo/aero"
 	"github.com/animenotifier/notify.moe/arn"
-	"github.com/animenotifier/notify.moe/assets"
 	"github.com/animenotifier/notify.moe/components"
 	"github.com/animenotifier/notify.moe/server/middleware"
 	"github.com/animenotifier/notify.moe/utils"
+	"github.com/animenotifier/notify.moe/utils/ogimage"
+	"github.com/animenotifier/notify.moe/utils/opengraph"
 )
 
 const (
@@ -22,12 +23,16 @@ const (
 // Get user profile page.
 func Get(ctx aero.Context) error {
 	nick := ctx.Get("nick")
-	viewUser, err := arn.GetUserByNick(nick)
+	viewUser, err := arn.GetVisibleUserByNick(nick)
 
 	if err != nil {
 		return ctx.Error(404, "User not found", err)
 	}
 
+	if viewUser.IsDeactivated() {
+		return ctx.Error(404, "This account has been deactivated")
+	}
+
 	return Profile(ctx, viewUser)
 }
 
@@ -105,21 +110,15 @@ func Profile(ctx aero.Context, viewUser *arn.User) error {
 	}
 
 	// Open graph
-	openGraph := &arn.OpenGraph{
-		Tags: map[string]string{
-			"og:title":         viewUser.Nick,
-			"og:image":         viewUser.AvatarLink("large"),
-			"og:url":           "https://" + assets.Domain + viewUser.Link(),
-			"og:site_name":     "notify.moe",
-			"og:description":   utils.CutLongDescription(viewUser.Introduction),
-			"og:type":          "profile",
-			"profile:username": viewUser.Nick,
-		},
-		Meta: map[string]string{
-			"description": utils.CutLongDescription(viewUser.Introduction),
-			"keywords":    viewUser.Nick + ",profile",
-		},
-	}
+	openGraph := opengraph.New(viewUser.Nick, viewUser.Introduction).
+		URL(viewUser.Link()).
+		Type("profile").
+		Tag("profile:username", viewUser.Nick).
+		Keywords(viewUser.Nick, "profile").
+		StructuredData(getStructuredData(viewUser)).
+		Build()
+
+	ogimage.SetImage(openGraph, "/og-image/user/"+viewUser.Nick+".png")
 
 	// Friends
 	friends := viewUser.Friends()
@@ -221,6 +220,7 @@ func Profile(ctx aero.Context, viewUser *arn.User) error {
 		completedList,
 		characters,
 		groups,
+		viewUser.CustomLists(user),
 		friends,
 		topGenres,
 		topStudios,
@@ -229,3 +229,25 @@ func Profile(ctx aero.Context, viewUser *arn.User) error {
 		ctx.Path(),
 	))
 }
+
+// getStructuredData describes the profile as a schema.org profile page about the user.
+func getStructuredData(viewUser *arn.User) arn.StructuredData {
+	person := arn.NewStructuredPerson(viewUser)
+
+	if viewUser.HasAvatar() {
+		person["image"] = "https:" + viewUser.AvatarLink("large")
+	}
+
+	if viewUser.Introduction != "" {
+		person["description"] = utils.CutLongDescription(viewUser.Introduction)
+	}
+
+	data := arn.NewStructuredData("ProfilePage")
+	data["mainEntity"] = person
+
+	if viewUser.Registered != "" {
+		data["dateCreated"] = viewUser.Registered
+	}
+
+	return data
+}
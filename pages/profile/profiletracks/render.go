@@ -20,7 +20,7 @@ package profiletracks
 // 	nick := ctx.Get("nick")
 // 	index, _ := ctx.GetInt("index")
 // 	user := arn.GetUserFromContext(ctx)
-// 	viewUser, err := arn.GetUserByNick(nick)
+// 	viewUser, err := arn.GetVisibleUserByNick(nick)
 
 // 	if err != nil {
 // 		return ctx.Error(http.StatusNotFound, "User not found", err)
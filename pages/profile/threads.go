@@ -14,7 +14,7 @@ package profile
 // // GetThreadsByUser shows all forum threads of a particular user.
 // func GetThreadsByUser(ctx aero.Context) error {
 // 	nick := ctx.Get("nick")
-// 	viewUser, err := arn.GetUserByNick(nick)
+// 	viewUser, err := arn.GetVisibleUserByNick(nick)
 
 // 	if err != nil {
 // 		return ctx.Error(http.StatusNotFound, "User not found", err)
@@ -3,13 +3,12 @@ package quote
 import (
 	"net/http"
 
-	"github.com/animenotifier/notify.moe/server/middleware"
-
 	"github.com/aerogo/aero"
 	"github.com/animenotifier/notify.moe/arn"
-	"github.com/animenotifier/notify.moe/assets"
 	"github.com/animenotifier/notify.moe/components"
+	"github.com/animenotifier/notify.moe/server/middleware"
 	"github.com/animenotifier/notify.moe/utils/editform"
+	"github.com/animenotifier/notify.moe/utils/opengraph"
 )
 
 // Edit quote.
@@ -22,18 +21,14 @@ func Edit(ctx aero.Context) error {
 		return ctx.Error(http.StatusNotFound, "Quote not found", err)
 	}
 
-	customCtx := ctx.(*middleware.OpenGraphContext)
-	customCtx.OpenGraph = &arn.OpenGraph{
-		Tags: map[string]string{
-			"og:title":     quote.Text.English,
-			"og:url":       "https://" + assets.Domain + quote.Link(),
-			"og:site_name": "notify.moe",
-		},
-	}
+	builder := opengraph.New(quote.Text.English, "").URL(quote.Link())
 
 	if quote.Character() != nil {
-		customCtx.OpenGraph.Tags["og:image"] = quote.Character().ImageLink("large")
+		builder.Image(quote.Character().ImageLink("large"))
 	}
 
+	customCtx := ctx.(*middleware.OpenGraphContext)
+	customCtx.OpenGraph = builder.Build()
+
 	return ctx.HTML(components.QuoteTabs(quote, user) + editform.Render(quote, "Edit quote", user))
 }
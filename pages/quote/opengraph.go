@@ -0,0 +1,52 @@
+package quote
+
+import (
+	"fmt"
+
+	"github.com/animenotifier/notify.moe/arn"
+	"github.com/animenotifier/notify.moe/utils"
+	"github.com/animenotifier/notify.moe/utils/opengraph"
+)
+
+func getOpenGraph(quote *arn.Quote, character *arn.Character) *arn.OpenGraph {
+	title := "Quote"
+	description := "\"" + quote.Text.English + "\""
+	image := ""
+	keywords := []string{"anime", "quote"}
+	anime := quote.Anime()
+
+	if character != nil {
+		title = character.Name.Canonical + "'s quote"
+		image = utils.BestImageVariant(character, utils.OpenGraphImageRatio)
+		keywords = append(keywords, character.Name.Canonical)
+	}
+
+	if anime != nil {
+		source := anime.Title.Canonical
+
+		if quote.EpisodeNumber > 0 {
+			source = fmt.Sprintf("%s, episode %d", source, quote.EpisodeNumber)
+		}
+
+		title += " in " + anime.Title.Canonical
+		description += " - " + source
+		keywords = append(keywords, anime.Title.Canonical)
+
+		if image == "" {
+			image = anime.ImageLink("large")
+		}
+	}
+
+	builder := opengraph.New(title, description).
+		URL(quote.Link()).
+		Image(image).
+		Type("article").
+		Keywords(keywords...)
+
+	// Quotes waiting for approval shouldn't show up in search engines
+	if !quote.IsPublic() {
+		builder.Meta("robots", "noindex")
+	}
+
+	return builder.Build()
+}
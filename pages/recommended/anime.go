@@ -19,7 +19,7 @@ const (
 func Anime(ctx aero.Context) error {
 	user := arn.GetUserFromContext(ctx)
 	nick := ctx.Get("nick")
-	viewUser, err := arn.GetUserByNick(nick)
+	viewUser, err := arn.GetVisibleUserByNick(nick)
 
 	if err != nil {
 		return ctx.Error(http.StatusUnauthorized, "Not logged in", err)
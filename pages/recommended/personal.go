@@ -0,0 +1,53 @@
+package recommended
+
+import (
+	"net/http"
+
+	"github.com/aerogo/aero"
+	"github.com/animenotifier/notify.moe/arn"
+	"github.com/animenotifier/notify.moe/components"
+)
+
+// Personal shows the anime that fans of the user's favorite anime liked as well.
+func Personal(ctx aero.Context) error {
+	user := arn.GetUserFromContext(ctx)
+
+	if user == nil {
+		return ctx.Error(http.StatusUnauthorized, "Not logged in")
+	}
+
+	animeList := user.AnimeList()
+	becauseOf := []*arn.Anime{}
+	suggestions := map[arn.AnimeID][]*arn.Anime{}
+	recommendations, err := arn.GetUserRecommendations(user.ID)
+
+	if err == nil {
+		for _, recommendation := range recommendations.Items {
+			// The recommendations are calculated once a day,
+			// therefore the user might have added the anime in the meantime.
+			if animeList.Contains(recommendation.AnimeID) {
+				continue
+			}
+
+			anime := recommendation.Anime()
+
+			if anime == nil || anime.IsDraft {
+				continue
+			}
+
+			if suggestions[recommendation.BecauseOf] == nil {
+				liked, err := arn.GetAnime(recommendation.BecauseOf)
+
+				if err != nil {
+					continue
+				}
+
+				becauseOf = append(becauseOf, liked)
+			}
+
+			suggestions[recommendation.BecauseOf] = append(suggestions[recommendation.BecauseOf], anime)
+		}
+	}
+
+	return ctx.HTML(components.PersonalRecommendations(becauseOf, suggestions, user))
+}
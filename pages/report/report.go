@@ -0,0 +1,34 @@
+package report
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/aerogo/aero"
+	"github.com/animenotifier/notify.moe/arn"
+	"github.com/animenotifier/notify.moe/components"
+)
+
+// Get shows the form to report a post, thread or user profile.
+func Get(ctx aero.Context) error {
+	user := arn.GetUserFromContext(ctx)
+
+	if user == nil {
+		return ctx.Error(http.StatusUnauthorized, "Not logged in")
+	}
+
+	typeName := strings.Title(ctx.Get("type"))
+	id := ctx.Get("id")
+
+	if !arn.IsReportableType(typeName) {
+		return ctx.Error(http.StatusNotFound, "This can't be reported")
+	}
+
+	title := arn.GetObjectTitle(typeName, id)
+
+	if strings.HasPrefix(title, "<not found:") {
+		return ctx.Error(http.StatusNotFound, typeName+" not found")
+	}
+
+	return ctx.HTML(components.NewReport(typeName, id, title, arn.GetObjectLink(typeName, id), user))
+}
@@ -0,0 +1,45 @@
+package reports
+
+import (
+	"sort"
+
+	"github.com/aerogo/aero"
+	"github.com/animenotifier/notify.moe/arn"
+	"github.com/animenotifier/notify.moe/components"
+)
+
+// maxResolvedReports is the number of resolved reports shown in the history.
+const maxResolvedReports = 100
+
+// Open shows the reports that need to be handled, oldest first.
+func Open(ctx aero.Context) error {
+	user := arn.GetUserFromContext(ctx)
+
+	reports := arn.FilterReports(func(report *arn.Report) bool {
+		return report.IsOpen()
+	})
+
+	// Handle the oldest reports first
+	sort.Slice(reports, func(i, j int) bool {
+		return reports[i].Created < reports[j].Created
+	})
+
+	return ctx.HTML(components.Reports(reports, false, user))
+}
+
+// Resolved shows the latest dismissed and actioned reports.
+func Resolved(ctx aero.Context) error {
+	user := arn.GetUserFromContext(ctx)
+
+	reports := arn.FilterReports(func(report *arn.Report) bool {
+		return !report.IsOpen()
+	})
+
+	arn.SortReportsLatestFirst(reports)
+
+	if len(reports) > maxResolvedReports {
+		reports = reports[:maxResolvedReports]
+	}
+
+	return ctx.HTML(components.Reports(reports, true, user))
+}
@@ -0,0 +1,27 @@
+package review
+
+import (
+	"net/http"
+
+	"github.com/aerogo/aero"
+	"github.com/animenotifier/notify.moe/arn"
+	"github.com/animenotifier/notify.moe/components"
+	"github.com/animenotifier/notify.moe/utils/editform"
+)
+
+// Edit shows the form to write or change a review.
+func Edit(ctx aero.Context) error {
+	user := arn.GetUserFromContext(ctx)
+
+	if user == nil {
+		return ctx.Error(http.StatusUnauthorized, "Not logged in")
+	}
+
+	review, err := arn.GetReview(ctx.Get("id"))
+
+	if err != nil || review.CreatedBy != user.ID {
+		return ctx.Error(http.StatusNotFound, "Review not found", err)
+	}
+
+	return ctx.HTML(components.ReviewTabs(review, user) + editform.Render(review, "Edit review", user))
+}
@@ -0,0 +1,37 @@
+package review
+
+import (
+	"net/http"
+
+	"github.com/aerogo/aero"
+	"github.com/animenotifier/notify.moe/arn"
+	"github.com/animenotifier/notify.moe/components"
+	"github.com/animenotifier/notify.moe/server/middleware"
+	"github.com/animenotifier/notify.moe/utils"
+	"github.com/animenotifier/notify.moe/utils/opengraph"
+)
+
+// Get renders a single review.
+func Get(ctx aero.Context) error {
+	user := arn.GetUserFromContext(ctx)
+	review, err := arn.GetReview(ctx.Get("id"))
+
+	if err != nil {
+		return ctx.Error(http.StatusNotFound, "Review not found", err)
+	}
+
+	anime := review.Anime()
+
+	if anime == nil {
+		return ctx.Error(http.StatusNotFound, "Anime not found")
+	}
+
+	customCtx := ctx.(*middleware.OpenGraphContext)
+	customCtx.OpenGraph = opengraph.New(review.TitleByUser(nil), utils.CutLongDescription(review.Text)).
+		URL(review.Link()).
+		Image(anime.ImageLink("large")).
+		Type("article").
+		Build()
+
+	return ctx.HTML(components.ReviewPage(review, anime, user))
+}
@@ -0,0 +1,37 @@
+package search
+
+import (
+	"net/http"
+
+	"github.com/aerogo/aero"
+	"github.com/animenotifier/notify.moe/arn"
+	"github.com/animenotifier/notify.moe/arn/search"
+	"github.com/animenotifier/notify.moe/components"
+)
+
+const (
+	maxFullTextAnime      = 25
+	maxFullTextCharacters = 22
+	maxFullTextThreads    = 10
+	maxFullTextUsers      = 25
+)
+
+// FullText renders the full-text search results for the "q" parameter split by category.
+func FullText(ctx aero.Context) error {
+	term := ctx.Query("q")
+	user := arn.GetUserFromContext(ctx)
+
+	if len(term) > search.MaxSearchTermLength {
+		ctx.SetStatus(http.StatusRequestEntityTooLarge)
+	}
+
+	results := search.FullText(
+		term,
+		maxFullTextAnime,
+		maxFullTextCharacters,
+		maxFullTextThreads,
+		maxFullTextUsers,
+	)
+
+	return ctx.HTML(components.FullTextSearchResults(term, results.Anime, results.Characters, results.Threads, results.Users, user))
+}
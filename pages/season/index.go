@@ -0,0 +1,111 @@
+package season
+
+import (
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/animenotifier/notify.moe/arn"
+)
+
+// indexRefreshInterval defines how often the season index is rebuilt.
+const indexRefreshInterval = 10 * time.Minute
+
+// seasonIndex maps each season to the anime that started airing in it.
+type seasonIndex struct {
+	seasons map[string]*seasonData
+	created time.Time
+}
+
+// seasonData contains the anime of a season and the genres and studios they have.
+type seasonData struct {
+	anime   []*arn.Anime
+	genres  []string
+	studios []*arn.Company
+}
+
+var (
+	index           *seasonIndex
+	indexRebuilding bool
+	indexMutex      sync.Mutex
+)
+
+// Anime returns all anime that started airing in the given season.
+// The returned slice is shared and must not be modified.
+func Anime(year int, season string) []*arn.Anime {
+	return cached(year, season).anime
+}
+
+// cached returns the indexed data for the given season.
+// Only the first request waits for the index, outdated indices
+// are rebuilt in the background while the old one is still served.
+func cached(year int, season string) *seasonData {
+	indexMutex.Lock()
+	defer indexMutex.Unlock()
+
+	if index == nil {
+		index = buildIndex()
+	} else if time.Since(index.created) > indexRefreshInterval && !indexRebuilding {
+		indexRebuilding = true
+		go rebuildIndex()
+	}
+
+	data, exists := index.seasons[key(year, season)]
+
+	if !exists {
+		return &seasonData{}
+	}
+
+	return data
+}
+
+// rebuildIndex replaces the index with a new one.
+func rebuildIndex() {
+	rebuilt := buildIndex()
+
+	indexMutex.Lock()
+	index = rebuilt
+	indexRebuilding = false
+	indexMutex.Unlock()
+}
+
+// buildIndex sorts all anime into their seasons.
+func buildIndex() *seasonIndex {
+	built := &seasonIndex{
+		seasons: map[string]*seasonData{},
+		created: time.Now(),
+	}
+
+	for anime := range arn.StreamAnime() {
+		if anime.IsDraft {
+			continue
+		}
+
+		season := anime.Season()
+
+		if season == "" {
+			continue
+		}
+
+		seasonKey := key(anime.StartDateTime().Year(), season)
+		data, exists := built.seasons[seasonKey]
+
+		if !exists {
+			data = &seasonData{}
+			built.seasons[seasonKey] = data
+		}
+
+		data.anime = append(data.anime, anime)
+	}
+
+	for _, data := range built.seasons {
+		data.genres, data.studios = filterChoices(data.anime)
+	}
+
+	return built
+}
+
+// key returns the index key of a season, e.g. "2024-winter".
+func key(year int, season string) string {
+	return strconv.Itoa(year) + "-" + season
+}
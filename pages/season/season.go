@@ -0,0 +1,203 @@
+package season
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/aerogo/aero"
+	"github.com/animenotifier/notify.moe/arn"
+	"github.com/animenotifier/notify.moe/components"
+)
+
+// Sort orders
+const (
+	SortPopularity = "popularity"
+	SortRating     = "rating"
+)
+
+// Seasons lists the seasons in the order they occur within a year.
+var Seasons = []string{"winter", "spring", "summer", "autumn"}
+
+// FilterOptions defines which anime of a season are shown.
+type FilterOptions struct {
+	Genre  string
+	Studio arn.CompanyID
+	Type   string
+}
+
+// Get renders all anime airing in the given season.
+func Get(ctx aero.Context) error {
+	user := arn.GetUserFromContext(ctx)
+	year, err := strconv.Atoi(ctx.Get("year"))
+
+	if err != nil || year < 1900 {
+		return ctx.Error(http.StatusBadRequest, "Invalid year")
+	}
+
+	season := ctx.Get("season")
+
+	if seasonPosition(season) == -1 {
+		return ctx.Error(http.StatusBadRequest, "Invalid season")
+	}
+
+	options := FilterOptions{
+		Genre:  ctx.Query("genre"),
+		Studio: ctx.Query("studio"),
+		Type:   ctx.Query("type"),
+	}
+
+	sortBy := ctx.Query("sort")
+
+	if sortBy != SortRating {
+		sortBy = SortPopularity
+	}
+
+	data := cached(year, season)
+	animes := Filter(data.anime, options)
+	Sort(animes, sortBy)
+
+	previousYear, previousSeason := Previous(year, season)
+	nextYear, nextSeason := Next(year, season)
+
+	return ctx.HTML(components.Season(
+		year,
+		season,
+		animes,
+		data.genres,
+		data.studios,
+		options.Genre,
+		options.Studio,
+		options.Type,
+		sortBy,
+		Link(previousYear, previousSeason),
+		Link(nextYear, nextSeason),
+		user,
+	))
+}
+
+// Filter returns the anime matching the filter options.
+func Filter(animes []*arn.Anime, options FilterOptions) []*arn.Anime {
+	results := make([]*arn.Anime, 0, len(animes))
+
+	for _, anime := range animes {
+		if options.Type != "" && anime.Type != options.Type {
+			continue
+		}
+
+		if options.Genre != "" && !hasGenre(anime, options.Genre) {
+			continue
+		}
+
+		if options.Studio != "" && !hasStudio(anime, options.Studio) {
+			continue
+		}
+
+		results = append(results, anime)
+	}
+
+	return results
+}
+
+// Sort sorts the anime by popularity or average rating.
+func Sort(animes []*arn.Anime, sortBy string) {
+	switch sortBy {
+	case SortRating:
+		arn.SortAnimeByRating(animes)
+	default:
+		arn.SortAnimeByPopularity(animes)
+	}
+}
+
+// Previous returns the season before the given season.
+func Previous(year int, season string) (int, string) {
+	index := seasonPosition(season) - 1
+
+	if index < 0 {
+		return year - 1, Seasons[len(Seasons)-1]
+	}
+
+	return year, Seasons[index]
+}
+
+// Next returns the season after the given season.
+func Next(year int, season string) (int, string) {
+	index := seasonPosition(season) + 1
+
+	if index >= len(Seasons) {
+		return year + 1, Seasons[0]
+	}
+
+	return year, Seasons[index]
+}
+
+// Link returns the link to the season page.
+func Link(year int, season string) string {
+	return "/anime/season/" + strconv.Itoa(year) + "/" + season
+}
+
+// filterChoices returns the genres and studios that appear in the given anime.
+func filterChoices(animes []*arn.Anime) ([]string, []*arn.Company) {
+	genres := []string{}
+	studios := []*arn.Company{}
+	genreFound := map[string]bool{}
+	studioFound := map[arn.CompanyID]bool{}
+
+	for _, anime := range animes {
+		for _, genre := range anime.Genres {
+			if !genreFound[genre] {
+				genreFound[genre] = true
+				genres = append(genres, genre)
+			}
+		}
+
+		for _, studio := range anime.Studios() {
+			if !studioFound[studio.ID] {
+				studioFound[studio.ID] = true
+				studios = append(studios, studio)
+			}
+		}
+	}
+
+	sort.Strings(genres)
+
+	sort.Slice(studios, func(i, j int) bool {
+		return studios[i].Name.English < studios[j].Name.English
+	})
+
+	return genres, studios
+}
+
+// hasGenre tells whether the anime has the given genre, ignoring the case.
+func hasGenre(anime *arn.Anime, genre string) bool {
+	for _, animeGenre := range anime.Genres {
+		if strings.EqualFold(animeGenre, genre) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hasStudio tells whether the anime was produced by the given studio.
+func hasStudio(anime *arn.Anime, studioID arn.CompanyID) bool {
+	for _, id := range anime.StudioIDs {
+		if id == studioID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// seasonPosition returns the position of the season within a year or -1 if it's not a valid season.
+func seasonPosition(season string) int {
+	for index, name := range Seasons {
+		if name == season {
+			return index
+		}
+	}
+
+	return -1
+}
@@ -0,0 +1,33 @@
+package settings
+
+import (
+	"net/http"
+	"sort"
+
+	"github.com/aerogo/aero"
+	"github.com/animenotifier/notify.moe/arn"
+	"github.com/animenotifier/notify.moe/components"
+)
+
+// Apps lists the apps the user registered and the apps the user authorized.
+func Apps(ctx aero.Context) error {
+	user := arn.GetUserFromContext(ctx)
+
+	if user == nil {
+		return ctx.Error(http.StatusUnauthorized, "Not logged in")
+	}
+
+	clients := user.OAuthClients()
+
+	sort.Slice(clients, func(i, j int) bool {
+		return clients[i].Created < clients[j].Created
+	})
+
+	authorizations := user.OAuthAuthorizations()
+
+	sort.Slice(authorizations, func(i, j int) bool {
+		return authorizations[i].Client.Name < authorizations[j].Client.Name
+	})
+
+	return ctx.HTML(components.SettingsApps(clients, authorizations, user))
+}
@@ -0,0 +1,23 @@
+package settings
+
+import (
+	"net/http"
+
+	"github.com/aerogo/aero"
+	"github.com/animenotifier/notify.moe/arn"
+	"github.com/animenotifier/notify.moe/components"
+)
+
+// Blocks lists the users the user has blocked.
+func Blocks(ctx aero.Context) error {
+	user := arn.GetUserFromContext(ctx)
+
+	if user == nil {
+		return ctx.Error(http.StatusUnauthorized, "Not logged in")
+	}
+
+	blocked := user.Blocks()
+	arn.SortUsersLastSeenFirst(blocked)
+
+	return ctx.HTML(components.SettingsBlocks(blocked, user))
+}
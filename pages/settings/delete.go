@@ -0,0 +1,24 @@
+package settings
+
+import (
+	"net/http"
+
+	"github.com/aerogo/aero"
+	"github.com/animenotifier/notify.moe/arn"
+)
+
+// DeleteAccount deactivates the account of the user and logs them out.
+// The data is deleted after the grace period unless the user logs in again.
+func DeleteAccount(ctx aero.Context) error {
+	user := arn.GetUserFromContext(ctx)
+
+	if user == nil {
+		return ctx.Error(http.StatusUnauthorized, "Not logged in")
+	}
+
+	user.Deactivate()
+	user.Save()
+
+	ctx.Session().Delete("userId")
+	return ctx.String("ok")
+}
@@ -0,0 +1,30 @@
+package settings
+
+import (
+	"net/http"
+
+	"github.com/aerogo/aero"
+	"github.com/animenotifier/notify.moe/arn"
+	"github.com/animenotifier/notify.moe/components"
+)
+
+// Subscriptions lists the threads the user receives notifications about.
+func Subscriptions(ctx aero.Context) error {
+	user := arn.GetUserFromContext(ctx)
+
+	if user == nil {
+		return ctx.Error(http.StatusUnauthorized, "Not logged in")
+	}
+
+	threads := []*arn.Thread{}
+
+	for thread := range arn.StreamThreads() {
+		if thread.IsSubscribed(user.ID) {
+			threads = append(threads, thread)
+		}
+	}
+
+	arn.SortThreadsLatestFirst(threads)
+
+	return ctx.HTML(components.SettingsSubscriptions(threads, user))
+}
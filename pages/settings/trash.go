@@ -0,0 +1,26 @@
+package settings
+
+import (
+	"net/http"
+
+	"github.com/aerogo/aero"
+	"github.com/animenotifier/notify.moe/arn"
+	"github.com/animenotifier/notify.moe/components"
+)
+
+// Trash lists the anime list entries the user removed recently.
+func Trash(ctx aero.Context) error {
+	user := arn.GetUserFromContext(ctx)
+
+	if user == nil {
+		return ctx.Error(http.StatusUnauthorized, "Not logged in")
+	}
+
+	animeList := user.AnimeList()
+
+	if animeList == nil {
+		return ctx.Error(http.StatusNotFound, "Anime list not found")
+	}
+
+	return ctx.HTML(components.SettingsTrash(animeList.TrashItems(), user))
+}
@@ -0,0 +1,47 @@
+package settings
+
+import (
+	"net/http"
+
+	"github.com/aerogo/aero"
+	"github.com/animenotifier/notify.moe/arn"
+	"github.com/animenotifier/notify.moe/components"
+)
+
+// Unsubscribe asks for a confirmation before the email digest is disabled
+// because email scanners open the links in emails.
+func Unsubscribe(ctx aero.Context) error {
+	user, err := arn.GetUser(ctx.Get("id"))
+
+	if err != nil {
+		return ctx.Error(http.StatusNotFound, "User not found", err)
+	}
+
+	if !user.VerifyUnsubscribeToken(ctx.Get("token")) {
+		return ctx.Error(http.StatusForbidden, "Invalid unsubscribe token")
+	}
+
+	if user.Settings().Notification.EmailDigest == arn.EmailDigestNever {
+		return ctx.HTML(components.EmailUnsubscribed())
+	}
+
+	return ctx.HTML(components.EmailUnsubscribe("/email/unsubscribe/" + user.ID + "/" + ctx.Get("token")))
+}
+
+// UnsubscribeConfirm disables the email digest using the token from the email.
+// Mail clients supporting one-click unsubscribe send this request directly.
+func UnsubscribeConfirm(ctx aero.Context) error {
+	user, err := arn.GetUser(ctx.Get("id"))
+
+	if err != nil {
+		return ctx.Error(http.StatusNotFound, "User not found", err)
+	}
+
+	err = user.UnsubscribeEmailDigest(ctx.Get("token"))
+
+	if err != nil {
+		return ctx.Error(http.StatusForbidden, err)
+	}
+
+	return nil
+}
@@ -5,18 +5,12 @@ import (
 
 	"github.com/animenotifier/notify.moe/arn"
 	"github.com/animenotifier/notify.moe/assets"
+	"github.com/animenotifier/notify.moe/utils/opengraph"
 )
 
 func getOpenGraph(track *arn.SoundTrack) *arn.OpenGraph {
-	openGraph := &arn.OpenGraph{
-		Tags: map[string]string{
-			"og:title":     track.Title.ByUser(nil),
-			"og:url":       "https://" + assets.Domain + track.Link(),
-			"og:site_name": assets.Domain,
-			"og:type":      "music.song",
-		},
-	}
-
+	description := ""
+	image := ""
 	descriptionTags := []string{}
 
 	for _, tag := range track.Tags {
@@ -27,22 +21,30 @@ func getOpenGraph(track *arn.SoundTrack) *arn.OpenGraph {
 		descriptionTags = append(descriptionTags, tag)
 	}
 
-	if track.MainAnime() != nil {
-		openGraph.Tags["og:image"] = track.MainAnime().ImageLink("large")
-		openGraph.Tags["og:description"] = track.MainAnime().Title.Canonical + " (" + strings.Join(descriptionTags, ", ") + ")"
+	mainAnime := track.MainAnime()
+
+	if mainAnime != nil {
+		image = mainAnime.ImageLink("large")
+		description = mainAnime.Title.Canonical + " (" + strings.Join(descriptionTags, ", ") + ")"
 	}
 
+	builder := opengraph.New(track.Title.ByUser(nil), description).
+		URL(track.Link()).
+		Image(image).
+		Type("music.song")
+
 	if track.File != "" {
-		openGraph.Tags["og:audio"] = "https://" + assets.Domain + "/audio/" + track.File
-		openGraph.Tags["og:audio:type"] = "audio/vnd.facebook.bridge"
+		builder.
+			Tag("og:audio", "https://"+assets.Domain+"/audio/"+track.File).
+			Tag("og:audio:type", "audio/vnd.facebook.bridge")
 	}
 
 	// Set video so that it can be played
 	youtube := track.MediaByService("Youtube")
 
 	if len(youtube) > 0 {
-		openGraph.Tags["og:video"] = "https://www.youtube.com/v/" + youtube[0].ServiceID
+		builder.Tag("og:video", "https://www.youtube.com/v/"+youtube[0].ServiceID)
 	}
 
-	return openGraph
+	return builder.Build()
 }
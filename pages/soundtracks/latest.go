@@ -14,5 +14,5 @@ func Latest(ctx aero.Context) error {
 	arn.SortSoundTracksLatestFirst(tracks)
 
 	// Render
-	return render(ctx, tracks)
+	return renderAll(ctx, tracks)
 }
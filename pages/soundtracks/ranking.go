@@ -0,0 +1,86 @@
+package soundtracks
+
+import (
+	"sync"
+	"time"
+
+	"github.com/animenotifier/notify.moe/arn"
+)
+
+// rankingRefreshInterval defines how often the ranking is recomputed.
+const rankingRefreshInterval = 10 * time.Minute
+
+// QueryResult is the requested page of soundtracks.
+type QueryResult struct {
+	Tracks []*arn.SoundTrack
+
+	// Total is the number of soundtracks before pagination.
+	Total int
+}
+
+var (
+	bestTracks           []*arn.SoundTrack
+	bestTracksCreated    time.Time
+	bestTracksRebuilding bool
+	bestTracksMutex      sync.Mutex
+)
+
+// Ranking returns the requested page of the most liked soundtracks.
+func Ranking(index int, limit int) *QueryResult {
+	return paginate(rankedTracks(), index, limit)
+}
+
+// rankedTracks returns the precomputed ranking of all soundtracks.
+// An outdated ranking is recomputed in the background and served until the new one is ready.
+func rankedTracks() []*arn.SoundTrack {
+	bestTracksMutex.Lock()
+	defer bestTracksMutex.Unlock()
+
+	if bestTracks == nil {
+		bestTracks = buildRanking()
+		bestTracksCreated = time.Now()
+	} else if time.Since(bestTracksCreated) > rankingRefreshInterval && !bestTracksRebuilding {
+		bestTracksRebuilding = true
+		go rebuildRanking()
+	}
+
+	return bestTracks
+}
+
+// rebuildRanking replaces the ranking with a new one.
+func rebuildRanking() {
+	tracks := buildRanking()
+
+	bestTracksMutex.Lock()
+	bestTracks = tracks
+	bestTracksCreated = time.Now()
+	bestTracksRebuilding = false
+	bestTracksMutex.Unlock()
+}
+
+// buildRanking returns all soundtracks sorted by popularity.
+func buildRanking() []*arn.SoundTrack {
+	tracks := fetchAll()
+	arn.SortSoundTracksPopularFirst(tracks)
+	return tracks
+}
+
+// paginate returns the part of the tracks starting at index with at most limit tracks.
+func paginate(tracks []*arn.SoundTrack, index int, limit int) *QueryResult {
+	total := len(tracks)
+
+	if index >= total {
+		return &QueryResult{Total: total}
+	}
+
+	tracks = tracks[index:]
+
+	if limit > 0 && len(tracks) > limit {
+		tracks = tracks[:limit]
+	}
+
+	return &QueryResult{
+		Tracks: tracks,
+		Total:  total,
+	}
+}
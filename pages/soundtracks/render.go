@@ -12,32 +12,36 @@ const (
 	tracksPerScroll = 9
 )
 
-// render renders the soundracks page with the given tracks.
-func render(ctx aero.Context, allTracks []*arn.SoundTrack) error {
+// render renders the soundracks page with the page of tracks returned by fetch.
+func render(ctx aero.Context, fetch func(index int, limit int) *QueryResult) error {
 	user := arn.GetUserFromContext(ctx)
 	index, _ := ctx.GetInt("index")
 	tag := ctx.Get("tag")
 
-	// Slice the part that we need
-	tracks := allTracks[index:]
+	// Query the part that we need
 	maxLength := tracksFirstLoad
 
 	if index > 0 {
 		maxLength = tracksPerScroll
 	}
 
-	if len(tracks) > maxLength {
-		tracks = tracks[:maxLength]
-	}
+	result := fetch(index, maxLength)
 
 	// Next index
-	nextIndex := infinitescroll.NextIndex(ctx, len(allTracks), maxLength, index)
+	nextIndex := infinitescroll.NextIndex(ctx, result.Total, maxLength, index)
 
 	// In case we're scrolling, send soundtracks only (without the page frame)
 	if index > 0 {
-		return ctx.HTML(components.SoundTracksScrollable(tracks, user))
+		return ctx.HTML(components.SoundTracksScrollable(result.Tracks, user))
 	}
 
 	// Otherwise, send the full page
-	return ctx.HTML(components.SoundTracks(tracks, nextIndex, tag, user))
+	return ctx.HTML(components.SoundTracks(result.Tracks, nextIndex, tag, user))
+}
+
+// renderAll renders a page of the given tracks.
+func renderAll(ctx aero.Context, tracks []*arn.SoundTrack) error {
+	return render(ctx, func(index int, limit int) *QueryResult {
+		return paginate(tracks, index, limit)
+	})
 }
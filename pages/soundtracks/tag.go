@@ -18,5 +18,5 @@ func FilterByTag(ctx aero.Context) error {
 	arn.SortSoundTracksPopularFirst(tracks)
 
 	// Render
-	return render(ctx, tracks)
+	return renderAll(ctx, tracks)
 }
@@ -28,28 +28,46 @@ func Events(ctx aero.Context) error {
 	go func() {
 		defer user.RemoveEventStream(stream)
 
-		// Send the ETag for the scripts
-		stream.Events <- &aero.Event{
-			Name: "etag",
-			Data: struct {
-				URL  string `json:"url"`
-				ETag string `json:"etag"`
-			}{
-				URL:  "/scripts",
-				ETag: scriptsETag,
+		// Send the ETags for the scripts and styles and the current counters
+		// because the client might have missed updates while it was disconnected
+		initialEvents := []*aero.Event{
+			{
+				Name: "etag",
+				Data: struct {
+					URL  string `json:"url"`
+					ETag string `json:"etag"`
+				}{
+					URL:  "/scripts",
+					ETag: scriptsETag,
+				},
+			},
+			{
+				Name: "etag",
+				Data: struct {
+					URL  string `json:"url"`
+					ETag string `json:"etag"`
+				}{
+					URL:  "/styles",
+					ETag: stylesETag,
+				},
+			},
+			{
+				Name: "notificationCount",
+				Data: user.Notifications().CountUnseen(),
+			},
+			{
+				Name: "messageCount",
+				Data: arn.CountUnreadConversations(user.ID),
 			},
 		}
 
-		// Send the ETag for the styles
-		stream.Events <- &aero.Event{
-			Name: "etag",
-			Data: struct {
-				URL  string `json:"url"`
-				ETag string `json:"etag"`
-			}{
-				URL:  "/styles",
-				ETag: stylesETag,
-			},
+		for _, event := range initialEvents {
+			// Stop if the user closed the tab before the events could be sent
+			select {
+			case stream.Events <- event:
+			case <-stream.Closed:
+				return
+			}
 		}
 
 		// Wait until the user closes the tab or disconnects
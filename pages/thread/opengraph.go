@@ -4,18 +4,43 @@ import (
 	"github.com/animenotifier/notify.moe/arn"
 	"github.com/animenotifier/notify.moe/assets"
 	"github.com/animenotifier/notify.moe/utils"
+	"github.com/animenotifier/notify.moe/utils/opengraph"
 )
 
-func getOpenGraph(thread *arn.Thread) *arn.OpenGraph {
-	openGraph := &arn.OpenGraph{
-		Tags: map[string]string{
-			"og:title":       thread.Title,
-			"og:description": utils.CutLongDescription(thread.Text),
-			"og:url":         "https://" + assets.Domain + thread.Link(),
-			"og:site_name":   assets.Domain,
-			"og:type":        "article",
-		},
+// getOpenGraph always describes the thread with its first post, regardless of the page.
+func getOpenGraph(thread *arn.Thread, page int) *arn.OpenGraph {
+	description := utils.CutLongDescription(utils.RemoveFootnotes(thread.Text))
+
+	return opengraph.New(thread.Title, description).
+		URL(thread.PageLink(page)).
+		Type("article").
+		StructuredData(getStructuredData(thread, description)).
+		Build()
+}
+
+// getStructuredData describes the thread as a schema.org forum posting.
+func getStructuredData(thread *arn.Thread, description string) arn.StructuredData {
+	data := arn.NewStructuredData("DiscussionForumPosting")
+	data["headline"] = thread.Title
+	data["text"] = description
+	data["url"] = "https://" + assets.Domain + thread.Link()
+	data["datePublished"] = thread.Created
+	data["commentCount"] = len(thread.PostIDs)
+	data["interactionStatistic"] = arn.StructuredData{
+		"@type":                "InteractionCounter",
+		"interactionType":      "https://schema.org/LikeAction",
+		"userInteractionCount": thread.CountLikes(),
+	}
+
+	if thread.Edited != "" {
+		data["dateModified"] = thread.Edited
+	}
+
+	creator := thread.Creator()
+
+	if creator != nil {
+		data["author"] = arn.NewStructuredPerson(creator)
 	}
 
-	return openGraph
+	return data
 }
@@ -0,0 +1,47 @@
+package thread
+
+import (
+	"net/http"
+
+	"github.com/aerogo/aero"
+	"github.com/animenotifier/notify.moe/arn"
+)
+
+// Save edits the thread unless the edit is based on an outdated revision.
+// Conflicting edits receive the current thread so the editor can merge the changes.
+func Save(ctx aero.Context) error {
+	id := ctx.Get("id")
+	thread, err := arn.GetThread(id)
+
+	if err != nil {
+		return ctx.Error(http.StatusNotFound, "Thread not found", err)
+	}
+
+	err = thread.Authorize(ctx, "edit")
+
+	if err != nil {
+		return ctx.Error(http.StatusForbidden, "Not authorized", err)
+	}
+
+	edits, err := ctx.Request().Body().JSONObject()
+
+	if err != nil {
+		return ctx.Error(http.StatusBadRequest, "Invalid data format (expected JSON)", err)
+	}
+
+	revision, _ := edits["Revision"].(float64)
+	delete(edits, "Revision")
+
+	err = thread.EditRevision(ctx, edits, int(revision))
+
+	if err == arn.ErrRevisionConflict {
+		ctx.SetStatus(http.StatusConflict)
+		return ctx.JSON(thread)
+	}
+
+	if err != nil {
+		return ctx.Error(http.StatusInternalServerError, "Thread could not be edited", err)
+	}
+
+	return ctx.String("ok")
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/animenotifier/notify.moe/arn"
 	"github.com/animenotifier/notify.moe/components"
 	"github.com/animenotifier/notify.moe/server/middleware"
+	"github.com/animenotifier/notify.moe/utils"
 )
 
 // Get thread.
@@ -21,7 +22,48 @@ func Get(ctx aero.Context) error {
 		return ctx.Error(http.StatusNotFound, "Thread not found", err)
 	}
 
+	// Removed threads are only visible to moderators
+	if thread.IsSoftDeleted() && (user == nil || !user.IsModerator()) {
+		return ctx.Error(http.StatusNotFound, "Thread not found")
+	}
+
+	// Page number
+	page := 1
+	pageCount := thread.PageCount()
+
+	if ctx.Get("page") != "" {
+		page, err = ctx.GetInt("page")
+
+		if err != nil || page < 1 || page > pageCount {
+			return ctx.Error(http.StatusNotFound, "Page not found", err)
+		}
+	}
+
+	// Viewers are informed about new posts
+	if user != nil {
+		thread.AddViewer(user.ID)
+	}
+
 	customCtx := ctx.(*middleware.OpenGraphContext)
-	customCtx.OpenGraph = getOpenGraph(thread)
-	return ctx.HTML(components.Thread(thread, user))
+	customCtx.OpenGraph = getOpenGraph(thread, page)
+	return ctx.HTML(components.Thread(thread, thread.PostsOnPage(page), page, pageCount, user))
+}
+
+// Permalink redirects to the page and anchor of a reply.
+func Permalink(ctx aero.Context) error {
+	id := ctx.Get("id")
+	postID := ctx.Get("post")
+	thread, err := arn.GetThread(id)
+
+	if err != nil {
+		return ctx.Error(http.StatusNotFound, "Thread not found", err)
+	}
+
+	page := thread.PostPage(postID)
+
+	if page == 0 {
+		return ctx.Error(http.StatusNotFound, "Post not found in this thread")
+	}
+
+	return utils.SmartRedirect(ctx, thread.PageLink(page)+"#post-"+postID)
 }
@@ -23,18 +23,18 @@ func AnimeImage(ctx aero.Context) error {
 		return ctx.Error(http.StatusNotFound, "Anime not found", err)
 	}
 
-	// Retrieve file from post body
-	data, err := ctx.Request().Body().Bytes()
+	// Retrieve and validate the image from post body
+	metaImage, err := readImage(ctx)
 
 	if err != nil {
-		return ctx.Error(http.StatusInternalServerError, "Reading request body failed", err)
+		return err
 	}
 
 	// Set anime image file
-	err = anime.SetImageBytes(data)
+	err = anime.SetImage(metaImage)
 
 	if err != nil {
-		return ctx.Error(http.StatusInternalServerError, "Invalid image format", err)
+		return ctx.Error(http.StatusInternalServerError, "Saving the image failed", err)
 	}
 
 	// Save image information
@@ -22,18 +22,18 @@ func GroupImage(ctx aero.Context) error {
 		return ctx.Error(http.StatusNotFound, "Group not found", err)
 	}
 
-	// Retrieve file from post body
-	data, err := ctx.Request().Body().Bytes()
+	// Retrieve and validate the image from post body
+	metaImage, err := readImage(ctx)
 
 	if err != nil {
-		return ctx.Error(http.StatusInternalServerError, "Reading request body failed", err)
+		return err
 	}
 
 	// Set group image file
-	err = group.SetImageBytes(data)
+	err = group.SetImage(metaImage)
 
 	if err != nil {
-		return ctx.Error(http.StatusInternalServerError, "Invalid image format", err)
+		return ctx.Error(http.StatusInternalServerError, "Saving the image failed", err)
 	}
 
 	// Save image information
@@ -0,0 +1,37 @@
+package upload
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/aerogo/aero"
+	"github.com/akyoto/imageserver"
+	"github.com/animenotifier/notify.moe/arn/imageupload"
+)
+
+// readImage reads the uploaded image from the request body and validates it.
+// Files exceeding the maximum file size are rejected without reading the whole body.
+func readImage(ctx aero.Context) (*imageserver.MetaImage, error) {
+	reader := ctx.Request().Body().Reader()
+	defer reader.Close()
+
+	data, err := ioutil.ReadAll(io.LimitReader(reader, imageupload.MaxFileSize+1))
+
+	if err != nil {
+		return nil, ctx.Error(http.StatusInternalServerError, "Reading request body failed", err)
+	}
+
+	metaImage, err := imageupload.Decode(data)
+
+	switch {
+	case err == nil:
+		return metaImage, nil
+	case err == imageupload.ErrTooLarge:
+		return nil, ctx.Error(http.StatusRequestEntityTooLarge, err)
+	case imageupload.IsUserError(err):
+		return nil, ctx.Error(http.StatusBadRequest, err)
+	default:
+		return nil, ctx.Error(http.StatusInternalServerError, "Processing the image failed", err)
+	}
+}
@@ -19,18 +19,18 @@ func UserCover(ctx aero.Context) error {
 		return ctx.Error(http.StatusUnauthorized, "Only available for PRO users")
 	}
 
-	// Retrieve file from post body
-	data, err := ctx.Request().Body().Bytes()
+	// Retrieve and validate the image from post body
+	metaImage, err := readImage(ctx)
 
 	if err != nil {
-		return ctx.Error(http.StatusInternalServerError, "Reading request body failed", err)
+		return err
 	}
 
 	// Set cover image file
-	err = user.SetCoverBytes(data)
+	err = user.SetCover(metaImage)
 
 	if err != nil {
-		return ctx.Error(http.StatusInternalServerError, "Invalid image format", err)
+		return ctx.Error(http.StatusInternalServerError, "Saving the image failed", err)
 	}
 
 	// Save cover image information
@@ -15,18 +15,18 @@ func UserImage(ctx aero.Context) error {
 		return ctx.Error(http.StatusUnauthorized, "Not logged in")
 	}
 
-	// Retrieve file from post body
-	data, err := ctx.Request().Body().Bytes()
+	// Retrieve and validate the image from post body
+	metaImage, err := readImage(ctx)
 
 	if err != nil {
-		return ctx.Error(http.StatusInternalServerError, "Reading request body failed", err)
+		return err
 	}
 
 	// Set avatar file
-	err = user.SetImageBytes(data)
+	err = user.SetAvatar(metaImage)
 
 	if err != nil {
-		return ctx.Error(http.StatusInternalServerError, "Invalid image format", err)
+		return ctx.Error(http.StatusInternalServerError, "Saving the image failed", err)
 	}
 
 	// Save avatar information
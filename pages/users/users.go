@@ -23,7 +23,7 @@ func Active(ctx aero.Context) error {
 // Pro ...
 func Pro(ctx aero.Context) error {
 	users := arn.FilterUsers(func(user *arn.User) bool {
-		return user.IsPro()
+		return user.IsPro() && !user.IsHidden()
 	})
 
 	sort.Slice(users, func(i, j int) bool {
@@ -47,7 +47,7 @@ func Editors(ctx aero.Context) error {
 
 		current, exists := score[entry.UserID]
 
-		if !exists {
+		if !exists && !entry.User().IsHidden() {
 			users = append(users, entry.User())
 		}
 
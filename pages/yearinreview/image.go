@@ -0,0 +1,120 @@
+package yearinreview
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"net/http"
+	"path"
+
+	"github.com/aerogo/aero"
+	"github.com/animenotifier/notify.moe/arn"
+	"github.com/animenotifier/notify.moe/server/middleware"
+	"github.com/animenotifier/notify.moe/utils/ogimage"
+)
+
+const (
+	imageQuality = 85
+	maxCovers    = 5
+	coverWidth   = 180
+	coverHeight  = 255
+	coverGap     = 30
+	coverTop     = 150
+	titleScale   = 6
+	textScale    = 4
+)
+
+// Image renders the picture that is shown when the review is shared.
+func Image(ctx aero.Context) error {
+	review, viewUser, err := getReview(ctx)
+
+	if err != nil {
+		return ctx.Error(http.StatusNotFound, "Year in review not found", err)
+	}
+
+	// Reviews only change when they are generated again
+	key := fmt.Sprintf("yearinreview:%s:%s:%s", review.ID, review.Created, viewUser.Nick)
+
+	data, err := ogimage.Cached(key, func() ([]byte, error) {
+		buffer := bytes.Buffer{}
+		err := jpeg.Encode(&buffer, renderImage(review, viewUser), &jpeg.Options{Quality: imageQuality})
+		return buffer.Bytes(), err
+	})
+
+	if err != nil {
+		return ctx.Error(http.StatusInternalServerError, "Could not render the image", err)
+	}
+
+	ctx.Response().SetHeader("Content-Type", "image/jpeg")
+	middleware.SetCacheControl(ctx, "public, max-age=86400")
+	return ctx.Bytes(data)
+}
+
+// renderImage draws the title, the covers of the most important anime of the year and the numbers.
+func renderImage(review *arn.YearInReview, viewUser *arn.User) *image.RGBA {
+	canvas := ogimage.NewCanvas()
+
+	title := fmt.Sprintf("%s's %d in anime", viewUser.Nick, review.Year)
+	drawCentered(canvas, title, coverTop/2-ogimage.LineHeight(titleScale)/2, titleScale, ogimage.TitleColor)
+
+	covers := loadCovers(coverAnimeIDs(review))
+	width := len(covers)*coverWidth + (len(covers)-1)*coverGap
+	x := (ogimage.Width - width) / 2
+
+	for _, cover := range covers {
+		ogimage.DrawCover(canvas, image.Rect(x, coverTop, x+coverWidth, coverTop+coverHeight), cover)
+		x += coverWidth + coverGap
+	}
+
+	numbers := fmt.Sprintf("%d anime | %d episodes | %d hours", review.AnimeWatched, review.EpisodesWatched, review.HoursWatched())
+	drawCentered(canvas, numbers, coverTop+coverHeight+ogimage.LineHeight(textScale), textScale, ogimage.TextColor)
+
+	ogimage.DrawBrand(canvas)
+	return canvas
+}
+
+// drawCentered draws a line of text in the horizontal center of the image.
+func drawCentered(canvas *image.RGBA, text string, y int, scale int, textColor color.Color) {
+	x := (ogimage.Width - ogimage.TextWidth(text, scale)) / 2
+	ogimage.DrawText(canvas, text, image.Pt(x, y), scale, textColor)
+}
+
+// coverAnimeIDs returns the anime shown on the image, the highest rated ones first.
+func coverAnimeIDs(review *arn.YearInReview) []arn.AnimeID {
+	animeIDs := []arn.AnimeID{}
+	candidates := append([]arn.AnimeID{}, review.HighestRated...)
+	candidates = append(candidates, review.MostRewatched...)
+
+	if review.FirstCompleted != nil {
+		candidates = append(candidates, review.FirstCompleted.AnimeID, review.LastCompleted.AnimeID)
+	}
+
+	for _, animeID := range candidates {
+		if len(animeIDs) == maxCovers {
+			break
+		}
+
+		if !arn.Contains(animeIDs, animeID) {
+			animeIDs = append(animeIDs, animeID)
+		}
+	}
+
+	return animeIDs
+}
+
+// loadCovers returns the large anime images that exist on disk.
+func loadCovers(animeIDs []arn.AnimeID) []image.Image {
+	covers := []image.Image{}
+
+	for _, animeID := range animeIDs {
+		cover := ogimage.LoadImage(path.Join(arn.Root, "images", "anime", "large", animeID+".jpg"))
+
+		if cover != nil {
+			covers = append(covers, cover)
+		}
+	}
+
+	return covers
+}
@@ -0,0 +1,21 @@
+package yearinreview
+
+import (
+	"fmt"
+
+	"github.com/animenotifier/notify.moe/arn"
+	"github.com/animenotifier/notify.moe/utils/ogimage"
+	"github.com/animenotifier/notify.moe/utils/opengraph"
+)
+
+func getOpenGraph(review *arn.YearInReview, viewUser *arn.User) *arn.OpenGraph {
+	title := fmt.Sprintf("%s's %d in anime", viewUser.Nick, review.Year)
+	description := fmt.Sprintf("%d anime, %d episodes and %d hours of watching.", review.AnimeWatched, review.EpisodesWatched, review.HoursWatched())
+
+	openGraph := opengraph.New(title, description).
+		URL(review.Link()).
+		Build()
+
+	ogimage.SetImage(openGraph, review.Link()+"/image.jpg")
+	return openGraph
+}
@@ -0,0 +1,47 @@
+package yearinreview
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/aerogo/aero"
+	"github.com/animenotifier/notify.moe/arn"
+	"github.com/animenotifier/notify.moe/components"
+	"github.com/animenotifier/notify.moe/server/middleware"
+)
+
+// Get shows the summary of a user's anime year.
+func Get(ctx aero.Context) error {
+	review, viewUser, err := getReview(ctx)
+
+	if err != nil {
+		return ctx.Error(http.StatusNotFound, "Year in review not found", err)
+	}
+
+	customCtx := ctx.(*middleware.OpenGraphContext)
+	customCtx.OpenGraph = getOpenGraph(review, viewUser)
+	return ctx.HTML(components.YearInReview(review, viewUser, arn.GetUserFromContext(ctx)))
+}
+
+// getReview returns the review requested by the nick and year parameters.
+func getReview(ctx aero.Context) (*arn.YearInReview, *arn.User, error) {
+	viewUser, err := arn.GetVisibleUserByNick(ctx.Get("nick"))
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	year, err := strconv.Atoi(ctx.Get("year"))
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	review, err := arn.GetYearInReview(viewUser.ID, year)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return review, viewUser, nil
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"bufio"
+	"os"
+	"path"
+
+	"github.com/akyoto/color"
+	"github.com/animenotifier/notify.moe/arn"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// legacyGroup contains the fields that have been removed from the group data.
+type legacyGroup struct {
+	Restricted bool `json:"restricted"`
+}
+
+// Converts founders to owners, assigns the member role to everyone else
+// and turns restricted groups into groups that require a join request.
+func main() {
+	defer arn.Node.Close()
+
+	restricted, err := restrictedGroups()
+
+	if err != nil {
+		color.Red(err.Error())
+		return
+	}
+
+	for group := range arn.StreamGroups() {
+		for _, member := range group.Members {
+			switch {
+			case member.Role == "founder" || member.UserID == group.CreatedBy:
+				member.Role = arn.GroupRoleOwner
+			case member.Role == "":
+				member.Role = arn.GroupRoleMember
+			}
+		}
+
+		if restricted[group.ID] {
+			group.JoinMode = arn.GroupJoinModeRequest
+		}
+
+		group.Save()
+	}
+
+	color.Green("Updated the member roles of %d groups, %d of them now require join requests", arn.DB.Collection("Group").Count(), len(restricted))
+}
+
+// restrictedGroups reads the IDs of restricted groups from the database file
+// because the restricted flag is no longer part of the group type.
+func restrictedGroups() (map[string]bool, error) {
+	file, err := os.Open(path.Join(arn.Root, "db", "arn", "Group.dat"))
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer file.Close()
+	restricted := map[string]bool{}
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(nil, 64*1024*1024)
+	var id string
+
+	for lineCount := 0; scanner.Scan(); lineCount++ {
+		if lineCount%2 == 0 {
+			id = scanner.Text()
+			continue
+		}
+
+		group := legacyGroup{}
+		err := jsoniter.Unmarshal(scanner.Bytes(), &group)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if group.Restricted {
+			restricted[id] = true
+		}
+	}
+
+	return restricted, scanner.Err()
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"bufio"
+	"os"
+	"path"
+
+	"github.com/akyoto/color"
+	"github.com/animenotifier/notify.moe/arn"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// legacySettings contains the notification flags that have been replaced by the preference matrix.
+// Missing flags are nil, preferences are nil if the settings haven't been migrated yet.
+type legacySettings struct {
+	Notification struct {
+		NewFollowers         *bool               `json:"newFollowers"`
+		AnimeEpisodeReleases *bool               `json:"animeEpisodeReleases"`
+		Preferences          jsoniter.RawMessage `json:"preferences"`
+	} `json:"notification"`
+}
+
+// Converts the old notification flags into the preference matrix.
+// Settings that already have preferences are never touched,
+// so running the patch again keeps the channels the users chose.
+func main() {
+	defer arn.Node.Close()
+
+	legacy, err := legacyNotificationSettings()
+
+	if err != nil {
+		color.Red(err.Error())
+		return
+	}
+
+	count := 0
+
+	for userID, old := range legacy {
+		settings, err := arn.GetSettings(userID)
+
+		if err != nil {
+			continue
+		}
+
+		preferences := arn.DefaultNotificationPreferences()
+
+		if old.Notification.NewFollowers != nil && !*old.Notification.NewFollowers {
+			preferences.Follow = arn.NotificationChannels{}
+		}
+
+		if old.Notification.AnimeEpisodeReleases != nil && !*old.Notification.AnimeEpisodeReleases {
+			preferences.AnimeEpisode = arn.NotificationChannels{}
+		}
+
+		settings.Notification.Preferences = preferences
+		settings.Save()
+		count++
+	}
+
+	color.Green("Updated the notification preferences of %d users", count)
+}
+
+// legacyNotificationSettings reads the settings without preferences from the database file
+// because the old flags are no longer part of the settings type.
+func legacyNotificationSettings() (map[arn.UserID]*legacySettings, error) {
+	file, err := os.Open(path.Join(arn.Root, "db", "arn", "Settings.dat"))
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer file.Close()
+	legacy := map[arn.UserID]*legacySettings{}
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(nil, 64*1024*1024)
+	var id string
+
+	for lineCount := 0; scanner.Scan(); lineCount++ {
+		if lineCount%2 == 0 {
+			id = scanner.Text()
+			continue
+		}
+
+		settings := &legacySettings{}
+		err := jsoniter.Unmarshal(scanner.Bytes(), settings)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if settings.Notification.Preferences == nil {
+			legacy[id] = settings
+		}
+	}
+
+	return legacy, scanner.Err()
+}
@@ -13,7 +13,7 @@ func main() {
 		settings.Notification.SoundTrackLikes = defaultSettings.SoundTrackLikes
 		settings.Notification.GroupPostLikes = defaultSettings.GroupPostLikes
 		settings.Notification.ForumLikes = defaultSettings.ForumLikes
-		settings.Notification.NewFollowers = defaultSettings.NewFollowers
+		settings.Notification.Preferences.Follow = defaultSettings.Preferences.Follow
 		settings.Save()
 	}
 }
@@ -0,0 +1,47 @@
+import AnimeNotifier from "../AnimeNotifier"
+
+// New API key
+export async function newAPIKey(arn: AnimeNotifier) {
+	const name = prompt("What will you use the API key for?")
+
+	if(name === null) {
+		return
+	}
+
+	// Keys are read-only unless the user allows changes
+	const scopes = ["read-list", "read-notifications"]
+
+	if(confirm("Should the API key be allowed to add, edit and remove anime on your list?")) {
+		scopes.push("write-list")
+	}
+
+	try {
+		const response = await arn.post("/api/v1/keys/new", {name, scopes})
+
+		if(!response) {
+			throw "Failed creating the API key"
+		}
+
+		const key = await response.json()
+		await arn.reloadContent()
+		prompt("Copy the API key now, it won't be shown again:", key.key)
+		arn.statusMessage.showInfo("Created a new API key.")
+	} catch(err) {
+		arn.statusMessage.showError(err)
+	}
+}
+
+// Revoke API key
+export async function revokeAPIKey(arn: AnimeNotifier, button: HTMLButtonElement) {
+	if(!confirm("Apps using this API key will stop working. Do you really want to revoke it?")) {
+		return
+	}
+
+	try {
+		await arn.post("/api/v1/keys/revoke", {id: button.dataset.id})
+		await arn.reloadContent()
+		arn.statusMessage.showInfo("Revoked the API key.")
+	} catch(err) {
+		arn.statusMessage.showError(err)
+	}
+}
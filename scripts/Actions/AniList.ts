@@ -0,0 +1,23 @@
+import AnimeNotifier from "../AnimeNotifier"
+
+// Enable AniList sync
+export async function enableAniListSync(arn: AnimeNotifier) {
+	try {
+		await arn.post("/api/import/anilist/sync/enable")
+		await arn.app.load("/import")
+		arn.statusMessage.showInfo("Your anime list is now synchronized with AniList.")
+	} catch(err) {
+		arn.statusMessage.showError(err)
+	}
+}
+
+// Disable AniList sync
+export async function disableAniListSync(arn: AnimeNotifier) {
+	try {
+		await arn.post("/api/import/anilist/sync/disable")
+		await arn.reloadContent()
+		arn.statusMessage.showInfo("Stopped syncing with AniList.")
+	} catch(err) {
+		arn.statusMessage.showError(err)
+	}
+}
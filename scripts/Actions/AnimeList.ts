@@ -1,4 +1,5 @@
 import AnimeNotifier from "../AnimeNotifier"
+import findAll from "scripts/Utils/findAll"
 
 // Add anime to collection
 export async function addAnimeToCollection(arn: AnimeNotifier, button: HTMLButtonElement) {
@@ -46,6 +47,7 @@ export async function removeAnimeFromCollection(arn: AnimeNotifier, button: HTML
 	try {
 		await arn.post(apiEndpoint + "/remove/" + animeId)
 		await arn.app.load(`/+${nick}/animelist/` + status.value)
+		arn.statusMessage.showInfo("Moved to the trash, you can restore it in your settings.")
 	} catch(err) {
 		arn.statusMessage.showError(err)
 	}
@@ -68,7 +70,99 @@ export async function deleteAnimeList(arn: AnimeNotifier, button: HTMLElement) {
 	try {
 		await arn.post("/api/delete/animelist")
 		await arn.app.load(returnPath)
-		arn.statusMessage.showInfo("Your anime list has been deleted.")
+		arn.statusMessage.showInfo("Your anime list has been moved to the trash.")
+	} catch(err) {
+		arn.statusMessage.showError(err)
+	}
+}
+
+// Restore a removed anime list entry from the trash
+export async function restoreAnimeListItem(arn: AnimeNotifier, button: HTMLButtonElement) {
+	const {animeId} = button.dataset
+	const apiEndpoint = arn.findAPIEndpoint(button)
+
+	try {
+		await arn.post(`${apiEndpoint}/trash/restore/${animeId}`)
+		await arn.reloadContent()
+		arn.statusMessage.showInfo("Restored the anime to your list.")
+	} catch(err) {
+		arn.statusMessage.showError(err)
+	}
+}
+
+// Permanently delete a removed anime list entry
+export async function deleteAnimeListItemForever(arn: AnimeNotifier, button: HTMLButtonElement) {
+	if(!confirm("This can't be undone. Do you really want to delete it permanently?")) {
+		return
+	}
+
+	const {animeId} = button.dataset
+	const apiEndpoint = arn.findAPIEndpoint(button)
+
+	try {
+		await arn.post(`${apiEndpoint}/trash/delete/${animeId}`)
+		await arn.reloadContent()
+	} catch(err) {
+		arn.statusMessage.showError(err)
+	}
+}
+
+// Show or hide the checkboxes for editing many anime list entries at once
+export function toggleBulkEdit() {
+	const toolbar = document.getElementById("anime-list-bulk-edit") as HTMLElement
+	const isActive = toolbar.classList.toggle("hidden") === false
+
+	for(const container of findAll("anime-list-container")) {
+		container.classList.toggle("bulk-edit", isActive)
+	}
+}
+
+// Select all anime list entries that are currently loaded
+export function selectAllAnimeListItems() {
+	const checkboxes = [...findAll("anime-list-item-select")] as HTMLInputElement[]
+	const check = checkboxes.some(checkbox => !checkbox.checked)
+
+	for(const checkbox of checkboxes) {
+		checkbox.checked = check
+	}
+}
+
+// Apply the selected bulk edit action to all selected anime list entries
+export async function bulkEditAnimeList(arn: AnimeNotifier, button: HTMLButtonElement) {
+	const actionSelect = document.getElementById("anime-list-bulk-action") as HTMLSelectElement
+	const tagInput = document.getElementById("anime-list-bulk-tag") as HTMLInputElement
+	const separator = actionSelect.value.indexOf(":")
+	const action = actionSelect.value.substring(0, separator)
+	let value = actionSelect.value.substring(separator + 1)
+
+	if(action === "tag") {
+		value = tagInput.value
+	}
+
+	const animeIds = ([...findAll("anime-list-item-select")] as HTMLInputElement[])
+		.filter(checkbox => checkbox.checked)
+		.map(checkbox => checkbox.dataset.animeId)
+
+	if(animeIds.length === 0) {
+		arn.statusMessage.showError("Please select the anime you want to edit.")
+		return
+	}
+
+	if(action === "remove" && !confirm(`Are you sure you want to remove ${animeIds.length} anime from your collection?`)) {
+		return
+	}
+
+	const apiEndpoint = arn.findAPIEndpoint(button)
+
+	try {
+		await arn.post(apiEndpoint + "/bulk", {action, value, animeIds})
+		await arn.reloadContent()
+
+		if(action === "remove") {
+			arn.statusMessage.showInfo(`Moved ${animeIds.length} anime to the trash.`)
+		} else {
+			arn.statusMessage.showInfo(`Edited ${animeIds.length} anime.`)
+		}
 	} catch(err) {
 		arn.statusMessage.showError(err)
 	}
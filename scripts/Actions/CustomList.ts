@@ -0,0 +1,26 @@
+import AnimeNotifier from "../AnimeNotifier"
+
+// Add an anime to a custom list
+export async function addAnimeToCustomList(arn: AnimeNotifier, button: HTMLButtonElement) {
+	const endpoint = arn.findAPIEndpoint(button)
+
+	try {
+		await arn.post(`${endpoint}/add/${button.dataset.animeId}`)
+		await arn.reloadContent()
+		arn.statusMessage.showInfo(`Added to "${button.dataset.listName}".`)
+	} catch(err) {
+		arn.statusMessage.showError(err)
+	}
+}
+
+// Remove an anime from a custom list
+export async function removeAnimeFromCustomList(arn: AnimeNotifier, button: HTMLButtonElement) {
+	const endpoint = arn.findAPIEndpoint(button)
+
+	try {
+		await arn.post(`${endpoint}/remove/${button.dataset.animeId}`)
+		await arn.reloadContent()
+	} catch(err) {
+		arn.statusMessage.showError(err)
+	}
+}
@@ -0,0 +1,81 @@
+import AnimeNotifier from "../AnimeNotifier"
+
+// Delay between the last input and saving the draft
+const draftSaveDelay = 1000
+const draftSaveTimeouts = new Map<string, number>()
+
+// Save draft after the user stopped typing
+export function saveDraft(arn: AnimeNotifier, element: HTMLElement) {
+	const container = element.closest("[data-draft-key]") as HTMLElement
+
+	if(!container || !container.dataset.draftKey) {
+		return
+	}
+
+	const key = container.dataset.draftKey
+	clearTimeout(draftSaveTimeouts.get(key))
+
+	draftSaveTimeouts.set(key, setTimeout(async () => {
+		draftSaveTimeouts.delete(key)
+
+		const draft = {
+			key,
+			title: "",
+			text: "",
+			tag: ""
+		}
+
+		for(const field of container.querySelectorAll("[data-draft-field]")) {
+			const input = field as HTMLInputElement
+			draft[input.dataset.draftField as string] = input.value
+		}
+
+		try {
+			const response = await fetch("/api/draft/save", {
+				method: "POST",
+				body: JSON.stringify(draft),
+				credentials: "same-origin"
+			})
+
+			if(response.status !== 200) {
+				throw await response.text()
+			}
+		} catch(err) {
+			arn.statusMessage.showError(err)
+		}
+	}, draftSaveDelay))
+}
+
+// Show rendered markdown of a draft
+export async function previewMarkdown(arn: AnimeNotifier, button: HTMLButtonElement) {
+	const container = document.getElementById(button.dataset.draft as string)
+
+	if(!container) {
+		return
+	}
+
+	const textarea = container.querySelector("[data-draft-field='text']") as HTMLTextAreaElement
+	const preview = container.getElementsByClassName("markdown-preview")[0] as HTMLElement
+
+	// Clicking again returns to the editor
+	if(!preview.classList.contains("hidden")) {
+		preview.classList.add("hidden")
+		textarea.classList.remove("hidden")
+		return
+	}
+
+	try {
+		const response = await arn.post("/api/markdown/preview", {text: textarea.value})
+
+		if(!response) {
+			return
+		}
+
+		preview.innerHTML = await response.text()
+		preview.classList.remove("hidden")
+		textarea.classList.add("hidden")
+		arn.onNewContent(preview)
+	} catch(err) {
+		arn.statusMessage.showError(err)
+	}
+}
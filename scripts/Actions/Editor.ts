@@ -123,3 +123,44 @@ export async function syncEpisodes(arn: AnimeNotifier, button: HTMLButtonElement
 		arn.statusMessage.showError(err)
 	}
 }
+
+// Revert a field edit
+export async function revertEdit(arn: AnimeNotifier, button: HTMLButtonElement) {
+	if(!confirm("Are you sure you want to revert this edit?")) {
+		return
+	}
+
+	const endpoint = arn.findAPIEndpoint(button)
+
+	try {
+		await arn.post(`${endpoint}/revert`)
+		await arn.reloadContent()
+	} catch(err) {
+		arn.statusMessage.showError(err)
+	}
+}
+
+// Approve or reject an edit made by a low-trust editor
+export async function resolvePendingEdit(arn: AnimeNotifier, button: HTMLButtonElement) {
+	const endpoint = arn.findAPIEndpoint(button)
+
+	try {
+		await arn.post(`${endpoint}/${button.dataset.resolution}`)
+		await arn.reloadContent()
+	} catch(err) {
+		arn.statusMessage.showError(err)
+	}
+}
+
+// Let related anime link back to the anime
+export async function addReverseAnimeRelations(arn: AnimeNotifier, button: HTMLButtonElement) {
+	const apiEndpoint = arn.findAPIEndpoint(button)
+
+	try {
+		await arn.post(`${apiEndpoint}/reverse`)
+		arn.reloadContent()
+		arn.statusMessage.showInfo("Added reverse relations", 1000)
+	} catch(err) {
+		arn.statusMessage.showError(err)
+	}
+}
@@ -26,6 +26,23 @@ export function filterAnime(arn: AnimeNotifier, _: HTMLInputElement) {
 	arn.diff(`${root.dataset.url}/${year}/${season}/${status}/${type}`)
 }
 
+// Filter anime on the season page
+export function filterSeason(arn: AnimeNotifier, _: HTMLSelectElement) {
+	const root = document.getElementById("season-filter-root") as HTMLElement
+	const parameters = new URLSearchParams()
+
+	for(const name of ["genre", "studio", "type", "sort"]) {
+		const element = document.getElementById(`season-filter-${name}`) as HTMLSelectElement
+
+		if(element.value) {
+			parameters.set(name, element.value)
+		}
+	}
+
+	const query = parameters.toString()
+	arn.diff(query ? `${root.dataset.url}?${query}` : root.dataset.url as string)
+}
+
 // Toggle hiding added anime.
 export function toggleHideAddedAnime() {
 	hideAddedAnime()
@@ -0,0 +1,31 @@
+import AnimeNotifier from "../AnimeNotifier"
+
+// Pin featured content
+export async function pinFeaturedContent(arn: AnimeNotifier) {
+	const value = (id: string) => (document.getElementById(id) as HTMLInputElement).value
+
+	try {
+		await arn.post("/api/featured/pin", {
+			date: value("featured-date"),
+			animeId: value("featured-anime"),
+			characterId: value("featured-character"),
+			quoteId: value("featured-quote")
+		})
+
+		await arn.reloadContent()
+		arn.statusMessage.showInfo("Pinned the featured content.")
+	} catch(err) {
+		arn.statusMessage.showError(err)
+	}
+}
+
+// Unpin featured content
+export async function unpinFeaturedContent(arn: AnimeNotifier, button: HTMLButtonElement) {
+	try {
+		await arn.post("/api/featured/unpin", {date: button.dataset.date})
+		await arn.reloadContent()
+		arn.statusMessage.showInfo("Unpinned the featured content.")
+	} catch(err) {
+		arn.statusMessage.showError(err)
+	}
+}
@@ -35,17 +35,51 @@ export async function savePost(arn: AnimeNotifier, element: HTMLElement) {
 		Text: text,
 	}
 
-	// Add title for threads only
+	let apiEndpoint = arn.findAPIEndpoint(element)
+
+	// Add title and the revision we're editing for threads only
 	if(title) {
 		updates.Title = title.value
+		updates.Revision = parseInt(title.dataset.revision || "0")
+		apiEndpoint += "/save"
 	}
 
-	const apiEndpoint = arn.findAPIEndpoint(element)
-
 	try {
-		await arn.post(apiEndpoint, updates)
+		if(!title) {
+			await arn.post(apiEndpoint, updates)
+			arn.reloadContent()
+			return
+		}
+
+		arn.loading(true)
+
+		const response = await fetch(apiEndpoint, {
+			method: "POST",
+			body: JSON.stringify(updates),
+			credentials: "same-origin"
+		})
+
+		arn.loading(false)
+
+		if(response.status === 409) {
+			// Somebody else saved the thread in the meantime:
+			// Load their version and keep ours in the clipboard for merging.
+			const latest = await response.json()
+			await navigator.clipboard.writeText(text)
+			source.value = latest.text
+			title.value = latest.title
+			title.dataset.revision = String(latest.revision)
+			arn.statusMessage.showError("This thread has been edited in the meantime. The latest version has been loaded and your changes have been copied to the clipboard.")
+			return
+		}
+
+		if(response.status !== 200) {
+			throw await response.text()
+		}
+
 		arn.reloadContent()
 	} catch(err) {
+		arn.loading(false)
 		arn.statusMessage.showError(err)
 	}
 }
@@ -159,6 +193,30 @@ export function unlockThread(arn: AnimeNotifier, element: HTMLButtonElement) {
 	return setThreadLock(arn, element, false)
 }
 
+// Subscribe to thread
+export function subscribeThread(arn: AnimeNotifier, element: HTMLButtonElement) {
+	return setThreadSubscription(arn, element, true)
+}
+
+// Unsubscribe from thread
+export function unsubscribeThread(arn: AnimeNotifier, element: HTMLButtonElement) {
+	return setThreadSubscription(arn, element, false)
+}
+
+// Set thread subscription state
+async function setThreadSubscription(arn: AnimeNotifier, element: HTMLButtonElement, state: boolean) {
+	const verb = state ? "subscribe" : "unsubscribe"
+	const endpoint = arn.findAPIEndpoint(element)
+
+	try {
+		await arn.post(`${endpoint}/${verb}`)
+		await arn.reloadContent()
+		arn.statusMessage.showInfo(state ? "Subscribed to the thread." : "Unsubscribed from the thread.")
+	} catch(err) {
+		arn.statusMessage.showError(err)
+	}
+}
+
 // Set thread locked state
 async function setThreadLock(arn: AnimeNotifier, element: HTMLButtonElement, state: boolean) {
 	const verb = state ? "lock" : "unlock"
@@ -176,3 +234,70 @@ async function setThreadLock(arn: AnimeNotifier, element: HTMLButtonElement, sta
 		arn.statusMessage.showError(err)
 	}
 }
+
+// Pin thread
+export function pinThread(arn: AnimeNotifier, element: HTMLButtonElement) {
+	return setThreadPinned(arn, element, true)
+}
+
+// Unpin thread
+export function unpinThread(arn: AnimeNotifier, element: HTMLButtonElement) {
+	return setThreadPinned(arn, element, false)
+}
+
+// Set thread pinned state
+async function setThreadPinned(arn: AnimeNotifier, element: HTMLButtonElement, state: boolean) {
+	const verb = state ? "pin" : "unpin"
+	const endpoint = arn.findAPIEndpoint(element)
+
+	try {
+		await arn.post(`${endpoint}/${verb}`)
+		await arn.reloadContent()
+	} catch(err) {
+		arn.statusMessage.showError(err)
+	}
+}
+
+// Move thread to a different forum
+export async function moveThread(arn: AnimeNotifier, element: HTMLButtonElement) {
+	const select = document.getElementById("thread-move-tag") as HTMLSelectElement
+	const endpoint = arn.findAPIEndpoint(element)
+
+	try {
+		await arn.post(`${endpoint}/move`, {tag: select.value})
+		await arn.reloadContent()
+		arn.statusMessage.showInfo(`Moved the thread to "${select.value}".`)
+	} catch(err) {
+		arn.statusMessage.showError(err)
+	}
+}
+
+// Remove a thread or post without deleting it
+export async function softDeleteObject(arn: AnimeNotifier, element: HTMLButtonElement) {
+	const reason = prompt("Why is this being removed?")
+
+	if(!reason) {
+		return
+	}
+
+	const endpoint = arn.findAPIEndpoint(element)
+
+	try {
+		await arn.post(`${endpoint}/softdelete`, {reason})
+		await arn.reloadContent()
+	} catch(err) {
+		arn.statusMessage.showError(err)
+	}
+}
+
+// Restore a removed thread or post
+export async function restoreObject(arn: AnimeNotifier, element: HTMLButtonElement) {
+	const endpoint = arn.findAPIEndpoint(element)
+
+	try {
+		await arn.post(`${endpoint}/restore`)
+		await arn.reloadContent()
+	} catch(err) {
+		arn.statusMessage.showError(err)
+	}
+}
@@ -33,3 +33,128 @@ export async function leave(arn: AnimeNotifier, element: HTMLElement) {
 		arn.statusMessage.showError(err)
 	}
 }
+
+// Send a request to join a group
+export async function requestJoin(arn: AnimeNotifier, element: HTMLElement) {
+	const apiEndpoint = arn.findAPIEndpoint(element)
+
+	try {
+		await arn.post(`${apiEndpoint}/join`)
+		arn.reloadContent()
+		arn.statusMessage.showInfo("Sent join request!", 1000)
+	} catch(err) {
+		arn.statusMessage.showError(err)
+	}
+}
+
+// Withdraw a join request
+export async function cancelJoinRequest(arn: AnimeNotifier, element: HTMLElement) {
+	const apiEndpoint = arn.findAPIEndpoint(element)
+
+	try {
+		await arn.post(`${apiEndpoint}/leave`)
+		arn.reloadContent()
+		arn.statusMessage.showInfo("Cancelled join request!", 1000)
+	} catch(err) {
+		arn.statusMessage.showError(err)
+	}
+}
+
+// Accept a join request
+export async function acceptJoinRequest(arn: AnimeNotifier, button: HTMLButtonElement) {
+	await groupMemberAction(arn, button, "accept", {userId: button.dataset.userId})
+}
+
+// Decline a join request
+export async function declineJoinRequest(arn: AnimeNotifier, button: HTMLButtonElement) {
+	await groupMemberAction(arn, button, "decline", {userId: button.dataset.userId})
+}
+
+// Invite a user to the group
+export async function inviteGroupMember(arn: AnimeNotifier, button: HTMLButtonElement) {
+	const nick = document.getElementById("group-invite-nick") as HTMLInputElement
+	await groupMemberAction(arn, button, "invite", {nick: nick.value})
+}
+
+// Promote or demote a group member
+export async function setGroupMemberRole(arn: AnimeNotifier, button: HTMLButtonElement) {
+	await groupMemberAction(arn, button, "role", {
+		userId: button.dataset.userId,
+		role: button.dataset.role
+	})
+}
+
+// Remove a member from the group
+export async function removeGroupMember(arn: AnimeNotifier, button: HTMLButtonElement) {
+	if(!confirm(`Are you sure you want to remove this member from the group?`)) {
+		return
+	}
+
+	await groupMemberAction(arn, button, "remove", {userId: button.dataset.userId})
+}
+
+// Pin an announcement in the group
+export async function announceInGroup(arn: AnimeNotifier, button: HTMLButtonElement) {
+	const text = document.getElementById("group-announcement-text") as HTMLTextAreaElement
+	await groupMemberAction(arn, button, "announce", {text: text.value})
+}
+
+// Create a new group event
+export async function newGroupEvent(arn: AnimeNotifier, button: HTMLButtonElement) {
+	const title = document.getElementById("new-group-event-title") as HTMLInputElement
+	const start = document.getElementById("new-group-event-start") as HTMLInputElement
+	const end = document.getElementById("new-group-event-end") as HTMLInputElement
+	const location = document.getElementById("new-group-event-location") as HTMLInputElement
+
+	try {
+		const response = await arn.post("/api/new/groupevent", {
+			groupId: button.dataset.groupId,
+			title: title.value,
+			start: toISODate(start.value),
+			end: toISODate(end.value),
+			location: location.value
+		})
+
+		if(!response) {
+			throw "Failed creating event"
+		}
+
+		const json = await response.json()
+		await arn.app.load(`/group/${json.groupId}/event/${json.id}`)
+	} catch(err) {
+		arn.statusMessage.showError(err)
+	}
+}
+
+// Respond to a group event
+export async function rsvpGroupEvent(arn: AnimeNotifier, button: HTMLButtonElement) {
+	const apiEndpoint = arn.findAPIEndpoint(button)
+
+	try {
+		await arn.post(`${apiEndpoint}/rsvp`, {status: button.dataset.status})
+		arn.reloadContent()
+	} catch(err) {
+		arn.statusMessage.showError(err)
+	}
+}
+
+// Runs a moderation action on the group and reloads the page
+async function groupMemberAction(arn: AnimeNotifier, button: HTMLButtonElement, action: string, body: any) {
+	const apiEndpoint = arn.findAPIEndpoint(button)
+
+	try {
+		await arn.post(`${apiEndpoint}/${action}`, body)
+		arn.reloadContent()
+	} catch(err) {
+		arn.statusMessage.showError(err)
+	}
+}
+
+// Converts the local time of a datetime input to an RFC 3339 date in UTC
+function toISODate(value: string): string {
+	if(!value) {
+		return ""
+	}
+
+	return new Date(value).toISOString().replace(/\.\d{3}Z$/, "Z")
+}
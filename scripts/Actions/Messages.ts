@@ -0,0 +1,55 @@
+import AnimeNotifier from "../AnimeNotifier"
+
+// Start a new conversation
+export async function createConversation(arn: AnimeNotifier) {
+	const recipients = document.getElementById("conversation-recipients") as HTMLInputElement
+	const subject = document.getElementById("conversation-subject") as HTMLInputElement
+	const text = document.getElementById("conversation-text") as HTMLTextAreaElement
+
+	const conversation = {
+		recipients: recipients.value.split(",").map(nick => nick.trim()).filter(nick => nick !== ""),
+		subject: subject.value,
+		text: text.value
+	}
+
+	try {
+		await arn.post("/api/new/conversation", conversation)
+		await arn.app.load("/messages/sent")
+		arn.statusMessage.showInfo("Your message has been sent.")
+	} catch(err) {
+		arn.statusMessage.showError(err)
+	}
+}
+
+// Reply in a conversation
+export async function replyConversation(arn: AnimeNotifier, button: HTMLButtonElement) {
+	const endpoint = arn.findAPIEndpoint(button)
+	const text = document.getElementById("conversation-reply-text") as HTMLTextAreaElement
+
+	try {
+		await arn.post(`${endpoint}/reply`, {
+			text: text.value
+		})
+
+		text.value = ""
+		await arn.reloadContent()
+	} catch(err) {
+		arn.statusMessage.showError(err)
+	}
+}
+
+// Leave a conversation
+export async function leaveConversation(arn: AnimeNotifier, button: HTMLButtonElement) {
+	if(!confirm("Leave this conversation? You won't receive its messages anymore.")) {
+		return
+	}
+
+	const endpoint = arn.findAPIEndpoint(button)
+
+	try {
+		await arn.post(`${endpoint}/leave`)
+		await arn.app.load("/messages")
+	} catch(err) {
+		arn.statusMessage.showError(err)
+	}
+}
@@ -0,0 +1,23 @@
+import AnimeNotifier from "../AnimeNotifier"
+
+// Enable MyAnimeList sync
+export async function enableMyAnimeListSync(arn: AnimeNotifier) {
+	try {
+		await arn.post("/api/import/myanimelist/sync/enable")
+		await arn.app.load("/import")
+		arn.statusMessage.showInfo("Your anime list is now synchronized with MyAnimeList.")
+	} catch(err) {
+		arn.statusMessage.showError(err)
+	}
+}
+
+// Disable MyAnimeList sync
+export async function disableMyAnimeListSync(arn: AnimeNotifier) {
+	try {
+		await arn.post("/api/import/myanimelist/sync/disable")
+		await arn.reloadContent()
+		arn.statusMessage.showInfo("Stopped syncing with MyAnimeList.")
+	} catch(err) {
+		arn.statusMessage.showError(err)
+	}
+}
@@ -42,3 +42,13 @@ export async function markNotificationsAsSeen(arn: AnimeNotifier) {
 	// Update notifications
 	arn.reloadContent()
 }
+
+// Unsubscribe from notification emails
+export async function unsubscribeEmail(arn: AnimeNotifier, button: HTMLButtonElement) {
+	try {
+		await arn.post(button.dataset.api as string)
+		await arn.reloadContent()
+	} catch(err) {
+		arn.statusMessage.showError(err)
+	}
+}
@@ -0,0 +1,91 @@
+import AnimeNotifier from "../AnimeNotifier"
+
+// Approve an app's authorization request
+export async function approveOAuth(arn: AnimeNotifier, button: HTMLButtonElement) {
+	await sendOAuthConsent(arn, "/api/oauth/authorize", button)
+}
+
+// Deny an app's authorization request
+export async function denyOAuth(arn: AnimeNotifier, button: HTMLButtonElement) {
+	await sendOAuthConsent(arn, "/api/oauth/deny", button)
+}
+
+// Send the consent decision and redirect to the app
+async function sendOAuthConsent(arn: AnimeNotifier, url: string, button: HTMLButtonElement) {
+	try {
+		const response = await arn.post(url, {
+			clientId: button.dataset.clientId,
+			redirectURI: button.dataset.redirectUri,
+			scope: button.dataset.scope || "",
+			state: button.dataset.state,
+			codeChallenge: button.dataset.codeChallenge || "",
+			codeChallengeMethod: button.dataset.codeChallengeMethod || ""
+		})
+
+		if(!response) {
+			throw "Failed sending the authorization"
+		}
+
+		const json = await response.json()
+		location.href = json.redirect
+	} catch(err) {
+		arn.statusMessage.showError(err)
+	}
+}
+
+// Register a new app
+export async function newOAuthClient(arn: AnimeNotifier) {
+	const name = document.getElementById("oauth-client-name") as HTMLInputElement
+	const website = document.getElementById("oauth-client-website") as HTMLInputElement
+	const redirectURIs = document.getElementById("oauth-client-redirect-uris") as HTMLTextAreaElement
+
+	try {
+		const response = await arn.post("/api/oauth/clients/new", {
+			name: name.value,
+			website: website.value,
+			redirectURIs: redirectURIs.value
+		})
+
+		if(!response) {
+			throw "Failed registering the app"
+		}
+
+		const client = await response.json()
+		const appName = name.value
+		await arn.reloadContent()
+		prompt("Copy the client secret now, it won't be shown again:", client.secret)
+		arn.statusMessage.showInfo(`Registered "${appName}".`)
+	} catch(err) {
+		arn.statusMessage.showError(err)
+	}
+}
+
+// Delete an app
+export async function deleteOAuthClient(arn: AnimeNotifier, button: HTMLButtonElement) {
+	if(!confirm(`All users will be logged out of "${button.dataset.name}". Do you really want to delete it?`)) {
+		return
+	}
+
+	try {
+		await arn.post("/api/oauth/clients/delete", {clientId: button.dataset.clientId})
+		await arn.reloadContent()
+		arn.statusMessage.showInfo(`Deleted "${button.dataset.name}".`)
+	} catch(err) {
+		arn.statusMessage.showError(err)
+	}
+}
+
+// Revoke the access of an authorized app
+export async function revokeOAuthAuthorization(arn: AnimeNotifier, button: HTMLButtonElement) {
+	if(!confirm(`"${button.dataset.name}" will no longer be able to access your account. Do you want to continue?`)) {
+		return
+	}
+
+	try {
+		await arn.post("/api/oauth/authorizations/revoke", {clientId: button.dataset.clientId})
+		await arn.reloadContent()
+		arn.statusMessage.showInfo(`Revoked the access of "${button.dataset.name}".`)
+	} catch(err) {
+		arn.statusMessage.showError(err)
+	}
+}
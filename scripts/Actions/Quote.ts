@@ -0,0 +1,13 @@
+import AnimeNotifier from "../AnimeNotifier"
+
+// Approve or reject a quote
+export async function reviewQuote(arn: AnimeNotifier, button: HTMLButtonElement) {
+	const endpoint = arn.findAPIEndpoint(button)
+
+	try {
+		await arn.post(`${endpoint}/${button.dataset.review}`)
+		await arn.reloadContent()
+	} catch(err) {
+		arn.statusMessage.showError(err)
+	}
+}
@@ -0,0 +1,34 @@
+import AnimeNotifier from "../AnimeNotifier"
+
+// Report a post, thread or user profile
+export async function createReport(arn: AnimeNotifier, button: HTMLButtonElement) {
+	const reason = document.getElementById("report-reason") as HTMLSelectElement
+	const text = document.getElementById("report-text") as HTMLTextAreaElement
+
+	const report = {
+		objectType: button.dataset.objectType,
+		objectId: button.dataset.objectId,
+		reason: reason.value,
+		text: text.value
+	}
+
+	try {
+		await arn.post("/api/new/report", report)
+		await arn.app.load(button.dataset.returnPath as string)
+		arn.statusMessage.showInfo("Thanks for your report, a moderator will take a look at it.")
+	} catch(err) {
+		arn.statusMessage.showError(err)
+	}
+}
+
+// Dismiss a report or mark it as actioned
+export async function resolveReport(arn: AnimeNotifier, button: HTMLButtonElement) {
+	const endpoint = arn.findAPIEndpoint(button)
+
+	try {
+		await arn.post(`${endpoint}/${button.dataset.resolution}`)
+		await arn.reloadContent()
+	} catch(err) {
+		arn.statusMessage.showError(err)
+	}
+}
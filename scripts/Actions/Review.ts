@@ -0,0 +1,31 @@
+import AnimeNotifier from "../AnimeNotifier"
+
+// Start writing a review for an anime
+export async function newReview(arn: AnimeNotifier, button: HTMLButtonElement) {
+	try {
+		const response = await arn.post("/api/new/review", {
+			animeId: button.dataset.animeId
+		})
+
+		if(!response) {
+			throw "Failed creating review"
+		}
+
+		const json = await response.json()
+		await arn.app.load(`/review/${json.id}/edit`)
+	} catch(err) {
+		arn.statusMessage.showError(err)
+	}
+}
+
+// Vote on whether a review is helpful or not
+export async function voteReview(arn: AnimeNotifier, button: HTMLButtonElement) {
+	const endpoint = arn.findAPIEndpoint(button)
+
+	try {
+		await arn.post(`${endpoint}/${button.dataset.vote}`)
+		await arn.reloadContent()
+	} catch(err) {
+		arn.statusMessage.showError(err)
+	}
+}
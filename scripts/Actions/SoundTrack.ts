@@ -0,0 +1,23 @@
+import AnimeNotifier from "../AnimeNotifier"
+
+// Add a soundtrack to an anime
+export async function newAnimeSoundTrack(arn: AnimeNotifier, button: HTMLButtonElement) {
+	const url = document.getElementById("new-soundtrack-url") as HTMLInputElement
+	const trackType = document.getElementById("new-soundtrack-type") as HTMLSelectElement
+
+	try {
+		const response = await arn.post(`/api/anime/${button.dataset.animeId}/soundtrack/new`, {
+			url: url.value,
+			type: trackType.value
+		})
+
+		if(!response) {
+			throw "Failed creating soundtrack"
+		}
+
+		const json = await response.json()
+		await arn.app.load(`/soundtrack/${json.id}/edit`)
+	} catch(err) {
+		arn.statusMessage.showError(err)
+	}
+}
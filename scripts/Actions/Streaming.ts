@@ -0,0 +1,35 @@
+import AnimeNotifier from "../AnimeNotifier"
+
+// Suggest a streaming link for an anime
+export async function suggestStreamingLink(arn: AnimeNotifier, button: HTMLButtonElement) {
+	const service = document.getElementById("streaming-link-service") as HTMLSelectElement
+	const url = document.getElementById("streaming-link-url") as HTMLInputElement
+	const regions = document.getElementById("streaming-link-regions") as HTMLInputElement
+
+	const submission = {
+		animeId: button.dataset.animeId,
+		service: service.value,
+		url: url.value,
+		regions: regions.value
+	}
+
+	try {
+		await arn.post("/api/new/streaminglinksubmission", submission)
+		await arn.app.load(button.dataset.returnPath as string)
+		arn.statusMessage.showInfo("Thanks for your suggestion, an editor will review it soon.")
+	} catch(err) {
+		arn.statusMessage.showError(err)
+	}
+}
+
+// Approve or reject a streaming link suggestion
+export async function resolveStreamingLink(arn: AnimeNotifier, button: HTMLButtonElement) {
+	const endpoint = arn.findAPIEndpoint(button)
+
+	try {
+		await arn.post(`${endpoint}/${button.dataset.resolution}`)
+		await arn.reloadContent()
+	} catch(err) {
+		arn.statusMessage.showError(err)
+	}
+}
@@ -2,6 +2,9 @@ import bytesHumanReadable from "scripts/Utils/bytesHumanReadable"
 import uploadWithProgress from "scripts/Utils/uploadWithProgress"
 import AnimeNotifier from "../AnimeNotifier"
 
+// Maximum file size of uploaded images, this needs to match the limit on the server
+const maxImageFileSize = 10 * 1024 * 1024
+
 // Select file
 export function selectFile(arn: AnimeNotifier, button: HTMLButtonElement) {
 	const fileType = button.dataset.type
@@ -35,6 +38,12 @@ export function selectFile(arn: AnimeNotifier, button: HTMLButtonElement) {
 			return
 		}
 
+		// Check file size for images
+		if(fileType === "image" && file.size > maxImageFileSize) {
+			arn.statusMessage.showError(`${file.name} is too large (${bytesHumanReadable(file.size)}), the maximum file size is ${bytesHumanReadable(maxImageFileSize)}.`, 8000)
+			return
+		}
+
 		// Check mime type for videos
 		if(fileType === "video" && !file.type.startsWith("video/webm")) {
 			arn.statusMessage.showError(file.name + " is not a WebM video file!")
@@ -106,8 +115,13 @@ function uploadFile(file: File, fileType: string, endpoint: string, arn: AnimeNo
 				// We received the new avatar URL
 				updateSideBarAvatar(responseText)
 			}
+
+			if(endpoint === "/api/import/file") {
+				// Show the preview of the uploaded list
+				arn.app.load("/import/file")
+			}
 		} catch(err) {
-			arn.statusMessage.showError(`Failed uploading your new ${fileType}.`)
+			arn.statusMessage.showError(typeof err === "string" && err ? err : `Failed uploading your new ${fileType}.`, 8000)
 			console.error(err)
 		}
 	}
@@ -30,6 +30,53 @@ async function updateFollow(arn: AnimeNotifier, element: HTMLElement, message: s
 	}
 }
 
+// Block user
+export async function blockUser(arn: AnimeNotifier, element: HTMLElement) {
+	if(!confirm(`Block ${element.dataset.nick}? Their posts will be hidden and they can't follow you.`)) {
+		return
+	}
+
+	return updateBlock(arn, element, "You blocked")
+}
+
+// Unblock user
+export async function unblockUser(arn: AnimeNotifier, element: HTMLElement) {
+	return updateBlock(arn, element, "You unblocked")
+}
+
+// Update block
+async function updateBlock(arn: AnimeNotifier, element: HTMLElement, message: string) {
+	const api = element.dataset.api
+	const nick = element.dataset.nick
+
+	if(!api || !nick) {
+		console.error("Missing data-api or data-nick:", element)
+		return
+	}
+
+	try {
+		await arn.post(api)
+		await arn.reloadContent()
+		arn.statusMessage.showInfo(`${message} ${nick}.`)
+	} catch(err) {
+		arn.statusMessage.showError(err)
+	}
+}
+
+// Delete account
+export async function deleteAccount(arn: AnimeNotifier) {
+	if(!confirm("Your account will be deactivated and your data will be deleted in 14 days unless you log in again. Do you really want to delete your account?")) {
+		return
+	}
+
+	try {
+		await arn.post("/api/delete/account")
+		location.href = "/"
+	} catch(err) {
+		arn.statusMessage.showError(err)
+	}
+}
+
 // Show more
 export function showMore(_: AnimeNotifier, showMoreElement: HTMLElement) {
 	const elements = [...document.getElementsByClassName("show-more")]
@@ -1,23 +1,36 @@
 export * from "./Activity"
+export * from "./AniList"
+export * from "./APIKeys"
 export * from "./Audio"
 export * from "./AnimeList"
+export * from "./CustomList"
 export * from "./Diff"
+export * from "./Draft"
 export * from "./Editor"
 export * from "./Explore"
+export * from "./FeaturedContent"
 export * from "./User"
 export * from "./Forum"
 export * from "./Group"
 export * from "./InfiniteScroller"
 export * from "./Install"
 export * from "./Like"
+export * from "./Messages"
+export * from "./MyAnimeList"
 export * from "./Notifications"
 export * from "./Object"
+export * from "./OAuth"
 export * from "./Publish"
+export * from "./Quote"
+export * from "./Report"
+export * from "./Review"
 export * from "./Search"
 export * from "./Serialization"
 export * from "./Shop"
+export * from "./SoundTrack"
 export * from "./SideBar"
 export * from "./StatusMessage"
+export * from "./Streaming"
 export * from "./Theme"
 export * from "./Upload"
 export * from "./Video"
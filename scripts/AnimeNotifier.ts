@@ -6,6 +6,7 @@ import { displayAiringDate, displayDate, displayTime } from "./DateView"
 import Diff from "./Diff"
 import ToolTip from "./Elements/tool-tip/tool-tip"
 import infiniteScroll from "./infiniteScroll"
+import MessageCounter from "./MessageCounter"
 import NotificationManager from "./NotificationManager"
 import PushManager from "./PushManager"
 import receiveServerEvents from "./ServerEvent/receiveServerEvents"
@@ -18,6 +19,7 @@ import emptyPixel from "./Utils/emptyPixel"
 import findAll from "./Utils/findAll"
 import findAllInside from "./Utils/findAllInside"
 import requestIdleCallback from "./Utils/requestIdleCallback"
+import supportsAVIF from "./Utils/supportsAVIF"
 import supportsWebP from "./Utils/supportsWebP"
 import swapElements from "./Utils/swapElements"
 import VideoPlayer from "./VideoPlayer"
@@ -28,6 +30,7 @@ export default class AnimeNotifier {
 	public app: Application
 	public statusMessage: StatusMessage
 	public notificationManager: NotificationManager | undefined
+	public messageCounter: MessageCounter | undefined
 	public currentMediaId: string
 	public audioPlayer: AudioPlayer
 	public videoPlayer: VideoPlayer
@@ -38,6 +41,8 @@ export default class AnimeNotifier {
 	private title: string
 	private webpCheck: Promise<boolean>
 	private webpEnabled: boolean
+	private avifCheck: Promise<boolean>
+	private avifEnabled: boolean
 	private visibilityObserver: IntersectionObserver
 	private serviceWorkerManager: ServiceWorkerManager
 	private diffCompletedForCurrentPath: boolean
@@ -456,6 +461,10 @@ export default class AnimeNotifier {
 				document.getElementById("notification-icon") as HTMLElement,
 				document.getElementById("notification-count") as HTMLElement
 			)
+
+			this.messageCounter = new MessageCounter(
+				document.getElementById("message-count") as HTMLElement
+			)
 		}
 
 		// Audio player
@@ -475,6 +484,9 @@ export default class AnimeNotifier {
 		// WebP
 		this.webpCheck = supportsWebP().then(val => this.webpEnabled = val)
 
+		// AVIF
+		this.avifCheck = supportsAVIF().then(val => this.avifEnabled = val)
+
 		// Loading
 		this.loading(false)
 	}
@@ -541,6 +553,12 @@ export default class AnimeNotifier {
 			}
 		})
 
+		// Restored drafts can be posted right away
+		if(newPostText.value.length > 0) {
+			const newPostActions = document.getElementsByClassName("new-post-actions")[0]
+			newPostActions.classList.add("new-post-actions-enabled")
+		}
+
 		newPostText["has-input-listener"] = true
 	}
 
@@ -569,6 +587,11 @@ export default class AnimeNotifier {
 			this.notificationManager.update()
 		}
 
+		// Message counter
+		if(this.messageCounter) {
+			this.messageCounter.update()
+		}
+
 		// Bind unload event
 		window.addEventListener("beforeunload", this.onBeforeUnload.bind(this))
 
@@ -766,6 +789,67 @@ export default class AnimeNotifier {
 			}
 		}
 
+		if(location.pathname.startsWith("/customlist/")) {
+			for(const element of findAll("custom-list-item")) {
+				// Skip elements that have their event listeners attached already
+				if(element["drag-listeners-attached"] || !element.draggable) {
+					continue
+				}
+
+				element.addEventListener("dragstart", e => {
+					if(!element.dataset.index || !e.dataTransfer) {
+						return
+					}
+
+					e.dataTransfer.setData("text", element.dataset.index)
+					e.dataTransfer.effectAllowed = "move"
+				}, false)
+
+				element.addEventListener("dragenter", _ => {
+					element.classList.add("drag-enter")
+				}, false)
+
+				element.addEventListener("dragleave", _ => {
+					element.classList.remove("drag-enter")
+				}, false)
+
+				element.addEventListener("dragover", e => {
+					e.preventDefault()
+				}, false)
+
+				element.addEventListener("drop", async e => {
+					element.classList.remove("drag-enter")
+
+					e.stopPropagation()
+					e.preventDefault()
+
+					if(!e.dataTransfer) {
+						return
+					}
+
+					const fromIndex = e.dataTransfer.getData("text")
+					const toIndex = element.dataset.index
+
+					if(!fromIndex || !toIndex || fromIndex === toIndex) {
+						return
+					}
+
+					// Move in database and show the new order
+					const apiEndpoint = this.findAPIEndpoint(element)
+
+					try {
+						await this.post(apiEndpoint + "/move/" + fromIndex + "/" + toIndex)
+						await this.reloadContent()
+					} catch(err) {
+						this.statusMessage.showError(err)
+					}
+				}, false)
+
+				// Prevent re-attaching the same listeners
+				element["drag-listeners-attached"] = true
+			}
+		}
+
 		if(location.pathname.startsWith("/inventory")) {
 			for(const element of findAll("inventory-slot")) {
 				// Skip elements that have their event listeners attached already
@@ -972,7 +1056,7 @@ export default class AnimeNotifier {
 			elements = findAll("lazy")
 		}
 
-		await this.webpCheck
+		await Promise.all([this.webpCheck, this.avifCheck])
 
 		for(const element of elements) {
 			switch(element.tagName) {
@@ -1027,7 +1111,15 @@ export default class AnimeNotifier {
 				}
 			}
 
-			const finalSrc = base + extension
+			// Prefer AVIF if supported and the server has an AVIF version of the image
+			let avifExtension = ""
+
+			if(this.avifEnabled && element.dataset.avif === "true" && !dataSrc.endsWith(".svg")) {
+				avifExtension = extension.replace(/^\.[a-z]+/, ".avif")
+			}
+
+			const fallbackSrc = base + extension
+			const finalSrc = avifExtension ? base + avifExtension : fallbackSrc
 
 			if(element.src !== finalSrc && element.src !== "https:" + finalSrc && element.src !== "https://notify.moe" + finalSrc) {
 				// Show average color
@@ -1051,6 +1143,12 @@ export default class AnimeNotifier {
 				}
 
 				element.onerror = () => {
+					// Fall back to the other formats if the AVIF version failed loading
+					if(element.src.includes(".avif")) {
+						element.src = fallbackSrc
+						return
+					}
+
 					// Try loading from the origin server if our CDN failed
 					if(element.src.includes("media.notify.moe/")) {
 						console.warn(`CDN failed loading ${element.src}`)
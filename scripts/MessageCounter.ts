@@ -0,0 +1,40 @@
+import Diff from "./Diff"
+
+export default class MessageCounter {
+	unread: number
+	counter: HTMLElement
+
+	constructor(counter: HTMLElement) {
+		this.counter = counter
+	}
+
+	async update() {
+		const response = await fetch("/api/count/messages/unread", {
+			credentials: "same-origin"
+		})
+
+		const body = await response.text()
+		this.setCounter(parseInt(body))
+	}
+
+	setCounter(unread: number) {
+		this.unread = unread
+
+		if(isNaN(this.unread)) {
+			this.unread = 0
+		}
+
+		if(this.unread > 99) {
+			this.unread = 99
+		}
+
+		this.render()
+	}
+
+	render() {
+		Diff.mutations.queue(() => {
+			this.counter.textContent = this.unread.toString()
+			this.counter.classList.toggle("hidden", this.unread === 0)
+		})
+	}
+}
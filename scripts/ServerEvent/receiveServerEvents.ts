@@ -2,12 +2,16 @@ import AnimeNotifier from "../AnimeNotifier"
 import plural from "../Utils/plural"
 import ServerEvent from "./ServerEvent"
 
-const reconnectDelay = 3000
+const minReconnectDelay = 3000
+const maxReconnectDelay = 60000
 
 let supported: boolean
 let eventSource: EventSource
 let arn: AnimeNotifier
 let etags: Map<string, string>
+let reconnectDelay = minReconnectDelay
+let reconnectTimer: number
+let disconnected = false
 
 export default function receiveServerEvents(animeNotifier: AnimeNotifier) {
 	supported = ("EventSource" in window)
@@ -34,9 +38,34 @@ function connect() {
 	eventSource.addEventListener("etag", (e: any) => etag(e))
 	eventSource.addEventListener("activity", (e: any) => activity(e))
 	eventSource.addEventListener("notificationCount", (e: any) => notificationCount(e))
+	eventSource.addEventListener("messageCount", (e: any) => messageCount(e))
+	eventSource.addEventListener("threadPost", (e: any) => threadPost(e))
+
+	eventSource.onopen = () => {
+		reconnectDelay = minReconnectDelay
+
+		// Posts written while we were disconnected
+		if(disconnected) {
+			disconnected = false
+			reloadOpenThread()
+		}
+	}
 
 	eventSource.onerror = () => {
-		setTimeout(() => connect(), reconnectDelay)
+		disconnected = true
+		eventSource.close()
+
+		if(reconnectTimer) {
+			return
+		}
+
+		// Wait a little longer after each failed attempt
+		reconnectTimer = window.setTimeout(() => {
+			reconnectTimer = 0
+			connect()
+		}, reconnectDelay)
+
+		reconnectDelay = Math.min(reconnectDelay * 2, maxReconnectDelay)
 	}
 }
 
@@ -93,3 +122,65 @@ function notificationCount(e: ServerEvent) {
 
 	arn.notificationManager.setCounter(parseInt(e.data, 10))
 }
+
+function messageCount(e: ServerEvent) {
+	if(!arn.messageCounter) {
+		return
+	}
+
+	arn.messageCounter.setCounter(parseInt(e.data, 10))
+}
+
+async function threadPost(e: ServerEvent) {
+	const data = JSON.parse(e.data)
+	const thread = document.getElementById("thread")
+
+	if(!thread || thread.dataset.id !== data.threadId) {
+		return
+	}
+
+	// New posts in the thread are added to the last page, replies to posts are shown on the page of their parent
+	if(data.parentId === data.threadId && thread.dataset.page !== thread.dataset.pageCount) {
+		return
+	}
+
+	const replies = document.getElementById(`replies-${data.parentId}`)
+
+	// Our own posts might already be on the page
+	if(!replies || document.getElementById(`post-${data.postId}`)) {
+		return
+	}
+
+	try {
+		const response = await fetch(`/api/post/${data.postId}/ui`, {
+			credentials: "same-origin"
+		})
+
+		if(!response.ok) {
+			return
+		}
+
+		const html = await response.text()
+
+		if(document.getElementById(`post-${data.postId}`)) {
+			return
+		}
+
+		replies.insertAdjacentHTML("beforeend", html)
+		arn.onNewContent(replies)
+		arn.assignActions()
+	} catch(err) {
+		console.error(err)
+	}
+}
+
+// New posts are added to the last page, so only the last page needs to be reloaded.
+function reloadOpenThread() {
+	const thread = document.getElementById("thread")
+
+	if(!thread || thread.dataset.page !== thread.dataset.pageCount) {
+		return
+	}
+
+	arn.reloadContent()
+}
@@ -3,6 +3,7 @@ package server
 import (
 	"flag"
 	"net/http"
+	"path"
 	"strings"
 
 	"github.com/aerogo/aero"
@@ -15,7 +16,9 @@ import (
 	"github.com/animenotifier/notify.moe/server/graphql"
 	"github.com/animenotifier/notify.moe/server/https"
 	"github.com/animenotifier/notify.moe/server/middleware"
+	"github.com/animenotifier/notify.moe/utils/currency"
 	"github.com/animenotifier/notify.moe/utils/htmlemail"
+	"github.com/animenotifier/notify.moe/utils/i18n"
 	"github.com/animenotifier/notify.moe/utils/routetests"
 )
 
@@ -39,6 +42,13 @@ func New() *aero.Application {
 	// Assets
 	assets.Configure(app)
 
+	// Translations
+	err := i18n.Load(path.Join(arn.Root, "locales"))
+
+	if err != nil {
+		panic(err)
+	}
+
 	// Pages
 	pages.Configure(app)
 
@@ -51,7 +61,9 @@ func New() *aero.Application {
 	} else {
 		app.Use(
 			middleware.Recover,
+			middleware.Metrics,
 			middleware.HTTPSRedirect,
+			middleware.ConditionalRequests,
 			middleware.OpenGraph,
 			middleware.Log,
 			middleware.Session,
@@ -65,6 +77,7 @@ func New() *aero.Application {
 	// Development server configuration
 	if arn.IsDevelopment() {
 		assets.Domain = "beta.notify.moe"
+		arn.Domain = assets.Domain
 		assets.Manifest.Name += " - Beta"
 	}
 
@@ -90,6 +103,11 @@ func New() *aero.Application {
 	// Emails
 	arn.HTMLEmailRenderer = &htmlemail.Renderer{}
 
+	// Exchange rates
+	if !IsTest() {
+		currency.StartRefreshing()
+	}
+
 	// Check that this is the server
 	if !arn.Node.IsServer() && !IsTest() {
 		panic("Another program is currently running as the database server")
@@ -0,0 +1,106 @@
+package auth
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"net/http"
+
+	"github.com/aerogo/aero"
+	"github.com/aerogo/http/client"
+	"github.com/aerogo/log"
+	"github.com/animenotifier/notify.moe/arn"
+	"github.com/animenotifier/notify.moe/assets"
+	"golang.org/x/oauth2"
+)
+
+// AniListViewer is the user data we receive from AniList
+type AniListViewer struct {
+	Data struct {
+		Viewer struct {
+			ID   int    `json:"id"`
+			Name string `json:"name"`
+		} `json:"Viewer"`
+	} `json:"data"`
+}
+
+// aniListStateKey is the session key of the random state of the current AniList authorization.
+const aniListStateKey = "anilistState"
+
+// AniList lets logged in users link their AniList account for anime list synchronization.
+func AniList(app *aero.Application, authLog *log.Log) {
+	config := &oauth2.Config{
+		ClientID:     arn.APIKeys.AniList.ID,
+		ClientSecret: arn.APIKeys.AniList.Secret,
+		RedirectURL:  "https://" + assets.Domain + "/auth/anilist/callback",
+		Endpoint: oauth2.Endpoint{
+			AuthURL:  "https://anilist.co/api/v2/oauth/authorize",
+			TokenURL: "https://anilist.co/api/v2/oauth/token",
+		},
+	}
+
+	// Linking requires an existing account, so we don't allow
+	// logging in or registering via AniList.
+	app.Get("/auth/anilist", func(ctx aero.Context) error {
+		user := arn.GetUserFromContext(ctx)
+
+		if user == nil {
+			return ctx.Error(http.StatusUnauthorized, "Not logged in")
+		}
+
+		state := arn.GenerateSecret()
+		ctx.Session().Set(aniListStateKey, state)
+		url := config.AuthCodeURL(state)
+		return ctx.Redirect(http.StatusTemporaryRedirect, url)
+	})
+
+	// The user has authorized us on AniList servers.
+	// Now we have to check for fraud requests and request the AniList user information.
+	app.Get("/auth/anilist/callback", func(ctx aero.Context) error {
+		user := arn.GetUserFromContext(ctx)
+
+		if user == nil {
+			return ctx.Error(http.StatusUnauthorized, "AniList authorization failed", errors.New("Not logged in"))
+		}
+
+		// Every state can only be used once
+		state := ctx.Session().GetString(aniListStateKey)
+		ctx.Session().Delete(aniListStateKey)
+
+		if state == "" || subtle.ConstantTimeCompare([]byte(state), []byte(ctx.Query("state"))) != 1 {
+			return ctx.Error(http.StatusUnauthorized, "AniList authorization failed", errors.New("Incorrect state"))
+		}
+
+		// Handle the exchange code to initiate a transport
+		token, err := config.Exchange(context.Background(), ctx.Query("code"))
+
+		if err != nil {
+			return ctx.Error(http.StatusBadRequest, "Could not obtain OAuth token", err)
+		}
+
+		// Fetch user data from AniList
+		viewer := AniListViewer{}
+		response, err := client.Post("https://graphql.anilist.co").
+			Header("Authorization", "Bearer "+token.AccessToken).
+			Header("Content-Type", "application/json").
+			Header("Accept", "application/json").
+			BodyJSON(map[string]string{"query": "{ Viewer { id name } }"}).
+			EndStruct(&viewer)
+
+		if err != nil {
+			return ctx.Error(http.StatusBadRequest, "Failed requesting user data from AniList", err)
+		}
+
+		if response.StatusCode() != http.StatusOK || viewer.Data.Viewer.ID == 0 {
+			return ctx.Error(http.StatusBadRequest, "Failed retrieving AniList data", errors.New("Empty ID"))
+		}
+
+		user.Accounts.AniList.ID = viewer.Data.Viewer.ID
+		user.Accounts.AniList.Nick = viewer.Data.Viewer.Name
+		user.Accounts.AniList.AccessToken = token.AccessToken
+		user.Save()
+
+		authLog.Info("Linked AniList account | %s | %s | %s | %s", user.Nick, user.ID, ctx.IP(), viewer.Data.Viewer.Name)
+		return ctx.Redirect(http.StatusTemporaryRedirect, "/import/anilist/sync")
+	})
+}
@@ -126,10 +126,11 @@ func Facebook(app *aero.Application, authLog *log.Log) {
 			user.ConnectFacebook(fbUser.ID)
 
 			user.LastLogin = arn.DateTimeUTC()
+			user.Reactivate()
 			user.Save()
 
 			session.Set("userId", user.ID)
-			return ctx.Redirect(http.StatusTemporaryRedirect, "/")
+			return ctx.Redirect(http.StatusTemporaryRedirect, loginRedirect(ctx))
 		}
 
 		// Try to find an existing user via the associated e-mail address
@@ -139,10 +140,11 @@ func Facebook(app *aero.Application, authLog *log.Log) {
 			authLog.Info("User logged in via Email | %s | %s | %s | %s | %s", user.Nick, user.ID, ctx.IP(), user.Email, user.RealName())
 
 			user.LastLogin = arn.DateTimeUTC()
+			user.Reactivate()
 			user.Save()
 
 			session.Set("userId", user.ID)
-			return ctx.Redirect(http.StatusTemporaryRedirect, "/")
+			return ctx.Redirect(http.StatusTemporaryRedirect, loginRedirect(ctx))
 		}
 
 		// Register new user
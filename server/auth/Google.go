@@ -134,10 +134,11 @@ func Google(app *aero.Application, authLog *log.Log) {
 			authLog.Info("User logged in via Google ID | %s | %s | %s | %s | %s", user.Nick, user.ID, ctx.IP(), user.Email, user.RealName())
 
 			user.LastLogin = arn.DateTimeUTC()
+			user.Reactivate()
 			user.Save()
 
 			session.Set("userId", user.ID)
-			return ctx.Redirect(http.StatusTemporaryRedirect, "/")
+			return ctx.Redirect(http.StatusTemporaryRedirect, loginRedirect(ctx))
 		}
 
 		// Try to find an existing user via the associated e-mail address
@@ -150,10 +151,11 @@ func Google(app *aero.Application, authLog *log.Log) {
 			user.ConnectGoogle(googleUser.Sub)
 
 			user.LastLogin = arn.DateTimeUTC()
+			user.Reactivate()
 			user.Save()
 
 			session.Set("userId", user.ID)
-			return ctx.Redirect(http.StatusTemporaryRedirect, "/")
+			return ctx.Redirect(http.StatusTemporaryRedirect, loginRedirect(ctx))
 		}
 
 		// Register new user
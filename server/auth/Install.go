@@ -2,6 +2,7 @@ package auth
 
 import (
 	"os"
+	"strings"
 
 	"github.com/aerogo/aero"
 	"github.com/aerogo/log"
@@ -9,6 +10,9 @@ import (
 
 const newUserStartRoute = "/welcome"
 
+// loginRedirectKey is the session key for the page that requested the login, e.g. an OAuth consent page.
+const loginRedirectKey = "loginRedirect"
+
 // Install installs all authentication routes in the application.
 func Install(app *aero.Application) {
 	authLog := log.New()
@@ -20,6 +24,23 @@ func Install(app *aero.Application) {
 	Facebook(app, authLog)
 	Twitter(app, authLog)
 
+	// Linked accounts
+	AniList(app, authLog)
+	MyAnimeList(app, authLog)
+
 	// Logout
 	Logout(app, authLog)
 }
+
+// loginRedirect returns the local page the user should see after logging in.
+func loginRedirect(ctx aero.Context) string {
+	session := ctx.Session()
+	target := session.GetString(loginRedirectKey)
+	session.Delete(loginRedirectKey)
+
+	if !strings.HasPrefix(target, "/") || strings.HasPrefix(target, "//") {
+		return "/"
+	}
+
+	return target
+}
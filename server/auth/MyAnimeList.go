@@ -0,0 +1,106 @@
+package auth
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"net/http"
+
+	"github.com/aerogo/aero"
+	"github.com/aerogo/http/client"
+	"github.com/aerogo/log"
+	"github.com/animenotifier/notify.moe/arn"
+	"golang.org/x/oauth2"
+)
+
+// MyAnimeListUser is the user data we receive from MyAnimeList
+type MyAnimeListUser struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// Session keys of the current MyAnimeList authorization
+const (
+	myAnimeListStateKey    = "myanimelistState"
+	myAnimeListVerifierKey = "myanimelistVerifier"
+)
+
+// MyAnimeList lets logged in users link their MyAnimeList account for anime list synchronization.
+func MyAnimeList(app *aero.Application, authLog *log.Log) {
+	config := arn.MyAnimeListOAuth()
+
+	// Linking requires an existing account, so we don't allow
+	// logging in or registering via MyAnimeList.
+	app.Get("/auth/myanimelist", func(ctx aero.Context) error {
+		user := arn.GetUserFromContext(ctx)
+
+		if user == nil {
+			return ctx.Error(http.StatusUnauthorized, "Not logged in")
+		}
+
+		// MyAnimeList requires PKCE and only supports the "plain" method,
+		// therefore the code challenge is the verifier itself.
+		state := arn.GenerateSecret()
+		verifier := arn.GenerateSecret()
+		ctx.Session().Set(myAnimeListStateKey, state)
+		ctx.Session().Set(myAnimeListVerifierKey, verifier)
+
+		url := config.AuthCodeURL(
+			state,
+			oauth2.SetAuthURLParam("code_challenge", verifier),
+			oauth2.SetAuthURLParam("code_challenge_method", "plain"),
+		)
+
+		return ctx.Redirect(http.StatusTemporaryRedirect, url)
+	})
+
+	// The user has authorized us on MyAnimeList servers.
+	// Now we have to check for fraud requests and request the MyAnimeList user information.
+	app.Get("/auth/myanimelist/callback", func(ctx aero.Context) error {
+		user := arn.GetUserFromContext(ctx)
+
+		if user == nil {
+			return ctx.Error(http.StatusUnauthorized, "MyAnimeList authorization failed", errors.New("Not logged in"))
+		}
+
+		// Every state can only be used once
+		state := ctx.Session().GetString(myAnimeListStateKey)
+		verifier := ctx.Session().GetString(myAnimeListVerifierKey)
+		ctx.Session().Delete(myAnimeListStateKey)
+		ctx.Session().Delete(myAnimeListVerifierKey)
+
+		if state == "" || subtle.ConstantTimeCompare([]byte(state), []byte(ctx.Query("state"))) != 1 {
+			return ctx.Error(http.StatusUnauthorized, "MyAnimeList authorization failed", errors.New("Incorrect state"))
+		}
+
+		// Handle the exchange code to initiate a transport
+		token, err := config.Exchange(context.Background(), ctx.Query("code"), oauth2.SetAuthURLParam("code_verifier", verifier))
+
+		if err != nil {
+			return ctx.Error(http.StatusBadRequest, "Could not obtain OAuth token", err)
+		}
+
+		// Fetch user data from MyAnimeList
+		malUser := MyAnimeListUser{}
+		response, err := client.Get("https://api.myanimelist.net/v2/users/@me").
+			Header("Authorization", "Bearer "+token.AccessToken).
+			Header("Accept", "application/json").
+			EndStruct(&malUser)
+
+		if err != nil {
+			return ctx.Error(http.StatusBadRequest, "Failed requesting user data from MyAnimeList", err)
+		}
+
+		if response.StatusCode() != http.StatusOK || malUser.ID == 0 {
+			return ctx.Error(http.StatusBadRequest, "Failed retrieving MyAnimeList data", errors.New("Empty ID"))
+		}
+
+		user.Accounts.MyAnimeList.ID = malUser.ID
+		user.Accounts.MyAnimeList.Nick = malUser.Name
+		user.SetMyAnimeListToken(token)
+		user.Save()
+
+		authLog.Info("Linked MyAnimeList account | %s | %s | %s | %s", user.Nick, user.ID, ctx.IP(), malUser.Name)
+		return ctx.Redirect(http.StatusTemporaryRedirect, "/import/myanimelist/sync")
+	})
+}
@@ -136,10 +136,11 @@ func Twitter(app *aero.Application, authLog *log.Log) {
 			authLog.Info("User logged in via Twitter ID | %s | %s | %s | %s | %s", user.Nick, user.ID, ctx.IP(), user.Email, user.RealName())
 
 			user.LastLogin = arn.DateTimeUTC()
+			user.Reactivate()
 			user.Save()
 
 			session.Set("userId", user.ID)
-			return ctx.Redirect(http.StatusTemporaryRedirect, "/")
+			return ctx.Redirect(http.StatusTemporaryRedirect, loginRedirect(ctx))
 		}
 
 		// Try to find an existing user via the associated e-mail address
@@ -153,10 +154,11 @@ func Twitter(app *aero.Application, authLog *log.Log) {
 
 			user.Accounts.Twitter.Nick = twUser.ScreenName
 			user.LastLogin = arn.DateTimeUTC()
+			user.Reactivate()
 			user.Save()
 
 			session.Set("userId", user.ID)
-			return ctx.Redirect(http.StatusTemporaryRedirect, "/")
+			return ctx.Redirect(http.StatusTemporaryRedirect, loginRedirect(ctx))
 		}
 
 		// Register new user
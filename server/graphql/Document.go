@@ -0,0 +1,88 @@
+package graphql
+
+// Document is a parsed GraphQL request containing operations and fragments.
+type Document struct {
+	Operations []*Operation
+	Fragments  map[string]*Fragment
+}
+
+// Operation is a query, mutation or subscription inside a document.
+type Operation struct {
+	Type       string
+	Name       string
+	Variables  []*VariableDefinition
+	Selections []*Selection
+}
+
+// VariableDefinition declares a variable of an operation, e.g. "$id: ID!".
+type VariableDefinition struct {
+	Name    string
+	Type    string
+	Default Value
+}
+
+// Fragment is a reusable set of selections for a type.
+type Fragment struct {
+	Name          string
+	TypeCondition string
+	Selections    []*Selection
+}
+
+// Selection is a field, a fragment spread ("...Name") or an inline fragment ("... on Type").
+type Selection struct {
+	Alias        string
+	Name         string
+	Arguments    map[string]Value
+	Directives   []*Directive
+	FragmentName string
+	Inline       bool
+	Condition    string
+	Selections   []*Selection
+}
+
+// Directive is an annotation like "@include(if: $details)".
+type Directive struct {
+	Name      string
+	Arguments map[string]Value
+}
+
+// Value is a literal argument value: a string, int, float64, bool, nil,
+// []Value, map[string]Value, an enum name as a string or a Variable.
+type Value = interface{}
+
+// Variable refers to a variable of the operation inside a value.
+type Variable string
+
+// Key returns the name of the field in the response.
+func (selection *Selection) Key() string {
+	if selection.Alias != "" {
+		return selection.Alias
+	}
+
+	return selection.Name
+}
+
+// IsField tells you whether the selection is a field and not a fragment.
+func (selection *Selection) IsField() bool {
+	return selection.FragmentName == "" && !selection.Inline
+}
+
+// Operation returns the operation with the given name.
+// The name can be empty if the document only contains a single operation.
+func (document *Document) Operation(name string) (*Operation, bool) {
+	if name == "" {
+		if len(document.Operations) != 1 {
+			return nil, false
+		}
+
+		return document.Operations[0], true
+	}
+
+	for _, operation := range document.Operations {
+		if operation.Name == name {
+			return operation, true
+		}
+	}
+
+	return nil, false
+}
@@ -0,0 +1,489 @@
+package graphql
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// Request is the body of a GraphQL request.
+type Request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// Response is the result of a GraphQL request.
+// Data is missing if the query couldn't be executed at all.
+type Response struct {
+	Data   interface{} `json:"data,omitempty"`
+	Errors []*Error    `json:"errors,omitempty"`
+}
+
+// Error describes a problem with the query or with resolving a field.
+// The path contains the response keys and list indices leading to the field.
+type Error struct {
+	Message string        `json:"message"`
+	Path    []interface{} `json:"path,omitempty"`
+}
+
+// execution is the state of a single request.
+type execution struct {
+	schema    *Schema
+	document  *Document
+	variables map[string]interface{}
+	errors    []*Error
+
+	// Validation state
+	fragmentComplexity map[fragmentUse]int
+	fragmentSpreads    int
+}
+
+// Execute parses, validates and executes the query of the request.
+func (schema *Schema) Execute(request *Request) *Response {
+	document, err := Parse(request.Query)
+
+	if err != nil {
+		return errorResponse(err)
+	}
+
+	operation, found := document.Operation(request.OperationName)
+
+	if !found {
+		if request.OperationName == "" {
+			return errorResponse(errors.New("Documents with multiple operations require an operation name"))
+		}
+
+		return errorResponse(fmt.Errorf("Operation '%s' does not exist", request.OperationName))
+	}
+
+	if operation.Type != "query" {
+		return errorResponse(fmt.Errorf("Operation type '%s' is not supported, please use the REST API to make changes", operation.Type))
+	}
+
+	exec := &execution{
+		schema:   schema,
+		document: document,
+	}
+
+	exec.variables, err = coerceVariables(operation.Variables, request.Variables)
+
+	if err != nil {
+		return errorResponse(err)
+	}
+
+	err = exec.validate(operation)
+
+	if err != nil {
+		return errorResponse(err)
+	}
+
+	data := exec.executeSelections(schema.Query, nil, operation.Selections, nil)
+
+	return &Response{
+		Data:   data,
+		Errors: exec.errors,
+	}
+}
+
+// executeSelections resolves the selected fields of the object.
+func (exec *execution) executeSelections(object *Object, parent interface{}, selections []*Selection, path []interface{}) *orderedMap {
+	result := &orderedMap{
+		values: map[string]interface{}{},
+	}
+
+	keys, fields := exec.collectFields(selections, nil, map[string][]*Selection{}, map[string]bool{})
+
+	for _, key := range keys {
+		selection := fields[key][0]
+
+		if selection.Name == "__typename" {
+			result.set(key, object.Name)
+			continue
+		}
+
+		// Selections that were merged under the same key share the fields below them
+		var children []*Selection
+
+		for _, merged := range fields[key] {
+			children = append(children, merged.Selections...)
+		}
+
+		field := object.Fields[selection.Name]
+		fieldPath := appendPath(path, key)
+		arguments, _ := exec.arguments(field, selection)
+
+		value, err := field.Resolve(&ResolveParams{
+			Parent:    parent,
+			Arguments: arguments,
+		})
+
+		if err != nil {
+			exec.errors = append(exec.errors, &Error{
+				Message: err.Error(),
+				Path:    fieldPath,
+			})
+
+			result.set(key, nil)
+			continue
+		}
+
+		result.set(key, exec.completeValue(field.Type, value, children, fieldPath))
+	}
+
+	return result
+}
+
+// completeValue converts the resolved value to the JSON representation of the type.
+func (exec *execution) completeValue(typ string, value interface{}, selections []*Selection, path []interface{}) interface{} {
+	if value == nil {
+		return nil
+	}
+
+	reflected := reflect.ValueOf(value)
+
+	switch reflected.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Interface:
+		if reflected.IsNil() {
+			return nil
+		}
+	}
+
+	if isListType(typ) {
+		if reflected.Kind() != reflect.Slice && reflected.Kind() != reflect.Array {
+			exec.errors = append(exec.errors, &Error{
+				Message: fmt.Sprintf("Expected a list but the resolver returned %T", value),
+				Path:    path,
+			})
+
+			return nil
+		}
+
+		items := make([]interface{}, reflected.Len())
+
+		for i := range items {
+			items[i] = exec.completeValue(elementType(typ), reflected.Index(i).Interface(), selections, appendPath(path, i))
+		}
+
+		return items
+	}
+
+	typeName := namedType(typ)
+
+	if scalars[typeName] {
+		return value
+	}
+
+	return exec.executeSelections(exec.schema.Type(typeName), value, selections, path)
+}
+
+// collectFields groups the fields of the selection set by their response key
+// and resolves fragments and directives.
+// Each fragment is only expanded once per selection set because repeated spreads select the same fields.
+func (exec *execution) collectFields(selections []*Selection, keys []string, fields map[string][]*Selection, visited map[string]bool) ([]string, map[string][]*Selection) {
+	for _, selection := range selections {
+		included, _ := exec.included(selection)
+
+		if !included {
+			continue
+		}
+
+		switch {
+		case selection.FragmentName != "":
+			if visited[selection.FragmentName] {
+				continue
+			}
+
+			visited[selection.FragmentName] = true
+			keys, fields = exec.collectFields(exec.document.Fragments[selection.FragmentName].Selections, keys, fields, visited)
+
+		case selection.Inline:
+			keys, fields = exec.collectFields(selection.Selections, keys, fields, visited)
+
+		default:
+			key := selection.Key()
+
+			if fields[key] == nil {
+				keys = append(keys, key)
+			}
+
+			fields[key] = append(fields[key], selection)
+		}
+	}
+
+	return keys, fields
+}
+
+// included evaluates the @include and @skip directives of the selection.
+func (exec *execution) included(selection *Selection) (bool, error) {
+	for _, directive := range selection.Directives {
+		if directive.Name != "include" && directive.Name != "skip" {
+			return false, fmt.Errorf("Unknown directive '@%s'", directive.Name)
+		}
+
+		value, err := exec.resolveValue(directive.Arguments["if"])
+
+		if err != nil {
+			return false, err
+		}
+
+		condition, isBool := value.(bool)
+
+		if !isBool || len(directive.Arguments) != 1 {
+			return false, fmt.Errorf("Directive '@%s' requires a Boolean argument 'if'", directive.Name)
+		}
+
+		if condition == (directive.Name == "skip") {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// arguments returns the coerced arguments of the field.
+func (exec *execution) arguments(field *Field, selection *Selection) (map[string]interface{}, error) {
+	arguments := map[string]interface{}{}
+
+	for name, literal := range selection.Arguments {
+		typ, exists := field.Arguments[name]
+
+		if !exists {
+			return nil, fmt.Errorf("Unknown argument '%s' on field '%s'", name, selection.Name)
+		}
+
+		value, err := exec.resolveValue(literal)
+
+		if err != nil {
+			return nil, err
+		}
+
+		value, err = coerce(value, typ)
+
+		if err != nil {
+			return nil, fmt.Errorf("Argument '%s' on field '%s' %v", name, selection.Name, err)
+		}
+
+		if value != nil {
+			arguments[name] = value
+		}
+	}
+
+	for name, typ := range field.Arguments {
+		if isNonNullType(typ) && arguments[name] == nil {
+			return nil, fmt.Errorf("Field '%s' requires the argument '%s'", selection.Name, name)
+		}
+	}
+
+	return arguments, nil
+}
+
+// resolveValue replaces the variables inside the value.
+func (exec *execution) resolveValue(value Value) (interface{}, error) {
+	switch value := value.(type) {
+	case Variable:
+		resolved, exists := exec.variables[string(value)]
+
+		if !exists {
+			return nil, fmt.Errorf("Variable '$%s' is not defined", value)
+		}
+
+		return resolved, nil
+
+	case []Value:
+		list := make([]interface{}, len(value))
+
+		for i, element := range value {
+			resolved, err := exec.resolveValue(element)
+
+			if err != nil {
+				return nil, err
+			}
+
+			list[i] = resolved
+		}
+
+		return list, nil
+
+	case map[string]Value:
+		object := make(map[string]interface{}, len(value))
+
+		for key, element := range value {
+			resolved, err := exec.resolveValue(element)
+
+			if err != nil {
+				return nil, err
+			}
+
+			object[key] = resolved
+		}
+
+		return object, nil
+
+	default:
+		return value, nil
+	}
+}
+
+// coerceVariables checks the provided variables against their definitions and applies the defaults.
+func coerceVariables(definitions []*VariableDefinition, provided map[string]interface{}) (map[string]interface{}, error) {
+	variables := map[string]interface{}{}
+
+	for _, definition := range definitions {
+		value, exists := provided[definition.Name]
+
+		if !exists {
+			value = definition.Default
+		}
+
+		coerced, err := coerce(value, definition.Type)
+
+		if err != nil {
+			return nil, fmt.Errorf("Variable '$%s' %v", definition.Name, err)
+		}
+
+		variables[definition.Name] = coerced
+	}
+
+	return variables, nil
+}
+
+// coerce converts an input value to the given type.
+// Numbers from JSON variables are float64 and need to be converted for Int arguments.
+func coerce(value interface{}, typ string) (interface{}, error) {
+	if value == nil {
+		if isNonNullType(typ) {
+			return nil, fmt.Errorf("of type '%s' must not be null", typ)
+		}
+
+		return nil, nil
+	}
+
+	if isListType(typ) {
+		list, isList := value.([]interface{})
+
+		if !isList {
+			list = []interface{}{value}
+		}
+
+		coerced := make([]interface{}, len(list))
+
+		for i, element := range list {
+			var err error
+			coerced[i], err = coerce(element, elementType(typ))
+
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return coerced, nil
+	}
+
+	switch namedType(typ) {
+	case "Int":
+		switch number := value.(type) {
+		case int:
+			return number, nil
+		case float64:
+			if number == float64(int(number)) {
+				return int(number), nil
+			}
+		}
+
+	case "Float":
+		switch number := value.(type) {
+		case int:
+			return float64(number), nil
+		case float64:
+			return number, nil
+		}
+
+	case "String":
+		if text, isString := value.(string); isString {
+			return text, nil
+		}
+
+	case "ID":
+		switch id := value.(type) {
+		case string:
+			return id, nil
+		case int:
+			return fmt.Sprint(id), nil
+		}
+
+	case "Boolean":
+		if boolean, isBool := value.(bool); isBool {
+			return boolean, nil
+		}
+
+	case "JSON":
+		return value, nil
+
+	default:
+		return nil, fmt.Errorf("has the unsupported input type '%s'", typ)
+	}
+
+	return nil, fmt.Errorf("expected a value of type '%s'", typ)
+}
+
+// errorResponse returns a response for requests that couldn't be executed.
+func errorResponse(err error) *Response {
+	return &Response{
+		Errors: []*Error{
+			{
+				Message: err.Error(),
+			},
+		},
+	}
+}
+
+// appendPath returns a copy of the path with the element added.
+func appendPath(path []interface{}, element interface{}) []interface{} {
+	newPath := make([]interface{}, len(path), len(path)+1)
+	copy(newPath, path)
+	return append(newPath, element)
+}
+
+// orderedMap is a JSON object that keeps the fields in the order they were requested.
+type orderedMap struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+// set adds the field to the object.
+func (object *orderedMap) set(key string, value interface{}) {
+	if _, exists := object.values[key]; !exists {
+		object.keys = append(object.keys, key)
+	}
+
+	object.values[key] = value
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (object *orderedMap) MarshalJSON() ([]byte, error) {
+	buffer := bytes.Buffer{}
+	buffer.WriteByte('{')
+
+	for index, key := range object.keys {
+		if index > 0 {
+			buffer.WriteByte(',')
+		}
+
+		keyJSON, _ := json.Marshal(key)
+		valueJSON, err := json.Marshal(object.values[key])
+
+		if err != nil {
+			return nil, err
+		}
+
+		buffer.Write(keyJSON)
+		buffer.WriteByte(':')
+		buffer.Write(valueJSON)
+	}
+
+	buffer.WriteByte('}')
+	return buffer.Bytes(), nil
+}
@@ -0,0 +1,453 @@
+package graphql
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/animenotifier/notify.moe/arn"
+	"github.com/animenotifier/notify.moe/arn/search"
+)
+
+// Pagination limits, the same as in the REST API
+const (
+	defaultLimit = 20
+	maxLimit     = 100
+)
+
+// maxSearchResults is the maximum number of search results that can be paginated.
+const maxSearchResults = 500
+
+// characterAnimeComplexity is the cost of finding the anime of a character,
+// which needs to look at the characters of every anime.
+const characterAnimeComplexity = 20
+
+// newSchema creates the schema of the main notify.moe models.
+func newSchema() *Schema {
+	query := NewObject("Query", "The root of all queries.")
+	schema := NewSchema(query)
+
+	anime := schema.Reflect(arn.Anime{}, "Anime represents an anime.")
+	episode := schema.Reflect(arn.Episode{}, "Episode represents a single episode for an anime.")
+	thread := schema.Reflect(arn.Thread{}, "Thread is a forum thread.")
+	post := schema.Reflect(arn.Post{}, "Post is a comment related to any parent type in the database.")
+	user := schema.Reflect(arn.User{}, "User is a registered person.")
+	character := schema.Reflect(arn.Character{}, "Character represents an anime or manga character.")
+	animeCharacter := schema.Reflect(arn.AnimeCharacter{}, "AnimeCharacter contains the information for a character and their role in an anime.")
+	animeListItem := schema.Reflect(arn.AnimeListItem{}, "AnimeListItem represents a single item in an anime list.")
+	schema.Reflect(arn.Company{}, "Company represents an anime studio, producer or licensor.")
+
+	// Query
+	query.Fields["anime"] = &Field{
+		Type:        "Anime",
+		Description: "Anime by ID.",
+		Arguments:   map[string]string{"id": "ID!"},
+		Resolve: func(params *ResolveParams) (interface{}, error) {
+			anime, err := arn.GetAnime(params.Arguments["id"].(string))
+
+			if err != nil || anime.IsDraft {
+				return nil, errors.New("Anime not found")
+			}
+
+			return anime, nil
+		},
+	}
+
+	query.Fields["searchAnime"] = &Field{
+		Type:        "[Anime]",
+		Description: "Anime search, ordered by relevance.",
+		Arguments:   withPagination(map[string]string{"term": "String!"}),
+		Complexity:  paginatedComplexity(0),
+		Resolve: func(params *ResolveParams) (interface{}, error) {
+			results := search.Anime(params.Arguments["term"].(string), maxSearchResults)
+			start, end, err := pageBounds(len(results), params.Arguments)
+
+			if err != nil {
+				return nil, err
+			}
+
+			return results[start:end], nil
+		},
+	}
+
+	query.Fields["episode"] = &Field{
+		Type:        "Episode",
+		Description: "Episode by ID.",
+		Arguments:   map[string]string{"id": "ID!"},
+		Resolve: func(params *ResolveParams) (interface{}, error) {
+			episode, err := arn.GetEpisode(params.Arguments["id"].(string))
+
+			if err != nil {
+				return nil, errors.New("Episode not found")
+			}
+
+			return episode, nil
+		},
+	}
+
+	query.Fields["thread"] = &Field{
+		Type:        "Thread",
+		Description: "Thread by ID.",
+		Arguments:   map[string]string{"id": "ID!"},
+		Resolve: func(params *ResolveParams) (interface{}, error) {
+			thread, err := arn.GetThread(params.Arguments["id"].(string))
+
+			if err != nil || thread.IsSoftDeleted() {
+				return nil, errors.New("Thread not found")
+			}
+
+			return thread, nil
+		},
+	}
+
+	query.Fields["post"] = &Field{
+		Type:        "Post",
+		Description: "Post by ID.",
+		Arguments:   map[string]string{"id": "ID!"},
+		Resolve: func(params *ResolveParams) (interface{}, error) {
+			post, err := arn.GetPost(params.Arguments["id"].(string))
+
+			if err != nil || post.IsSoftDeleted() {
+				return nil, errors.New("Post not found")
+			}
+
+			return post, nil
+		},
+	}
+
+	query.Fields["user"] = &Field{
+		Type:        "User",
+		Description: "User by nickname.",
+		Arguments:   map[string]string{"nick": "String!"},
+		Resolve: func(params *ResolveParams) (interface{}, error) {
+			user, err := arn.GetVisibleUserByNick(params.Arguments["nick"].(string))
+
+			if err != nil {
+				return nil, errors.New("User not found")
+			}
+
+			return user, nil
+		},
+	}
+
+	query.Fields["character"] = &Field{
+		Type:        "Character",
+		Description: "Character by ID.",
+		Arguments:   map[string]string{"id": "ID!"},
+		Resolve: func(params *ResolveParams) (interface{}, error) {
+			character, err := arn.GetCharacter(params.Arguments["id"].(string))
+
+			if err != nil || character.IsDraft {
+				return nil, errors.New("Character not found")
+			}
+
+			return character, nil
+		},
+	}
+
+	// Anime
+	anime.Fields["episodes"] = &Field{
+		Type:        "[Episode]",
+		Description: "Episodes sorted by number.",
+		Arguments:   withPagination(nil),
+		Complexity:  paginatedComplexity(0),
+		Resolve: func(params *ResolveParams) (interface{}, error) {
+			episodes := params.Parent.(*arn.Anime).Episodes()
+			start, end, err := pageBounds(len(episodes), params.Arguments)
+
+			if err != nil {
+				return nil, err
+			}
+
+			return episodes[start:end], nil
+		},
+	}
+
+	anime.Fields["characters"] = &Field{
+		Type:        "[AnimeCharacter]",
+		Description: "Characters with their role in the anime.",
+		Arguments:   withPagination(nil),
+		Complexity:  paginatedComplexity(0),
+		Resolve: func(params *ResolveParams) (interface{}, error) {
+			characters := params.Parent.(*arn.Anime).Characters()
+
+			if characters == nil {
+				return nil, nil
+			}
+
+			start, end, err := pageBounds(len(characters.Items), params.Arguments)
+
+			if err != nil {
+				return nil, err
+			}
+
+			return characters.Items[start:end], nil
+		},
+	}
+
+	anime.Fields["studios"] = companiesField("Studios", (*arn.Anime).Studios)
+	anime.Fields["producers"] = companiesField("Producers", (*arn.Anime).Producers)
+	anime.Fields["licensors"] = companiesField("Licensors", (*arn.Anime).Licensors)
+
+	// Episode
+	episode.Fields["anime"] = &Field{
+		Type:        "Anime",
+		Description: "The anime the episode belongs to.",
+		Resolve: func(params *ResolveParams) (interface{}, error) {
+			return params.Parent.(*arn.Episode).Anime(), nil
+		},
+	}
+
+	episode.Fields["thread"] = &Field{
+		Type:        "Thread",
+		Description: "The discussion thread of the episode.",
+		Resolve: func(params *ResolveParams) (interface{}, error) {
+			thread := params.Parent.(*arn.Episode).DiscussionThread()
+
+			if thread == nil || thread.IsSoftDeleted() {
+				return nil, nil
+			}
+
+			return thread, nil
+		},
+	}
+
+	// Thread
+	thread.Fields["posts"] = postsField("Replies in chronological order.", func(parent interface{}) []arn.PostID {
+		return parent.(*arn.Thread).PostIDs
+	})
+
+	thread.Fields["creator"] = creatorField("The author of the thread.", func(parent interface{}) *arn.User {
+		return parent.(*arn.Thread).Creator()
+	})
+
+	thread.Fields["html"] = &Field{
+		Type:        "String",
+		Description: "The text rendered as HTML.",
+		Resolve: func(params *ResolveParams) (interface{}, error) {
+			return params.Parent.(*arn.Thread).HTML(), nil
+		},
+	}
+
+	// Post
+	post.Fields["posts"] = postsField("Replies to the post in chronological order.", func(parent interface{}) []arn.PostID {
+		return parent.(*arn.Post).PostIDs
+	})
+
+	post.Fields["creator"] = creatorField("The author of the post.", func(parent interface{}) *arn.User {
+		return parent.(*arn.Post).Creator()
+	})
+
+	post.Fields["html"] = &Field{
+		Type:        "String",
+		Description: "The text rendered as HTML.",
+		Resolve: func(params *ResolveParams) (interface{}, error) {
+			return params.Parent.(*arn.Post).HTML(), nil
+		},
+	}
+
+	// User
+	user.Fields["animeList"] = &Field{
+		Type:        "[AnimeListItem]",
+		Description: "Public anime list entries, optionally filtered by status.",
+		Arguments:   withPagination(map[string]string{"status": "String"}),
+		Complexity:  paginatedComplexity(0),
+		Resolve: func(params *ResolveParams) (interface{}, error) {
+			animeList := params.Parent.(*arn.User).AnimeList()
+
+			if animeList == nil {
+				return nil, nil
+			}
+
+			status, _ := params.Arguments["status"].(string)
+			items := []*arn.AnimeListItem{}
+
+			for _, item := range animeList.Items {
+				if item.Private || (status != "" && item.Status != status) {
+					continue
+				}
+
+				items = append(items, item)
+			}
+
+			start, end, err := pageBounds(len(items), params.Arguments)
+
+			if err != nil {
+				return nil, err
+			}
+
+			return items[start:end], nil
+		},
+	}
+
+	// Anime list item
+	animeListItem.Fields["anime"] = &Field{
+		Type:        "Anime",
+		Description: "The anime of the entry.",
+		Resolve: func(params *ResolveParams) (interface{}, error) {
+			return params.Parent.(*arn.AnimeListItem).Anime(), nil
+		},
+	}
+
+	// Anime character
+	animeCharacter.Fields["character"] = &Field{
+		Type:        "Character",
+		Description: "The referenced character.",
+		Resolve: func(params *ResolveParams) (interface{}, error) {
+			character := params.Parent.(*arn.AnimeCharacter).Character()
+
+			if character == nil || character.IsDraft {
+				return nil, nil
+			}
+
+			return character, nil
+		},
+	}
+
+	// Character
+	character.Fields["anime"] = &Field{
+		Type:        "[Anime]",
+		Description: "The anime the character appears in.",
+		Arguments:   withPagination(nil),
+		Complexity:  paginatedComplexity(characterAnimeComplexity),
+		Resolve: func(params *ResolveParams) (interface{}, error) {
+			results := params.Parent.(*arn.Character).Anime()
+			start, end, err := pageBounds(len(results), params.Arguments)
+
+			if err != nil {
+				return nil, err
+			}
+
+			return results[start:end], nil
+		},
+	}
+
+	err := schema.Validate()
+
+	if err != nil {
+		panic(err)
+	}
+
+	return schema
+}
+
+// companiesField returns a field for one of the company lists of an anime.
+func companiesField(description string, companies func(*arn.Anime) []*arn.Company) *Field {
+	return &Field{
+		Type:        "[Company]",
+		Description: description + ".",
+		Resolve: func(params *ResolveParams) (interface{}, error) {
+			return companies(params.Parent.(*arn.Anime)), nil
+		},
+	}
+}
+
+// postsField returns a paginated field for the replies of a thread or post.
+// Soft deleted posts are left out.
+func postsField(description string, postIDs func(parent interface{}) []arn.PostID) *Field {
+	return &Field{
+		Type:        "[Post]",
+		Description: description,
+		Arguments:   withPagination(nil),
+		Complexity:  paginatedComplexity(0),
+		Resolve: func(params *ResolveParams) (interface{}, error) {
+			ids := postIDs(params.Parent)
+			start, end, err := pageBounds(len(ids), params.Arguments)
+
+			if err != nil {
+				return nil, err
+			}
+
+			posts := []*arn.Post{}
+
+			for _, obj := range arn.DB.GetMany("Post", ids[start:end]) {
+				if obj == nil || obj.(*arn.Post).IsSoftDeleted() {
+					continue
+				}
+
+				posts = append(posts, obj.(*arn.Post))
+			}
+
+			return posts, nil
+		},
+	}
+}
+
+// creatorField returns a field for the author of an object.
+func creatorField(description string, creator func(parent interface{}) *arn.User) *Field {
+	return &Field{
+		Type:        "User",
+		Description: description,
+		Resolve: func(params *ResolveParams) (interface{}, error) {
+			return creator(params.Parent), nil
+		},
+	}
+}
+
+// withPagination adds the "limit" and "offset" arguments.
+func withPagination(arguments map[string]string) map[string]string {
+	if arguments == nil {
+		arguments = map[string]string{}
+	}
+
+	arguments["limit"] = "Int"
+	arguments["offset"] = "Int"
+	return arguments
+}
+
+// pageBounds returns the slice bounds of the requested page for a list of the given length.
+func pageBounds(length int, arguments map[string]interface{}) (int, int, error) {
+	limit, offset, err := pagination(arguments)
+
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if offset > length {
+		offset = length
+	}
+
+	end := offset + limit
+
+	if end > length {
+		end = length
+	}
+
+	return offset, end, nil
+}
+
+// pagination reads the "limit" and "offset" arguments.
+func pagination(arguments map[string]interface{}) (limit int, offset int, err error) {
+	limit = defaultLimit
+
+	if value, exists := arguments["limit"]; exists {
+		limit = value.(int)
+
+		if limit < 1 || limit > maxLimit {
+			return 0, 0, fmt.Errorf("Limit must be a number between 1 and %d", maxLimit)
+		}
+	}
+
+	if value, exists := arguments["offset"]; exists {
+		offset = value.(int)
+
+		if offset < 0 {
+			return 0, 0, errors.New("Offset must be a positive number")
+		}
+	}
+
+	return limit, offset, nil
+}
+
+// paginatedComplexity returns the complexity of a list field with a base cost
+// where each of the requested objects costs 1 plus its children.
+func paginatedComplexity(base int) func(arguments map[string]interface{}, childComplexity int) int {
+	return func(arguments map[string]interface{}, childComplexity int) int {
+		limit, _, err := pagination(arguments)
+
+		if err != nil {
+			limit = maxLimit
+		}
+
+		return base + limit*(1+childComplexity)
+	}
+}
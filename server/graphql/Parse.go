@@ -0,0 +1,794 @@
+package graphql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// tokenKind is the kind of a lexical token.
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenPunctuator
+	tokenName
+	tokenInt
+	tokenFloat
+	tokenString
+)
+
+// token is a lexical token of a GraphQL document.
+type token struct {
+	kind     tokenKind
+	value    string
+	position int
+}
+
+// parser is a recursive descent parser for executable GraphQL documents.
+type parser struct {
+	input    string
+	position int
+	current  token
+}
+
+// Parse parses a GraphQL document containing operations and fragments.
+func Parse(query string) (*Document, error) {
+	p := &parser{input: query}
+	err := p.advance()
+
+	if err != nil {
+		return nil, err
+	}
+
+	document := &Document{
+		Fragments: map[string]*Fragment{},
+	}
+
+	for p.current.kind != tokenEOF {
+		switch {
+		case p.peek("{"):
+			selections, err := p.parseSelectionSet()
+
+			if err != nil {
+				return nil, err
+			}
+
+			document.Operations = append(document.Operations, &Operation{
+				Type:       "query",
+				Selections: selections,
+			})
+
+		case p.peekName("query"), p.peekName("mutation"), p.peekName("subscription"):
+			operation, err := p.parseOperation()
+
+			if err != nil {
+				return nil, err
+			}
+
+			document.Operations = append(document.Operations, operation)
+
+		case p.peekName("fragment"):
+			fragment, err := p.parseFragment()
+
+			if err != nil {
+				return nil, err
+			}
+
+			if document.Fragments[fragment.Name] != nil {
+				return nil, fmt.Errorf("Fragment '%s' is defined more than once", fragment.Name)
+			}
+
+			document.Fragments[fragment.Name] = fragment
+
+		default:
+			return nil, p.unexpected()
+		}
+	}
+
+	if len(document.Operations) == 0 {
+		return nil, fmt.Errorf("Document does not contain any operations")
+	}
+
+	return document, nil
+}
+
+// parseOperation parses "query Name($variable: Type) @directive { ... }".
+func (p *parser) parseOperation() (*Operation, error) {
+	operation := &Operation{
+		Type: p.current.value,
+	}
+
+	err := p.advance()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if p.current.kind == tokenName {
+		operation.Name = p.current.value
+
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+	}
+
+	if p.peek("(") {
+		operation.Variables, err = p.parseVariableDefinitions()
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := p.parseDirectives(); err != nil {
+		return nil, err
+	}
+
+	operation.Selections, err = p.parseSelectionSet()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return operation, nil
+}
+
+// parseVariableDefinitions parses "($id: ID!, $limit: Int = 20)".
+func (p *parser) parseVariableDefinitions() ([]*VariableDefinition, error) {
+	var definitions []*VariableDefinition
+
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+
+	for !p.peek(")") {
+		if err := p.expect("$"); err != nil {
+			return nil, err
+		}
+
+		name, err := p.parseName()
+
+		if err != nil {
+			return nil, err
+		}
+
+		if err := p.expect(":"); err != nil {
+			return nil, err
+		}
+
+		typ, err := p.parseType()
+
+		if err != nil {
+			return nil, err
+		}
+
+		definition := &VariableDefinition{
+			Name: name,
+			Type: typ,
+		}
+
+		if p.peek("=") {
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+
+			definition.Default, err = p.parseValue(true)
+
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		definitions = append(definitions, definition)
+	}
+
+	return definitions, p.expect(")")
+}
+
+// parseType parses a type reference like "Int", "[ID]" or "String!".
+func (p *parser) parseType() (string, error) {
+	var typ string
+
+	if p.peek("[") {
+		if err := p.advance(); err != nil {
+			return "", err
+		}
+
+		elementType, err := p.parseType()
+
+		if err != nil {
+			return "", err
+		}
+
+		if err := p.expect("]"); err != nil {
+			return "", err
+		}
+
+		typ = "[" + elementType + "]"
+	} else {
+		name, err := p.parseName()
+
+		if err != nil {
+			return "", err
+		}
+
+		typ = name
+	}
+
+	if p.peek("!") {
+		if err := p.advance(); err != nil {
+			return "", err
+		}
+
+		typ += "!"
+	}
+
+	return typ, nil
+}
+
+// parseFragment parses "fragment Name on Type { ... }".
+func (p *parser) parseFragment() (*Fragment, error) {
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	name, err := p.parseName()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if name == "on" {
+		return nil, fmt.Errorf("Fragment name 'on' is not allowed")
+	}
+
+	if !p.peekName("on") {
+		return nil, p.unexpected()
+	}
+
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	condition, err := p.parseName()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := p.parseDirectives(); err != nil {
+		return nil, err
+	}
+
+	selections, err := p.parseSelectionSet()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &Fragment{
+		Name:          name,
+		TypeCondition: condition,
+		Selections:    selections,
+	}, nil
+}
+
+// parseSelectionSet parses "{ field, ...Fragment, ... on Type { field } }".
+func (p *parser) parseSelectionSet() ([]*Selection, error) {
+	var selections []*Selection
+
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+
+	for !p.peek("}") {
+		selection, err := p.parseSelection()
+
+		if err != nil {
+			return nil, err
+		}
+
+		selections = append(selections, selection)
+	}
+
+	if len(selections) == 0 {
+		return nil, fmt.Errorf("Selection set at position %d is empty", p.current.position)
+	}
+
+	return selections, p.expect("}")
+}
+
+// parseSelection parses a single field or fragment.
+func (p *parser) parseSelection() (*Selection, error) {
+	var err error
+	selection := &Selection{}
+
+	if p.peek("...") {
+		if err = p.advance(); err != nil {
+			return nil, err
+		}
+
+		switch {
+		case p.peekName("on"):
+			if err = p.advance(); err != nil {
+				return nil, err
+			}
+
+			selection.Inline = true
+			selection.Condition, err = p.parseName()
+
+		case p.current.kind == tokenName:
+			selection.FragmentName, err = p.parseName()
+
+		default:
+			selection.Inline = true
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		selection.Directives, err = p.parseDirectives()
+
+		if err != nil {
+			return nil, err
+		}
+
+		if selection.Inline {
+			selection.Selections, err = p.parseSelectionSet()
+		}
+
+		return selection, err
+	}
+
+	selection.Name, err = p.parseName()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if p.peek(":") {
+		if err = p.advance(); err != nil {
+			return nil, err
+		}
+
+		selection.Alias = selection.Name
+		selection.Name, err = p.parseName()
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if p.peek("(") {
+		selection.Arguments, err = p.parseArguments(false)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	selection.Directives, err = p.parseDirectives()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if p.peek("{") {
+		selection.Selections, err = p.parseSelectionSet()
+	}
+
+	return selection, err
+}
+
+// parseDirectives parses a list of directives like "@skip(if: true)".
+func (p *parser) parseDirectives() ([]*Directive, error) {
+	var directives []*Directive
+
+	for p.peek("@") {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+
+		name, err := p.parseName()
+
+		if err != nil {
+			return nil, err
+		}
+
+		directive := &Directive{
+			Name: name,
+		}
+
+		if p.peek("(") {
+			directive.Arguments, err = p.parseArguments(false)
+
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		directives = append(directives, directive)
+	}
+
+	return directives, nil
+}
+
+// parseArguments parses "(name: value, ...)".
+func (p *parser) parseArguments(constant bool) (map[string]Value, error) {
+	arguments := map[string]Value{}
+
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+
+	for !p.peek(")") {
+		name, err := p.parseName()
+
+		if err != nil {
+			return nil, err
+		}
+
+		if err := p.expect(":"); err != nil {
+			return nil, err
+		}
+
+		if _, exists := arguments[name]; exists {
+			return nil, fmt.Errorf("Argument '%s' is specified more than once", name)
+		}
+
+		arguments[name], err = p.parseValue(constant)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return arguments, p.expect(")")
+}
+
+// parseValue parses a literal value.
+// Variables are not allowed in constant values like the defaults of variables.
+func (p *parser) parseValue(constant bool) (Value, error) {
+	current := p.current
+
+	switch current.kind {
+	case tokenInt:
+		value, err := strconv.Atoi(current.value)
+
+		if err != nil {
+			return nil, fmt.Errorf("Invalid integer '%s'", current.value)
+		}
+
+		return value, p.advance()
+
+	case tokenFloat:
+		value, err := strconv.ParseFloat(current.value, 64)
+
+		if err != nil {
+			return nil, fmt.Errorf("Invalid float '%s'", current.value)
+		}
+
+		return value, p.advance()
+
+	case tokenString:
+		return current.value, p.advance()
+
+	case tokenName:
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+
+		switch current.value {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		case "null":
+			return nil, nil
+		default:
+			// Enum values are passed as strings
+			return current.value, nil
+		}
+	}
+
+	switch {
+	case p.peek("$") && !constant:
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+
+		name, err := p.parseName()
+		return Variable(name), err
+
+	case p.peek("["):
+		list := []Value{}
+
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+
+		for !p.peek("]") {
+			value, err := p.parseValue(constant)
+
+			if err != nil {
+				return nil, err
+			}
+
+			list = append(list, value)
+		}
+
+		return list, p.expect("]")
+
+	case p.peek("{"):
+		object := map[string]Value{}
+
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+
+		for !p.peek("}") {
+			name, err := p.parseName()
+
+			if err != nil {
+				return nil, err
+			}
+
+			if err := p.expect(":"); err != nil {
+				return nil, err
+			}
+
+			object[name], err = p.parseValue(constant)
+
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return object, p.expect("}")
+	}
+
+	return nil, p.unexpected()
+}
+
+// parseName returns the current name token and advances.
+func (p *parser) parseName() (string, error) {
+	if p.current.kind != tokenName {
+		return "", p.unexpected()
+	}
+
+	name := p.current.value
+	return name, p.advance()
+}
+
+// expect advances if the current token is the given punctuator and returns an error otherwise.
+func (p *parser) expect(punctuator string) error {
+	if !p.peek(punctuator) {
+		return p.unexpected()
+	}
+
+	return p.advance()
+}
+
+// peek tells you whether the current token is the given punctuator.
+func (p *parser) peek(punctuator string) bool {
+	return p.current.kind == tokenPunctuator && p.current.value == punctuator
+}
+
+// peekName tells you whether the current token is the given name.
+func (p *parser) peekName(name string) bool {
+	return p.current.kind == tokenName && p.current.value == name
+}
+
+// unexpected returns a syntax error for the current token.
+func (p *parser) unexpected() error {
+	if p.current.kind == tokenEOF {
+		return fmt.Errorf("Syntax error: Unexpected end of document")
+	}
+
+	return fmt.Errorf("Syntax error: Unexpected '%s' at position %d", p.current.value, p.current.position)
+}
+
+// advance reads the next token.
+// Whitespace, commas and comments are ignored.
+func (p *parser) advance() error {
+	input := p.input
+
+	for p.position < len(input) {
+		char := input[p.position]
+
+		if char == '#' {
+			for p.position < len(input) && input[p.position] != '\n' && input[p.position] != '\r' {
+				p.position++
+			}
+
+			continue
+		}
+
+		if char != ' ' && char != '\t' && char != '\n' && char != '\r' && char != ',' {
+			break
+		}
+
+		p.position++
+	}
+
+	start := p.position
+
+	if start >= len(input) {
+		p.current = token{kind: tokenEOF, position: start}
+		return nil
+	}
+
+	char := input[start]
+
+	switch {
+	case strings.HasPrefix(input[start:], "..."):
+		p.position += 3
+		p.current = token{kind: tokenPunctuator, value: "...", position: start}
+
+	case strings.IndexByte("!$()[]{}:=@|&", char) != -1:
+		p.position++
+		p.current = token{kind: tokenPunctuator, value: string(char), position: start}
+
+	case char == '_' || isLetter(char):
+		for p.position < len(input) && (input[p.position] == '_' || isLetter(input[p.position]) || isDigit(input[p.position])) {
+			p.position++
+		}
+
+		p.current = token{kind: tokenName, value: input[start:p.position], position: start}
+
+	case char == '-' || isDigit(char):
+		return p.readNumber()
+
+	case char == '"':
+		return p.readString()
+
+	default:
+		character, _ := utf8.DecodeRuneInString(input[start:])
+		return fmt.Errorf("Syntax error: Unexpected character '%c' at position %d", character, start)
+	}
+
+	return nil
+}
+
+// readNumber reads an int or float token.
+func (p *parser) readNumber() error {
+	input := p.input
+	start := p.position
+	kind := tokenInt
+
+	if input[p.position] == '-' {
+		p.position++
+	}
+
+	digits := func() {
+		for p.position < len(input) && isDigit(input[p.position]) {
+			p.position++
+		}
+	}
+
+	digits()
+
+	if p.position < len(input) && input[p.position] == '.' {
+		kind = tokenFloat
+		p.position++
+		digits()
+	}
+
+	if p.position < len(input) && (input[p.position] == 'e' || input[p.position] == 'E') {
+		kind = tokenFloat
+		p.position++
+
+		if p.position < len(input) && (input[p.position] == '+' || input[p.position] == '-') {
+			p.position++
+		}
+
+		digits()
+	}
+
+	p.current = token{kind: kind, value: input[start:p.position], position: start}
+	return nil
+}
+
+// readString reads a quoted string or a block string (""") and resolves escape sequences.
+func (p *parser) readString() error {
+	input := p.input
+	start := p.position
+
+	if strings.HasPrefix(input[start:], `"""`) {
+		end := strings.Index(input[start+3:], `"""`)
+
+		for end != -1 && input[start+3+end-1] == '\\' {
+			next := strings.Index(input[start+3+end+3:], `"""`)
+
+			if next == -1 {
+				end = -1
+				break
+			}
+
+			end += 3 + next
+		}
+
+		if end == -1 {
+			return fmt.Errorf("Syntax error: Unterminated string at position %d", start)
+		}
+
+		value := strings.ReplaceAll(input[start+3:start+3+end], `\"""`, `"""`)
+		p.position = start + 3 + end + 3
+		p.current = token{kind: tokenString, value: value, position: start}
+		return nil
+	}
+
+	value := strings.Builder{}
+	p.position++
+
+	for p.position < len(input) {
+		char := input[p.position]
+
+		switch char {
+		case '"':
+			p.position++
+			p.current = token{kind: tokenString, value: value.String(), position: start}
+			return nil
+
+		case '\n', '\r':
+			return fmt.Errorf("Syntax error: Unterminated string at position %d", start)
+
+		case '\\':
+			if p.position+1 >= len(input) {
+				return fmt.Errorf("Syntax error: Unterminated string at position %d", start)
+			}
+
+			escaped := input[p.position+1]
+			p.position += 2
+
+			switch escaped {
+			case '"', '\\', '/':
+				value.WriteByte(escaped)
+			case 'b':
+				value.WriteByte('\b')
+			case 'f':
+				value.WriteByte('\f')
+			case 'n':
+				value.WriteByte('\n')
+			case 'r':
+				value.WriteByte('\r')
+			case 't':
+				value.WriteByte('\t')
+			case 'u':
+				if p.position+4 > len(input) {
+					return fmt.Errorf("Syntax error: Invalid unicode escape at position %d", p.position)
+				}
+
+				code, err := strconv.ParseUint(input[p.position:p.position+4], 16, 32)
+
+				if err != nil {
+					return fmt.Errorf("Syntax error: Invalid unicode escape at position %d", p.position)
+				}
+
+				value.WriteRune(rune(code))
+				p.position += 4
+			default:
+				return fmt.Errorf("Syntax error: Invalid escape sequence '\\%c' at position %d", escaped, p.position-2)
+			}
+
+		default:
+			value.WriteByte(char)
+			p.position++
+		}
+	}
+
+	return fmt.Errorf("Syntax error: Unterminated string at position %d", start)
+}
+
+// isLetter tells you whether the character is an ASCII letter.
+func isLetter(char byte) bool {
+	return (char >= 'a' && char <= 'z') || (char >= 'A' && char <= 'Z')
+}
+
+// isDigit tells you whether the character is an ASCII digit.
+func isDigit(char byte) bool {
+	return char >= '0' && char <= '9'
+}
@@ -0,0 +1,133 @@
+package graphql
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Reflect creates an object type with a field for each JSON field of the given struct.
+// Nested structs become separate object types named like their Go type.
+// Fields tagged with `private:"true"` are not exposed.
+// Relations to other objects can be added as custom fields afterwards.
+func (schema *Schema) Reflect(value interface{}, description string) *Object {
+	object, _ := schema.reflectObject(reflect.TypeOf(value))
+	object.Description = description
+	return object
+}
+
+// reflectObject returns the object type for the struct type and creates it if needed.
+func (schema *Schema) reflectObject(typ reflect.Type) (*Object, bool) {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	if object := schema.types[typ.Name()]; object != nil {
+		return object, true
+	}
+
+	// Register the type first so that recursive types refer to it
+	object := NewObject(typ.Name(), "")
+	schema.Add(object)
+	schema.reflectFields(object, typ, nil)
+
+	// Object types need at least one field
+	if len(object.Fields) == 0 {
+		delete(schema.types, object.Name)
+		return nil, false
+	}
+
+	return object, true
+}
+
+// reflectFields adds the JSON fields of the struct type to the object.
+// The fields of embedded structs are added as if they were direct descendants.
+func (schema *Schema) reflectFields(object *Object, typ reflect.Type, parentIndex []int) {
+	for i := 0; i < typ.NumField(); i++ {
+		structField := typ.Field(i)
+		index := append(append([]int{}, parentIndex...), i)
+		name := strings.Split(structField.Tag.Get("json"), ",")[0]
+
+		if structField.Anonymous && name == "" && structField.Type.Kind() == reflect.Struct {
+			schema.reflectFields(object, structField.Type, index)
+			continue
+		}
+
+		if structField.PkgPath != "" || name == "" || name == "-" || structField.Tag.Get("private") == "true" {
+			continue
+		}
+
+		typeName, ok := schema.reflectTypeReference(structField.Type)
+
+		if !ok {
+			continue
+		}
+
+		if name == "id" && typeName == "String" {
+			typeName = "ID"
+		}
+
+		object.Fields[name] = &Field{
+			Type:    typeName,
+			Resolve: structFieldResolver(index),
+		}
+	}
+}
+
+// reflectTypeReference returns the GraphQL type reference for a Go type.
+// It returns false for types that can't be represented.
+func (schema *Schema) reflectTypeReference(typ reflect.Type) (string, bool) {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	switch typ.Kind() {
+	case reflect.String:
+		return "String", true
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "Int", true
+
+	case reflect.Float32, reflect.Float64:
+		return "Float", true
+
+	case reflect.Bool:
+		return "Boolean", true
+
+	case reflect.Map:
+		return "JSON", true
+
+	case reflect.Slice, reflect.Array:
+		if typ.Elem().Kind() == reflect.Uint8 {
+			return "", false
+		}
+
+		element, ok := schema.reflectTypeReference(typ.Elem())
+
+		if !ok {
+			return "", false
+		}
+
+		return "[" + element + "]", true
+
+	case reflect.Struct:
+		object, ok := schema.reflectObject(typ)
+
+		if !ok {
+			return "", false
+		}
+
+		return object.Name, true
+
+	default:
+		return "", false
+	}
+}
+
+// structFieldResolver returns a resolver that reads the struct field with the given index.
+func structFieldResolver(index []int) func(params *ResolveParams) (interface{}, error) {
+	return func(params *ResolveParams) (interface{}, error) {
+		value := reflect.Indirect(reflect.ValueOf(params.Parent))
+		return value.FieldByIndex(index).Interface(), nil
+	}
+}
@@ -0,0 +1,218 @@
+package graphql
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Schema describes the object types and the root query of the API.
+type Schema struct {
+	Query *Object
+
+	// MaxDepth is the maximum nesting level of fields in a query.
+	MaxDepth int
+
+	// MaxComplexity is the maximum estimated cost of a query, see Field.Complexity.
+	MaxComplexity int
+
+	types map[string]*Object
+}
+
+// Object is a GraphQL object type.
+type Object struct {
+	Name        string
+	Description string
+	Fields      map[string]*Field
+}
+
+// Field is a field of an object type with its resolver.
+type Field struct {
+	// Type is a type reference like "Anime", "[Post]" or "ID!".
+	Type        string
+	Description string
+
+	// Arguments maps the allowed argument names to their types.
+	Arguments map[string]string
+
+	// Resolve returns the value of the field for the parent object.
+	Resolve func(params *ResolveParams) (interface{}, error)
+
+	// Complexity estimates the cost of the field from its arguments
+	// and the complexity of the selected child fields.
+	// By default, every field costs 1 plus its children.
+	// Fields always cost at least 1, even if the function returns less.
+	Complexity func(arguments map[string]interface{}, childComplexity int) int
+}
+
+// ResolveParams contains the parent object and the arguments of the resolved field.
+type ResolveParams struct {
+	Parent    interface{}
+	Arguments map[string]interface{}
+}
+
+// Scalar types
+var scalars = map[string]bool{
+	"ID":      true,
+	"String":  true,
+	"Int":     true,
+	"Float":   true,
+	"Boolean": true,
+	"JSON":    true,
+}
+
+// Default limits
+const (
+	defaultMaxDepth      = 10
+	defaultMaxComplexity = 1000
+)
+
+// NewSchema creates a schema with the given root query type.
+func NewSchema(query *Object) *Schema {
+	schema := &Schema{
+		Query:         query,
+		MaxDepth:      defaultMaxDepth,
+		MaxComplexity: defaultMaxComplexity,
+		types:         map[string]*Object{},
+	}
+
+	schema.Add(query)
+	return schema
+}
+
+// NewObject creates an object type without fields.
+func NewObject(name string, description string) *Object {
+	return &Object{
+		Name:        name,
+		Description: description,
+		Fields:      map[string]*Field{},
+	}
+}
+
+// Add registers object types that can be returned by fields.
+func (schema *Schema) Add(objects ...*Object) {
+	for _, object := range objects {
+		schema.types[object.Name] = object
+	}
+}
+
+// Type returns the object type with the given name.
+func (schema *Schema) Type(name string) *Object {
+	return schema.types[name]
+}
+
+// Validate checks that all field types refer to scalars or registered object types.
+func (schema *Schema) Validate() error {
+	for _, object := range schema.types {
+		for name, field := range object.Fields {
+			typeName := namedType(field.Type)
+
+			if !scalars[typeName] && schema.types[typeName] == nil {
+				return fmt.Errorf("Field '%s.%s' has the unknown type '%s'", object.Name, name, typeName)
+			}
+
+			if field.Resolve == nil {
+				return fmt.Errorf("Field '%s.%s' doesn't have a resolver", object.Name, name)
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns the schema in the GraphQL schema definition language.
+func (schema *Schema) String() string {
+	var names []string
+
+	for name := range schema.types {
+		if name != schema.Query.Name {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+	names = append([]string{schema.Query.Name}, names...)
+	output := strings.Builder{}
+
+	for index, name := range names {
+		if index > 0 {
+			output.WriteString("\n")
+		}
+
+		schema.types[name].write(&output)
+	}
+
+	return output.String()
+}
+
+// write writes the type definition in the schema definition language.
+func (object *Object) write(output *strings.Builder) {
+	if object.Description != "" {
+		fmt.Fprintf(output, "\"%s\"\n", object.Description)
+	}
+
+	fmt.Fprintf(output, "type %s {\n", object.Name)
+
+	var names []string
+
+	for name := range object.Fields {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		field := object.Fields[name]
+
+		if field.Description != "" {
+			fmt.Fprintf(output, "\t\"%s\"\n", field.Description)
+		}
+
+		output.WriteString("\t" + name)
+
+		if len(field.Arguments) > 0 {
+			var arguments []string
+
+			for argument, typ := range field.Arguments {
+				arguments = append(arguments, argument+": "+typ)
+			}
+
+			sort.Strings(arguments)
+			output.WriteString("(" + strings.Join(arguments, ", ") + ")")
+		}
+
+		output.WriteString(": " + field.Type + "\n")
+	}
+
+	output.WriteString("}\n")
+}
+
+// complexity returns the estimated cost of the field.
+func (field *Field) complexity(arguments map[string]interface{}, childComplexity int) int {
+	if field.Complexity != nil {
+		return field.Complexity(arguments, childComplexity)
+	}
+
+	return 1 + childComplexity
+}
+
+// namedType removes the list and non-null modifiers from a type reference.
+func namedType(typ string) string {
+	return strings.Trim(typ, "[]!")
+}
+
+// isListType tells you whether the type reference is a list.
+func isListType(typ string) bool {
+	return strings.HasPrefix(typ, "[")
+}
+
+// elementType returns the type of the list elements.
+func elementType(typ string) string {
+	typ = strings.TrimSuffix(typ, "!")
+	return typ[1 : len(typ)-1]
+}
+
+// isNonNullType tells you whether the type reference is non-null.
+func isNonNullType(typ string) bool {
+	return strings.HasSuffix(typ, "!")
+}
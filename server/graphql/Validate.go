@@ -0,0 +1,164 @@
+package graphql
+
+import (
+	"fmt"
+)
+
+// maxFragmentSpreads is the maximum number of fragment spreads that are expanded in a query.
+const maxFragmentSpreads = 100
+
+// fragmentUse identifies a fragment spread at a nesting level.
+// The complexity of a fragment only depends on the depth it is spread at.
+type fragmentUse struct {
+	name  string
+	depth int
+}
+
+// validate checks the operation against the schema before anything is resolved.
+// Queries exceeding the depth or complexity limits of the schema are rejected.
+func (exec *execution) validate(operation *Operation) error {
+	exec.fragmentComplexity = map[fragmentUse]int{}
+	_, err := exec.validateSelections(exec.schema.Query, operation.Selections, 1, map[string]bool{})
+	return err
+}
+
+// validateSelections validates the selection set on the object type and returns its complexity.
+// The fragments map contains the fragments currently being expanded to detect cycles.
+// Validation stops as soon as the complexity exceeds the maximum.
+func (exec *execution) validateSelections(object *Object, selections []*Selection, depth int, fragments map[string]bool) (int, error) {
+	if depth > exec.schema.MaxDepth {
+		return 0, fmt.Errorf("Query exceeds the maximum depth of %d", exec.schema.MaxDepth)
+	}
+
+	complexity := 0
+
+	for _, selection := range selections {
+		included, err := exec.included(selection)
+
+		if err != nil {
+			return 0, err
+		}
+
+		if !included {
+			continue
+		}
+
+		var selectionComplexity int
+
+		switch {
+		case selection.FragmentName != "":
+			selectionComplexity, err = exec.validateFragment(object, selection.FragmentName, depth, fragments)
+
+		case selection.Inline:
+			if selection.Condition != "" && selection.Condition != object.Name {
+				return 0, fmt.Errorf("Inline fragment on type '%s' can't be used on type '%s'", selection.Condition, object.Name)
+			}
+
+			selectionComplexity, err = exec.validateSelections(object, selection.Selections, depth, fragments)
+
+		default:
+			selectionComplexity, err = exec.validateField(object, selection, depth, fragments)
+		}
+
+		if err != nil {
+			return 0, err
+		}
+
+		complexity += selectionComplexity
+
+		if complexity > exec.schema.MaxComplexity {
+			return 0, fmt.Errorf("Query exceeds the maximum complexity of %d, please request fewer objects", exec.schema.MaxComplexity)
+		}
+	}
+
+	return complexity, nil
+}
+
+// validateFragment validates a fragment spread and returns its complexity.
+// Each fragment is only validated once for every depth it is spread at.
+func (exec *execution) validateFragment(object *Object, name string, depth int, fragments map[string]bool) (int, error) {
+	exec.fragmentSpreads++
+
+	if exec.fragmentSpreads > maxFragmentSpreads {
+		return 0, fmt.Errorf("Query exceeds the maximum of %d fragment spreads", maxFragmentSpreads)
+	}
+
+	fragment := exec.document.Fragments[name]
+
+	if fragment == nil {
+		return 0, fmt.Errorf("Fragment '%s' is not defined", name)
+	}
+
+	if fragments[fragment.Name] {
+		return 0, fmt.Errorf("Fragment '%s' spreads itself", fragment.Name)
+	}
+
+	if fragment.TypeCondition != object.Name {
+		return 0, fmt.Errorf("Fragment '%s' on type '%s' can't be spread on type '%s'", fragment.Name, fragment.TypeCondition, object.Name)
+	}
+
+	use := fragmentUse{name: fragment.Name, depth: depth}
+	complexity, validated := exec.fragmentComplexity[use]
+
+	if validated {
+		return complexity, nil
+	}
+
+	fragments[fragment.Name] = true
+	complexity, err := exec.validateSelections(object, fragment.Selections, depth, fragments)
+	delete(fragments, fragment.Name)
+
+	if err != nil {
+		return 0, err
+	}
+
+	exec.fragmentComplexity[use] = complexity
+	return complexity, nil
+}
+
+// validateField validates a single field and returns its complexity.
+// Every field costs at least 1, even if it's a scalar.
+func (exec *execution) validateField(object *Object, selection *Selection, depth int, fragments map[string]bool) (int, error) {
+	if selection.Name == "__typename" {
+		return 1, nil
+	}
+
+	field := object.Fields[selection.Name]
+
+	if field == nil {
+		return 0, fmt.Errorf("Cannot query field '%s' on type '%s'", selection.Name, object.Name)
+	}
+
+	arguments, err := exec.arguments(field, selection)
+
+	if err != nil {
+		return 0, err
+	}
+
+	typeName := namedType(field.Type)
+	childComplexity := 0
+
+	if scalars[typeName] {
+		if len(selection.Selections) > 0 {
+			return 0, fmt.Errorf("Field '%s' of type '%s' must not have a selection of subfields", selection.Name, field.Type)
+		}
+	} else {
+		if len(selection.Selections) == 0 {
+			return 0, fmt.Errorf("Field '%s' of type '%s' must have a selection of subfields", selection.Name, field.Type)
+		}
+
+		childComplexity, err = exec.validateSelections(exec.schema.Type(typeName), selection.Selections, depth+1, fragments)
+
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	complexity := field.complexity(arguments, childComplexity)
+
+	if complexity < 1 {
+		complexity = 1
+	}
+
+	return complexity, nil
+}
@@ -1,79 +1,86 @@
+// Package graphql implements the GraphQL endpoint for querying nested data in a single request.
+//
+// Each object type is a set of fields with their own resolvers.
+// Queries are validated against the schema and rejected if they are nested
+// deeper than Schema.MaxDepth or their estimated cost exceeds Schema.MaxComplexity.
+// Only queries are supported, changes need to be made via the REST API.
 package graphql
 
 import (
-	"fmt"
-	"strings"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
 
 	"github.com/aerogo/aero"
-	"github.com/aerogo/graphql"
-	"github.com/animenotifier/notify.moe/arn"
+	"github.com/animenotifier/notify.moe/pages/apiv1"
+	jsoniter "github.com/json-iterator/go"
 )
 
-func Install(app *aero.Application) {
-	api := graphql.New(arn.DB)
-
-	// Block private collections
-	api.AddRootResolver(func(name string, arguments graphql.Map) (interface{}, error, bool) {
-		typeName := strings.TrimPrefix(name, "all")
-		typeName = strings.TrimPrefix(typeName, "like")
+// maxRequestSize is the maximum size of a request body in bytes.
+const maxRequestSize = 64 * 1024
 
-		if arn.IsPrivateType(typeName) {
-			return nil, fmt.Errorf("Type '%s' is private", typeName), true
-		}
+// Install registers the GraphQL endpoint and the schema documentation.
+// Anonymous requests are allowed with a lower rate limit,
+// requests with an API key count towards the same rate limit as the REST API.
+func Install(app *aero.Application) {
+	schema := newSchema()
+	definition := schema.String()
 
-		return nil, nil, false
+	app.Post("/graphql", apiv1.Public(schema.Handler()))
+	app.Get("/graphql/schema", func(ctx aero.Context) error {
+		return ctx.Text(definition)
 	})
+}
 
-	// Like objects
-	// api.AddRootResolver(func(name string, arguments graphql.Map) (interface{}, error, bool) {
-	// 	if !strings.HasPrefix(name, "like") {
-	// 		return nil, nil, false
-	// 	}
-
-	// 	id, ok := arguments["ID"].(string)
-
-	// 	if !ok {
-	// 		return nil, fmt.Errorf("'%s' needs to specify an ID", name), true
-	// 	}
+// Handler returns the HTTP handler that executes GraphQL requests.
+// The body can be a JSON request or, with the content type "application/graphql", only the query.
+func (schema *Schema) Handler() aero.Handler {
+	return func(ctx aero.Context) error {
+		request, err := readRequest(ctx)
 
-	// 	typeName := strings.TrimPrefix(name, "like")
-	// 	obj, err := arn.DB.Get(typeName, id)
+		if err != nil {
+			ctx.SetStatus(http.StatusBadRequest)
+			return ctx.JSON(errorResponse(err))
+		}
 
-	// 	if err != nil {
-	// 		return nil, err, true
-	// 	}
+		response := schema.Execute(request)
 
-	// 	field := reflect.ValueOf(obj).Elem().FieldByName("IsDraft")
+		if response.Data == nil {
+			ctx.SetStatus(http.StatusBadRequest)
+		}
 
-	// 	if field.IsValid() && field.Bool() {
-	// 		return nil, errors.New("Drafts need to be published before they can be liked"), true
-	// 	}
+		return ctx.JSON(response)
+	}
+}
 
-	// 	likeable, ok := obj.(arn.Likeable)
+// readRequest reads the GraphQL request from the body.
+func readRequest(ctx aero.Context) (*Request, error) {
+	reader := ctx.Request().Body().Reader()
+	defer reader.Close()
 
-	// 	if !ok {
-	// 		return nil, fmt.Errorf("'%s' does not implement the Likeable interface", name), true
-	// 	}
+	body, err := ioutil.ReadAll(io.LimitReader(reader, maxRequestSize+1))
 
-	// 	// TODO: Authentication
-	// 	// user := GetUserFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
 
-	// 	// if user == nil {
-	// 	// 	return errors.New("Not logged in")
-	// 	// }
+	if len(body) > maxRequestSize {
+		return nil, errors.New("Request body is too large")
+	}
 
-	// 	// likeable.Like(user.ID)
+	request := &Request{}
 
-	// 	// Call OnLike if the object implements it
-	// 	// receiver, ok := likeable.(LikeEventReceiver)
+	if ctx.Request().Header("Content-Type") == "application/graphql" {
+		request.Query = string(body)
+		return request, nil
+	}
 
-	// 	// if ok {
-	// 	// 	receiver.OnLike(user)
-	// 	// }
+	err = jsoniter.Unmarshal(body, request)
 
-	// 	likeable.Save()
-	// 	return obj, nil, true
-	// })
+	if err != nil {
+		return nil, err
+	}
 
-	app.Post("/graphql", api.Handler())
+	return request, nil
 }
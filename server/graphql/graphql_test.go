@@ -0,0 +1,279 @@
+package graphql_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/akyoto/assert"
+	"github.com/animenotifier/notify.moe/server/graphql"
+)
+
+type base struct {
+	ID string `json:"id"`
+}
+
+type author struct {
+	Name    string   `json:"name"`
+	BookIDs []string `json:"books"`
+	Email   string   `json:"email" private:"true"`
+
+	base
+}
+
+type book struct {
+	Title    string   `json:"title"`
+	Tags     []string `json:"tags"`
+	AuthorID string   `json:"authorId"`
+
+	base
+}
+
+var (
+	authors = map[string]*author{
+		"a1": {Name: "Akyoto", BookIDs: []string{"b1", "b2", "b3"}, base: base{ID: "a1"}},
+	}
+
+	books = map[string]*book{
+		"b1": {Title: "First", Tags: []string{"anime"}, AuthorID: "a1", base: base{ID: "b1"}},
+		"b2": {Title: "Second", AuthorID: "a1", base: base{ID: "b2"}},
+		"b3": {Title: "Third", AuthorID: "a1", base: base{ID: "b3"}},
+	}
+)
+
+func newTestSchema(t *testing.T) *graphql.Schema {
+	query := graphql.NewObject("Query", "")
+	schema := graphql.NewSchema(query)
+	authorType := schema.Reflect(author{}, "")
+	bookType := schema.Reflect(book{}, "")
+
+	query.Fields["book"] = &graphql.Field{
+		Type:      "book",
+		Arguments: map[string]string{"id": "ID!"},
+		Resolve: func(params *graphql.ResolveParams) (interface{}, error) {
+			result, exists := books[params.Arguments["id"].(string)]
+
+			if !exists {
+				return nil, errors.New("Book not found")
+			}
+
+			return result, nil
+		},
+	}
+
+	bookType.Fields["author"] = &graphql.Field{
+		Type: "author",
+		Resolve: func(params *graphql.ResolveParams) (interface{}, error) {
+			return authors[params.Parent.(*book).AuthorID], nil
+		},
+	}
+
+	authorType.Fields["books"] = &graphql.Field{
+		Type:      "[book]",
+		Arguments: map[string]string{"limit": "Int"},
+		Complexity: func(arguments map[string]interface{}, childComplexity int) int {
+			limit, _ := arguments["limit"].(int)
+			return limit * (1 + childComplexity)
+		},
+		Resolve: func(params *graphql.ResolveParams) (interface{}, error) {
+			var results []*book
+
+			for _, id := range params.Parent.(*author).BookIDs {
+				if len(results) == params.Arguments["limit"] {
+					break
+				}
+
+				results = append(results, books[id])
+			}
+
+			return results, nil
+		},
+	}
+
+	assert.Nil(t, schema.Validate())
+	return schema
+}
+
+func execute(t *testing.T, schema *graphql.Schema, query string, variables map[string]interface{}) string {
+	response := schema.Execute(&graphql.Request{
+		Query:     query,
+		Variables: variables,
+	})
+
+	output, err := json.Marshal(response)
+	assert.Nil(t, err)
+	return string(output)
+}
+
+func TestQuery(t *testing.T) {
+	schema := newTestSchema(t)
+
+	output := execute(t, schema, `
+		# Fields are returned in the requested order
+		query Book($id: ID!) {
+			book(id: $id) {
+				title
+				id
+				tags
+				writer: author {
+					__typename
+					name
+				}
+			}
+		}
+	`, map[string]interface{}{"id": "b1"})
+
+	assert.Equal(t, output, `{"data":{"book":{"title":"First","id":"b1","tags":["anime"],"writer":{"__typename":"author","name":"Akyoto"}}}}`)
+}
+
+func TestNestedLists(t *testing.T) {
+	schema := newTestSchema(t)
+
+	output := execute(t, schema, `{
+		book(id: "b1") {
+			author {
+				books(limit: 2) { title }
+			}
+		}
+	}`, nil)
+
+	assert.Equal(t, output, `{"data":{"book":{"author":{"books":[{"title":"First"},{"title":"Second"}]}}}}`)
+}
+
+func TestFragments(t *testing.T) {
+	schema := newTestSchema(t)
+
+	output := execute(t, schema, `
+		query {
+			book(id: "b2") {
+				...BookInfo
+				... on book {
+					author { name }
+				}
+				author { id }
+			}
+		}
+
+		fragment BookInfo on book {
+			title
+		}
+	`, nil)
+
+	assert.Equal(t, output, `{"data":{"book":{"title":"Second","author":{"name":"Akyoto","id":"a1"}}}}`)
+}
+
+func TestDirectives(t *testing.T) {
+	schema := newTestSchema(t)
+	query := `query ($details: Boolean = false) {
+		book(id: "b1") {
+			title
+			tags @include(if: $details)
+			authorId @skip(if: true)
+		}
+	}`
+
+	assert.Equal(t, execute(t, schema, query, nil), `{"data":{"book":{"title":"First"}}}`)
+	assert.Equal(t, execute(t, schema, query, map[string]interface{}{"details": true}), `{"data":{"book":{"title":"First","tags":["anime"]}}}`)
+}
+
+func TestResolverError(t *testing.T) {
+	schema := newTestSchema(t)
+	output := execute(t, schema, `{ first: book(id: "b1") { title } missing: book(id: "b9") { title } }`, nil)
+	assert.Equal(t, output, `{"data":{"first":{"title":"First"},"missing":null},"errors":[{"message":"Book not found","path":["missing"]}]}`)
+}
+
+func TestPrivateFields(t *testing.T) {
+	schema := newTestSchema(t)
+	output := execute(t, schema, `{ book(id: "b1") { author { email } } }`, nil)
+	assert.Equal(t, output, `{"errors":[{"message":"Cannot query field 'email' on type 'author'"}]}`)
+}
+
+func TestInvalidQueries(t *testing.T) {
+	schema := newTestSchema(t)
+
+	queries := map[string]string{
+		`{ book(id: "b1") { title }`:                              "Syntax error: Unexpected end of document",
+		`{ book { title } }`:                                      "Field 'book' requires the argument 'id'",
+		`{ book(id: "b1", year: 2000) { title } }`:                "Unknown argument 'year' on field 'book'",
+		`{ book(id: 5.5) { title } }`:                             "Argument 'id' on field 'book' expected a value of type 'ID!'",
+		`{ book(id: "b1") }`:                                      "Field 'book' of type 'book' must have a selection of subfields",
+		`{ book(id: "b1") { title { length } } }`:                 "Field 'title' of type 'String' must not have a selection of subfields",
+		`{ book(id: "b1") { ...Missing } }`:                       "Fragment 'Missing' is not defined",
+		`{ book(id: "b1") { ...A } } fragment A on book { ...A }`: "Fragment 'A' spreads itself",
+		`{ book(id: $id) { title } }`:                             "Variable '$id' is not defined",
+		`mutation { book(id: "b1") { title } }`:                   "Operation type 'mutation' is not supported, please use the REST API to make changes",
+	}
+
+	for query, message := range queries {
+		response := schema.Execute(&graphql.Request{Query: query})
+		assert.Nil(t, response.Data)
+		assert.Equal(t, len(response.Errors), 1)
+		assert.Equal(t, response.Errors[0].Message, message)
+	}
+}
+
+func TestDepthLimit(t *testing.T) {
+	schema := newTestSchema(t)
+	schema.MaxDepth = 4
+
+	response := schema.Execute(&graphql.Request{Query: `{ book(id: "b1") { author { books(limit: 1) { title } } } }`})
+	assert.NotNil(t, response.Data)
+
+	response = schema.Execute(&graphql.Request{Query: `{ book(id: "b1") { author { books(limit: 1) { author { name } } } } }`})
+	assert.Nil(t, response.Data)
+	assert.Equal(t, response.Errors[0].Message, "Query exceeds the maximum depth of 4")
+}
+
+func TestComplexityLimit(t *testing.T) {
+	schema := newTestSchema(t)
+	schema.MaxComplexity = 50
+	query := `query ($limit: Int) { book(id: "b1") { author { books(limit: $limit) { author { name } } } } }`
+
+	// book (1) + author (1) + books (limit * (1 + author (1) + name (1)))
+	response := schema.Execute(&graphql.Request{Query: query, Variables: map[string]interface{}{"limit": 16.0}})
+	assert.NotNil(t, response.Data)
+
+	response = schema.Execute(&graphql.Request{Query: query, Variables: map[string]interface{}{"limit": 17.0}})
+	assert.Nil(t, response.Data)
+	assert.Equal(t, response.Errors[0].Message, "Query exceeds the maximum complexity of 50, please request fewer objects")
+}
+
+func TestFragmentExpansion(t *testing.T) {
+	schema := newTestSchema(t)
+
+	// Every fragment spreads the previous one twice, doubling the number of fields
+	query := strings.Builder{}
+	query.WriteString(`{ book(id: "b1") { ...F21 } } fragment F0 on book { title }`)
+
+	for i := 1; i <= 21; i++ {
+		fmt.Fprintf(&query, " fragment F%d on book { ...F%d ...F%d }", i, i-1, i-1)
+	}
+
+	start := time.Now()
+	response := schema.Execute(&graphql.Request{Query: query.String()})
+	assert.True(t, time.Since(start) < time.Second)
+	assert.Nil(t, response.Data)
+	assert.Equal(t, response.Errors[0].Message, "Query exceeds the maximum complexity of 1000, please request fewer objects")
+
+	// Cheap fragments can't be spread an unlimited number of times either
+	response = schema.Execute(&graphql.Request{Query: `{ book(id: "b1") { ` + strings.Repeat("...A ", 101) + `} } fragment A on book { title }`})
+	assert.Nil(t, response.Data)
+	assert.Equal(t, response.Errors[0].Message, "Query exceeds the maximum of 100 fragment spreads")
+
+	// Repeated spreads of the same fragment are merged
+	output := execute(t, schema, `{ book(id: "b1") { ...A ...A id } } fragment A on book { title }`, nil)
+	assert.Equal(t, output, `{"data":{"book":{"title":"First","id":"b1"}}}`)
+}
+
+func TestSchemaDefinition(t *testing.T) {
+	schema := newTestSchema(t)
+	definition := schema.String()
+
+	assert.Contains(t, definition, "type Query {\n\tbook(id: ID!): book\n}")
+	assert.Contains(t, definition, "\tbooks(limit: Int): [book]\n")
+	assert.Contains(t, definition, "\tid: ID\n")
+	assert.Nil(t, schema.Type("author").Fields["email"])
+}
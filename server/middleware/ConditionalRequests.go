@@ -0,0 +1,238 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/aerogo/aero"
+)
+
+// cachePolicy is the Cache-Control header used for all paths starting with the prefix.
+// An empty value keeps the default header set by aero.
+type cachePolicy struct {
+	prefix       string
+	cacheControl string
+}
+
+// cachePolicies are checked in order and the first matching prefix wins.
+var cachePolicies = []cachePolicy{
+	// Static files are versioned by their URL and can stay in caches for a long time
+	{"/images/", ""},
+	{"/videos/", ""},
+	{"/audio/", ""},
+	{"/favicon.ico", ""},
+
+	// Bundles change with each deployment and are revalidated by aero's default header
+	{"/scripts", ""},
+	{"/styles", ""},
+	{"/service-worker", ""},
+	{"/manifest.json", ""},
+
+	// API responses contain private data like anime lists and settings
+	{"/api/", "private, no-cache"},
+
+	// Page contents requested by the client-side router
+	{"/_/", "private, no-cache"},
+}
+
+// defaultCachePolicy is used for full page loads.
+// Pages are rendered for the logged in user so they must never end up in shared caches.
+const defaultCachePolicy = "private, no-cache"
+
+// ConditionalRequests middleware answers GET and HEAD requests with 304 Not Modified
+// when the client already has the latest version of the response.
+// Clients are recognized via If-None-Match and the ETag that aero generates for the response body
+// or via If-Modified-Since if the handler set a Last-Modified date with SetLastModified.
+// It also sets the Cache-Control header depending on the route group.
+func ConditionalRequests(next aero.Handler) aero.Handler {
+	return func(ctx aero.Context) error {
+		request := ctx.Request().Internal()
+
+		if request.Method != http.MethodGet && request.Method != http.MethodHead {
+			return next(ctx)
+		}
+
+		writer := &conditionalWriter{
+			ResponseWriter: ctx.Response().Internal(),
+			request:        request,
+			cacheControl:   cacheControlForPath(request.URL.Path),
+		}
+
+		ctx.Response().SetInternal(writer)
+		return next(ctx)
+	}
+}
+
+// SetCacheControl overrides the Cache-Control header of the route group for the current response.
+func SetCacheControl(ctx aero.Context, cacheControl string) {
+	writer, ok := ctx.Response().Internal().(*conditionalWriter)
+
+	if !ok {
+		ctx.Response().SetHeader("Cache-Control", cacheControl)
+		return
+	}
+
+	writer.cacheControl = cacheControl
+}
+
+// SetLastModified sets the Last-Modified header to the given ISO 8601 date
+// so that clients can revalidate the response via If-Modified-Since.
+func SetLastModified(ctx aero.Context, date string) {
+	modified, err := time.Parse(time.RFC3339, date)
+
+	if err != nil {
+		return
+	}
+
+	ctx.Response().SetHeader("Last-Modified", modified.UTC().Format(http.TimeFormat))
+}
+
+// cacheControlForPath returns the Cache-Control header for the given request path.
+func cacheControlForPath(path string) string {
+	for _, policy := range cachePolicies {
+		if strings.HasPrefix(path, policy.prefix) {
+			return policy.cacheControl
+		}
+	}
+
+	return defaultCachePolicy
+}
+
+// conditionalWriter replaces successful responses with 304 Not Modified
+// if the client's cached version is still up to date.
+type conditionalWriter struct {
+	http.ResponseWriter
+	request      *http.Request
+	cacheControl string
+	notModified  bool
+}
+
+// WriteHeader sends the response headers.
+func (writer *conditionalWriter) WriteHeader(status int) {
+	// aero answers clients that still have the unquoted ETag by itself
+	if status == http.StatusNotModified {
+		ConditionalRequestResults.Inc(CacheHit)
+	}
+
+	if status != http.StatusOK {
+		writer.ResponseWriter.WriteHeader(status)
+		return
+	}
+
+	header := writer.Header()
+	etag := header.Get("ETag")
+
+	// aero sends the hash without quotes, but the standard requires a quoted string
+	if etag != "" && !strings.HasSuffix(etag, `"`) {
+		etag = `"` + etag + `"`
+		header.Set("ETag", etag)
+	}
+
+	if writer.cacheControl != "" {
+		header.Set("Cache-Control", writer.cacheControl)
+	}
+
+	if IsNotModified(writer.request.Header, etag, header.Get("Last-Modified")) {
+		writer.notModified = true
+		header.Del("Content-Length")
+		header.Del("Content-Encoding")
+		status = http.StatusNotModified
+		ConditionalRequestResults.Inc(CacheHit)
+	} else if isConditional(writer.request.Header) {
+		ConditionalRequestResults.Inc(CacheMiss)
+	}
+
+	writer.ResponseWriter.WriteHeader(status)
+}
+
+// Write discards the response body if the client's cache is up to date.
+func (writer *conditionalWriter) Write(data []byte) (int, error) {
+	if writer.notModified {
+		return len(data), nil
+	}
+
+	return writer.ResponseWriter.Write(data)
+}
+
+// Flush is needed for event streams.
+func (writer *conditionalWriter) Flush() {
+	flusher, ok := writer.ResponseWriter.(http.Flusher)
+
+	if ok {
+		flusher.Flush()
+	}
+}
+
+// Push is needed for HTTP/2 server push.
+func (writer *conditionalWriter) Push(target string, options *http.PushOptions) error {
+	pusher, ok := writer.ResponseWriter.(http.Pusher)
+
+	if !ok {
+		return http.ErrNotSupported
+	}
+
+	return pusher.Push(target, options)
+}
+
+// IsNotModified tells you whether the conditional request headers say that the client
+// already has the response with the given ETag and Last-Modified date.
+// If-None-Match takes precedence over If-Modified-Since.
+func IsNotModified(requestHeader http.Header, etag string, lastModified string) bool {
+	ifNoneMatch := requestHeader.Get("If-None-Match")
+
+	if ifNoneMatch != "" {
+		return etagMatches(ifNoneMatch, etag)
+	}
+
+	ifModifiedSince := requestHeader.Get("If-Modified-Since")
+
+	if ifModifiedSince == "" || lastModified == "" {
+		return false
+	}
+
+	since, err := http.ParseTime(ifModifiedSince)
+
+	if err != nil {
+		return false
+	}
+
+	modified, err := http.ParseTime(lastModified)
+
+	if err != nil {
+		return false
+	}
+
+	return !modified.After(since)
+}
+
+// isConditional tells you whether the request contains conditional headers.
+func isConditional(requestHeader http.Header) bool {
+	return requestHeader.Get("If-None-Match") != "" || requestHeader.Get("If-Modified-Since") != ""
+}
+
+// etagMatches performs the weak comparison of the ETag with the comma separated If-None-Match list.
+func etagMatches(ifNoneMatch string, etag string) bool {
+	if etag == "" {
+		return false
+	}
+
+	etag = strings.TrimPrefix(etag, "W/")
+
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+
+		if candidate == "*" {
+			return true
+		}
+
+		candidate = strings.TrimPrefix(candidate, "W/")
+
+		// Clients that still have the unquoted ETag of older versions
+		if candidate == etag || `"`+candidate+`"` == etag {
+			return true
+		}
+	}
+
+	return false
+}
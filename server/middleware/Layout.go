@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"encoding/json"
 	"sort"
 
 	"github.com/aerogo/aero"
@@ -22,6 +23,7 @@ func Layout(next aero.Handler) aero.Handler {
 			// To do this, we need to create slices and sort the tags.
 			var meta []string
 			var tags []string
+			var structuredData string
 
 			if openGraph != nil {
 				for name := range openGraph.Meta {
@@ -35,12 +37,21 @@ func Layout(next aero.Handler) aero.Handler {
 				}
 
 				sort.Strings(tags)
+
+				// JSON maps are serialized with sorted keys.
+				if openGraph.StructuredData != nil {
+					data, err := json.Marshal(openGraph.StructuredData)
+
+					if err == nil {
+						structuredData = unsafe.BytesToString(data)
+					}
+				}
 			}
 
 			// Assure that errors are formatted as HTML
 			ctx.Response().SetHeader("Content-Type", "text/html; charset=utf-8")
 
-			html := components.Layout(ctx, user, openGraph, meta, tags, unsafe.BytesToString(content))
+			html := components.Layout(ctx, user, openGraph, meta, tags, structuredData, unsafe.BytesToString(content))
 			return unsafe.StringToBytes(html)
 		})
 
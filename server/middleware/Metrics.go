@@ -0,0 +1,135 @@
+package middleware
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aerogo/aero"
+	"github.com/animenotifier/notify.moe/arn/metrics"
+)
+
+var (
+	// RequestDuration measures the response time of each route.
+	RequestDuration = metrics.NewHistogram(
+		"notify_http_request_duration_seconds",
+		"Response time of HTTP requests by route.",
+		metrics.DefaultBuckets,
+		"route",
+	)
+
+	// Requests counts the requests of each route by status code.
+	Requests = metrics.NewCounter(
+		"notify_http_requests_total",
+		"Number of HTTP requests by route and status code.",
+		"route", "status",
+	)
+
+	// ConditionalRequestResults counts the outcome of requests with If-None-Match or If-Modified-Since.
+	// A hit is a request answered with 304 Not Modified.
+	ConditionalRequestResults = metrics.NewCounter(
+		"notify_http_conditional_requests_total",
+		"Number of conditional HTTP requests by cache result.",
+		"result",
+	)
+)
+
+// Conditional request results
+const (
+	CacheHit  = "hit"
+	CacheMiss = "miss"
+)
+
+// UnmatchedRoute is the label of all requests that don't match a registered route.
+// Using the path instead would let every client create new label values.
+const UnmatchedRoute = "unmatched"
+
+// routePattern is a registered route split into its path segments.
+type routePattern struct {
+	route    string
+	segments []string
+}
+
+// routePatterns contains the routes registered via RegisterRoute.
+// Routes are only registered on startup, therefore the slice is not protected by a mutex.
+var routePatterns []routePattern
+
+// RegisterRoute makes the route known to the metrics middleware
+// so that requests are counted per route instead of per URL.
+func RegisterRoute(route string) {
+	routePatterns = append(routePatterns, routePattern{
+		route:    route,
+		segments: strings.Split(strings.Trim(route, "/"), "/"),
+	})
+}
+
+// Metrics middleware measures the response time and status code of every request.
+func Metrics(next aero.Handler) aero.Handler {
+	return func(ctx aero.Context) error {
+		start := time.Now()
+		err := next(ctx)
+		responseTime := time.Since(start)
+
+		route := RouteLabel(ctx.Path())
+		RequestDuration.Observe(responseTime.Seconds(), route)
+		Requests.Inc(route, strconv.Itoa(ctx.Status()))
+		return err
+	}
+}
+
+// RouteLabel returns the registered route that matches the path.
+// Content-only requests starting with "/_" are counted for the full page route.
+// Paths of unregistered routes are counted as UnmatchedRoute.
+func RouteLabel(path string) string {
+	if path == "/_" {
+		path = "/"
+	}
+
+	path = strings.TrimPrefix(path, "/_/")
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	bestRoute := ""
+	bestScore := -1
+
+	for _, pattern := range routePatterns {
+		score, ok := pattern.match(segments)
+
+		if ok && score > bestScore {
+			bestRoute = pattern.route
+			bestScore = score
+		}
+	}
+
+	if bestRoute == "" {
+		return UnmatchedRoute
+	}
+
+	return bestRoute
+}
+
+// match tells you whether the path segments match the route
+// and returns the number of static segments, so that "/anime/random" wins over "/anime/:id".
+func (pattern *routePattern) match(segments []string) (int, bool) {
+	score := 0
+
+	for index, segment := range pattern.segments {
+		if strings.HasPrefix(segment, "*") {
+			return score, true
+		}
+
+		if index >= len(segments) {
+			return 0, false
+		}
+
+		if strings.HasPrefix(segment, ":") {
+			continue
+		}
+
+		if segment != segments[index] {
+			return 0, false
+		}
+
+		score++
+	}
+
+	return score, len(segments) == len(pattern.segments)
+}
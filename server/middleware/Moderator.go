@@ -0,0 +1,22 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/aerogo/aero"
+	"github.com/animenotifier/notify.moe/arn"
+)
+
+// Moderator middleware only lets forum moderators access the route.
+func Moderator(next aero.Handler) aero.Handler {
+	return func(ctx aero.Context) error {
+		user := arn.GetUserFromContext(ctx)
+
+		if user == nil || !user.IsModerator() {
+			return ctx.Error(http.StatusUnauthorized, "Not authorized")
+		}
+
+		// Handle the request
+		return next(ctx)
+	}
+}
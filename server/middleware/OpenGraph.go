@@ -3,12 +3,25 @@ package middleware
 import (
 	"github.com/aerogo/aero"
 	"github.com/animenotifier/notify.moe/arn"
+	"github.com/animenotifier/notify.moe/utils/i18n"
 )
 
 // OpenGraphContext is a context with open graph data.
+// It also keeps the locale of the request after it has been resolved.
 type OpenGraphContext struct {
 	aero.Context
 	*arn.OpenGraph
+	locale *i18n.Locale
+}
+
+// Locale returns the locale of the request or nil if it hasn't been resolved yet.
+func (ctx *OpenGraphContext) Locale() *i18n.Locale {
+	return ctx.locale
+}
+
+// SetLocale keeps the resolved locale for the rest of the request.
+func (ctx *OpenGraphContext) SetLocale(locale *i18n.Locale) {
+	ctx.locale = locale
 }
 
 // OpenGraph middleware modifies the context to be an OpenGraphContext.
@@ -0,0 +1,40 @@
+package utils
+
+import (
+	"math"
+
+	"github.com/animenotifier/notify.moe/arn"
+)
+
+// OpenGraphImageRatio is the aspect ratio of landscape OpenGraph images.
+const OpenGraphImageRatio = 1.91
+
+// BestImageVariant returns the link of the image variant whose aspect ratio
+// is the closest to the target ratio. Larger images win in case of a tie.
+func BestImageVariant(asset arn.ImageAsset, targetRatio float64) string {
+	var best *arn.ImageVariant
+	bestDistance := math.Inf(1)
+
+	for _, variant := range asset.ImageVariants() {
+		ratio := variant.Ratio()
+
+		if ratio <= 0 {
+			continue
+		}
+
+		// Compare on a logarithmic scale so that 2:1 and 1:2
+		// are equally far away from a square image.
+		distance := math.Abs(math.Log(ratio / targetRatio))
+
+		if best == nil || distance < bestDistance || (distance == bestDistance && variant.Width*variant.Height > best.Width*best.Height) {
+			best = variant
+			bestDistance = distance
+		}
+	}
+
+	if best == nil {
+		return ""
+	}
+
+	return best.Link
+}
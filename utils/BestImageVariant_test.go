@@ -0,0 +1,51 @@
+package utils_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/akyoto/assert"
+	"github.com/animenotifier/notify.moe/arn"
+	"github.com/animenotifier/notify.moe/utils"
+)
+
+type testAsset []*arn.ImageVariant
+
+func (asset testAsset) ImageVariants() []*arn.ImageVariant {
+	return asset
+}
+
+func TestBestImageVariant(t *testing.T) {
+	asset := testAsset{
+		{Link: "square", Width: 560, Height: 560},
+		{Link: "portrait", Width: 250, Height: 350},
+		{Link: "landscape", Width: 1200, Height: 630},
+		{Link: "banner", Width: 1920, Height: 450},
+	}
+
+	assert.Equal(t, utils.BestImageVariant(asset, utils.OpenGraphImageRatio), "landscape")
+	assert.Equal(t, utils.BestImageVariant(asset, 1), "square")
+	assert.Equal(t, utils.BestImageVariant(asset, 0.7), "portrait")
+
+	// Falls back to the nearest available ratio
+	assert.Equal(t, utils.BestImageVariant(asset[:2], utils.OpenGraphImageRatio), "square")
+
+	// Prefers the larger image when the ratios are equal
+	asset = append(asset, &arn.ImageVariant{Link: "landscape-large", Width: 2400, Height: 1260})
+	assert.Equal(t, utils.BestImageVariant(asset, utils.OpenGraphImageRatio), "landscape-large")
+
+	// No usable variants
+	assert.Equal(t, utils.BestImageVariant(testAsset{}, utils.OpenGraphImageRatio), "")
+}
+
+func TestBestImageVariantSkipsOriginal(t *testing.T) {
+	// The original upload matches the ratio perfectly but must not be used
+	anime := &arn.Anime{ID: "74y2cFiiR"}
+	anime.Image.Extension = ".png"
+	anime.Image.Width = 1910
+	anime.Image.Height = 1000
+
+	link := utils.BestImageVariant(anime, utils.OpenGraphImageRatio)
+	assert.NotEqual(t, link, "")
+	assert.False(t, strings.Contains(link, "original"))
+}
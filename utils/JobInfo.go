@@ -1,6 +1,9 @@
 package utils
 
 import (
+	"errors"
+	"fmt"
+	"os"
 	"os/exec"
 	"path"
 	"time"
@@ -9,6 +12,15 @@ import (
 	"github.com/animenotifier/notify.moe/arn"
 )
 
+// jobLockDuration is the maximum time a job started from the web server stays locked.
+const jobLockDuration = 1 * time.Hour
+
+// jobOwner identifies the process in the locks of the jobs it started.
+var jobOwner = func() string {
+	hostName, _ := os.Hostname()
+	return fmt.Sprintf("%s:%d", hostName, os.Getpid())
+}()
+
 // JobInfo gives you information about a background job.
 type JobInfo struct {
 	Name         string
@@ -17,16 +29,29 @@ type JobInfo struct {
 }
 
 // IsRunning tells you whether the given job is running or not.
+// This includes runs started by the scheduler.
 func (job *JobInfo) IsRunning() bool {
-	return job.LastStarted.After(job.LastFinished)
+	if job.LastStarted.After(job.LastFinished) {
+		return true
+	}
+
+	status, err := arn.GetJobStatus(job.Name)
+	return err == nil && status.IsRunning()
 }
 
 // Start will start the job.
 func (job *JobInfo) Start() error {
+	token, locked := arn.LockJob(job.Name, jobOwner, jobLockDuration)
+
+	if !locked {
+		return errors.New("Job is already running")
+	}
+
 	cmd := exec.Command(path.Join(arn.Root, "jobs", job.Name, job.Name))
 	err := cmd.Start()
 
 	if err != nil {
+		arn.UnlockJob(job.Name, token)
 		return err
 	}
 
@@ -41,6 +66,8 @@ func (job *JobInfo) Start() error {
 		}
 
 		job.LastFinished = time.Now()
+		arn.RecordJobRun(job.Name, job.LastStarted, err)
+		arn.UnlockJob(job.Name, token)
 	}()
 
 	return nil
@@ -0,0 +1,76 @@
+package utils
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/animenotifier/notify.moe/arn/metrics"
+)
+
+// MetricsDashboard contains the data shown on the admin metrics page.
+type MetricsDashboard struct {
+	Routes            []*RouteStatistics
+	CacheHits         float64
+	CacheMisses       float64
+	NotificationsSent float64
+	PushSuccess       float64
+	PushFailure       float64
+	PushExpired       float64
+	ObjectCounts      []*ObjectCount
+}
+
+// RouteStatistics summarizes the requests of a single route.
+type RouteStatistics struct {
+	Route    string
+	Requests uint64
+	Errors   float64
+
+	// Response times in seconds
+	Average float64
+	P95     float64
+}
+
+// ObjectCount is the number of objects of a database type.
+type ObjectCount struct {
+	Type  string
+	Count int64
+}
+
+// CacheHitRate returns the percentage of conditional requests answered with 304 Not Modified.
+func (dashboard *MetricsDashboard) CacheHitRate() float64 {
+	return percentage(dashboard.CacheHits, dashboard.CacheHits+dashboard.CacheMisses)
+}
+
+// PushSuccessRate returns the percentage of successful push notification deliveries.
+func (dashboard *MetricsDashboard) PushSuccessRate() float64 {
+	return percentage(dashboard.PushSuccess, dashboard.PushSuccess+dashboard.PushFailure+dashboard.PushExpired)
+}
+
+// AverageText returns the average response time in a human readable format.
+func (route *RouteStatistics) AverageText() string {
+	return formatResponseTime(route.Average)
+}
+
+// P95Text returns the response time that 95% of the requests were faster than.
+func (route *RouteStatistics) P95Text() string {
+	return formatResponseTime(route.P95)
+}
+
+// formatResponseTime formats the response time in seconds as milliseconds.
+// Times above the largest histogram bucket are shown as the bucket limit.
+func formatResponseTime(seconds float64) string {
+	if math.IsInf(seconds, 1) {
+		return fmt.Sprintf("> %.0f s", metrics.DefaultBuckets[len(metrics.DefaultBuckets)-1])
+	}
+
+	return fmt.Sprintf("%.0f ms", seconds*1000)
+}
+
+// percentage returns the share of the part in the total in percent.
+func percentage(part float64, total float64) float64 {
+	if total == 0 {
+		return 0
+	}
+
+	return part / total * 100
+}
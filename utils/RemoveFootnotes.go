@@ -0,0 +1,19 @@
+package utils
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	footnoteDefinitionRegex = regexp.MustCompile(`(?m)^\[\^[^\]\s]+\]:.*$`)
+	footnoteReferenceRegex  = regexp.MustCompile(`\[\^[^\]\s]+\]`)
+)
+
+// RemoveFootnotes removes markdown footnote markers and definitions
+// so that descriptions generated from the text stay clean.
+func RemoveFootnotes(text string) string {
+	text = footnoteDefinitionRegex.ReplaceAllString(text, "")
+	text = footnoteReferenceRegex.ReplaceAllString(text, "")
+	return strings.TrimSpace(text)
+}
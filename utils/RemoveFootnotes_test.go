@@ -0,0 +1,15 @@
+package utils_test
+
+import (
+	"testing"
+
+	"github.com/akyoto/assert"
+	"github.com/animenotifier/notify.moe/utils"
+)
+
+func TestRemoveFootnotes(t *testing.T) {
+	text := "Episode 3 was great[^1].\n\n[^1]: Especially the ending."
+	assert.Equal(t, utils.RemoveFootnotes(text), "Episode 3 was great.")
+	assert.Equal(t, utils.RemoveFootnotes("No footnotes [here](/link)."), "No footnotes [here](/link).")
+	assert.Equal(t, utils.RemoveFootnotes("Trailer: www.example.com/pv"), "Trailer: www.example.com/pv")
+}
@@ -0,0 +1,16 @@
+package utils
+
+import (
+	"github.com/aerogo/aero"
+	"github.com/animenotifier/notify.moe/utils/i18n"
+)
+
+// T translates the English text to the language of the request.
+func T(ctx aero.Context, text string, args ...interface{}) string {
+	return i18n.FromContext(ctx).T(text, args...)
+}
+
+// Locale returns the locale of the request.
+func Locale(ctx aero.Context) *i18n.Locale {
+	return i18n.FromContext(ctx)
+}
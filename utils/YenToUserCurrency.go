@@ -1,35 +1,21 @@
 package utils
 
 import (
-	"fmt"
-
+	"github.com/aerogo/aero"
 	"github.com/animenotifier/notify.moe/arn"
-	"github.com/pariz/gountries"
-)
-
-// Current currency rates
-const (
-	yenToEuro   = 0.0077
-	yenToDollar = 0.0090
+	"github.com/animenotifier/notify.moe/utils/currency"
+	"github.com/animenotifier/notify.moe/utils/i18n"
 )
 
-var countryQuery = gountries.New()
-
-// YenToUserCurrency converts the Yen price to the user currency.
-func YenToUserCurrency(amount int, user *arn.User) string {
-	if user == nil || user.Location.CountryName == "" {
-		return fmt.Sprintf("%.2f $", float64(amount)*yenToDollar)
-	}
-
-	country, err := countryQuery.FindCountryByName(user.Location.CountryName)
-
-	if err != nil {
-		return fmt.Sprintf("%.2f $", float64(amount)*yenToDollar)
-	}
+// YenToUserCurrency converts the Yen price to the user currency
+// and formats it for the locale of the request.
+func YenToUserCurrency(ctx aero.Context, amount int) string {
+	user := arn.GetUserFromContext(ctx)
+	userCurrency := currency.All[currency.Default]
 
-	if arn.Contains(country.Currencies, "EUR") {
-		return fmt.Sprintf("%.2f €", float64(amount)*yenToEuro)
+	if user != nil {
+		userCurrency = currency.ForCountry(user.Location.CountryName)
 	}
 
-	return fmt.Sprintf("%.2f $", float64(amount)*yenToDollar)
+	return i18n.FromContext(ctx).FormatCurrency(currency.FromYen(amount, userCurrency), userCurrency)
 }
@@ -0,0 +1,275 @@
+// Package cron parses cron-like schedule expressions.
+//
+// Supported are the 5 standard fields "minute hour day-of-month month day-of-week"
+// with "*", lists ("1,15"), ranges ("1-5") and steps ("*/10", "0-30/5"),
+// the descriptors "@hourly", "@daily", "@weekly", "@monthly", "@yearly"
+// and fixed intervals like "@every 30m".
+package cron
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule calculates the run times of a job.
+type Schedule interface {
+	// Next returns the first run time after the given time.
+	Next(after time.Time) time.Time
+}
+
+// descriptors are shortcuts for common schedules.
+var descriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// field describes the allowed values of a schedule field.
+type field struct {
+	name string
+	min  int
+	max  int
+}
+
+var (
+	minuteField     = field{"minute", 0, 59}
+	hourField       = field{"hour", 0, 23}
+	dayOfMonthField = field{"day of month", 1, 31}
+	monthField      = field{"month", 1, 12}
+	dayOfWeekField  = field{"day of week", 0, 7}
+)
+
+// maxSearch limits the search for the next run time of schedules that can never match, e.g. "0 0 30 2 *".
+const maxSearch = 5 * 366 * 24 * time.Hour
+
+// Parse parses the schedule expression.
+func Parse(spec string) (Schedule, error) {
+	spec = strings.TrimSpace(spec)
+
+	if strings.HasPrefix(spec, "@every ") {
+		interval, err := time.ParseDuration(strings.TrimSpace(strings.TrimPrefix(spec, "@every ")))
+
+		if err != nil {
+			return nil, err
+		}
+
+		if interval <= 0 {
+			return nil, errors.New("Interval must be positive")
+		}
+
+		return &intervalSchedule{interval: interval}, nil
+	}
+
+	if expanded, exists := descriptors[spec]; exists {
+		spec = expanded
+	}
+
+	parts := strings.Fields(spec)
+
+	if len(parts) != 5 {
+		return nil, fmt.Errorf("Expected 5 fields in schedule '%s', got %d", spec, len(parts))
+	}
+
+	schedule := &fieldSchedule{}
+	var err error
+
+	if schedule.minute, err = parseField(parts[0], minuteField); err != nil {
+		return nil, err
+	}
+
+	if schedule.hour, err = parseField(parts[1], hourField); err != nil {
+		return nil, err
+	}
+
+	if schedule.dayOfMonth, err = parseField(parts[2], dayOfMonthField); err != nil {
+		return nil, err
+	}
+
+	if schedule.month, err = parseField(parts[3], monthField); err != nil {
+		return nil, err
+	}
+
+	if schedule.dayOfWeek, err = parseField(parts[4], dayOfWeekField); err != nil {
+		return nil, err
+	}
+
+	// Sunday can be written as 0 or 7
+	if schedule.dayOfWeek&(1<<7) != 0 {
+		schedule.dayOfWeek |= 1
+	}
+
+	schedule.anyDayOfMonth = parts[2] == "*"
+	schedule.anyDayOfWeek = parts[4] == "*"
+	return schedule, nil
+}
+
+// MustParse is like Parse but panics if the expression is invalid.
+// It is meant to be used for schedules defined in the source code.
+func MustParse(spec string) Schedule {
+	schedule, err := Parse(spec)
+
+	if err != nil {
+		panic(err)
+	}
+
+	return schedule
+}
+
+// intervalSchedule runs a job in fixed intervals.
+type intervalSchedule struct {
+	interval time.Duration
+}
+
+// Next returns the time one interval after the given time.
+func (schedule *intervalSchedule) Next(after time.Time) time.Time {
+	return after.Add(schedule.interval)
+}
+
+// fieldSchedule runs a job at the times matching all fields.
+// Each field is a bit set of the allowed values.
+type fieldSchedule struct {
+	minute        uint64
+	hour          uint64
+	dayOfMonth    uint64
+	month         uint64
+	dayOfWeek     uint64
+	anyDayOfMonth bool
+	anyDayOfWeek  bool
+}
+
+// Next returns the first matching minute after the given time.
+// A zero time is returned if the schedule never matches.
+func (schedule *fieldSchedule) Next(after time.Time) time.Time {
+	next := after.Truncate(time.Minute).Add(time.Minute)
+	limit := next.Add(maxSearch)
+
+	for next.Before(limit) {
+		if !has(schedule.month, int(next.Month())) {
+			next = time.Date(next.Year(), next.Month()+1, 1, 0, 0, 0, 0, next.Location())
+			continue
+		}
+
+		if !schedule.matchesDay(next) {
+			next = time.Date(next.Year(), next.Month(), next.Day()+1, 0, 0, 0, 0, next.Location())
+			continue
+		}
+
+		if !has(schedule.hour, next.Hour()) {
+			next = next.Truncate(time.Hour).Add(time.Hour)
+			continue
+		}
+
+		if !has(schedule.minute, next.Minute()) {
+			next = next.Add(time.Minute)
+			continue
+		}
+
+		return next
+	}
+
+	return time.Time{}
+}
+
+// matchesDay checks the day of the month and the day of the week.
+// Like in cron, a day matches either of them if both are restricted.
+func (schedule *fieldSchedule) matchesDay(t time.Time) bool {
+	dayOfMonth := has(schedule.dayOfMonth, t.Day())
+	dayOfWeek := has(schedule.dayOfWeek, int(t.Weekday()))
+
+	switch {
+	case schedule.anyDayOfMonth && schedule.anyDayOfWeek:
+		return true
+	case schedule.anyDayOfMonth:
+		return dayOfWeek
+	case schedule.anyDayOfWeek:
+		return dayOfMonth
+	default:
+		return dayOfMonth || dayOfWeek
+	}
+}
+
+// parseField parses a comma separated list of values, ranges and steps into a bit set.
+func parseField(expression string, field field) (uint64, error) {
+	bits := uint64(0)
+
+	for _, part := range strings.Split(expression, ",") {
+		step := 1
+		rangeExpression := part
+
+		if index := strings.Index(part, "/"); index != -1 {
+			var err error
+			step, err = strconv.Atoi(part[index+1:])
+
+			if err != nil || step <= 0 {
+				return 0, fmt.Errorf("Invalid step in %s '%s'", field.name, part)
+			}
+
+			rangeExpression = part[:index]
+		}
+
+		start, end, err := parseRange(rangeExpression, field)
+
+		if err != nil {
+			return 0, err
+		}
+
+		for value := start; value <= end; value += step {
+			bits |= 1 << uint(value)
+		}
+	}
+
+	return bits, nil
+}
+
+// parseRange parses "*", a single value or a range like "1-5".
+func parseRange(expression string, field field) (int, int, error) {
+	if expression == "*" {
+		return field.min, field.max, nil
+	}
+
+	bounds := strings.SplitN(expression, "-", 2)
+	start, err := parseValue(bounds[0], field)
+
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if len(bounds) == 1 {
+		return start, start, nil
+	}
+
+	end, err := parseValue(bounds[1], field)
+
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if end < start {
+		return 0, 0, fmt.Errorf("Invalid range in %s '%s'", field.name, expression)
+	}
+
+	return start, end, nil
+}
+
+// parseValue parses a single number and checks that it's allowed in the field.
+func parseValue(expression string, field field) (int, error) {
+	value, err := strconv.Atoi(expression)
+
+	if err != nil || value < field.min || value > field.max {
+		return 0, fmt.Errorf("Invalid %s '%s', expected a number from %d to %d", field.name, expression, field.min, field.max)
+	}
+
+	return value, nil
+}
+
+// has tells you whether the value is in the bit set.
+func has(bits uint64, value int) bool {
+	return bits&(1<<uint(value)) != 0
+}
@@ -0,0 +1,69 @@
+package cron_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/akyoto/assert"
+	"github.com/animenotifier/notify.moe/utils/cron"
+)
+
+var start = time.Date(2026, time.October, 14, 10, 17, 30, 0, time.UTC)
+
+func TestInterval(t *testing.T) {
+	schedule, err := cron.Parse("@every 30m")
+	assert.Nil(t, err)
+	assert.Equal(t, schedule.Next(start), start.Add(30*time.Minute))
+}
+
+func TestSteps(t *testing.T) {
+	schedule, err := cron.Parse("*/5 * * * *")
+	assert.Nil(t, err)
+	assert.Equal(t, schedule.Next(start), time.Date(2026, time.October, 14, 10, 20, 0, 0, time.UTC))
+}
+
+func TestDaily(t *testing.T) {
+	schedule, err := cron.Parse("30 4 * * *")
+	assert.Nil(t, err)
+	assert.Equal(t, schedule.Next(start), time.Date(2026, time.October, 15, 4, 30, 0, 0, time.UTC))
+
+	schedule, err = cron.Parse("@daily")
+	assert.Nil(t, err)
+	assert.Equal(t, schedule.Next(start), time.Date(2026, time.October, 15, 0, 0, 0, 0, time.UTC))
+}
+
+func TestListsAndRanges(t *testing.T) {
+	schedule, err := cron.Parse("0 9-17/4 * * 1-5")
+	assert.Nil(t, err)
+
+	// Wednesday 10:17 -> Wednesday 13:00
+	assert.Equal(t, schedule.Next(start), time.Date(2026, time.October, 14, 13, 0, 0, 0, time.UTC))
+
+	// Friday 17:00 -> Monday 09:00
+	friday := time.Date(2026, time.October, 16, 17, 0, 0, 0, time.UTC)
+	assert.Equal(t, schedule.Next(friday), time.Date(2026, time.October, 19, 9, 0, 0, 0, time.UTC))
+
+	schedule, err = cron.Parse("15,45 * * * *")
+	assert.Nil(t, err)
+	assert.Equal(t, schedule.Next(start), time.Date(2026, time.October, 14, 10, 45, 0, 0, time.UTC))
+}
+
+func TestDayOfMonthOrWeek(t *testing.T) {
+	// Either the 1st of the month or a Sunday
+	schedule, err := cron.Parse("0 0 1 * 7")
+	assert.Nil(t, err)
+	assert.Equal(t, schedule.Next(start), time.Date(2026, time.October, 18, 0, 0, 0, 0, time.UTC))
+}
+
+func TestNeverMatches(t *testing.T) {
+	schedule, err := cron.Parse("0 0 30 2 *")
+	assert.Nil(t, err)
+	assert.True(t, schedule.Next(start).IsZero())
+}
+
+func TestInvalid(t *testing.T) {
+	for _, spec := range []string{"", "* * * *", "60 * * * *", "*/0 * * * *", "5-1 * * * *", "@every -5m", "@every soon"} {
+		_, err := cron.Parse(spec)
+		assert.NotNil(t, err)
+	}
+}
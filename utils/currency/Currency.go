@@ -0,0 +1,54 @@
+package currency
+
+import "fmt"
+
+// Default is the currency used when the currency of the user is unknown.
+const Default = "USD"
+
+// Currency describes how prices in a currency are displayed.
+type Currency struct {
+	Code     string
+	Symbol   string
+	Decimals int
+
+	// yenRate is the static exchange rate from 1 yen to this currency.
+	// It's only used when live exchange rates are not available.
+	yenRate float64
+}
+
+// Format formats the amount in this currency.
+func (currency *Currency) Format(amount float64) string {
+	return fmt.Sprintf("%.*f %s", currency.Decimals, amount, currency.Symbol)
+}
+
+// All contains the supported currencies by their ISO 4217 code.
+var All = map[string]*Currency{
+	"ARS": {Code: "ARS", Symbol: "ARS", Decimals: 2, yenRate: 0.38},
+	"AUD": {Code: "AUD", Symbol: "A$", Decimals: 2, yenRate: 0.013},
+	"BRL": {Code: "BRL", Symbol: "R$", Decimals: 2, yenRate: 0.036},
+	"CAD": {Code: "CAD", Symbol: "C$", Decimals: 2, yenRate: 0.012},
+	"CHF": {Code: "CHF", Symbol: "CHF", Decimals: 2, yenRate: 0.0091},
+	"CLP": {Code: "CLP", Symbol: "CLP", Decimals: 0, yenRate: 6.5},
+	"CNY": {Code: "CNY", Symbol: "CN¥", Decimals: 2, yenRate: 0.064},
+	"CZK": {Code: "CZK", Symbol: "Kč", Decimals: 2, yenRate: 0.21},
+	"DKK": {Code: "DKK", Symbol: "kr.", Decimals: 2, yenRate: 0.058},
+	"EUR": {Code: "EUR", Symbol: "€", Decimals: 2, yenRate: 0.0077},
+	"GBP": {Code: "GBP", Symbol: "£", Decimals: 2, yenRate: 0.0070},
+	"HKD": {Code: "HKD", Symbol: "HK$", Decimals: 2, yenRate: 0.071},
+	"IDR": {Code: "IDR", Symbol: "Rp", Decimals: 0, yenRate: 128},
+	"INR": {Code: "INR", Symbol: "₹", Decimals: 2, yenRate: 0.65},
+	"JPY": {Code: "JPY", Symbol: "¥", Decimals: 0, yenRate: 1},
+	"KRW": {Code: "KRW", Symbol: "₩", Decimals: 0, yenRate: 10.8},
+	"MXN": {Code: "MXN", Symbol: "MX$", Decimals: 2, yenRate: 0.17},
+	"NOK": {Code: "NOK", Symbol: "kr", Decimals: 2, yenRate: 0.083},
+	"NZD": {Code: "NZD", Symbol: "NZ$", Decimals: 2, yenRate: 0.014},
+	"PHP": {Code: "PHP", Symbol: "₱", Decimals: 2, yenRate: 0.46},
+	"PLN": {Code: "PLN", Symbol: "zł", Decimals: 2, yenRate: 0.035},
+	"RUB": {Code: "RUB", Symbol: "₽", Decimals: 2, yenRate: 0.58},
+	"SEK": {Code: "SEK", Symbol: "kr", Decimals: 2, yenRate: 0.087},
+	"SGD": {Code: "SGD", Symbol: "S$", Decimals: 2, yenRate: 0.013},
+	"THB": {Code: "THB", Symbol: "฿", Decimals: 2, yenRate: 0.28},
+	"TRY": {Code: "TRY", Symbol: "₺", Decimals: 2, yenRate: 0.053},
+	"USD": {Code: "USD", Symbol: "$", Decimals: 2, yenRate: 0.0090},
+	"ZAR": {Code: "ZAR", Symbol: "R", Decimals: 2, yenRate: 0.13},
+}
@@ -0,0 +1,24 @@
+package currency_test
+
+import (
+	"testing"
+
+	"github.com/akyoto/assert"
+	"github.com/animenotifier/notify.moe/utils/currency"
+)
+
+func TestForCountry(t *testing.T) {
+	assert.Equal(t, currency.ForCountry("Germany").Code, "EUR")
+	assert.Equal(t, currency.ForCountry("United Kingdom").Code, "GBP")
+	assert.Equal(t, currency.ForCountry("Brazil").Code, "BRL")
+	assert.Equal(t, currency.ForCountry("Japan").Code, "JPY")
+	assert.Equal(t, currency.ForCountry("").Code, currency.Default)
+	assert.Equal(t, currency.ForCountry("Atlantis").Code, currency.Default)
+}
+
+func TestFromYenWithoutProvider(t *testing.T) {
+	// Without live rates the static rates are used
+	assert.NotNil(t, currency.Refresh())
+	assert.Equal(t, currency.All["EUR"].Format(currency.FromYen(1000, currency.All["EUR"])), "7.70 €")
+	assert.Equal(t, currency.All["JPY"].Format(currency.FromYen(1000, currency.All["JPY"])), "1000 ¥")
+}
@@ -0,0 +1,29 @@
+package currency
+
+import "github.com/pariz/gountries"
+
+var countryQuery = gountries.New()
+
+// ForCountry returns the currency used in the given country.
+// Countries with unsupported currencies fall back to the default currency.
+func ForCountry(countryName string) *Currency {
+	if countryName == "" {
+		return All[Default]
+	}
+
+	country, err := countryQuery.FindCountryByName(countryName)
+
+	if err != nil {
+		return All[Default]
+	}
+
+	for _, code := range country.Currencies {
+		currency, exists := All[code]
+
+		if exists {
+			return currency
+		}
+	}
+
+	return All[Default]
+}
@@ -0,0 +1,109 @@
+package currency
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/aerogo/http/client"
+	"github.com/akyoto/color"
+	"github.com/animenotifier/notify.moe/arn"
+)
+
+// RefreshInterval is the time between two exchange rate updates.
+const RefreshInterval = 6 * time.Hour
+
+var (
+	liveRates      map[string]float64
+	liveRatesMutex sync.RWMutex
+)
+
+// exchangeRatesResponse is the response of the exchange rate provider.
+// The rates convert 1 yen to the given currency.
+type exchangeRatesResponse struct {
+	Rates map[string]float64 `json:"rates"`
+}
+
+// YenRate returns the exchange rate from 1 yen to the given currency.
+// It uses the latest live rate and falls back to the static rate.
+func YenRate(currency *Currency) float64 {
+	liveRatesMutex.RLock()
+	rate, exists := liveRates[currency.Code]
+	liveRatesMutex.RUnlock()
+
+	if exists && rate > 0 {
+		return rate
+	}
+
+	return currency.yenRate
+}
+
+// FromYen converts the yen amount to the given currency.
+func FromYen(amount int, currency *Currency) float64 {
+	return float64(amount) * YenRate(currency)
+}
+
+// Refresh downloads the latest exchange rates from the configured provider.
+// The previous rates are kept when the provider is not reachable.
+func Refresh() error {
+	providerURL := arn.APIKeys.ExchangeRates.URL
+
+	if providerURL == "" {
+		return errors.New("Exchange rate provider not defined")
+	}
+
+	response, err := client.Get(providerURL).End()
+
+	if err != nil {
+		return err
+	}
+
+	if response.StatusCode() != http.StatusOK {
+		return errors.New("Exchange rate provider returned " + http.StatusText(response.StatusCode()))
+	}
+
+	data := exchangeRatesResponse{}
+	err = response.Unmarshal(&data)
+
+	if err != nil {
+		return err
+	}
+
+	rates := make(map[string]float64, len(All))
+
+	for code := range All {
+		rate, exists := data.Rates[code]
+
+		if exists && rate > 0 {
+			rates[code] = rate
+		}
+	}
+
+	liveRatesMutex.Lock()
+	liveRates = rates
+	liveRatesMutex.Unlock()
+	return nil
+}
+
+// StartRefreshing periodically refreshes the exchange rates in the background.
+func StartRefreshing() {
+	if arn.APIKeys.ExchangeRates.URL == "" {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(RefreshInterval)
+		defer ticker.Stop()
+
+		for {
+			err := Refresh()
+
+			if err != nil {
+				color.Red("Couldn't refresh exchange rates: %s", err.Error())
+			}
+
+			<-ticker.C
+		}
+	}()
+}
@@ -32,6 +32,11 @@ func Render(obj interface{}, title string, user *arn.User) string {
 	b.WriteString(title)
 	b.WriteString(`</h1>`)
 
+	// Edits of low-trust editors are reviewed before they are applied
+	if user != nil && arn.IsPendingEditType(t.Name()) && !user.IsTrustedEditor() {
+		b.WriteString(`<p class="mountable">Your changes will be applied after another editor approved them.</p>`)
+	}
+
 	// Render the object with its fields
 	RenderObject(&b, obj, "")
 
@@ -200,6 +205,9 @@ func renderStringField(b io.StringWriter, v *reflect.Value, field reflect.Struct
 
 		case "CharacterID":
 			idType = "Character"
+
+		case "PersonID":
+			idType = "Person"
 		}
 	}
 
@@ -252,7 +260,7 @@ func renderStringField(b io.StringWriter, v *reflect.Value, field reflect.Struct
 		anime, err := arn.GetAnime(animeID)
 
 		if err == nil {
-			b.WriteString(components.EditFormImagePreview(anime.Link(), anime.ImageLink("small"), true, anime.Title.ByUser(nil)))
+			b.WriteString(components.EditFormImagePreview(anime.Link(), anime.ImageLink("small"), true, anime.Image.AVIF, anime.Title.ByUser(nil)))
 		}
 
 	case "Character":
@@ -260,7 +268,15 @@ func renderStringField(b io.StringWriter, v *reflect.Value, field reflect.Struct
 		character, err := arn.GetCharacter(characterID)
 
 		if err == nil {
-			b.WriteString(components.EditFormImagePreview(character.Link(), character.ImageLink("medium"), true, character.Name.ByUser(nil)))
+			b.WriteString(components.EditFormImagePreview(character.Link(), character.ImageLink("medium"), true, character.Image.AVIF, character.Name.ByUser(nil)))
+		}
+
+	case "Person":
+		personID := fieldValue.String()
+		person, err := arn.GetPerson(personID)
+
+		if err == nil {
+			b.WriteString(components.EditFormImagePreview(person.Link(), person.ImageLink("small"), true, person.Image.AVIF, person.Name.ByUser(nil)))
 		}
 
 	case "":
@@ -12,3 +12,8 @@ type Renderer struct{}
 func (writer *Renderer) Notification(notification *arn.Notification) string {
 	return components.NotificationEmail(notification)
 }
+
+// Digest renders an email containing multiple notifications.
+func (writer *Renderer) Digest(user *arn.User, notifications []*arn.Notification) string {
+	return components.NotificationDigestEmail(user, notifications)
+}
@@ -0,0 +1,62 @@
+package i18n
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// languageRange is a language with its quality value from the Accept-Language header.
+type languageRange struct {
+	code    string
+	quality float64
+}
+
+// parseAcceptLanguage returns the lowercase language codes of the Accept-Language header,
+// ordered from most to least preferred. Languages with a quality of 0 are not acceptable.
+func parseAcceptLanguage(header string) []string {
+	var ranges []languageRange
+
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(part, ";")
+		code := strings.ToLower(strings.TrimSpace(fields[0]))
+
+		if code == "" || code == "*" {
+			continue
+		}
+
+		quality := 1.0
+
+		for _, parameter := range fields[1:] {
+			parameter = strings.TrimSpace(parameter)
+
+			if !strings.HasPrefix(parameter, "q=") {
+				continue
+			}
+
+			value, err := strconv.ParseFloat(strings.TrimPrefix(parameter, "q="), 64)
+
+			if err == nil {
+				quality = value
+			}
+		}
+
+		if quality <= 0 {
+			continue
+		}
+
+		ranges = append(ranges, languageRange{code, quality})
+	}
+
+	sort.SliceStable(ranges, func(i, j int) bool {
+		return ranges[i].quality > ranges[j].quality
+	})
+
+	codes := make([]string, len(ranges))
+
+	for index, languageRange := range ranges {
+		codes[index] = languageRange.code
+	}
+
+	return codes
+}
@@ -0,0 +1,90 @@
+package i18n
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/animenotifier/notify.moe/utils/currency"
+)
+
+// Locale contains the translations and number formats of a language.
+type Locale struct {
+	Code     string  `json:"-"`
+	Name     string  `json:"name"`
+	Format   Format  `json:"format"`
+	Messages Catalog `json:"messages"`
+}
+
+// Format describes how numbers and dates are written in a locale.
+// Date is a layout for time.Format.
+type Format struct {
+	Decimal  string `json:"decimal"`
+	Grouping string `json:"grouping"`
+	Date     string `json:"date"`
+}
+
+// Catalog maps the English source text to its translation.
+type Catalog map[string]string
+
+// T returns the translation of the English text.
+// Untranslated texts are returned as they are. Arguments are inserted via fmt.Sprintf.
+func (locale *Locale) T(text string, args ...interface{}) string {
+	translation, exists := locale.Messages[text]
+
+	if !exists || translation == "" {
+		translation = text
+	}
+
+	if len(args) == 0 {
+		return translation
+	}
+
+	return fmt.Sprintf(translation, args...)
+}
+
+// FormatNumber formats the number with the given number of decimals
+// and the decimal and grouping separators of the locale.
+func (locale *Locale) FormatNumber(value float64, decimals int) string {
+	formatted := strconv.FormatFloat(math.Abs(value), 'f', decimals, 64)
+	integer := formatted
+	fraction := ""
+
+	if index := strings.IndexByte(formatted, '.'); index != -1 {
+		integer = formatted[:index]
+		fraction = formatted[index+1:]
+	}
+
+	var result strings.Builder
+
+	if value < 0 && strings.Trim(formatted, "0.") != "" {
+		result.WriteByte('-')
+	}
+
+	for index, digit := range integer {
+		if index > 0 && (len(integer)-index)%3 == 0 {
+			result.WriteString(locale.Format.Grouping)
+		}
+
+		result.WriteRune(digit)
+	}
+
+	if fraction != "" {
+		result.WriteString(locale.Format.Decimal)
+		result.WriteString(fraction)
+	}
+
+	return result.String()
+}
+
+// FormatCurrency formats the amount in the given currency.
+func (locale *Locale) FormatCurrency(amount float64, money *currency.Currency) string {
+	return locale.FormatNumber(amount, money.Decimals) + " " + money.Symbol
+}
+
+// FormatDate formats the date without the time of day.
+func (locale *Locale) FormatDate(date time.Time) string {
+	return date.Format(locale.Format.Date)
+}
@@ -0,0 +1,162 @@
+// Package i18n translates server-rendered texts and formats numbers and dates for the language of the user.
+//
+// Catalogs are JSON files named after the language code, e.g. "locales/de.json".
+// They map the English source text to its translation, so texts without a translation stay readable.
+// The locale of a request is the language from the user settings, followed by the
+// Accept-Language header of the browser and finally English.
+package i18n
+
+import (
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/aerogo/aero"
+	"github.com/animenotifier/notify.moe/arn"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// Default is the language code of the source texts.
+const Default = "en"
+
+// defaultLocale is the built-in English locale.
+var defaultLocale = &Locale{
+	Code: Default,
+	Name: "English",
+	Format: Format{
+		Decimal:  ".",
+		Grouping: ",",
+		Date:     "Jan 2, 2006",
+	},
+	Messages: Catalog{},
+}
+
+// locales contains all available locales by their language code.
+var locales = map[string]*Locale{
+	Default: defaultLocale,
+}
+
+// Load reads all catalogs in the directory and makes them available as a user setting.
+func Load(directory string) error {
+	files, err := filepath.Glob(filepath.Join(directory, "*.json"))
+
+	if err != nil {
+		return err
+	}
+
+	if len(files) == 0 {
+		return errors.New("No translation catalogs found in " + directory)
+	}
+
+	for _, file := range files {
+		data, err := ioutil.ReadFile(file)
+
+		if err != nil {
+			return err
+		}
+
+		locale := &Locale{}
+		err = jsoniter.Unmarshal(data, locale)
+
+		if err != nil {
+			return err
+		}
+
+		locale.Code = strings.TrimSuffix(filepath.Base(file), ".json")
+		locales[locale.Code] = locale
+	}
+
+	options := []*arn.Option{
+		{Value: "", Label: "Browser language"},
+	}
+
+	for _, locale := range All() {
+		options = append(options, &arn.Option{Value: locale.Code, Label: locale.Name})
+	}
+
+	arn.DataLists["locales"] = options
+	return nil
+}
+
+// Get returns the locale with the given language code or the default locale if it doesn't exist.
+func Get(code string) *Locale {
+	locale, exists := locales[code]
+
+	if !exists {
+		return defaultLocale
+	}
+
+	return locale
+}
+
+// All returns all available locales sorted by language code.
+func All() []*Locale {
+	all := make([]*Locale, 0, len(locales))
+
+	for _, locale := range locales {
+		all = append(all, locale)
+	}
+
+	sort.Slice(all, func(i, j int) bool {
+		return all[i].Code < all[j].Code
+	})
+
+	return all
+}
+
+// localeCache is implemented by request contexts that keep the locale once it has been resolved.
+type localeCache interface {
+	Locale() *Locale
+	SetLocale(*Locale)
+}
+
+// FromContext returns the locale for the request.
+// The locale is only resolved once per request if the context can keep it.
+func FromContext(ctx aero.Context) *Locale {
+	cache, canCache := ctx.(localeCache)
+
+	if canCache && cache.Locale() != nil {
+		return cache.Locale()
+	}
+
+	locale := Resolve(arn.GetUserFromContext(ctx), ctx.Request().Header("Accept-Language"))
+
+	if canCache {
+		cache.SetLocale(locale)
+	}
+
+	return locale
+}
+
+// Resolve returns the locale chosen in the user settings,
+// the preferred language of the browser or the default locale.
+func Resolve(user *arn.User, acceptLanguage string) *Locale {
+	if user != nil {
+		locale, exists := locales[user.Settings().Locale]
+
+		if exists {
+			return locale
+		}
+	}
+
+	for _, code := range parseAcceptLanguage(acceptLanguage) {
+		locale, exists := locales[code]
+
+		if exists {
+			return locale
+		}
+
+		// Fall back from regional variants like "de-AT" to the language
+		if index := strings.IndexByte(code, '-'); index != -1 {
+			locale, exists = locales[code[:index]]
+
+			if exists {
+				return locale
+			}
+		}
+	}
+
+	return defaultLocale
+}
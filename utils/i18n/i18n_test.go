@@ -0,0 +1,51 @@
+package i18n_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/akyoto/assert"
+	"github.com/animenotifier/notify.moe/utils/currency"
+	"github.com/animenotifier/notify.moe/utils/i18n"
+)
+
+func TestLoadWithoutCatalogs(t *testing.T) {
+	assert.NotNil(t, i18n.Load("."))
+}
+
+func TestResolve(t *testing.T) {
+	assert.Nil(t, i18n.Load("../../locales"))
+
+	assert.Equal(t, i18n.Resolve(nil, "").Code, i18n.Default)
+	assert.Equal(t, i18n.Resolve(nil, "de-AT,de;q=0.9,en;q=0.8").Code, "de")
+	assert.Equal(t, i18n.Resolve(nil, "fr-FR, ja;q=0.5, de;q=0.7").Code, "de")
+	assert.Equal(t, i18n.Resolve(nil, "ja;q=0, xx").Code, i18n.Default)
+	assert.Equal(t, i18n.Resolve(nil, "JA-jp").Code, "ja")
+}
+
+func TestTranslate(t *testing.T) {
+	assert.Nil(t, i18n.Load("../../locales"))
+
+	german := i18n.Get("de")
+	assert.Equal(t, german.T("Settings"), "Einstellungen")
+	assert.Equal(t, german.T("%d new episodes", 3), "3 new episodes")
+	assert.Equal(t, i18n.Get("xx").T("Settings"), "Settings")
+}
+
+func TestFormat(t *testing.T) {
+	assert.Nil(t, i18n.Load("../../locales"))
+
+	english := i18n.Get(i18n.Default)
+	german := i18n.Get("de")
+
+	assert.Equal(t, english.FormatNumber(1234567.891, 2), "1,234,567.89")
+	assert.Equal(t, german.FormatNumber(1234567.891, 2), "1.234.567,89")
+	assert.Equal(t, german.FormatNumber(-999.5, 0), "-1.000")
+	assert.Equal(t, german.FormatNumber(-0.001, 2), "0,00")
+	assert.Equal(t, german.FormatCurrency(7.7, currency.All["EUR"]), "7,70 €")
+
+	date := time.Date(2019, time.March, 4, 12, 0, 0, 0, time.UTC)
+	assert.Equal(t, english.FormatDate(date), "Mar 4, 2019")
+	assert.Equal(t, german.FormatDate(date), "04.03.2019")
+	assert.Equal(t, i18n.Get("ja").FormatDate(date), "2019年3月4日")
+}
@@ -0,0 +1,115 @@
+package ical
+
+import (
+	"strings"
+	"time"
+)
+
+// maxLineLength is the maximum length of a content line in octets, excluding the line break.
+const maxLineLength = 75
+
+// dateTimeFormat is the UTC date-time format used in iCalendar files.
+const dateTimeFormat = "20060102T150405Z"
+
+// Calendar is an iCalendar feed as defined in RFC 5545.
+type Calendar struct {
+	Name   string
+	Events []*Event
+}
+
+// Event is a single calendar entry.
+type Event struct {
+	UID         string
+	Start       time.Time
+	End         time.Time
+	Summary     string
+	Description string
+	Location    string
+	URL         string
+}
+
+// String serializes the calendar to the iCalendar format.
+func (calendar *Calendar) String() string {
+	builder := strings.Builder{}
+	now := time.Now().UTC().Format(dateTimeFormat)
+
+	writeLine(&builder, "BEGIN:VCALENDAR")
+	writeLine(&builder, "VERSION:2.0")
+	writeLine(&builder, "PRODID:-//notify.moe//Anime calendar//EN")
+	writeLine(&builder, "CALSCALE:GREGORIAN")
+	writeLine(&builder, "METHOD:PUBLISH")
+
+	if calendar.Name != "" {
+		writeLine(&builder, "X-WR-CALNAME:"+escapeText(calendar.Name))
+	}
+
+	for _, event := range calendar.Events {
+		writeLine(&builder, "BEGIN:VEVENT")
+		writeLine(&builder, "UID:"+escapeText(event.UID))
+		writeLine(&builder, "DTSTAMP:"+now)
+		writeLine(&builder, "DTSTART:"+event.Start.UTC().Format(dateTimeFormat))
+
+		if !event.End.IsZero() {
+			writeLine(&builder, "DTEND:"+event.End.UTC().Format(dateTimeFormat))
+		}
+
+		writeLine(&builder, "SUMMARY:"+escapeText(event.Summary))
+
+		if event.Description != "" {
+			writeLine(&builder, "DESCRIPTION:"+escapeText(event.Description))
+		}
+
+		if event.Location != "" {
+			writeLine(&builder, "LOCATION:"+escapeText(event.Location))
+		}
+
+		if event.URL != "" {
+			writeLine(&builder, "URL:"+event.URL)
+		}
+
+		writeLine(&builder, "END:VEVENT")
+	}
+
+	writeLine(&builder, "END:VCALENDAR")
+	return builder.String()
+}
+
+// escapeText escapes the special characters of text values.
+func escapeText(text string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	).Replace(text)
+}
+
+// writeLine writes a content line and folds it when it's too long.
+// Lines are never split in the middle of a multi-byte character.
+func writeLine(builder *strings.Builder, line string) {
+	limit := maxLineLength
+
+	for len(line) > limit {
+		cut := limit
+
+		for cut > 0 && !isCharacterStart(line[cut]) {
+			cut--
+		}
+
+		builder.WriteString(line[:cut])
+		builder.WriteString("\r\n ")
+		line = line[cut:]
+
+		// Continuation lines start with a space that counts towards the limit
+		limit = maxLineLength - 1
+	}
+
+	builder.WriteString(line)
+	builder.WriteString("\r\n")
+}
+
+// isCharacterStart tells whether the byte is the first byte of a UTF-8 encoded character.
+func isCharacterStart(b byte) bool {
+	return b&0xC0 != 0x80
+}
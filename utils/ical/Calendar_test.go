@@ -0,0 +1,46 @@
+package ical_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/akyoto/assert"
+	"github.com/animenotifier/notify.moe/utils/ical"
+)
+
+func TestCalendar(t *testing.T) {
+	start := time.Date(2019, 10, 5, 15, 30, 0, 0, time.UTC)
+
+	calendar := &ical.Calendar{
+		Name: "Anime",
+		Events: []*ical.Event{
+			{
+				UID:         "episode-1@notify.moe",
+				Start:       start,
+				End:         start.Add(24 * time.Minute),
+				Summary:     "Dr. Stone, Episode 14; \"Special\"",
+				Description: strings.Repeat("あ", 40),
+				Location:    "Discord, #watch-party",
+				URL:         "https://notify.moe/anime/1",
+			},
+		},
+	}
+
+	text := calendar.String()
+	assert.True(t, strings.HasPrefix(text, "BEGIN:VCALENDAR\r\n"))
+	assert.True(t, strings.HasSuffix(text, "END:VCALENDAR\r\n"))
+	assert.Contains(t, text, "DTSTART:20191005T153000Z\r\n")
+	assert.Contains(t, text, "DTEND:20191005T155400Z\r\n")
+	assert.Contains(t, text, `SUMMARY:Dr. Stone\, Episode 14\; "Special"`+"\r\n")
+	assert.Contains(t, text, `LOCATION:Discord\, #watch-party`+"\r\n")
+
+	// Long lines are folded without breaking characters
+	for _, line := range strings.Split(strings.TrimSuffix(text, "\r\n"), "\r\n") {
+		assert.True(t, len(line) <= 75)
+		assert.True(t, utf8.ValidString(line))
+	}
+
+	assert.Contains(t, strings.Replace(text, "\r\n ", "", -1), "DESCRIPTION:"+strings.Repeat("あ", 40)+"\r\n")
+}
@@ -0,0 +1,15 @@
+package infinitescroll
+
+import (
+	"github.com/aerogo/aero"
+)
+
+// NextCursor sends the cursor for the next request as an HTTP header.
+// An empty cursor means that there is no more data.
+func NextCursor(ctx aero.Context, cursor string) {
+	if cursor == "" {
+		cursor = "-1"
+	}
+
+	ctx.Response().SetHeader("X-LoadMore-Index", cursor)
+}
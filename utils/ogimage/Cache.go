@@ -0,0 +1,31 @@
+package ogimage
+
+import (
+	"time"
+
+	"github.com/akyoto/cache"
+)
+
+// cacheDuration defines how long a rendered image is kept in memory.
+const cacheDuration = 30 * time.Minute
+
+var rendered = cache.New(10 * time.Minute)
+
+// Cached returns the image rendered for the key and only calls render if the key is unknown.
+// The key must contain the object ID and its last modification so that edits render a new image.
+func Cached(key string, render func() ([]byte, error)) ([]byte, error) {
+	data, found := rendered.Get(key)
+
+	if found {
+		return data.([]byte), nil
+	}
+
+	encoded, err := render()
+
+	if err != nil {
+		return nil, err
+	}
+
+	rendered.Set(key, encoded, cacheDuration)
+	return encoded, nil
+}
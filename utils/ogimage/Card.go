@@ -0,0 +1,113 @@
+package ogimage
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+)
+
+const (
+	// Width is the width of OpenGraph images.
+	Width = 1200
+
+	// Height is the height of OpenGraph images.
+	Height = 630
+
+	padding      = 60
+	posterWidth  = 340
+	titleScale   = 7
+	titleLines   = 3
+	lineScale    = 4
+	brandScale   = 4
+	accentHeight = 12
+)
+
+// Colors of the card
+var (
+	BackgroundColor = color.RGBA{R: 24, G: 24, B: 28, A: 255}
+	TitleColor      = color.RGBA{R: 255, G: 255, B: 255, A: 255}
+	TextColor       = color.RGBA{R: 170, G: 170, B: 180, A: 255}
+	AccentColor     = color.RGBA{R: 242, G: 79, B: 65, A: 255}
+)
+
+// Card is a branded preview image with a poster on the left and text on the right.
+type Card struct {
+	Poster image.Image
+	Title  string
+	Lines  []string
+}
+
+// Render draws the card.
+func (card *Card) Render() *image.RGBA {
+	canvas := NewCanvas()
+	textLeft := padding
+
+	if card.Poster != nil {
+		DrawCover(canvas, posterArea(card.Poster.Bounds()), card.Poster)
+		textLeft += posterWidth + padding
+	}
+
+	textWidth := Width - textLeft - padding
+	y := padding
+
+	for _, line := range WrapText(card.Title, titleScale, textWidth, titleLines) {
+		DrawText(canvas, line, image.Pt(textLeft, y), titleScale, TitleColor)
+		y += LineHeight(titleScale)
+	}
+
+	y += LineHeight(lineScale)
+
+	for _, line := range card.Lines {
+		DrawText(canvas, ellipsis(line, lineScale, textWidth), image.Pt(textLeft, y), lineScale, TextColor)
+		y += LineHeight(lineScale)
+	}
+
+	DrawBrand(canvas)
+	return canvas
+}
+
+// posterArea returns the largest area with the aspect ratio of the poster
+// that fits into the left column, centered vertically.
+func posterArea(bounds image.Rectangle) image.Rectangle {
+	maxHeight := Height - 2*padding - accentHeight
+	width := posterWidth
+	height := maxHeight
+
+	if bounds.Dx() > 0 {
+		height = width * bounds.Dy() / bounds.Dx()
+	}
+
+	if height > maxHeight {
+		height = maxHeight
+	}
+
+	top := (Height - accentHeight - height) / 2
+	return image.Rect(padding, top, padding+width, top+height)
+}
+
+// PNG returns the rendered card in PNG format.
+func (card *Card) PNG() ([]byte, error) {
+	buffer := bytes.Buffer{}
+	err := png.Encode(&buffer, card.Render())
+	return buffer.Bytes(), err
+}
+
+// NewCanvas returns an empty image in the OpenGraph size.
+func NewCanvas() *image.RGBA {
+	canvas := image.NewRGBA(image.Rect(0, 0, Width, Height))
+	draw.Draw(canvas, canvas.Bounds(), &image.Uniform{C: BackgroundColor}, image.Point{}, draw.Src)
+	return canvas
+}
+
+// DrawBrand draws the site name in the bottom right corner and the accent line at the bottom.
+func DrawBrand(canvas draw.Image) {
+	brand := "notify.moe"
+	x := Width - padding - TextWidth(brand, brandScale)
+	y := Height - accentHeight - padding/2 - LineHeight(brandScale)
+	DrawText(canvas, brand, image.Pt(x, y), brandScale, AccentColor)
+
+	accent := image.Rect(0, Height-accentHeight, Width, Height)
+	draw.Draw(canvas, accent, &image.Uniform{C: AccentColor}, image.Point{}, draw.Src)
+}
@@ -0,0 +1,55 @@
+package ogimage
+
+import (
+	"image"
+	"image/draw"
+	"os"
+
+	// Supported image formats
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+)
+
+// LoadImage decodes the image file or returns nil if it can't be read.
+func LoadImage(file string) image.Image {
+	reader, err := os.Open(file)
+
+	if err != nil {
+		return nil
+	}
+
+	defer reader.Close()
+	img, _, err := image.Decode(reader)
+
+	if err != nil {
+		return nil
+	}
+
+	return img
+}
+
+// DrawCover fills the area with the source image.
+// The image is scaled and cropped in the center to keep its aspect ratio.
+func DrawCover(canvas draw.Image, area image.Rectangle, source image.Image) {
+	bounds := source.Bounds()
+	scaleX := float64(bounds.Dx()) / float64(area.Dx())
+	scaleY := float64(bounds.Dy()) / float64(area.Dy())
+	scale := scaleX
+
+	if scaleY < scale {
+		scale = scaleY
+	}
+
+	offsetX := (float64(bounds.Dx()) - float64(area.Dx())*scale) / 2
+	offsetY := (float64(bounds.Dy()) - float64(area.Dy())*scale) / 2
+
+	for y := 0; y < area.Dy(); y++ {
+		sourceY := bounds.Min.Y + int(offsetY+float64(y)*scale)
+
+		for x := 0; x < area.Dx(); x++ {
+			sourceX := bounds.Min.X + int(offsetX+float64(x)*scale)
+			canvas.Set(area.Min.X+x, area.Min.Y+y, source.At(sourceX, sourceY))
+		}
+	}
+}
@@ -0,0 +1,16 @@
+package ogimage
+
+import (
+	"strconv"
+
+	"github.com/animenotifier/notify.moe/arn"
+	"github.com/animenotifier/notify.moe/assets"
+)
+
+// SetImage uses the rendered image at the given path as the preview image of the page.
+func SetImage(openGraph *arn.OpenGraph, imagePath string) {
+	openGraph.Tags["og:image"] = "https://" + assets.Domain + imagePath
+	openGraph.Tags["og:image:width"] = strconv.Itoa(Width)
+	openGraph.Tags["og:image:height"] = strconv.Itoa(Height)
+	openGraph.Meta["twitter:card"] = "summary_large_image"
+}
@@ -0,0 +1,114 @@
+package ogimage
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"strings"
+)
+
+// DrawText draws a single line of text with its top left corner at the given point.
+// Every pixel of the font is drawn as a square with a side length of scale.
+func DrawText(canvas draw.Image, text string, point image.Point, scale int, textColor color.Color) {
+	x := point.X
+	pixel := &image.Uniform{C: textColor}
+
+	for _, char := range text {
+		columns := glyph(char)
+
+		for column, bits := range columns {
+			for row := 0; row < glyphHeight; row++ {
+				if bits&(1<<uint(row)) == 0 {
+					continue
+				}
+
+				left := x + column*scale
+				top := point.Y + row*scale
+				draw.Draw(canvas, image.Rect(left, top, left+scale, top+scale), pixel, image.Point{}, draw.Over)
+			}
+		}
+
+		x += TextWidth(string(char), scale)
+	}
+}
+
+// TextWidth returns the width of the text in pixels.
+func TextWidth(text string, scale int) int {
+	return len([]rune(text)) * (glyphWidth + 1) * scale
+}
+
+// LineHeight returns the height of a line of text in pixels.
+func LineHeight(scale int) int {
+	return (glyphHeight + 2) * scale
+}
+
+// WrapText splits the text into lines that fit into the given width.
+// Text that doesn't fit into maxLines is cut off with an ellipsis.
+func WrapText(text string, scale int, maxWidth int, maxLines int) []string {
+	lines := []string{}
+	line := ""
+
+	for _, word := range strings.Fields(text) {
+		candidate := word
+
+		if line != "" {
+			candidate = line + " " + word
+		}
+
+		if TextWidth(candidate, scale) <= maxWidth || line == "" {
+			line = candidate
+			continue
+		}
+
+		lines = append(lines, line)
+		line = word
+	}
+
+	if line != "" {
+		lines = append(lines, line)
+	}
+
+	// Cut off long words and lines
+	for index, line := range lines {
+		lines[index] = ellipsis(line, scale, maxWidth)
+	}
+
+	if len(lines) > maxLines {
+		lines = lines[:maxLines]
+		lines[maxLines-1] = cut(lines[maxLines-1], scale, maxWidth)
+	}
+
+	return lines
+}
+
+// ellipsis shortens the line to the width if it's too long.
+func ellipsis(line string, scale int, maxWidth int) string {
+	if TextWidth(line, scale) <= maxWidth {
+		return line
+	}
+
+	return cut(line, scale, maxWidth)
+}
+
+// cut marks the end of the line with "..." and removes characters until it fits into the width.
+func cut(line string, scale int, maxWidth int) string {
+	runes := []rune(line)
+
+	for len(runes) > 0 && TextWidth(string(runes)+"...", scale) > maxWidth {
+		runes = runes[:len(runes)-1]
+	}
+
+	return strings.TrimSpace(string(runes)) + "..."
+}
+
+// Readable returns the first text that the font can draw completely.
+// If none of them is fully supported, the first one is returned.
+func Readable(texts ...string) string {
+	for _, text := range texts {
+		if text != "" && strings.IndexFunc(text, func(char rune) bool { return !IsSupported(char) }) == -1 {
+			return text
+		}
+	}
+
+	return texts[0]
+}
@@ -0,0 +1,38 @@
+package ogimage_test
+
+import (
+	"image"
+	"testing"
+
+	"github.com/akyoto/assert"
+	"github.com/animenotifier/notify.moe/utils/ogimage"
+)
+
+func TestWrapText(t *testing.T) {
+	// Every character is 6 pixels wide at scale 1
+	assert.Equal(t, ogimage.TextWidth("Steins", 1), 36)
+	assert.DeepEqual(t, ogimage.WrapText("Steins Gate Zero", 1, 60, 3), []string{"Steins", "Gate Zero"})
+	assert.DeepEqual(t, ogimage.WrapText("Steins Gate Zero", 1, 36, 2), []string{"Steins", "Gat..."})
+	assert.DeepEqual(t, ogimage.WrapText("Supercalifragilistic", 1, 48, 1), []string{"Super..."})
+}
+
+func TestReadable(t *testing.T) {
+	assert.Equal(t, ogimage.Readable("進撃の巨人", "Shingeki no Kyojin"), "Shingeki no Kyojin")
+	assert.Equal(t, ogimage.Readable("進撃の巨人"), "進撃の巨人")
+}
+
+func TestCard(t *testing.T) {
+	card := &ogimage.Card{
+		Poster: image.NewRGBA(image.Rect(0, 0, 100, 150)),
+		Title:  "Steins;Gate",
+		Lines:  []string{"Score: 9.1 / 10", "24 episodes"},
+	}
+
+	canvas := card.Render()
+	assert.Equal(t, canvas.Bounds().Dx(), ogimage.Width)
+	assert.Equal(t, canvas.Bounds().Dy(), ogimage.Height)
+
+	data, err := card.PNG()
+	assert.Nil(t, err)
+	assert.True(t, len(data) > 0)
+}
@@ -0,0 +1,136 @@
+package opengraph
+
+import (
+	"strings"
+
+	"github.com/animenotifier/notify.moe/arn"
+	"github.com/animenotifier/notify.moe/assets"
+)
+
+const (
+	// MaxTitleLength is the number of characters after which titles are cut off.
+	MaxTitleLength = 95
+
+	// MaxDescriptionLength is the number of characters after which descriptions are cut off.
+	MaxDescriptionLength = 170
+
+	// DefaultType is the OpenGraph type of pages that don't specify one.
+	DefaultType = "website"
+
+	// DefaultImage is the preview image of pages that don't have their own.
+	DefaultImage = "/images/brand/220.png"
+)
+
+// Builder creates the OpenGraph data of a page.
+// Tags that every page needs but that weren't set explicitly are filled with defaults.
+type Builder struct {
+	openGraph *arn.OpenGraph
+}
+
+// New starts building the OpenGraph data for a page with the given title and description.
+func New(title string, description string) *Builder {
+	builder := &Builder{
+		openGraph: &arn.OpenGraph{
+			Tags: map[string]string{},
+			Meta: map[string]string{},
+		},
+	}
+
+	return builder.
+		Tag("og:title", cut(strings.TrimSpace(title), MaxTitleLength)).
+		Tag("og:description", cut(strings.TrimSpace(description), MaxDescriptionLength))
+}
+
+// URL sets the canonical address of the page.
+// Relative links are resolved against the domain of the website.
+func (builder *Builder) URL(link string) *Builder {
+	return builder.Tag("og:url", absolute(link))
+}
+
+// Image sets the preview image of the page.
+func (builder *Builder) Image(url string) *Builder {
+	return builder.Tag("og:image", absolute(url))
+}
+
+// Type sets the OpenGraph type, e.g. "article" or "profile".
+func (builder *Builder) Type(objectType string) *Builder {
+	return builder.Tag("og:type", objectType)
+}
+
+// Keywords sets the keywords for search engines.
+func (builder *Builder) Keywords(keywords ...string) *Builder {
+	return builder.Meta("keywords", strings.Join(keywords, ","))
+}
+
+// StructuredData attaches a schema.org object to the page.
+func (builder *Builder) StructuredData(data arn.StructuredData) *Builder {
+	builder.openGraph.StructuredData = data
+	return builder
+}
+
+// Tag sets an OpenGraph property. Empty values are ignored.
+func (builder *Builder) Tag(property string, value string) *Builder {
+	if value != "" {
+		builder.openGraph.Tags[property] = value
+	}
+
+	return builder
+}
+
+// Meta sets a meta tag. Empty values are ignored.
+func (builder *Builder) Meta(name string, value string) *Builder {
+	if value != "" {
+		builder.openGraph.Meta[name] = value
+	}
+
+	return builder
+}
+
+// Build fills in the missing defaults and returns the OpenGraph data.
+func (builder *Builder) Build() *arn.OpenGraph {
+	tags := builder.openGraph.Tags
+	tags["og:site_name"] = assets.Domain
+
+	if tags["og:type"] == "" {
+		builder.Type(DefaultType)
+	}
+
+	if tags["og:url"] == "" {
+		builder.URL("/")
+	}
+
+	if tags["og:image"] == "" {
+		builder.Image(DefaultImage)
+	}
+
+	if builder.openGraph.Meta["description"] == "" {
+		builder.Meta("description", tags["og:description"])
+	}
+
+	return builder.openGraph
+}
+
+// absolute turns relative and protocol-relative links into absolute https links.
+func absolute(link string) string {
+	switch {
+	case link == "":
+		return ""
+	case strings.HasPrefix(link, "https://"), strings.HasPrefix(link, "http://"):
+		return link
+	case strings.HasPrefix(link, "//"):
+		return "https:" + link
+	default:
+		return "https://" + assets.Domain + "/" + strings.TrimPrefix(link, "/")
+	}
+}
+
+// cut shortens the text to the maximum number of characters and marks the end with "...".
+func cut(text string, maxLength int) string {
+	runes := []rune(text)
+
+	if len(runes) <= maxLength {
+		return text
+	}
+
+	return strings.TrimSpace(string(runes[:maxLength-3])) + "..."
+}
@@ -0,0 +1,44 @@
+package opengraph_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/akyoto/assert"
+	"github.com/animenotifier/notify.moe/utils/opengraph"
+)
+
+func TestBuilderDefaults(t *testing.T) {
+	openGraph := opengraph.New("Steins;Gate", "A time travel anime.").Build()
+
+	assert.Equal(t, openGraph.Tags["og:title"], "Steins;Gate")
+	assert.Equal(t, openGraph.Tags["og:description"], "A time travel anime.")
+	assert.Equal(t, openGraph.Tags["og:site_name"], "notify.moe")
+	assert.Equal(t, openGraph.Tags["og:type"], opengraph.DefaultType)
+	assert.Equal(t, openGraph.Tags["og:url"], "https://notify.moe/")
+	assert.Equal(t, openGraph.Tags["og:image"], "https://notify.moe"+opengraph.DefaultImage)
+	assert.Equal(t, openGraph.Meta["description"], "A time travel anime.")
+}
+
+func TestBuilderLinks(t *testing.T) {
+	openGraph := opengraph.New("Steins;Gate", "").
+		URL("/anime/0KoQVLHUsU").
+		Image("//media.notify.moe/images/anime/large/0KoQVLHUsU.jpg").
+		Type("video.tv_show").
+		Build()
+
+	assert.Equal(t, openGraph.Tags["og:url"], "https://notify.moe/anime/0KoQVLHUsU")
+	assert.Equal(t, openGraph.Tags["og:image"], "https://media.notify.moe/images/anime/large/0KoQVLHUsU.jpg")
+	assert.Equal(t, openGraph.Tags["og:type"], "video.tv_show")
+	assert.Equal(t, openGraph.Tags["og:description"], "")
+	assert.Equal(t, openGraph.Meta["description"], "")
+}
+
+func TestBuilderLengths(t *testing.T) {
+	openGraph := opengraph.New(strings.Repeat("ア", 200), strings.Repeat("a ", 200)).Build()
+	title := []rune(openGraph.Tags["og:title"])
+
+	assert.Equal(t, len(title), opengraph.MaxTitleLength)
+	assert.True(t, strings.HasSuffix(string(title), "..."))
+	assert.True(t, len([]rune(openGraph.Tags["og:description"])) <= opengraph.MaxDescriptionLength)
+}
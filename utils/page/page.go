@@ -7,6 +7,7 @@ import (
 
 // Get registers a layout rendered route and a contents-only route.
 func Get(app *aero.Application, route string, handler aero.Handler) {
+	middleware.RegisterRoute(route)
 	app.Get(route, middleware.Layout(handler))
 	app.Get("/_"+route, handler)
 }
@@ -54,9 +54,13 @@ var routeTests = map[string][]string{
 	// 	"/+Akyoto/followers",
 	// },
 
-	// "/user/:nick/stats": []string{
-	// 	"/+Akyoto/stats",
-	// },
+	"/user/:nick/stats": {
+		"/+Akyoto/stats",
+	},
+
+	"/user/:nick/lists": {
+		"/+Akyoto/lists",
+	},
 
 	"/user/:nick/animelist/anime/:id": {
 		"/+Akyoto/animelist/anime/74y2cFiiR",
@@ -102,6 +106,22 @@ var routeTests = map[string][]string{
 		"/+Akyoto/animelist/dropped/from/3",
 	},
 
+	"/user/:nick/animelist.rss": {
+		"/+Akyoto/animelist.rss",
+	},
+
+	"/user/:nick/animelist.atom": {
+		"/+Akyoto/animelist.atom",
+	},
+
+	"/user/:nick/animelist.xml": {
+		"/+Akyoto/animelist.xml",
+	},
+
+	"/user/:nick/calendar.ics": {
+		"/+Akyoto/calendar.ics",
+	},
+
 	"/user/:nick/anime/recommended": {
 		"/+Akyoto/anime/recommended",
 	},
@@ -131,6 +151,10 @@ var routeTests = map[string][]string{
 		"/anime/74y2cFiiR/comments",
 	},
 
+	"/anime/:id/reviews": {
+		"/anime/74y2cFiiR/reviews",
+	},
+
 	"/anime/:id/tracks": {
 		"/anime/74y2cFiiR/tracks",
 	},
@@ -139,10 +163,18 @@ var routeTests = map[string][]string{
 		"/anime/74y2cFiiR/relations",
 	},
 
+	"/anime/:id/franchise": {
+		"/anime/74y2cFiiR/franchise",
+	},
+
 	"/thread/:id": {
 		"/thread/HJgS7c2K",
 	},
 
+	"/thread/:id/page/:page": {
+		"/thread/HJgS7c2K/page/1",
+	},
+
 	"/post/:id": {
 		"/post/B1RzshnK",
 	},
@@ -167,6 +199,19 @@ var routeTests = map[string][]string{
 		"/companies/from/3",
 	},
 
+	"/characters/best/week/from/:index": {
+		"/characters/best/week/from/3",
+	},
+
+	"/characters/best/month/from/:index": {
+		"/characters/best/month/from/3",
+	},
+
+	"/season/:year/:season": {
+		"/anime/season/2019/winter",
+		"/anime/season/2019/autumn?genre=action&type=tv&sort=rating",
+	},
+
 	"/explore/color/:color/anime": {
 		"/explore/color/hsl:0.050,0.25,0.5/anime",
 	},
@@ -175,6 +220,10 @@ var routeTests = map[string][]string{
 		"/explore/color/hsl:0.050,0.25,0.5/anime/from/3",
 	},
 
+	"/search": {
+		"/search?q=dragon+ball",
+	},
+
 	"/search/:term": {
 		"/search/Dragon Ball",
 	},
@@ -247,6 +296,10 @@ var routeTests = map[string][]string{
 		"/episode/oFlqThKWga",
 	},
 
+	"/og-image/anime/:id": {
+		"/og-image/anime/74y2cFiiR.png",
+	},
+
 	// "/kitsu/character/:id": []string{
 	// 	"/kitsu/character/6556",
 	// },
@@ -404,14 +457,23 @@ var routeTests = map[string][]string{
 	"/auth/facebook/callback":                        nil,
 	"/auth/twitter":                                  nil,
 	"/auth/twitter/callback":                         nil,
+	"/auth/anilist":                                  nil,
+	"/auth/anilist/callback":                         nil,
+	"/auth/myanimelist":                              nil,
+	"/auth/myanimelist/callback":                     nil,
+	"/email/unsubscribe/:id/:token":                  nil,
 	"/dashboard":                                     nil,
 	"/import":                                        nil,
 	"/import/anilist/animelist":                      nil,
 	"/import/anilist/animelist/finish":               nil,
+	"/import/anilist/sync":                           nil,
 	"/import/myanimelist/animelist":                  nil,
 	"/import/myanimelist/animelist/finish":           nil,
+	"/import/myanimelist/sync":                       nil,
 	"/import/kitsu/animelist":                        nil,
 	"/import/kitsu/animelist/finish":                 nil,
+	"/import/file":                                   nil,
+	"/import/file/finish/:strategy":                  nil,
 	"/animelist/watching":                            nil,
 	"/animelist/completed":                           nil,
 	"/animelist/planned":                             nil,
@@ -419,6 +481,11 @@ var routeTests = map[string][]string{
 	"/animelist/dropped":                             nil,
 	"/notifications":                                 nil,
 	"/user/:nick/notifications":                      nil,
+	"/messages":                                      nil,
+	"/messages/sent":                                 nil,
+	"/messages/new":                                  nil,
+	"/messages/new/:nick":                            nil,
+	"/conversation/:id":                              nil,
 	"/user/:nick/edit":                               nil,
 	"/user/:nick/log":                                nil,
 	"/user/:nick/log/from/:index":                    nil,
@@ -427,14 +494,31 @@ var routeTests = map[string][]string{
 	"/editor/soundtracks/lyrics/missing":             nil,
 	"/editor/soundtracks/lyrics/unaligned":           nil,
 	"/editor/soundtracks/tags":                       nil,
+	"/editor/quotes":                                 nil,
+	"/editor/streaming":                              nil,
+	"/editor/streaming/resolved":                     nil,
+	"/editor/edits":                                  nil,
+	"/editor/edits/pending":                          nil,
+	"/anime/:id/streaming/suggest":                   nil,
+	"/customlist/:id":                                nil,
+	"/customlist/:id/edit":                           nil,
+	"/person/:id":                                    nil,
+	"/person/:id/edit":                               nil,
+	"/person/:id/history":                            nil,
+	"/review/:id":                                    nil,
+	"/review/:id/edit":                               nil,
 	"/api/test/notification":                         nil,
 	"/api/paypal/payment/create":                     nil,
 	"/api/emailtouser/:id":                           nil,
 	"/api/pushsubscriptions/:id/get/:item":           nil,
 	"/api/pushsubscriptions/:id/get/:item/:property": nil,
 	"/api/count/notifications/unseen":                nil,
+	"/api/airing/upcoming":                           nil,
+	"/graphql/schema":                                {"/graphql/schema"},
 	"/api/mark/notifications/seen":                   nil,
+	"/api/count/messages/unread":                     nil,
 	"/api/sse/events":                                nil,
+	"/api/export/animelist/:format":                  nil,
 	"/editor/kitsu/new/anime":                        nil,
 	"/paypal/success":                                nil,
 	"/paypal/cancel":                                 nil,
@@ -446,11 +530,15 @@ var routeTests = map[string][]string{
 	"/anime/:id/edit/history":                        nil,
 	"/new/thread":                                    nil,
 	"/thread/:id/edit":                               nil,
+	"/thread/:id/post/:post":                         nil,
 	"/post/:id/edit":                                 nil,
 	"/company/:id/edit":                              nil,
 	"/admin/purchases":                               nil,
 	"/admin/registrations":                           nil,
 	"/admin/payments":                                nil,
+	"/admin/metrics":                                 nil,
+	"/admin/jobs":                                    nil,
+	"/metrics":                                       nil,
 	"/editor/anilist":                                nil,
 	"/editor/shoboi":                                 nil,
 	"/dark-flame-master":                             nil,
@@ -462,15 +550,29 @@ var routeTests = map[string][]string{
 	"/settings/info":                                 nil,
 	"/settings/style":                                nil,
 	"/settings/extras":                               nil,
+	"/settings/subscriptions":                        nil,
+	"/settings/blocks":                               nil,
+	"/settings/apps":                                 nil,
+	"/settings/trash":                                nil,
+	"/recommendations":                               nil,
+	"/user/:nick/year/:year":                         nil,
+	"/user/:nick/year/:year/image.jpg":               nil,
+	"/og-image/user/:nick":                           nil,
 	"/shop":                                          nil,
 	"/shop/history":                                  nil,
 	"/support":                                       nil,
 	"/charge":                                        nil,
 	"/log":                                           nil,
 	"/log/from/:index":                               nil,
+	"/log/moderation":                                nil,
+	"/log/moderation/from/:index":                    nil,
+	"/report/:type/:id":                              nil,
+	"/reports":                                       nil,
+	"/reports/resolved":                              nil,
 	"/inventory":                                     nil,
 	"/extension/embed":                               nil,
 	"/welcome":                                       nil,
+	"/oauth/authorize":                               nil,
 }
 
 // All returns which specific routes to test for a given generic route.